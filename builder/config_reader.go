@@ -3,10 +3,8 @@ package builder
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
-	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/config"
@@ -23,6 +21,7 @@ type Config struct {
 	OrderExtensions dist.Order       `toml:"order-extensions"`
 	Stack           StackConfig      `toml:"stack"`
 	Lifecycle       LifecycleConfig  `toml:"lifecycle"`
+	Launcher        LauncherConfig   `toml:"launcher"`
 	Run             RunConfig        `toml:"run"`
 	Build           BuildConfig      `toml:"build"`
 	Targets         []dist.Target    `toml:"targets"`
@@ -35,6 +34,9 @@ type ModuleCollection []ModuleConfig
 type ModuleConfig struct {
 	dist.ModuleInfo
 	dist.ImageOrURI
+
+	// SHA256 digest of the archive of the URI, the module fails to download when the archive has another digest
+	SHA256 string `toml:"sha256,omitempty"`
 }
 
 func (c *ModuleConfig) DisplayString() string {
@@ -59,6 +61,15 @@ type LifecycleConfig struct {
 	Version string `toml:"version"`
 }
 
+// LauncherConfig details the launcher to replace the launcher of the Lifecycle with, such as a patched launcher
+type LauncherConfig struct {
+	// URI of a launcher binary.
+	URI string `toml:"uri"`
+
+	// Version of the Lifecycle release to take the launcher of.
+	Version string `toml:"version"`
+}
+
 // RunConfig set of run image configuration
 type RunConfig struct {
 	Images []RunImageConfig `toml:"images"`
@@ -94,19 +105,27 @@ type BuildConfigEnv struct {
 }
 
 // ReadConfig reads a builder configuration from the file path provided and returns the
-// configuration along with any warnings encountered while parsing
+// configuration along with any warnings encountered while parsing. The configuration may be written in TOML or YAML.
 func ReadConfig(path string) (config Config, warnings []string, err error) {
-	file, err := os.Open(filepath.Clean(path))
-	if err != nil {
+	return ReadConfigWithVariables(path, nil)
+}
+
+// ReadConfigWithVariables reads a builder configuration like ReadConfig, substituting ${NAME} references with the
+// values in vars or, if absent, the environment
+func ReadConfigWithVariables(path string, vars map[string]string) (config Config, warnings []string, err error) {
+	if _, err := os.Stat(path); err != nil {
 		return Config{}, nil, errors.Wrap(err, "opening config file")
 	}
-	defer file.Close()
 
-	config, err = parseConfig(file)
+	config, unresolved, err := parseConfig(path, vars)
 	if err != nil {
 		return Config{}, nil, errors.Wrapf(err, "parse contents of '%s'", path)
 	}
 
+	for _, name := range unresolved {
+		warnings = append(warnings, fmt.Sprintf("variable %s is not set", style.Symbol(name)))
+	}
+
 	if len(config.Order) == 0 {
 		warnings = append(warnings, fmt.Sprintf("empty %s definition", style.Symbol("order")))
 	}
@@ -163,24 +182,24 @@ func (c *Config) mergeStackWithImages() {
 }
 
 // parseConfig reads a builder configuration from file
-func parseConfig(file *os.File) (Config, error) {
+func parseConfig(path string, vars map[string]string) (Config, []string, error) {
 	builderConfig := Config{}
-	tomlMetadata, err := toml.NewDecoder(file).Decode(&builderConfig)
+	tomlMetadata, unresolved, err := config.DecodeDescriptorFile(path, vars, &builderConfig)
 	if err != nil {
-		return Config{}, errors.Wrap(err, "decoding toml contents")
+		return Config{}, nil, errors.Wrapf(err, "decoding %s contents", config.DescriptorFormat(path))
 	}
 
 	undecodedKeys := tomlMetadata.Undecoded()
 	if len(undecodedKeys) > 0 {
 		unknownElementsMsg := config.FormatUndecodedKeys(undecodedKeys)
 
-		return Config{}, errors.Errorf("%s in %s",
+		return Config{}, nil, errors.Errorf("%s in %s",
 			unknownElementsMsg,
-			style.Symbol(file.Name()),
+			style.Symbol(path),
 		)
 	}
 
-	return builderConfig, nil
+	return builderConfig, unresolved, nil
 }
 
 func ParseBuildConfigEnv(env []BuildConfigEnv, path string) (envMap map[string]string, warnings []string, err error) {
@@ -50,6 +50,7 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 
 [[buildpacks]]
   uri = "https://example.com/buildpack-3.tgz"
+  sha256 = "sha256:0123"
 
 [[order]]
 [[order.group]]
@@ -73,12 +74,110 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 
 				h.AssertEq(t, builderConfig.Buildpacks[2].ID, "")
 				h.AssertEq(t, builderConfig.Buildpacks[2].URI, "https://example.com/buildpack-3.tgz")
+				h.AssertEq(t, builderConfig.Buildpacks[2].SHA256, "sha256:0123")
 				h.AssertEq(t, builderConfig.Buildpacks[2].ImageName, "")
 
 				h.AssertEq(t, builderConfig.Order[0].Group[0].ID, "buildpack/1")
 			})
 		})
 
+		when("file is written in yaml", func() {
+			it.Before(func() {
+				builderConfigPath = filepath.Join(tmpDir, "builder.yaml")
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+buildpacks:
+  - id: buildpack/1
+    version: 0.0.1
+    uri: https://example.com/buildpack-1.tgz
+  - image: example.com/buildpack:2
+
+order:
+  - group:
+      - id: buildpack/1
+        optional: true
+
+build:
+  image: some/build-image
+
+run:
+  images:
+    - image: some/run-image
+      mirrors: [some/mirror]
+`), 0666))
+			})
+
+			it("returns a builder config", func() {
+				builderConfig, warns, err := builder.ReadConfig(builderConfigPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(warns), 0)
+
+				h.AssertEq(t, builderConfig.Buildpacks[0].ID, "buildpack/1")
+				h.AssertEq(t, builderConfig.Buildpacks[0].Version, "0.0.1")
+				h.AssertEq(t, builderConfig.Buildpacks[0].URI, "https://example.com/buildpack-1.tgz")
+				h.AssertEq(t, builderConfig.Buildpacks[1].ImageName, "example.com/buildpack:2")
+
+				h.AssertEq(t, builderConfig.Order[0].Group[0].ID, "buildpack/1")
+				h.AssertEq(t, builderConfig.Order[0].Group[0].Optional, true)
+
+				h.AssertEq(t, builderConfig.Build.Image, "some/build-image")
+				h.AssertEq(t, builderConfig.Run.Images, []builder.RunImageConfig{{Image: "some/run-image", Mirrors: []string{"some/mirror"}}})
+			})
+
+			it("returns an error when an unknown key is present", func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+buildpacks:
+  - id: buildpack/1
+    url: https://example.com/buildpack-1.tgz
+`), 0666))
+
+				_, _, err := builder.ReadConfig(builderConfigPath)
+				h.AssertError(t, err, "unknown configuration element 'buildpacks.url'")
+			})
+		})
+
+		when("variables are referenced", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[[buildpacks]]
+  id = "buildpack/1"
+  image = "${registry}/buildpack:${tag}"
+
+[[order]]
+[[order.group]]
+  id = "buildpack/1"
+
+[build]
+  image = "${PACK_TEST_BUILD_IMAGE}"
+
+[[build.env]]
+  name = "ESCAPED"
+  value = "$${registry}"
+
+[[run.images]]
+  image = "${registry}/run:${missing}"
+`), 0666))
+				h.AssertNil(t, os.Setenv("PACK_TEST_BUILD_IMAGE", "some/build-image"))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Unsetenv("PACK_TEST_BUILD_IMAGE"))
+			})
+
+			it("substitutes values and warns about unset variables", func() {
+				builderConfig, warns, err := builder.ReadConfigWithVariables(builderConfigPath, map[string]string{
+					"registry": "registry.example.com",
+					"tag":      "1.0",
+				})
+				h.AssertNil(t, err)
+				h.AssertEq(t, warns, []string{"variable 'missing' is not set"})
+
+				h.AssertEq(t, builderConfig.Buildpacks[0].ImageName, "registry.example.com/buildpack:1.0")
+				h.AssertEq(t, builderConfig.Build.Image, "some/build-image")
+				h.AssertEq(t, builderConfig.Build.Env[0].Value, "${registry}")
+				h.AssertEq(t, builderConfig.Run.Images[0].Image, "registry.example.com/run:${missing}")
+			})
+		})
+
 		when("an error occurs while reading", func() {
 			it("bubbles up the error", func() {
 				_, _, err := builder.ReadConfig(builderConfigPath)
@@ -54,17 +54,27 @@ func NewConfigReader() *ConfigReader {
 }
 
 // ConfigReader implements a Read method for buildpackage configuration which parses and validates buildpackage
-// configuration from a toml file.
+// configuration from a toml or yaml file.
 type ConfigReader struct{}
 
 // Read reads and validates a buildpackage configuration from the file path provided and returns the
 // configuration and any error that occurred during reading or validation.
 func (r *ConfigReader) Read(path string) (Config, error) {
+	return r.ReadWithVariables(path, nil)
+}
+
+// ReadWithVariables reads and validates a buildpackage configuration like Read, substituting ${NAME} references with
+// the values in vars or, if absent, the environment. Unresolved references are an error.
+func (r *ConfigReader) ReadWithVariables(path string, vars map[string]string) (Config, error) {
 	packageConfig := Config{}
 
-	tomlMetadata, err := toml.DecodeFile(path, &packageConfig)
+	tomlMetadata, unresolved, err := config.DecodeDescriptorFile(path, vars, &packageConfig)
 	if err != nil {
-		return packageConfig, errors.Wrap(err, "decoding toml")
+		return packageConfig, errors.Wrapf(err, "decoding %s", config.DescriptorFormat(path))
+	}
+
+	if len(unresolved) > 0 {
+		return packageConfig, errors.Errorf("variable %s is not set", style.Symbol(unresolved[0]))
 	}
 
 	undecodedKeys := tomlMetadata.Undecoded()
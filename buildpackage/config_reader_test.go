@@ -79,6 +79,76 @@ func testBuildpackageConfigReader(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, config.Dependencies[0].URI, "https://example.com/bp/b.tgz")
 		})
 
+		it("returns correct config when provided yaml file is valid", func() {
+			configFile := filepath.Join(tmpDir, "package.yaml")
+
+			err := os.WriteFile(configFile, []byte(validPackageYaml), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			config, err := packageConfigReader.Read(configFile)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, config.Platform.OS, "windows")
+			h.AssertEq(t, config.Buildpack.URI, "https://example.com/bp/a.tgz")
+			h.AssertEq(t, len(config.Dependencies), 1)
+			h.AssertEq(t, config.Dependencies[0].URI, "https://example.com/bp/b.tgz")
+		})
+
+		it("returns an error when an unknown key is present in a yaml file", func() {
+			configFile := filepath.Join(tmpDir, "package.yml")
+
+			err := os.WriteFile(configFile, []byte(unknownBPKeyPackageYaml), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			_, err = packageConfigReader.Read(configFile)
+			h.AssertError(t, err, "unknown configuration element 'buildpack.url'")
+		})
+
+		it("returns an error when yaml decode fails", func() {
+			configFile := filepath.Join(tmpDir, "package.yaml")
+
+			err := os.WriteFile(configFile, []byte("buildpack: [uri"), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			_, err = packageConfigReader.Read(configFile)
+			h.AssertError(t, err, "decoding yaml")
+		})
+
+		it("substitutes variables in the config", func() {
+			configFile := filepath.Join(tmpDir, "package.toml")
+
+			err := os.WriteFile(configFile, []byte(variablesPackageToml), os.ModePerm)
+			h.AssertNil(t, err)
+			h.AssertNil(t, os.Setenv("PACK_TEST_PACKAGE_OS", "windows"))
+			defer os.Unsetenv("PACK_TEST_PACKAGE_OS")
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			config, err := packageConfigReader.ReadWithVariables(configFile, map[string]string{"registry": "registry.example.com"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, config.Platform.OS, "windows")
+			h.AssertEq(t, config.Dependencies[0].ImageName, "registry.example.com/bp/b")
+		})
+
+		it("returns an error when a variable is not set", func() {
+			configFile := filepath.Join(tmpDir, "package.toml")
+
+			err := os.WriteFile(configFile, []byte(variablesPackageToml), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			_, err = packageConfigReader.Read(configFile)
+			h.AssertError(t, err, "variable 'registry' is not set")
+		})
+
 		it("returns a config with 'linux' as default when platform is missing", func() {
 			configFile := filepath.Join(tmpDir, "package.toml")
 
@@ -235,6 +305,33 @@ uri = "https://example.com/bp/b.tgz"
 os = "windows"
 `
 
+const variablesPackageToml = `
+[buildpack]
+uri = "https://example.com/bp/a.tgz"
+
+[[dependencies]]
+image = "${registry}/bp/b"
+
+[platform]
+os = "${PACK_TEST_PACKAGE_OS}"
+`
+
+const validPackageYaml = `
+buildpack:
+  uri: https://example.com/bp/a.tgz
+
+platform:
+  os: windows
+
+dependencies:
+  - uri: https://example.com/bp/b.tgz
+`
+
+const unknownBPKeyPackageYaml = `
+buildpack:
+  url: https://example.com/bp/a.tgz
+`
+
 const validPackageWithoutPlatformToml = `
 [buildpack]
 uri = "https://example.com/bp/a.tgz"
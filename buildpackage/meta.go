@@ -0,0 +1,96 @@
+package buildpackage
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// MetaBuildpackConfig discovers the buildpacks in the immediate subdirectories of dir and writes a meta-buildpack to
+// metaDir whose order is a single group containing every discovered buildpack. The ID, version and API of the
+// meta-buildpack are read from the buildpack.toml in dir. The returned config packages the meta-buildpack together with
+// the discovered buildpacks.
+func MetaBuildpackConfig(dir, metaDir string) (Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Config{}, err
+	}
+
+	reader := NewConfigReader()
+	metaDescriptorPath := filepath.Join(dir, "buildpack.toml")
+	if _, err := os.Stat(metaDescriptorPath); err != nil {
+		return Config{}, errors.Wrapf(err, "reading meta-buildpack descriptor %s", style.Symbol(metaDescriptorPath))
+	}
+	metaDescriptor, err := reader.ReadBuildpackDescriptor(metaDescriptorPath)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "reading meta-buildpack descriptor %s", style.Symbol(metaDescriptorPath))
+	}
+	if len(metaDescriptor.Order()) > 0 {
+		return Config{}, errors.Errorf("meta-buildpack descriptor %s must not define an order", style.Symbol(metaDescriptorPath))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "reading buildpacks directory %s", style.Symbol(dir))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	cfg := DefaultConfig()
+	var group []dist.ModuleRef
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		bpDir := filepath.Join(dir, entry.Name())
+		descriptorPath := filepath.Join(bpDir, "buildpack.toml")
+		if _, err := os.Stat(descriptorPath); os.IsNotExist(err) {
+			continue
+		}
+
+		descriptor, err := reader.ReadBuildpackDescriptor(descriptorPath)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, "reading buildpack descriptor %s", style.Symbol(descriptorPath))
+		}
+		if descriptor.Info().ID == metaDescriptor.Info().ID {
+			return Config{}, errors.Errorf("buildpack in %s has the same ID as the meta-buildpack: %s", style.Symbol(bpDir), style.Symbol(descriptor.Info().ID))
+		}
+
+		group = append(group, dist.ModuleRef{ModuleInfo: dist.ModuleInfo{
+			ID:      descriptor.Info().ID,
+			Version: descriptor.Info().Version,
+		}})
+		cfg.Dependencies = append(cfg.Dependencies, dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: bpDir}})
+	}
+
+	if len(group) == 0 {
+		return Config{}, errors.Errorf("no buildpacks found in %s", style.Symbol(dir))
+	}
+
+	// targets of composite buildpacks are defined in the package config, see RFC-0128
+	cfg.Targets = metaDescriptor.Targets()
+	metaDescriptor.WithTargets = nil
+	metaDescriptor.WithOrder = dist.Order{{Group: group}}
+	if err := writeBuildpackDescriptor(filepath.Join(metaDir, "buildpack.toml"), metaDescriptor); err != nil {
+		return Config{}, errors.Wrap(err, "writing meta-buildpack descriptor")
+	}
+
+	cfg.Buildpack.URI = metaDir
+	return cfg, nil
+}
+
+func writeBuildpackDescriptor(path string, descriptor dist.BuildpackDescriptor) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(descriptor)
+}
@@ -0,0 +1,127 @@
+package buildpackage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/buildpackage"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMetaBuildpackConfig(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "MetaBuildpackConfig", testMetaBuildpackConfig, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testMetaBuildpackConfig(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir  string
+		bpsDir  string
+		metaDir string
+	)
+
+	writeFile := func(path, contents string) {
+		t.Helper()
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0644))
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "meta-buildpack-test")
+		h.AssertNil(t, err)
+
+		bpsDir = filepath.Join(tmpDir, "buildpacks")
+		metaDir = filepath.Join(tmpDir, "meta")
+		h.AssertNil(t, os.MkdirAll(metaDir, 0755))
+
+		writeFile(filepath.Join(bpsDir, "buildpack.toml"), `
+api = "0.10"
+
+[buildpack]
+id = "some/meta"
+version = "1.0.0"
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+`)
+		writeFile(filepath.Join(bpsDir, "b-buildpack", "buildpack.toml"), `
+api = "0.10"
+[buildpack]
+id = "some/b"
+version = "2.0.0"
+`)
+		writeFile(filepath.Join(bpsDir, "a-buildpack", "buildpack.toml"), `
+api = "0.10"
+[buildpack]
+id = "some/a"
+version = "1.0.0"
+`)
+		h.AssertNil(t, os.MkdirAll(filepath.Join(bpsDir, "not-a-buildpack"), 0755))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	it("generates a meta-buildpack ordering every discovered buildpack", func() {
+		cfg, err := buildpackage.MetaBuildpackConfig(bpsDir, metaDir)
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, cfg.Buildpack.URI, metaDir)
+		h.AssertEq(t, cfg.Dependencies, []dist.ImageOrURI{
+			{BuildpackURI: dist.BuildpackURI{URI: filepath.Join(bpsDir, "a-buildpack")}},
+			{BuildpackURI: dist.BuildpackURI{URI: filepath.Join(bpsDir, "b-buildpack")}},
+		})
+		h.AssertEq(t, cfg.Targets, []dist.Target{{OS: "linux", Arch: "amd64"}})
+
+		descriptor, err := buildpackage.NewConfigReader().ReadBuildpackDescriptor(filepath.Join(metaDir, "buildpack.toml"))
+		h.AssertNil(t, err)
+		h.AssertEq(t, descriptor.Info().ID, "some/meta")
+		h.AssertEq(t, descriptor.API().String(), "0.10")
+		h.AssertEq(t, len(descriptor.Targets()), 0)
+		h.AssertEq(t, descriptor.Order(), dist.Order{{Group: []dist.ModuleRef{
+			{ModuleInfo: dist.ModuleInfo{ID: "some/a", Version: "1.0.0"}},
+			{ModuleInfo: dist.ModuleInfo{ID: "some/b", Version: "2.0.0"}},
+		}}})
+	})
+
+	it("errors when the meta-buildpack descriptor is missing", func() {
+		h.AssertNil(t, os.Remove(filepath.Join(bpsDir, "buildpack.toml")))
+
+		_, err := buildpackage.MetaBuildpackConfig(bpsDir, metaDir)
+		h.AssertError(t, err, "reading meta-buildpack descriptor")
+	})
+
+	it("errors when the meta-buildpack descriptor already defines an order", func() {
+		writeFile(filepath.Join(bpsDir, "buildpack.toml"), `
+api = "0.10"
+[buildpack]
+id = "some/meta"
+version = "1.0.0"
+
+[[order]]
+[[order.group]]
+id = "some/a"
+`)
+
+		_, err := buildpackage.MetaBuildpackConfig(bpsDir, metaDir)
+		h.AssertError(t, err, "must not define an order")
+	})
+
+	it("errors when no buildpacks are found", func() {
+		h.AssertNil(t, os.RemoveAll(filepath.Join(bpsDir, "a-buildpack")))
+		h.AssertNil(t, os.RemoveAll(filepath.Join(bpsDir, "b-buildpack")))
+
+		_, err := buildpackage.MetaBuildpackConfig(bpsDir, metaDir)
+		h.AssertError(t, err, "no buildpacks found in")
+	})
+}
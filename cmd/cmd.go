@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/buildpackage"
+	"github.com/buildpacks/pack/internal/bandwidth"
 	builderwriter "github.com/buildpacks/pack/internal/builder/writer"
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/config"
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/internal/logexport"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -21,6 +28,9 @@ type ConfigurableLogger interface {
 	WantTime(f bool)
 	WantQuiet(f bool)
 	WantVerbose(f bool)
+	WantStrictDeprecations(f bool)
+	WantDeprecationFormat(format logging.DeprecationFormat)
+	WantExport(exporter logging.Exporter)
 }
 
 // NewPackCommand generates a Pack command
@@ -38,10 +48,24 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 		return nil, err
 	}
 
+	var bandwidthLimit bandwidth.Rate
+	applyEnvFlags := commands.ApplyEnvFlags(logger, os.LookupEnv)
+	applyDefaults := commands.ApplyDefaults(logger, cfg)
 	rootCmd := &cobra.Command{
 		Use:   "pack",
 		Short: "CLI for building apps using Cloud Native Buildpacks",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		Long: "CLI for building apps using Cloud Native Buildpacks.\n\n" +
+			"Flags of any command can also be set by environment variables named after them, such as " + commands.FlagEnvVar("pull-policy") + " for --pull-policy. " +
+			"Flags given on the command line take precedence over environment variables, which take precedence over the defaults in the config file.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// flags on the command line take precedence over environment variables, which take precedence over the config
+			if err := applyEnvFlags(cmd, args); err != nil {
+				return err
+			}
+			if err := applyDefaults(cmd, args); err != nil {
+				return err
+			}
+
 			if fs := cmd.Flags(); fs != nil {
 				if forceColor, err := fs.GetBool("force-color"); err == nil && !forceColor {
 					if flag, err := fs.GetBool("no-color"); err == nil && flag {
@@ -62,7 +86,38 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 				if flag, err := fs.GetBool("timestamps"); err == nil {
 					logger.WantTime(flag)
 				}
+				if flag, err := fs.GetBool("strict-deprecations"); err == nil {
+					logger.WantStrictDeprecations(flag)
+				}
+				if format, err := fs.GetString("deprecation-format"); err == nil {
+					switch format := logging.DeprecationFormat(format); format {
+					case logging.DeprecationFormatText, logging.DeprecationFormatJSON:
+						logger.WantDeprecationFormat(format)
+					default:
+						return errors.Errorf("invalid deprecation format %s, must be one of text or json", style.Symbol(string(format)))
+					}
+				}
+			}
+			if cfg.LogExport.Protocol != "" {
+				exporter, err := newLogExporter(cfg, logexport.Source{Command: cmd.CommandPath(), Version: packClient.Version()})
+				if err != nil {
+					logger.Warnf("Not exporting logs: %s", err)
+				} else {
+					logger.WantExport(exporter)
+				}
+			}
+			bandwidth.Limit(bandwidthLimit)
+
+			// config commands checking the config file report its problems themselves
+			if path := cmd.CommandPath(); path != "pack config validate" && path != "pack config migrate" {
+				if _, err := config.ReadStrict(cfgPath); err != nil {
+					logger.Warnf("%s, run %s for details", err, style.Symbol("pack config validate"))
+				}
 			}
+			for _, warning := range config.KnownExperimentalFeatures.Warnings(cfg.ExperimentalFeatures) {
+				logger.Warn(warning)
+			}
+			return nil
 		},
 	}
 
@@ -71,6 +126,9 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().Bool("timestamps", false, "Enable timestamps in output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Show less output")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show more output")
+	rootCmd.PersistentFlags().Bool("strict-deprecations", false, "Fail on the usage of deprecated commands, flags, APIs and schema versions")
+	rootCmd.PersistentFlags().String("deprecation-format", string(logging.DeprecationFormatText), "Format to report the usage of deprecated features in. Accepted values are text, and json for a JSON object on each line")
+	rootCmd.PersistentFlags().Var(&bandwidthLimit, "limit-bandwidth", "Limit registry transfers made by pack to this rate, such as 10MB/s.\nPulls by the Docker daemon and exports by the lifecycle are not limited")
 	rootCmd.Flags().Bool("version", false, "Show current 'pack' version")
 
 	commands.AddHelpFlag(rootCmd, "pack")
@@ -81,29 +139,42 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.NewExtensionCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewConfigCommand(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.InspectImage(logger, imagewriter.NewFactory(), cfg, packClient))
-	rootCmd.AddCommand(commands.NewStackCommand(logger))
+	rootCmd.AddCommand(commands.NewImageCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewStackCommand(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.Test(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewPhaseCommand(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewSamplesCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewSchemaCommand(logger))
 
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
 
 	rootCmd.AddCommand(commands.SetDefaultBuilder(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.SetRunImagesMirrors(logger, cfg, cfgPath))
-	rootCmd.AddCommand(commands.SuggestBuilders(logger, packClient))
+	rootCmd.AddCommand(commands.SuggestBuilders(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.TrustBuilder(logger, cfg, cfgPath))
 	rootCmd.AddCommand(commands.UntrustBuilder(logger, cfg, cfgPath))
 	rootCmd.AddCommand(commands.ListTrustedBuilders(logger, cfg))
 	rootCmd.AddCommand(commands.CreateBuilder(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.PackageBuildpack(logger, cfg, packClient, buildpackage.NewConfigReader()))
+	rootCmd.AddCommand(commands.Compat(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewRegistryCommand(logger, packClient))
+	rootCmd.AddCommand(commands.GC(logger, packClient))
+	rootCmd.AddCommand(commands.Doctor(logger, packClient))
+	rootCmd.AddCommand(commands.Login(logger, packClient))
+	rootCmd.AddCommand(commands.Logout(logger, packClient))
 
-	if cfg.Experimental {
+	if cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
 		rootCmd.AddCommand(commands.AddBuildpackRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.ListBuildpackRegistries(logger, cfg))
 		rootCmd.AddCommand(commands.RegisterBuildpack(logger, cfg, packClient))
 		rootCmd.AddCommand(commands.SetDefaultRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.RemoveRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.YankBuildpack(logger, cfg, packClient))
+	}
+	if cfg.ExperimentalEnabled(config.FeatureManifest) {
 		rootCmd.AddCommand(commands.NewManifestCommand(logger, packClient))
 	}
 
@@ -115,6 +186,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.CompletionCommand(logger, packHome))
 	rootCmd.AddCommand(commands.Report(logger, packClient.Version(), cfgPath))
 	rootCmd.AddCommand(commands.Version(logger, packClient.Version()))
+	commands.AddPlugin(logger, rootCmd, os.Args[1:], exec.LookPath)
 
 	rootCmd.Version = packClient.Version()
 	rootCmd.SetVersionTemplate(`{{.Version}}{{"\n"}}`)
@@ -124,6 +196,15 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	return rootCmd, nil
 }
 
+// newLogExporter returns the exporter of the logs of the config, with the headers of the requests decrypted
+func newLogExporter(cfg config.Config, source logexport.Source) (logging.Exporter, error) {
+	decrypted, err := config.DecryptSecrets(config.Config{LogExport: cfg.LogExport}, config.NewKeyring(cfg.Keyring))
+	if err != nil {
+		return nil, err
+	}
+	return logexport.New(decrypted.LogExport, source)
+}
+
 func initConfig() (config.Config, string, error) {
 	path, err := config.DefaultConfigPath()
 	if err != nil {
@@ -138,6 +219,16 @@ func initConfig() (config.Config, string, error) {
 }
 
 func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error) {
+	opts, err := ClientOptions(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewClient(opts...)
+}
+
+// ClientOptions returns the options of the pack client pack configures by the config, using the Docker context pack
+// uses, and the Docker host over SSH of DOCKER_HOST
+func ClientOptions(logger logging.Logger, cfg config.Config) ([]client.Option, error) {
 	if err := client.ProcessDockerContext(logger); err != nil {
 		return nil, err
 	}
@@ -146,5 +237,22 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	opts := []client.Option{client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithExperimentalFeatures(cfg.ExperimentalFeatures...), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc), client.WithTrustPolicy(commands.ImageTrustPolicy(cfg.TrustPolicy))}
+	shimPolicy, err := client.ParseBuildpackAPIShimPolicy(cfg.BuildpackAPIShims)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, client.WithBuildpackAPIShimPolicy(shimPolicy))
+	packHome, err := config.PackHome()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, client.WithIntermediateImagesFile(filepath.Join(packHome, "intermediate-images.json")))
+	opts = append(opts, client.WithCredentialsDir(filepath.Join(packHome, "credentials")))
+	opts = append(opts, client.WithCredentialsCipher(config.KeyringCipher{Keyring: config.NewKeyring(cfg.Keyring)}))
+	opts = append(opts, client.WithPullTimesFile(filepath.Join(packHome, "pull-times.json")))
+	if cfg.LocalCacheRegistry {
+		opts = append(opts, client.WithLocalCacheRegistry(filepath.Join(packHome, "registry-cache")))
+	}
+	return opts, nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/heroku/color"
@@ -23,7 +24,15 @@ func main() {
 	}
 
 	ctx := commands.CreateCancellableContext()
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
+	err = rootCmd.ExecuteContext(ctx)
+	if closeErr := logger.CloseExporter(); closeErr != nil {
+		logger.Warnf("Exporting logs: %s", closeErr)
+	}
+	if err != nil {
+		var pluginErr commands.PluginExitError
+		if errors.As(err, &pluginErr) {
+			os.Exit(pluginErr.Code)
+		}
 		if _, isSoftError := err.(client.SoftError); isSoftError {
 			os.Exit(2)
 		}
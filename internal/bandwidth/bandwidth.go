@@ -0,0 +1,198 @@
+// Package bandwidth limits the rate at which pack transfers data over the network.
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Rate is a transfer rate in bytes per second, that can be set from a flag such as 10MB/s.
+// A zero Rate is unlimited.
+type Rate int64
+
+var units = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30},
+	{"kb", 1e3}, {"mb", 1e6}, {"gb", 1e9},
+	{"k", 1e3}, {"m", 1e6}, {"g", 1e9},
+	{"b", 1},
+}
+
+// ParseRate parses rates such as 10MB/s, 512KiB/s or 1000000, in bytes per second.
+func ParseRate(value string) (Rate, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	s = strings.TrimSuffix(s, "/s")
+
+	multiplier := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s, multiplier = strings.TrimSuffix(s, u.suffix), u.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("invalid rate %s, must be a number of bytes per second such as 10MB/s", style.Symbol(value))
+	}
+	return Rate(n * multiplier), nil
+}
+
+func (r *Rate) Set(value string) error {
+	rate, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+func (r *Rate) String() string {
+	switch {
+	case *r == 0:
+		return ""
+	case *r%1e6 == 0:
+		return fmt.Sprintf("%dMB/s", *r/1e6)
+	case *r%1e3 == 0:
+		return fmt.Sprintf("%dKB/s", *r/1e3)
+	}
+	return fmt.Sprintf("%dB/s", int64(*r))
+}
+
+func (r *Rate) Type() string {
+	return "rate"
+}
+
+// Limiter is a token bucket shared by every connection it limits, so that the total rate across all of them stays
+// under the limit.
+type Limiter struct {
+	rate Rate
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a Limiter allowing rate bytes per second, with bursts of up to one second worth of bytes.
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{rate: rate, tokens: float64(rate), last: time.Now()}
+}
+
+// WaitN blocks until n bytes may be transferred.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * float64(l.rate)
+	if l.tokens > float64(l.rate) {
+		l.tokens = float64(l.rate)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	wait := time.Duration(-l.tokens / float64(l.rate) * float64(time.Second))
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// chunk returns the largest number of bytes read or written at once, so that a single large buffer doesn't burst
+// far above the limit
+func (l *Limiter) chunk() int {
+	if c := int(l.rate) / 10; c > 1024 {
+		return c
+	}
+	return 1024
+}
+
+// conn is a net.Conn whose reads and writes are limited by a Limiter
+type conn struct {
+	net.Conn
+	limiter *Limiter
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	if len(b) > c.limiter.chunk() {
+		b = b[:c.limiter.chunk()]
+	}
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		if werr := c.limiter.WaitN(context.Background(), n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	var written int
+	for len(b) > 0 {
+		n := len(b)
+		if n > c.limiter.chunk() {
+			n = c.limiter.chunk()
+		}
+		if err := c.limiter.WaitN(context.Background(), n); err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(b[:n])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
+
+// Transport returns a copy of inner whose connections are limited by limiter.
+func Transport(inner *http.Transport, limiter *Limiter) *http.Transport {
+	t := inner.Clone()
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		c, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &conn{Conn: c, limiter: limiter}, nil
+	}
+	return t
+}
+
+// Limit limits every registry and download transfer made by this process to rate bytes per second in total.
+// Transfers made by the Docker daemon or inside containers, such as image pulls and lifecycle exports, are not limited.
+func Limit(rate Rate) {
+	if rate <= 0 {
+		return
+	}
+	limiter := NewLimiter(rate)
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		http.DefaultTransport = Transport(t, limiter)
+	}
+	if t, ok := remote.DefaultTransport.(*http.Transport); ok {
+		remote.DefaultTransport = Transport(t, limiter)
+	}
+}
@@ -0,0 +1,81 @@
+package bandwidth_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/bandwidth"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBandwidth(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Bandwidth", testBandwidth, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBandwidth(t *testing.T, when spec.G, it spec.S) {
+	when("#ParseRate", func() {
+		it("parses rates with units", func() {
+			for value, expected := range map[string]bandwidth.Rate{
+				"10MB/s":   10e6,
+				"512KiB/s": 512 * 1024,
+				"1.5mb":    1.5e6,
+				"2G":       2e9,
+				"1000":     1000,
+				"100B/s":   100,
+			} {
+				rate, err := bandwidth.ParseRate(value)
+				h.AssertNil(t, err)
+				h.AssertEq(t, rate, expected)
+			}
+		})
+
+		it("rejects invalid rates", func() {
+			_, err := bandwidth.ParseRate("fast")
+			h.AssertError(t, err, "invalid rate 'fast', must be a number of bytes per second such as 10MB/s")
+
+			_, err = bandwidth.ParseRate("-1MB/s")
+			h.AssertNotNil(t, err)
+		})
+	})
+
+	when("#Rate", func() {
+		it("is a flag value", func() {
+			var rate bandwidth.Rate
+			h.AssertNil(t, rate.Set("10MB/s"))
+			h.AssertEq(t, rate.String(), "10MB/s")
+			h.AssertEq(t, rate.Type(), "rate")
+		})
+	})
+
+	when("#Transport", func() {
+		it("limits the rate of responses", func() {
+			body := bytes.Repeat([]byte("a"), 300*1000)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			// the first second worth of bytes is allowed as a burst, the remaining 100KB take half a second
+			client := &http.Client{Transport: bandwidth.Transport(&http.Transport{}, bandwidth.NewLimiter(200*1000))}
+			start := time.Now()
+			resp, err := client.Get(server.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+			received, err := io.ReadAll(resp.Body)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(received), len(body))
+			h.AssertTrue(t, time.Since(start) >= 400*time.Millisecond)
+		})
+	})
+}
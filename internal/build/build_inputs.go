@@ -0,0 +1,107 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// BuildInputs are the inputs of a build that the layers cached by the previous builds of an app depend on. They're
+// kept in the build cache along with the layers, so the next build of the app can explain why they weren't reused.
+type BuildInputs struct {
+	Builder    string   `toml:"builder"`
+	BuilderID  string   `toml:"builder-id"`
+	Lifecycle  string   `toml:"lifecycle"`
+	RunImage   string   `toml:"run-image"`
+	Buildpacks []string `toml:"buildpacks"` // as id@version
+}
+
+// Changes describes the changes from the inputs of a previous build that invalidate its cached layers, in order of
+// how likely they are to cause a cache miss.
+func (b BuildInputs) Changes(prev BuildInputs) []string {
+	var changes []string
+	switch {
+	case b.Builder != prev.Builder:
+		changes = append(changes, fmt.Sprintf("builder changed from %s to %s", style.Symbol(prev.Builder), style.Symbol(b.Builder)))
+	case b.BuilderID != prev.BuilderID:
+		changes = append(changes, fmt.Sprintf("builder %s was updated", style.Symbol(b.Builder)))
+	}
+	if b.Lifecycle != prev.Lifecycle {
+		changes = append(changes, fmt.Sprintf("lifecycle changed from %s to %s", style.Symbol(prev.Lifecycle), style.Symbol(b.Lifecycle)))
+	}
+
+	prevVersions := buildpackVersions(prev.Buildpacks)
+	versions := buildpackVersions(b.Buildpacks)
+	for _, bp := range prev.Buildpacks {
+		id, version, _ := strings.Cut(bp, "@")
+		newVersion, ok := versions[id]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("buildpack %s was removed", style.Symbol(bp)))
+		case newVersion != version:
+			changes = append(changes, fmt.Sprintf("buildpack %s changed from %s to %s", style.Symbol(id), style.Symbol(version), style.Symbol(newVersion)))
+		}
+	}
+	for _, bp := range b.Buildpacks {
+		id, _, _ := strings.Cut(bp, "@")
+		if _, ok := prevVersions[id]; !ok {
+			changes = append(changes, fmt.Sprintf("buildpack %s was added", style.Symbol(bp)))
+		}
+	}
+
+	if b.RunImage != prev.RunImage {
+		changes = append(changes, fmt.Sprintf("run image changed from %s to %s", style.Symbol(prev.RunImage), style.Symbol(b.RunImage)))
+	}
+	return changes
+}
+
+func buildpackVersions(buildpacks []string) map[string]string {
+	versions := map[string]string{}
+	for _, bp := range buildpacks {
+		id, version, _ := strings.Cut(bp, "@")
+		versions[id] = version
+	}
+	return versions
+}
+
+// ReadBuildInputs reads the `BuildInputs` recorded at the path in the container by a previous build, and passes them to
+// the handler. The handler isn't called when no build recorded them.
+func ReadBuildInputs(srcPath string, handler func(BuildInputs)) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		reader, _, err := ctrClient.CopyFromContainer(ctx, containerID, srcPath)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		defer reader.Close()
+
+		tr := tar.NewReader(reader)
+		if _, err := tr.Next(); err != nil {
+			return errors.Wrapf(err, "reading %s", style.Symbol(srcPath))
+		}
+
+		var inputs BuildInputs
+		if _, err := toml.NewDecoder(tr).Decode(&inputs); err != nil {
+			return errors.Wrapf(err, "decoding %s", style.Symbol(srcPath))
+		}
+		handler(inputs)
+		return nil
+	}
+}
+
+// WriteBuildInputs writes the `BuildInputs` provided to the destination path.
+func WriteBuildInputs(dstPath string, inputs BuildInputs, os string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		return writeToml(ctrClient, ctx, inputs, dstPath, containerID, os, stdout, stderr)
+	}
+}
@@ -0,0 +1,110 @@
+package build_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildInputs(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildInputs", testBuildInputs, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildInputs(t *testing.T, when spec.G, it spec.S) {
+	prev := build.BuildInputs{
+		Builder:    "some/builder",
+		BuilderID:  "some-builder-id",
+		Lifecycle:  "0.20.0",
+		RunImage:   "some/run",
+		Buildpacks: []string{"some/bp@1.0.0", "some/removed-bp@1.0.0"},
+	}
+
+	when("#Changes", func() {
+		it("describes the changes that invalidate cached layers", func() {
+			inputs := build.BuildInputs{
+				Builder:    "some/builder",
+				BuilderID:  "other-builder-id",
+				Lifecycle:  "0.20.1",
+				RunImage:   "other/run",
+				Buildpacks: []string{"some/bp@2.0.0", "some/added-bp@1.0.0"},
+			}
+
+			h.AssertEq(t, inputs.Changes(prev), []string{
+				"builder 'some/builder' was updated",
+				"lifecycle changed from '0.20.0' to '0.20.1'",
+				"buildpack 'some/bp' changed from '1.0.0' to '2.0.0'",
+				"buildpack 'some/removed-bp@1.0.0' was removed",
+				"buildpack 'some/added-bp@1.0.0' was added",
+				"run image changed from 'some/run' to 'other/run'",
+			})
+		})
+
+		it("describes a different builder", func() {
+			inputs := prev
+			inputs.Builder = "other/builder"
+			inputs.BuilderID = "other-builder-id"
+
+			h.AssertEq(t, inputs.Changes(prev), []string{"builder changed from 'some/builder' to 'other/builder'"})
+		})
+
+		it("has no changes for the same inputs", func() {
+			h.AssertEq(t, len(prev.Changes(prev)), 0)
+		})
+	})
+
+	when("#ReadBuildInputs", func() {
+		var (
+			mockController *gomock.Controller
+			mockDocker     *testmocks.MockCommonAPIClient
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		it("passes the recorded inputs to the handler", func() {
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddFile("pack-build-inputs.toml", 0755, archive.NormalizedDateTime, []byte(`builder = "some/builder"
+buildpacks = ["some/bp@1.0.0"]
+`))
+			mockDocker.EXPECT().
+				CopyFromContainer(gomock.Any(), "some-container", "/cache/pack-build-inputs.toml").
+				Return(tarBuilder.Reader(archive.DefaultTarWriterFactory()), types.ContainerPathStat{}, nil)
+
+			var read build.BuildInputs
+			op := build.ReadBuildInputs("/cache/pack-build-inputs.toml", func(inputs build.BuildInputs) { read = inputs })
+			h.AssertNil(t, op(mockDocker, context.TODO(), "some-container", io.Discard, io.Discard))
+
+			h.AssertEq(t, read, build.BuildInputs{Builder: "some/builder", Buildpacks: []string{"some/bp@1.0.0"}})
+		})
+
+		it("doesn't call the handler when no build recorded them", func() {
+			mockDocker.EXPECT().
+				CopyFromContainer(gomock.Any(), "some-container", "/cache/pack-build-inputs.toml").
+				Return(nil, types.ContainerPathStat{}, errdefs.NotFound(errors.New("no such file")))
+
+			op := build.ReadBuildInputs("/cache/pack-build-inputs.toml", func(build.BuildInputs) { t.Fatal("handler called") })
+			h.AssertNil(t, op(mockDocker, context.TODO(), "some-container", io.Discard, io.Discard))
+		})
+	})
+}
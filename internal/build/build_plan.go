@@ -0,0 +1,87 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// BuildPlan is the buildpack group and build plan resolved by the detection of a build. A later build may replay it
+// to skip detection, and build with the same buildpacks.
+type BuildPlan struct {
+	Group           []buildpack.GroupElement `toml:"group"`
+	GroupExtensions []buildpack.GroupElement `toml:"group-extensions,omitempty"`
+	Entries         []files.BuildPlanEntry   `toml:"entries"`
+}
+
+// ReadBuildPlanFile reads a `BuildPlan` exported by a previous build.
+func ReadBuildPlanFile(path string) (BuildPlan, error) {
+	var plan BuildPlan
+	if _, err := toml.DecodeFile(path, &plan); err != nil {
+		return BuildPlan{}, errors.Wrapf(err, "reading build plan %s", style.Symbol(path))
+	}
+	if len(plan.Group) == 0 {
+		return BuildPlan{}, errors.Errorf("build plan %s has no buildpack group", style.Symbol(path))
+	}
+	return plan, nil
+}
+
+// ExportBuildPlan reads the group and the plan resolved by detection from the paths in the container, and writes them
+// as a `BuildPlan` to the path on the host.
+func ExportBuildPlan(groupPath, planPath, dstPath string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		var plan BuildPlan
+		for _, srcPath := range []string{groupPath, planPath} {
+			if err := readToml(ctrClient, ctx, containerID, srcPath, &plan); err != nil {
+				return err
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+			return errors.Wrapf(err, "creating directory of build plan %s", style.Symbol(dstPath))
+		}
+		f, err := os.Create(dstPath)
+		if err != nil {
+			return errors.Wrapf(err, "writing build plan %s", style.Symbol(dstPath))
+		}
+		defer f.Close()
+		return toml.NewEncoder(f).Encode(plan)
+	}
+}
+
+// WriteBuildPlan writes the group and the plan of the `BuildPlan` provided to the paths the detection writes them to.
+func WriteBuildPlan(groupPath, planPath string, plan BuildPlan, os string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		group := buildpack.Group{Group: plan.Group, GroupExtensions: plan.GroupExtensions}
+		if err := writeToml(ctrClient, ctx, group, groupPath, containerID, os, stdout, stderr); err != nil {
+			return err
+		}
+		return writeToml(ctrClient, ctx, files.Plan{Entries: plan.Entries}, planPath, containerID, os, stdout, stderr)
+	}
+}
+
+func readToml(ctrClient DockerClient, ctx context.Context, containerID, srcPath string, data interface{}) error {
+	reader, _, err := ctrClient.CopyFromContainer(ctx, containerID, srcPath)
+	if err != nil {
+		return errors.Wrapf(err, "copying %s from container", style.Symbol(srcPath))
+	}
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	if _, err := tr.Next(); err != nil {
+		return errors.Wrapf(err, "reading %s", style.Symbol(srcPath))
+	}
+	if _, err := toml.NewDecoder(tr).Decode(data); err != nil {
+		return errors.Wrapf(err, "decoding %s", style.Symbol(srcPath))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package build_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildPlan(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildPlan", testBuildPlan, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildPlan(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		tmpDir         string
+	)
+
+	// expectFile makes the file at the path of the container have the contents
+	expectFile := func(path, name, contents string) {
+		tarBuilder := archive.TarBuilder{}
+		tarBuilder.AddFile(name, 0755, archive.NormalizedDateTime, []byte(contents))
+		mockDocker.EXPECT().
+			CopyFromContainer(gomock.Any(), "some-container", path).
+			Return(tarBuilder.Reader(archive.DefaultTarWriterFactory()), types.ContainerPathStat{}, nil)
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "build-plan")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		os.RemoveAll(tmpDir)
+	})
+
+	when("#ExportBuildPlan", func() {
+		it("exports the group and the plan for a later build to replay", func() {
+			expectFile("/layers/group.toml", "group.toml", "[[group]]\nid = \"some/bp\"\nversion = \"1.0.0\"\n")
+			expectFile("/layers/plan.toml", "plan.toml", "[[entries]]\n[[entries.requires]]\nname = \"some-dep\"\n")
+
+			planPath := filepath.Join(tmpDir, "out", "plan.toml")
+			op := build.ExportBuildPlan("/layers/group.toml", "/layers/plan.toml", planPath)
+			h.AssertNil(t, op(mockDocker, context.TODO(), "some-container", io.Discard, io.Discard))
+
+			plan, err := build.ReadBuildPlanFile(planPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, plan.Group[0].ID, "some/bp")
+			h.AssertEq(t, plan.Group[0].Version, "1.0.0")
+			h.AssertEq(t, plan.Entries[0].Requires[0].Name, "some-dep")
+		})
+	})
+}
@@ -68,27 +68,27 @@ func CopyOutMaybe(handler func(closer io.ReadCloser) error, srcs ...string) Cont
 }
 
 func CopyOutTo(src, dest string) ContainerOperation {
-	return CopyOut(func(reader io.ReadCloser) error {
-		info := darchive.CopyInfo{
-			Path:  src,
-			IsDir: true,
-		}
-
-		defer reader.Close()
-		return darchive.CopyTo(reader, info, dest)
-	}, src)
+	return CopyOut(copyTo(src, dest), src)
 }
 
 func CopyOutToMaybe(src, dest string) ContainerOperation {
-	return CopyOutMaybe(func(reader io.ReadCloser) error {
+	return CopyOutMaybe(copyTo(src, dest), src)
+}
+
+func copyTo(src, dest string) func(reader io.ReadCloser) error {
+	return func(reader io.ReadCloser) error {
 		info := darchive.CopyInfo{
 			Path:  src,
 			IsDir: true,
 		}
 
 		defer reader.Close()
-		return darchive.CopyTo(reader, info, dest)
-	}, src)
+		if err := darchive.CopyTo(reader, info, dest); err != nil {
+			// files copied before the failure, such as when the build is canceled, are left in place
+			return errors.Wrapf(err, "copying '%s' to '%s', which may be incomplete", src, dest)
+		}
+		return nil
+	}
 }
 
 // CopyDir copies a local directory (src) to the destination on the container while filtering files and changing it's UID/GID.
@@ -0,0 +1,113 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// DebugSnapshotDockerClient is the docker client needed to snapshot failed phases, see LifecycleOptions.DebugSnapshot
+type DebugSnapshotDockerClient interface {
+	DockerClient
+	ContainerCommit(ctx context.Context, container string, options dcontainer.CommitOptions) (types.IDResponse, error)
+}
+
+// debugSnapshots saves the state of a failed phase to a debug image, to look into the failure from a shell. The image
+// holds the filesystem of the phase container, the layers and app volumes it mounted, which hold the build plan and the
+// buildpack layers, and the environment of the phase apart from registry credentials.
+type debugSnapshots struct {
+	docker     DebugSnapshotDockerClient
+	logger     logging.Logger
+	imageName  name.Reference
+	volumeDirs []string
+}
+
+// debugImageName returns the name of the debug image of the phase of the build of the image
+func debugImageName(imageName name.Reference, phase string) string {
+	repo := imageName.Context().Name()
+	if imageName.Context().RegistryStr() == name.DefaultRegistry {
+		repo = imageName.Context().RepositoryStr()
+	}
+	return fmt.Sprintf("%s-debug:%s", repo, phase)
+}
+
+// take saves the state of the failed phase, logging how to look into it. Failing to do so is logged as well, for the
+// failure of the phase to be returned rather than that of the snapshot.
+func (d *debugSnapshots) take(ctx context.Context, p *Phase) {
+	debugImage := debugImageName(d.imageName, p.name)
+	if err := d.save(ctx, p, debugImage); err != nil {
+		d.logger.Warnf("Saving the state of the failed %s phase: %s", style.Symbol(p.name), err)
+		return
+	}
+	d.logger.Infof("Saved the state of the failed %s phase to image %s, including the build plan, the layers and the app", style.Symbol(p.name), style.Symbol(debugImage))
+	logging.Tip(d.logger, "Run 'docker run --rm -it --entrypoint /bin/sh %s' to look into it", debugImage)
+}
+
+func (d *debugSnapshots) save(ctx context.Context, p *Phase, debugImage string) error {
+	// volumes aren't part of the committed filesystem, they are copied into a container of it to be committed again
+	ctrImage, err := d.docker.ContainerCommit(ctx, p.ctr.ID, dcontainer.CommitOptions{})
+	if err != nil {
+		return errors.Wrap(err, "committing phase container")
+	}
+	// the committed phase container is only the base of the debug image. Daemons keeping the parents of images refuse
+	// to remove it while the debug image is based on it, and remove it along with the debug image instead.
+	defer d.docker.ImageRemove(context.Background(), ctrImage.ID, image.RemoveOptions{})
+
+	snapshotCtr, err := d.docker.ContainerCreate(ctx, &dcontainer.Config{Image: ctrImage.ID, User: "root"}, &dcontainer.HostConfig{}, nil, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "creating snapshot container")
+	}
+	defer d.docker.ContainerRemove(context.Background(), snapshotCtr.ID, dcontainer.RemoveOptions{Force: true})
+
+	for _, dir := range d.volumeDirs {
+		if err := d.copyDir(ctx, p.ctr.ID, snapshotCtr.ID, dir); err != nil {
+			return err
+		}
+	}
+
+	_, err = d.docker.ContainerCommit(ctx, snapshotCtr.ID, dcontainer.CommitOptions{
+		Reference: debugImage,
+		Comment:   fmt.Sprintf("state of the failed %s phase", p.name),
+		Config: &dcontainer.Config{
+			User:       p.ctrConf.User,
+			Env:        d.env(p.ctrConf.Env),
+			WorkingDir: p.ctrConf.WorkingDir,
+			Cmd:        p.ctrConf.Cmd,
+		},
+	})
+	return errors.Wrap(err, "committing snapshot container")
+}
+
+func (d *debugSnapshots) copyDir(ctx context.Context, srcCtrID, dstCtrID, dir string) error {
+	reader, _, err := d.docker.CopyFromContainer(ctx, srcCtrID, dir)
+	if err != nil {
+		return errors.Wrapf(err, "copying %s from phase container", style.Symbol(dir))
+	}
+	defer reader.Close()
+
+	if err := d.docker.CopyToContainer(ctx, dstCtrID, path.Dir(dir), reader, types.CopyToContainerOptions{}); err != nil {
+		return errors.Wrapf(err, "copying %s to snapshot container", style.Symbol(dir))
+	}
+	return nil
+}
+
+// env returns the environment of the phase without the registry credentials
+func (d *debugSnapshots) env(env []string) []string {
+	var kept []string
+	for _, e := range env {
+		if !strings.HasPrefix(e, "CNB_REGISTRY_AUTH=") {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,116 @@
+package build_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDebugSnapshot(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DebugSnapshot", testDebugSnapshot, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDebugSnapshot(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		lifecycleExec  *build.LifecycleExecution
+		outBuf         bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+
+		fakeBuilder, err := fakes.NewFakeBuilder()
+		h.AssertNil(t, err)
+		lifecycleExec, err = build.NewLifecycleExecution(logging.NewLogWithWriters(&outBuf, &outBuf), mockDocker, "some-temp-dir", build.LifecycleOptions{
+			Builder:       fakeBuilder,
+			Image:         name.MustParseReference("some/image"),
+			DebugSnapshot: true,
+			Termui:        &fakes.FakeTermui{},
+		})
+		h.AssertNil(t, err)
+
+		// the phase fails once its container is created
+		mockDocker.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "phase-container"}, nil)
+		mockDocker.EXPECT().ContainerWait(gomock.Any(), "phase-container", gomock.Any()).
+			Return(make(chan dcontainer.WaitResponse), make(chan error)).AnyTimes()
+		mockDocker.EXPECT().ContainerAttach(gomock.Any(), "phase-container", gomock.Any()).
+			Return(types.HijackedResponse{}, errors.New("some-attach-error"))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	runPhase := func() error {
+		phase := build.NewDefaultPhaseFactory(lifecycleExec).New(build.NewPhaseConfigProvider("detector", lifecycleExec,
+			build.WithArgs("some-arg"), build.WithEnv("SOME_VAR=some-value"), build.WithEnv("CNB_REGISTRY_AUTH=some-secret")))
+		return phase.Run(context.TODO())
+	}
+
+	it("saves the state of the failed phase to a debug image", func() {
+		mockDocker.EXPECT().ContainerCommit(gomock.Any(), "phase-container", gomock.Any()).Return(types.IDResponse{ID: "phase-image"}, nil)
+		mockDocker.EXPECT().ContainerCreate(gomock.Any(), &dcontainer.Config{Image: "phase-image", User: "root"}, gomock.Any(), nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "snapshot-container"}, nil)
+		for _, dir := range []string{"/layers", "/workspace"} {
+			mockDocker.EXPECT().CopyFromContainer(gomock.Any(), "phase-container", dir).
+				Return(io.NopCloser(bytes.NewReader(nil)), types.ContainerPathStat{}, nil)
+			mockDocker.EXPECT().CopyToContainer(gomock.Any(), "snapshot-container", "/", gomock.Any(), gomock.Any()).Return(nil)
+		}
+		snapshotCommit := mockDocker.EXPECT().ContainerCommit(gomock.Any(), "snapshot-container", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, options dcontainer.CommitOptions) (types.IDResponse, error) {
+				h.AssertEq(t, options.Reference, "some/image-debug:detector")
+				h.AssertEq(t, []string(options.Config.Cmd), []string{"/cnb/lifecycle/detector", "some-arg"})
+				h.AssertSliceContains(t, options.Config.Env, "SOME_VAR=some-value")
+				h.AssertSliceNotContains(t, options.Config.Env, "CNB_REGISTRY_AUTH=some-secret")
+				return types.IDResponse{ID: "debug-image"}, nil
+			})
+		gomock.InOrder(
+			snapshotCommit,
+			mockDocker.EXPECT().ContainerRemove(gomock.Any(), "snapshot-container", gomock.Any()).Return(nil),
+			mockDocker.EXPECT().ImageRemove(gomock.Any(), "phase-image", gomock.Any()).Return(nil, nil),
+		)
+
+		h.AssertError(t, runPhase(), "some-attach-error")
+		h.AssertContains(t, outBuf.String(), "Saved the state of the failed 'detector' phase to image 'some/image-debug:detector'")
+		h.AssertContains(t, outBuf.String(), "docker run --rm -it --entrypoint /bin/sh some/image-debug:detector")
+	})
+
+	it("removes the committed phase container when the snapshot fails", func() {
+		mockDocker.EXPECT().ContainerCommit(gomock.Any(), "phase-container", gomock.Any()).Return(types.IDResponse{ID: "phase-image"}, nil)
+		mockDocker.EXPECT().ContainerCreate(gomock.Any(), &dcontainer.Config{Image: "phase-image", User: "root"}, gomock.Any(), nil, nil, "").
+			Return(dcontainer.CreateResponse{}, errors.New("some-create-error"))
+		mockDocker.EXPECT().ImageRemove(gomock.Any(), "phase-image", gomock.Any()).Return(nil, nil)
+
+		h.AssertError(t, runPhase(), "some-attach-error")
+		h.AssertContains(t, outBuf.String(), "creating snapshot container: some-create-error")
+	})
+
+	it("returns the failure of the phase when the state can't be saved", func() {
+		mockDocker.EXPECT().ContainerCommit(gomock.Any(), "phase-container", gomock.Any()).Return(types.IDResponse{}, errors.New("some-commit-error"))
+
+		h.AssertError(t, runPhase(), "some-attach-error")
+		h.AssertContains(t, outBuf.String(), "Warning: Saving the state of the failed 'detector' phase: committing phase container: some-commit-error")
+	})
+}
@@ -1,8 +1,14 @@
 package fakes
 
-import "github.com/buildpacks/pack/internal/build"
+import (
+	"sync"
+
+	"github.com/buildpacks/pack/internal/build"
+)
 
 type FakePhaseFactory struct {
+	mu sync.Mutex
+
 	NewCallCount          int
 	ReturnForNew          build.RunnerCleaner
 	NewCalledWithProvider []*build.PhaseConfigProvider
@@ -27,6 +33,9 @@ func WhichReturnsForNew(phase build.RunnerCleaner) func(*FakePhaseFactory) {
 }
 
 func (f *FakePhaseFactory) New(phaseConfigProvider *build.PhaseConfigProvider) build.RunnerCleaner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.NewCallCount++
 	f.NewCalledWithProvider = append(f.NewCalledWithProvider, phaseConfigProvider)
 
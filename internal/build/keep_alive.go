@@ -0,0 +1,183 @@
+package build
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+)
+
+const (
+	// KeepAliveLabel is set on the containers kept between builds, with a hash of their configuration as value
+	KeepAliveLabel = "io.buildpacks.pack.keep-alive"
+
+	// keepAliveDuration is how long a container is kept once created, after which it is removed
+	keepAliveDuration = 30 * time.Minute
+)
+
+// KeepAliveDockerClient is the docker client needed to keep build containers alive, see LifecycleOptions.KeepAlive
+type KeepAliveDockerClient interface {
+	DockerClient
+	ContainerList(ctx context.Context, options dcontainer.ListOptions) ([]types.Container, error)
+	ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.IDResponse, error)
+	ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error)
+	ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error)
+	ContainerKill(ctx context.Context, container, signal string) error
+}
+
+// warmContainers runs phases in containers that are kept between builds, rather than in a new container each time.
+// A container is reused by any phase with the same configuration, apart from its command, user and environment, which are
+// given when running the phase in it. The layers and app volumes, which are kept between builds as well, are emptied
+// before the first phase of a build runs.
+type warmContainers struct {
+	docker    KeepAliveDockerClient
+	resetDirs []string
+
+	resetOnce sync.Once
+	resetErr  error
+}
+
+func (w *warmContainers) run(ctx context.Context, p *Phase) error {
+	ctrID, err := w.container(ctx, p)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get '%s' container", p.name)
+	}
+	p.ctr.ID = ctrID
+
+	w.resetOnce.Do(func() {
+		cmd := append([]string{"find"}, w.resetDirs...)
+		w.resetErr = w.exec(ctx, ctrID, types.ExecConfig{User: "root", Cmd: append(cmd, "-mindepth", "1", "-delete")}, p)
+	})
+	if w.resetErr != nil {
+		return errors.Wrap(w.resetErr, "emptying layers and app volumes")
+	}
+
+	for _, containerOp := range p.containerOps {
+		if err := containerOp(p.docker, ctx, ctrID, p.infoWriter, p.errorWriter); err != nil {
+			return err
+		}
+	}
+
+	if err := w.exec(ctx, ctrID, types.ExecConfig{
+		User:       p.ctrConf.User,
+		Env:        p.ctrConf.Env,
+		WorkingDir: p.ctrConf.WorkingDir,
+		Cmd:        p.ctrConf.Cmd,
+	}, p); err != nil {
+		return err
+	}
+
+	for _, containerOp := range p.postContainerRunOps {
+		if err := containerOp(p.docker, ctx, ctrID, p.infoWriter, p.errorWriter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// container returns a running container for the phase, creating and starting it if there is none
+func (w *warmContainers) container(ctx context.Context, p *Phase) (string, error) {
+	key, err := keepAliveKey(p.ctrConf, p.hostConf)
+	if err != nil {
+		return "", err
+	}
+
+	ctrs, err := w.docker.ContainerList(ctx, dcontainer.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", KeepAliveLabel+"="+key), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(ctrs) > 0 {
+		return ctrs[0].ID, nil
+	}
+
+	// the environment of the phase may hold credentials, it is only given to the phase when it runs
+	ctrConf := *p.ctrConf
+	ctrConf.Env = nil
+	ctrConf.Cmd = nil
+	ctrConf.Entrypoint = []string{"sleep", strconv.Itoa(int(keepAliveDuration.Seconds()))}
+	ctrConf.Labels = map[string]string{KeepAliveLabel: key}
+	for k, v := range p.ctrConf.Labels {
+		ctrConf.Labels[k] = v
+	}
+	hostConf := *p.hostConf
+	hostConf.AutoRemove = true
+
+	ctr, err := w.docker.ContainerCreate(ctx, &ctrConf, &hostConf, nil, nil, "")
+	if err != nil {
+		return "", err
+	}
+	if err := w.docker.ContainerStart(ctx, ctr.ID, dcontainer.StartOptions{}); err != nil {
+		return "", errors.Wrap(err, "container start")
+	}
+	return ctr.ID, nil
+}
+
+func (w *warmContainers) exec(ctx context.Context, ctrID string, config types.ExecConfig, p *Phase) error {
+	config.AttachStdout = true
+	config.AttachStderr = true
+	exec, err := w.docker.ContainerExecCreate(ctx, ctrID, config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run '%s' in container", p.name)
+	}
+
+	resp, err := w.docker.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to run '%s' in container", p.name)
+	}
+	defer resp.Close()
+
+	stop := context.AfterFunc(ctx, resp.Close)
+	defer stop()
+
+	if _, err := stdcopy.StdCopy(p.infoWriter, p.errorWriter, resp.Reader); err != nil {
+		if ctx.Err() != nil {
+			// commands run in the container can't be stopped on their own, so the container is killed instead, and
+			// a new one is created by the next build
+			_ = w.docker.ContainerKill(context.Background(), ctrID, "KILL")
+			return errors.Wrapf(ctx.Err(), "'%s' was stopped", p.name)
+		}
+		return err
+	}
+
+	inspect, err := w.docker.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return &container.ExitError{Code: int64(inspect.ExitCode)}
+	}
+	return nil
+}
+
+// keepAliveKey identifies the configuration of a container, apart from what can be given when running a command in it
+func keepAliveKey(ctrConf *dcontainer.Config, hostConf *dcontainer.HostConfig) (string, error) {
+	contents, err := json.Marshal(struct {
+		Image       string
+		Binds       []string
+		NetworkMode dcontainer.NetworkMode
+		Isolation   dcontainer.Isolation
+		SecurityOpt []string
+		UsernsMode  dcontainer.UsernsMode
+		CapAdd      []string
+		CapDrop     []string
+		ReadOnly    bool
+		Tmpfs       map[string]string
+	}{ctrConf.Image, hostConf.Binds, hostConf.NetworkMode, hostConf.Isolation, hostConf.SecurityOpt, hostConf.UsernsMode, hostConf.CapAdd, hostConf.CapDrop, hostConf.ReadonlyRootfs, hostConf.Tmpfs})
+	if err != nil {
+		return "", errors.Wrap(err, "hashing container configuration")
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(contents)), nil
+}
@@ -0,0 +1,153 @@
+package build_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestKeepAlive(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "KeepAlive", testKeepAlive, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testKeepAlive(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		lifecycleExec  *build.LifecycleExecution
+		outBuf         bytes.Buffer
+		execs          []types.ExecConfig
+	)
+
+	// expectExecs runs every command with the given exit code, writing output to stdout
+	expectExecs := func(exitCode int) {
+		mockDocker.EXPECT().ContainerExecCreate(gomock.Any(), "warm-container", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, config types.ExecConfig) (types.IDResponse, error) {
+				execs = append(execs, config)
+				return types.IDResponse{ID: "some-exec"}, nil
+			}).AnyTimes()
+		mockDocker.EXPECT().ContainerExecAttach(gomock.Any(), "some-exec", gomock.Any()).DoAndReturn(
+			func(context.Context, string, types.ExecStartCheck) (types.HijackedResponse, error) {
+				var output bytes.Buffer
+				_, err := stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte("some output\n"))
+				h.AssertNil(t, err)
+				conn, _ := net.Pipe()
+				return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&output)}, nil
+			}).AnyTimes()
+		mockDocker.EXPECT().ContainerExecInspect(gomock.Any(), "some-exec").Return(types.ContainerExecInspect{ExitCode: exitCode}, nil).AnyTimes()
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		execs = nil
+
+		fakeBuilder, err := fakes.NewFakeBuilder()
+		h.AssertNil(t, err)
+		lifecycleExec, err = build.NewLifecycleExecution(logging.NewLogWithWriters(&outBuf, &outBuf), mockDocker, "some-temp-dir", build.LifecycleOptions{
+			Builder:   fakeBuilder,
+			Image:     name.MustParseReference("some/image"),
+			KeepAlive: true,
+			Termui:    &fakes.FakeTermui{},
+		})
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	runPhaseWithContext := func(ctx context.Context) error {
+		phase := build.NewDefaultPhaseFactory(lifecycleExec).New(build.NewPhaseConfigProvider("detector", lifecycleExec, build.WithArgs("some-arg"), build.WithEnv("SOME_VAR=some-value")))
+		if err := phase.Run(ctx); err != nil {
+			return err
+		}
+		return phase.Cleanup()
+	}
+
+	runPhase := func() error {
+		return runPhaseWithContext(context.TODO())
+	}
+
+	when("a container is running for the phase", func() {
+		it.Before(func() {
+			mockDocker.EXPECT().ContainerList(gomock.Any(), gomock.Any()).
+				Return([]types.Container{{ID: "warm-container"}}, nil).AnyTimes()
+		})
+
+		it("runs the phase in it after emptying the volumes once", func() {
+			expectExecs(0)
+
+			h.AssertNil(t, runPhase())
+			h.AssertNil(t, runPhase())
+
+			h.AssertEq(t, len(execs), 3)
+			h.AssertEq(t, execs[0].User, "root")
+			h.AssertEq(t, execs[0].Cmd, []string{"find", "/layers", "/workspace", "-mindepth", "1", "-delete"})
+			h.AssertEq(t, execs[1].Cmd, []string{"/cnb/lifecycle/detector", "some-arg"})
+			h.AssertSliceContains(t, execs[1].Env, "SOME_VAR=some-value")
+			h.AssertContains(t, outBuf.String(), "some output")
+		})
+
+		it("fails when the phase fails", func() {
+			expectExecs(1)
+
+			h.AssertError(t, runPhase(), "failed with status code: 1")
+		})
+
+		it("kills the container when the build is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockDocker.EXPECT().ContainerExecCreate(gomock.Any(), "warm-container", gomock.Any()).Return(types.IDResponse{ID: "some-exec"}, nil).AnyTimes()
+			mockDocker.EXPECT().ContainerExecAttach(gomock.Any(), "some-exec", gomock.Any()).DoAndReturn(
+				func(context.Context, string, types.ExecStartCheck) (types.HijackedResponse, error) {
+					// the command never writes any output nor ends
+					conn, _ := net.Pipe()
+					cancel()
+					return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}, nil
+				})
+			mockDocker.EXPECT().ContainerKill(gomock.Any(), "warm-container", "KILL").Return(nil)
+
+			err := runPhaseWithContext(ctx)
+			h.AssertError(t, err, "context canceled")
+		})
+	})
+
+	when("no container is running for the phase", func() {
+		it("creates one without the environment of the phase", func() {
+			mockDocker.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockDocker.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").DoAndReturn(
+				func(_ context.Context, config *dcontainer.Config, hostConfig *dcontainer.HostConfig, _, _ interface{}, _ string) (dcontainer.CreateResponse, error) {
+					h.AssertEq(t, len(config.Env), 0)
+					h.AssertEq(t, []string(config.Entrypoint), []string{"sleep", "1800"})
+					h.AssertNotEq(t, config.Labels[build.KeepAliveLabel], "")
+					h.AssertEq(t, hostConfig.AutoRemove, true)
+					return dcontainer.CreateResponse{ID: "warm-container"}, nil
+				})
+			mockDocker.EXPECT().ContainerStart(gomock.Any(), "warm-container", gomock.Any()).Return(nil)
+			expectExecs(0)
+
+			h.AssertNil(t, runPhase())
+		})
+	})
+}
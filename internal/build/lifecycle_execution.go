@@ -2,19 +2,21 @@ package build
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/auth"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/paths"
@@ -36,10 +38,17 @@ type LifecycleExecution struct {
 	platformAPI  *api.Version
 	layersVolume string
 	appVolume    string
+	layoutVolume string
 	os           string
 	mountPaths   mountPaths
 	opts         LifecycleOptions
 	tmpDir       string
+
+	// warmContainers runs the phases when containers are kept between builds, see LifecycleOptions.KeepAlive
+	warmContainers *warmContainers
+
+	// debugSnapshots saves the state of failed phases, see LifecycleOptions.DebugSnapshot
+	debugSnapshots *debugSnapshots
 }
 
 func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir string, opts LifecycleOptions) (*LifecycleExecution, error) {
@@ -72,10 +81,59 @@ func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir st
 		exec.logger = opts.Termui
 	}
 
+	if len(opts.LayoutCopies) > 0 {
+		exec.layoutVolume = paths.FilterReservedNames("pack-layout-" + randString(10))
+	}
+
+	if opts.KeepAlive {
+		if osType == "windows" {
+			return nil, errors.New("keeping build containers alive is not supported for Windows builders")
+		}
+		keepAliveDocker, ok := docker.(KeepAliveDockerClient)
+		if !ok {
+			return nil, errors.New("keeping build containers alive is not supported by the docker client")
+		}
+		// the containers are bound to the volumes, which must be kept as well
+		sum := sha256.Sum256([]byte(opts.Image.Name()))
+		exec.layersVolume = paths.FilterReservedNames(fmt.Sprintf("pack-layers-%x", sum[:6]))
+		exec.appVolume = paths.FilterReservedNames(fmt.Sprintf("pack-app-%x", sum[:6]))
+		exec.warmContainers = &warmContainers{
+			docker:    keepAliveDocker,
+			resetDirs: []string{exec.mountPaths.layersDir(), exec.mountPaths.appDir()},
+		}
+	}
+
+	if opts.Phase != "" {
+		// the phases run one at a time share the state of the build through the volumes
+		exec.layersVolume, exec.appVolume = PhaseVolumes(opts.Image)
+	}
+
+	if opts.DebugSnapshot {
+		if osType == "windows" {
+			return nil, errors.New("saving the state of failed phases is not supported for Windows builders")
+		}
+		debugSnapshotDocker, ok := docker.(DebugSnapshotDockerClient)
+		if !ok {
+			return nil, errors.New("saving the state of failed phases is not supported by the docker client")
+		}
+		exec.debugSnapshots = &debugSnapshots{
+			docker:     debugSnapshotDocker,
+			logger:     exec.logger,
+			imageName:  opts.Image,
+			volumeDirs: []string{exec.mountPaths.layersDir(), exec.mountPaths.appDir()},
+		}
+	}
+
 	return exec, nil
 }
 
 // intersection of two sorted lists of api versions
+// PhaseVolumes returns the layers and app volumes the phases of an image share when run one at a time
+func PhaseVolumes(image name.Reference) (layersVolume, appVolume string) {
+	sum := sha256.Sum256([]byte(image.Name()))
+	return paths.FilterReservedNames(fmt.Sprintf("pack-phase-layers-%x", sum[:6])), paths.FilterReservedNames(fmt.Sprintf("pack-phase-app-%x", sum[:6]))
+}
+
 func apiIntersection(apisA, apisB []*api.Version) []*api.Version {
 	bind := 0
 	aind := 0
@@ -196,10 +254,13 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 
 	launchCache := cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Launch, "launch", l.docker)
 
+	if l.opts.Phase != "" {
+		return l.runPhase(ctx, buildCache, launchCache, phaseFactory)
+	}
+
 	if !l.opts.UseCreator {
 		if l.platformAPI.LessThan("0.7") {
-			l.logger.Info(style.Step("DETECTING"))
-			if err := l.Detect(ctx, phaseFactory); err != nil {
+			if err := l.detectOrReplay(ctx, phaseFactory); err != nil {
 				return err
 			}
 
@@ -213,87 +274,96 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 				return err
 			}
 
-			l.logger.Info(style.Step("DETECTING"))
-			if err := l.Detect(ctx, phaseFactory); err != nil {
+			if err := l.detectOrReplay(ctx, phaseFactory); err != nil {
 				return err
 			}
 		}
 
-		var kanikoCache Cache
-		if l.PlatformAPI().AtLeast("0.12") {
-			// lifecycle 0.17.0 (introduces support for Platform API 0.12) and above will ensure that
-			// this volume is owned by the CNB user,
-			// and hence the restorer (after dropping privileges) will be able to write to it.
-			kanikoCache = cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Kaniko, "kaniko", l.docker)
-		} else {
-			switch {
-			case buildCache.Type() == cache.Volume:
-				// Re-use the build cache as the kaniko cache. Earlier versions of the lifecycle (0.16.x and below)
-				// already ensure this volume is owned by the CNB user.
-				kanikoCache = buildCache
-			case l.hasExtensionsForBuild():
-				// We need a usable kaniko cache, so error in this case.
-				return fmt.Errorf("build cache must be volume cache when building with extensions")
-			default:
-				// The kaniko cache is unused, so it doesn't matter that it's not usable.
-				kanikoCache = cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Kaniko, "kaniko", l.docker)
-			}
-		}
-
-		var (
-			ephemeralRunImage string
-			err               error
-		)
-		if l.runImageChanged() || l.hasExtensionsForRun() {
-			// Pull the run image by name in case we fail to pull it by identifier later.
-			if ephemeralRunImage, err = l.opts.FetchRunImageWithLifecycleLayer(l.runImageNameAfterExtensions()); err != nil {
-				return err
-			}
-		}
-
-		l.logger.Info(style.Step("RESTORING"))
-		if l.opts.ClearCache && l.PlatformAPI().LessThan("0.10") {
-			l.logger.Info("Skipping 'restore' due to clearing cache")
-		} else if err := l.Restore(ctx, buildCache, kanikoCache, phaseFactory); err != nil {
+		kanikoCache, err := l.kanikoCache(buildCache)
+		if err != nil {
 			return err
 		}
 
+		var ephemeralRunImage string
+		tasks := []task{{
+			name: "restore",
+			run: func(ctx context.Context) error {
+				l.logger.Info(style.Step("RESTORING"))
+				if l.opts.ClearCache && l.PlatformAPI().LessThan("0.10") {
+					l.logger.Info("Skipping 'restore' due to clearing cache")
+					return nil
+				}
+				return l.Restore(ctx, buildCache, kanikoCache, phaseFactory)
+			},
+		}}
+
+		exportDeps := []string{"build"}
 		if l.runImageChanged() || l.hasExtensionsForRun() {
-			if newEphemeralRunImage, err := l.opts.FetchRunImageWithLifecycleLayer(l.runImageIdentifierAfterExtensions()); err == nil {
-				// If the run image was switched by extensions, the run image reference as written by the __restorer__ will be a digest reference
-				// that is pullable from a registry.
-				// However, if the run image is only extended (not switched), the run image reference as written by the __analyzer__ may be an image identifier
-				// (in the daemon case), and will not be pullable.
-				ephemeralRunImage = newEphemeralRunImage
-			}
+			tasks = append(tasks, task{
+				// Pull the run image by name in case we fail to pull it by identifier later.
+				name: "pull-run-image",
+				run: func(ctx context.Context) (err error) {
+					ephemeralRunImage, err = l.opts.FetchRunImageWithLifecycleLayer(l.runImageNameAfterExtensions())
+					return err
+				},
+			}, task{
+				name: "resolve-run-image",
+				deps: []string{"pull-run-image", "restore"},
+				run: func(ctx context.Context) error {
+					if newEphemeralRunImage, err := l.opts.FetchRunImageWithLifecycleLayer(l.runImageIdentifierAfterExtensions()); err == nil {
+						// If the run image was switched by extensions, the run image reference as written by the __restorer__ will be a digest reference
+						// that is pullable from a registry.
+						// However, if the run image is only extended (not switched), the run image reference as written by the __analyzer__ may be an image identifier
+						// (in the daemon case), and will not be pullable.
+						ephemeralRunImage = newEphemeralRunImage
+					}
+					return nil
+				},
+			})
 		}
 
-		group, _ := errgroup.WithContext(context.TODO())
 		if l.platformAPI.AtLeast("0.10") && l.hasExtensionsForBuild() {
-			group.Go(func() error {
-				l.logger.Info(style.Step("EXTENDING (BUILD)"))
-				return l.ExtendBuild(ctx, kanikoCache, phaseFactory, l.extensionsAreExperimental())
+			tasks = append(tasks, task{
+				name: "build",
+				deps: []string{"restore"},
+				run: func(ctx context.Context) error {
+					l.logger.Info(style.Step("EXTENDING (BUILD)"))
+					return l.ExtendBuild(ctx, kanikoCache, phaseFactory, l.extensionsAreExperimental())
+				},
 			})
 		} else {
-			group.Go(func() error {
-				l.logger.Info(style.Step("BUILDING"))
-				return l.Build(ctx, phaseFactory)
+			tasks = append(tasks, task{
+				name: "build",
+				deps: []string{"restore"},
+				run: func(ctx context.Context) error {
+					l.logger.Info(style.Step("BUILDING"))
+					return l.Build(ctx, phaseFactory)
+				},
 			})
 		}
 
 		if l.platformAPI.AtLeast("0.12") && l.hasExtensionsForRun() {
-			group.Go(func() error {
-				l.logger.Info(style.Step("EXTENDING (RUN)"))
-				return l.ExtendRun(ctx, kanikoCache, phaseFactory, ephemeralRunImage, l.extensionsAreExperimental())
+			tasks = append(tasks, task{
+				name: "extend-run",
+				deps: []string{"resolve-run-image"},
+				run: func(ctx context.Context) error {
+					l.logger.Info(style.Step("EXTENDING (RUN)"))
+					return l.ExtendRun(ctx, kanikoCache, phaseFactory, ephemeralRunImage, l.extensionsAreExperimental())
+				},
 			})
+			exportDeps = append(exportDeps, "extend-run")
 		}
 
-		if err := group.Wait(); err != nil {
-			return err
-		}
+		tasks = append(tasks, task{
+			name: "export",
+			deps: exportDeps,
+			run: func(ctx context.Context) error {
+				l.logger.Info(style.Step("EXPORTING"))
+				return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+			},
+		})
 
-		l.logger.Info(style.Step("EXPORTING"))
-		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+		return runTasks(ctx, l.opts.MaxConcurrency, tasks)
 	}
 
 	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
@@ -302,13 +372,75 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 	return l.Create(ctx, buildCache, launchCache, phaseFactory)
 }
 
+func (l *LifecycleExecution) kanikoCache(buildCache Cache) (Cache, error) {
+	if l.PlatformAPI().AtLeast("0.12") {
+		// lifecycle 0.17.0 (introduces support for Platform API 0.12) and above will ensure that
+		// this volume is owned by the CNB user,
+		// and hence the restorer (after dropping privileges) will be able to write to it.
+		return cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Kaniko, "kaniko", l.docker), nil
+	}
+
+	switch {
+	case buildCache.Type() == cache.Volume:
+		// Re-use the build cache as the kaniko cache. Earlier versions of the lifecycle (0.16.x and below)
+		// already ensure this volume is owned by the CNB user.
+		return buildCache, nil
+	case l.hasExtensionsForBuild():
+		// We need a usable kaniko cache, so error in this case.
+		return nil, fmt.Errorf("build cache must be volume cache when building with extensions")
+	default:
+		// The kaniko cache is unused, so it doesn't matter that it's not usable.
+		return cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Kaniko, "kaniko", l.docker), nil
+	}
+}
+
+// runPhase runs the phase of the options alone, against the volumes left by the phases run before it
+func (l *LifecycleExecution) runPhase(ctx context.Context, buildCache, launchCache Cache, phaseFactory PhaseFactory) error {
+	kanikoCache, err := l.kanikoCache(buildCache)
+	if err != nil {
+		return err
+	}
+
+	l.logger.Debugf("Running the %s phase against volumes %s and %s", style.Symbol(l.opts.Phase), style.Symbol(l.layersVolume), style.Symbol(l.appVolume))
+	switch l.opts.Phase {
+	case "analyze":
+		l.logger.Info(style.Step("ANALYZING"))
+		return l.Analyze(ctx, buildCache, launchCache, phaseFactory)
+	case "detect":
+		l.logger.Info(style.Step("DETECTING"))
+		return l.detectOrReplay(ctx, phaseFactory)
+	case "restore":
+		l.logger.Info(style.Step("RESTORING"))
+		return l.Restore(ctx, buildCache, kanikoCache, phaseFactory)
+	case "build":
+		if l.platformAPI.AtLeast("0.10") && l.hasExtensionsForBuild() {
+			l.logger.Info(style.Step("EXTENDING (BUILD)"))
+			return l.ExtendBuild(ctx, kanikoCache, phaseFactory, l.extensionsAreExperimental())
+		}
+		l.logger.Info(style.Step("BUILDING"))
+		return l.Build(ctx, phaseFactory)
+	case "export":
+		l.logger.Info(style.Step("EXPORTING"))
+		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+	default:
+		return errors.Errorf("unknown phase %s, must be one of %s", style.Symbol(l.opts.Phase), strings.Join(Phases, ", "))
+	}
+}
+
 func (l *LifecycleExecution) Cleanup() error {
 	var reterr error
-	if err := l.docker.VolumeRemove(context.Background(), l.layersVolume, true); err != nil {
-		reterr = errors.Wrapf(err, "failed to clean up layers volume %s", l.layersVolume)
+	if l.warmContainers == nil && l.opts.Phase == "" {
+		if err := l.docker.VolumeRemove(context.Background(), l.layersVolume, true); err != nil {
+			reterr = errors.Wrapf(err, "failed to clean up layers volume %s", l.layersVolume)
+		}
+		if err := l.docker.VolumeRemove(context.Background(), l.appVolume, true); err != nil {
+			reterr = errors.Wrapf(err, "failed to clean up app volume %s", l.appVolume)
+		}
 	}
-	if err := l.docker.VolumeRemove(context.Background(), l.appVolume, true); err != nil {
-		reterr = errors.Wrapf(err, "failed to clean up app volume %s", l.appVolume)
+	if l.layoutVolume != "" {
+		if err := l.docker.VolumeRemove(context.Background(), l.layoutVolume, true); err != nil {
+			reterr = errors.Wrapf(err, "failed to clean up layout volume %s", l.layoutVolume)
+		}
 	}
 	if err := os.RemoveAll(l.tmpDir); err != nil {
 		reterr = errors.Wrapf(err, "failed to clean up working directory %s", l.tmpDir)
@@ -316,6 +448,38 @@ func (l *LifecycleExecution) Cleanup() error {
 	return reterr
 }
 
+// exportRegistryImages returns the images the lifecycle reads from or writes to when exporting to a registry,
+// including every additional tag so that each target registry gets its own credentials
+func (l *LifecycleExecution) exportRegistryImages() []string {
+	return append([]string{l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage}, l.opts.AdditionalTags...)
+}
+
+// parsePreviousImage validates the previous image reference.
+// Platform API 0.7 added the -previous-image flag, letting the analyzer reuse layers from an image in any registry.
+// Earlier platforms analyze the previous image in place of the image, so both must be in the same registry when publishing.
+func (l *LifecycleExecution) parsePreviousImage() (name.Reference, error) {
+	if l.opts.Image == nil {
+		return nil, errors.New("image can't be nil")
+	}
+
+	image, err := name.ParseReference(l.opts.Image.Name(), name.WeakValidation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image name: %s", err)
+	}
+
+	prevImage, err := name.ParseReference(l.opts.PreviousImage, name.WeakValidation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous image name: %s", err)
+	}
+
+	if l.opts.Publish && l.platformAPI.LessThan("0.7") && image.Context().RegistryStr() != prevImage.Context().RegistryStr() {
+		return nil, fmt.Errorf(`when --publish is used with platform API < 0.7, <previous-image> must be in the same image registry as <image>
+                image registry = %s
+                previous-image registry = %s`, image.Context().RegistryStr(), prevImage.Context().RegistryStr())
+	}
+	return prevImage, nil
+}
+
 func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache Cache, phaseFactory PhaseFactory) error {
 	flags := addTags([]string{
 		"-app", l.mountPaths.appDir(),
@@ -336,30 +500,15 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	if l.opts.PreviousImage != "" {
-		if l.opts.Image == nil {
-			return errors.New("image can't be nil")
-		}
-
-		image, err := name.ParseReference(l.opts.Image.Name(), name.WeakValidation)
-		if err != nil {
-			return fmt.Errorf("invalid image name: %s", err)
-		}
-
-		prevImage, err := name.ParseReference(l.opts.PreviousImage, name.WeakValidation)
-		if err != nil {
-			return fmt.Errorf("invalid previous image name: %s", err)
-		}
-		if l.opts.Publish {
-			if image.Context().RegistryStr() != prevImage.Context().RegistryStr() {
-				return fmt.Errorf(`when --publish is used, <previous-image> must be in the same image registry as <image>
-                image registry = %s
-                previous-image registry = %s`, image.Context().RegistryStr(), prevImage.Context().RegistryStr())
-			}
+		if _, err := l.parsePreviousImage(); err != nil {
+			return err
 		}
 
 		flags = append(flags, "-previous-image", l.opts.PreviousImage)
 	}
 
+	flags = append(flags, l.insecureRegistryFlags()...)
+
 	processType := determineDefaultProcessType(l.platformAPI, l.opts.DefaultProcessType)
 	if processType != "" {
 		flags = append(flags, "-process-type", processType)
@@ -380,10 +529,12 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	opts := []PhaseConfigProviderOperation{
+		If(l.opts.Timestamps, WithLogPrefix("creator")),
 		WithFlags(l.withLogLevel(flags...)...),
 		WithArgs(l.opts.Image.String()),
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
+		l.buildInputsOp(buildCache.Type() != cache.Image && !l.opts.ClearCache, buildCache.Type() != cache.Image),
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
 		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
@@ -392,9 +543,13 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		If(l.opts.ReportDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.reportPath(), l.opts.ReportDestinationDir))),
+		If(l.opts.ArtifactsDestinationDir != "", WithPostContainerRunOperations(
+			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
+			CopyOutToMaybe(l.mountPaths.artifactsDir()+"/.", l.opts.ArtifactsDestinationDir))),
 		If(l.opts.Interactive, WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOut(l.opts.Termui.ReadLayers, l.mountPaths.layersDir(), l.mountPaths.appDir()))),
+		If(l.opts.ExportPlan != "", WithPostContainerRunOperations(l.exportPlanOp())),
 		withEnv,
 	}
 
@@ -404,10 +559,11 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		if err != nil {
 			return err
 		}
+		opts = append(opts, l.withLayoutCopies(true, true))
 	}
 
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.exportRegistryImages()...)
 		if err != nil {
 			return err
 		}
@@ -452,6 +608,7 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "analyzed.toml"), l.tmpDir))),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "generated"), l.tmpDir))),
+		If(l.opts.ExportPlan != "", WithPostContainerRunOperations(l.exportPlanOp())),
 		envOp,
 	)
 
@@ -521,9 +678,11 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 	// for export to OCI layout
 	layoutOp := NullOp()
 	layoutBindOp := NullOp()
+	layoutCopyOp := NullOp()
 	if l.opts.Layout && l.platformAPI.AtLeast("0.12") {
 		layoutOp = withLayoutOperation()
 		layoutBindOp = WithBinds(l.opts.Volumes...)
+		layoutCopyOp = l.withLayoutCopies(true, false)
 	}
 
 	dockerOp := NullOp()
@@ -532,6 +691,7 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 		flags = append(flags, "-daemon")
 	}
 
+	flags = append(flags, l.insecureRegistryFlags()...)
 	flagsOp := WithFlags(flags...)
 
 	configProvider := NewPhaseConfigProvider(
@@ -546,12 +706,14 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 		),
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
+		l.buildInputsOp(buildCache.Type() == cache.Volume && !l.opts.ClearCache, false),
 		dockerOp,
 		flagsOp,
 		kanikoCacheBindOp,
 		registryOp,
 		layoutOp,
 		layoutBindOp,
+		layoutCopyOp,
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "analyzed.toml"), l.tmpDir))),
 	)
@@ -600,25 +762,9 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 	}
 
 	if l.opts.PreviousImage != "" {
-		if l.opts.Image == nil {
-			return errors.New("image can't be nil")
-		}
-
-		image, err := name.ParseReference(l.opts.Image.Name(), name.WeakValidation)
+		prevImage, err := l.parsePreviousImage()
 		if err != nil {
-			return fmt.Errorf("invalid image name: %s", err)
-		}
-
-		prevImage, err := name.ParseReference(l.opts.PreviousImage, name.WeakValidation)
-		if err != nil {
-			return fmt.Errorf("invalid previous image name: %s", err)
-		}
-		if l.opts.Publish {
-			if image.Context().RegistryStr() != prevImage.Context().RegistryStr() {
-				return fmt.Errorf(`when --publish is used, <previous-image> must be in the same image registry as <image>
-	            image registry = %s
-	            previous-image registry = %s`, image.Context().RegistryStr(), prevImage.Context().RegistryStr())
-			}
+			return err
 		}
 		if platformAPILessThan07 {
 			l.opts.Image = prevImage
@@ -650,11 +796,12 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 		layoutOp = withLayoutOperation()
 	}
 
+	flags = append(flags, l.insecureRegistryFlags()...)
 	flagsOp := WithFlags(flags...)
 
 	var analyze RunnerCleaner
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.exportRegistryImages()...)
 		if err != nil {
 			return err
 		}
@@ -674,6 +821,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 			stackOp,
 			runOp,
 			layoutOp,
+			l.replayPlanOp(),
 		)
 
 		analyze = phaseFactory.New(configProvider)
@@ -696,6 +844,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 			cacheBindOp,
 			stackOp,
 			runOp,
+			l.replayPlanOp(),
 		)
 
 		analyze = phaseFactory.New(configProvider)
@@ -715,6 +864,9 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
 		WithFlags(flags...),
+		If(l.opts.ReadOnlyRootfs, WithReadOnlyRootfs(l.opts.ScratchDirs...)),
+		If(l.opts.ArtifactsDestinationDir != "", WithPostContainerRunOperations(
+			CopyOutToMaybe(l.mountPaths.artifactsDir()+"/.", l.opts.ArtifactsDestinationDir))),
 	)
 
 	build := phaseFactory.New(configProvider)
@@ -796,6 +948,8 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	if l.platformAPI.LessThan("0.7") {
 		flags = append(flags, "-run-image", l.opts.RunImage)
 	}
+	flags = append(flags, l.insecureRegistryFlags()...)
+
 	processType := determineDefaultProcessType(l.platformAPI, l.opts.DefaultProcessType)
 	if processType != "" {
 		flags = append(flags, "-process-type", processType)
@@ -836,9 +990,11 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
 		kanikoCacheBindOp,
+		l.buildInputsOp(false, buildCache.Type() == cache.Volume),
 		WithContainerOperations(WriteStackToml(l.mountPaths.stackPath(), l.opts.Builder.Stack(), l.os)),
 		WithContainerOperations(WriteRunToml(l.mountPaths.runPath(), l.opts.Builder.RunImages(), l.os)),
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
+		l.slicesOp(),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.sbomDir(), l.opts.SBOMDestinationDir))),
@@ -858,12 +1014,12 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		if err != nil {
 			return err
 		}
-		opts = append(opts, WithBinds(l.opts.Volumes...))
+		opts = append(opts, WithBinds(l.opts.Volumes...), l.withLayoutCopies(false, true))
 	}
 
 	var export RunnerCleaner
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.exportRegistryImages()...)
 		if err != nil {
 			return err
 		}
@@ -888,6 +1044,126 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	return export.Run(ctx)
 }
 
+// detectOrReplay runs the detection, unless a build plan is replayed, see replayPlanOp
+func (l *LifecycleExecution) detectOrReplay(ctx context.Context, phaseFactory PhaseFactory) error {
+	l.logger.Info(style.Step("DETECTING"))
+	if l.opts.Plan != nil {
+		l.logger.Info("Skipping 'detect', replaying the build plan")
+		return nil
+	}
+	return l.Detect(ctx, phaseFactory)
+}
+
+// replayPlanOp writes the replayed build plan where the detection would have, and copies the app the detection would
+// have copied, for the analyzer, which runs first when the detection is skipped, to do so instead.
+func (l *LifecycleExecution) replayPlanOp() PhaseConfigProviderOperation {
+	if l.opts.Plan == nil {
+		return NullOp()
+	}
+	return func(provider *PhaseConfigProvider) {
+		WithContainerOperations(
+			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
+			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
+			WriteBuildPlan(l.mountPaths.groupPath(), l.mountPaths.planPath(), *l.opts.Plan, l.os),
+		)(provider)
+		If(l.opts.ExportPlan != "", WithPostContainerRunOperations(l.exportPlanOp()))(provider)
+	}
+}
+
+// exportPlanOp exports the build plan resolved by the detection, see LifecycleOptions.ExportPlan
+func (l *LifecycleExecution) exportPlanOp() ContainerOperation {
+	return ExportBuildPlan(l.mountPaths.groupPath(), l.mountPaths.planPath(), l.opts.ExportPlan)
+}
+
+// ArtifactsDir returns the directory buildpacks write the build outputs to, for the build to copy them out to
+// LifecycleOptions.ArtifactsDestinationDir
+func ArtifactsDir(os string) string {
+	return mountPathsForOS(os, "").artifactsDir()
+}
+
+// phaseArtifactsOp imports the phase artifacts of the phases run before the phase, and exports the ones it leaves for the
+// phases after it, see LifecycleOptions.PhaseArtifactsDir. As the volumes of the phases before it may be on another host,
+// the restore phase copies the app too.
+func (l *LifecycleExecution) phaseArtifactsOp() PhaseConfigProviderOperation {
+	if l.opts.Phase == "" || l.opts.PhaseArtifactsDir == "" {
+		return NullOp()
+	}
+
+	var artifacts []string
+	for _, artifact := range PhaseArtifacts {
+		artifacts = append(artifacts, l.mountPaths.join(l.mountPaths.layersDir(), artifact))
+	}
+	exportOp := ExportPhaseArtifacts(l.opts.PhaseArtifactsDir, artifacts...)
+
+	var ops []ContainerOperation
+	if l.opts.Phase == "restore" {
+		ops = append(ops,
+			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
+			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
+		)
+	}
+	ops = append(ops, ImportPhaseArtifacts(l.opts.PhaseArtifactsDir, l.mountPaths.layersDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os))
+
+	return func(provider *PhaseConfigProvider) {
+		WithContainerOperations(ops...)(provider)
+		WithPostContainerRunOperations(exportOp)(provider)
+	}
+}
+
+// slicesOp adds the slices of the app to the build metadata before the export, and reports the files of the app that
+// fell into the layer of each slice after, see LifecycleOptions.Slices
+func (l *LifecycleExecution) slicesOp() PhaseConfigProviderOperation {
+	if len(l.opts.Slices) == 0 {
+		return NullOp()
+	}
+
+	writeOp := WriteSlices(l.mountPaths.join(l.mountPaths.layersDir(), "config", "metadata.toml"), l.opts.Slices, l.os)
+	verifyOp := VerifySlices(l.mountPaths.appDir(), l.opts.Slices, l.logSlices)
+	return func(provider *PhaseConfigProvider) {
+		WithContainerOperations(writeOp)(provider)
+		WithPostContainerRunOperations(verifyOp)(provider)
+	}
+}
+
+func (l *LifecycleExecution) logSlices(slices []SliceFiles) {
+	l.logger.Info("Files of the app in each layer of the app:")
+	for i, slice := range slices {
+		name := fmt.Sprintf("slice %d (%s)", i+1, strings.Join(slice.Paths, ", "))
+		if i == len(slices)-1 {
+			name = "remaining files"
+		}
+		l.logger.Infof("  %s: %d files, %s", name, slice.Files, humanize.Bytes(uint64(slice.Size)))
+		if slice.Files == 0 && i < len(slices)-1 {
+			l.logger.Warnf("Slice %d (%s) matches no file of the app, its layer is empty", i+1, strings.Join(slice.Paths, ", "))
+		}
+	}
+}
+
+// buildInputsOp reads the inputs of the previous build of the app from the build cache when read is set, warning about
+// the changes that invalidate its cached layers, and records the inputs of this build there when write is set
+func (l *LifecycleExecution) buildInputsOp(read, write bool) PhaseConfigProviderOperation {
+	if l.opts.BuildInputs == nil || l.os == "windows" {
+		return NullOp()
+	}
+
+	return func(provider *PhaseConfigProvider) {
+		If(read, WithContainerOperations(ReadBuildInputs(l.mountPaths.buildInputsPath(), l.warnBuildInputChanges)))(provider)
+		If(write, WithPostContainerRunOperations(WriteBuildInputs(l.mountPaths.buildInputsPath(), *l.opts.BuildInputs, l.os)))(provider)
+	}
+}
+
+func (l *LifecycleExecution) warnBuildInputChanges(prev BuildInputs) {
+	changes := l.opts.BuildInputs.Changes(prev)
+	if len(changes) == 0 {
+		return
+	}
+
+	l.logger.Warn("Layers cached by the previous build may not be reused, as its inputs changed:")
+	for _, change := range changes {
+		l.logger.Warnf("  %s", change)
+	}
+}
+
 func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	if l.logger.IsVerbose() {
 		return append([]string{"-log-level", "debug"}, args...)
@@ -990,6 +1266,46 @@ func withLayoutOperation() PhaseConfigProviderOperation {
 	return WithEnv("CNB_USE_LAYOUT=true", "CNB_LAYOUT_DIR="+layoutDir, "CNB_EXPERIMENTAL_MODE=warn")
 }
 
+// withLayoutCopies binds the layout volume, when layout directories are copied rather than bound, copying the input
+// directories into it before the phase runs when copyIn is set, and the output directories out of it after it ran when
+// copyOut is set. The volume is kept between phases, so inputs are copied by the first phase only.
+func (l *LifecycleExecution) withLayoutCopies(copyIn, copyOut bool) PhaseConfigProviderOperation {
+	if l.layoutVolume == "" {
+		return NullOp()
+	}
+
+	var inOps, outOps []ContainerOperation
+	for _, c := range l.opts.LayoutCopies {
+		switch {
+		case c.Output && copyOut:
+			// copies the contents of the directory, as in 'docker cp <container>:<dir>/. <host dir>'
+			outOps = append(outOps, CopyOutTo(c.TargetPath+"/.", c.HostPath))
+		case !c.Output && copyIn:
+			inOps = append(inOps, CopyDir(c.HostPath, c.TargetPath, l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, nil))
+		}
+	}
+
+	layoutDir := filepath.Join(paths.RootDir, "layout-repo")
+	return func(provider *PhaseConfigProvider) {
+		WithBinds(fmt.Sprintf("%s:%s", l.layoutVolume, layoutDir))(provider)
+		WithContainerOperations(inOps...)(provider)
+		WithPostContainerRunOperations(outOps...)(provider)
+	}
+}
+
+// insecureRegistryFlags returns the flags for the lifecycle to access the insecure registries, which it supports as of
+// Platform API 0.13
+func (l *LifecycleExecution) insecureRegistryFlags() []string {
+	var flags []string
+	if l.platformAPI.LessThan("0.13") {
+		return flags
+	}
+	for _, registry := range l.opts.InsecureRegistries {
+		flags = append(flags, "-insecure-registry", registry)
+	}
+	return flags
+}
+
 func prependArg(arg string, args []string) []string {
 	return append([]string{arg}, args...)
 }
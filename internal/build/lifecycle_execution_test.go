@@ -15,6 +15,8 @@ import (
 	"github.com/apex/log"
 	ifakes "github.com/buildpacks/imgutil/fakes"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
@@ -226,6 +228,32 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				h.AssertError(t, err, "unable to find a supported Platform API version")
 			})
 		})
+
+		when("build containers are kept alive", func() {
+			var withKeepAlive = func(opts *build.LifecycleOptions) {
+				opts.KeepAlive = true
+				opts.Image = name.MustParseReference("some/image")
+			}
+
+			it("keeps the volumes of the image between builds", func() {
+				first := newTestLifecycleExec(t, false, "some-temp-dir", withKeepAlive)
+				second := newTestLifecycleExec(t, false, "some-temp-dir", withKeepAlive)
+
+				h.AssertEq(t, first.LayersVolume(), second.LayersVolume())
+				h.AssertEq(t, first.AppVolume(), second.AppVolume())
+				h.AssertContainsMatch(t, first.LayersVolume(), `^pack-layers-[0-9a-f]{12}$`)
+			})
+
+			it("errors for Windows builders", func() {
+				image := ifakes.NewImage("some-image", "", nil)
+				h.AssertNil(t, image.SetOS("windows"))
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithImage(image))
+				h.AssertNil(t, err)
+
+				_, err = newTestLifecycleExecErr(t, false, "some-temp-dir", fakes.WithBuilder(fakeBuilder), withKeepAlive)
+				h.AssertError(t, err, "keeping build containers alive is not supported for Windows builders")
+			})
+		})
 	})
 
 	when("FindLatestSupported", func() {
@@ -479,6 +507,95 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("a build plan is replayed", func() {
+				it("skips the detector", func() {
+					fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+					h.AssertNil(t, err)
+
+					opts := build.LifecycleOptions{
+						RunImage: "test",
+						Image:    imageName,
+						Builder:  fakeBuilder,
+						Termui:   fakeTermui,
+						Plan:     &build.BuildPlan{Group: []buildpack.GroupElement{{ID: "some/bp", Version: "1.0.0"}}},
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					expectedPhases := []string{"analyzer", "restorer", "builder", "exporter"}
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), len(expectedPhases))
+					for i, entry := range fakePhaseFactory.NewCalledWithProvider {
+						h.AssertEq(t, entry.Name(), expectedPhases[i])
+					}
+				})
+			})
+
+			when("a single phase is run", func() {
+				it("runs it against the volumes the phases of the image share", func() {
+					fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+					h.AssertNil(t, err)
+
+					opts := build.LifecycleOptions{
+						RunImage: "test",
+						Image:    imageName,
+						Builder:  fakeBuilder,
+						Termui:   fakeTermui,
+						Phase:    "restore",
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					layersVolume, appVolume := build.PhaseVolumes(imageName)
+					h.AssertEq(t, lifecycle.LayersVolume(), layersVolume)
+					h.AssertEq(t, lifecycle.AppVolume(), appVolume)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 1)
+					h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[0].Name(), "restorer")
+					h.AssertSliceContains(t, fakePhaseFactory.NewCalledWithProvider[0].HostConfig().Binds, layersVolume+":/layers")
+				})
+
+				it("imports and exports the phase artifacts with an artifacts directory", func() {
+					fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+					h.AssertNil(t, err)
+
+					opts := build.LifecycleOptions{
+						RunImage:          "test",
+						Image:             imageName,
+						Builder:           fakeBuilder,
+						Termui:            fakeTermui,
+						Phase:             "restore",
+						PhaseArtifactsDir: "some-artifacts-dir",
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 1)
+					configProvider := fakePhaseFactory.NewCalledWithProvider[0]
+					containerOps := configProvider.ContainerOps()
+					h.AssertFunctionName(t, containerOps[len(containerOps)-1], "ImportPhaseArtifacts")
+					postContainerRunOps := configProvider.PostContainerRunOps()
+					h.AssertFunctionName(t, postContainerRunOps[len(postContainerRunOps)-1], "ExportPhaseArtifacts")
+				})
+			})
+
 			it("succeeds", func() {
 				opts := build.LifecycleOptions{
 					Publish:      false,
@@ -705,6 +822,32 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 								h.AssertEq(t, fakeFetcher.calledWithArgAtCall[0], "some-new-run-image")
 								h.AssertEq(t, fakeFetcher.calledWithArgAtCall[1], "some-new-run-image-identifier")
 							})
+
+							it("pulls the new run image while restoring", func() {
+								restoring := make(chan struct{})
+								lifecycle = newTestLifecycleExec(t, true, tmpDir, append(lifecycleOps, func(opts *build.LifecycleOptions) {
+									opts.FetchRunImageWithLifecycleLayer = func(name string) (string, error) {
+										if name == "some-new-run-image" {
+											select {
+											case <-restoring:
+											case <-time.After(10 * time.Second):
+												return "", fmt.Errorf("restorer was not started")
+											}
+										}
+										return "ephemeral-" + name, nil
+									}
+								})...)
+
+								err := lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+									return phaseFactoryFunc(func(provider *build.PhaseConfigProvider) build.RunnerCleaner {
+										if provider.Name() == "restorer" {
+											close(restoring)
+										}
+										return fakePhaseFactory.New(provider)
+									})
+								})
+								h.AssertNil(t, err)
+							})
 						})
 					})
 
@@ -1212,6 +1355,27 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 						err = lifecycle.Create(context.Background(), fakeBuildCache, fakeLaunchCache, fakePhaseFactory)
 						h.AssertError(t, err, fmt.Sprintf("%s", err))
 					})
+
+					when("platform >= 0.7", func() {
+						it("passes previous-image to creator", func() {
+							imageName, err := name.NewTag("/some/image", name.WeakValidation)
+							h.AssertNil(t, err)
+							fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+							h.AssertNil(t, err)
+
+							lifecycleOps := append(lifecycleOps, func(options *build.LifecycleOptions) {
+								options.PreviousImage = "example.io/some/previous:latest"
+								options.Image = imageName
+								options.Builder = fakeBuilder
+							})
+							lifecycle := newTestLifecycleExec(t, true, "some-temp-dir", lifecycleOps...)
+							fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+							h.AssertNil(t, lifecycle.Create(context.Background(), fakeBuildCache, fakeLaunchCache, fakePhaseFactory))
+							configProvider := fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+							h.AssertIncludeAllExpectedPatterns(t, configProvider.ContainerConfig().Cmd, []string{"-previous-image", "example.io/some/previous:latest"})
+						})
+					})
 				})
 			})
 		})
@@ -1249,6 +1413,21 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("artifacts destination directory is provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.ArtifactsDestinationDir = "some-artifacts-dir"
+			})
+
+			it("provides copy-artifacts-func as a post container operation", func() {
+				h.AssertEq(t, fakePhase.CleanupCallCount, 1)
+				h.AssertEq(t, fakePhase.RunCallCount, 1)
+
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "EnsureVolumeAccess")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOutMaybe")
+			})
+		})
+
 		when("report destination directory is provided", func() {
 			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
 				opts.ReportDestinationDir = "a-destination-dir"
@@ -1264,6 +1443,20 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("build inputs are provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.BuildInputs = &build.BuildInputs{Builder: "some-builder"}
+			})
+
+			it("reads the inputs of the previous build and records the inputs of this one", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 3)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[0], "ReadBuildInputs")
+
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "WriteBuildInputs")
+			})
+		})
+
 		when("--creation-time", func() {
 			when("platform < 0.9", func() {
 				platformAPI = api.MustParse("0.8")
@@ -1356,6 +1549,17 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertFunctionName(t, configProvider.ContainerOps()[1], "CopyDir")
 		})
 
+		when("the build plan is exported", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.ExportPlan = "some-plan.toml"
+			})
+
+			it("exports the resolved build plan", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "ExportBuildPlan")
+			})
+		})
+
 		when("extensions", func() {
 			platformAPI = api.MustParse("0.10")
 
@@ -1392,6 +1596,19 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, fakePhase.RunCallCount, 1)
 		})
 
+		when("a build plan is replayed", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.Plan = &build.BuildPlan{Group: []buildpack.GroupElement{{ID: "some/bp", Version: "1.0.0"}}}
+			})
+
+			it("copies the app and writes the build plan for the skipped detector", func() {
+				ops := configProvider.ContainerOps()
+				h.AssertFunctionName(t, ops[len(ops)-3], "EnsureVolumeAccess")
+				h.AssertFunctionName(t, ops[len(ops)-2], "CopyDir")
+				h.AssertFunctionName(t, ops[len(ops)-1], "WriteBuildPlan")
+			})
+		})
+
 		when("platform < 0.7", func() {
 			when("clear cache", func() {
 				providedClearCache = true
@@ -2021,6 +2238,22 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 						configProvider.ContainerConfig().Env, "CNB_USE_LAYOUT=true", fmt.Sprintf("CNB_LAYOUT_DIR=%s", layoutDir),
 					)
 				})
+
+				when("layout directories are copied", func() {
+					lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+						opts.LayoutCopies = []build.LayoutCopy{
+							{HostPath: "some-run-image-path", TargetPath: "/layout-repo/some-run-image"},
+							{HostPath: "some-image-path", TargetPath: "/layout-repo/some-image", Output: true},
+						}
+					})
+
+					it("copies the input directories into the layout volume", func() {
+						layoutDir := filepath.Join(paths.RootDir, "layout-repo")
+						h.AssertSliceContainsMatch(t, configProvider.HostConfig().Binds, "pack-layout-.*:"+layoutDir)
+						h.AssertEq(t, len(configProvider.ContainerOps()), 1)
+						h.AssertFunctionName(t, configProvider.ContainerOps()[0], "CopyDir")
+					})
+				})
 			})
 		})
 	})
@@ -2056,6 +2289,37 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		it("configures the phase with binds", func() {
 			h.AssertSliceContains(t, configProvider.HostConfig().Binds, providedVolumes...)
 		})
+
+		it("configures the phase with a writable root filesystem", func() {
+			h.AssertEq(t, configProvider.HostConfig().ReadonlyRootfs, false)
+		})
+
+		it("doesn't copy out artifacts", func() {
+			h.AssertEq(t, len(configProvider.PostContainerRunOps()), 0)
+		})
+
+		when("artifacts destination directory is provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.ArtifactsDestinationDir = "some-artifacts-dir"
+			})
+
+			it("copies out the artifacts after the phase", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOutMaybe")
+			})
+		})
+
+		when("the root filesystem is read-only", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.ReadOnlyRootfs = true
+				opts.ScratchDirs = []string{"/home/cnb"}
+			})
+
+			it("configures the phase with a read-only root filesystem and tmpfs scratch directories", func() {
+				h.AssertEq(t, configProvider.HostConfig().ReadonlyRootfs, true)
+				h.AssertEq(t, configProvider.HostConfig().Tmpfs, map[string]string{"/tmp": "", "/home/cnb": ""})
+			})
+		})
 	})
 
 	when("#ExtendBuild", func() {
@@ -2217,6 +2481,28 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertSliceNotContains(t, configProvider.ContainerConfig().Cmd, "-run")
 		})
 
+		when("insecure registries are provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.InsecureRegistries = []string{"localhost:5000", "kind-registry:5000"}
+			})
+
+			it("doesn't provide them with platform < 0.13", func() {
+				h.AssertSliceNotContains(t, configProvider.ContainerConfig().Cmd, "-insecure-registry")
+			})
+
+			when("platform >= 0.13", func() {
+				platformAPI = api.MustParse("0.13")
+
+				it("provides them to the exporter", func() {
+					h.AssertIncludeAllExpectedPatterns(t,
+						configProvider.ContainerConfig().Cmd,
+						[]string{"-insecure-registry", "localhost:5000"},
+						[]string{"-insecure-registry", "kind-registry:5000"},
+					)
+				})
+			})
+		})
+
 		when("platform >= 0.12", func() {
 			platformAPI = api.MustParse("0.12")
 
@@ -2260,6 +2546,22 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 						configProvider.ContainerConfig().Env, "CNB_USE_LAYOUT=true", fmt.Sprintf("CNB_LAYOUT_DIR=%s", layoutDir),
 					)
 				})
+
+				when("layout directories are copied", func() {
+					lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+						opts.LayoutCopies = []build.LayoutCopy{
+							{HostPath: "some-run-image-path", TargetPath: "/layout-repo/some-run-image"},
+							{HostPath: "some-image-path", TargetPath: "/layout-repo/some-image", Output: true},
+						}
+					})
+
+					it("copies the output directories out of the layout volume", func() {
+						layoutDir := filepath.Join(paths.RootDir, "layout-repo")
+						h.AssertSliceContainsMatch(t, configProvider.HostConfig().Binds, "pack-layout-.*:"+layoutDir)
+						h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+						h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOut")
+					})
+				})
 			})
 		})
 
@@ -2327,6 +2629,21 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, configProvider.ContainerConfig().User, "root")
 			})
 
+			when("additional tags are in other registries", func() {
+				lifecycleOps = append(lifecycleOps, func(options *build.LifecycleOptions) {
+					options.AdditionalTags = []string{"other-registry.example.com/some/image:latest"}
+					h.AssertNil(t, os.WriteFile(
+						filepath.Join(os.Getenv("DOCKER_CONFIG"), "config.json"),
+						[]byte(`{"auths": {"other-registry.example.com": {"auth": "dXNlcjpwYXNz"}}}`),
+						0600,
+					))
+				})
+
+				it("configures the phase with credentials for every tag", func() {
+					h.AssertSliceContains(t, configProvider.ContainerConfig().Env, `CNB_REGISTRY_AUTH={"other-registry.example.com":"Basic dXNlcjpwYXNz"}`)
+				})
+			})
+
 			it("configures the phase with the expected network mode", func() {
 				h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(providedNetworkMode))
 			})
@@ -2572,6 +2889,20 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("slices are provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.Slices = []layers.Slice{{Paths: []string{"static"}}}
+			})
+
+			it("adds the slices to the build metadata, and verifies the files of each slice after the export", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 4)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[3], "WriteSlices")
+
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "VerifySlices")
+			})
+		})
+
 		when("report destination directory is provided", func() {
 			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
 				opts.ReportDestinationDir = "a-destination-dir"
@@ -2640,6 +2971,13 @@ func newFakeImageCache() *fakes.FakeCache {
 	return c
 }
 
+// phaseFactoryFunc is a build.PhaseFactory creating phases with a function
+type phaseFactoryFunc func(provider *build.PhaseConfigProvider) build.RunnerCleaner
+
+func (f phaseFactoryFunc) New(provider *build.PhaseConfigProvider) build.RunnerCleaner {
+	return f(provider)
+}
+
 func newFakeFetchRunImageFunc(f *fakeImageFetcher) func(name string) (string, error) {
 	return func(name string) (string, error) {
 		return fmt.Sprintf("ephemeral-%s", name), f.fetchRunImage(name)
@@ -8,15 +8,18 @@ import (
 
 	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/container"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/metrics"
 )
 
 var (
@@ -66,6 +69,12 @@ type Termui interface {
 	ReadLayers(reader io.ReadCloser) error
 }
 
+// LifecyclePhases are the phases of the lifecycle pack runs, which can be given additional arguments
+var LifecyclePhases = []string{"analyzer", "builder", "creator", "detector", "exporter", "extender", "restorer"}
+
+// Phases are the phases that can be run one at a time, in the order they run in
+var Phases = []string{"analyze", "detect", "restore", "build", "export"}
+
 type LifecycleOptions struct {
 	AppPath                         string
 	Image                           name.Reference
@@ -93,6 +102,12 @@ type LifecycleOptions struct {
 	Network                         string
 	AdditionalTags                  []string
 	Volumes                         []string
+	UsernsMode                      string   // user namespace of the phase containers
+	SecurityOpts                    []string // security options of the phase containers, with seccomp profiles rather than their paths
+	CapAdd                          []string // kernel capabilities added to the phase containers
+	CapDrop                         []string // kernel capabilities dropped from the phase containers
+	ReadOnlyRootfs                  bool     // the build phase runs with a read-only root filesystem, to validate buildpacks only write to their layers
+	ScratchDirs                     []string // tmpfs mounts of the build phase in addition to /tmp, when its root filesystem is read-only
 	DefaultProcessType              string
 	FileFilter                      func(string) bool
 	Workspace                       string
@@ -101,8 +116,34 @@ type LifecycleOptions struct {
 	PreviousImage                   string
 	ReportDestinationDir            string
 	SBOMDestinationDir              string
+	ArtifactsDestinationDir         string // the files the buildpacks write to ArtifactsDir are copied to this directory after the build, if set
 	CreationTime                    *time.Time
 	Keychain                        authn.Keychain
+	MaxConcurrency                  int // maximum number of independent operations run at once, no limit if zero
+	KeepAlive                       bool
+	InsecureRegistries              []string
+	LayoutCopies                    []LayoutCopy        // directories in OCI layout format copied in and out of the build instead of being bound, see Volumes
+	BuildInputs                     *BuildInputs        // recorded in the build cache to explain the cache misses of the next build, if set
+	Timestamps                      bool                // each line of the phases is prefixed with the phase, and the buildpack when the lifecycle reports it, for the logger to timestamp
+	RawOutput                       bool                // the output of the phases is logged as it is, without prefixes, timestamps or color removal
+	DebugSnapshot                   bool                // the state of a failed phase is saved to a debug image, to look into the failure
+	ExportPlan                      string              // path the build plan resolved by detection is exported to, if set
+	Plan                            *BuildPlan          // replayed instead of running detection, if set
+	PhaseArgs                       map[string][]string // additional arguments of the phases, by phase, overriding the flags pack passes
+	Phase                           string              // only this phase is run, one of Phases, against the volumes the phases of the image share, if set
+	PhaseArtifactsDir               string              // the PhaseArtifacts are imported from and exported to this directory around the Phase, if set
+	Slices                          []layers.Slice      // slices of the app, exported as layers of their own along with the slices of the buildpacks
+	Metrics                         metrics.Collector
+	Tracer                          trace.Tracer   // spans of the phases are started with it, if set
+	Logger                          logging.Logger // the build is logged with it rather than the logger of the executor, if set
+}
+
+// LayoutCopy is a directory in OCI layout format given to the lifecycle by copying it into a volume, rather than by
+// binding it, which a daemon on another host can't do
+type LayoutCopy struct {
+	HostPath   string
+	TargetPath string
+	Output     bool // copied out of the volume once the image is exported, instead of into it
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
@@ -115,7 +156,12 @@ func (l *LifecycleExecutor) Execute(ctx context.Context, opts LifecycleOptions)
 		return err
 	}
 
-	lifecycleExec, err := NewLifecycleExecution(l.logger, l.docker, tmpDir, opts)
+	logger := l.logger
+	if opts.Logger != nil {
+		logger = opts.Logger
+	}
+
+	lifecycleExec, err := NewLifecycleExecution(logger, l.docker, tmpDir, opts)
 	if err != nil {
 		return err
 	}
@@ -34,6 +34,13 @@ func (m mountPaths) layersDir() string {
 	return m.join(m.volume, "layers")
 }
 
+// artifactsDir is where buildpacks write the build outputs to copy out of the build container, such as test reports.
+// It's in the layers directory, to be shared by the phases of the build, and starts with a dot, so it can't be the
+// layers directory of a buildpack.
+func (m mountPaths) artifactsDir() string {
+	return m.join(m.layersDir(), ".artifacts")
+}
+
 func (m mountPaths) stackPath() string {
 	return m.join(m.layersDir(), "stack.toml")
 }
@@ -50,6 +57,14 @@ func (m mountPaths) reportPath() string {
 	return m.join(m.layersDir(), "report.toml")
 }
 
+func (m mountPaths) groupPath() string {
+	return m.join(m.layersDir(), "group.toml")
+}
+
+func (m mountPaths) planPath() string {
+	return m.join(m.layersDir(), "plan.toml")
+}
+
 func (m mountPaths) appDirName() string {
 	return m.workspace
 }
@@ -62,6 +77,10 @@ func (m mountPaths) cacheDir() string {
 	return m.join(m.volume, "cache")
 }
 
+func (m mountPaths) buildInputsPath() string {
+	return m.join(m.cacheDir(), "pack-build-inputs.toml")
+}
+
 func (m mountPaths) kanikoCacheDir() string {
 	return m.join(m.volume, "kaniko")
 }
@@ -6,8 +6,12 @@ import (
 
 	dcontainer "github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/metrics"
 )
 
 type Phase struct {
@@ -24,9 +28,39 @@ type Phase struct {
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
 	fileFilter          func(string) bool
+	warm                *warmContainers
+	snapshots           *debugSnapshots
+	metrics             metrics.Collector
+	tracer              trace.Tracer
 }
 
-func (p *Phase) Run(ctx context.Context) error {
+func (p *Phase) Run(ctx context.Context) (err error) {
+	if p.tracer != nil {
+		var span trace.Span
+		ctx, span = p.tracer.Start(ctx, "lifecycle "+p.name, trace.WithAttributes(attribute.String("pack.phase", p.name)))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	err = metrics.Observe(p.metrics, metrics.LifecyclePhase, map[string]string{"phase": p.name}, func() error {
+		return p.run(ctx)
+	})
+	if err != nil && p.snapshots != nil && p.ctr.ID != "" && ctx.Err() == nil {
+		p.snapshots.take(ctx, p)
+	}
+	return err
+}
+
+func (p *Phase) run(ctx context.Context) error {
+	if p.warm != nil {
+		return p.warm.run(ctx, p)
+	}
+
 	var err error
 	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
 	if err != nil {
@@ -63,5 +97,9 @@ func (p *Phase) Run(ctx context.Context) error {
 }
 
 func (p *Phase) Cleanup() error {
+	if p.warm != nil {
+		// the container is kept for the next build
+		return nil
+	}
 	return p.docker.ContainerRemove(context.Background(), p.ctr.ID, dcontainer.RemoveOptions{Force: true})
 }
@@ -0,0 +1,57 @@
+package build
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// PhaseArtifacts are the files phases leave in the layers directory for the phases after them to read. Exporting them
+// to a directory, and importing them in a later stage, allows the phases of a build to run on different hosts, such as
+// detection on a small CI runner, and the build and export on a bigger one.
+var PhaseArtifacts = []string{"analyzed.toml", "group.toml", "plan.toml"}
+
+// ImportPhaseArtifacts copies the phase artifacts found in the directory on the host to the layers directory in the
+// container. Nothing is copied if the directory doesn't exist, such as before the first phase.
+func ImportPhaseArtifacts(srcDir, layersDir string, uid, gid int, targetOS string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		if _, err := os.Stat(srcDir); os.IsNotExist(err) {
+			return nil
+		}
+
+		isArtifact := func(relPath string) bool {
+			for _, artifact := range PhaseArtifacts {
+				if filepath.ToSlash(relPath) == artifact {
+					return true
+				}
+			}
+			return false
+		}
+		if err := CopyDir(srcDir, layersDir, uid, gid, targetOS, false, isArtifact)(ctrClient, ctx, containerID, stdout, stderr); err != nil {
+			return errors.Wrapf(err, "importing phase artifacts from %s", style.Symbol(srcDir))
+		}
+		return nil
+	}
+}
+
+// ExportPhaseArtifacts copies the phase artifacts at the paths in the container to the directory on the host, skipping
+// the ones the phases haven't written yet.
+func ExportPhaseArtifacts(dstDir string, srcs ...string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		if err := os.MkdirAll(dstDir, os.ModePerm); err != nil {
+			return errors.Wrapf(err, "creating phase artifacts directory %s", style.Symbol(dstDir))
+		}
+
+		for _, src := range srcs {
+			if err := CopyOutToMaybe(src, dstDir)(ctrClient, ctx, containerID, stdout, stderr); err != nil {
+				return errors.Wrapf(err, "exporting phase artifact %s", style.Symbol(src))
+			}
+		}
+		return nil
+	}
+}
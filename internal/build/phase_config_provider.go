@@ -31,6 +31,8 @@ type PhaseConfigProvider struct {
 	infoWriter          io.Writer
 	errorWriter         io.Writer
 	handler             pcontainer.Handler
+	rawOutput           bool
+	moduleNames         bool
 }
 
 func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops ...PhaseConfigProviderOperation) *PhaseConfigProvider {
@@ -41,6 +43,13 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		os:          lifecycleExec.os,
 		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
 		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
+		rawOutput:   lifecycleExec.opts.RawOutput,
+		moduleNames: lifecycleExec.opts.Timestamps,
+	}
+
+	if provider.rawOutput {
+		provider.infoWriter = logging.GetRawWriterForLevel(lifecycleExec.logger, logging.InfoLevel)
+		provider.errorWriter = logging.GetRawWriterForLevel(lifecycleExec.logger, logging.ErrorLevel)
 	}
 
 	provider.ctrConf.Image = lifecycleExec.opts.Builder.Name()
@@ -57,12 +66,21 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 			fmt.Sprintf("%s:%s", lifecycleExec.layersVolume, lifecycleExec.mountPaths.layersDir()),
 			fmt.Sprintf("%s:%s", lifecycleExec.appVolume, lifecycleExec.mountPaths.appDir()),
 		}...),
+		lifecycleExec.phaseArtifactsOp(),
 	)
 
 	for _, op := range ops {
 		op(provider)
 	}
 
+	// security options are applied after the ops of the phase, so that they add to the ones the phase requires
+	WithSecurity(lifecycleExec.opts.UsernsMode, lifecycleExec.opts.SecurityOpts, lifecycleExec.opts.CapAdd, lifecycleExec.opts.CapDrop)(provider)
+
+	if args := lifecycleExec.opts.PhaseArgs[name]; len(args) > 0 {
+		lifecycleExec.logger.Warnf("Passing additional arguments to the %s: %s", name, style.Symbol(strings.Join(args, " ")))
+		provider.ctrConf.Cmd = withPhaseArgs(provider.ctrConf.Cmd, args)
+	}
+
 	provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
@@ -85,6 +103,38 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	return provider
 }
 
+// lifecycleBoolFlags are the flags pack passes the phases that take no value
+var lifecycleBoolFlags = map[string]bool{"-daemon": true, "-skip-layers": true, "-skip-restore": true}
+
+// withPhaseArgs puts additional arguments ahead of the arguments pack passes a phase, dropping the flags of pack they
+// override, so that the values of the additional arguments apply
+func withPhaseArgs(cmd []string, args []string) []string {
+	overridden := map[string]bool{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			overridden[flagName(arg)] = true
+		}
+	}
+
+	result := append([]string{}, args...)
+	for i := 0; i < len(cmd); i++ {
+		if strings.HasPrefix(cmd[i], "-") && overridden[flagName(cmd[i])] {
+			if !strings.Contains(cmd[i], "=") && !lifecycleBoolFlags[flagName(cmd[i])] {
+				i++ // skip the value of the flag too
+			}
+			continue
+		}
+		result = append(result, cmd[i])
+	}
+	return result
+}
+
+// flagName returns the name of a flag, in the single dash form, without its value
+func flagName(arg string) string {
+	name, _, _ := strings.Cut(arg, "=")
+	return "-" + strings.TrimLeft(name, "-")
+}
+
 func sanitized(origEnv []string) []string {
 	var sanitizedEnv []string
 	for _, env := range origEnv {
@@ -196,12 +246,16 @@ func WithImage(image string) PhaseConfigProviderOperation {
 	}
 }
 
-// WithLogPrefix sets a prefix for logs produced by this phase
+// WithLogPrefix sets a prefix for logs produced by this phase, unless its output is logged as it is
 func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
-		if prefix != "" {
-			provider.infoWriter = logging.NewPrefixWriter(provider.infoWriter, prefix)
-			provider.errorWriter = logging.NewPrefixWriter(provider.errorWriter, prefix)
+		if prefix != "" && !provider.rawOutput {
+			var opts []logging.PrefixWriterOption
+			if provider.moduleNames {
+				opts = append(opts, logging.WithModuleNames())
+			}
+			provider.infoWriter = logging.NewPrefixWriter(provider.infoWriter, prefix, opts...)
+			provider.errorWriter = logging.NewPrefixWriter(provider.errorWriter, prefix, opts...)
 		}
 	}
 }
@@ -228,6 +282,32 @@ func WithNetwork(networkMode string) PhaseConfigProviderOperation {
 	}
 }
 
+// WithSecurity sets the user namespace of the container, and adds the security options and kernel capabilities
+func WithSecurity(usernsMode string, securityOpts, capAdd, capDrop []string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		if usernsMode != "" {
+			provider.hostConf.UsernsMode = container.UsernsMode(usernsMode)
+		}
+		provider.hostConf.SecurityOpt = append(provider.hostConf.SecurityOpt, securityOpts...)
+		provider.hostConf.CapAdd = append(provider.hostConf.CapAdd, capAdd...)
+		provider.hostConf.CapDrop = append(provider.hostConf.CapDrop, capDrop...)
+	}
+}
+
+// WithReadOnlyRootfs makes the root filesystem of the container read-only, with tmpfs mounts at /tmp and at the scratch
+// directories, such that the phase can only write to them and to the volumes
+func WithReadOnlyRootfs(scratchDirs ...string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.hostConf.ReadonlyRootfs = true
+		if provider.hostConf.Tmpfs == nil {
+			provider.hostConf.Tmpfs = map[string]string{}
+		}
+		for _, dir := range append([]string{"/tmp"}, scratchDirs...) {
+			provider.hostConf.Tmpfs[dir] = ""
+		}
+	}
+}
+
 func WithRegistryAccess(authConfig string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		provider.ctrConf.Env = append(provider.ctrConf.Env, fmt.Sprintf(`CNB_REGISTRY_AUTH=%s`, authConfig))
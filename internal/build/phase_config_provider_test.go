@@ -105,6 +105,30 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the phase has additional arguments", func() {
+			it("puts them ahead, in place of the flags they override", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.PhaseArgs = map[string][]string{
+						"exporter": {"-process-type=worker", "--daemon", "-log-level", "debug"},
+						"detector": {"-unused"},
+					}
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"exporter",
+					lifecycle,
+					build.WithArgs("some-image"),
+					build.WithFlags("-process-type", "web", "-daemon", "-run-image=some/run"),
+				)
+
+				h.AssertEq(t, phaseConfigProvider.ContainerConfig().Cmd, strslice.StrSlice{
+					"/cnb/lifecycle/exporter",
+					"-process-type=worker", "--daemon", "-log-level", "debug",
+					"-run-image=some/run", "some-image",
+				})
+			})
+		})
+
 		when("called with WithFlags", func() {
 			it("sets args on the config", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -220,6 +244,39 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithSecurity", func() {
+			it("sets the user namespace, and adds the security options and capabilities", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithDaemonAccess(""),
+					build.WithSecurity("host", []string{"no-new-privileges"}, []string{"NET_ADMIN"}, []string{"ALL"}),
+				)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().UsernsMode, container.UsernsMode("host"))
+				h.AssertEq(t, phaseConfigProvider.HostConfig().SecurityOpt, []string{"label=disable", "no-new-privileges"})
+				h.AssertEq(t, phaseConfigProvider.HostConfig().CapAdd, strslice.StrSlice{"NET_ADMIN"})
+				h.AssertEq(t, phaseConfigProvider.HostConfig().CapDrop, strslice.StrSlice{"ALL"})
+			})
+		})
+
+		when("called with WithReadOnlyRootfs", func() {
+			it("makes the root filesystem read-only, with tmpfs mounts at /tmp and the scratch directories", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithReadOnlyRootfs("/var/tmp"),
+				)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().ReadonlyRootfs, true)
+				h.AssertEq(t, phaseConfigProvider.HostConfig().Tmpfs, map[string]string{"/tmp": "", "/var/tmp": ""})
+			})
+		})
+
 		when("called with WithRegistryAccess", func() {
 			it("sets registry access on the config", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -291,6 +348,26 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithLogPrefix and raw output", func() {
+			it("doesn't set prefix writers", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.RawOutput = true
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithLogPrefix("some-prefix"),
+				)
+
+				_, isType := phaseConfigProvider.InfoWriter().(*logging.PrefixWriter)
+				h.AssertEq(t, isType, false)
+
+				_, isType = phaseConfigProvider.ErrorWriter().(*logging.PrefixWriter)
+				h.AssertEq(t, isType, false)
+			})
+		})
+
 		when("verbose", func() {
 			it("prints debug information about the phase", func() {
 				var outBuf bytes.Buffer
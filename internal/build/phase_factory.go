@@ -36,5 +36,9 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 		containerOps:        provider.containerOps,
 		postContainerRunOps: provider.postContainerRunOps,
 		fileFilter:          m.lifecycleExec.opts.FileFilter,
+		warm:                m.lifecycleExec.warmContainers,
+		snapshots:           m.lifecycleExec.debugSnapshots,
+		metrics:             m.lifecycleExec.opts.Metrics,
+		tracer:              m.lifecycleExec.opts.Tracer,
 	}
 }
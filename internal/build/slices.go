@@ -0,0 +1,112 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/layers"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SliceFiles is the files of the app that fell into a layer of the app, see VerifySlices
+type SliceFiles struct {
+	// Paths of the slice, none for the layer of the files no slice matches.
+	Paths []string
+	Files int
+	Size  int64
+}
+
+// WriteSlices adds slices of the app to the build metadata the builder phase writes, for the exporter to export the
+// files they match as layers of their own, along with the slices of the buildpacks.
+func WriteSlices(metadataPath string, slices []layers.Slice, os string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		// the metadata is kept as it is, rather than decoded into the lifecycle types, so that no field is lost
+		metadata := map[string]interface{}{}
+		if err := readToml(ctrClient, ctx, containerID, metadataPath, &metadata); err != nil {
+			return err
+		}
+
+		var allSlices []map[string]interface{}
+		if existing, ok := metadata["slices"].([]map[string]interface{}); ok {
+			allSlices = existing
+		}
+		for _, slice := range slices {
+			allSlices = append(allSlices, map[string]interface{}{"paths": slice.Paths})
+		}
+		metadata["slices"] = allSlices
+
+		return writeToml(ctrClient, ctx, metadata, metadataPath, containerID, os, stdout, stderr)
+	}
+}
+
+// VerifySlices reads the files of the app directory in the container, and reports the files that fell into the layer
+// of each slice, and into the layer of the files no slice matches, the way the exporter slices the app.
+func VerifySlices(appDir string, slices []layers.Slice, report func([]SliceFiles)) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		reader, _, err := ctrClient.CopyFromContainer(ctx, containerID, appDir)
+		if err != nil {
+			return errors.Wrapf(err, "copying %s from container", style.Symbol(appDir))
+		}
+		defer reader.Close()
+
+		result := make([]SliceFiles, len(slices)+1)
+		for i, slice := range slices {
+			result[i].Paths = slice.Paths
+		}
+
+		tr := tar.NewReader(reader)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", style.Symbol(appDir))
+			}
+			if header.Typeflag == tar.TypeDir {
+				continue
+			}
+
+			// entries are relative to the parent of the app directory
+			_, relPath, ok := strings.Cut(header.Name, "/")
+			if !ok {
+				continue
+			}
+
+			i, err := sliceOf(relPath, slices)
+			if err != nil {
+				return err
+			}
+			result[i].Files++
+			result[i].Size += header.Size
+		}
+
+		report(result)
+		return nil
+	}
+}
+
+// sliceOf returns the index of the first slice matching the file, or one of its parent directories, or the number of
+// slices if none matches it
+func sliceOf(relPath string, slices []layers.Slice) (int, error) {
+	for i, slice := range slices {
+		for _, pattern := range slice.Paths {
+			pattern = path.Clean(pattern)
+			for p := relPath; p != "." && p != "/"; p = path.Dir(p) {
+				match, err := path.Match(pattern, p)
+				if err != nil {
+					return 0, errors.Wrapf(err, "matching slice path %s", style.Symbol(pattern))
+				}
+				if match {
+					return i, nil
+				}
+			}
+		}
+	}
+	return len(slices), nil
+}
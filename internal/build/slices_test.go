@@ -0,0 +1,105 @@
+package build_test
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/layers"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSlices(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Slices", testSlices, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSlices(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		slices         []layers.Slice
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		slices = []layers.Slice{{Paths: []string{"static/*.css", "vendor"}}, {Paths: []string{"docs"}}}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#WriteSlices", func() {
+		it("adds the slices to the build metadata, keeping the slices of the buildpacks", func() {
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddFile("metadata.toml", 0755, archive.NormalizedDateTime, []byte("buildpacks = []\n\n[[slices]]\npaths = [\"bp-slice\"]\n"))
+			mockDocker.EXPECT().
+				CopyFromContainer(gomock.Any(), "some-container", "/layers/config/metadata.toml").
+				Return(tarBuilder.Reader(archive.DefaultTarWriterFactory()), types.ContainerPathStat{}, nil)
+
+			var written struct {
+				Slices []layers.Slice `toml:"slices"`
+			}
+			mockDocker.EXPECT().
+				CopyToContainer(gomock.Any(), "some-container", "/", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, content io.Reader, _ types.CopyToContainerOptions) error {
+					tr := tar.NewReader(content)
+					header, err := tr.Next()
+					h.AssertNil(t, err)
+					h.AssertEq(t, header.Name, "/layers/config/metadata.toml")
+					_, err = toml.NewDecoder(tr).Decode(&written)
+					return err
+				})
+
+			op := build.WriteSlices("/layers/config/metadata.toml", slices, "linux")
+			h.AssertNil(t, op(mockDocker, context.TODO(), "some-container", io.Discard, io.Discard))
+
+			h.AssertEq(t, written.Slices, []layers.Slice{
+				{Paths: []string{"bp-slice"}},
+				{Paths: []string{"static/*.css", "vendor"}},
+				{Paths: []string{"docs"}},
+			})
+		})
+	})
+
+	when("#VerifySlices", func() {
+		it("reports the files of the app in the layer of each slice, the way the exporter slices the app", func() {
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddDir("workspace", 0755, archive.NormalizedDateTime)
+			tarBuilder.AddFile("workspace/static/app.css", 0644, archive.NormalizedDateTime, []byte("css"))
+			tarBuilder.AddFile("workspace/static/app.js", 0644, archive.NormalizedDateTime, []byte("js"))
+			tarBuilder.AddDir("workspace/vendor", 0755, archive.NormalizedDateTime)
+			tarBuilder.AddFile("workspace/vendor/lib/some.rb", 0644, archive.NormalizedDateTime, []byte("ruby"))
+			tarBuilder.AddFile("workspace/main.rb", 0644, archive.NormalizedDateTime, []byte("main"))
+			mockDocker.EXPECT().
+				CopyFromContainer(gomock.Any(), "some-container", "/workspace").
+				Return(tarBuilder.Reader(archive.DefaultTarWriterFactory()), types.ContainerPathStat{}, nil)
+
+			var reported []build.SliceFiles
+			op := build.VerifySlices("/workspace", slices, func(files []build.SliceFiles) {
+				reported = files
+			})
+			h.AssertNil(t, op(mockDocker, context.TODO(), "some-container", io.Discard, io.Discard))
+
+			h.AssertEq(t, reported, []build.SliceFiles{
+				{Paths: []string{"static/*.css", "vendor"}, Files: 2, Size: 7},
+				{Paths: []string{"docs"}, Files: 0, Size: 0},
+				{Files: 2, Size: 6},
+			})
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package build
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// task is an operation of a build, which is run once all the tasks it depends on have succeeded
+type task struct {
+	name string
+	deps []string
+	run  func(ctx context.Context) error
+}
+
+// runTasks runs tasks in dependency order, and independent tasks concurrently, up to maxConcurrency at once, or
+// without limit if maxConcurrency isn't positive. Tasks may only depend on tasks listed before them, and are started
+// in the order they are listed. No tasks are started after one fails, and the first failure is returned once the
+// running tasks are done.
+func runTasks(ctx context.Context, maxConcurrency int, tasks []task) error {
+	index := map[string]int{}
+	for i, t := range tasks {
+		for _, dep := range t.deps {
+			if _, ok := index[dep]; !ok {
+				return errors.Errorf("task %s depends on %s, which must be listed before it", style.Symbol(t.name), style.Symbol(dep))
+			}
+		}
+		index[t.name] = i
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result)
+	started := make([]bool, len(tasks))
+	done := make([]bool, len(tasks))
+	var (
+		running  int
+		firstErr error
+	)
+	for {
+		for i, t := range tasks {
+			if firstErr != nil || (maxConcurrency > 0 && running >= maxConcurrency) {
+				break
+			}
+			if started[i] || !depsDone(t, index, done) {
+				continue
+			}
+			started[i] = true
+			running++
+			go func(i int, t task) {
+				results <- result{index: i, err: t.run(ctx)}
+			}(i, t)
+		}
+
+		if running == 0 {
+			return firstErr
+		}
+
+		res := <-results
+		running--
+		done[res.index] = true
+		if res.err != nil && firstErr == nil {
+			firstErr = res.err
+			cancel()
+		}
+	}
+}
+
+func depsDone(t task, index map[string]int, done []bool) bool {
+	for _, dep := range t.deps {
+		if !done[index[dep]] {
+			return false
+		}
+	}
+	return true
+}
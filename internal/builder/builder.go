@@ -51,7 +51,7 @@ const (
 
 	emptyTarDiffID = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
 
-	metadataLabel = "io.buildpacks.builder.metadata"
+	MetadataLabel = "io.buildpacks.builder.metadata"
 	stackLabel    = "io.buildpacks.stack.id"
 
 	EnvUID = "CNB_USER_ID"
@@ -74,17 +74,21 @@ type Builder struct {
 	layerWriterFactory   archive.TarWriterFactory
 	lifecycle            Lifecycle
 	lifecycleDescriptor  LifecycleDescriptor
+	launcher             Blob
+	launcherMetadata     LauncherMetadata
 	additionalBuildpacks buildpack.ManagedCollection
 	additionalExtensions buildpack.ManagedCollection
 	metadata             Metadata
 	mixins               []string
 	env                  map[string]string
+	buildpackEnv         map[string]map[string]string
 	uid, gid             int
 	StackID              string
 	replaceOrder         bool
 	order                dist.Order
 	orderExtensions      dist.Order
 	validateMixins       bool
+	memoryLimit          int64
 }
 
 type orderTOML struct {
@@ -102,9 +106,11 @@ type moduleWithDiffID struct {
 type BuilderOption func(*options) error
 
 type options struct {
-	toFlatten buildpack.FlattenModuleInfos
-	labels    map[string]string
-	runImage  string
+	toFlatten   buildpack.FlattenModuleInfos
+	labels      map[string]string
+	annotations map[string]string
+	runImage    string
+	memoryLimit int64
 }
 
 func WithRunImage(name string) BuilderOption {
@@ -126,13 +132,13 @@ func New(baseImage imgutil.Image, name string, ops ...BuilderOption) (*Builder,
 
 func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool, ops ...BuilderOption) (*Builder, error) {
 	var metadata Metadata
-	if ok, err := dist.GetLabel(img, metadataLabel, &metadata); err != nil {
-		return nil, errors.Wrapf(err, "getting label %s", metadataLabel)
+	if ok, err := dist.GetLabel(img, MetadataLabel, &metadata); err != nil {
+		return nil, errors.Wrapf(err, "getting label %s", MetadataLabel)
 	} else if !ok && errOnMissingLabel {
-		return nil, fmt.Errorf("builder %s missing label %s -- try recreating builder", style.Symbol(img.Name()), style.Symbol(metadataLabel))
+		return nil, fmt.Errorf("builder %s missing label %s -- try recreating builder", style.Symbol(img.Name()), style.Symbol(MetadataLabel))
 	}
 
-	opts := &options{}
+	opts := &options{memoryLimit: DefaultMemoryLimit}
 	for _, op := range ops {
 		if err := op(opts); err != nil {
 			return nil, err
@@ -162,6 +168,12 @@ func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool,
 		}
 	}
 
+	if len(opts.annotations) > 0 {
+		if err = img.SetAnnotations(opts.annotations); err != nil {
+			return nil, errors.Wrap(err, "adding annotations")
+		}
+	}
+
 	bldr := &Builder{
 		baseImageName:        img.Name(),
 		image:                img,
@@ -171,6 +183,7 @@ func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool,
 		env:                  map[string]string{},
 		buildConfigEnv:       map[string]string{},
 		validateMixins:       true,
+		memoryLimit:          opts.memoryLimit,
 		additionalBuildpacks: buildpack.NewManagedCollectionV2(opts.toFlatten),
 		additionalExtensions: buildpack.NewManagedCollectionV2(opts.toFlatten),
 	}
@@ -200,6 +213,26 @@ func WithLabels(labels map[string]string) BuilderOption {
 	}
 }
 
+// WithAnnotations sets OCI manifest annotations on the builder image
+func WithAnnotations(annotations map[string]string) BuilderOption {
+	return func(o *options) error {
+		o.annotations = annotations
+		return nil
+	}
+}
+
+// WithMemoryLimit sets the size of the contents the builder keeps in memory while creating its layers, beyond which
+// they're written to temporary files, see DefaultMemoryLimit
+func WithMemoryLimit(limit int64) BuilderOption {
+	return func(o *options) error {
+		if limit <= 0 {
+			return errors.Errorf("memory limit must be positive, got %d", limit)
+		}
+		o.memoryLimit = limit
+		return nil
+	}
+}
+
 func constructLifecycleDescriptor(metadata Metadata) LifecycleDescriptor {
 	return CompatDescriptor(LifecycleDescriptor{
 		Info: LifecycleInfo{
@@ -349,24 +382,34 @@ func (b *Builder) ShouldFlatten(module buildpack.BuildModule) bool {
 // AddBuildpack adds a buildpack to the builder
 func (b *Builder) AddBuildpack(bp buildpack.BuildModule) {
 	b.additionalBuildpacks.AddModules(bp)
-	b.metadata.Buildpacks = append(b.metadata.Buildpacks, bp.Descriptor().Info())
+	b.metadata.Buildpacks = appendModuleInfo(b.metadata.Buildpacks, bp.Descriptor().Info())
 }
 
 func (b *Builder) AddBuildpacks(main buildpack.BuildModule, dependencies []buildpack.BuildModule) {
 	b.additionalBuildpacks.AddModules(main, dependencies...)
-	b.metadata.Buildpacks = append(b.metadata.Buildpacks, main.Descriptor().Info())
+	b.metadata.Buildpacks = appendModuleInfo(b.metadata.Buildpacks, main.Descriptor().Info())
 	for _, dep := range dependencies {
-		b.metadata.Buildpacks = append(b.metadata.Buildpacks, dep.Descriptor().Info())
+		b.metadata.Buildpacks = appendModuleInfo(b.metadata.Buildpacks, dep.Descriptor().Info())
 	}
 }
 
 // AddExtension adds an extension to the builder
 func (b *Builder) AddExtension(bp buildpack.BuildModule) {
 	b.additionalExtensions.AddModules(bp)
-	b.metadata.Extensions = append(b.metadata.Extensions, bp.Descriptor().Info())
+	b.metadata.Extensions = appendModuleInfo(b.metadata.Extensions, bp.Descriptor().Info())
+}
+
+// appendModuleInfo appends the module to the modules, unless a module of the same ID and version is already there,
+// such as when the layer of a module already on the builder is replaced
+func appendModuleInfo(modules []dist.ModuleInfo, info dist.ModuleInfo) []dist.ModuleInfo {
+	for _, module := range modules {
+		if module.ID == info.ID && module.Version == info.Version {
+			return modules
+		}
+	}
+	return append(modules, info)
 }
 
-// SetLifecycle sets the lifecycle of the builder
 func (b *Builder) SetLifecycle(lifecycle Lifecycle) {
 	b.lifecycle = lifecycle
 	b.lifecycleDescriptor = lifecycle.Descriptor()
@@ -377,6 +420,12 @@ func (b *Builder) SetEnv(env map[string]string) {
 	b.env = env
 }
 
+// SetBuildpackEnv sets environment variables scoped to buildpacks, keyed by buildpack ID. They are written to the
+// platform directory of each buildpack, rather than to the platform directory shared by all buildpacks.
+func (b *Builder) SetBuildpackEnv(env map[string]map[string]string) {
+	b.buildpackEnv = env
+}
+
 // SetBuildConfigEnv sets an environment variable to a value that will take action on platform environment variables basedon filename suffix
 func (b *Builder) SetBuildConfigEnv(env map[string]string) {
 	b.buildConfigEnv = env
@@ -459,6 +508,7 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		b.metadata.Lifecycle.LifecycleInfo = lifecycleDescriptor.Info
 		b.metadata.Lifecycle.API = lifecycleDescriptor.API
 		b.metadata.Lifecycle.APIs = lifecycleDescriptor.APIs
+		b.metadata.Launcher = nil
 		lifecycleTar, err := b.lifecycleLayer(tmpDir)
 		if err != nil {
 			return err
@@ -573,6 +623,9 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 	if len(b.env) > 0 {
 		logger.Debugf("Provided Environment Variables\n  %s", style.Map(b.env, "  ", "\n"))
 	}
+	for id, env := range b.buildpackEnv {
+		logger.Debugf("Provided Environment Variables for buildpack %s\n  %s", style.Symbol(id), style.Map(env, "  ", "\n"))
+	}
 
 	envTar, err := b.envLayer(tmpDir, b.env)
 	if err != nil {
@@ -589,7 +642,7 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 
 	b.metadata.CreatedBy = creatorMetadata
 
-	if err := dist.SetLabel(b.image, metadataLabel, b.metadata); err != nil {
+	if err := dist.SetLabel(b.image, MetadataLabel, b.metadata); err != nil {
 		return err
 	}
 
@@ -801,7 +854,7 @@ func (b *Builder) validateBuildpacks() error {
 
 	for _, bp := range b.AllModules(buildpack.KindBuildpack) {
 		bpd := bp.Descriptor()
-		if err := validateLifecycleCompat(bpd, b.LifecycleDescriptor()); err != nil {
+		if err := ValidateLifecycleCompat(bpd, b.LifecycleDescriptor()); err != nil {
 			return err
 		}
 
@@ -855,7 +908,7 @@ func validateExtensions(lifecycleDescriptor LifecycleDescriptor, allExtensions [
 
 	for _, ext := range extsToValidate {
 		extd := ext.Descriptor()
-		if err := validateLifecycleCompat(extd, lifecycleDescriptor); err != nil {
+		if err := ValidateLifecycleCompat(extd, lifecycleDescriptor); err != nil {
 			return err
 		}
 	}
@@ -863,7 +916,8 @@ func validateExtensions(lifecycleDescriptor LifecycleDescriptor, allExtensions [
 	return nil
 }
 
-func validateLifecycleCompat(descriptor buildpack.Descriptor, lifecycleDescriptor LifecycleDescriptor) error {
+// ValidateLifecycleCompat returns an error if the Buildpack API of the given module is not supported by the lifecycle
+func ValidateLifecycleCompat(descriptor buildpack.Descriptor, lifecycleDescriptor LifecycleDescriptor) error {
 	compatible := false
 	for _, version := range append(lifecycleDescriptor.APIs.Buildpack.Supported, lifecycleDescriptor.APIs.Buildpack.Deprecated...) {
 		compatible = version.Compare(descriptor.API()) == 0
@@ -995,7 +1049,7 @@ func (b *Builder) lifecycleLayer(dest string) (string, error) {
 		return "", err
 	}
 
-	err = b.embedLifecycleTar(lw)
+	err = b.embedLifecycleTar(lw, dest)
 	if err != nil {
 		return "", errors.Wrap(err, "embedding lifecycle tar")
 	}
@@ -1013,7 +1067,7 @@ func (b *Builder) lifecycleLayer(dest string) (string, error) {
 	return fh.Name(), nil
 }
 
-func (b *Builder) embedLifecycleTar(tw archive.TarWriter) error {
+func (b *Builder) embedLifecycleTar(tw archive.TarWriter, scratchDir string) error {
 	var regex = regexp.MustCompile(`^[^/]+/([^/]+)$`)
 
 	lr, err := b.lifecycle.Open()
@@ -1036,17 +1090,19 @@ func (b *Builder) embedLifecycleTar(tw archive.TarWriter) error {
 			binaryName := pathMatches[1]
 
 			header.Name = lifecycleDir + "/" + binaryName
+			if b.launcher != nil && (binaryName == "launcher" || binaryName == "launcher.exe") {
+				if err := b.embedLauncher(tw, header, scratchDir); err != nil {
+					return errors.Wrap(err, "embedding launcher")
+				}
+				continue
+			}
 			err = tw.WriteHeader(header)
 			if err != nil {
 				return errors.Wrapf(err, "failed to write header for '%s'", header.Name)
 			}
 
-			buf, err := io.ReadAll(tr)
-			if err != nil {
-				return errors.Wrapf(err, "failed to read contents of '%s'", header.Name)
-			}
-
-			_, err = tw.Write(buf)
+			// binaries are streamed into the layer, rather than read in memory
+			_, err = io.Copy(tw, tr)
 			if err != nil {
 				return errors.Wrapf(err, "failed to write contents to '%s'", header.Name)
 			}
@@ -1143,9 +1199,30 @@ func (b *Builder) envLayer(dest string, env map[string]string) (string, error) {
 		}
 	}
 
+	for id, env := range b.buildpackEnv {
+		for k, v := range env {
+			if err := lw.WriteHeader(&tar.Header{
+				Name:    path.Join(BuildpackPlatformDir(id), "env", k),
+				Size:    int64(len(v)),
+				Mode:    0644,
+				ModTime: archive.NormalizedDateTime,
+			}); err != nil {
+				return "", err
+			}
+			if _, err := lw.Write([]byte(v)); err != nil {
+				return "", err
+			}
+		}
+	}
+
 	return fh.Name(), nil
 }
 
+// BuildpackPlatformDir returns the platform directory of a buildpack, holding the environment variables scoped to it
+func BuildpackPlatformDir(id string) string {
+	return path.Join(platformDir, "buildpacks", strings.ReplaceAll(id, "/", "_"))
+}
+
 func (b *Builder) buildConfigEnvLayer(dest string, env map[string]string) (string, error) {
 	fh, err := os.Create(filepath.Join(dest, "build-config-env.tar"))
 	if err != nil {
@@ -1,6 +1,7 @@
 package builder_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"crypto/sha256"
 	"encoding/json"
@@ -27,6 +28,7 @@ import (
 	"github.com/buildpacks/pack/internal/builder/testmocks"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -39,6 +41,81 @@ func TestBuilder(t *testing.T) {
 	spec.Run(t, "Builder", testBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
 }
 
+func TestBuilderStreamsLifecycle(t *testing.T) {
+	const binarySize = 16 << 20
+
+	baseImage := fakes.NewImage("base/image", "", nil)
+	h.AssertNil(t, baseImage.SetEnv("CNB_USER_ID", "1234"))
+	h.AssertNil(t, baseImage.SetEnv("CNB_GROUP_ID", "4321"))
+	h.AssertNil(t, baseImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+	defer baseImage.Cleanup()
+
+	descriptorContents, err := os.ReadFile(filepath.Join("testdata", "lifecycle", "platform-0.4", "lifecycle.toml"))
+	h.AssertNil(t, err)
+	lifecycleDescriptor, err := builder.ParseDescriptor(string(descriptorContents))
+	h.AssertNil(t, err)
+
+	// the lifecycle archive is generated as it's read, and records the largest read of it
+	var source *recordingReader
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	mockLifecycle := testmocks.NewMockLifecycle(mockController)
+	mockLifecycle.EXPECT().Open().DoAndReturn(func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			tw := tar.NewWriter(pw)
+			if err := tw.WriteHeader(&tar.Header{Name: "lifecycle/builder", Mode: 0755, Size: binarySize}); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := io.Copy(tw, io.LimitReader(zeroReader{}, binarySize)); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			pw.CloseWithError(tw.Close())
+		}()
+		source = &recordingReader{ReadCloser: pr}
+		return source, nil
+	}).AnyTimes()
+	mockLifecycle.EXPECT().Descriptor().Return(builder.CompatDescriptor(lifecycleDescriptor)).AnyTimes()
+
+	subject, err := builder.New(baseImage, "some/builder")
+	h.AssertNil(t, err)
+	subject.SetLifecycle(mockLifecycle)
+	h.AssertNil(t, subject.Save(logging.NewSimpleLogger(io.Discard), builder.CreatorMetadata{}))
+
+	// the binary is copied into the layer a chunk at a time, rather than read whole
+	h.AssertTrue(t, source.total > binarySize)
+	if source.maxRead > 1<<20 {
+		t.Fatalf("expected the lifecycle to be read in chunks, got a read of %d bytes", source.maxRead)
+	}
+
+	layerTar, err := baseImage.FindLayerWithPath("/cnb/lifecycle/builder")
+	h.AssertNil(t, err)
+	h.AssertOnTarEntry(t, layerTar, "/cnb/lifecycle/builder", h.HasFileMode(0755))
+}
+
+// recordingReader records the total size and the largest of the reads of a reader
+type recordingReader struct {
+	io.ReadCloser
+	total   int
+	maxRead int
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	r.maxRead = max(r.maxRead, len(p))
+	n, err := r.ReadCloser.Read(p)
+	r.total += n
+	return n, err
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
 func testBuilder(t *testing.T, when spec.G, it spec.S) {
 	var (
 		baseImage      *fakes.Image
@@ -522,6 +599,10 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 						subject.AddBuildpack(bp1v1)
 					})
 
+					it("lists the buildpack once", func() {
+						h.AssertEq(t, len(subject.Buildpacks()), 1)
+					})
+
 					when("order omits version", func() {
 						it("should de-duplicate identical buildpacks", func() {
 							subject.SetOrder(dist.Order{
@@ -1003,6 +1084,104 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("#SetLauncher", func() {
+			var tmpDir string
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "launcher")
+				h.AssertNil(t, err)
+
+				launcherPath := filepath.Join(tmpDir, "launcher")
+				h.AssertNil(t, os.WriteFile(launcherPath, []byte("patched-launcher"), 0755))
+
+				subject.SetLauncher(blob.NewBlob(launcherPath), builder.LauncherMetadata{URI: "some/launcher"})
+				h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+				h.AssertEq(t, baseImage.IsSaved(), true)
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("replaces the launcher of the lifecycle", func() {
+				layerTar, err := baseImage.FindLayerWithPath("/cnb/lifecycle")
+				h.AssertNil(t, err)
+
+				h.AssertOnTarEntry(t, layerTar, "/cnb/lifecycle/launcher",
+					h.ContentEquals("patched-launcher"),
+					h.HasFileMode(0755),
+					h.HasModTime(archive.NormalizedDateTime),
+				)
+				h.AssertOnTarEntry(t, layerTar, "/cnb/lifecycle/detector",
+					h.ContentEquals("detector"),
+				)
+			})
+
+			it("sets the launcher on the metadata", func() {
+				label, err := baseImage.Label("io.buildpacks.builder.metadata")
+				h.AssertNil(t, err)
+
+				var metadata builder.Metadata
+				h.AssertNil(t, json.Unmarshal([]byte(label), &metadata))
+				h.AssertNotNil(t, metadata.Launcher)
+				h.AssertEq(t, metadata.Launcher.URI, "some/launcher")
+				h.AssertEq(t, metadata.Launcher.SHA256, fmt.Sprintf("%x", sha256.Sum256([]byte("patched-launcher"))))
+				h.AssertEq(t, subject.Launcher(), metadata.Launcher)
+			})
+		})
+
+		when("#WithMemoryLimit", func() {
+			it("writes launchers larger than the limit to the layer", func() {
+				launcherContents := strings.Repeat("patched-launcher", 1024)
+				launcherPath := filepath.Join(t.TempDir(), "launcher")
+				h.AssertNil(t, os.WriteFile(launcherPath, []byte(launcherContents), 0755))
+
+				subject, err := builder.New(baseImage, "some/builder", builder.WithMemoryLimit(1024))
+				h.AssertNil(t, err)
+				subject.SetLifecycle(mockLifecycle)
+				subject.SetLauncher(blob.NewBlob(launcherPath), builder.LauncherMetadata{URI: "some/launcher"})
+				h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+
+				layerTar, err := baseImage.FindLayerWithPath("/cnb/lifecycle")
+				h.AssertNil(t, err)
+				h.AssertOnTarEntry(t, layerTar, "/cnb/lifecycle/launcher", h.ContentEquals(launcherContents))
+				h.AssertEq(t, subject.Launcher().SHA256, fmt.Sprintf("%x", sha256.Sum256([]byte(launcherContents))))
+			})
+
+			it("fails on limits that aren't positive", func() {
+				_, err := builder.New(baseImage, "some/builder", builder.WithMemoryLimit(0))
+				h.AssertError(t, err, "memory limit must be positive")
+			})
+		})
+
+		when("#LauncherFromLifecycle", func() {
+			it("returns the launcher of the lifecycle", func() {
+				launcher := builder.LauncherFromLifecycle(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")))
+
+				rc, err := launcher.Open()
+				h.AssertNil(t, err)
+				defer rc.Close()
+
+				contents, err := io.ReadAll(rc)
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(contents), "launcher")
+			})
+
+			it("errors when the lifecycle has no launcher", func() {
+				tmpDir, err := os.MkdirTemp("", "lifecycle")
+				h.AssertNil(t, err)
+				defer os.RemoveAll(tmpDir)
+
+				h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "lifecycle"), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "lifecycle", "detector"), []byte("detector"), 0755))
+				launcher := builder.LauncherFromLifecycle(blob.NewBlob(tmpDir))
+
+				_, err = launcher.Open()
+				h.AssertError(t, err, "could not find the launcher in the lifecycle")
+			})
+		})
+
 		when("#AddBuildpack", func() {
 			it.Before(func() {
 				subject.AddBuildpack(bp1v1)
@@ -57,6 +57,16 @@ func (a APISet) Latest() *api.Version {
 	return a.search(func(prevMatch, value *api.Version) bool { return value.Compare(prevMatch) > 0 })
 }
 
+// Contains returns true when the set has the version
+func (a APISet) Contains(version *api.Version) bool {
+	for _, v := range a {
+		if v != nil && version != nil && v.Equal(version) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a APISet) AsStrings() []string {
 	verStrings := make([]string, len(a))
 	for i, version := range a {
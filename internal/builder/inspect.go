@@ -21,6 +21,7 @@ type Info struct {
 	BuildpackLayers dist.ModuleLayers
 	Lifecycle       LifecycleDescriptor
 	CreatedBy       CreatorMetadata
+	Launcher        *LauncherMetadata
 	Extensions      []dist.ModuleInfo
 	OrderExtensions pubbldr.DetectionOrder
 }
@@ -152,6 +153,7 @@ func (i *Inspector) Inspect(name string, daemon bool, orderDetectionDepth int) (
 		BuildpackLayers: layers,
 		Lifecycle:       lifecycle,
 		CreatedBy:       metadata.CreatedBy,
+		Launcher:        metadata.Launcher,
 		Extensions:      metadata.Extensions,
 		OrderExtensions: detectionOrderExtensions,
 	}, nil
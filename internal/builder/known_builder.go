@@ -1,11 +1,11 @@
 package builder
 
 type KnownBuilder struct {
-	Vendor             string
-	Image              string
-	DefaultDescription string
-	Suggested          bool
-	Trusted            bool
+	Vendor             string `json:"vendor"`
+	Image              string `json:"image"`
+	DefaultDescription string `json:"description"`
+	Suggested          bool   `json:"-"`
+	Trusted            bool   `json:"-"`
 }
 
 var KnownBuilders = []KnownBuilder{
@@ -19,7 +19,7 @@ func NewLabelManager(inspectable Inspectable) *LabelManager {
 
 func (m *LabelManager) Metadata() (Metadata, error) {
 	var parsedMetadata Metadata
-	err := m.labelJSON(metadataLabel, &parsedMetadata)
+	err := m.labelJSON(MetadataLabel, &parsedMetadata)
 	return parsedMetadata, err
 }
 
@@ -0,0 +1,100 @@
+package builder
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+var launcherEntryRegexp = regexp.MustCompile(`^[^/]+/launcher(\.exe)?$`)
+
+// LauncherFromLifecycle returns the launcher binary of a lifecycle archive, such as to use the launcher of a lifecycle
+// version other than the one of the builder.
+func LauncherFromLifecycle(lifecycle Blob) Blob {
+	return &lifecycleLauncher{lifecycle: lifecycle}
+}
+
+type lifecycleLauncher struct {
+	lifecycle Blob
+}
+
+// Open returns the contents of the launcher binary of the lifecycle
+func (l *lifecycleLauncher) Open() (io.ReadCloser, error) {
+	rc, err := l.lifecycle.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "open lifecycle blob")
+	}
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rc.Close()
+			return nil, errors.Wrap(err, "failed to get next tar entry")
+		}
+		if launcherEntryRegexp.MatchString(path.Clean(header.Name)) {
+			return ioutils.NewReadCloserWrapper(tr, rc.Close), nil
+		}
+	}
+
+	rc.Close()
+	return nil, errors.Wrap(archive.ErrEntryNotExist, "could not find the launcher in the lifecycle")
+}
+
+// SetLauncher replaces the launcher of the lifecycle of the builder with the given binary
+func (b *Builder) SetLauncher(launcher Blob, metadata LauncherMetadata) {
+	b.launcher = launcher
+	b.launcherMetadata = metadata
+}
+
+// Launcher returns the metadata of the launcher replacing the launcher of the lifecycle of the builder, if any
+func (b *Builder) Launcher() *LauncherMetadata {
+	return b.metadata.Launcher
+}
+
+// embedLauncher writes the launcher of the builder in place of the launcher of the lifecycle, with the header of the
+// launcher of the lifecycle, and records its digest in the builder metadata
+func (b *Builder) embedLauncher(tw archive.TarWriter, header *tar.Header, scratchDir string) error {
+	// the launcher is read once, to size and digest it, and buffered up to the memory limit of the builder to be
+	// written to the layer, as reading the launcher of a lifecycle reads the lifecycle archive again
+	rc, err := b.launcher.Open()
+	if err != nil {
+		return errors.Wrap(err, "open launcher")
+	}
+	defer rc.Close()
+
+	buf := newSpillBuffer(scratchDir, b.memoryLimit)
+	defer buf.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(hasher, buf), rc); err != nil {
+		return errors.Wrap(err, "reading launcher")
+	}
+	contents, err := buf.Reader()
+	if err != nil {
+		return errors.Wrap(err, "reading launcher")
+	}
+
+	header.Size = buf.Size()
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed to write header for '%s'", header.Name)
+	}
+	if _, err := io.Copy(tw, contents); err != nil {
+		return errors.Wrapf(err, "failed to write contents to '%s'", header.Name)
+	}
+
+	metadata := b.launcherMetadata
+	metadata.SHA256 = fmt.Sprintf("%x", hasher.Sum(nil))
+	b.metadata.Launcher = &metadata
+	return nil
+}
@@ -13,6 +13,7 @@ type Metadata struct {
 	Extensions  []dist.ModuleInfo  `json:"extensions"`
 	Stack       StackMetadata      `json:"stack"`
 	Lifecycle   LifecycleMetadata  `json:"lifecycle"`
+	Launcher    *LauncherMetadata  `json:"launcher,omitempty"`
 	CreatedBy   CreatorMetadata    `json:"createdBy"`
 	RunImages   []RunImageMetadata `json:"images"`
 }
@@ -29,6 +30,18 @@ type LifecycleMetadata struct {
 	APIs LifecycleAPIs `json:"apis"`
 }
 
+// LauncherMetadata describes a launcher replacing the launcher of the lifecycle of a builder
+type LauncherMetadata struct {
+	// Version of the lifecycle the launcher comes from, when it comes from a lifecycle release.
+	Version string `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+
+	// URI of the launcher binary, when it doesn't come from a lifecycle release.
+	URI string `json:"uri,omitempty" yaml:"uri,omitempty" toml:"uri,omitempty"`
+
+	// Digest of the launcher binary.
+	SHA256 string `json:"sha256" yaml:"sha256" toml:"sha256"`
+}
+
 type StackMetadata struct {
 	RunImage RunImageMetadata `json:"runImage" toml:"run-image"`
 }
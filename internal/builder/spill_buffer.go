@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMemoryLimit is the size of the contents the builder keeps in memory while creating its layers, unless
+// WithMemoryLimit sets another limit. Contents beyond it are written to temporary files.
+const DefaultMemoryLimit = 32 << 20
+
+// spillBuffer buffers contents in memory up to its limit, and in a temporary file of its directory beyond that, so
+// that contents of any size can be read again without running out of memory.
+type spillBuffer struct {
+	dir   string
+	limit int64
+	mem   bytes.Buffer
+	file  *os.File
+	size  int64
+}
+
+func newSpillBuffer(dir string, limit int64) *spillBuffer {
+	return &spillBuffer{dir: dir, limit: limit}
+}
+
+func (s *spillBuffer) Write(p []byte) (int, error) {
+	if s.file == nil && int64(s.mem.Len()+len(p)) > s.limit {
+		file, err := os.CreateTemp(s.dir, "spill")
+		if err != nil {
+			return 0, errors.Wrap(err, "creating temporary file")
+		}
+		s.file = file
+		if _, err := s.mem.WriteTo(s.file); err != nil {
+			return 0, errors.Wrap(err, "writing temporary file")
+		}
+	}
+
+	var (
+		n   int
+		err error
+	)
+	if s.file != nil {
+		n, err = s.file.Write(p)
+	} else {
+		n, err = s.mem.Write(p)
+	}
+	s.size += int64(n)
+	return n, err
+}
+
+// Size returns the size of the contents written to the buffer
+func (s *spillBuffer) Size() int64 {
+	return s.size
+}
+
+// Reader returns a reader of the contents written to the buffer
+func (s *spillBuffer) Reader() (io.Reader, error) {
+	if s.file == nil {
+		return bytes.NewReader(s.mem.Bytes()), nil
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "reading temporary file")
+	}
+	return s.file, nil
+}
+
+// Close removes the temporary file of the buffer, if any
+func (s *spillBuffer) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	s.file.Close()
+	return os.Remove(s.file.Name())
+}
@@ -0,0 +1,218 @@
+package builder
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// SignatureSuffix is appended to the suggested builders metadata URL to locate its detached signature
+	SignatureSuffix = ".sig"
+
+	// DefaultSuggestedBuildersTTL is how long fetched suggested builders metadata is considered fresh
+	DefaultSuggestedBuildersTTL = 24 * time.Hour
+
+	// DefaultSuggestedBuildersTimeout is how long fetching suggested builders metadata may take, before falling back to
+	// the cached or built-in suggestions
+	DefaultSuggestedBuildersTimeout = 5 * time.Second
+
+	suggestedBuildersCacheDir = "suggested-builders"
+)
+
+// SuggestedBuildersMetadata is the document served by a suggested builders metadata source
+type SuggestedBuildersMetadata struct {
+	Builders []KnownBuilder `json:"builders"`
+}
+
+// SuggestedBuildersSource fetches, verifies and caches a signed suggested builders metadata document.
+//
+// The document is fetched from URL and must be accompanied by a detached, base64 encoded ed25519
+// signature located at URL + SignatureSuffix, which is verified against PublicKey.
+type SuggestedBuildersSource struct {
+	URL       string
+	PublicKey string
+	CacheDir  string
+	TTL       time.Duration
+	Client    *http.Client
+}
+
+// NewSuggestedBuildersSource creates a source that caches metadata under packHome
+func NewSuggestedBuildersSource(url, publicKey, packHome string) *SuggestedBuildersSource {
+	return &SuggestedBuildersSource{
+		URL:       url,
+		PublicKey: publicKey,
+		CacheDir:  filepath.Join(packHome, suggestedBuildersCacheDir),
+		TTL:       DefaultSuggestedBuildersTTL,
+		Client:    &http.Client{Timeout: DefaultSuggestedBuildersTimeout},
+	}
+}
+
+// Builders returns the suggested builders described by the source. Cached metadata is used while it is
+// fresh, unless refresh is true. When fetching fails, stale cached metadata is returned along with the error.
+func (s *SuggestedBuildersSource) Builders(refresh bool) ([]KnownBuilder, error) {
+	if !refresh {
+		if builders, fresh, err := s.readCache(); err == nil && fresh {
+			return builders, nil
+		}
+	}
+
+	data, signature, err := s.fetch()
+	if err == nil {
+		var builders []KnownBuilder
+		if builders, err = s.verifyAndParse(data, signature); err == nil {
+			if err := s.writeCache(data, signature); err != nil {
+				return builders, errors.Wrap(err, "caching suggested builders")
+			}
+			return builders, nil
+		}
+	}
+
+	if cached, _, cacheErr := s.readCache(); cacheErr == nil {
+		return cached, errors.Wrap(err, "refreshing suggested builders, using cached copy")
+	}
+	return nil, err
+}
+
+func (s *SuggestedBuildersSource) fetch() ([]byte, []byte, error) {
+	data, err := s.get(s.URL)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fetching suggested builders from %s", style.Symbol(s.URL))
+	}
+
+	signature, err := s.get(s.URL + SignatureSuffix)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fetching suggested builders signature from %s", style.Symbol(s.URL+SignatureSuffix))
+	}
+
+	return data, signature, nil
+}
+
+func (s *SuggestedBuildersSource) get(url string) ([]byte, error) {
+	httpClient := s.Client
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultSuggestedBuildersTimeout}
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch %s: status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *SuggestedBuildersSource) verifyAndParse(data, signature []byte) ([]KnownBuilder, error) {
+	if err := VerifySuggestedBuilders(data, signature, s.PublicKey); err != nil {
+		return nil, err
+	}
+	return ParseSuggestedBuilders(data)
+}
+
+func (s *SuggestedBuildersSource) cachePaths() (string, string) {
+	key := sha256.Sum256([]byte(s.URL))
+	base := filepath.Join(s.CacheDir, hex.EncodeToString(key[:]))
+	return base + ".json", base + ".json" + SignatureSuffix
+}
+
+func (s *SuggestedBuildersSource) readCache() ([]KnownBuilder, bool, error) {
+	dataPath, sigPath := s.cachePaths()
+
+	info, err := os.Stat(dataPath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(dataPath))
+	if err != nil {
+		return nil, false, err
+	}
+
+	signature, err := os.ReadFile(filepath.Clean(sigPath))
+	if err != nil {
+		return nil, false, err
+	}
+
+	// cached metadata is verified again so that tampering with the cache has no effect
+	builders, err := s.verifyAndParse(data, signature)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return builders, time.Since(info.ModTime()) < s.TTL, nil
+}
+
+func (s *SuggestedBuildersSource) writeCache(data, signature []byte) error {
+	if err := os.MkdirAll(s.CacheDir, 0750); err != nil {
+		return err
+	}
+
+	dataPath, sigPath := s.cachePaths()
+	if err := os.WriteFile(sigPath, signature, 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath, data, 0600)
+}
+
+// VerifySuggestedBuilders checks that signature is a valid, base64 encoded ed25519 signature of data
+// made by the private key matching the base64 encoded publicKey
+func VerifySuggestedBuilders(data, signature []byte, publicKey string) error {
+	if publicKey == "" {
+		return errors.New("a public key is required to verify suggested builders metadata")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return errors.Wrap(err, "decoding public key")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return errors.Errorf("invalid public key size %d, expected %d", len(key), ed25519.PublicKeySize)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrap(err, "decoding signature")
+	}
+
+	if !ed25519.Verify(key, data, sig) {
+		return errors.New("suggested builders metadata signature is invalid")
+	}
+	return nil
+}
+
+// ParseSuggestedBuilders parses a suggested builders metadata document, marking every builder as suggested.
+// Builders from a metadata document are never trusted by default.
+func ParseSuggestedBuilders(data []byte) ([]KnownBuilder, error) {
+	var metadata SuggestedBuildersMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, errors.Wrap(err, "parsing suggested builders metadata")
+	}
+
+	var builders []KnownBuilder
+	for _, b := range metadata.Builders {
+		if b.Image == "" {
+			return nil, errors.New("suggested builders metadata contains a builder without an image")
+		}
+		b.Suggested = true
+		b.Trusted = false
+		builders = append(builders, b)
+	}
+	return builders, nil
+}
@@ -0,0 +1,158 @@
+package builder_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSuggestedBuilders(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "testSuggestedBuilders", testSuggestedBuilders, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSuggestedBuilders(t *testing.T, when spec.G, it spec.S) {
+	const metadata = `{"builders": [{"vendor": "Some Vendor", "image": "some/builder", "description": "Some description"}]}`
+
+	var (
+		publicKey  string
+		privateKey ed25519.PrivateKey
+		signature  string
+		server     *httptest.Server
+		requests   int
+		packHome   string
+		subject    *builder.SuggestedBuildersSource
+	)
+
+	it.Before(func() {
+		var (
+			pub ed25519.PublicKey
+			err error
+		)
+		pub, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		h.AssertNil(t, err)
+		publicKey = base64.StdEncoding.EncodeToString(pub)
+		signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(metadata)))
+
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			switch r.URL.Path {
+			case "/builders.json":
+				w.Write([]byte(metadata))
+			case "/builders.json.sig":
+				w.Write([]byte(signature))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		packHome, err = os.MkdirTemp("", "suggested-builders")
+		h.AssertNil(t, err)
+
+		subject = builder.NewSuggestedBuildersSource(server.URL+"/builders.json", publicKey, packHome)
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNil(t, os.RemoveAll(packHome))
+	})
+
+	when("#Builders", func() {
+		it("returns verified builders as suggested but untrusted", func() {
+			builders, err := subject.Builders(false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, builders, []builder.KnownBuilder{{
+				Vendor:             "Some Vendor",
+				Image:              "some/builder",
+				DefaultDescription: "Some description",
+				Suggested:          true,
+				Trusted:            false,
+			}})
+		})
+
+		it("uses the cache while it is fresh", func() {
+			_, err := subject.Builders(false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, requests, 2)
+
+			_, err = subject.Builders(false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, requests, 2)
+		})
+
+		it("fetches again when refresh is requested", func() {
+			_, err := subject.Builders(false)
+			h.AssertNil(t, err)
+
+			_, err = subject.Builders(true)
+			h.AssertNil(t, err)
+			h.AssertEq(t, requests, 4)
+		})
+
+		it("falls back to the cache when the source is unavailable", func() {
+			_, err := subject.Builders(false)
+			h.AssertNil(t, err)
+			server.Close()
+
+			builders, err := subject.Builders(true)
+			h.AssertError(t, err, "using cached copy")
+			h.AssertEq(t, len(builders), 1)
+		})
+
+		it("gives up on a source that doesn't respond in time", func() {
+			h.AssertEq(t, subject.Client.Timeout, builder.DefaultSuggestedBuildersTimeout)
+
+			unblock := make(chan struct{})
+			slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-unblock
+			}))
+			defer slowServer.Close()
+			defer close(unblock)
+			slowSubject := builder.NewSuggestedBuildersSource(slowServer.URL+"/builders.json", publicKey, packHome)
+			slowSubject.Client.Timeout = 10 * time.Millisecond
+
+			_, err := slowSubject.Builders(true)
+			h.AssertError(t, err, "Client.Timeout exceeded")
+		})
+
+		it("rejects metadata with an invalid signature", func() {
+			signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte("something else")))
+
+			_, err := subject.Builders(false)
+			h.AssertError(t, err, "signature is invalid")
+		})
+	})
+
+	when("#VerifySuggestedBuilders", func() {
+		it("requires a public key", func() {
+			err := builder.VerifySuggestedBuilders([]byte(metadata), []byte(signature), "")
+			h.AssertError(t, err, "a public key is required")
+		})
+
+		it("rejects malformed public keys", func() {
+			err := builder.VerifySuggestedBuilders([]byte(metadata), []byte(signature), base64.StdEncoding.EncodeToString([]byte("short")))
+			h.AssertError(t, err, "invalid public key size")
+		})
+	})
+
+	when("#ParseSuggestedBuilders", func() {
+		it("errors when a builder has no image", func() {
+			_, err := builder.ParseSuggestedBuilders([]byte(`{"builders": [{"vendor": "Some Vendor"}]}`))
+			h.AssertError(t, err, "without an image")
+		})
+	})
+}
@@ -58,6 +58,9 @@ Trusted: {{.Trusted}}
 {{- end }}
 {{- end }}
 {{ .Lifecycle }}
+{{- if ne .Launcher "" }}
+{{ .Launcher }}
+{{- end }}
 {{ .RunImages }}
 {{ .Buildpacks }}
 {{ .Order }}
@@ -125,7 +128,7 @@ func writeBuilderInfo(
 
 	var warnings []string
 
-	runImagesString, runImagesWarnings, err := runImagesOutput(info.RunImages, localRunImages, sharedInfo.Name)
+	runImagesString, runImagesWarnings, err := runImagesOutput(info.RunImages, localRunImages, info.RunImageChecks, sharedInfo.Name)
 	if err != nil {
 		return fmt.Errorf("compiling run images output: %w", err)
 	}
@@ -148,6 +151,7 @@ func writeBuilderInfo(
 		return fmt.Errorf("compiling buildpacks output: %w", err)
 	}
 	lifecycleString, lifecycleWarnings := lifecycleOutput(info.Lifecycle, sharedInfo.Name)
+	launcherString := launcherOutput(info.Launcher)
 
 	var extensionsString string
 	var extensionsWarnings []string
@@ -179,6 +183,7 @@ func writeBuilderInfo(
 			Order           string
 			Trusted         string
 			Lifecycle       string
+			Launcher        string
 			Extensions      string
 			OrderExtensions string
 		}{
@@ -189,6 +194,7 @@ func writeBuilderInfo(
 			orderString,
 			stringFromBool(sharedInfo.Trusted),
 			lifecycleString,
+			launcherString,
 			extensionsString,
 			orderExtString,
 		},
@@ -242,17 +248,31 @@ func stringFromBool(subject bool) string {
 	return "No"
 }
 
+func stringOrNone(subject string) string {
+	if subject == "" {
+		return none
+	}
+
+	return subject
+}
+
 func runImagesOutput(
 	runImages []pubbldr.RunImageConfig,
 	localRunImages []config.RunImage,
+	runImageChecks []client.RunImageCheck,
 	builderName string,
 ) (string, []string, error) {
 	output := "Run Images:\n"
 
 	tabWriterBuf := bytes.Buffer{}
 
+	checks := map[string]client.RunImageCheck{}
+	for _, check := range runImageChecks {
+		checks[check.Image] = check
+	}
+
 	localMirrorTabWriter := tabwriter.NewWriter(&tabWriterBuf, writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
-	err := writeLocalMirrors(localMirrorTabWriter, runImages, localRunImages)
+	err := writeLocalMirrors(localMirrorTabWriter, runImages, localRunImages, checks)
 	if err != nil {
 		return "", []string{}, fmt.Errorf("writing local mirrors: %w", err)
 	}
@@ -268,13 +288,13 @@ func runImagesOutput(
 	} else {
 		for _, runImage := range runImages {
 			if runImage.Image != "" {
-				_, err = fmt.Fprintf(localMirrorTabWriter, "  %s\n", runImage.Image)
+				_, err = fmt.Fprintf(localMirrorTabWriter, "  %s%s\n", runImage.Image, runImageCheckOutput(checks, runImage.Image, "\t"))
 				if err != nil {
 					return "", []string{}, fmt.Errorf("writing to tabwriter: %w", err)
 				}
 			}
 			for _, m := range runImage.Mirrors {
-				_, err = fmt.Fprintf(localMirrorTabWriter, "  %s\n", m)
+				_, err = fmt.Fprintf(localMirrorTabWriter, "  %s%s\n", m, runImageCheckOutput(checks, m, "\t"))
 				if err != nil {
 					return "", []string{}, fmt.Errorf("writing to tab writer: %w", err)
 				}
@@ -292,15 +312,24 @@ func runImagesOutput(
 
 	output += runImageOutput
 
+	for _, check := range runImageChecks {
+		switch check.Status {
+		case client.RunImageUnreachable:
+			warnings = append(warnings, fmt.Sprintf("%s is unreachable: %s", style.Symbol(check.Image), check.Error))
+		case client.RunImageStale:
+			warnings = append(warnings, fmt.Sprintf("%s is stale, it does not hold the same image as %s", style.Symbol(check.Image), style.Symbol(check.RunImage)))
+		}
+	}
+
 	return output, warnings, nil
 }
 
-func writeLocalMirrors(logWriter io.Writer, runImages []pubbldr.RunImageConfig, localRunImages []config.RunImage) error {
+func writeLocalMirrors(logWriter io.Writer, runImages []pubbldr.RunImageConfig, localRunImages []config.RunImage, checks map[string]client.RunImageCheck) error {
 	for _, i := range localRunImages {
 		for _, ri := range runImages {
 			if i.Image == ri.Image {
 				for _, m := range i.Mirrors {
-					_, err := fmt.Fprintf(logWriter, "  %s\t(user-configured)\n", m)
+					_, err := fmt.Fprintf(logWriter, "  %s\t(user-configured)%s\n", m, runImageCheckOutput(checks, m, ""))
 					if err != nil {
 						return fmt.Errorf("writing local mirror: %s: %w", m, err)
 					}
@@ -312,6 +341,19 @@ func writeLocalMirrors(logWriter io.Writer, runImages []pubbldr.RunImageConfig,
 	return nil
 }
 
+// runImageCheckOutput returns the column of the status of a run image, when it was checked, after the given separator
+// of the column of user-configured mirrors
+func runImageCheckOutput(checks map[string]client.RunImageCheck, imageName, separator string) string {
+	check, ok := checks[imageName]
+	if !ok {
+		return ""
+	}
+	if check.Digest == "" {
+		return fmt.Sprintf("%s\t%s", separator, check.Status)
+	}
+	return fmt.Sprintf("%s\t%s %s", separator, check.Status, check.Digest)
+}
+
 func extensionsOutput(extensions []dist.ModuleInfo, builderName string) (string, []string, error) {
 	output := "Extensions:\n"
 
@@ -400,6 +442,21 @@ Lifecycle:
     Supported: %s
 `
 
+const launcherFormat = `
+Launcher:
+  Version: %s
+  URI: %s
+  SHA256: %s
+`
+
+func launcherOutput(launcher *builder.LauncherMetadata) string {
+	if launcher == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(launcherFormat, stringOrNone(launcher.Version), stringOrNone(launcher.URI), stringOrNone(launcher.SHA256))
+}
+
 func lifecycleOutput(lifecycleInfo builder.LifecycleDescriptor, builderName string) (string, []string) {
 	var warnings []string
 
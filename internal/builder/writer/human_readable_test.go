@@ -512,6 +512,37 @@ REMOTE:
 			})
 		})
 
+		when("the launcher was replaced", func() {
+			it("prints the launcher block", func() {
+				remoteInfo.Launcher = &builder.LauncherMetadata{Version: "0.20.1", SHA256: "some-sha256"}
+
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), `
+Launcher:
+  Version: 0.20.1
+  URI: (none)
+  SHA256: some-sha256
+`)
+			})
+		})
+
+		when("the launcher wasn't replaced", func() {
+			it("doesn't print the launcher block", func() {
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.NotContains(outBuf.String(), "Launcher:")
+			})
+		})
+
 		when("logger is verbose", func() {
 			it("displays mixins associated with the stack", func() {
 				humanReadableWriter := writer.NewHumanReadable()
@@ -588,6 +619,35 @@ REMOTE:
 			})
 		})
 
+		when("run images were checked", func() {
+			it("displays the status of each run image and warns about the unreachable and stale mirrors", func() {
+				localInfo = nil
+				remoteInfo.RunImageChecks = []client.RunImageCheck{
+					{Image: "first/local", RunImage: "some/run-image", Digest: "sha256:run", Status: client.RunImageAvailable},
+					{Image: "second/local", RunImage: "some/run-image", Digest: "sha256:run", Status: client.RunImageAvailable},
+					{Image: "some/run-image", RunImage: "some/run-image", Digest: "sha256:run", Status: client.RunImageAvailable},
+					{Image: "first/default", RunImage: "some/run-image", Digest: "sha256:old", Status: client.RunImageStale},
+					{Image: "second/default", RunImage: "some/run-image", Status: client.RunImageUnreachable, Error: "some error"},
+				}
+
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), `Run Images:
+  first/local       (user-configured)    available sha256:run
+  second/local      (user-configured)    available sha256:run
+  some/run-image                         available sha256:run
+  first/default                          stale sha256:old
+  second/default                         unreachable
+`)
+				assert.Contains(outBuf.String(), "Warning: 'first/default' is stale, it does not hold the same image as 'some/run-image'")
+				assert.Contains(outBuf.String(), "Warning: 'second/default' is unreachable: some error")
+			})
+		})
+
 		when("no buildpacks are specified", func() {
 			it("displays buildpacks as (none) and prints warnings", func() {
 				localInfo.Buildpacks = []dist.ModuleInfo{}
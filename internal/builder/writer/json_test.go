@@ -465,6 +465,31 @@ func testJSON(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("run images were checked", func() {
+			it("displays the status of each run image", func() {
+				localInfo.RunImages = []pubbldr.RunImageConfig{}
+				remoteInfo.RunImages = []pubbldr.RunImageConfig{{Image: "some/run-image", Mirrors: []string{"first/default"}}}
+				remoteInfo.RunImageChecks = []client.RunImageCheck{
+					{Image: "some/run-image", RunImage: "some/run-image", Digest: "sha256:run", Status: client.RunImageAvailable},
+					{Image: "first/default", RunImage: "some/run-image", Status: client.RunImageUnreachable, Error: "some error"},
+				}
+
+				jsonWriter := writer.NewJSON()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := jsonWriter.Print(logger, []config.RunImage{}, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				prettifiedJSON, err := validPrettifiedJSONOutput(outBuf)
+				assert.Nil(err)
+
+				assert.ContainsJSON(prettifiedJSON, `{"run_images": [
+  {"name": "some/run-image", "status": "available", "digest": "sha256:run"},
+  {"name": "first/default", "status": "unreachable", "error": "some error"}
+]}`)
+			})
+		})
+
 		when("no buildpacks are specified", func() {
 			it("displays buildpacks as empty list", func() {
 				localInfo.Buildpacks = []dist.ModuleInfo{}
@@ -22,6 +22,9 @@ type InspectOutput struct {
 type RunImage struct {
 	Name           string `json:"name" yaml:"name" toml:"name"`
 	UserConfigured bool   `json:"user_configured,omitempty" yaml:"user_configured,omitempty" toml:"user_configured,omitempty"`
+	Status         string `json:"status,omitempty" yaml:"status,omitempty" toml:"status,omitempty"`
+	Digest         string `json:"digest,omitempty" yaml:"digest,omitempty" toml:"digest,omitempty"`
+	Error          string `json:"error,omitempty" yaml:"error,omitempty" toml:"error,omitempty"`
 }
 
 type Lifecycle struct {
@@ -36,12 +39,13 @@ type Stack struct {
 }
 
 type BuilderInfo struct {
-	Description            string                  `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
-	CreatedBy              builder.CreatorMetadata `json:"created_by" yaml:"created_by" toml:"created_by"`
-	Stack                  *Stack                  `json:"stack,omitempty" yaml:"stack,omitempty" toml:"stack,omitempty"`
-	Lifecycle              Lifecycle               `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
-	RunImages              []RunImage              `json:"run_images" yaml:"run_images" toml:"run_images"`
-	Buildpacks             []dist.ModuleInfo       `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	Description            string                    `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
+	CreatedBy              builder.CreatorMetadata   `json:"created_by" yaml:"created_by" toml:"created_by"`
+	Stack                  *Stack                    `json:"stack,omitempty" yaml:"stack,omitempty" toml:"stack,omitempty"`
+	Lifecycle              Lifecycle                 `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
+	Launcher               *builder.LauncherMetadata `json:"launcher,omitempty" yaml:"launcher,omitempty" toml:"launcher,omitempty"`
+	RunImages              []RunImage                `json:"run_images" yaml:"run_images" toml:"run_images"`
+	Buildpacks             []dist.ModuleInfo         `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
 	pubbldr.DetectionOrder `json:"detection_order" yaml:"detection_order" toml:"detection_order"`
 	Extensions             []dist.ModuleInfo      `json:"extensions,omitempty" yaml:"extensions,omitempty" toml:"extensions,omitempty"`
 	OrderExtensions        pubbldr.DetectionOrder `json:"order_extensions,omitempty" yaml:"order_extensions,omitempty" toml:"order_extensions,omitempty"`
@@ -87,7 +91,8 @@ func (w *StructuredFormat) Print(
 				BuildpackAPIs: local.Lifecycle.APIs.Buildpack,
 				PlatformAPIs:  local.Lifecycle.APIs.Platform,
 			},
-			RunImages:       runImages(local.RunImages, localRunImages),
+			Launcher:        local.Launcher,
+			RunImages:       runImages(local.RunImages, localRunImages, local.RunImageChecks),
 			Buildpacks:      local.Buildpacks,
 			DetectionOrder:  local.Order,
 			Extensions:      local.Extensions,
@@ -114,7 +119,8 @@ func (w *StructuredFormat) Print(
 				BuildpackAPIs: remote.Lifecycle.APIs.Buildpack,
 				PlatformAPIs:  remote.Lifecycle.APIs.Platform,
 			},
-			RunImages:       runImages(remote.RunImages, localRunImages),
+			Launcher:        remote.Launcher,
+			RunImages:       runImages(remote.RunImages, localRunImages, remote.RunImageChecks),
 			Buildpacks:      remote.Buildpacks,
 			DetectionOrder:  remote.Order,
 			Extensions:      remote.Extensions,
@@ -139,7 +145,7 @@ func (w *StructuredFormat) Print(
 	return nil
 }
 
-func runImages(runImages []pubbldr.RunImageConfig, localRunImages []config.RunImage) []RunImage {
+func runImages(runImages []pubbldr.RunImageConfig, localRunImages []config.RunImage, runImageChecks []client.RunImageCheck) []RunImage {
 	images := []RunImage{}
 
 	for _, i := range localRunImages {
@@ -159,5 +165,15 @@ func runImages(runImages []pubbldr.RunImageConfig, localRunImages []config.RunIm
 		}
 	}
 
+	for i := range images {
+		for _, check := range runImageChecks {
+			if check.Image == images[i].Name {
+				images[i].Status = check.Status
+				images[i].Digest = check.Digest
+				images[i].Error = check.Error
+			}
+		}
+	}
+
 	return images
 }
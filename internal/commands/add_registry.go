@@ -23,7 +23,9 @@ func AddBuildpackRegistry(logger logging.Logger, cfg config.Config, cfgPath stri
 		Long: "A Buildpack Registry is a (still experimental) place to publish, store, and discover buildpacks. " +
 			"Users can add buildpacks registries using add-registry, and publish/yank buildpacks from it, as well as use those buildpacks when building applications.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "add-registry", "config registries add")
+			if err := deprecationWarning(logger, "add-registry", "config registries add"); err != nil {
+				return err
+			}
 			newRegistry := config.Registry{
 				Name: args[0],
 				URL:  args[1],
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -9,13 +10,18 @@ import (
 
 	"github.com/buildpacks/pack/pkg/cache"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/git"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/target"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project"
@@ -32,6 +38,7 @@ type BuildFlags struct {
 	CacheImage           string
 	Cache                cache.CacheOpts
 	AppPath              string
+	Git                  string
 	Builder              string
 	Registry             string
 	RunImage             string
@@ -40,11 +47,20 @@ type BuildFlags struct {
 	DescriptorPath       string
 	DefaultProcessType   string
 	LifecycleImage       string
+	LifecycleImageAuth   string
+	LifecycleDigests     []string
 	Env                  []string
 	EnvFiles             []string
+	EnvFor               []string
 	Buildpacks           []string
 	Extensions           []string
 	Volumes              []string
+	UsernsMode           string
+	SecurityOpts         []string
+	CapAdd               []string
+	CapDrop              []string
+	ReadOnlyRootfs       bool
+	ScratchDirs          []string
 	AdditionalTags       []string
 	Workspace            string
 	GID                  int
@@ -52,9 +68,32 @@ type BuildFlags struct {
 	PreviousImage        string
 	SBOMDestinationDir   string
 	ReportDestinationDir string
+	ArtifactsDir         string
 	DateTime             string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
+	Annotations          map[string]string
+	Scanner              string
+	ScannerImage         string
+	ScanFailOn           string
+	Attest               bool
+	AttestationKey       string
+	MaxConcurrency       int
+	KeepAlive            bool
+	LayoutBindStrategy   string
+	InsecureRegistries   []string
+	Output               string
+	Bindings             map[string]string
+	CacheReport          bool
+	FailIfLocked         bool
+	RebuildTriggers      bool
+	CompatMode           string
+	RawOutput            bool
+	Platform             string
+	DebugSnapshot        bool
+	ExportPlan           string
+	Plan                 string
+	LifecycleArgs        []string
 }
 
 // Build an image from source code
@@ -77,6 +116,15 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			if flags.Git != "" {
+				appDir, err := cloneAppSource(cmd.Context(), flags.Git, logger)
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(appDir)
+				flags.AppPath = appDir
+			}
+
 			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
 
 			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
@@ -96,7 +144,7 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			}
 
 			if builder == "" {
-				suggestSettingBuilder(logger, packClient)
+				suggestSettingBuilder(logger, cfg, packClient)
 				return client.NewSoftError()
 			}
 
@@ -108,6 +156,16 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			buildpackEnv, err := parseBuildpackEnv(flags.EnvFor)
+			if err != nil {
+				return err
+			}
+
+			proxyConfig, err := configProxy(cfg)
+			if err != nil {
+				return err
+			}
+
 			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
 			if trustBuilder {
 				logger.Debugf("Builder %s is trusted", style.Symbol(builder))
@@ -132,6 +190,10 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
 			}
+			compatMode, err := client.ParseCompatMode(flags.CompatMode)
+			if err != nil {
+				return err
+			}
 			var lifecycleImage string
 			if flags.LifecycleImage != "" {
 				ref, err := name.ParseReference(flags.LifecycleImage)
@@ -140,6 +202,12 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				}
 				lifecycleImage = ref.Name()
 			}
+			var lifecycleImageKeychain authn.Keychain
+			if flags.LifecycleImageAuth != "" {
+				if lifecycleImageKeychain, err = image.NewDockerConfigKeychain(flags.LifecycleImageAuth); err != nil {
+					return errors.Wrap(err, "reading lifecycle image credentials")
+				}
+			}
 			var gid = -1
 			if cmd.Flags().Changed("gid") {
 				gid = flags.GID
@@ -154,27 +222,55 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
-			if err := packClient.Build(cmd.Context(), client.BuildOptions{
-				AppPath:           flags.AppPath,
-				Builder:           builder,
-				Registry:          flags.Registry,
-				AdditionalMirrors: getMirrors(cfg),
-				AdditionalTags:    flags.AdditionalTags,
-				RunImage:          flags.RunImage,
-				Env:               env,
-				Image:             inputImageName.Name(),
-				Publish:           flags.Publish,
-				DockerHost:        flags.DockerHost,
-				PullPolicy:        pullPolicy,
-				ClearCache:        flags.ClearCache,
+
+			lifecycleArgs, err := parseLifecycleArgs(flags.LifecycleArgs)
+			if err != nil {
+				return err
+			}
+
+			var platform *dist.Target
+			if flags.Platform != "" {
+				parsed, err := target.ParseTarget(flags.Platform, logger)
+				if err != nil {
+					return errors.Wrapf(err, "parsing platform %s", style.Symbol(flags.Platform))
+				}
+				platform = &parsed
+			}
+
+			// --timestamps is a flag of the root command
+			timestamps, _ := cmd.Flags().GetBool("timestamps")
+			buildOpts := client.BuildOptions{
+				AppPath:            flags.AppPath,
+				Builder:            builder,
+				Registry:           flags.Registry,
+				AdditionalMirrors:  getMirrors(cfg),
+				InsecureRegistries: flags.InsecureRegistries,
+				AdditionalTags:     flags.AdditionalTags,
+				Annotations:        flags.Annotations,
+				RunImage:           flags.RunImage,
+				Env:                env,
+				BuildpackEnv:       buildpackEnv,
+				ProxyConfig:        proxyConfig,
+				Bindings:           flags.Bindings,
+				Image:              inputImageName.Name(),
+				Publish:            flags.Publish,
+				DockerHost:         flags.DockerHost,
+				PullPolicy:         pullPolicy,
+				ClearCache:         flags.ClearCache,
 				TrustBuilder: func(string) bool {
 					return trustBuilder
 				},
 				Buildpacks: buildpacks,
 				Extensions: extensions,
 				ContainerConfig: client.ContainerConfig{
-					Network: flags.Network,
-					Volumes: flags.Volumes,
+					Network:        flags.Network,
+					Volumes:        flags.Volumes,
+					UsernsMode:     flags.UsernsMode,
+					SecurityOpts:   flags.SecurityOpts,
+					CapAdd:         flags.CapAdd,
+					CapDrop:        flags.CapDrop,
+					ReadOnlyRootfs: flags.ReadOnlyRootfs,
+					ScratchDirs:    flags.ScratchDirs,
 				},
 				DefaultProcessType:       flags.DefaultProcessType,
 				ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
@@ -183,12 +279,27 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				CacheImage:               flags.CacheImage,
 				Workspace:                flags.Workspace,
 				LifecycleImage:           lifecycleImage,
+				LifecycleImageKeychain:   lifecycleImageKeychain,
+				LifecycleImageDigests:    flags.LifecycleDigests,
 				GroupID:                  gid,
 				UserID:                   uid,
 				PreviousImage:            inputPreviousImage.Name(),
 				Interactive:              flags.Interactive,
 				SBOMDestinationDir:       flags.SBOMDestinationDir,
 				ReportDestinationDir:     flags.ReportDestinationDir,
+				ArtifactsDestinationDir:  flags.ArtifactsDir,
+				CacheReport:              flags.CacheReport,
+				FailIfLocked:             flags.FailIfLocked,
+				RebuildTriggers:          flags.RebuildTriggers,
+				CompatMode:               compatMode,
+				Color:                    color.Enabled(),
+				Timestamps:               timestamps,
+				RawOutput:                flags.RawOutput,
+				DebugSnapshot:            flags.DebugSnapshot,
+				ExportPlan:               flags.ExportPlan,
+				Plan:                     flags.Plan,
+				LifecycleArgs:            lifecycleArgs,
+				Platform:                 platform,
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
@@ -197,8 +308,24 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 					InputImage:         inputImageName,
 					PreviousInputImage: inputPreviousImage,
 					LayoutRepoDir:      cfg.LayoutRepositoryDir,
+					BindStrategy:       flags.LayoutBindStrategy,
+				},
+				Attest: client.AttestOptions{
+					Enabled:        flags.Attest,
+					SigningKeyPath: flags.AttestationKey,
+				},
+				Scan: client.ScanOptions{
+					Scanner: flags.Scanner,
+					Image:   flags.ScannerImage,
+					FailOn:  flags.ScanFailOn,
 				},
-			}); err != nil {
+				MaxConcurrency: flags.MaxConcurrency,
+				KeepAlive:      flags.KeepAlive,
+			}
+			if flags.Output != "" {
+				return packClient.WriteBuildManifest(cmd.Context(), buildOpts, flags.Output, logger.Writer())
+			}
+			if err := packClient.Build(cmd.Context(), buildOpts); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
@@ -207,6 +334,10 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 	}
 	buildCommandFlags(cmd, &flags, cfg)
 	AddHelpFlag(cmd, "build")
+	cmd.ValidArgsFunction = completeLocalImages(packClient)
+	cmd.RegisterFlagCompletionFunc("builder", completeBuilders(cfg))
+	cmd.RegisterFlagCompletionFunc("buildpack", completeRegistryBuildpacks(logger, cfg))
+	cmd.RegisterFlagCompletionFunc("run-image", completeRunImages(cfg))
 	return cmd
 }
 
@@ -229,8 +360,10 @@ func parseTime(providedTime string) (*time.Time, error) {
 
 func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Config) {
 	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
-	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
-	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file, or\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("extension"))
+	cmd.Flags().StringVar(&buildFlags.Git, "git", "", "Git repository of the app source to clone and build instead of a path, in the form of '<url>[#<branch, tag or commit>]'")
+	cmd.Flags().StringToStringVar(&buildFlags.Annotations, "annotation", nil, "OCI manifest annotations to add to the app image, in the form of '<name>=<value>'.\nAnnotations are only persisted when used with --publish.")
+	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, including 's3://<bucket>/<key>', 'gs://<bucket>/<object>' and 'az://<account>/<container>/<blob>' URIs of cloud storage,\n  a git repository of a buildpack in the form of 'git://<host>/<repository>[#<ref>]' or 'git+https://<host>/<repository>[#<ref>]',\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]', or\n  path to an image archive of a packaged buildpack in the form of 'docker-archive:<path>'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file,\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]', or\n  path to an image archive of a packaged extension in the form of 'docker-archive:<path>'"+stringSliceHelp("extension"))
 	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
 		`Cache options used to define cache techniques for build process.
@@ -245,7 +378,10 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
+	cmd.Flags().StringArrayVar(&buildFlags.EnvFor, "env-for", []string{}, "Build-time environment variable scoped to a buildpack, in the form '<buildpack-id>:VAR=VALUE' or '<buildpack-id>:VAR'.\nIt is written to '/platform/buildpacks/<buildpack-id>/env', with any '/' of the ID replaced by '_',\n  rather than to '/platform/env', so that other buildpacks aren't given it."+stringArrayHelp("env-for")+"\nNOTE: These are NOT available at image runtime.")
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
+	cmd.Flags().IntVar(&buildFlags.MaxConcurrency, "max-concurrency", 0, "Maximum number of independent build operations run at once, such as restoring the cache while pulling the run image.\nSet to 1 to run them one at a time. There is no limit if set to 0.")
+	cmd.Flags().StringToStringVar(&buildFlags.Bindings, "binding", nil, "Service binding to give to the buildpacks, in the form of '<name>=<dir>'.\nThe directory must have a 'type' file, and a file for each secret of the binding. It is mounted in /platform/bindings/<name>.")
 	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
 	cmd.Flags().StringArrayVar(&buildFlags.PreBuildpacks, "pre-buildpack", []string{}, "Buildpacks to prepend to the groups in the builder's order")
 	cmd.Flags().StringArrayVar(&buildFlags.PostBuildpacks, "post-buildpack", []string{}, "Buildpacks to append to the groups in the builder's order")
@@ -257,29 +393,63 @@ Special value 'inherit' may be used in which case DOCKER_HOST environment variab
 This option may set DOCKER_HOST environment variable for the build container if needed.
 `)
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
-	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().StringVar(&buildFlags.LifecycleImageAuth, "lifecycle-image-docker-config", cfg.LifecycleImageAuth, "Directory of a docker config with the credentials to pull the lifecycle image with, for lifecycle images in private registries.\nThe credentials of the user are used when not set.")
+	cmd.Flags().StringSliceVar(&buildFlags.LifecycleDigests, "lifecycle-image-digest", cfg.LifecycleImageDigests, "Digest the lifecycle image is allowed to have, such as sha256:..., the build fails when the lifecycle image has none of them."+stringSliceHelp("lifecycle image digest"))
+	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
-	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
-	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
+	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to the run image of the project descriptor, or else the default stack's run image)")
+	cmd.Flags().StringSliceVar(&buildFlags.InsecureRegistries, "insecure-registry", nil, "Registry to access without TLS, or without verifying its certificate, such as a local registry at localhost:5000.\nRequires Platform API 0.13 or later for the lifecycle to access it this way."+stringSliceHelp("insecure registry"))
+	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag', and may be in other registries than the image."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.\nFor more on trusted builders, and when to trust or untrust a builder, check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders")
 	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
+	cmd.Flags().BoolVar(&buildFlags.ReadOnlyRootfs, "read-only-rootfs", false, "Run the build phase with a read-only root filesystem, such that buildpacks can only write to their layers, the app, /tmp and the scratch directories.\nUseful to validate buildpacks don't write anywhere else.")
+	cmd.Flags().StringArrayVar(&buildFlags.ScratchDirs, "scratch-dir", nil, "Directory to give the build phase as a tmpfs mount with --read-only-rootfs, in addition to /tmp"+stringArrayHelp("scratch directory"))
+	cmd.Flags().StringVar(&buildFlags.UsernsMode, "userns", "", "User namespace of the build containers, such as 'host' when the daemon remaps users")
+	cmd.Flags().StringArrayVar(&buildFlags.SecurityOpts, "security-opt", nil, "Security option of the build containers, such as 'no-new-privileges', 'apparmor=<profile>' or 'seccomp=<profile path>'"+stringArrayHelp("security option"))
+	cmd.Flags().StringSliceVar(&buildFlags.CapAdd, "cap-add", nil, "Kernel capability to add to the build containers, such as 'NET_ADMIN'"+stringSliceHelp("capability"))
+	cmd.Flags().StringSliceVar(&buildFlags.CapDrop, "cap-drop", nil, "Kernel capability to drop from the build containers, such as 'ALL'"+stringSliceHelp("capability"))
 	cmd.Flags().StringVar(&buildFlags.Workspace, "workspace", "", "Location at which to mount the app dir in the build image")
 	cmd.Flags().IntVar(&buildFlags.GID, "gid", 0, `Override GID of user's group in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
-	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
+	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID.\nWhen publishing, the previous image may be in a different repository or registry than <image-name>.")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
+	cmd.Flags().StringVar(&buildFlags.ArtifactsDir, "artifacts-dir", "", "Path to copy the build outputs buildpacks write to the directory in the CNB_ARTIFACTS_DIR build environment variable,\nsuch as test reports or compiled binaries, to.\nOmitting the flag yields no artifacts.")
 	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().BoolVar(&buildFlags.CacheReport, "cache-report", false, "Explain which buildpack layers were restored from the previous image, and why the others were rebuilt or invalidated.\nThe report is also added to the report.toml in the --report-output-dir, when provided.")
+	cmd.Flags().BoolVar(&buildFlags.FailIfLocked, "fail-if-locked", false, "Fail at once when another build is using the caches of the build, rather than waiting for it to finish")
+	cmd.Flags().BoolVar(&buildFlags.RebuildTriggers, "rebuild-triggers", false, "Record the builder and buildpacks the app image is built with in a label of the app image,\nfor `pack image needs-rebuild` to tell whether the app image should be rebuilt or rebased when they change.")
+	cmd.Flags().StringVar(&buildFlags.CompatMode, "compat-mode", string(client.CompatModeStrict), "What to do when the run image doesn't match the builder, by stack ID, target or mixins, or when buildpacks require mixins the stack doesn't provide.\nAccepted values are strict, to fail the build, warn, to report the mismatch and build anyway, and ignore, to build anyway.")
+	cmd.Flags().StringVar(&buildFlags.Platform, "platform", "", "Platform of the builder and run image variants to build with, in the form 'os/arch[/variant]', such as linux/amd64 or linux/arm64.\nDefaults to the variants for the platform of the daemon, when the images have them.")
+	cmd.Flags().BoolVar(&buildFlags.RawOutput, "raw-output", false, "Write the lifecycle output as it is, without phase prefixes, timestamps, or color removal, to capture it exactly")
+	cmd.Flags().StringVar(&buildFlags.ExportPlan, "export-plan", "", "Path to export the build plan resolved by detection to, with the buildpack group, for later builds to replay with --plan")
+	cmd.Flags().StringVar(&buildFlags.Plan, "plan", "", "Path of a build plan exported with --export-plan to replay, skipping detection and building with the same buildpacks")
+	cmd.Flags().StringArrayVar(&buildFlags.LifecycleArgs, "lifecycle-arg", nil, "Additional argument of a lifecycle phase, in the form '<phase>:<argument>', such as 'exporter:-process-type=web'.\nIt overrides the flag pack passes the phase, if any. Meant for debugging, and for using lifecycle features pack has no flag for."+stringArrayHelp("lifecycle-arg"))
+	cmd.Flags().BoolVar(&buildFlags.DebugSnapshot, "debug-snapshot", false, "When a lifecycle phase fails, save its state to the image <image-name>-debug:<phase>, with the build plan, the layers, the app and the phase environment, to look into the failure from a shell")
+	cmd.Flags().BoolVar(&buildFlags.KeepAlive, "keep-alive", false, "Keep the build containers for the next build of the same image, which then runs in them rather than in new containers.\nThe containers are removed after 30 minutes.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
+	cmd.Flags().BoolVar(&buildFlags.Attest, "attest", false, "Attach an in-toto attestation of the buildpacks and build plan to the published image.\nThe attestation is also written to the --report-output-dir, when provided.")
+	cmd.Flags().StringVar(&buildFlags.AttestationKey, "attestation-key", "", "Path to a PEM encoded private key used to sign the attestation")
+	cmd.Flags().StringVar(&buildFlags.Scanner, "scan", "", "Scan the built image for vulnerabilities. Accepted values are grype, trivy, and none.\nResults are written to scan.json in the --report-output-dir, when provided.")
+	cmd.Flags().StringVar(&buildFlags.ScannerImage, "scanner-image", "", "Scanner image to use instead of the latest release of the selected --scan tool")
+	cmd.Flags().StringVar(&buildFlags.ScanFailOn, "scan-fail-on", "", "Fail the build if a vulnerability of this severity or higher is found. Accepted values are negligible, low, medium, high, and critical.")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
-	if !cfg.Experimental {
+	cmd.Flags().StringVar(&buildFlags.Output, "output", "", "Write a manifest building the image in a cluster instead of building it. Accepted values are k8s-job, for a Kubernetes Job, and tekton-taskrun, for a Tekton TaskRun.\nThe builder is run as it is, so buildpacks that are not in the builder can't be given.")
+	cmd.Flags().StringVar(&buildFlags.LayoutBindStrategy, "layout-bind-strategy", client.LayoutBindAuto, "How the OCI layout directories are given to the build containers. Accepted values are auto, bind, and copy.\nWith auto, they are copied when the daemon is not on this host, and bound otherwise.")
+	if !cfg.ExperimentalEnabled(config.FeatureInteractive) {
 		cmd.Flags().MarkHidden("interactive")
+	}
+	if !cfg.ExperimentalEnabled(config.FeatureKeepAlive) {
+		cmd.Flags().MarkHidden("keep-alive")
+	}
+	if !cfg.ExperimentalEnabled(config.FeatureOCILayout) {
 		cmd.Flags().MarkHidden("sparse")
+		cmd.Flags().MarkHidden("layout-bind-strategy")
 	}
 }
 
 func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef client.InputImageReference, logger logging.Logger) error {
-	if flags.Registry != "" && !cfg.Experimental {
-		return client.NewExperimentError("Support for buildpack registries is currently experimental.")
+	if flags.Registry != "" && !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		return client.NewFeatureExperimentError(config.FeatureBuildpackRegistry, "Support for buildpack registries is currently experimental.")
 	}
 
 	if flags.Cache.Launch.Format == cache.CacheImage {
@@ -306,17 +476,83 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("uid flag must be in the range of 0-2147483647")
 	}
 
-	if flags.Interactive && !cfg.Experimental {
-		return client.NewExperimentError("Interactive mode is currently experimental.")
+	if flags.KeepAlive && !cfg.ExperimentalEnabled(config.FeatureKeepAlive) {
+		return client.NewFeatureExperimentError(config.FeatureKeepAlive, "Keeping build containers alive is currently experimental.")
+	}
+
+	if flags.KeepAlive && flags.Interactive {
+		return errors.New("keep-alive flag cannot be used with the interactive flag")
+	}
+
+	if flags.MaxConcurrency < 0 {
+		return errors.New("max-concurrency flag must not be negative")
+	}
+
+	if flags.Interactive && !cfg.ExperimentalEnabled(config.FeatureInteractive) {
+		return client.NewFeatureExperimentError(config.FeatureInteractive, "Interactive mode is currently experimental.")
+	}
+
+	if inputImageRef.Layout() && !cfg.ExperimentalEnabled(config.FeatureOCILayout) {
+		return client.NewFeatureExperimentError(config.FeatureOCILayout, "Exporting to OCI layout is currently experimental.")
+	}
+
+	if err := client.ValidateScanOptions(client.ScanOptions{Scanner: flags.Scanner, FailOn: flags.ScanFailOn}); err != nil {
+		return err
+	}
+
+	if flags.ScanFailOn != "" && (flags.Scanner == "" || flags.Scanner == client.ScannerNone) {
+		return errors.New("scan-fail-on flag requires the scan flag")
 	}
 
-	if inputImageRef.Layout() && !cfg.Experimental {
-		return client.NewExperimentError("Exporting to OCI layout is currently experimental.")
+	if flags.AttestationKey != "" && !flags.Attest {
+		return errors.New("attestation-key flag requires the attest flag")
+	}
+
+	if flags.Git != "" && flags.AppPath != "" {
+		return errors.New("git flag cannot be used with the path flag")
 	}
 
 	return nil
 }
 
+// cloneAppSource clones the repository of the git flag to a temporary directory, to be built like the directory of
+// the path flag, with its project descriptor and git metadata
+func cloneAppSource(ctx context.Context, uri string, logger logging.Logger) (string, error) {
+	repo, err := git.ParseURI(uri)
+	if err != nil {
+		return "", err
+	}
+
+	appDir, err := os.MkdirTemp("", "pack.app.")
+	if err != nil {
+		return "", errors.Wrap(err, "creating app source directory")
+	}
+
+	logger.Infof("Cloning app source from %s", style.Symbol(repo.String()))
+	if _, err := git.Clone(ctx, repo, appDir); err != nil {
+		os.RemoveAll(appDir)
+		return "", err
+	}
+	return appDir, nil
+}
+
+// parseLifecycleArgs parses arguments of the form <phase>:<argument> into the arguments of each phase
+func parseLifecycleArgs(args []string) (map[string][]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	phaseArgs := map[string][]string{}
+	for _, arg := range args {
+		phase, phaseArg, ok := strings.Cut(arg, ":")
+		if !ok || phase == "" || phaseArg == "" {
+			return nil, errors.Errorf("invalid lifecycle argument %s, must be in the form <phase>:<argument>", style.Symbol(arg))
+		}
+		phaseArgs[phase] = append(phaseArgs[phase], phaseArg)
+	}
+	return phaseArgs, nil
+}
+
 func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	env := map[string]string{}
 
@@ -336,6 +572,45 @@ func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	return env, nil
 }
 
+// configProxy returns the proxy of the config, decrypted, or nil for the build to use the proxy environment variables
+func configProxy(cfg config.Config) (*client.ProxyConfig, error) {
+	if cfg.Proxy == (config.Proxy{}) {
+		return nil, nil
+	}
+
+	keyring := config.NewKeyring(cfg.Keyring)
+	httpProxy, err := config.Decrypt(keyring, cfg.Proxy.HTTPProxy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypting %s", style.Symbol("proxy.http-proxy"))
+	}
+	httpsProxy, err := config.Decrypt(keyring, cfg.Proxy.HTTPSProxy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypting %s", style.Symbol("proxy.https-proxy"))
+	}
+	return &client.ProxyConfig{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    cfg.Proxy.NoProxy,
+	}, nil
+}
+
+// parseBuildpackEnv parses environment variables scoped to buildpacks, in the form '<buildpack-id>:VAR=VALUE' or
+// '<buildpack-id>:VAR', keyed by buildpack ID
+func parseBuildpackEnv(envVars []string) (map[string]map[string]string, error) {
+	env := map[string]map[string]string{}
+	for _, envVar := range envVars {
+		id, item, ok := strings.Cut(envVar, ":")
+		if !ok || id == "" || item == "" || strings.HasPrefix(item, "=") {
+			return nil, errors.Errorf("invalid buildpack environment variable %s, must be in the form '<buildpack-id>:VAR=VALUE' or '<buildpack-id>:VAR'", style.Symbol(envVar))
+		}
+		if env[id] == nil {
+			env[id] = map[string]string{}
+		}
+		env[id] = addEnvVar(env[id], item)
+	}
+	return env, nil
+}
+
 func parseEnvFile(filename string) (map[string]string, error) {
 	out := make(map[string]string)
 	f, err := os.ReadFile(filepath.Clean(filename))
@@ -2,8 +2,10 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -23,6 +25,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
@@ -148,6 +151,28 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("security options are given", func() {
+			it("forwards them onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSecurity("host", []string{"no-new-privileges", "seccomp=unconfined"}, []string{"NET_ADMIN", "SYS_PTRACE"}, []string{"ALL"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--userns", "host", "--security-opt", "no-new-privileges", "--security-opt", "seccomp=unconfined", "--cap-add", "NET_ADMIN,SYS_PTRACE", "--cap-drop", "ALL"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--read-only-rootfs", func() {
+			it("forwards it and the scratch directories onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithReadOnlyRootfs([]string{"/home/cnb", "/var/tmp"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--read-only-rootfs", "--scratch-dir", "/home/cnb", "--scratch-dir", "/var/tmp"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("--pull-policy", func() {
 			it("sets pull-policy=never", func() {
 				mockClient.EXPECT().
@@ -356,6 +381,47 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--attest is passed", func() {
+			it("passes the attestation options to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithAttest(client.AttestOptions{Enabled: true, SigningKeyPath: "cosign.key"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--attest", "--attestation-key", "cosign.key"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("--attestation-key is passed without --attest", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--attestation-key", "cosign.key"})
+					h.AssertError(t, command.Execute(), "attestation-key flag requires the attest flag")
+				})
+			})
+		})
+
+		when("--scan is passed", func() {
+			it("passes the scan options to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithScan(client.ScanOptions{Scanner: "trivy", Image: "my/trivy", FailOn: "high"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--scan", "trivy", "--scanner-image", "my/trivy", "--scan-fail-on", "high"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for unsupported scanners", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--scan", "clair"})
+				h.AssertError(t, command.Execute(), "unsupported scanner 'clair'")
+			})
+
+			when("--scan-fail-on is passed without --scan", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--scan-fail-on", "high"})
+					h.AssertError(t, command.Execute(), "scan-fail-on flag requires the scan flag")
+				})
+			})
+		})
+
 		when("cache flag with 'format=image' is passed", func() {
 			when("--publish is not used", func() {
 				it("errors", func() {
@@ -732,6 +798,18 @@ builder = "my-builder"
 			})
 		})
 
+		when("annotations are specified", func() {
+			it("forwards annotations to the client", func() {
+				expectedAnnotations := map[string]string{"org.opencontainers.image.source": "https://example.com/repo"}
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithAnnotations(expectedAnnotations)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--annotation", "org.opencontainers.image.source=https://example.com/repo"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("gid flag is provided", func() {
 			when("--gid is a valid value", func() {
 				it("override build option should be set to true", func() {
@@ -763,6 +841,298 @@ builder = "my-builder"
 			})
 		})
 
+		when("max-concurrency flag is provided", func() {
+			it("sets the maximum concurrency of the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithMaxConcurrency(1)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--max-concurrency", "1"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails with a negative value", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--max-concurrency", "-1"})
+				h.AssertError(t, command.Execute(), "max-concurrency flag must not be negative")
+			})
+		})
+
+		when("insecure-registry flag is provided", func() {
+			it("sets the insecure registries of the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithInsecureRegistries([]string{"localhost:5000", "kind-registry:5000"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--insecure-registry", "localhost:5000", "--insecure-registry", "kind-registry:5000"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("binding flag is provided", func() {
+			it("sets the bindings of the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBindings(map[string]string{"db": "./bindings/db", "cache": "/some/cache"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--binding", "db=./bindings/db", "--binding", "cache=/some/cache"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("cache-report flag is provided", func() {
+			it("asks for a cache report", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCacheReport(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--cache-report"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("rebuild-triggers flag is provided", func() {
+			it("records the rebuild triggers", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithRebuildTriggers(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--rebuild-triggers"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("compat-mode flag is provided", func() {
+			it("builds with the compat mode", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCompatMode(client.CompatModeWarn)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--compat-mode", "warn"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("defaults to strict", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCompatMode(client.CompatModeStrict)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails for unknown compat modes", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--compat-mode", "lenient"})
+				h.AssertError(t, command.Execute(), "invalid compat mode 'lenient', must be one of strict, warn or ignore")
+			})
+		})
+
+		when("env-for flag is provided", func() {
+			it("scopes the environment variables to the buildpacks", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuildpackEnv(map[string]map[string]string{
+						"some/buildpack":  {"TOKEN": "some-token", "KEY": "some=value"},
+						"other/buildpack": {"TOKEN": "other-token"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{
+					"--builder", "my-builder", "image",
+					"--env-for", "some/buildpack:TOKEN=some-token",
+					"--env-for", "some/buildpack:KEY=some=value",
+					"--env-for", "other/buildpack:TOKEN=other-token",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails for variables without a buildpack", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--env-for", "TOKEN=some-token"})
+				h.AssertError(t, command.Execute(), "invalid buildpack environment variable 'TOKEN=some-token'")
+			})
+		})
+
+		when("the config has a proxy", func() {
+			it("builds with the proxy of the config", func() {
+				cfg.Proxy = config.Proxy{HTTPProxy: "http://proxy:3128", NoProxy: "localhost"}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProxyConfig(&client.ProxyConfig{HTTPProxy: "http://proxy:3128", NoProxy: "localhost"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("leaves the proxy to the environment without one", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProxyConfig(nil)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("fail-if-locked flag is provided", func() {
+			it("fails rather than waiting for other builds using the caches", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithFailIfLocked(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--fail-if-locked"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("raw-output flag is provided", func() {
+			it("logs the lifecycle output as it is", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithRawOutput(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--raw-output"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("export-plan and plan flags are provided", func() {
+			it("exports the build plan and replays the given one", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithPlans("new-plan.toml", "old-plan.toml")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--export-plan", "new-plan.toml", "--plan", "old-plan.toml"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("lifecycle-arg flag is provided", func() {
+			it("passes the arguments of each phase", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLifecycleArgs(map[string][]string{
+						"exporter": {"-process-type=web", "-log-level=debug"},
+						"analyzer": {"-skip-layers"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image",
+					"--lifecycle-arg", "exporter:-process-type=web",
+					"--lifecycle-arg", "analyzer:-skip-layers",
+					"--lifecycle-arg", "exporter:-log-level=debug",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for arguments without a phase", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--lifecycle-arg", "-process-type=web"})
+				h.AssertError(t, command.Execute(), "invalid lifecycle argument '-process-type=web', must be in the form <phase>:<argument>")
+			})
+		})
+
+		when("git flag is provided", func() {
+			var repoDir string
+
+			it.Before(func() {
+				var err error
+				repoDir, err = os.MkdirTemp("", "app-repo")
+				h.AssertNil(t, err)
+				h.AssertNil(t, os.WriteFile(filepath.Join(repoDir, "project.toml"), []byte(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+builder = "repo-builder"
+`), 0600))
+				for _, args := range [][]string{
+					{"init", "--initial-branch", "main"},
+					{"add", "project.toml"},
+					{"commit", "--message", "add project.toml"},
+				} {
+					cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+					cmd.Dir = repoDir
+					out, err := cmd.CombinedOutput()
+					h.AssertNil(t, errors.Wrap(err, string(out)))
+				}
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(repoDir))
+			})
+
+			it("builds a clone of the repository with its project descriptor", func() {
+				var appPath string
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilder("repo-builder")).
+					DoAndReturn(func(_ context.Context, opts client.BuildOptions) error {
+						appPath = opts.AppPath
+						_, err := os.Stat(filepath.Join(appPath, "project.toml"))
+						return err
+					})
+
+				command.SetArgs([]string{"image", "--git", "git+file://" + repoDir + "#main"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Cloning app source from 'file://"+repoDir+"#main'")
+
+				_, err := os.Stat(appPath)
+				h.AssertEq(t, os.IsNotExist(err), true)
+			})
+
+			it("errors with the path flag", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--git", "git+file://" + repoDir, "--path", "app"})
+				h.AssertError(t, command.Execute(), "git flag cannot be used with the path flag")
+			})
+		})
+
+		when("debug-snapshot flag is provided", func() {
+			it("saves the state of failed phases", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithDebugSnapshot(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--debug-snapshot"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("timestamps flag of the root command is provided", func() {
+			it("prefixes the lifecycle output for timestamps", func() {
+				command.PersistentFlags().Bool("timestamps", false, "")
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTimestamps(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--timestamps"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("platform flag is provided", func() {
+			it("builds with the variants for the platform", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithPlatform(&dist.Target{OS: "linux", Arch: "arm64"})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--platform", "linux/arm64"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails for an unknown platform", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--platform", "linux/sparc"})
+				h.AssertError(t, command.Execute(), "parsing platform 'linux/sparc'")
+			})
+		})
+
+		when("output flag is provided", func() {
+			it("writes the manifest instead of building", func() {
+				mockClient.EXPECT().
+					WriteBuildManifest(gomock.Any(), EqBuildOptionsWithImage("my-builder", "image"), "k8s-job", gomock.Any()).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--output", "k8s-job"})
+				h.AssertNil(t, command.Execute())
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+		})
+
 		when("previous-image flag is provided", func() {
 			when("image is invalid", func() {
 				it("error must be thrown", func() {
@@ -822,6 +1192,53 @@ builder = "my-builder"
 			})
 		})
 
+		when("keep-alive flag is provided", func() {
+			it("errors with a descriptive message when experimental isn't set in the config", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--keep-alive"})
+				h.AssertError(t, command.Execute(), "Keeping build containers alive is currently experimental.")
+			})
+
+			when("experimental is set in the config", func() {
+				it.Before(func() {
+					command = commands.Build(logger, config.Config{Experimental: true}, mockClient)
+				})
+
+				it("keeps the build containers alive", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithKeepAlive(true)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--keep-alive"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("can't be used in interactive mode", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--keep-alive", "--interactive"})
+					h.AssertError(t, command.Execute(), "keep-alive flag cannot be used with the interactive flag")
+				})
+			})
+
+			when("only the keep-alive experimental feature is enabled in the config", func() {
+				it.Before(func() {
+					command = commands.Build(logger, config.Config{ExperimentalFeatures: []string{config.FeatureKeepAlive}}, mockClient)
+				})
+
+				it("keeps the build containers alive", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithKeepAlive(true)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--keep-alive"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("still gates other experimental features", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--interactive"})
+					h.AssertError(t, command.Execute(), "Interactive mode is currently experimental.")
+				})
+			})
+		})
+
 		when("sbom destination directory is provided", func() {
 			it("forwards the network onto the client", func() {
 				mockClient.EXPECT().
@@ -833,6 +1250,17 @@ builder = "my-builder"
 			})
 		})
 
+		when("artifacts directory is provided", func() {
+			it("forwards the artifacts directory onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithArtifactsDir("some-artifacts-dir")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--artifacts-dir", "some-artifacts-dir"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("--creation-time", func() {
 			when("provided as 'now'", func() {
 				it("passes it to the builder", func() {
@@ -937,6 +1365,17 @@ builder = "my-builder"
 				h.AssertNil(t, err)
 			})
 		})
+
+		when("--layout-bind-strategy flag is provided", func() {
+			it("build is called with the bind strategy", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLayoutBindStrategy(client.LayoutBindCopy)).
+					Return(nil)
+
+				command.SetArgs([]string{"oci:image", "--layout-bind-strategy", "copy", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
 	})
 }
 
@@ -1003,6 +1442,27 @@ func EqBuildOptionsWithNetwork(network string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithSecurity(usernsMode string, securityOpts, capAdd, capDrop []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("UsernsMode=%s SecurityOpts=%s CapAdd=%s CapDrop=%s", usernsMode, securityOpts, capAdd, capDrop),
+		equals: func(o client.BuildOptions) bool {
+			return o.ContainerConfig.UsernsMode == usernsMode &&
+				reflect.DeepEqual(o.ContainerConfig.SecurityOpts, securityOpts) &&
+				reflect.DeepEqual(o.ContainerConfig.CapAdd, capAdd) &&
+				reflect.DeepEqual(o.ContainerConfig.CapDrop, capDrop)
+		},
+	}
+}
+
+func EqBuildOptionsWithReadOnlyRootfs(scratchDirs []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ReadOnlyRootfs=true ScratchDirs=%s", scratchDirs),
+		equals: func(o client.BuildOptions) bool {
+			return o.ContainerConfig.ReadOnlyRootfs && reflect.DeepEqual(o.ContainerConfig.ScratchDirs, scratchDirs)
+		},
+	}
+}
+
 func EqBuildOptionsWithBuilder(builder string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Builder=%s", builder),
@@ -1039,6 +1499,33 @@ func EqBuildOptionsWithAdditionalTags(additionalTags []string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithAnnotations(annotations map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Annotations=%+v", annotations),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Annotations, annotations)
+		},
+	}
+}
+
+func EqBuildOptionsWithAttest(attest client.AttestOptions) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Attest=%+v", attest),
+		equals: func(o client.BuildOptions) bool {
+			return o.Attest == attest
+		},
+	}
+}
+
+func EqBuildOptionsWithScan(scan client.ScanOptions) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Scan=%+v", scan),
+		equals: func(o client.BuildOptions) bool {
+			return o.Scan == scan
+		},
+	}
+}
+
 func EqBuildOptionsWithProjectDescriptor(descriptor projectTypes.Descriptor) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Descriptor=%s", descriptor),
@@ -1067,6 +1554,33 @@ func EqBuildOptionsWithEnv(env map[string]string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithBuildpackEnv(env map[string]map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("BuildpackEnv=%+v", env),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.BuildpackEnv, env)
+		},
+	}
+}
+
+func EqBuildOptionsWithProxyConfig(proxyConfig *client.ProxyConfig) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ProxyConfig=%+v", proxyConfig),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.ProxyConfig, proxyConfig)
+		},
+	}
+}
+
+func EqBuildOptionsWithFailIfLocked(failIfLocked bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("FailIfLocked=%t", failIfLocked),
+		equals: func(o client.BuildOptions) bool {
+			return o.FailIfLocked == failIfLocked
+		},
+	}
+}
+
 func EqBuildOptionsWithOverrideGroupID(gid int) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("GID=%d", gid),
@@ -1076,6 +1590,123 @@ func EqBuildOptionsWithOverrideGroupID(gid int) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithBindings(bindings map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Bindings=%s", bindings),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Bindings, bindings)
+		},
+	}
+}
+
+func EqBuildOptionsWithRebuildTriggers(rebuildTriggers bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RebuildTriggers=%t", rebuildTriggers),
+		equals: func(o client.BuildOptions) bool {
+			return o.RebuildTriggers == rebuildTriggers
+		},
+	}
+}
+
+func EqBuildOptionsWithCompatMode(mode client.CompatMode) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("CompatMode=%s", mode),
+		equals: func(o client.BuildOptions) bool {
+			return o.CompatMode == mode
+		},
+	}
+}
+
+func EqBuildOptionsWithCacheReport(cacheReport bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("CacheReport=%t", cacheReport),
+		equals: func(o client.BuildOptions) bool {
+			return o.CacheReport == cacheReport
+		},
+	}
+}
+
+func EqBuildOptionsWithRawOutput(rawOutput bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RawOutput=%t", rawOutput),
+		equals: func(o client.BuildOptions) bool {
+			return o.RawOutput == rawOutput
+		},
+	}
+}
+
+func EqBuildOptionsWithTimestamps(timestamps bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Timestamps=%t", timestamps),
+		equals: func(o client.BuildOptions) bool {
+			return o.Timestamps == timestamps
+		},
+	}
+}
+
+func EqBuildOptionsWithPlatform(platform *dist.Target) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Platform=%v", platform),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Platform, platform)
+		},
+	}
+}
+
+func EqBuildOptionsWithInsecureRegistries(insecureRegistries []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("InsecureRegistries=%s", insecureRegistries),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.InsecureRegistries, insecureRegistries)
+		},
+	}
+}
+
+func EqBuildOptionsWithMaxConcurrency(maxConcurrency int) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("MaxConcurrency=%d", maxConcurrency),
+		equals: func(o client.BuildOptions) bool {
+			return o.MaxConcurrency == maxConcurrency
+		},
+	}
+}
+
+func EqBuildOptionsWithKeepAlive(keepAlive bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("KeepAlive=%t", keepAlive),
+		equals: func(o client.BuildOptions) bool {
+			return o.KeepAlive == keepAlive
+		},
+	}
+}
+
+func EqBuildOptionsWithPlans(exportPlan, plan string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ExportPlan=%s, Plan=%s", exportPlan, plan),
+		equals: func(o client.BuildOptions) bool {
+			return o.ExportPlan == exportPlan && o.Plan == plan
+		},
+	}
+}
+
+func EqBuildOptionsWithLifecycleArgs(lifecycleArgs map[string][]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("LifecycleArgs=%v", lifecycleArgs),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.LifecycleArgs, lifecycleArgs)
+		},
+	}
+}
+
+func EqBuildOptionsWithDebugSnapshot(debugSnapshot bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("DebugSnapshot=%t", debugSnapshot),
+		equals: func(o client.BuildOptions) bool {
+			return o.DebugSnapshot == debugSnapshot
+		},
+	}
+}
+
 func EqBuildOptionsWithPreviousImage(prevImage string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Previous image=%s", prevImage),
@@ -1094,6 +1725,15 @@ func EqBuildOptionsWithSBOMOutputDir(s string) interface{} {
 	}
 }
 
+func EqBuildOptionsWithArtifactsDir(s string) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("artifacts-destination-dir=%s", s),
+		equals: func(o client.BuildOptions) bool {
+			return o.ArtifactsDestinationDir == s
+		},
+	}
+}
+
 func EqBuildOptionsWithDateTime(t *time.Time) interface{} {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("CreationTime=%s", t),
@@ -1122,6 +1762,15 @@ func EqBuildOptionsWithLayoutConfig(image, previousImage string, sparse bool, la
 	}
 }
 
+func EqBuildOptionsWithLayoutBindStrategy(strategy string) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("layout-bind-strategy=%s", strategy),
+		equals: func(o client.BuildOptions) bool {
+			return o.Layout() && o.LayoutConfig.BindStrategy == strategy
+		},
+	}
+}
+
 type buildOptionsMatcher struct {
 	equals      func(client.BuildOptions) bool
 	description string
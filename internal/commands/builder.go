@@ -17,8 +17,16 @@ func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClie
 	}
 
 	cmd.AddCommand(BuilderCreate(logger, cfg, client))
+	cmd.AddCommand(BuilderCopy(logger, client))
+	cmd.AddCommand(BuilderDiff(logger, client))
+	cmd.AddCommand(BuilderExtract(logger, cfg, client))
 	cmd.AddCommand(BuilderInspect(logger, cfg, client, builderwriter.NewFactory()))
-	cmd.AddCommand(BuilderSuggest(logger, client))
+	cmd.AddCommand(BuilderLint(logger, cfg, client))
+	cmd.AddCommand(BuilderOutdated(logger, cfg, client))
+	cmd.AddCommand(BuilderRelocate(logger, cfg, client))
+	cmd.AddCommand(BuilderShims(logger, cfg, client))
+	cmd.AddCommand(BuilderSuggest(logger, cfg, client))
+	cmd.AddCommand(BuilderUpdate(logger, cfg, client))
 	AddHelpFlag(cmd, "builder")
 	return cmd
 }
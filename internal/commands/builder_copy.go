@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderCopyFlags define flags provided to the BuilderCopy command
+type BuilderCopyFlags struct {
+	RunImages      bool
+	LifecycleImage bool
+}
+
+// BuilderCopy copies a builder between registries
+func BuilderCopy(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags BuilderCopyFlags
+
+	cmd := &cobra.Command{
+		Use:   "copy <source> <destination>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Copy a builder between registries",
+		Long: "Copy a builder image, or image index, from one registry to another as it is, keeping its digest, " +
+			"such as to promote a builder from a staging registry to a production one.\n\n" +
+			"Use --run-images and --lifecycle-image to copy the images the builder references next to the destination, " +
+			"keeping their tag or digest.",
+		Example: "pack builder copy staging.example.com/builders/base:1.2.0 registry.example.com/builders/base:1.2.0 --run-images",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.CopyBuilder(cmd.Context(), client.CopyBuilderOptions{
+				Source:             args[0],
+				Destination:        args[1],
+				CopyRunImages:      flags.RunImages,
+				CopyLifecycleImage: flags.LifecycleImage,
+			})
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.RunImages, "run-images", false, "Also copy the run images of the builder next to the destination")
+	cmd.Flags().BoolVar(&flags.LifecycleImage, "lifecycle-image", false, "Also copy the lifecycle image of the lifecycle version of the builder next to the destination")
+
+	AddHelpFlag(cmd, "copy")
+	return cmd
+}
@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderCopyCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderCopyCommand", testBuilderCopyCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderCopyCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderCopy(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderCopy", func() {
+		it("copies the builder", func() {
+			mockClient.EXPECT().CopyBuilder(gomock.Any(), client.CopyBuilderOptions{
+				Source:      "staging.example.com/builder:1",
+				Destination: "registry.example.com/builder:1",
+			}).Return(nil)
+
+			command.SetArgs([]string{"staging.example.com/builder:1", "registry.example.com/builder:1"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("copies the images the builder references", func() {
+			mockClient.EXPECT().CopyBuilder(gomock.Any(), client.CopyBuilderOptions{
+				Source:             "staging.example.com/builder:1",
+				Destination:        "registry.example.com/builder:1",
+				CopyRunImages:      true,
+				CopyLifecycleImage: true,
+			}).Return(nil)
+
+			command.SetArgs([]string{"staging.example.com/builder:1", "registry.example.com/builder:1", "--run-images", "--lifecycle-image"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("fails when the builder can't be copied", func() {
+			mockClient.EXPECT().CopyBuilder(gomock.Any(), gomock.Any()).Return(errors.New("no access"))
+
+			command.SetArgs([]string{"staging.example.com/builder:1", "registry.example.com/builder:1"})
+			h.AssertError(t, command.Execute(), "no access")
+		})
+	})
+}
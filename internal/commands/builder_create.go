@@ -3,7 +3,9 @@ package commands
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -19,12 +21,19 @@ import (
 // BuilderCreateFlags define flags provided to the CreateBuilder command
 type BuilderCreateFlags struct {
 	Publish         bool
+	Format          string
+	DryRun          bool
 	BuilderTomlPath string
 	Registry        string
 	Policy          string
 	Flatten         []string
 	Targets         []string
 	Label           map[string]string
+	Annotation      map[string]string
+	Variables       map[string]string
+	Watch           bool
+	WatchInterval   time.Duration
+	MemoryLimit     string
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -56,17 +65,10 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
 			}
 
-			builderConfig, warns, err := builder.ReadConfig(flags.BuilderTomlPath)
-			if err != nil {
-				return errors.Wrap(err, "invalid builder toml")
-			}
-			for _, w := range warns {
-				logger.Warnf("builder configuration: %s", w)
-			}
-
-			if hasExtensions(builderConfig) {
-				if !cfg.Experimental {
-					return errors.New("builder config contains image extensions; support for image extensions is currently experimental")
+			var memoryLimit uint64
+			if flags.MemoryLimit != "" {
+				if memoryLimit, err = humanize.ParseBytes(flags.MemoryLimit); err != nil || memoryLimit == 0 {
+					return errors.Errorf("invalid memory limit %s, must be a size such as 64MB", style.Symbol(flags.MemoryLimit))
 				}
 			}
 
@@ -75,58 +77,115 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				return errors.Wrap(err, "getting absolute path for config")
 			}
 
-			envMap, warnings, err := builder.ParseBuildConfigEnv(builderConfig.Build.Env, flags.BuilderTomlPath)
-			for _, v := range warnings {
-				logger.Warn(v)
-			}
-			if err != nil {
-				return err
-			}
+			imageName := args[0]
+			createBuilder := func() error {
+				builderConfig, warns, err := builder.ReadConfigWithVariables(flags.BuilderTomlPath, flags.Variables)
+				if err != nil {
+					return errors.Wrap(err, "invalid builder toml")
+				}
+				for _, w := range warns {
+					logger.Warnf("builder configuration: %s", w)
+				}
 
-			toFlatten, err := buildpack.ParseFlattenBuildModules(flags.Flatten)
-			if err != nil {
-				return err
-			}
+				if hasExtensions(builderConfig) {
+					if !cfg.ExperimentalEnabled(config.FeatureExtensions) {
+						return client.NewFeatureExperimentError(config.FeatureExtensions, "builder config contains image extensions; support for image extensions is currently experimental")
+					}
+				}
 
-			multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, builderConfig.Targets, !flags.Publish)
-			if err != nil {
-				return err
+				envMap, warnings, err := builder.ParseBuildConfigEnv(builderConfig.Build.Env, flags.BuilderTomlPath)
+				for _, v := range warnings {
+					logger.Warn(v)
+				}
+				if err != nil {
+					return err
+				}
+
+				toFlatten, err := buildpack.ParseFlattenBuildModules(flags.Flatten)
+				if err != nil {
+					return err
+				}
+
+				multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, builderConfig.Targets, client.SavesToDaemon(flags.Format, flags.Publish))
+				if err != nil {
+					return err
+				}
+
+				if len(multiArchCfg.Targets()) == 0 {
+					logger.Infof("Pro tip: use --targets flag OR [[targets]] in builder.toml to specify the desired platform")
+				}
+
+				if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
+					RelativeBaseDir: relativeBaseDir,
+					BuildConfigEnv:  envMap,
+					BuilderName:     imageName,
+					Config:          builderConfig,
+					Publish:         flags.Publish,
+					Format:          flags.Format,
+					Registry:        flags.Registry,
+					PullPolicy:      pullPolicy,
+					Flatten:         toFlatten,
+					Labels:          flags.Label,
+					Annotations:     flags.Annotation,
+					Targets:         multiArchCfg.Targets(),
+					DryRun:          flags.DryRun,
+					MemoryLimit:     int64(memoryLimit),
+				}); err != nil {
+					return err
+				}
+				if flags.DryRun {
+					return nil
+				}
+				if writesToHost(flags.Format) {
+					_, location := savedLocation(flags.Format, flags.Publish)
+					logger.Infof("Successfully created builder %s and saved to %s", style.Symbol(imageName), location)
+					return nil
+				}
+				logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
+				if !flags.Watch {
+					logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
+				}
+				return nil
 			}
 
-			if len(multiArchCfg.Targets()) == 0 {
-				logger.Infof("Pro tip: use --targets flag OR [[targets]] in builder.toml to specify the desired platform")
+			if !flags.Watch {
+				return createBuilder()
 			}
 
-			imageName := args[0]
-			if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
-				RelativeBaseDir: relativeBaseDir,
-				BuildConfigEnv:  envMap,
-				BuilderName:     imageName,
-				Config:          builderConfig,
-				Publish:         flags.Publish,
-				Registry:        flags.Registry,
-				PullPolicy:      pullPolicy,
-				Flatten:         toFlatten,
-				Labels:          flags.Label,
-				Targets:         multiArchCfg.Targets(),
-			}); err != nil {
-				return err
+			updateBuilder := func(buildpacks []string) error {
+				updatePolicy := image.PullNever
+				if flags.Publish {
+					updatePolicy = image.PullAlways
+				}
+				return pack.UpdateBuilder(cmd.Context(), client.UpdateBuilderOptions{
+					BuilderName:     imageName,
+					Buildpacks:      buildpacks,
+					RelativeBaseDir: relativeBaseDir,
+					Publish:         flags.Publish,
+					PullPolicy:      updatePolicy,
+					Registry:        flags.Registry,
+				})
 			}
-			logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
-			logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
-			return nil
+			return watchBuilder(cmd.Context(), logger, flags.BuilderTomlPath, flags.Variables, relativeBaseDir, flags.WatchInterval, createBuilder, updateBuilder)
 		}),
 	}
 
 	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
-	if !cfg.Experimental {
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
 		cmd.Flags().MarkHidden("buildpack-registry")
 	}
-	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML file (required)")
-	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon.")
-	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML or YAML file (required)")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon. No daemon is needed to publish.")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", formatFlagUsage)
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Resolve the build image, lifecycle and buildpacks, and print them with their digests and sizes, without saving or publishing the builder")
 	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "List of buildpacks to flatten together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to the builder image, in the form of '<name>=<value>'")
+	cmd.Flags().StringToStringVar(&flags.Annotation, "annotation", nil, "OCI manifest annotations to add to the builder image, in the form of '<name>=<value>'")
+	cmd.Flags().StringToStringVar(&flags.Variables, "set", nil, "Set a variable referenced as ${<name>} in the config, in the form of '<name>=<value>'. Environment variables are used for variables that are not set")
+	cmd.Flags().BoolVar(&flags.Watch, "watch", false, "Watch the builder config, and the local buildpacks and extensions it references, and re-create the builder when they change.\nWhen only local buildpacks change, their layers are replaced on the builder rather than re-creating it.")
+	cmd.Flags().DurationVar(&flags.WatchInterval, "watch-interval", time.Second, "Interval to check for changes at, with --watch")
+	cmd.Flags().StringVar(&flags.MemoryLimit, "memory-limit", "", "Size of the contents kept in memory while creating the layers of the builder, such as 64MB, beyond which they're written to temporary files (default 32MiB)")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.\nTargets should be in the format '[os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]'.
 - To specify two different architectures:  '--target "linux/amd64" --target "linux/arm64"'
@@ -147,8 +206,24 @@ func validateCreateFlags(flags *BuilderCreateFlags, cfg config.Config) error {
 		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
 	}
 
-	if flags.Registry != "" && !cfg.Experimental {
-		return client.NewExperimentError("Support for buildpack registries is currently experimental.")
+	if flags.Registry != "" && !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		return client.NewFeatureExperimentError(config.FeatureBuildpackRegistry, "Support for buildpack registries is currently experimental.")
+	}
+
+	if err := validateFormat(flags.Format, flags.Publish); err != nil {
+		return err
+	}
+
+	if flags.Watch && writesToHost(flags.Format) {
+		return errors.New("--watch can only be used with --format image")
+	}
+
+	if flags.Watch && flags.DryRun {
+		return errors.New("--watch and --dry-run cannot be used together")
+	}
+
+	if flags.Watch && len(flags.Targets) > 1 {
+		return errors.New("--watch can only be used with a single target")
 	}
 
 	if flags.BuilderTomlPath == "" {
@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -462,6 +463,103 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--annotation", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes annotations to the client", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsAnnotations(map[string]string{
+					"org.opencontainers.image.source": "https://example.com/repo",
+				})).Return(nil)
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--annotation", "org.opencontainers.image.source=https://example.com/repo",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--dry-run", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes dry run to the client without logging success", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsDryRun(true)).Return(nil)
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--dry-run",
+				})
+				h.AssertNil(t, command.Execute())
+				h.AssertNotContains(t, outBuf.String(), "Successfully created builder image")
+			})
+		})
+
+		when("--memory-limit", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the memory limit to the client", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsMemoryLimit(64<<20)).Return(nil)
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--memory-limit", "64MiB",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails on invalid sizes", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--memory-limit", "lots",
+				})
+				h.AssertError(t, command.Execute(), "invalid memory limit 'lots'")
+			})
+		})
+
+		when("--format", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the format to the client and reports where the builder was saved", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsFormat(client.FormatOCILayout)).Return(nil)
+
+				command.SetArgs([]string{"some-layout", "--config", builderConfigPath, "--format", "oci-layout"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Successfully created builder 'some-layout' and saved to OCI layout")
+			})
+
+			it("allows several targets for builders written to the host", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsTargets([]dist.Target{
+					{OS: "linux", Arch: "amd64"},
+					{OS: "linux", Arch: "arm64"},
+				})).Return(nil)
+
+				command.SetArgs([]string{"some-builder.tar", "--config", builderConfigPath, "--format", "file", "--target", "linux/amd64", "--target", "linux/arm64"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when publishing a format other than image", func() {
+				command.SetArgs([]string{"some/builder", "--config", builderConfigPath, "--format", "file", "--publish"})
+				h.AssertError(t, command.Execute(), "--publish cannot be used with --format file, only images can be published")
+			})
+
+			it("errors when watching a format other than image", func() {
+				command.SetArgs([]string{"some-layout", "--config", builderConfigPath, "--format", "oci-layout", "--watch"})
+				h.AssertError(t, command.Execute(), "--watch can only be used with --format image")
+			})
+		})
+
 		when("multi-platform builder is expected to be created", func() {
 			when("builder config has no targets defined", func() {
 				it.Before(func() {
@@ -555,6 +653,74 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("--watch", func() {
+			var (
+				ctx    context.Context
+				cancel context.CancelFunc
+			)
+
+			it.Before(func() {
+				ctx, cancel = context.WithCancel(context.Background())
+				h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "some-buildpack"), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "some-buildpack", "buildpack.toml"), []byte("some-contents"), 0644))
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[[buildpacks]]
+  uri = "some-buildpack"
+
+[[order]]
+  [[order.group]]
+    id = "some.buildpack"
+`), 0666))
+			})
+
+			it.After(func() {
+				cancel()
+			})
+
+			it("re-creates the builder when the config changes", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, client.CreateBuilderOptions) error {
+						f, err := os.OpenFile(builderConfigPath, os.O_APPEND|os.O_WRONLY, 0666)
+						h.AssertNil(t, err)
+						defer f.Close()
+						_, err = f.WriteString("# changed\n")
+						return err
+					}),
+					mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, client.CreateBuilderOptions) error {
+						cancel()
+						return nil
+					}),
+				)
+
+				command.SetArgs([]string{"some/builder", "--config", builderConfigPath, "--watch", "--watch-interval", "10ms"})
+				h.AssertNil(t, command.ExecuteContext(ctx))
+				h.AssertContains(t, outBuf.String(), "Builder config changed, re-creating builder...")
+			})
+
+			it("updates the builder when only local buildpacks change", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, client.CreateBuilderOptions) error {
+					return os.WriteFile(filepath.Join(tmpDir, "some-buildpack", "buildpack.toml"), []byte("some-other-contents"), 0644)
+				})
+				mockClient.EXPECT().UpdateBuilder(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts client.UpdateBuilderOptions) error {
+					h.AssertEq(t, opts.BuilderName, "some/builder")
+					h.AssertEq(t, opts.Buildpacks, []string{"some-buildpack"})
+					cancel()
+					return nil
+				})
+
+				command.SetArgs([]string{"some/builder", "--config", builderConfigPath, "--watch", "--watch-interval", "10ms"})
+				h.AssertNil(t, command.ExecuteContext(ctx))
+				h.AssertContains(t, outBuf.String(), "Buildpacks some-buildpack changed, updating builder...")
+			})
+
+			when("--dry-run is provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"some/builder", "--config", builderConfigPath, "--watch", "--dry-run"})
+					h.AssertError(t, command.Execute(), "--watch and --dry-run cannot be used together")
+				})
+			})
+		})
 	})
 }
 
@@ -570,6 +736,42 @@ func EqCreateBuilderOptionsTargets(targets []dist.Target) gomock.Matcher {
 	}
 }
 
+func EqCreateBuilderOptionsFormat(format string) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("Format=%s", format),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return o.Format == format
+		},
+	}
+}
+
+func EqCreateBuilderOptionsAnnotations(annotations map[string]string) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("Annotations=%v", annotations),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return reflect.DeepEqual(o.Annotations, annotations)
+		},
+	}
+}
+
+func EqCreateBuilderOptionsDryRun(dryRun bool) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("DryRun=%t", dryRun),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return o.DryRun == dryRun
+		},
+	}
+}
+
+func EqCreateBuilderOptionsMemoryLimit(limit int64) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("MemoryLimit=%d", limit),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return o.MemoryLimit == limit
+		},
+	}
+}
+
 type createbuilderOptionsMatcher struct {
 	equals      func(options client.CreateBuilderOptions) bool
 	description string
@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// builderSources are the digests of the contents of a builder config, and of the local buildpacks and extensions it
+// references by URI
+type builderSources struct {
+	config     string
+	buildpacks map[string]string
+	extensions map[string]string
+
+	// whether the builder is created for several targets, and can't be updated in place
+	multiTarget bool
+}
+
+// readBuilderSources reads the digests of the sources of a builder. Modification times are ignored, so that only
+// changes of contents are detected.
+func readBuilderSources(configPath string, variables map[string]string, relativeBaseDir string) (builderSources, error) {
+	configDigest, err := fileDigest(configPath)
+	if err != nil {
+		return builderSources{}, errors.Wrapf(err, "reading builder config %s", style.Symbol(configPath))
+	}
+	sources := builderSources{config: configDigest}
+
+	builderConfig, _, err := builder.ReadConfigWithVariables(configPath, variables)
+	if err != nil {
+		// the builder config is re-created, and its errors reported, once the config changes
+		return sources, nil
+	}
+	sources.multiTarget = len(builderConfig.Targets) > 1
+
+	if sources.buildpacks, err = localModuleDigests(builderConfig.Buildpacks, relativeBaseDir); err != nil {
+		return builderSources{}, err
+	}
+	if sources.extensions, err = localModuleDigests(builderConfig.Extensions, relativeBaseDir); err != nil {
+		return builderSources{}, err
+	}
+	return sources, nil
+}
+
+// changedBuildpacks returns the URIs of the local buildpacks that changed since the previous sources, and whether
+// anything but local buildpacks changed, requiring the builder to be re-created
+func (s builderSources) changedBuildpacks(previous builderSources) (uris []string, recreate bool) {
+	if s.config != previous.config || !equalDigests(s.extensions, previous.extensions) {
+		return nil, true
+	}
+
+	for uri, digest := range s.buildpacks {
+		if previous.buildpacks[uri] != digest {
+			uris = append(uris, uri)
+		}
+	}
+	if len(uris) > 0 && s.multiTarget {
+		return nil, true
+	}
+	sort.Strings(uris)
+	return uris, false
+}
+
+func localModuleDigests(modules []builder.ModuleConfig, relativeBaseDir string) (map[string]string, error) {
+	digests := map[string]string{}
+	for _, module := range modules {
+		path, ok := localModulePath(module.URI, relativeBaseDir)
+		if !ok {
+			continue
+		}
+
+		digest, err := pathDigest(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", style.Symbol(module.URI))
+		}
+		digests[module.URI] = digest
+	}
+	return digests, nil
+}
+
+// localModulePath returns the path of a buildpack or extension on the filesystem, if its URI refers to one
+func localModulePath(uri, relativeBaseDir string) (string, bool) {
+	path := uri
+	switch {
+	case uri == "":
+		return "", false
+	case strings.HasPrefix(uri, "file://"):
+		var err error
+		if path, err = paths.URIToFilePath(uri); err != nil {
+			return "", false
+		}
+	case paths.IsURI(uri):
+		return "", false
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(relativeBaseDir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+func pathDigest(path string) (string, error) {
+	isDir, err := paths.IsDir(path)
+	if err != nil {
+		return "", err
+	}
+	if !isDir {
+		return fileDigest(path)
+	}
+
+	rc := archive.ReadDirAsTar(path, "/", 0, 0, -1, true, false, nil)
+	defer rc.Close()
+	return readerDigest(rc)
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return readerDigest(f)
+}
+
+func readerDigest(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func equalDigests(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// watchBuilder creates the builder, and then checks its sources for changes at every interval until the context is
+// done. The builder is re-created when its config changes; when only local buildpacks change, their layers are
+// replaced on the builder instead. Failures are logged rather than returned, so that they can be fixed while watching,
+// and the builder is re-created on the next change.
+func watchBuilder(
+	ctx context.Context,
+	logger logging.Logger,
+	configPath string,
+	variables map[string]string,
+	relativeBaseDir string,
+	interval time.Duration,
+	create func() error,
+	update func(buildpacks []string) error,
+) error {
+	sources, err := readBuilderSources(configPath, variables, relativeBaseDir)
+	if err != nil {
+		return err
+	}
+	if err := create(); err != nil {
+		return err
+	}
+	logger.Infof("Watching %s for changes...", style.Symbol(configPath))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := readBuilderSources(configPath, variables, relativeBaseDir)
+		if err != nil {
+			logger.Warnf("Failed to check for changes: %s", err)
+			continue
+		}
+
+		buildpacks, recreate := current.changedBuildpacks(sources)
+		switch {
+		case recreate || (failed && len(buildpacks) > 0):
+			logger.Info("Builder config changed, re-creating builder...")
+			err = create()
+		case len(buildpacks) > 0:
+			logger.Infof("Buildpacks %s changed, updating builder...", strings.Join(buildpacks, ", "))
+			err = update(buildpacks)
+		default:
+			continue
+		}
+		sources = current
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		failed = err != nil
+		if failed {
+			logger.Errorf("%s", err)
+			logger.Info("The builder will be re-created on the next change")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderDiffFlags define flags provided to the BuilderDiff command
+type BuilderDiffFlags struct {
+	OutputFormat string
+	Publish      bool
+}
+
+// BuilderDiff compares two builders, such as to validate a builder upgrade
+func BuilderDiff(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags BuilderDiffFlags
+
+	cmd := &cobra.Command{
+		Use:   "diff <builder-image-name> <other-builder-image-name>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Compare two builders",
+		Long: "Compare two builders, showing the buildpacks and extensions added, removed or with versions changed, " +
+			"the change of lifecycle version, the run images added, removed or with mirrors changed, and the groups " +
+			"of the detection order that changed, such as to validate a builder upgrade.",
+		Example: "pack builder diff cnbs/sample-builder:jammy my-registry.example.com/sample-builder:jammy-next",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			diff, err := pack.DiffBuilders(client.DiffBuildersOptions{
+				From:   args[0],
+				To:     args[1],
+				Daemon: !flags.Publish,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling diff")
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			writeBuilderDiff(logger, diff)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, human-readable)")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Read the builders from the registry, rather than from the daemon")
+
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
+
+func writeBuilderDiff(logger logging.Logger, diff *client.BuilderDiff) {
+	if diff.Empty() {
+		logger.Infof("Builders %s and %s have no differences", style.Symbol(diff.From), style.Symbol(diff.To))
+		return
+	}
+	logger.Infof("Differences from builder %s to %s:", style.Symbol(diff.From), style.Symbol(diff.To))
+
+	if diff.Lifecycle != nil {
+		logger.Info("")
+		logger.Infof("Lifecycle: %s -> %s", orDash(diff.Lifecycle.From), orDash(diff.Lifecycle.To))
+	}
+
+	writeModuleChanges(logger, "Buildpacks", diff.Buildpacks)
+	writeModuleChanges(logger, "Extensions", diff.Extensions)
+
+	if len(diff.RunImages) > 0 {
+		logger.Info("")
+		logger.Info("Run Images:")
+		tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "  CHANGE\tIMAGE\tFROM MIRRORS\tTO MIRRORS")
+		for _, change := range diff.RunImages {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", change.Change, change.Image, orDash(strings.Join(change.FromMirrors, ", ")), orDash(strings.Join(change.ToMirrors, ", ")))
+		}
+		tw.Flush()
+	}
+
+	if diff.Order != nil {
+		logger.Info("")
+		logger.Info("Detection Order:")
+		for _, group := range diff.Order.Removed {
+			logger.Infof("  - %s", group)
+		}
+		for _, group := range diff.Order.Added {
+			logger.Infof("  + %s", group)
+		}
+	}
+}
+
+func writeModuleChanges(logger logging.Logger, title string, changes []client.ModuleChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	logger.Info("")
+	logger.Infof("%s:", title)
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "  CHANGE\tID\tFROM\tTO")
+	for _, change := range changes {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", change.Change, change.ID, orDash(strings.Join(change.From, ", ")), orDash(strings.Join(change.To, ", ")))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,100 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderDiffCommand", testBuilderDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+
+		diff = &client.BuilderDiff{
+			From:       "some/builder",
+			To:         "some/builder:next",
+			Buildpacks: []client.ModuleChange{{ID: "some/buildpack", Change: client.ModuleChanged, From: []string{"1.0.0"}, To: []string{"2.0.0"}}},
+			Lifecycle:  &client.VersionChange{From: "0.19.0", To: "0.20.0"},
+			RunImages:  []client.RunImageChange{{Image: "some/run", Change: client.ModuleAdded}},
+			Order:      &client.OrderChange{Removed: []string{"some/buildpack@1.0.0"}, Added: []string{"some/buildpack@2.0.0"}},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderDiff(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderDiff", func() {
+		it("shows the differences between the builders", func() {
+			mockClient.EXPECT().DiffBuilders(client.DiffBuildersOptions{From: "some/builder", To: "some/builder:next", Daemon: true}).Return(diff, nil)
+
+			command.SetArgs([]string{"some/builder", "some/builder:next"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Differences from builder 'some/builder' to 'some/builder:next':")
+			h.AssertContains(t, outBuf.String(), "Lifecycle: 0.19.0 -> 0.20.0")
+			h.AssertContainsMatch(t, outBuf.String(), `changed\s+some/buildpack\s+1.0.0\s+2.0.0`)
+			h.AssertContainsMatch(t, outBuf.String(), `added\s+some/run\s+-\s+-`)
+			h.AssertContains(t, outBuf.String(), "  - some/buildpack@1.0.0\n")
+			h.AssertContains(t, outBuf.String(), "  + some/buildpack@2.0.0\n")
+		})
+
+		it("reads the builders from the registry with --publish", func() {
+			mockClient.EXPECT().DiffBuilders(client.DiffBuildersOptions{From: "some/builder", To: "some/builder:next"}).Return(&client.BuilderDiff{From: "some/builder", To: "some/builder:next"}, nil)
+
+			command.SetArgs([]string{"some/builder", "some/builder:next", "--publish"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Builders 'some/builder' and 'some/builder:next' have no differences")
+		})
+
+		it("shows the differences as json", func() {
+			mockClient.EXPECT().DiffBuilders(gomock.Any()).Return(diff, nil)
+
+			command.SetArgs([]string{"some/builder", "some/builder:next", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"lifecycle": {
+    "from": "0.19.0",
+    "to": "0.20.0"
+  }`)
+		})
+
+		it("fails for unknown output formats", func() {
+			command.SetArgs([]string{"some/builder", "some/builder:next", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml', must be one of human-readable or json")
+		})
+
+		it("fails when the builders can't be compared", func() {
+			mockClient.EXPECT().DiffBuilders(gomock.Any()).Return(nil, errors.New("builder 'some/builder' not found"))
+
+			command.SetArgs([]string{"some/builder", "some/builder:next"})
+			h.AssertError(t, command.Execute(), "builder 'some/builder' not found")
+		})
+	})
+}
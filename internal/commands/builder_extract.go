@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderExtractFlags define flags provided to the BuilderExtract command
+type BuilderExtractFlags struct {
+	Buildpack string
+	Output    string
+	Format    string
+	Publish   bool
+	Policy    string
+}
+
+// BuilderExtract re-packages a buildpack of a builder as a buildpackage of its own
+func BuilderExtract(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderExtractFlags
+
+	cmd := &cobra.Command{
+		Use:   "extract <builder> --buildpack <id@version> --output <name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Extract a buildpack from a builder as a buildpackage",
+		Long: "Re-package a buildpack of a builder as a standalone buildpackage, along with the buildpacks in its order, " +
+			"such as to fork a buildpack of a builder or to pin its version.",
+		Example: "pack builder extract paketobuildpacks/builder-jammy-base --buildpack paketo-buildpacks/node-engine@1.2.3 --output ./node-engine.cnb",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Buildpack == "" {
+				return errors.Errorf("%s is required", style.Symbol("--buildpack"))
+			}
+			if flags.Output == "" {
+				return errors.Errorf("%s is required", style.Symbol("--output"))
+			}
+			if err := validateFormat(flags.Format, flags.Publish); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrap(err, "parsing pull policy")
+			}
+
+			name := packageFileName(logger, flags.Output, flags.Format, "buildpack")
+			if err := pack.ExtractBuildpack(cmd.Context(), client.ExtractBuildpackOptions{
+				BuilderName: args[0],
+				Buildpack:   flags.Buildpack,
+				Name:        name,
+				Format:      flags.Format,
+				Publish:     flags.Publish,
+				PullPolicy:  pullPolicy,
+			}); err != nil {
+				return err
+			}
+
+			action, location := savedLocation(flags.Format, flags.Publish)
+			logger.Infof("Successfully %s package %s of buildpack %s and saved to %s", action, style.Symbol(name), style.Symbol(flags.Buildpack), location)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Buildpack, "buildpack", "b", "", "Buildpack to extract, in the form of '<buildpack-id>@<buildpack-version>', or '<buildpack-id>' when the builder has a single version of it (required)")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Name of the buildpackage to save the buildpack as: a path for files and OCI layouts, or an image name (required)")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", client.FormatFile, formatFlagUsage)
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, `Publish the buildpackage directly to the container registry specified in --output, instead of the daemon (applies to "--format=image" only).`)
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+
+	AddHelpFlag(cmd, "extract")
+	return cmd
+}
@@ -0,0 +1,95 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderExtractCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderExtractCommand", testBuilderExtractCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderExtractCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderExtract(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderExtract", func() {
+		it("extracts the buildpack to a file", func() {
+			mockClient.EXPECT().ExtractBuildpack(gomock.Any(), client.ExtractBuildpackOptions{
+				BuilderName: "some/builder",
+				Buildpack:   "some/buildpack@1.2.3",
+				Name:        "some-buildpack.cnb",
+				Format:      client.FormatFile,
+				PullPolicy:  image.PullAlways,
+			}).Return(nil)
+
+			command.SetArgs([]string{"some/builder", "--buildpack", "some/buildpack@1.2.3", "--output", "some-buildpack"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully created package 'some-buildpack.cnb' of buildpack 'some/buildpack@1.2.3' and saved to file")
+		})
+
+		it("publishes the buildpack as an image", func() {
+			mockClient.EXPECT().ExtractBuildpack(gomock.Any(), client.ExtractBuildpackOptions{
+				BuilderName: "some/builder",
+				Buildpack:   "some/buildpack",
+				Name:        "registry.example.com/some/buildpack:1.2.3",
+				Format:      client.FormatImage,
+				Publish:     true,
+				PullPolicy:  image.PullIfNotPresent,
+			}).Return(nil)
+
+			command.SetArgs([]string{"some/builder", "-b", "some/buildpack", "-o", "registry.example.com/some/buildpack:1.2.3", "--format", "image", "--publish", "--pull-policy", "if-not-present"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully published package 'registry.example.com/some/buildpack:1.2.3' of buildpack 'some/buildpack' and saved to registry")
+		})
+
+		it("requires the buildpack", func() {
+			command.SetArgs([]string{"some/builder", "--output", "some-buildpack.cnb"})
+			h.AssertError(t, command.Execute(), "'--buildpack' is required")
+		})
+
+		it("requires the output", func() {
+			command.SetArgs([]string{"some/builder", "--buildpack", "some/buildpack"})
+			h.AssertError(t, command.Execute(), "'--output' is required")
+		})
+
+		it("fails when the buildpack can't be extracted", func() {
+			mockClient.EXPECT().ExtractBuildpack(gomock.Any(), gomock.Any()).Return(errors.New("image has no buildpack"))
+
+			command.SetArgs([]string{"some/builder", "--buildpack", "some/buildpack", "--output", "some-buildpack.cnb"})
+			h.AssertError(t, command.Execute(), "image has no buildpack")
+		})
+	})
+}
@@ -15,8 +15,9 @@ type BuilderInspector interface {
 }
 
 type BuilderInspectFlags struct {
-	Depth        int
-	OutputFormat string
+	Depth          int
+	OutputFormat   string
+	CheckRunImages bool
 }
 
 func BuilderInspect(logger logging.Logger,
@@ -39,7 +40,7 @@ func BuilderInspect(logger logging.Logger,
 			}
 
 			if imageName == "" {
-				suggestSettingBuilder(logger, inspector)
+				suggestSettingBuilder(logger, cfg, inspector)
 				return client.NewSoftError()
 			}
 
@@ -49,7 +50,9 @@ func BuilderInspect(logger logging.Logger,
 
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", builder.OrderDetectionMaxDepth, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	cmd.Flags().BoolVar(&flags.CheckRunImages, "check-run-images", false, "Check the availability of the run images and of their mirrors in their registries, flagging the mirrors that are unreachable or stale")
 	AddHelpFlag(cmd, "inspect")
+	cmd.ValidArgsFunction = completeBuilders(cfg)
 	return cmd
 }
 
@@ -67,8 +70,17 @@ func inspectBuilder(
 		Trusted:   isTrustedBuilder(cfg, imageName),
 	}
 
-	localInfo, localErr := inspector.InspectBuilder(imageName, true, client.WithDetectionOrderDepth(flags.Depth))
-	remoteInfo, remoteErr := inspector.InspectBuilder(imageName, false, client.WithDetectionOrderDepth(flags.Depth))
+	modifiers := []client.BuilderInspectionModifier{client.WithDetectionOrderDepth(flags.Depth)}
+	if flags.CheckRunImages {
+		var localMirrors []builder.RunImageConfig
+		for _, runImage := range cfg.RunImages {
+			localMirrors = append(localMirrors, builder.RunImageConfig{Image: runImage.Image, Mirrors: runImage.Mirrors})
+		}
+		modifiers = append(modifiers, client.WithRunImageChecks(localMirrors...))
+	}
+
+	localInfo, localErr := inspector.InspectBuilder(imageName, true, modifiers...)
+	remoteInfo, remoteErr := inspector.InspectBuilder(imageName, false, modifiers...)
 
 	writer, err := writerFactory.Writer(flags.OutputFormat)
 	if err != nil {
@@ -133,6 +133,23 @@ func testBuilderInspectCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("check-run-images flag is provided", func() {
+			it("passes a modifier checking the run images along with the user-configured mirrors", func() {
+				builderInspector := newDefaultBuilderInspector()
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+				command.SetArgs([]string{"--check-run-images"})
+
+				err := command.Execute()
+				assert.Nil(err)
+
+				expectedMirrors := []pubbldr.RunImageConfig{{Image: "some/run-image", Mirrors: []string{"first/local", "second/local"}}}
+				assert.Equal(builderInspector.CalculatedConfigForLocal.CheckRunImages, true)
+				assert.Equal(builderInspector.CalculatedConfigForLocal.AdditionalMirrors, expectedMirrors)
+				assert.Equal(builderInspector.CalculatedConfigForRemote.CheckRunImages, true)
+				assert.Equal(builderInspector.CalculatedConfigForRemote.AdditionalMirrors, expectedMirrors)
+			})
+		})
+
 		when("output type is set to json", func() {
 			it("passes json to the writer factory", func() {
 				writerFactory := newDefaultWriterFactory()
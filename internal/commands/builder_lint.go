@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderLintFlags define flags provided to the BuilderLint command
+type BuilderLintFlags struct {
+	OutputFormat string
+	Strict       bool
+	Publish      bool
+	Policy       string
+	Registry     string
+	Variables    map[string]string
+}
+
+// BuilderLint validates a builder configuration without creating a builder
+func BuilderLint(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderLintFlags
+
+	cmd := &cobra.Command{
+		Use:   "lint <builder-config-path>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check a builder config for problems",
+		Long: "Validate order groups, buildpack references, build and run image labels, and lifecycle compatibility of a builder config, " +
+			"reporting the problems that would cause `pack builder create` to fail.\n\n" +
+			"The command fails if any error is found, or if any warning is found when --strict is provided. Use --output json for machine-readable findings.",
+		Example: "pack builder lint ./builder.toml",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateLintOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			builderConfig, warns, err := builder.ReadConfigWithVariables(args[0], flags.Variables)
+			if err != nil {
+				return errors.Wrap(err, "invalid builder toml")
+			}
+
+			relativeBaseDir, err := filepath.Abs(filepath.Dir(args[0]))
+			if err != nil {
+				return errors.Wrap(err, "getting absolute path for config")
+			}
+
+			findings, err := pack.LintBuilder(cmd.Context(), client.LintBuilderOptions{
+				Config:          builderConfig,
+				RelativeBaseDir: relativeBaseDir,
+				Registry:        flags.Registry,
+				Publish:         flags.Publish,
+				PullPolicy:      pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			var configFindings []buildpack.LintFinding
+			for _, w := range warns {
+				configFindings = append(configFindings, buildpack.LintFinding{
+					Rule:     "config",
+					Severity: buildpack.LintSeverityWarning,
+					Message:  w,
+				})
+			}
+
+			return reportLintFindings(logger, flags.OutputFormat, flags.Strict, args[0], append(configFindings, findings...))
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display findings (json, human-readable)")
+	cmd.Flags().BoolVar(&flags.Strict, "strict", false, "Fail when warnings are found")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Fetch images from the registry instead of the daemon, as `pack builder create --publish` would")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	cmd.Flags().StringToStringVar(&flags.Variables, "set", nil, "Set a variable referenced as ${<name>} in the config, in the form of '<name>=<value>'. Environment variables are used for variables that are not set")
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "lint")
+	return cmd
+}
@@ -0,0 +1,130 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderLintCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderLintCommand", testBuilderLintCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderLintCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command           *cobra.Command
+		logger            logging.Logger
+		outBuf            bytes.Buffer
+		mockController    *gomock.Controller
+		mockClient        *testmocks.MockPackClient
+		tmpDir            string
+		builderConfigPath string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "builder-lint-test")
+		h.AssertNil(t, err)
+		builderConfigPath = filepath.Join(tmpDir, "builder.toml")
+		h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[[buildpacks]]
+id = "some.buildpack"
+uri = "some/buildpack"
+
+[[order]]
+[[order.group]]
+id = "some.buildpack"
+
+[build]
+image = "some/build-image"
+
+[[run.images]]
+image = "some/run-image"
+`), 0644))
+
+		command = commands.BuilderLint(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#BuilderLint", func() {
+		it("passes the config to the client", func() {
+			mockClient.EXPECT().
+				LintBuilder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, opts client.LintBuilderOptions) ([]buildpack.LintFinding, error) {
+					h.AssertEq(t, opts.Config.Build.Image, "some/build-image")
+					h.AssertEq(t, opts.Config.Run.Images, []pubbldr.RunImageConfig{{Image: "some/run-image"}})
+					h.AssertEq(t, opts.Config.Buildpacks[0].URI, "some/buildpack")
+					h.AssertEq(t, opts.RelativeBaseDir, tmpDir)
+					h.AssertEq(t, opts.PullPolicy, image.PullAlways)
+					return nil, nil
+				})
+
+			command.SetArgs([]string{builderConfigPath})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No problems found")
+		})
+
+		it("fails when errors are found", func() {
+			mockClient.EXPECT().LintBuilder(gomock.Any(), gomock.Any()).Return([]buildpack.LintFinding{{
+				Buildpack: "some.buildpack",
+				Rule:      "reference",
+				Severity:  buildpack.LintSeverityError,
+				Path:      "order[0]",
+				Message:   "no versions of buildpack 'some.buildpack' were found",
+			}}, nil)
+
+			command.SetArgs([]string{builderConfigPath})
+			h.AssertError(t, command.Execute(), client.NewSoftError().Error())
+			h.AssertContains(t, outBuf.String(), "error      some.buildpack   reference   order[0]   no versions of buildpack 'some.buildpack' were found")
+		})
+
+		it("reports config warnings as findings", func() {
+			h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[build]
+image = "some/build-image"
+
+[[run.images]]
+image = "some/run-image"
+`), 0644))
+			mockClient.EXPECT().LintBuilder(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			command.SetArgs([]string{builderConfigPath, "--output", "json", "--strict"})
+			h.AssertError(t, command.Execute(), client.NewSoftError().Error())
+			h.AssertContains(t, outBuf.String(), `"rule": "config"`)
+			h.AssertContains(t, outBuf.String(), `"message": "empty 'order' definition"`)
+		})
+
+		it("errors when the config cannot be read", func() {
+			command.SetArgs([]string{filepath.Join(tmpDir, "missing.toml")})
+			h.AssertError(t, command.Execute(), "invalid builder toml")
+		})
+	})
+}
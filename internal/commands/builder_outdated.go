@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderOutdatedFlags define flags provided to the BuilderOutdated command
+type BuilderOutdatedFlags struct {
+	OutputFormat string
+	Publish      bool
+	Registry     string
+	ConfigOutput string
+	ExitCode     bool
+}
+
+// BuilderOutdated reports the buildpacks of a builder with a newer version in the buildpack registry
+func BuilderOutdated(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderOutdatedFlags
+
+	cmd := &cobra.Command{
+		Use:   "outdated <builder-image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "List the buildpacks of a builder with newer versions in the buildpack registry",
+		Long: "Compare each buildpack of a builder to the latest version of it in the buildpack registry, and report the upgrades available.\n\n" +
+			"Use --config-output to write a builder config with the latest version of each buildpack, to create the upgraded builder with " +
+			"`pack builder create` once the build image of the builder is filled in.",
+		Example: "pack builder outdated cnbs/sample-builder:jammy --config-output builder.toml",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			report, err := pack.OutdatedBuilder(client.OutdatedBuilderOptions{
+				BuilderName: args[0],
+				Daemon:      !flags.Publish,
+				Registry:    flags.Registry,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling report")
+				}
+				logger.Info(string(out))
+			} else {
+				writeBuilderUpgradeReport(logger, report)
+			}
+
+			if flags.ConfigOutput != "" {
+				buf := &bytes.Buffer{}
+				if err := toml.NewEncoder(buf).Encode(report.Config); err != nil {
+					return errors.Wrap(err, "encoding builder config")
+				}
+				if err := os.WriteFile(flags.ConfigOutput, buf.Bytes(), 0600); err != nil {
+					return errors.Wrapf(err, "writing builder config to %s", style.Symbol(flags.ConfigOutput))
+				}
+				logger.Infof("Wrote the upgraded builder config to %s, set %s before creating the builder", style.Symbol(flags.ConfigOutput), style.Symbol("build.image"))
+			}
+
+			if flags.ExitCode && len(report.Outdated()) > 0 {
+				return client.NewSoftError()
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the report (json, human-readable)")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Read the builder from the registry, rather than from the daemon")
+	cmd.Flags().StringVar(&flags.ConfigOutput, "config-output", "", "Path to write a builder config with the latest version of each buildpack to")
+	cmd.Flags().BoolVar(&flags.ExitCode, "exit-code", false, "Exit with a non-zero status when buildpacks are outdated")
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "outdated")
+	return cmd
+}
+
+func writeBuilderUpgradeReport(logger logging.Logger, report *client.BuilderUpgradeReport) {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "BUILDPACK\tCURRENT\tLATEST\tSTATUS")
+	for _, upgrade := range report.Buildpacks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", upgrade.ID, upgrade.Current, orDash(upgrade.Latest), upgrade.Status)
+	}
+	tw.Flush()
+
+	outdated := report.Outdated()
+	logger.Info("")
+	if len(outdated) == 0 {
+		logger.Infof("Buildpacks of builder %s are up to date", style.Symbol(report.Builder))
+		return
+	}
+	logger.Infof("Upgrades available for builder %s:", style.Symbol(report.Builder))
+	for _, upgrade := range outdated {
+		logger.Infof("  %s: %s -> %s", upgrade.ID, upgrade.Current, upgrade.Latest)
+	}
+}
@@ -0,0 +1,110 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderOutdatedCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderOutdatedCommand", testBuilderOutdatedCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderOutdatedCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+
+		upgradeReport = &client.BuilderUpgradeReport{
+			Builder: "some/builder",
+			Buildpacks: []client.BuildpackUpgrade{
+				{ID: "example/foo", Current: "1.1.0", Latest: "1.2.0", Status: client.BuildpackOutdated},
+				{ID: "some-local-buildpack", Current: "0.1.0", Status: client.BuildpackNotInRegistry},
+			},
+			Config: pubbldr.Config{
+				Buildpacks: pubbldr.ModuleCollection{
+					{ModuleInfo: dist.ModuleInfo{ID: "example/foo", Version: "1.2.0"}, ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "urn:cnb:registry:example/foo@1.2.0"}}},
+				},
+			},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderOutdated(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderOutdated", func() {
+		it("prints the upgrade report", func() {
+			mockClient.EXPECT().OutdatedBuilder(client.OutdatedBuilderOptions{BuilderName: "some/builder", Daemon: true}).Return(upgradeReport, nil)
+
+			command.SetArgs([]string{"some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsMatch(t, outBuf.String(), `example/foo\s+1.1.0\s+1.2.0\s+outdated`)
+			h.AssertContainsMatch(t, outBuf.String(), `some-local-buildpack\s+0.1.0\s+-\s+not-in-registry`)
+			h.AssertContains(t, outBuf.String(), "Upgrades available for builder 'some/builder':\n  example/foo: 1.1.0 -> 1.2.0")
+		})
+
+		it("writes the upgraded builder config", func() {
+			mockClient.EXPECT().OutdatedBuilder(client.OutdatedBuilderOptions{BuilderName: "some/builder", Registry: "some-registry"}).Return(upgradeReport, nil)
+			configPath := filepath.Join(t.TempDir(), "builder.toml")
+
+			command.SetArgs([]string{"some/builder", "--publish", "--buildpack-registry", "some-registry", "--config-output", configPath})
+			h.AssertNil(t, command.Execute())
+
+			contents, err := os.ReadFile(configPath)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `uri = "urn:cnb:registry:example/foo@1.2.0"`)
+			h.AssertContains(t, outBuf.String(), "set 'build.image' before creating the builder")
+		})
+
+		it("exits with a non-zero status when buildpacks are outdated with --exit-code", func() {
+			mockClient.EXPECT().OutdatedBuilder(gomock.Any()).Return(upgradeReport, nil)
+
+			command.SetArgs([]string{"some/builder", "--exit-code"})
+			err := command.Execute()
+			_, isSoftError := err.(client.SoftError)
+			h.AssertTrue(t, isSoftError)
+		})
+
+		it("prints the report as json", func() {
+			mockClient.EXPECT().OutdatedBuilder(gomock.Any()).Return(upgradeReport, nil)
+
+			command.SetArgs([]string{"some/builder", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"latest": "1.2.0"`)
+			h.AssertNotContains(t, outBuf.String(), "urn:cnb:registry")
+		})
+
+		it("fails for unknown output formats", func() {
+			command.SetArgs([]string{"some/builder", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml', must be one of human-readable or json")
+		})
+	})
+}
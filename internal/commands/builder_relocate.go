@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderRelocateFlags define flags provided to the BuilderRelocate command
+type BuilderRelocateFlags struct {
+	Builder      string
+	To           string
+	ConfigOutput string
+}
+
+// BuilderRelocate copies a builder and the images it references to a registry, and prints the config to use them
+func BuilderRelocate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderRelocateFlags
+
+	cmd := &cobra.Command{
+		Use:   "relocate",
+		Args:  cobra.NoArgs,
+		Short: "Copy a builder and the images it uses to a private registry",
+		Long: "Copy a builder, the lifecycle image of its lifecycle version and its run images to a registry, such as a private one, " +
+			"keeping their names, tags and digests.\n\n" +
+			"The pack config to use the relocated images is printed, or written to the file given by --config-output, for developers to add to their config: " +
+			"the relocated builder as the default and trusted builder, the relocated lifecycle image, and the relocated run images as mirrors.",
+		Example: "pack builder relocate --builder paketobuildpacks/builder-jammy-base --to registry.example.com/cnb",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.To == "" {
+				return errors.Errorf("%s is required", style.Symbol("--to"))
+			}
+			builderName := flags.Builder
+			if builderName == "" {
+				builderName = cfg.DefaultBuilder
+			}
+			if builderName == "" {
+				return errors.Errorf("%s is required, as there is no default builder", style.Symbol("--builder"))
+			}
+
+			relocated, err := pack.RelocateBuilder(cmd.Context(), client.RelocateBuilderOptions{
+				Builder:  builderName,
+				Registry: flags.To,
+			})
+			if err != nil {
+				return err
+			}
+
+			contents, err := relocatedBuilderConfig(relocated)
+			if err != nil {
+				return err
+			}
+			if flags.ConfigOutput == "" {
+				logger.Infof("Add the following to your pack config to use the relocated images:\n\n%s", contents)
+				return nil
+			}
+			if err := os.WriteFile(flags.ConfigOutput, contents, 0600); err != nil {
+				return errors.Wrapf(err, "writing config to %s", style.Symbol(flags.ConfigOutput))
+			}
+			logger.Infof("Wrote the config to use the relocated images to %s", style.Symbol(flags.ConfigOutput))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder to relocate (defaults to the default builder)")
+	cmd.Flags().StringVar(&flags.To, "to", "", "Registry, and optional namespace, to copy the images to, such as registry.example.com/cnb")
+	cmd.Flags().StringVar(&flags.ConfigOutput, "config-output", "", "Path to write the pack config to use the relocated images to, instead of printing it")
+
+	AddHelpFlag(cmd, "relocate")
+	return cmd
+}
+
+// relocatedBuilderConfig returns the pack config using the relocated images of a builder, as TOML
+func relocatedBuilderConfig(relocated *client.RelocatedBuilder) ([]byte, error) {
+	relocatedCfg := config.Config{
+		DefaultBuilder:  relocated.Builder,
+		TrustedBuilders: []config.TrustedBuilder{{Name: relocated.Builder}},
+		LifecycleImage:  relocated.LifecycleImage,
+		RunImages:       []config.RunImage{},
+	}
+	for runImage, mirror := range relocated.RunImages {
+		relocatedCfg.RunImages = append(relocatedCfg.RunImages, config.RunImage{Image: runImage, Mirrors: []string{mirror}})
+	}
+	sort.Slice(relocatedCfg.RunImages, func(i, j int) bool {
+		return relocatedCfg.RunImages[i].Image < relocatedCfg.RunImages[j].Image
+	})
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(relocatedCfg); err != nil {
+		return nil, errors.Wrap(err, "encoding config")
+	}
+	return buf.Bytes(), nil
+}
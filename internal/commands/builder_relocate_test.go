@@ -0,0 +1,87 @@
+package commands_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderRelocateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderRelocateCommand", testBuilderRelocateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderRelocateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		relocated      *client.RelocatedBuilder
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderRelocate(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{DefaultBuilder: "some/builder"}, mockClient)
+		relocated = &client.RelocatedBuilder{
+			Builder:        "registry.example.com/cnb/builder:latest",
+			LifecycleImage: "registry.example.com/cnb/lifecycle:0.19.6",
+			RunImages:      map[string]string{"some/run:base": "registry.example.com/cnb/run:base"},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderRelocate", func() {
+		it("relocates the default builder and prints the config to use it", func() {
+			mockClient.EXPECT().RelocateBuilder(gomock.Any(), client.RelocateBuilderOptions{
+				Builder:  "some/builder",
+				Registry: "registry.example.com/cnb",
+			}).Return(relocated, nil)
+
+			command.SetArgs([]string{"--to", "registry.example.com/cnb"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `default-builder-image = "registry.example.com/cnb/builder:latest"`)
+			h.AssertContains(t, outBuf.String(), `lifecycle-image = "registry.example.com/cnb/lifecycle:0.19.6"`)
+			h.AssertContains(t, outBuf.String(), `name = "registry.example.com/cnb/builder:latest"`)
+			h.AssertContains(t, outBuf.String(), `image = "some/run:base"`)
+			h.AssertContains(t, outBuf.String(), `mirrors = ["registry.example.com/cnb/run:base"]`)
+		})
+
+		it("writes the config to a file", func() {
+			configOutput := filepath.Join(t.TempDir(), "config.toml")
+			mockClient.EXPECT().RelocateBuilder(gomock.Any(), gomock.Any()).Return(relocated, nil)
+
+			command.SetArgs([]string{"--builder", "other/builder", "--to", "registry.example.com/cnb", "--config-output", configOutput})
+			h.AssertNil(t, command.Execute())
+
+			cfg, err := config.Read(configOutput)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.DefaultBuilder, "registry.example.com/cnb/builder:latest")
+			h.AssertEq(t, cfg.RunImages, []config.RunImage{{Image: "some/run:base", Mirrors: []string{"registry.example.com/cnb/run:base"}}})
+		})
+
+		it("requires the registry to relocate to", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "'--to' is required")
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderShimsFlags define flags provided to the BuilderShims command
+type BuilderShimsFlags struct {
+	OutputFormat string
+	Policy       string
+	Publish      bool
+}
+
+// BuilderShims lists the Buildpack API shims the lifecycle of a builder provides, and the ones its buildpacks require
+func BuilderShims(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderShimsFlags
+
+	cmd := &cobra.Command{
+		Use:   "shims <builder-image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "List the Buildpack API shims of a builder",
+		Long: "List the Buildpack APIs the lifecycle of a builder supports natively, and the deprecated ones it supports through " +
+			"a compatibility shim, along with the Buildpack API of each buildpack and extension of the builder. " +
+			"Buildpacks using a Buildpack API the lifecycle no longer supports are reported with a warning.\n\n" +
+			"Run `pack config buildpack-api-shims` to choose what builds do when buildpacks use a shim.",
+		Example: "pack builder shims cnbs/sample-builder:jammy",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			report, err := pack.InspectBuildpackAPIShims(cmd.Context(), client.InspectBuildpackAPIShimsOptions{
+				BuilderName: args[0],
+				Daemon:      !flags.Publish,
+				PullPolicy:  pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling shims")
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			writeBuildpackAPIShims(logger, args[0], report)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the shims (json, human-readable)")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Read the builder from the registry, rather than from the daemon")
+
+	AddHelpFlag(cmd, "shims")
+	return cmd
+}
+
+func writeBuildpackAPIShims(logger logging.Logger, builderName string, report *client.BuildpackAPIShimsReport) {
+	logger.Infof("Lifecycle %s of builder %s", style.Symbol(report.LifecycleVersion), style.Symbol(builderName))
+	logger.Infof("  Native Buildpack APIs:  %s", orDash(strings.Join(report.NativeAPIs, ", ")))
+	logger.Infof("  Shimmed Buildpack APIs: %s", orDash(strings.Join(report.ShimmedAPIs, ", ")))
+	logger.Info("")
+
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tMODULE\tAPI\tSTATUS")
+	for _, module := range report.Modules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", module.Kind, module.Module, module.API, module.Status)
+	}
+	tw.Flush()
+
+	for _, module := range report.Modules {
+		if module.Status == client.BuildpackAPIUnsupported {
+			logger.Warnf("%s %s uses Buildpack API %s, which lifecycle %s doesn't support, even through a shim", module.Kind, style.Symbol(module.Module), module.API, style.Symbol(report.LifecycleVersion))
+		}
+	}
+}
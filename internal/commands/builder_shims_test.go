@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderShimsCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderShimsCommand", testBuilderShimsCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderShimsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		shims          = &client.BuildpackAPIShimsReport{
+			LifecycleVersion: "0.20.0",
+			NativeAPIs:       []string{"0.9", "0.10"},
+			ShimmedAPIs:      []string{"0.8"},
+			Modules: []client.BuildpackAPIShim{
+				{Module: "some/bp@1.0.0", Kind: "buildpack", API: "0.8", Status: client.BuildpackAPIShimmed},
+				{Module: "old/bp@1.0.0", Kind: "buildpack", API: "0.2", Status: client.BuildpackAPIUnsupported},
+			},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderShims(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderShims", func() {
+		it("lists the shims of the builder, and warns about unsupported Buildpack APIs", func() {
+			mockClient.EXPECT().InspectBuildpackAPIShims(gomock.Any(), client.InspectBuildpackAPIShimsOptions{
+				BuilderName: "some/builder",
+				Daemon:      true,
+				PullPolicy:  image.PullAlways,
+			}).Return(shims, nil)
+
+			command.SetArgs([]string{"some/builder"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Native Buildpack APIs:  0.9, 0.10")
+			h.AssertContains(t, outBuf.String(), "Shimmed Buildpack APIs: 0.8")
+			h.AssertContainsMatch(t, outBuf.String(), `buildpack\s+some/bp@1.0.0\s+0.8\s+shimmed`)
+			h.AssertContains(t, outBuf.String(), "Warning: buildpack 'old/bp@1.0.0' uses Buildpack API 0.2, which lifecycle '0.20.0' doesn't support, even through a shim")
+		})
+
+		it("reads the builder from the registry with --publish, and outputs json", func() {
+			mockClient.EXPECT().InspectBuildpackAPIShims(gomock.Any(), client.InspectBuildpackAPIShimsOptions{
+				BuilderName: "some/builder",
+				Daemon:      false,
+				PullPolicy:  image.PullAlways,
+			}).Return(shims, nil)
+
+			command.SetArgs([]string{"some/builder", "--publish", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), `"status": "unsupported"`)
+			h.AssertNotContains(t, outBuf.String(), "Warning")
+		})
+	})
+}
@@ -3,20 +3,24 @@ package commands
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func BuilderSuggest(logger logging.Logger, inspector BuilderInspector) *cobra.Command {
+func BuilderSuggest(logger logging.Logger, cfg config.Config, inspector BuilderInspector) *cobra.Command {
+	var refresh bool
 	cmd := &cobra.Command{
 		Use:     "suggest",
 		Args:    cobra.NoArgs,
 		Short:   "List the recommended builders",
 		Example: "pack builder suggest",
 		Run: func(cmd *cobra.Command, s []string) {
-			suggestBuilders(logger, inspector)
+			suggestBuilders(logger, cfg, inspector, refresh)
 		},
 	}
 
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Refresh suggested builders from the configured metadata source, ignoring any cached copy")
+
 	AddHelpFlag(cmd, "suggest")
 	return cmd
 }
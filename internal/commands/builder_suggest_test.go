@@ -13,6 +13,7 @@ import (
 	bldr "github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
@@ -91,4 +92,33 @@ func testSuggestCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("#BuilderSuggest", func() {
+		it.Before(func() {
+			mockClient.EXPECT().InspectBuilder(gomock.Any(), false).Return(nil, errors.New("some error")).AnyTimes()
+		})
+
+		it("displays the built-in suggestions", func() {
+			command := commands.BuilderSuggest(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Suggested builders:")
+			h.AssertContains(t, outBuf.String(), "paketobuildpacks/builder-jammy-base")
+		})
+
+		it("displays builders added to the config", func() {
+			cfg := config.Config{SuggestedBuilders: []config.SuggestedBuilder{{
+				Image:       "registry.example.com/platform/builder:latest",
+				Vendor:      "Platform Team",
+				Description: "Internal builder",
+			}}}
+			command := commands.BuilderSuggest(logger, cfg, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContainsMatch(t, outBuf.String(), `Platform Team:\s+'registry.example.com/platform/builder:latest'\s+Internal builder`)
+			h.AssertContains(t, outBuf.String(), "paketobuildpacks/builder-jammy-base")
+		})
+	})
 }
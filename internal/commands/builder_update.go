@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderUpdateFlags define flags provided to the BuilderUpdate command
+type BuilderUpdateFlags struct {
+	Buildpacks []string
+	Tag        string
+	Publish    bool
+	Policy     string
+	Registry   string
+}
+
+// BuilderUpdate adds or replaces buildpacks on an existing builder
+func BuilderUpdate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderUpdateFlags
+
+	cmd := &cobra.Command{
+		Use:   "update <builder-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Add or replace buildpacks on an existing builder",
+		Long: "Add or replace buildpacks on an existing builder, by adding their layers on top of it rather than re-creating the builder from its config.\n\n" +
+			"A buildpack with the ID of a buildpack in the order of the builder replaces it in the order; the previous version is kept on the builder for composite buildpacks referencing it.",
+		Example: "pack builder update my-builder:noble --buildpack ./my-buildpack --tag my-builder:noble-patched",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(flags.Buildpacks) == 0 {
+				return errors.New("at least one --buildpack is required")
+			}
+			if flags.Publish && flags.Policy == image.PullNever.String() {
+				return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
+			}
+			if flags.Registry != "" && !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+				return client.NewFeatureExperimentError(config.FeatureBuildpackRegistry, "Support for buildpack registries is currently experimental.")
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			relativeBaseDir, err := filepath.Abs(".")
+			if err != nil {
+				return errors.Wrap(err, "getting current directory")
+			}
+
+			return pack.UpdateBuilder(cmd.Context(), client.UpdateBuilderOptions{
+				BuilderName:     args[0],
+				Tag:             flags.Tag,
+				Buildpacks:      flags.Buildpacks,
+				RelativeBaseDir: relativeBaseDir,
+				Publish:         flags.Publish,
+				PullPolicy:      pullPolicy,
+				Registry:        flags.Registry,
+			})
+		}),
+	}
+
+	cmd.Flags().StringSliceVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to add to the builder, in any of the forms accepted by `pack build --buildpack`"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringVarP(&flags.Tag, "tag", "t", "", "Name of the updated builder (defaults to overwriting <builder-name>)")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Read the builder from, and publish the updated builder to, the container registry, instead of the daemon")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "update")
+	return cmd
+}
@@ -0,0 +1,87 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderUpdateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuilderUpdateCommand", testBuilderUpdateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderUpdateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderUpdate(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderUpdate", func() {
+		it("updates the builder with the buildpacks", func() {
+			var opts client.UpdateBuilderOptions
+			mockClient.EXPECT().UpdateBuilder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, o client.UpdateBuilderOptions) error {
+					opts = o
+					return nil
+				})
+
+			command.SetArgs([]string{
+				"some/builder",
+				"--buildpack", "docker://some/buildpack:2.0.0",
+				"--buildpack", "other/buildpack@1.0.0",
+				"--tag", "some/builder:patched",
+				"--publish",
+				"--pull-policy", "if-not-present",
+			})
+			h.AssertNil(t, command.Execute())
+			h.AssertEq(t, opts.BuilderName, "some/builder")
+			h.AssertEq(t, opts.Tag, "some/builder:patched")
+			h.AssertEq(t, opts.Buildpacks, []string{"docker://some/buildpack:2.0.0", "other/buildpack@1.0.0"})
+			h.AssertEq(t, opts.Publish, true)
+			h.AssertEq(t, opts.PullPolicy, image.PullIfNotPresent)
+		})
+
+		when("no buildpack is provided", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"some/builder"})
+				h.AssertError(t, command.Execute(), "at least one --buildpack is required")
+			})
+		})
+
+		when("--publish and --pull-policy never are provided", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"some/builder", "--buildpack", "some/buildpack@1.0.0", "--publish", "--pull-policy", "never"})
+				h.AssertError(t, command.Execute(), "--publish and --pull-policy never cannot be used together")
+			})
+		})
+	})
+}
@@ -15,7 +15,10 @@ func NewBuildpackCommand(logger logging.Logger, cfg config.Config, client PackCl
 		RunE:    nil,
 	}
 
+	cmd.AddCommand(BuildpackCNB(logger, client))
+	cmd.AddCommand(BuildpackCopy(logger, cfg, client))
 	cmd.AddCommand(BuildpackInspect(logger, cfg, client))
+	cmd.AddCommand(BuildpackLint(logger, cfg, client))
 	cmd.AddCommand(BuildpackPackage(logger, cfg, client, packageConfigReader))
 	cmd.AddCommand(BuildpackNew(logger, client))
 	cmd.AddCommand(BuildpackPull(logger, cfg, client))
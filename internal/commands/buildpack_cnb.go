@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackCNB groups the commands operating on .cnb files
+func BuildpackCNB(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cnb",
+		Short: "Operate on .cnb files",
+		Long:  "A .cnb file is a packaged buildpack, along with the buildpacks it depends on, in OCI layout format, as written by `pack buildpack package --format file`.",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(buildpackCNBSplit(logger, pack))
+	cmd.AddCommand(buildpackCNBMerge(logger, pack))
+
+	AddHelpFlag(cmd, "cnb")
+	return cmd
+}
+
+func buildpackCNBSplit(logger logging.Logger, pack PackClient) *cobra.Command {
+	var outputDir string
+
+	cmd := &cobra.Command{
+		Use:     "split <path>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Write each buildpack of a .cnb file to a .cnb file of its own",
+		Long:    "Write each buildpack of a .cnb file to a .cnb file of its own, along with the buildpacks it depends on, such as to extract the buildpacks nested in a composite buildpack.",
+		Example: "pack buildpack cnb split java.cnb --output-dir ./buildpacks",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			written, err := pack.SplitCNB(cmd.Context(), client.SplitCNBOptions{
+				Path:      args[0],
+				OutputDir: outputDir,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, path := range written {
+				logger.Infof("Wrote %s", style.Symbol(path))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", ".", "Directory to write the .cnb files to")
+
+	AddHelpFlag(cmd, "split")
+	return cmd
+}
+
+func buildpackCNBMerge(logger logging.Logger, pack PackClient) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "merge <path>...",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Write the buildpacks of several .cnb files to a single .cnb file",
+		Long: "Write the buildpacks of several .cnb files to a single .cnb file. " +
+			"The buildpack of the first .cnb file is the main buildpack of the merged file, and the buildpacks of the others its dependencies.",
+		Example: "pack buildpack cnb merge java.cnb maven.cnb gradle.cnb --output java-full.cnb",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				return errors.New("--output is required")
+			}
+
+			if err := pack.MergeCNB(cmd.Context(), client.MergeCNBOptions{
+				Paths:  args,
+				Output: output,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Wrote %s", style.Symbol(output))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path to write the merged .cnb file to (required)")
+
+	AddHelpFlag(cmd, "merge")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackCNBCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuildpackCNBCommand", testBuildpackCNBCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackCNBCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuildpackCNB(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("split", func() {
+		it("splits the .cnb file", func() {
+			mockClient.EXPECT().SplitCNB(gomock.Any(), client.SplitCNBOptions{
+				Path:      "java.cnb",
+				OutputDir: "some-dir",
+			}).Return([]string{"some-dir/java_1.0.0.cnb", "some-dir/maven_1.0.0.cnb"}, nil)
+
+			command.SetArgs([]string{"split", "java.cnb", "--output-dir", "some-dir"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Wrote 'some-dir/java_1.0.0.cnb'")
+			h.AssertContains(t, outBuf.String(), "Wrote 'some-dir/maven_1.0.0.cnb'")
+		})
+	})
+
+	when("merge", func() {
+		it("merges the .cnb files", func() {
+			mockClient.EXPECT().MergeCNB(gomock.Any(), client.MergeCNBOptions{
+				Paths:  []string{"java.cnb", "maven.cnb"},
+				Output: "merged.cnb",
+			}).Return(nil)
+
+			command.SetArgs([]string{"merge", "java.cnb", "maven.cnb", "--output", "merged.cnb"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Wrote 'merged.cnb'")
+		})
+
+		when("--output isn't provided", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"merge", "java.cnb"})
+				h.AssertError(t, command.Execute(), "--output is required")
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackCopyFlags define flags provided to the BuildpackCopy command
+type BuildpackCopyFlags struct {
+	IncludeDependencies bool
+	BuildpackRegistry   string
+	RelocationFile      string
+}
+
+// BuildpackCopy copies a buildpackage between registries
+func BuildpackCopy(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackCopyFlags
+
+	cmd := &cobra.Command{
+		Use:   "copy <source> <destination>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Copy a buildpackage between registries",
+		Long: "Copy a buildpackage from one registry to another as it is, keeping its digest, such as to mirror buildpacks to an air-gapped registry.\n\n" +
+			"The buildpacks a buildpackage depends on are part of it, and are copied along with it. " +
+			"Use --include-dependencies to also copy the packages of its dependencies found in the buildpack registry next to the destination, " +
+			"and --relocation-file to write where each buildpackage was copied to, to rewrite the references of builder and package configs.",
+		Example: "pack buildpack copy paketobuildpacks/java:latest registry.example.com/buildpacks/java:latest --include-dependencies",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			relocated, err := pack.CopyBuildpack(cmd.Context(), client.CopyBuildpackOptions{
+				Source:              args[0],
+				Destination:         args[1],
+				IncludeDependencies: flags.IncludeDependencies,
+				Registry:            registry.Name,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.RelocationFile == "" {
+				return nil
+			}
+			contents, err := json.MarshalIndent(relocated, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "marshalling relocated buildpackages")
+			}
+			if err := os.WriteFile(flags.RelocationFile, contents, 0600); err != nil {
+				return errors.Wrapf(err, "writing relocation file %s", style.Symbol(flags.RelocationFile))
+			}
+			logger.Infof("Wrote relocated references to %s", style.Symbol(flags.RelocationFile))
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.IncludeDependencies, "include-dependencies", false, "Also copy the packages of the dependencies of the buildpackage next to the destination")
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry to find the packages of dependencies in")
+	cmd.Flags().StringVar(&flags.RelocationFile, "relocation-file", "", "Path to write the source and destination references of the copied buildpackages to, as JSON")
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "copy")
+	return cmd
+}
@@ -0,0 +1,79 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackCopyCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuildpackCopyCommand", testBuildpackCopyCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackCopyCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		relocated      []client.RelocatedBuildpack
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuildpackCopy(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+		relocated = []client.RelocatedBuildpack{{
+			Buildpack:   "example/java@2.0.0",
+			Source:      "index.docker.io/example/java:2.0.0",
+			Destination: "registry.example.com/mirror/java@sha256:some-digest",
+		}}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackCopy", func() {
+		it("copies the buildpackage and its dependencies", func() {
+			mockClient.EXPECT().CopyBuildpack(gomock.Any(), client.CopyBuildpackOptions{
+				Source:              "example/java:2.0.0",
+				Destination:         "registry.example.com/mirror/java:2.0.0",
+				IncludeDependencies: true,
+				Registry:            "official",
+			}).Return(relocated, nil)
+
+			command.SetArgs([]string{"example/java:2.0.0", "registry.example.com/mirror/java:2.0.0", "--include-dependencies"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("writes the relocated references", func() {
+			relocationFile := filepath.Join(t.TempDir(), "relocation.json")
+			mockClient.EXPECT().CopyBuildpack(gomock.Any(), gomock.Any()).Return(relocated, nil)
+
+			command.SetArgs([]string{"example/java:2.0.0", "registry.example.com/mirror/java:2.0.0", "--relocation-file", relocationFile})
+			h.AssertNil(t, command.Execute())
+
+			contents, err := os.ReadFile(relocationFile)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `"destination": "registry.example.com/mirror/java@sha256:some-digest"`)
+		})
+	})
+}
@@ -12,9 +12,11 @@ import (
 )
 
 type BuildpackInspectFlags struct {
-	Depth    int
-	Registry string
-	Verbose  bool
+	Depth     int
+	Registry  string
+	Verbose   bool
+	Layers    bool
+	Buildpack string
 }
 
 func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
@@ -38,6 +40,8 @@ func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClien
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", -1, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.Registry, "registry", "r", "", "buildpack registry that may be searched")
 	cmd.Flags().BoolVarP(&flags.Verbose, "verbose", "v", false, "show more output")
+	cmd.Flags().BoolVar(&flags.Layers, "layers", false, "show the layer diff ID and uncompressed size of each buildpack")
+	cmd.Flags().StringVar(&flags.Buildpack, "buildpack", "", "only show the buildpack with the given ID (and optional version, as <id>@<version>) within a package")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
@@ -52,11 +56,13 @@ func buildpackInspect(logger logging.Logger, buildpackName, registryName string,
 			BuildpackName: buildpackName,
 			Daemon:        true,
 			Registry:      registryName,
+			LayerDetails:  flags.Layers,
 		},
 		client.InspectBuildpackOptions{
 			BuildpackName: buildpackName,
 			Daemon:        false,
 			Registry:      registryName,
+			LayerDetails:  flags.Layers,
 		})
 	if err != nil {
 		return fmt.Errorf("error writing buildpack output: %q", err)
@@ -578,6 +578,69 @@ func testBuildpackInspectCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("layers flag is passed", func() {
+		it.Before(func() {
+			complexInfo.Location = buildpack.URILocator
+			complexInfo.LayerSizes = map[string]int64{
+				"sha256:first-inner-buildpack-diff-id":  1000,
+				"sha256:second-inner-buildpack-diff-id": 2000000,
+				"sha256:top-buildpack-diff-id":          3000,
+			}
+			mockClient.EXPECT().InspectBuildpack(client.InspectBuildpackOptions{
+				BuildpackName: "/path/to/test/buildpack",
+				Daemon:        true,
+				Registry:      "default-registry",
+				LayerDetails:  true,
+			}).Return(complexInfo, nil)
+		})
+
+		it("displays layer digests and sizes", func() {
+			command.SetArgs([]string{"/path/to/test/buildpack", "--layers"})
+			assert.Nil(command.Execute())
+
+			assert.AssertTrimmedContains(outBuf.String(), `Layers:
+  ID                                 VERSION        DIFF ID                                      SIZE
+  some/first-inner-buildpack         1.0.0          sha256:first-inner-buildpack-diff-id         1.0 kB
+  some/second-inner-buildpack        2.0.0          sha256:second-inner-buildpack-diff-id        2.0 MB
+  some/third-inner-buildpack         3.0.0          sha256:third-inner-buildpack-diff-id         -
+  some/top-buildpack                 0.0.1          sha256:top-buildpack-diff-id                 3.0 kB`)
+		})
+	})
+
+	when("buildpack flag is passed", func() {
+		it.Before(func() {
+			complexInfo.Location = buildpack.URILocator
+			mockClient.EXPECT().InspectBuildpack(client.InspectBuildpackOptions{
+				BuildpackName: "/path/to/test/buildpack",
+				Daemon:        true,
+				Registry:      "default-registry",
+			}).Return(complexInfo, nil)
+		})
+
+		it("only displays the matching buildpack and its nested order", func() {
+			command.SetArgs([]string{"/path/to/test/buildpack", "--buildpack", "some/first-inner-buildpack@1.0.0"})
+			assert.Nil(command.Execute())
+
+			assert.AssertTrimmedContains(outBuf.String(), `Buildpacks:
+  ID                                NAME        VERSION        HOMEPAGE
+  some/first-inner-buildpack        -           1.0.0          first-inner-buildpack-homepage
+
+Detection Order:
+ └ Group #1:
+    └ some/first-inner-buildpack@1.0.0
+       ├ Group #1:
+       │  ├ some/first-inner-buildpack@1.0.0    [cyclic]
+       │  └ some/third-inner-buildpack@3.0.0
+       └ Group #2:
+          └ some/third-inner-buildpack@3.0.0`)
+		})
+
+		it("errors when the buildpack is not in the package", func() {
+			command.SetArgs([]string{"/path/to/test/buildpack", "--buildpack", "some/missing-buildpack"})
+			assert.ErrorContains(command.Execute(), "buildpack 'some/missing-buildpack' not found in package")
+		})
+	})
+
 	when("verbose flag is passed", func() {
 		it.Before(func() {
 			simpleInfo.Location = buildpack.URILocator
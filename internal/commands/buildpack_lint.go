@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackLintFlags define flags provided to the BuildpackLint command
+type BuildpackLintFlags struct {
+	OutputFormat      string
+	Strict            bool
+	Policy            string
+	BuildpackRegistry string
+}
+
+// BuildpackLint validates a buildpack directory, archive or image
+func BuildpackLint(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackLintFlags
+
+	cmd := &cobra.Command{
+		Use:   "lint <dir|image>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check a buildpack for common problems",
+		Long: "Validate the buildpack.toml schema, target and stack declarations, exec.d layout and Buildpack API version of a buildpack.\n\n" +
+			"Every buildpack in a packaged buildpack is checked. The command fails if any error is found, " +
+			"or if any warning is found when --strict is provided. Use --output json for machine-readable findings.",
+		Example: "pack buildpack lint ./my-buildpack --output json",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateLintOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			findings, err := pack.LintBuildpack(cmd.Context(), client.LintBuildpackOptions{
+				BuildpackURI: args[0],
+				Registry:     registry.Name,
+				Daemon:       true,
+				PullPolicy:   pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			return reportLintFindings(logger, flags.OutputFormat, flags.Strict, args[0], findings)
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display findings (json, human-readable)")
+	cmd.Flags().BoolVar(&flags.Strict, "strict", false, "Fail when warnings are found")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "lint")
+	return cmd
+}
+
+func validateLintOutputFormat(format string) error {
+	if format != "human-readable" && format != "json" {
+		return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(format))
+	}
+	return nil
+}
+
+// reportLintFindings prints findings in the given format, and returns a soft error if the findings should fail the command
+func reportLintFindings(logger logging.Logger, format string, strict bool, subject string, findings []buildpack.LintFinding) error {
+	if format == "json" {
+		if findings == nil {
+			findings = []buildpack.LintFinding{}
+		}
+		out, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshalling findings")
+		}
+		logger.Info(string(out))
+	} else {
+		writeLintFindings(logger, subject, findings)
+	}
+
+	if buildpack.HasLintErrors(findings) || (strict && len(findings) > 0) {
+		return client.NewSoftError()
+	}
+	return nil
+}
+
+func writeLintFindings(logger logging.Logger, uri string, findings []buildpack.LintFinding) {
+	if len(findings) == 0 {
+		logger.Infof("No problems found in %s", style.Symbol(uri))
+		return
+	}
+
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "SEVERITY\tBUILDPACK\tRULE\tPATH\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", f.Severity, orDash(f.Buildpack), f.Rule, orDash(f.Path), f.Message)
+	}
+	tw.Flush()
+
+	errCount := 0
+	for _, f := range findings {
+		if f.Severity == buildpack.LintSeverityError {
+			errCount++
+		}
+	}
+	logger.Infof("\nFound %d error(s) and %d warning(s) in %s", errCount, len(findings)-errCount, style.Symbol(uri))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackLintCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuildpackLintCommand", testBuildpackLintCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackLintCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		warning        = buildpack.LintFinding{
+			Buildpack: "some.bp@1.0.0",
+			Rule:      "unknown-key",
+			Severity:  buildpack.LintSeverityWarning,
+			Path:      "buildpack.toml",
+			Message:   "unexpected key 'some-key'",
+		}
+		lintErr = buildpack.LintFinding{
+			Buildpack: "some.bp@1.0.0",
+			Rule:      "bin",
+			Severity:  buildpack.LintSeverityError,
+			Path:      "bin/detect",
+			Message:   "'bin/detect' is required",
+		}
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.BuildpackLint(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackLint", func() {
+		it("reports when no problems are found", func() {
+			mockClient.EXPECT().
+				LintBuildpack(gomock.Any(), client.LintBuildpackOptions{
+					BuildpackURI: "some/dir",
+					Registry:     "official",
+					Daemon:       true,
+					PullPolicy:   image.PullAlways,
+				}).
+				Return(nil, nil)
+
+			command.SetArgs([]string{"some/dir"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No problems found in 'some/dir'")
+		})
+
+		it("prints findings and fails when errors are found", func() {
+			mockClient.EXPECT().LintBuildpack(gomock.Any(), gomock.Any()).Return([]buildpack.LintFinding{warning, lintErr}, nil)
+
+			command.SetArgs([]string{"some/dir"})
+			err := command.Execute()
+			h.AssertError(t, err, client.NewSoftError().Error())
+			h.AssertContains(t, outBuf.String(), "warning    some.bp@1.0.0   unknown-key   buildpack.toml   unexpected key 'some-key'")
+			h.AssertContains(t, outBuf.String(), "Found 1 error(s) and 1 warning(s) in 'some/dir'")
+		})
+
+		it("succeeds with warnings", func() {
+			mockClient.EXPECT().LintBuildpack(gomock.Any(), gomock.Any()).Return([]buildpack.LintFinding{warning}, nil)
+
+			command.SetArgs([]string{"some/dir"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		when("--strict", func() {
+			it("fails with warnings", func() {
+				mockClient.EXPECT().LintBuildpack(gomock.Any(), gomock.Any()).Return([]buildpack.LintFinding{warning}, nil)
+
+				command.SetArgs([]string{"some/dir", "--strict"})
+				h.AssertError(t, command.Execute(), client.NewSoftError().Error())
+			})
+		})
+
+		when("--output json", func() {
+			it("prints machine-readable findings", func() {
+				mockClient.EXPECT().LintBuildpack(gomock.Any(), gomock.Any()).Return([]buildpack.LintFinding{lintErr}, nil)
+
+				command.SetArgs([]string{"some/dir", "--output", "json"})
+				h.AssertNotNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `"rule": "bin"`)
+				h.AssertContains(t, outBuf.String(), `"severity": "error"`)
+				h.AssertContains(t, outBuf.String(), `"path": "bin/detect"`)
+			})
+
+			it("prints an empty list when no problems are found", func() {
+				mockClient.EXPECT().LintBuildpack(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+				command.SetArgs([]string{"some/dir", "-o", "json"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "[]")
+			})
+		})
+
+		it("errors on an invalid output format", func() {
+			command.SetArgs([]string{"some/dir", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml'")
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/style"
@@ -22,9 +23,11 @@ type BuildpackNewFlags struct {
 	API  string
 	Path string
 	// Deprecated: Stacks are deprecated
-	Stacks  []string
-	Targets []string
-	Version string
+	Stacks   []string
+	Targets  []string
+	Version  string
+	Template string
+	GoModule string
 }
 
 // BuildpackCreator creates buildpacks
@@ -40,8 +43,12 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 		Short:   "Creates basic scaffolding of a buildpack.",
 		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		Example: "pack buildpack new sample/my-buildpack",
-		Long:    "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and two executable bash scripts, `bin/detect` and `bin/build`. ",
+		Long:    "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and two executable bash scripts, `bin/detect` and `bin/build`. With `--template libcnb`, it instead generates a Go buildpack using libcnb, along with an integration test building a fixture app with pack.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := deprecatedFlagWarning(logger, cmd, "stacks", "--targets", "see https://github.com/buildpacks/rfcs/blob/main/text/0096-remove-stacks-mixins.md"); err != nil {
+				return err
+			}
+
 			id := args[0]
 			idParts := strings.Split(id, "/")
 			dirName := idParts[len(idParts)-1]
@@ -57,6 +64,10 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 				path = flags.Path
 			}
 
+			if flags.GoModule != "" && flags.Template != client.BuildpackTemplateLibcnb {
+				return errors.Errorf("--go-module can only be used with --template %s", client.BuildpackTemplateLibcnb)
+			}
+
 			_, err := os.Stat(path)
 			if !os.IsNotExist(err) {
 				return fmt.Errorf("directory %s exists", style.Symbol(path))
@@ -83,12 +94,14 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 			}
 
 			if err := creator.NewBuildpack(cmd.Context(), client.NewBuildpackOptions{
-				API:     flags.API,
-				ID:      id,
-				Path:    path,
-				Stacks:  stacks,
-				Targets: targets,
-				Version: flags.Version,
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Stacks:   stacks,
+				Targets:  targets,
+				Version:  flags.Version,
+				Template: flags.Template,
+				GoModule: flags.GoModule,
 			}); err != nil {
 				return err
 			}
@@ -101,8 +114,10 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.8", "Buildpack API compatibility of the generated buildpack")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the buildpack")
 	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated buildpack")
+	cmd.Flags().StringVar(&flags.Template, "template", "", "Template of the generated buildpack, 'bash' (default) or 'libcnb'. The 'libcnb' template generates a Go buildpack using libcnb, with an integration test building a fixture app with pack")
+	cmd.Flags().StringVar(&flags.GoModule, "go-module", "", "Go module path of a buildpack generated with the libcnb template. Defaults to the buildpack ID")
 	cmd.Flags().StringSliceVarP(&flags.Stacks, "stacks", "s", nil, "Stack(s) this buildpack will be compatible with"+stringSliceHelp("stack"))
-	cmd.Flags().MarkDeprecated("stacks", "prefer `--targets` instead: https://github.com/buildpacks/rfcs/blob/main/text/0096-remove-stacks-mixins.md")
+	cmd.Flags().MarkHidden("stacks")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "targets", "t", nil,
 		`Targets are the list platforms that one targeting, these are generated as part of scaffolding inside buildpack.toml file. one can provide target platforms in format [os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]
 	- Base case for two different architectures :  '--targets "linux/amd64" --targets "linux/arm64"'
@@ -85,6 +85,32 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertContains(t, outBuf.String(), "ERROR: directory")
 		})
 
+		when("--template is specified", func() {
+			it("passes the template and Go module", func() {
+				mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
+					API:      "0.8",
+					ID:       "example/some-cnb",
+					Path:     filepath.Join(tmpDir, "some-cnb"),
+					Version:  "1.0.0",
+					Targets:  targets,
+					Template: client.BuildpackTemplateLibcnb,
+					GoModule: "github.com/example/some-cnb",
+				}).Return(nil)
+
+				path := filepath.Join(tmpDir, "some-cnb")
+				command.SetArgs([]string{"--path", path, "example/some-cnb", "--template", "libcnb", "--go-module", "github.com/example/some-cnb"})
+
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when --go-module is used without the libcnb template", func() {
+				path := filepath.Join(tmpDir, "some-cnb")
+				command.SetArgs([]string{"--path", path, "example/some-cnb", "--go-module", "github.com/example/some-cnb"})
+
+				h.AssertError(t, command.Execute(), "--go-module can only be used with --template libcnb")
+			})
+		})
+
 		when("target flag is specified, ", func() {
 			it("it uses target to generate artifacts", func() {
 				mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
@@ -192,14 +218,18 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 					}).Return(nil).MaxTimes(1)
 
 					path := filepath.Join(tmpDir, "stacks")
-					output := new(bytes.Buffer)
-					command.SetOut(output)
-					command.SetErr(output)
 					command.SetArgs([]string{"--path", path, "example/stacks", "--stacks", "io.buildpacks.stacks.jammy"})
 
 					err := command.Execute()
 					h.AssertNil(t, err)
-					h.AssertContains(t, output.String(), "Flag --stacks has been deprecated,")
+					h.AssertContains(t, outBuf.String(), "Warning: Flag '--stacks' has been deprecated, please use '--targets' instead")
+				})
+
+				it("fails when deprecations are strict", func() {
+					logger.WantStrictDeprecations(true)
+					command.SetArgs([]string{"--path", filepath.Join(tmpDir, "stacks"), "example/stacks", "--stacks", "io.buildpacks.stacks.jammy"})
+
+					h.AssertError(t, command.Execute(), "Flag '--stacks' has been deprecated, please use '--targets' instead")
 				})
 			})
 		})
@@ -24,12 +24,19 @@ type BuildpackPackageFlags struct {
 	Format            string
 	Policy            string
 	BuildpackRegistry string
+	SourceBuildImage  string
 	Path              string
+	FromDir           string
 	FlattenExclude    []string
 	Targets           []string
 	Label             map[string]string
+	Annotation        map[string]string
+	Variables         map[string]string
 	Publish           bool
+	DryRun            bool
 	Flatten           bool
+	Meta              bool
+	BuildSource       bool
 }
 
 // BuildpackPackager packages buildpacks
@@ -40,6 +47,7 @@ type BuildpackPackager interface {
 // PackageConfigReader reads BuildpackPackage configs
 type PackageConfigReader interface {
 	Read(path string) (pubbldpkg.Config, error)
+	ReadWithVariables(path string, vars map[string]string) (pubbldpkg.Config, error)
 	ReadBuildpackDescriptor(path string) (dist.BuildpackDescriptor, error)
 }
 
@@ -79,8 +87,26 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				bpPackageCfg.Buildpack.URI = bpPath
 			}
 			relativeBaseDir := ""
+			targetsPath := flags.Path
+			if flags.FromDir != "" {
+				metaDir, err := os.MkdirTemp("", "meta-buildpack")
+				if err != nil {
+					return errors.Wrap(err, "creating meta-buildpack directory")
+				}
+				defer os.RemoveAll(metaDir)
+
+				bpPackageCfg, err = pubbldpkg.MetaBuildpackConfig(flags.FromDir, metaDir)
+				if err != nil {
+					return errors.Wrap(err, "generating meta-buildpack")
+				}
+				for _, dep := range bpPackageCfg.Dependencies {
+					logger.Debugf("Adding buildpack from %s to meta-buildpack", style.Symbol(dep.URI))
+				}
+
+				targetsPath = metaDir
+			}
 			if flags.PackageTomlPath != "" {
-				bpPackageCfg, err = packageConfigReader.Read(flags.PackageTomlPath)
+				bpPackageCfg, err = packageConfigReader.ReadWithVariables(flags.PackageTomlPath, flags.Variables)
 				if err != nil {
 					return errors.Wrap(err, "reading config")
 				}
@@ -90,27 +116,17 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 					return errors.Wrap(err, "getting absolute path for config")
 				}
 			}
-			name := args[0]
-			if flags.Format == client.FormatFile {
-				switch ext := filepath.Ext(name); ext {
-				case client.CNBExtension:
-				case "":
-					name += client.CNBExtension
-				default:
-					logger.Warnf("%s is not a valid extension for a packaged buildpack. Packaged buildpacks must have a %s extension", style.Symbol(ext), style.Symbol(client.CNBExtension))
-				}
-			}
+			name := packageFileName(logger, args[0], flags.Format, "buildpack")
 			if flags.Flatten {
 				logger.Warn("Flattening a buildpack package could break the distribution specification. Please use it with caution.")
 			}
 
-			targets, isCompositeBP, err := processBuildpackPackageTargets(flags.Path, packageConfigReader, bpPackageCfg)
+			targets, isCompositeBP, err := processBuildpackPackageTargets(targetsPath, packageConfigReader, bpPackageCfg)
 			if err != nil {
 				return err
 			}
 
-			daemon := !flags.Publish && flags.Format == ""
-			multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, targets, daemon)
+			multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, targets, client.SavesToDaemon(flags.Format, flags.Publish))
 			if err != nil {
 				return err
 			}
@@ -131,44 +147,50 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 			}
 
 			if err := packager.PackageBuildpack(cmd.Context(), client.PackageBuildpackOptions{
-				RelativeBaseDir: relativeBaseDir,
-				Name:            name,
-				Format:          flags.Format,
-				Config:          bpPackageCfg,
-				Publish:         flags.Publish,
-				PullPolicy:      pullPolicy,
-				Registry:        flags.BuildpackRegistry,
-				Flatten:         flags.Flatten,
-				FlattenExclude:  flags.FlattenExclude,
-				Labels:          flags.Label,
-				Targets:         multiArchCfg.Targets(),
+				RelativeBaseDir:  relativeBaseDir,
+				Name:             name,
+				Format:           flags.Format,
+				Config:           bpPackageCfg,
+				Publish:          flags.Publish,
+				PullPolicy:       pullPolicy,
+				Registry:         flags.BuildpackRegistry,
+				Flatten:          flags.Flatten,
+				FlattenExclude:   flags.FlattenExclude,
+				Labels:           flags.Label,
+				Annotations:      flags.Annotation,
+				Targets:          multiArchCfg.Targets(),
+				DryRun:           flags.DryRun,
+				BuildSource:      flags.BuildSource || flags.SourceBuildImage != "",
+				SourceBuildImage: flags.SourceBuildImage,
 			}); err != nil {
 				return err
 			}
-
-			action := "created"
-			location := "docker daemon"
-			if flags.Publish {
-				action = "published"
-				location = "registry"
-			}
-			if flags.Format == client.FormatFile {
-				location = "file"
+			if flags.DryRun {
+				return nil
 			}
+
+			action, location := savedLocation(flags.Format, flags.Publish)
 			logger.Infof("Successfully %s package %s and saved to %s", action, style.Symbol(name), location)
 			return nil
 		}),
 	}
 
-	cmd.Flags().StringVarP(&flags.PackageTomlPath, "config", "c", "", "Path to package TOML config")
-	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", `Format to save package as ("image" or "file")`)
+	cmd.Flags().StringVarP(&flags.PackageTomlPath, "config", "c", "", "Path to package TOML or YAML config")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", formatFlagUsage)
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, `Publish the buildpack directly to the container registry specified in <name>, instead of the daemon (applies to "--format=image" only).`)
-	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Resolve the buildpack and its dependencies, and print them with their digests and sizes, without saving or publishing the package")
+	cmd.Flags().BoolVar(&flags.BuildSource, "build-source", false, "Build the source of the buildpack with its Makefile or build.sh before packaging it, when the buildpack is a directory")
+	cmd.Flags().StringVar(&flags.SourceBuildImage, "build-source-image", "", "Image to build the source of the buildpack in, instead of on the host (implies --build-source)")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to the Buildpack that needs to be packaged")
+	cmd.Flags().StringVar(&flags.FromDir, "from-dir", "", "Path to a directory of buildpacks to package together, one buildpack per subdirectory (requires --meta)")
+	cmd.Flags().BoolVar(&flags.Meta, "meta", false, "Generate a meta-buildpack from the buildpack.toml in --from-dir, with an order containing every buildpack found in its subdirectories")
 	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
 	cmd.Flags().BoolVar(&flags.Flatten, "flatten", false, "Flatten the buildpack into a single layer")
 	cmd.Flags().StringSliceVarP(&flags.FlattenExclude, "flatten-exclude", "e", nil, "Buildpacks to exclude from flattening, in the form of '<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to packaged Buildpack, in the form of '<name>=<value>'")
+	cmd.Flags().StringToStringVar(&flags.Annotation, "annotation", nil, "OCI manifest annotations to add to packaged Buildpack, in the form of '<name>=<value>'")
+	cmd.Flags().StringToStringVar(&flags.Variables, "set", nil, "Set a variable referenced as ${<name>} in the config, in the form of '<name>=<value>'. Environment variables are used for variables that are not set")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.
 Targets should be in the format '[os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]'.
@@ -176,7 +198,7 @@ Targets should be in the format '[os][/arch][/variant]:[distroname@osversion@ano
 - To specify the distribution version: '--target "linux/arm/v6:ubuntu@14.04"'
 - To specify multiple distribution versions: '--target "linux/arm/v6:ubuntu@14.04"  --target "linux/arm/v6:ubuntu@16.04"'
 	`)
-	if !cfg.Experimental {
+	if !cfg.ExperimentalEnabled(config.FeatureFlatten) {
 		cmd.Flags().MarkHidden("flatten")
 		cmd.Flags().MarkHidden("flatten-exclude")
 	}
@@ -188,13 +210,27 @@ func validateBuildpackPackageFlags(cfg config.Config, p *BuildpackPackageFlags)
 	if p.Publish && p.Policy == image.PullNever.String() {
 		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
 	}
+	if err := validateFormat(p.Format, p.Publish); err != nil {
+		return err
+	}
 	if p.PackageTomlPath != "" && p.Path != "" {
 		return errors.Errorf("--config and --path cannot be used together. Please specify the relative path to the Buildpack directory in the package config file.")
 	}
 
+	if p.FromDir != "" {
+		if !p.Meta {
+			return errors.Errorf("--from-dir requires --meta")
+		}
+		if p.PackageTomlPath != "" || p.Path != "" {
+			return errors.Errorf("--from-dir cannot be used together with --config or --path")
+		}
+	} else if p.Meta {
+		return errors.Errorf("--meta requires --from-dir")
+	}
+
 	if p.Flatten {
-		if !cfg.Experimental {
-			return client.NewExperimentError("Flattening a buildpack package is currently experimental.")
+		if !cfg.ExperimentalEnabled(config.FeatureFlatten) {
+			return client.NewFeatureExperimentError(config.FeatureFlatten, "Flattening a buildpack package is currently experimental.")
 		}
 
 		if len(p.FlattenExclude) > 0 {
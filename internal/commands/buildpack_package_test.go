@@ -3,6 +3,7 @@ package commands_test
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -60,6 +61,16 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, fakePackageConfigReader.ReadCalledWithArg, expectedPackageConfigPath)
 			})
 
+			it("passes variables to the package config reader", func() {
+				fakePackageConfigReader := fakes.NewFakePackageConfigReader()
+
+				cmd := packageCommand(withPackageConfigReader(fakePackageConfigReader))
+				cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--set", "registry=example.com", "--set", "tag=1.0"})
+				h.AssertNil(t, cmd.Execute())
+
+				h.AssertEq(t, fakePackageConfigReader.ReadCalledWithVariables, map[string]string{"registry": "example.com", "tag": "1.0"})
+			})
+
 			it("creates package with correct image name", func() {
 				cmd := packageCommand(
 					withImageName("my-specific-image"),
@@ -162,6 +173,65 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("oci-layout format", func() {
+				it("does not modify the name and reports the OCI layout", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager), withLogger(logger))
+					cmd.SetArgs([]string{"some-layout", "-f", "oci-layout"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.Name, "some-layout")
+					h.AssertEq(t, receivedOptions.Format, "oci-layout")
+					h.AssertContains(t, outBuf.String(), "Successfully created package 'some-layout' and saved to OCI layout")
+				})
+			})
+
+			when("--annotation", func() {
+				it("passes annotations to the packager", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--annotation", "org.opencontainers.image.source=https://example.com/repo"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.Annotations, map[string]string{"org.opencontainers.image.source": "https://example.com/repo"})
+				})
+			})
+
+			when("--dry-run", func() {
+				it("passes dry run to the packager without logging success", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager), withLogger(logger))
+					cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--dry-run"})
+					h.AssertNil(t, cmd.Execute())
+
+					h.AssertEq(t, fakeBuildpackPackager.CreateCalledWithOptions.DryRun, true)
+					h.AssertNotContains(t, outBuf.String(), "Successfully")
+				})
+			})
+
+			when("--build-source", func() {
+				it("passes build source to the packager", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--build-source"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.BuildSource, true)
+					h.AssertEq(t, receivedOptions.SourceBuildImage, "")
+				})
+			})
+
+			when("--build-source-image", func() {
+				it("builds the source in the image", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--build-source-image", "some/build-image"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.BuildSource, true)
+					h.AssertEq(t, receivedOptions.SourceBuildImage, "some/build-image")
+				})
+			})
+
 			when("there is a path flag", func() {
 				it("returns an error saying that it cannot be used with the config flag", func() {
 					myConfig := pubbldpkg.Config{
@@ -321,7 +391,88 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("--from-dir and --meta are specified", func() {
+		var (
+			tmpDir                string
+			fakeBuildpackPackager *fakes.FakeBuildpackPackager
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "package-from-dir-test")
+			h.AssertNil(t, err)
+			fakeBuildpackPackager = &fakes.FakeBuildpackPackager{}
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "buildpack.toml"), []byte(`
+api = "0.10"
+[buildpack]
+id = "some/meta"
+version = "1.0.0"
+`), 0644))
+			for _, id := range []string{"a", "b"} {
+				h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, id), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, id, "buildpack.toml"), []byte(fmt.Sprintf(`
+api = "0.10"
+[buildpack]
+id = "some/%s"
+version = "1.0.0"
+`, id)), 0644))
+			}
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		it("packages a generated meta-buildpack with every discovered buildpack", func() {
+			fakePackageConfigReader := fakes.NewFakePackageConfigReader()
+			cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager), withPackageConfigReader(fakePackageConfigReader))
+			cmd.SetArgs([]string{"some-image-name", "--from-dir", tmpDir, "--meta"})
+			h.AssertNil(t, cmd.Execute())
+
+			receivedConfig := fakeBuildpackPackager.CreateCalledWithOptions.Config
+			h.AssertNotEq(t, receivedConfig.Buildpack.URI, "")
+			h.AssertEq(t, receivedConfig.Dependencies, []dist.ImageOrURI{
+				{BuildpackURI: dist.BuildpackURI{URI: filepath.Join(tmpDir, "a")}},
+				{BuildpackURI: dist.BuildpackURI{URI: filepath.Join(tmpDir, "b")}},
+			})
+			h.AssertEq(t, fakePackageConfigReader.ReadBuildpackDescriptorCalledWithArg, filepath.Join(receivedConfig.Buildpack.URI, "buildpack.toml"))
+		})
+
+		it("errors when the meta-buildpack cannot be generated", func() {
+			h.AssertNil(t, os.Remove(filepath.Join(tmpDir, "buildpack.toml")))
+
+			cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+			cmd.SetArgs([]string{"some-image-name", "--from-dir", tmpDir, "--meta"})
+			h.AssertError(t, cmd.Execute(), "generating meta-buildpack")
+		})
+	})
+
 	when("invalid flags", func() {
+		when("--from-dir is specified without --meta", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{"some-image-name", "--from-dir", "some-dir"})
+				h.AssertError(t, cmd.Execute(), "--from-dir requires --meta")
+			})
+		})
+
+		when("--meta is specified without --from-dir", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{"some-image-name", "--meta"})
+				h.AssertError(t, cmd.Execute(), "--meta requires --from-dir")
+			})
+		})
+
+		when("--from-dir is specified with --config", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{"some-image-name", "--from-dir", "some-dir", "--meta", "--config", "/path/to/some/file"})
+				h.AssertError(t, cmd.Execute(), "--from-dir cannot be used together with --config or --path")
+			})
+		})
+
 		when("both --publish and --pull-policy never flags are specified", func() {
 			it("errors with a descriptive message", func() {
 				cmd := packageCommand()
@@ -353,6 +504,24 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertContains(t, outBuf.String(), fmt.Sprintf("ERROR: reading config: %s", expectedErr))
 		})
 
+		when("--publish is specified with a format other than image", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--publish", "--format", "oci-layout"})
+
+				h.AssertError(t, cmd.Execute(), "--publish cannot be used with --format oci-layout, only images can be published")
+			})
+		})
+
+		when("--format is unknown", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--format", "tarball"})
+
+				h.AssertError(t, cmd.Execute(), "invalid format 'tarball', must be one of image, file or oci-layout")
+			})
+		})
+
 		when("package-config is specified", func() {
 			it("errors with a descriptive message", func() {
 				cmd := packageCommand()
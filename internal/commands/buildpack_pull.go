@@ -44,5 +44,6 @@ func BuildpackPull(logger logging.Logger, cfg config.Config, pack PackClient) *c
 	}
 	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
 	AddHelpFlag(cmd, "pull")
+	cmd.ValidArgsFunction = completeRegistryBuildpacks(logger, cfg)
 	return cmd
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
@@ -30,6 +31,7 @@ type PackClient interface {
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
+	WriteBuildManifest(context.Context, client.BuildOptions, string, io.Writer) error
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
@@ -43,6 +45,34 @@ type PackClient interface {
 	RemoveManifest(name string, images []string) error
 	PushManifest(client.PushManifestOptions) error
 	InspectManifest(string) error
+	InspectIndex(context.Context, string) (*client.IndexInfo, error)
+	CopyBuilder(context.Context, client.CopyBuilderOptions) error
+	CopyBuildpack(context.Context, client.CopyBuildpackOptions) ([]client.RelocatedBuildpack, error)
+	ExtractBuildpack(context.Context, client.ExtractBuildpackOptions) error
+	DiffBuilders(client.DiffBuildersOptions) (*client.BuilderDiff, error)
+	OutdatedBuilder(client.OutdatedBuilderOptions) (*client.BuilderUpgradeReport, error)
+	UpdateBuilder(context.Context, client.UpdateBuilderOptions) error
+	SplitCNB(context.Context, client.SplitCNBOptions) ([]string, error)
+	MergeCNB(context.Context, client.MergeCNBOptions) error
+	RelocateBuilder(context.Context, client.RelocateBuilderOptions) (*client.RelocatedBuilder, error)
+	CheckCompatibility(context.Context, client.CompatOptions) (*client.CompatReport, error)
+	LintBuildpack(context.Context, client.LintBuildpackOptions) ([]buildpack.LintFinding, error)
+	LintBuilder(context.Context, client.LintBuilderOptions) ([]buildpack.LintFinding, error)
+	TestBuildpack(context.Context, client.TestBuildpackOptions) ([]client.BuildpackTestResult, error)
+	GenerateSample(context.Context, client.GenerateSampleOptions) error
+	InspectBuildpackAPIShims(context.Context, client.InspectBuildpackAPIShimsOptions) (*client.BuildpackAPIShimsReport, error)
+	UpdateRegistryIndex(context.Context, client.RegistryIndexOptions) error
+	CreateStack(context.Context, client.CreateStackOptions) error
+	CheckUpdates(context.Context, client.CheckUpdatesOptions) (*client.RunImageUpdate, error)
+	NeedsRebuild(context.Context, client.NeedsRebuildOptions) (*client.RebuildCheck, error)
+	ImageProvenance(context.Context, client.ImageProvenanceOptions) (*client.ImageProvenance, error)
+	GC(context.Context, client.GCOptions) ([]client.IntermediateImage, error)
+	Doctor(context.Context) []client.DoctorCheck
+	Login(context.Context, client.LoginOptions) error
+	Logout(context.Context, string) (bool, error)
+	RebaseCatalog(context.Context, client.RebaseCatalogOptions) ([]client.CatalogRebaseResult, error)
+	ListLocalImages(context.Context) ([]string, error)
+	CleanPhases(ctx context.Context, imageName string) error
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -72,12 +102,12 @@ func logError(logger logging.Logger, f func(cmd *cobra.Command, args []string) e
 				logger.Error(err.Error())
 			}
 
-			if _, isExpError := errors.Cause(err).(client.ExperimentError); isExpError {
+			if expErr, isExpError := errors.Cause(err).(client.ExperimentError); isExpError {
 				configPath, err := config.DefaultConfigPath()
 				if err != nil {
 					return err
 				}
-				enableExperimentalTip(logger, configPath)
+				enableExperimentalTip(logger, configPath, expErr.Feature())
 			}
 			return err
 		}
@@ -85,7 +115,11 @@ func logError(logger logging.Logger, f func(cmd *cobra.Command, args []string) e
 	}
 }
 
-func enableExperimentalTip(logger logging.Logger, configPath string) {
+func enableExperimentalTip(logger logging.Logger, configPath, feature string) {
+	if feature != "" {
+		logging.Tip(logger, "To enable this experimental feature, run `pack config experimental enable %s` to add it to %s in %s.", feature, style.Symbol("experimental-features"), style.Symbol(configPath))
+		return
+	}
 	logging.Tip(logger, "To enable experimental features, run `pack config experimental true` to add %s to %s.", style.Symbol("experimental = true"), style.Symbol(configPath))
 }
 
@@ -115,8 +149,25 @@ func isTrustedBuilder(cfg config.Config, builder string) bool {
 	return isSuggestedBuilder(builder)
 }
 
-func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
-	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
+func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) error {
+	return logging.ReportDeprecation(logger, logging.Deprecation{
+		Kind:        logging.DeprecatedCommand,
+		Name:        "pack " + oldCmd,
+		Replacement: "pack " + replacementCmd,
+	})
+}
+
+// deprecatedFlagWarning reports the usage of a deprecated flag, if it was set
+func deprecatedFlagWarning(logger logging.Logger, cmd *cobra.Command, flag, replacement, details string) error {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+	return logging.ReportDeprecation(logger, logging.Deprecation{
+		Kind:        logging.DeprecatedFlag,
+		Name:        "--" + flag,
+		Replacement: replacement,
+		Details:     details,
+	})
 }
 
 func parseFormatFlag(value string) (types.MediaType, error) {
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CompatFlags struct {
+	Builder    string
+	Buildpacks []string
+	Registry   string
+	Policy     string
+}
+
+// Compat checks buildpacks against a builder and prints a compatibility matrix
+func Compat(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags CompatFlags
+
+	cmd := &cobra.Command{
+		Use:   "compat",
+		Args:  cobra.NoArgs,
+		Short: "Check that buildpacks are compatible with a builder",
+		Long: "Check the Buildpack API, targets and mixins of each buildpack against a builder, and print a compatibility matrix.\n\n" +
+			"Dependencies of buildpackages are checked as well. The command fails if any buildpack is incompatible.",
+		Example: "pack compat --builder cnbs/sample-builder:noble --buildpack docker://cnbs/sample-package:hello-universe",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Builder == "" {
+				suggestSettingBuilder(logger, cfg, pack)
+				return client.NewSoftError()
+			}
+			if len(flags.Buildpacks) == 0 {
+				return errors.New("at least one --buildpack is required")
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			report, err := pack.CheckCompatibility(cmd.Context(), client.CompatOptions{
+				Builder:    flags.Builder,
+				Buildpacks: flags.Buildpacks,
+				Registry:   flags.Registry,
+				Daemon:     true,
+				PullPolicy: pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			writeCompatReport(logger, report)
+
+			if !report.Compatible() {
+				return errors.Errorf("one or more buildpacks are incompatible with builder %s", style.Symbol(flags.Builder))
+			}
+			logger.Infof("All buildpacks are compatible with builder %s", style.Symbol(flags.Builder))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringSliceVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to check. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file,\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]', or\n  path to an image archive of a packaged buildpack in the form of 'docker-archive:<path>'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+
+	AddHelpFlag(cmd, "compat")
+	return cmd
+}
+
+func writeCompatReport(logger logging.Logger, report *client.CompatReport) {
+	logger.Infof("Builder: %s", style.Symbol(report.Builder))
+	if report.Lifecycle.Info.Version != nil {
+		logger.Infof("Lifecycle: %s (Buildpack APIs: %s)",
+			report.Lifecycle.Info.Version.String(),
+			strings.Join(append(report.Lifecycle.APIs.Buildpack.Supported.AsStrings(), report.Lifecycle.APIs.Buildpack.Deprecated.AsStrings()...), ", "),
+		)
+	}
+	target := fmt.Sprintf("%s/%s", report.Target.OS, report.Target.Arch)
+	for _, d := range report.Target.Distributions {
+		target += fmt.Sprintf(" (%s %s)", d.Name, d.Version)
+	}
+	logger.Infof("Target: %s", target)
+	if report.StackID != "" {
+		logger.Infof("Stack: %s", report.StackID)
+	}
+	logger.Info("")
+
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "BUILDPACK\tAPI\tLIFECYCLE\tTARGET\tMIXINS")
+	for _, m := range report.Modules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			m.Module.FullName(),
+			m.API,
+			compatStatus(m.APIReason),
+			compatStatus(m.TargetReason),
+			compatStatus(m.MixinsReason),
+		)
+	}
+	tw.Flush()
+
+	var reasons []string
+	for _, m := range report.Modules {
+		for _, reason := range []string{m.APIReason, m.TargetReason, m.MixinsReason} {
+			if reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	if len(reasons) > 0 {
+		logger.Info("")
+		logger.Info("Problems:")
+		for _, reason := range reasons {
+			logger.Infof("  - %s", reason)
+		}
+	}
+	logger.Info("")
+}
+
+func compatStatus(reason string) string {
+	if reason == "" {
+		return "ok"
+	}
+	return "incompatible"
+}
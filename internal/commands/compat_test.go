@@ -0,0 +1,102 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCompatCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "CompatCommand", testCompatCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCompatCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.Compat(logger, config.Config{DefaultBuilder: "some/builder"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Compat", func() {
+		it("requires a buildpack", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "at least one --buildpack is required")
+		})
+
+		it("prints the compatibility matrix", func() {
+			mockClient.EXPECT().
+				CheckCompatibility(gomock.Any(), client.CompatOptions{
+					Builder:    "some/builder",
+					Buildpacks: []string{"some/bp", "other/bp"},
+					Daemon:     true,
+					PullPolicy: image.PullAlways,
+				}).
+				Return(&client.CompatReport{
+					Builder: "some/builder",
+					Target:  dist.Target{OS: "linux", Arch: "amd64"},
+					StackID: "some.stack.id",
+					Modules: []client.ModuleCompatibility{
+						{Module: dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"}, API: "0.8"},
+						{Module: dist.ModuleInfo{ID: "other.bp", Version: "2.0.0"}, API: "0.10"},
+					},
+				}, nil)
+
+			command.SetArgs([]string{"--buildpack", "some/bp", "--buildpack", "other/bp"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Target: linux/amd64")
+			h.AssertContains(t, outBuf.String(), "Stack: some.stack.id")
+			h.AssertContains(t, outBuf.String(), "some.bp@1.0.0    0.8    ok          ok       ok")
+			h.AssertContains(t, outBuf.String(), "All buildpacks are compatible with builder 'some/builder'")
+		})
+
+		it("fails and lists problems when a buildpack is incompatible", func() {
+			mockClient.EXPECT().
+				CheckCompatibility(gomock.Any(), gomock.Any()).
+				Return(&client.CompatReport{
+					Builder: "other/builder",
+					Target:  dist.Target{OS: "linux", Arch: "arm64"},
+					Modules: []client.ModuleCompatibility{
+						{Module: dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"}, API: "0.8", TargetReason: "some target problem"},
+					},
+				}, nil)
+
+			command.SetArgs([]string{"--builder", "other/builder", "--buildpack", "some/bp"})
+			h.AssertError(t, command.Execute(), "one or more buildpacks are incompatible with builder 'other/builder'")
+
+			h.AssertContains(t, outBuf.String(), "incompatible")
+			h.AssertContains(t, outBuf.String(), "  - some target problem")
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// suggestFunc suggests values of an argument or flag of a command in shell completions
+type suggestFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// suggestions collects values with a description, once each, in the order they're added
+type suggestions struct {
+	toComplete string
+	seen       map[string]bool
+	values     []string
+}
+
+func newSuggestions(toComplete string) *suggestions {
+	return &suggestions{toComplete: toComplete, seen: map[string]bool{}}
+}
+
+func (s *suggestions) add(value, description string) {
+	if value == "" || s.seen[value] || !strings.HasPrefix(value, s.toComplete) {
+		return
+	}
+	s.seen[value] = true
+	if description != "" {
+		value += "\t" + description
+	}
+	s.values = append(s.values, value)
+}
+
+// completeBuilders suggests the default, trusted and suggested builders of the config, without looking them up
+func completeBuilders(cfg config.Config) suggestFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		s := newSuggestions(toComplete)
+		s.add(cfg.DefaultBuilder, "default builder")
+		for _, trusted := range cfg.TrustedBuilders {
+			s.add(trusted.Name, "trusted builder")
+		}
+		for _, known := range builder.KnownBuilders {
+			if known.Suggested {
+				s.add(known.Image, known.Vendor)
+			}
+		}
+		for _, suggested := range cfg.SuggestedBuilders {
+			s.add(suggested.Image, suggested.Vendor)
+		}
+		return s.values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeLocalImages suggests the tagged images of the daemon
+func completeLocalImages(packClient PackClient) suggestFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		images, err := packClient.ListLocalImages(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		s := newSuggestions(toComplete)
+		for _, image := range images {
+			s.add(image, "")
+		}
+		return s.values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeRegistryBuildpacks suggests the IDs of the buildpacks in the cached index of the default registry. As
+// buildpacks may also be given by path, files are completed when none match.
+func completeRegistryBuildpacks(logger logging.Logger, cfg config.Config) suggestFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ids, err := registryBuildpackIDs(logger, cfg)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		s := newSuggestions(toComplete)
+		for _, id := range ids {
+			s.add(id, "")
+		}
+		return s.values, cobra.ShellCompDirectiveDefault
+	}
+}
+
+func registryBuildpackIDs(logger logging.Logger, cfg config.Config) ([]string, error) {
+	packHome, err := config.PackHome()
+	if err != nil {
+		return nil, err
+	}
+	reg, err := config.GetRegistry(cfg, "")
+	if err != nil {
+		return nil, err
+	}
+	cache, err := registry.NewRegistryCache(logger, packHome, reg.URL)
+	if err != nil {
+		return nil, err
+	}
+	return registry.ListIndex(cache.Root)
+}
+
+// completeRunImages suggests the run images of the config and their mirrors
+func completeRunImages(cfg config.Config) suggestFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		s := newSuggestions(toComplete)
+		for _, runImage := range cfg.RunImages {
+			s.add(runImage.Image, "run image")
+			for _, mirror := range runImage.Mirrors {
+				s.add(mirror, "mirror of "+runImage.Image)
+			}
+		}
+		return s.values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
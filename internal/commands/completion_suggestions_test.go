@@ -0,0 +1,117 @@
+package commands_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCompletionSuggestions(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CompletionSuggestions", testCompletionSuggestions, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCompletionSuggestions(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+		packHome       string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		packHome = t.TempDir()
+		t.Setenv("PACK_HOME", packHome)
+		cfg = config.Config{
+			DefaultBuilder:  "some/default-builder",
+			TrustedBuilders: []config.TrustedBuilder{{Name: "some/trusted-builder"}},
+			RunImages:       []config.RunImage{{Image: "some/run-image", Mirrors: []string{"mirror.example.com/run-image"}}},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	complete := func(args ...string) []string {
+		rootCmd := &cobra.Command{Use: "pack"}
+		rootCmd.AddCommand(commands.Build(logger, cfg, mockClient))
+		rootCmd.AddCommand(commands.Rebase(logger, cfg, mockClient))
+		var completions bytes.Buffer
+		rootCmd.SetOut(&completions)
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+		h.AssertNil(t, rootCmd.Execute())
+
+		lines := strings.Split(strings.TrimSpace(completions.String()), "\n")
+		return lines[:len(lines)-1]
+	}
+
+	when("completing builders", func() {
+		it("suggests the default, trusted and suggested builders", func() {
+			completions := complete("build", "some-image", "--builder", "some/")
+			h.AssertEq(t, completions, []string{
+				"some/default-builder\tdefault builder",
+				"some/trusted-builder\ttrusted builder",
+			})
+
+			completions = complete("build", "some-image", "--builder", "")
+			h.AssertSliceContains(t, completions, "paketobuildpacks/builder-jammy-base\tPaketo Buildpacks")
+		})
+	})
+
+	when("completing run images", func() {
+		it("suggests the run images of the config and their mirrors", func() {
+			h.AssertEq(t, complete("rebase", "some-image", "--run-image", ""), []string{
+				"some/run-image\trun image",
+				"mirror.example.com/run-image\tmirror of some/run-image",
+			})
+		})
+	})
+
+	when("completing images", func() {
+		it("suggests the local images", func() {
+			mockClient.EXPECT().ListLocalImages(gomock.Any()).Return([]string{"other/image:latest", "some/image:latest"}, nil)
+
+			h.AssertEq(t, complete("rebase", "some/"), []string{"some/image:latest"})
+		})
+
+		it("suggests nothing when the daemon can't be reached", func() {
+			mockClient.EXPECT().ListLocalImages(gomock.Any()).Return(nil, errors.New("no daemon"))
+
+			h.AssertEq(t, complete("build", ""), []string{})
+		})
+	})
+
+	when("completing buildpacks", func() {
+		it("suggests the buildpacks of the cached registry index", func() {
+			reg, err := config.GetRegistry(cfg, "")
+			h.AssertNil(t, err)
+			cache, err := registry.NewRegistryCache(logger, packHome, reg.URL)
+			h.AssertNil(t, err)
+			_, err = registry.AddToIndex(cache.Root, registry.Buildpack{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "example.com/acme/java@sha256:1"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, complete("build", "some-image", "--buildpack", "ac"), []string{"acme/java"})
+		})
+	})
+}
@@ -22,8 +22,16 @@ func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string,
 	cmd.AddCommand(ConfigRegistries(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRunImagesMirrors(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigTrustedBuilder(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigSuggestedBuilders(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigLifecycleImage(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRegistryMirrors(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigTrustPolicy(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigLocalCacheRegistry(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigBuildpackAPIShims(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigValidate(logger, cfgPath))
+	cmd.AddCommand(ConfigValidateProject(logger))
+	cmd.AddCommand(ConfigMigrate(logger, cfgPath))
+	cmd.AddCommand(ConfigEncrypt(logger, cfg, cfgPath, config.NewKeyring))
 
 	AddHelpFlag(cmd, "config")
 	return cmd
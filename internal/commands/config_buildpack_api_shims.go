@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigBuildpackAPIShims(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	var unset bool
+
+	cmd := &cobra.Command{
+		Use:   "buildpack-api-shims [<warn | allow | deny>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "List, set and unset the policy for buildpacks using a Buildpack API shim",
+		Long: "The lifecycle runs buildpacks using a deprecated Buildpack API through a compatibility shim. " +
+			"You can use this command to choose what builds do when buildpacks of the builder use such a shim:\n" +
+			"* `warn` reports the buildpacks as deprecated usage, which is the default.\n" +
+			"* `allow` uses the shims without reporting them.\n" +
+			"* `deny` fails the build.\n\n" +
+			"Run `pack builder shims <builder>` to list the shims the buildpacks of a builder require.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			switch {
+			case unset:
+				if len(args) > 0 {
+					return errors.Errorf("buildpack API shim policy and --unset cannot be specified simultaneously")
+				}
+				cfg.BuildpackAPIShims = ""
+				if err := config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrapf(err, "writing config to %s", cfgPath)
+				}
+				logger.Infof("Buildpack API shim policy has been reset to %s", style.Symbol(string(client.BuildpackAPIShimsWarn)))
+			case len(args) == 0:
+				policy, err := client.ParseBuildpackAPIShimPolicy(cfg.BuildpackAPIShims)
+				if err != nil {
+					return err
+				}
+				logger.Infof("The current buildpack API shim policy is %s", style.Symbol(string(policy)))
+			default:
+				policy, err := client.ParseBuildpackAPIShimPolicy(args[0])
+				if err != nil {
+					return err
+				}
+				cfg.BuildpackAPIShims = string(policy)
+				if err := config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrapf(err, "writing config to %s", cfgPath)
+				}
+				logger.Infof("Successfully set %s as the buildpack API shim policy", style.Symbol(string(policy)))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "Unset the buildpack API shim policy, and set it back to the default, which is "+style.Symbol(string(client.BuildpackAPIShimsWarn)))
+	AddHelpFlag(cmd, "buildpack-api-shims")
+	return cmd
+}
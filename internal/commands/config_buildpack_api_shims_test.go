@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigBuildpackAPIShims(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigBuildpackAPIShimsCommand", testConfigBuildpackAPIShimsCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigBuildpackAPIShimsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger     logging.Logger
+		outBuf     bytes.Buffer
+		configFile string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		configFile = filepath.Join(t.TempDir(), "config.toml")
+	})
+
+	when("#ConfigBuildpackAPIShims", func() {
+		it("lists the default policy", func() {
+			command := commands.ConfigBuildpackAPIShims(logger, config.Config{}, configFile)
+			command.SetArgs([]string{})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "The current buildpack API shim policy is 'warn'")
+		})
+
+		it("sets the policy", func() {
+			command := commands.ConfigBuildpackAPIShims(logger, config.Config{}, configFile)
+			command.SetArgs([]string{"deny"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully set 'deny' as the buildpack API shim policy")
+			cfg, err := config.Read(configFile)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.BuildpackAPIShims, "deny")
+		})
+
+		it("unsets the policy", func() {
+			command := commands.ConfigBuildpackAPIShims(logger, config.Config{BuildpackAPIShims: "allow"}, configFile)
+			command.SetArgs([]string{"--unset"})
+
+			h.AssertNil(t, command.Execute())
+			cfg, err := config.Read(configFile)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.BuildpackAPIShims, "")
+		})
+
+		it("errors for unknown policies", func() {
+			command := commands.ConfigBuildpackAPIShims(logger, config.Config{}, configFile)
+			command.SetArgs([]string{"ignore"})
+
+			h.AssertError(t, command.Execute(), "invalid buildpack API shim policy 'ignore'")
+		})
+	})
+}
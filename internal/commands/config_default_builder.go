@@ -65,6 +65,7 @@ func ConfigDefaultBuilder(logger logging.Logger, cfg config.Config, cfgPath stri
 
 	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "Unset the current default builder")
 	AddHelpFlag(cmd, "config default-builder")
+	cmd.ValidArgsFunction = completeBuilders(cfg)
 	return cmd
 }
 
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ConfigEncrypt encrypts the sensitive values of the config file with a key kept in the keychain of the OS
+func ConfigEncrypt(logger logging.Logger, cfg config.Config, cfgPath string, newKeyring func(helper string) config.Keyring) *cobra.Command {
+	var keyringHelper string
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Args:  cobra.NoArgs,
+		Short: "Encrypt the registry tokens, proxies and log export headers of the config with a key kept in the keychain of the OS",
+		Long: "You can use this command to encrypt the sensitive values of your config file, the tokens of the registries, " +
+			"the proxies, which may hold credentials, and the headers of the log export. The key they are encrypted with is " +
+			"kept in the keychain of the OS by a docker credential helper, and created on first use. pack decrypts the values " +
+			"when it uses them.",
+		Example: "pack config encrypt\npack config encrypt --keyring pass",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			helper := cfg.Keyring
+			if helper == "" {
+				helper = config.DefaultKeyringHelper()
+			}
+			if keyringHelper != "" && keyringHelper != helper {
+				if countEncrypted(cfg) > 0 {
+					return errors.Errorf("the config has values encrypted with the key of keyring %s already, they can't be encrypted with the key of another keyring", style.Symbol(helper))
+				}
+				cfg.Keyring, helper = keyringHelper, keyringHelper
+			}
+
+			encrypted, err := config.EncryptSecrets(cfg, newKeyring(cfg.Keyring))
+			if err != nil {
+				return errors.Wrap(err, "encrypting config")
+			}
+
+			n := countEncrypted(encrypted) - countEncrypted(cfg)
+			if n == 0 {
+				logger.Info("The config has no sensitive values to encrypt")
+				return nil
+			}
+
+			if err := config.Write(encrypted, cfgPath); err != nil {
+				return errors.Wrapf(err, "failed to write to config at %s", cfgPath)
+			}
+			logger.Infof("Encrypted %d value(s) of the config with the key of keyring %s", n, style.Symbol(helper))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&keyringHelper, "keyring", "", "Docker credential helper keeping the key, such as osxkeychain, wincred, secretservice or pass, defaults to the one of the OS")
+	AddHelpFlag(cmd, "encrypt")
+	return cmd
+}
+
+// countEncrypted returns the number of sensitive values of the config that are encrypted
+func countEncrypted(cfg config.Config) int {
+	values := []string{cfg.Proxy.HTTPProxy, cfg.Proxy.HTTPSProxy}
+	for _, registry := range cfg.Registries {
+		values = append(values, registry.Token)
+	}
+	for _, header := range cfg.LogExport.Headers {
+		values = append(values, header)
+	}
+
+	n := 0
+	for _, value := range values {
+		if config.IsEncrypted(value) {
+			n++
+		}
+	}
+	return n
+}
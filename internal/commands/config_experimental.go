@@ -1,13 +1,16 @@
 package commands
 
 import (
+	"fmt"
 	"path/filepath"
 	"strconv"
+	"text/tabwriter"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/stringset"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -17,28 +20,27 @@ func ConfigExperimental(logger logging.Logger, cfg config.Config, cfgPath string
 		Use:   "experimental [<true | false>]",
 		Args:  cobra.MaximumNArgs(1),
 		Short: "List and set the current 'experimental' value from the config",
-		Long: "Experimental features in pack are gated, and require you adding setting `experimental=true` to the Pack Config, either manually, or using this command.\n\n" +
-			"* Running `pack config experimental` prints whether experimental features are currently enabled.\n" +
-			"* Running `pack config experimental <true | false>` enables or disables experimental features.",
+		Long: "Experimental features in pack are gated, and require you enabling them in the Pack Config, either manually, or using this command.\n\n" +
+			"* Running `pack config experimental` prints whether experimental features are currently enabled, and lists each experimental feature.\n" +
+			"* Running `pack config experimental enable <feature>` enables a single experimental feature, by adding it to `experimental-features`.\n" +
+			"* Running `pack config experimental disable <feature>` disables a single experimental feature.\n" +
+			"* Running `pack config experimental <true | false>` enables or disables every experimental feature at once, by setting `experimental`.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			switch {
 			case len(args) == 0:
 				if cfg.Experimental {
 					logger.Infof("Experimental features are enabled! To turn them off, run `pack config experimental false`")
 				} else {
-					logger.Info("Experimental features aren't currently enabled. To enable them, run `pack config experimental true`")
+					logger.Info("Experimental features aren't currently enabled. To enable them, run `pack config experimental true`, or `pack config experimental enable <feature>` for a single one")
 				}
+				return listExperimentalFeatures(logger, cfg)
 			default:
 				val, err := strconv.ParseBool(args[0])
 				if err != nil {
 					return errors.Wrapf(err, "invalid value %s provided", style.Symbol(args[0]))
 				}
 				cfg.Experimental = val
-				if cfg.Experimental {
-					cfg.LayoutRepositoryDir = filepath.Join(filepath.Dir(cfgPath), "layout-repo")
-				} else {
-					cfg.LayoutRepositoryDir = ""
-				}
+				cfg.LayoutRepositoryDir = layoutRepositoryDir(cfg, cfgPath)
 
 				if err = config.Write(cfg, cfgPath); err != nil {
 					return errors.Wrap(err, "writing to config")
@@ -55,6 +57,113 @@ func ConfigExperimental(logger logging.Logger, cfg config.Config, cfgPath string
 		}),
 	}
 
+	cmd.AddCommand(configExperimentalEnable(logger, cfg, cfgPath))
+	cmd.AddCommand(configExperimentalDisable(logger, cfg, cfgPath))
+
 	AddHelpFlag(cmd, "experimental")
 	return cmd
 }
+
+func configExperimentalEnable(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "enable <feature>...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Enable experimental features on their own",
+		Example: "pack config experimental enable oci-layout",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			for _, name := range args {
+				if _, ok := config.KnownExperimentalFeatures.Find(name); !ok {
+					return errors.Errorf("unknown experimental feature %s", style.Symbol(name))
+				}
+			}
+
+			enabled := stringset.FromSlice(cfg.ExperimentalFeatures)
+			for _, name := range args {
+				if _, ok := enabled[name]; !ok {
+					cfg.ExperimentalFeatures = append(cfg.ExperimentalFeatures, name)
+					enabled[name] = nil
+				}
+			}
+			cfg.LayoutRepositoryDir = layoutRepositoryDir(cfg, cfgPath)
+
+			if err := config.Write(cfg, cfgPath); err != nil {
+				return errors.Wrap(err, "writing to config")
+			}
+
+			for _, warning := range config.KnownExperimentalFeatures.Warnings(args) {
+				logger.Warn(warning)
+			}
+			for _, name := range args {
+				logger.Infof("Experimental feature %s enabled", style.Symbol(name))
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "enable")
+	return cmd
+}
+
+func configExperimentalDisable(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "disable <feature>...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Disable experimental features enabled on their own",
+		Example: "pack config experimental disable oci-layout",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			disabled := stringset.FromSlice(args)
+			var features []string
+			for _, name := range cfg.ExperimentalFeatures {
+				if _, ok := disabled[name]; !ok {
+					features = append(features, name)
+				}
+			}
+			cfg.ExperimentalFeatures = features
+			cfg.LayoutRepositoryDir = layoutRepositoryDir(cfg, cfgPath)
+
+			if err := config.Write(cfg, cfgPath); err != nil {
+				return errors.Wrap(err, "writing to config")
+			}
+
+			for _, name := range args {
+				logger.Infof("Experimental feature %s disabled", style.Symbol(name))
+			}
+			if cfg.Experimental {
+				logger.Warnf("Every experimental feature is still enabled by %s, run `pack config experimental false` to disable them", style.Symbol("experimental = true"))
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "disable")
+	return cmd
+}
+
+func listExperimentalFeatures(logger logging.Logger, cfg config.Config) error {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "FEATURE\tENABLED\tDESCRIPTION")
+	for _, feature := range config.KnownExperimentalFeatures {
+		enabled := "no"
+		if cfg.ExperimentalEnabled(feature.Name) {
+			enabled = "yes"
+		}
+
+		description := feature.Description
+		switch {
+		case feature.Graduated != "":
+			description += fmt.Sprintf(" (no longer experimental since pack %s)", feature.Graduated)
+		case feature.Removal != "":
+			description += fmt.Sprintf(" (deprecated, to be removed in pack %s)", feature.Removal)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", feature.Name, enabled, description)
+	}
+	return tw.Flush()
+}
+
+// layoutRepositoryDir returns the directory of the OCI layout repository when exporting to OCI layout is enabled
+func layoutRepositoryDir(cfg config.Config, cfgPath string) string {
+	if !cfg.ExperimentalEnabled(config.FeatureOCILayout) {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfgPath), "layout-repo")
+}
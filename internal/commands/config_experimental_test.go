@@ -66,6 +66,72 @@ func testConfigExperimental(t *testing.T, when spec.G, it spec.S) {
 				output := outBuf.String()
 				h.AssertContains(t, output, "Experimental features are enabled!")
 			})
+
+			it("lists the experimental features and whether they are enabled", func() {
+				cmd = commands.ConfigExperimental(logger, config.Config{ExperimentalFeatures: []string{config.FeatureOCILayout}}, configPath)
+				cmd.SetArgs([]string{})
+				h.AssertNil(t, cmd.Execute())
+				output := outBuf.String()
+				h.AssertContainsMatch(t, output, `oci-layout\s+yes\s+Building images to, and from, OCI layout directories`)
+				h.AssertContainsMatch(t, output, `windows\s+no\s+`)
+			})
+		})
+
+		when("enable", func() {
+			it("adds the features to the config", func() {
+				cmd = commands.ConfigExperimental(logger, config.Config{ExperimentalFeatures: []string{config.FeatureWindows}}, configPath)
+				cmd.SetArgs([]string{"enable", "wasi", "windows"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Experimental feature 'wasi' enabled")
+
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.ExperimentalFeatures, []string{"windows", "wasi"})
+				h.AssertEq(t, cfg.Experimental, false)
+				h.AssertEq(t, cfg.LayoutRepositoryDir, "")
+			})
+
+			it("configures the oci layout repo for oci-layout", func() {
+				cmd.SetArgs([]string{"enable", "oci-layout"})
+				h.AssertNil(t, cmd.Execute())
+
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.LayoutRepositoryDir, filepath.Join(filepath.Dir(configPath), "layout-repo"))
+			})
+
+			it("returns error for unknown features", func() {
+				cmd.SetArgs([]string{"enable", "wasi", "time-travel"})
+				h.AssertError(t, cmd.Execute(), fmt.Sprintf("unknown experimental feature %s", style.Symbol("time-travel")))
+
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(cfg.ExperimentalFeatures), 0)
+			})
+		})
+
+		when("disable", func() {
+			it("removes the features from the config", func() {
+				cmd = commands.ConfigExperimental(logger, config.Config{
+					ExperimentalFeatures: []string{config.FeatureWindows, config.FeatureOCILayout},
+					LayoutRepositoryDir:  "some/layout-repo",
+				}, configPath)
+				cmd.SetArgs([]string{"disable", "oci-layout"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Experimental feature 'oci-layout' disabled")
+
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.ExperimentalFeatures, []string{"windows"})
+				h.AssertEq(t, cfg.LayoutRepositoryDir, "")
+			})
+
+			it("warns when every experimental feature is still enabled", func() {
+				cmd = commands.ConfigExperimental(logger, config.Config{Experimental: true, ExperimentalFeatures: []string{config.FeatureWindows}}, configPath)
+				cmd.SetArgs([]string{"disable", "windows"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Warning: Every experimental feature is still enabled by 'experimental = true'")
+			})
 		})
 
 		when("set", func() {
@@ -1,7 +1,12 @@
 package commands
 
 import (
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -11,7 +16,11 @@ import (
 )
 
 func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
-	var unset bool
+	var (
+		unset        bool
+		dockerConfig string
+		allowDigests []string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "lifecycle-image <lifecycle-image>",
@@ -20,7 +29,8 @@ func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath stri
 		Long: "You can use this command to set a custom image to fetch the lifecycle from." +
 			"This will be used for untrusted builders. If unset, defaults to: " + config.DefaultLifecycleImageRepo +
 			"For more on trusted builders, and when to trust or untrust a builder, " +
-			"check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders/",
+			"check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders/\n" +
+			"The lifecycle image may be pulled with credentials of its own, and verified against the digests it is allowed to have.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			switch {
 			case unset:
@@ -33,6 +43,8 @@ func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath stri
 				} else {
 					oldImage := cfg.LifecycleImage
 					cfg.LifecycleImage = ""
+					cfg.LifecycleImageAuth = ""
+					cfg.LifecycleImageDigests = nil
 					if err := config.Write(cfg, cfgPath); err != nil {
 						return errors.Wrapf(err, "failed to write to config at %s", cfgPath)
 					}
@@ -41,6 +53,12 @@ func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath stri
 			case len(args) == 0:
 				if cfg.LifecycleImage != "" {
 					logger.Infof("The current custom lifecycle image is %s", style.Symbol(cfg.LifecycleImage))
+					if cfg.LifecycleImageAuth != "" {
+						logger.Infof("It is pulled with the credentials of the docker config in %s", style.Symbol(cfg.LifecycleImageAuth))
+					}
+					if len(cfg.LifecycleImageDigests) > 0 {
+						logger.Infof("It is allowed to have the digests %s", style.Symbol(strings.Join(cfg.LifecycleImageDigests, ", ")))
+					}
 				} else {
 					logger.Infof("No custom lifecycle image is set. Lifecycle images from %s repo will be used.", style.Symbol(config.DefaultLifecycleImageRepo))
 				}
@@ -52,12 +70,24 @@ func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath stri
 				if err != nil {
 					return errors.Wrapf(err, "Invalid image name %s provided", style.Symbol(imageName))
 				}
-				if imageName == cfg.LifecycleImage {
+				for _, digest := range allowDigests {
+					if _, err := v1.NewHash(digest); err != nil {
+						return errors.Wrapf(err, "Invalid digest %s provided", style.Symbol(digest))
+					}
+				}
+				if dockerConfig != "" {
+					if dockerConfig, err = filepath.Abs(dockerConfig); err != nil {
+						return errors.Wrapf(err, "resolving docker config directory")
+					}
+				}
+				if imageName == cfg.LifecycleImage && dockerConfig == cfg.LifecycleImageAuth && slices.Equal(allowDigests, cfg.LifecycleImageDigests) {
 					logger.Infof("Custom lifecycle image is already set to %s", style.Symbol(imageName))
 					return nil
 				}
 
 				cfg.LifecycleImage = imageName
+				cfg.LifecycleImageAuth = dockerConfig
+				cfg.LifecycleImageDigests = allowDigests
 				if err := config.Write(cfg, cfgPath); err != nil {
 					return errors.Wrapf(err, "failed to write to config at %s", cfgPath)
 				}
@@ -69,6 +99,8 @@ func ConfigLifecycleImage(logger logging.Logger, cfg config.Config, cfgPath stri
 	}
 
 	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "Unset custom lifecycle image, and use the lifecycle images from "+style.Symbol(config.DefaultLifecycleImageRepo))
+	cmd.Flags().StringVar(&dockerConfig, "docker-config", "", "Directory of a docker config with the credentials to pull the lifecycle image with, for lifecycle images in private registries")
+	cmd.Flags().StringSliceVar(&allowDigests, "allow-digest", nil, "Digest the lifecycle image is allowed to have, such as sha256:..., builds fail when the lifecycle image has none of them."+stringSliceHelp("digest"))
 	AddHelpFlag(cmd, "lifecycle-image")
 	return cmd
 }
@@ -120,6 +120,24 @@ func testConfigLifecycleImageCommand(t *testing.T, when spec.G, it spec.S) {
 					assert.ErrorContains(command.Execute(), "failed to write to config at")
 				})
 			})
+			when("credentials and digests are specified", func() {
+				it("sets them in config along with the lifecycle-image", func() {
+					digest := "sha256:" + strings.Repeat("a", 64)
+					command.SetArgs([]string{"registry.example.com/lifecycle:v1", "--docker-config", tempPackHome, "--allow-digest", digest})
+					assert.Succeeds(command.Execute())
+
+					readCfg, err := config.Read(configFile)
+					assert.Nil(err)
+					assert.Equal(readCfg.LifecycleImage, "registry.example.com/lifecycle:v1")
+					assert.Equal(readCfg.LifecycleImageAuth, tempPackHome)
+					assert.Equal(readCfg.LifecycleImageDigests, []string{digest})
+				})
+
+				it("returns an error for invalid digests", func() {
+					command.SetArgs([]string{"registry.example.com/lifecycle:v1", "--allow-digest", "some-digest"})
+					h.AssertError(t, command.Execute(), "Invalid digest 'some-digest' provided")
+				})
+			})
 			when("invalid lifecycle-image is specified", func() {
 				it("returns an error", func() {
 					command.SetArgs([]string{"custom$1#-lifecycle/image-repo"})
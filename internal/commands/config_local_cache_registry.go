@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigLocalCacheRegistry(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enable-local-cache-registry [<true | false>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Pull images through a local cache registry",
+		Long: "When enabled, pack starts a pull-through cache registry whenever it pulls an image, and stores the manifests and layers it pulls in your pack home directory. " +
+			"Images shared by several builds or projects are then only downloaded once.\n\n" +
+			"* Running `pack config enable-local-cache-registry` enables the local cache registry.\n" +
+			"* Running `pack config enable-local-cache-registry <true | false>` enables or disables the local cache registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			val := true
+			if len(args) > 0 {
+				var err error
+				val, err = strconv.ParseBool(args[0])
+				if err != nil {
+					return errors.Wrapf(err, "invalid value %s provided", style.Symbol(args[0]))
+				}
+			}
+
+			cfg.LocalCacheRegistry = val
+			if err := config.Write(cfg, cfgPath); err != nil {
+				return errors.Wrap(err, "writing to config")
+			}
+
+			if cfg.LocalCacheRegistry {
+				logger.Info("Local cache registry enabled")
+			} else {
+				logger.Info("Local cache registry disabled")
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "enable-local-cache-registry")
+	return cmd
+}
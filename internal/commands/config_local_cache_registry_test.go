@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigLocalCacheRegistry(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigLocalCacheRegistryCommand", testConfigLocalCacheRegistry, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigLocalCacheRegistry(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+	)
+
+	it.Before(func() {
+		var err error
+
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		cmd = commands.ConfigLocalCacheRegistry(logger, config.Config{}, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("#ConfigLocalCacheRegistry", func() {
+		it("enables the local cache registry by default", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Local cache registry enabled")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, cfg.LocalCacheRegistry)
+		})
+
+		it("disables the local cache registry", func() {
+			cmd = commands.ConfigLocalCacheRegistry(logger, config.Config{LocalCacheRegistry: true}, configPath)
+			cmd.SetArgs([]string{"false"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Local cache registry disabled")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, cfg.LocalCacheRegistry)
+		})
+
+		it("returns an error for invalid values", func() {
+			cmd.SetArgs([]string{"maybe"})
+			h.AssertError(t, cmd.Execute(), "invalid value 'maybe' provided")
+		})
+	})
+}
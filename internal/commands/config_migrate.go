@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigMigrate(logger logging.Logger, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Args:  cobra.NoArgs,
+		Short: "Upgrade your pack config file to the latest schema version",
+		Long: "Upgrade your pack config file to the latest schema version, replacing deprecated keys with their successors.\n\n" +
+			"The config file must be valid, see `pack config validate`, for none of its keys to be lost.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.ReadStrict(cfgPath)
+			if err != nil {
+				return err
+			}
+
+			if cfg.SchemaVersion == config.SchemaVersion {
+				logger.Infof("Config file %s already uses the latest schema version %d", style.Symbol(cfgPath), config.SchemaVersion)
+				return nil
+			}
+
+			migrated, changes := config.Migrate(cfg)
+			if err := config.Write(migrated, cfgPath); err != nil {
+				return errors.Wrap(err, "writing config")
+			}
+
+			for _, change := range changes {
+				logger.Infof("Config file: %s", change)
+			}
+			logger.Infof("Migrated config file %s from schema version %d to %d", style.Symbol(cfgPath), cfg.SchemaVersion, config.SchemaVersion)
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "migrate")
+	return cmd
+}
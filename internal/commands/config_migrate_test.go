@@ -0,0 +1,71 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigMigrate(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigMigrateCommand", testConfigMigrate, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigMigrate(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		configPath string
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		configPath = filepath.Join(t.TempDir(), "config.toml")
+
+		cmd = commands.ConfigMigrate(logger, configPath)
+		cmd.SetArgs([]string{})
+	})
+
+	when("#ConfigMigrate", func() {
+		it("upgrades the config to the latest schema version", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("default-registry-url = \"https://example.com/registry-index\"\n"), 0600))
+
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Config file: replaced 'default-registry-url' with registry 'default'")
+			h.AssertContains(t, outBuf.String(), "Migrated config file '"+configPath+"' from schema version 0 to 1")
+
+			cfg, err := config.ReadStrict(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.SchemaVersion, config.SchemaVersion)
+			h.AssertEq(t, cfg.DefaultRegistryName, "default")
+		})
+
+		it("doesn't rewrite configs of the latest schema version", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("schema-version = 1\n"), 0600))
+
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "already uses the latest schema version 1")
+		})
+
+		it("fails on invalid configs, not to lose their unknown keys", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("pull-polcy = \"never\"\n"), 0600))
+
+			h.AssertError(t, cmd.Execute(), "unknown configuration element 'pull-polcy'")
+			contents, err := os.ReadFile(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "pull-polcy = \"never\"\n")
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigSuggestedBuilders(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	var (
+		vendor      string
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "suggested-builders",
+		Short: "List, add and remove suggested builders",
+		Long: "Suggested builders are displayed by `pack builder suggest`. Use these commands to surface additional builders, " +
+			"such as builders maintained by your organization, alongside the default suggestions.\n\n" +
+			"Builders added here are not trusted by default; use `pack config trusted-builders add` to trust them.",
+		Aliases: []string{"suggested-builder"},
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			listSuggestedBuilders(args, logger, cfg)
+			return nil
+		}),
+	}
+
+	listCmd := generateListCmd("suggested-builders", logger, cfg, listSuggestedBuilders)
+	listCmd.Long = "List Suggested Builders.\n\nShow the builders that have been added locally using `suggested-builders add`"
+	listCmd.Example = "pack config suggested-builders list"
+	cmd.AddCommand(listCmd)
+
+	addCmd := generateAdd("suggested-builders", logger, cfg, cfgPath, func(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+		return addSuggestedBuilder(args[0], vendor, description, logger, cfg, cfgPath)
+	})
+	addCmd.Long = "Suggest builder.\n\nThe builder will be displayed by `pack builder suggest`."
+	addCmd.Example = "pack config suggested-builders add registry.example.com/platform/builder:latest --vendor \"Platform Team\""
+	addCmd.Flags().StringVar(&vendor, "vendor", "Custom", "Vendor of the builder")
+	addCmd.Flags().StringVar(&description, "description", "", "Description of the builder, used when the builder image does not provide one")
+	cmd.AddCommand(addCmd)
+
+	rmCmd := generateRemove("suggested-builders", logger, cfg, cfgPath, removeSuggestedBuilder)
+	rmCmd.Long = "Stop suggesting builder.\n\nThe builder will no longer be displayed by `pack builder suggest`."
+	rmCmd.Example = "pack config suggested-builders remove registry.example.com/platform/builder:latest"
+	cmd.AddCommand(rmCmd)
+
+	cmd.AddCommand(configSuggestedBuildersSource(logger, cfg, cfgPath))
+
+	AddHelpFlag(cmd, "suggested-builders")
+	return cmd
+}
+
+func configSuggestedBuildersSource(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	var (
+		publicKey string
+		unset     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "source [<url>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Configure a signed metadata source for suggested builders",
+		Long: "Replace the built-in builder suggestions with a JSON document fetched from <url>.\n\n" +
+			"The document must have the form {\"builders\": [{\"vendor\": \"...\", \"image\": \"...\", \"description\": \"...\"}]} " +
+			"and be accompanied by a base64 encoded ed25519 signature at <url>.sig, which is verified using --public-key. " +
+			"The document is cached for 24 hours; use `pack builder suggest --refresh` to fetch it again.",
+		Example: "pack config suggested-builders source https://example.com/builders.json --public-key <base64-ed25519-key>",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			switch {
+			case unset:
+				if len(args) > 0 {
+					return errors.Errorf("source url and --unset cannot be specified simultaneously")
+				}
+
+				if cfg.SuggestedBuildersSource.URL == "" {
+					logger.Info("No suggested builders source was set.")
+					return nil
+				}
+
+				cfg.SuggestedBuildersSource = config.SuggestedBuildersSource{}
+				if err := config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrapf(err, "writing config to %s", cfgPath)
+				}
+				logger.Info("Successfully unset suggested builders source")
+			case len(args) == 0:
+				if cfg.SuggestedBuildersSource.URL == "" {
+					logger.Info("No suggested builders source is set. Built-in suggestions will be used.")
+					return nil
+				}
+				logger.Infof("The current suggested builders source is %s", style.Symbol(cfg.SuggestedBuildersSource.URL))
+			default:
+				if publicKey == "" {
+					return errors.New("--public-key is required to verify the suggested builders source")
+				}
+
+				cfg.SuggestedBuildersSource = config.SuggestedBuildersSource{URL: args[0], PublicKey: publicKey}
+				if err := config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrapf(err, "writing config to %s", cfgPath)
+				}
+				logger.Infof("Suggested builders will now be loaded from %s", style.Symbol(args[0]))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&publicKey, "public-key", "", "Base64 encoded ed25519 public key used to verify the source signature")
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "Unset the suggested builders source, and use the built-in suggestions")
+	AddHelpFlag(cmd, "source")
+	return cmd
+}
+
+func addSuggestedBuilder(imageName, vendor, description string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	if _, err := name.ParseReference(imageName, name.WeakValidation); err != nil {
+		return errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+	}
+
+	for _, b := range cfg.SuggestedBuilders {
+		if b.Image == imageName {
+			logger.Infof("Builder %s is already suggested", style.Symbol(imageName))
+			return nil
+		}
+	}
+
+	cfg.SuggestedBuilders = append(cfg.SuggestedBuilders, config.SuggestedBuilder{
+		Image:       imageName,
+		Vendor:      vendor,
+		Description: description,
+	})
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+	logger.Infof("Builder %s is now suggested", style.Symbol(imageName))
+
+	return nil
+}
+
+func removeSuggestedBuilder(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	imageName := args[0]
+
+	existingBuilders := cfg.SuggestedBuilders
+	cfg.SuggestedBuilders = []config.SuggestedBuilder{}
+	for _, b := range existingBuilders {
+		if b.Image == imageName {
+			continue
+		}
+		cfg.SuggestedBuilders = append(cfg.SuggestedBuilders, b)
+	}
+
+	if len(existingBuilders) == len(cfg.SuggestedBuilders) {
+		logger.Infof("Builder %s wasn't suggested", style.Symbol(imageName))
+		return nil
+	}
+
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+	logger.Infof("Builder %s is no longer suggested", style.Symbol(imageName))
+
+	return nil
+}
+
+func listSuggestedBuilders(args []string, logger logging.Logger, cfg config.Config) {
+	if len(cfg.SuggestedBuilders) == 0 {
+		logger.Info("No suggested builders have been added")
+		return
+	}
+
+	logger.Info("Suggested Builders:")
+	for _, b := range cfg.SuggestedBuilders {
+		logger.Infof("  %s (%s)", b.Image, b.Vendor)
+	}
+}
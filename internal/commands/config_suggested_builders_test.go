@@ -0,0 +1,154 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSuggestedBuildersCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SuggestedBuildersCommands", testSuggestedBuildersCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testSuggestedBuildersCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command      *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+	)
+
+	it.Before(func() {
+		var err error
+
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		command = commands.ConfigSuggestedBuilders(logger, config.Config{}, configPath)
+		command.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("no args", func() {
+		it("reports that no builders were added", func() {
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No suggested builders have been added")
+		})
+	})
+
+	when("list", func() {
+		it("shows builders from the config", func() {
+			command = commands.ConfigSuggestedBuilders(logger, config.Config{
+				SuggestedBuilders: []config.SuggestedBuilder{{Image: "some/builder", Vendor: "Some Vendor"}},
+			}, configPath)
+			command.SetArgs([]string{"list"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "some/builder (Some Vendor)")
+		})
+	})
+
+	when("add", func() {
+		it("adds the builder to the config", func() {
+			command.SetArgs([]string{"add", "some/builder", "--vendor", "Some Vendor", "--description", "Some description"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Builder 'some/builder' is now suggested")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.SuggestedBuilders, []config.SuggestedBuilder{{
+				Image:       "some/builder",
+				Vendor:      "Some Vendor",
+				Description: "Some description",
+			}})
+		})
+
+		it("does nothing when the builder is already suggested", func() {
+			command = commands.ConfigSuggestedBuilders(logger, config.Config{
+				SuggestedBuilders: []config.SuggestedBuilder{{Image: "some/builder"}},
+			}, configPath)
+			command.SetArgs([]string{"add", "some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Builder 'some/builder' is already suggested")
+
+			_, err := os.Stat(configPath)
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("errors on an invalid image name", func() {
+			command.SetArgs([]string{"add", "Invalid Image"})
+			h.AssertError(t, command.Execute(), "invalid image name")
+		})
+	})
+
+	when("remove", func() {
+		it("removes the builder from the config", func() {
+			command = commands.ConfigSuggestedBuilders(logger, config.Config{
+				SuggestedBuilders: []config.SuggestedBuilder{{Image: "some/builder"}, {Image: "other/builder"}},
+			}, configPath)
+			command.SetArgs([]string{"remove", "some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Builder 'some/builder' is no longer suggested")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.SuggestedBuilders, []config.SuggestedBuilder{{Image: "other/builder"}})
+		})
+
+		it("reports builders that weren't suggested", func() {
+			command.SetArgs([]string{"remove", "some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Builder 'some/builder' wasn't suggested")
+		})
+	})
+
+	when("source", func() {
+		it("requires a public key", func() {
+			command.SetArgs([]string{"source", "https://example.com/builders.json"})
+			h.AssertError(t, command.Execute(), "--public-key is required")
+		})
+
+		it("saves the source to the config", func() {
+			command.SetArgs([]string{"source", "https://example.com/builders.json", "--public-key", "some-key"})
+			h.AssertNil(t, command.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.SuggestedBuildersSource, config.SuggestedBuildersSource{
+				URL:       "https://example.com/builders.json",
+				PublicKey: "some-key",
+			})
+		})
+
+		it("unsets the source", func() {
+			command = commands.ConfigSuggestedBuilders(logger, config.Config{
+				SuggestedBuildersSource: config.SuggestedBuildersSource{URL: "https://example.com/builders.json", PublicKey: "some-key"},
+			}, configPath)
+			command.SetArgs([]string{"source", "--unset"})
+			h.AssertNil(t, command.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.SuggestedBuildersSource, config.SuggestedBuildersSource{})
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ConfigTrustPolicy manages the registries whose images must be signed by trusted signers
+func ConfigTrustPolicy(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	var (
+		publicKeyPaths       []string
+		identities           []string
+		rootCertificatesPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "trust-policy",
+		Short: "List, add and remove registries that require signed images",
+		Long: "Builders, buildpacks, lifecycle and run images pulled from a registry in the trust policy must carry a cosign " +
+			"signature from one of the public keys or certificate identities trusted for that registry.\n\n" +
+			"Images from other registries are not verified. Use `pack config trust-policy enforce true` to refuse images " +
+			"that fail verification; otherwise failures are only reported as warnings.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			listTrustPolicy(args, logger, cfg)
+			return nil
+		}),
+	}
+
+	listCmd := generateListCmd("trust-policy", logger, cfg, listTrustPolicy)
+	listCmd.Long = "List trust policy.\n\nShow the registries that require signed images, and the signers trusted for each."
+	listCmd.Example = "pack config trust-policy list"
+	cmd.AddCommand(listCmd)
+
+	addCmd := generateAdd("trust-policy registry", logger, cfg, cfgPath, func(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+		return addTrustPolicyRegistry(args[0], publicKeyPaths, identities, rootCertificatesPath, logger, cfg, cfgPath)
+	})
+	addCmd.Use = "add <registry-or-repository-prefix>"
+	addCmd.Long = "Require signed images.\n\nImages whose name starts with the given registry or repository prefix must be signed " +
+		"with one of the given public keys, or with a certificate issued to one of the given identities that chains to the given root certificates."
+	addCmd.Example = "pack config trust-policy add docker.io/paketobuildpacks --public-key ./cosign.pub\n" +
+		"pack config trust-policy add ghcr.io/my-org --identity https://token.actions.githubusercontent.com=https://github.com/my-org/buildpacks/.github/workflows/release.yml@refs/heads/main --root-certificates ./fulcio.pem"
+	addCmd.Flags().StringSliceVar(&publicKeyPaths, "public-key", nil, "Path to a PEM encoded public key trusted to sign images"+stringSliceHelp("public key"))
+	addCmd.Flags().StringSliceVar(&identities, "identity", nil, "Certificate identity trusted to sign images, in the form <issuer>=<subject>"+stringSliceHelp("identity"))
+	addCmd.Flags().StringVar(&rootCertificatesPath, "root-certificates", "", "Path to the PEM encoded root certificates that signing certificates must chain to")
+	cmd.AddCommand(addCmd)
+
+	rmCmd := generateRemove("trust-policy registry", logger, cfg, cfgPath, removeTrustPolicyRegistry)
+	rmCmd.Use = "remove <registry-or-repository-prefix>"
+	rmCmd.Long = "Stop requiring signed images.\n\nImages under the given prefix will no longer be verified."
+	rmCmd.Example = "pack config trust-policy remove docker.io/paketobuildpacks"
+	cmd.AddCommand(rmCmd)
+
+	cmd.AddCommand(configTrustPolicyEnforce(logger, cfg, cfgPath))
+
+	AddHelpFlag(cmd, "trust-policy")
+	return cmd
+}
+
+func configTrustPolicyEnforce(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enforce [<true | false>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Show or set whether images failing verification are refused",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if cfg.TrustPolicy.Enforce {
+					logger.Info("The trust policy is enforced: images failing verification are refused")
+				} else {
+					logger.Info("The trust policy is not enforced: images failing verification are reported as warnings")
+				}
+				return nil
+			}
+
+			val, err := strconv.ParseBool(args[0])
+			if err != nil {
+				return errors.Wrapf(err, "invalid value %s provided", style.Symbol(args[0]))
+			}
+			cfg.TrustPolicy.Enforce = val
+			if err := config.Write(cfg, cfgPath); err != nil {
+				return errors.Wrapf(err, "writing config to %s", cfgPath)
+			}
+
+			if val {
+				logger.Info("Trust policy enforced")
+			} else {
+				logger.Info("Trust policy no longer enforced")
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "enforce")
+	return cmd
+}
+
+// ImageTrustPolicy converts the trust policy of the pack config for use by the client
+func ImageTrustPolicy(policy config.TrustPolicy) image.TrustPolicy {
+	result := image.TrustPolicy{Enforce: policy.Enforce}
+	for _, r := range policy.Registries {
+		registry := image.TrustedRegistry{
+			Prefix:           r.Prefix,
+			PublicKeys:       r.PublicKeys,
+			RootCertificates: r.RootCertificates,
+		}
+		for _, identity := range r.Identities {
+			registry.Identities = append(registry.Identities, image.TrustedIdentity{Issuer: identity.Issuer, Subject: identity.Subject})
+		}
+		result.Registries = append(result.Registries, registry)
+	}
+	return result
+}
+
+func addTrustPolicyRegistry(prefix string, publicKeyPaths, identities []string, rootCertificatesPath string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	registry := config.TrustPolicyRegistry{Prefix: prefix}
+
+	for _, path := range publicKeyPaths {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading public key %s", style.Symbol(path))
+		}
+		registry.PublicKeys = append(registry.PublicKeys, string(key))
+	}
+
+	for _, identity := range identities {
+		issuer, subject, ok := strings.Cut(identity, "=")
+		if !ok {
+			return errors.Errorf("invalid identity %s, must be in the form <issuer>=<subject>", style.Symbol(identity))
+		}
+		registry.Identities = append(registry.Identities, config.TrustPolicyIdentity{Issuer: issuer, Subject: subject})
+	}
+
+	if rootCertificatesPath != "" {
+		roots, err := os.ReadFile(rootCertificatesPath)
+		if err != nil {
+			return errors.Wrapf(err, "reading root certificates %s", style.Symbol(rootCertificatesPath))
+		}
+		registry.RootCertificates = string(roots)
+	}
+
+	var registries []config.TrustPolicyRegistry
+	for _, r := range cfg.TrustPolicy.Registries {
+		if r.Prefix != prefix {
+			registries = append(registries, r)
+		}
+	}
+	registries = append(registries, registry)
+
+	if err := ImageTrustPolicy(config.TrustPolicy{Registries: []config.TrustPolicyRegistry{registry}}).Validate(); err != nil {
+		return err
+	}
+
+	cfg.TrustPolicy.Registries = registries
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+	logger.Infof("Images from %s now require a trusted signature", style.Symbol(prefix))
+	return nil
+}
+
+func removeTrustPolicyRegistry(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	prefix := args[0]
+
+	existing := cfg.TrustPolicy.Registries
+	cfg.TrustPolicy.Registries = nil
+	for _, r := range existing {
+		if r.Prefix != prefix {
+			cfg.TrustPolicy.Registries = append(cfg.TrustPolicy.Registries, r)
+		}
+	}
+
+	if len(existing) == len(cfg.TrustPolicy.Registries) {
+		logger.Infof("Images from %s didn't require a trusted signature", style.Symbol(prefix))
+		return nil
+	}
+
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+	logger.Infof("Images from %s no longer require a trusted signature", style.Symbol(prefix))
+	return nil
+}
+
+func listTrustPolicy(args []string, logger logging.Logger, cfg config.Config) {
+	if len(cfg.TrustPolicy.Registries) == 0 {
+		logger.Info("No registries require signed images")
+		return
+	}
+
+	if cfg.TrustPolicy.Enforce {
+		logger.Info("Trust policy (enforced):")
+	} else {
+		logger.Info("Trust policy (not enforced):")
+	}
+	for _, r := range cfg.TrustPolicy.Registries {
+		logger.Infof("  %s: %d public key(s), %d identities", r.Prefix, len(r.PublicKeys), len(r.Identities))
+		for _, identity := range r.Identities {
+			logger.Infof("    %s=%s", identity.Issuer, identity.Subject)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package commands_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigTrustPolicy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigTrustPolicyCommand", testConfigTrustPolicyCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigTrustPolicyCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+		keyPath      string
+		keyPEM       string
+	)
+
+	it.Before(func() {
+		var err error
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		h.AssertNil(t, err)
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		h.AssertNil(t, err)
+		keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		keyPath = filepath.Join(tempPackHome, "cosign.pub")
+		h.AssertNil(t, os.WriteFile(keyPath, []byte(keyPEM), 0600))
+
+		cmd = commands.ConfigTrustPolicy(logger, config.Config{}, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("-h", func() {
+		it("prints available commands", func() {
+			cmd.SetArgs([]string{"-h"})
+			h.AssertNil(t, cmd.Execute())
+			for _, command := range []string{"add", "remove", "list", "enforce"} {
+				h.AssertContains(t, outBuf.String(), command)
+			}
+		})
+	})
+
+	when("no arguments", func() {
+		it("reports that no registries require signed images", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "No registries require signed images")
+		})
+
+		it("lists the registries of the policy", func() {
+			cmd = commands.ConfigTrustPolicy(logger, config.Config{TrustPolicy: config.TrustPolicy{
+				Enforce: true,
+				Registries: []config.TrustPolicyRegistry{{
+					Prefix:     "gcr.io/my-org",
+					PublicKeys: []string{keyPEM},
+					Identities: []config.TrustPolicyIdentity{{Issuer: "https://issuer.example.com", Subject: "release@example.com"}},
+				}},
+			}}, configPath)
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Trust policy (enforced):")
+			h.AssertContains(t, outBuf.String(), "gcr.io/my-org: 1 public key(s), 1 identities")
+			h.AssertContains(t, outBuf.String(), "https://issuer.example.com=release@example.com")
+		})
+	})
+
+	when("add", func() {
+		it("stores the public key in the config", func() {
+			cmd.SetArgs([]string{"add", "gcr.io/my-org", "--public-key", keyPath})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Images from 'gcr.io/my-org' now require a trusted signature")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.TrustPolicy.Registries, []config.TrustPolicyRegistry{{Prefix: "gcr.io/my-org", PublicKeys: []string{keyPEM}}})
+		})
+
+		it("fails for identities without root certificates", func() {
+			cmd.SetArgs([]string{"add", "gcr.io/my-org", "--identity", "https://issuer.example.com=release@example.com"})
+			h.AssertError(t, cmd.Execute(), "trust policy for 'gcr.io/my-org' defines identities but no root certificates")
+		})
+
+		it("fails for malformed identities", func() {
+			cmd.SetArgs([]string{"add", "gcr.io/my-org", "--identity", "release@example.com"})
+			h.AssertError(t, cmd.Execute(), "invalid identity 'release@example.com', must be in the form <issuer>=<subject>")
+		})
+	})
+
+	when("remove", func() {
+		it("removes the registry from the policy", func() {
+			cmd = commands.ConfigTrustPolicy(logger, config.Config{TrustPolicy: config.TrustPolicy{
+				Registries: []config.TrustPolicyRegistry{{Prefix: "gcr.io/my-org", PublicKeys: []string{keyPEM}}},
+			}}, configPath)
+			cmd.SetArgs([]string{"remove", "gcr.io/my-org"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Images from 'gcr.io/my-org' no longer require a trusted signature")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cfg.TrustPolicy.Registries), 0)
+		})
+	})
+
+	when("enforce", func() {
+		it("enforces the policy", func() {
+			cmd.SetArgs([]string{"enforce", "true"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Trust policy enforced")
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, cfg.TrustPolicy.Enforce)
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigValidate(logger logging.Logger, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Args:  cobra.NoArgs,
+		Short: "Validate your pack config file",
+		Long: "Check your pack config file against the schema of this version of pack. Keys the schema doesn't define, such as misspelled ones, are listed as errors.\n\n" +
+			"A config file of an older schema version is valid, but `pack config migrate` upgrades it to the latest one.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.ReadStrict(cfgPath)
+			if err != nil {
+				return err
+			}
+
+			if _, changes := config.Migrate(cfg); len(changes) > 0 {
+				logger.Warnf("Config file %s uses schema version %d, run %s to upgrade it to %d: %s", style.Symbol(cfgPath), cfg.SchemaVersion, style.Symbol("pack config migrate"), config.SchemaVersion, strings.Join(changes, ", "))
+			}
+			logger.Infof("Config file %s is valid", style.Symbol(cfgPath))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "validate")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+)
+
+func ConfigValidateProject(logger logging.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-project <path>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Validate a project.toml file",
+		Long: "Check a project descriptor before building with it: its schema version, keys, environment variable names, " +
+			"buildpack references, and include and exclude patterns. Every problem found is listed, and the command fails if any error is found.\n\n" +
+			"The path may be a project.toml file, or a directory containing one.",
+		Example: "pack config validate-project ./my-app",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			descriptorPath := args[0]
+			if isDir, err := paths.IsDir(descriptorPath); err == nil && isDir {
+				descriptorPath = filepath.Join(descriptorPath, "project.toml")
+			}
+			if _, err := os.Stat(descriptorPath); err != nil {
+				return err
+			}
+
+			result, err := project.ValidateProjectDescriptor(descriptorPath)
+			if err != nil {
+				return err
+			}
+
+			for _, warning := range result.Warnings {
+				logger.Warnf("%s: %s", descriptorPath, warning)
+			}
+			for _, validationErr := range result.Errors {
+				logger.Errorf("%s: %s", descriptorPath, validationErr)
+			}
+			if !result.Valid() {
+				return client.NewSoftError()
+			}
+
+			logger.Infof("Project descriptor %s is valid", style.Symbol(descriptorPath))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "validate-project")
+	return cmd
+}
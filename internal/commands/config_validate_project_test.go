@@ -0,0 +1,61 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigValidateProject(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigValidateProjectCommand", testConfigValidateProject, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigValidateProject(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		projectDir string
+	)
+
+	it.Before(func() {
+		cmd = commands.ConfigValidateProject(logging.NewLogWithWriters(&outBuf, &outBuf))
+		projectDir = t.TempDir()
+	})
+
+	when("#ConfigValidateProject", func() {
+		it("reports a valid project.toml of a directory", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(projectDir, "project.toml"), []byte("[_]\nschema-version = \"0.2\"\n"), 0600))
+
+			cmd.SetArgs([]string{projectDir})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Project descriptor '"+filepath.Join(projectDir, "project.toml")+"' is valid")
+		})
+
+		it("lists the errors, and fails", func() {
+			path := filepath.Join(projectDir, "project.toml")
+			h.AssertNil(t, os.WriteFile(path, []byte("[_]\nschema-version = \"0.2\"\n\n[[io.buildpacks.build.env]]\nname = \"MY VAR\"\n"), 0600))
+
+			cmd.SetArgs([]string{path})
+			h.AssertEq(t, cmd.Execute(), client.NewSoftError())
+			h.AssertContains(t, outBuf.String(), "ERROR: "+path+": environment variable name 'MY VAR' is invalid")
+		})
+
+		it("errors when there is no project.toml", func() {
+			cmd.SetArgs([]string{projectDir})
+			h.AssertNotNil(t, cmd.Execute())
+		})
+	})
+}
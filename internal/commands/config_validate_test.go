@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigValidate(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigValidateCommand", testConfigValidate, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigValidate(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		configPath string
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		configPath = filepath.Join(t.TempDir(), "config.toml")
+
+		cmd = commands.ConfigValidate(logger, configPath)
+		cmd.SetArgs([]string{})
+	})
+
+	when("#ConfigValidate", func() {
+		it("reports a valid config", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("schema-version = 1\npull-policy = \"never\"\n"), 0600))
+
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Config file '"+configPath+"' is valid")
+			h.AssertNotContains(t, outBuf.String(), "Warning")
+		})
+
+		it("lists unknown keys", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("pull-polcy = \"never\"\n"), 0600))
+
+			h.AssertError(t, cmd.Execute(), "unknown configuration element 'pull-polcy'")
+		})
+
+		it("warns when the config can be migrated", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("default-registry-url = \"https://example.com/registry-index\"\n"), 0600))
+
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Warning: Config file '"+configPath+"' uses schema version 0, run 'pack config migrate' to upgrade it to 1")
+			h.AssertContains(t, outBuf.String(), "is valid")
+		})
+	})
+}
@@ -33,7 +33,9 @@ func CreateBuilder(logger logging.Logger, cfg config.Config, pack PackClient) *c
 Creating a custom builder allows you to control what buildpacks are used and what image apps are based on. For more on how to create a builder, see: https://buildpacks.io/docs/operator-guide/create-a-builder/.
 `,
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "create-builder", "builder create")
+			if err := deprecationWarning(logger, "create-builder", "builder create"); err != nil {
+				return err
+			}
 
 			if err := validateCreateFlags(&flags, cfg); err != nil {
 				return err
@@ -57,8 +59,8 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 			}
 
 			if hasExtensions(builderConfig) {
-				if !cfg.Experimental {
-					return errors.New("builder config contains image extensions; support for image extensions is currently experimental")
+				if !cfg.ExperimentalEnabled(config.FeatureExtensions) {
+					return client.NewFeatureExperimentError(config.FeatureExtensions, "builder config contains image extensions; support for image extensions is currently experimental")
 				}
 			}
 
@@ -85,11 +87,11 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	}
 
 	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
-	if !cfg.Experimental {
+	if !cfg.ExperimentalEnabled(config.FeatureBuildpackRegistry) {
 		cmd.Flags().MarkHidden("buildpack-registry")
 	}
-	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML file (required)")
+	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML or YAML file (required)")
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon.")
-	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
 	return cmd
 }
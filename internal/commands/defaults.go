@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ApplyDefaults returns a hook that sets the flags of the command it runs for to their defaults from the config,
+// unless they were given on the command line.
+func ApplyDefaults(logger logging.Logger, cfg config.Config) func(*cobra.Command, []string) error {
+	return logError(logger, func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		defaults := cfg.Defaults[name]
+
+		var flagNames []string
+		for flagName := range defaults {
+			flagNames = append(flagNames, flagName)
+		}
+		sort.Strings(flagNames)
+
+		for _, flagName := range flagNames {
+			flag := cmd.Flags().Lookup(flagName)
+			if flag == nil {
+				return errors.Errorf("config defaults of %s have unknown flag %s", style.Symbol(name), style.Symbol(flagName))
+			}
+			if flag.Changed {
+				continue
+			}
+
+			for _, value := range flagValues(defaults[flagName]) {
+				if err := cmd.Flags().Set(flagName, value); err != nil {
+					return errors.Wrapf(err, "config defaults of %s have invalid value for flag %s", style.Symbol(name), style.Symbol(flagName))
+				}
+			}
+			logger.Debugf("Using default %s from config for flag %s", style.Symbol(flag.Value.String()), style.Symbol(flagName))
+		}
+		return nil
+	})
+}
+
+// flagValues returns the values to set a flag to for a default from the config, one for each element of lists and
+// tables, which repeated flags accept one by one.
+func flagValues(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var values []string
+		for _, e := range v {
+			values = append(values, fmt.Sprint(e))
+		}
+		return values
+	case map[string]interface{}:
+		var values []string
+		for k, e := range v {
+			values = append(values, fmt.Sprintf("%s=%v", k, e))
+		}
+		sort.Strings(values)
+		return values
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,119 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ApplyDefaults", testApplyDefaults, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testApplyDefaults(t *testing.T, when spec.G, it spec.S) {
+	var (
+		rootCmd *cobra.Command
+		outBuf  bytes.Buffer
+		logger  logging.Logger
+
+		pullPolicy   string
+		trustBuilder bool
+		env          []string
+		labels       map[string]string
+	)
+
+	newRootCmd := func(cfg config.Config) {
+		rootCmd = &cobra.Command{
+			Use:               "pack",
+			PersistentPreRunE: commands.ApplyDefaults(logger, cfg),
+		}
+		buildCmd := &cobra.Command{Use: "build", RunE: func(*cobra.Command, []string) error { return nil }}
+		buildCmd.Flags().StringVar(&pullPolicy, "pull-policy", "", "")
+		buildCmd.Flags().BoolVar(&trustBuilder, "trust-builder", false, "")
+		buildCmd.Flags().StringArrayVarP(&env, "env", "e", nil, "")
+		builderCmd := &cobra.Command{Use: "builder"}
+		createCmd := &cobra.Command{Use: "create", RunE: func(*cobra.Command, []string) error { return nil }}
+		createCmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "")
+		builderCmd.AddCommand(createCmd)
+		rootCmd.AddCommand(buildCmd, builderCmd)
+	}
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		pullPolicy, trustBuilder, env, labels = "", false, nil, nil
+	})
+
+	when("#ApplyDefaults", func() {
+		it("sets the flags of the command to their defaults", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"build": {"pull-policy": "if-not-present", "trust-builder": true, "env": []interface{}{"A=1", "B=2"}},
+			}})
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, pullPolicy, "if-not-present")
+			h.AssertEq(t, trustBuilder, true)
+			h.AssertEq(t, env, []string{"A=1", "B=2"})
+		})
+
+		it("gives precedence to flags on the command line", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"build": {"pull-policy": "if-not-present", "trust-builder": true},
+			}})
+			rootCmd.SetArgs([]string{"build", "--pull-policy", "always"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, pullPolicy, "always")
+			h.AssertEq(t, trustBuilder, true)
+		})
+
+		it("sets the flags of nested commands by their path", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"builder create": {"label": map[string]interface{}{"team": "some-team"}},
+			}})
+			rootCmd.SetArgs([]string{"builder", "create"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, labels, map[string]string{"team": "some-team"})
+		})
+
+		it("doesn't set the flags of other commands", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"rebase": {"pull-policy": "never"},
+			}})
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, pullPolicy, "")
+		})
+
+		it("fails on unknown flags", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"build": {"pull-polcy": "never"},
+			}})
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertError(t, rootCmd.Execute(), "config defaults of 'build' have unknown flag 'pull-polcy'")
+		})
+
+		it("fails on invalid values", func() {
+			newRootCmd(config.Config{Defaults: config.Defaults{
+				"build": {"trust-builder": "maybe"},
+			}})
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertError(t, rootCmd.Execute(), "config defaults of 'build' have invalid value for flag 'trust-builder'")
+		})
+	})
+}
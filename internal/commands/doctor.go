@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// DoctorFlags define flags provided to the doctor command
+type DoctorFlags struct {
+	OutputFormat string
+}
+
+// Doctor diagnoses the environment pack runs in
+func Doctor(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags DoctorFlags
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Args:  cobra.NoArgs,
+		Short: "Diagnose the environment pack runs in",
+		Long: "Diagnose the environment pack runs in: whether the docker daemon is reachable and serves a supported API version, " +
+			"the permissions of its socket, the disk space available for caches and temporary files, the experimental features enabled, " +
+			"whether the daemon runs on another architecture, and whether the credential helpers of the docker config are installed.\n\n" +
+			"Each problem comes with a fix. The command fails when any check fails.",
+		Example: "pack doctor",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			checks := pack.Doctor(cmd.Context())
+
+			failed := 0
+			for _, check := range checks {
+				if check.Status == client.DoctorFailure {
+					failed++
+				}
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(checks, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling checks")
+				}
+				logger.Info(string(out))
+			} else {
+				for _, check := range checks {
+					logger.Infof("[%s] %s: %s", check.Status, check.Name, check.Message)
+					if check.Fix != "" {
+						logger.Infof("    fix: %s", check.Fix)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return errors.Errorf("%d of %d checks failed", failed, len(checks))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the checks (human-readable, json)")
+	AddHelpFlag(cmd, "doctor")
+	return cmd
+}
@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDoctorCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "DoctorCommand", testDoctorCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDoctorCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.Doctor(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Doctor", func() {
+		it("reports each check with its fix", func() {
+			mockClient.EXPECT().Doctor(gomock.Any()).Return([]client.DoctorCheck{
+				{Name: "docker daemon", Status: client.DoctorOK, Message: "Docker 24.0.7, API 1.43"},
+				{Name: "platform", Status: client.DoctorWarning, Message: "pack runs on arm64", Fix: "pass --platform"},
+			})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "[ok] docker daemon: Docker 24.0.7, API 1.43")
+			h.AssertContains(t, outBuf.String(), "[warning] platform: pack runs on arm64\n    fix: pass --platform")
+		})
+
+		it("fails when any check fails", func() {
+			mockClient.EXPECT().Doctor(gomock.Any()).Return([]client.DoctorCheck{
+				{Name: "docker daemon", Status: client.DoctorFailure, Message: "cannot connect to the daemon", Fix: "start the docker daemon"},
+				{Name: "credential helpers", Status: client.DoctorOK, Message: "no credential helpers are configured"},
+			})
+
+			h.AssertError(t, command.Execute(), "1 of 2 checks failed")
+		})
+
+		it("prints the checks as json", func() {
+			mockClient.EXPECT().Doctor(gomock.Any()).Return([]client.DoctorCheck{
+				{Name: "docker daemon", Status: client.DoctorOK, Message: "Docker 24.0.7, API 1.43"},
+			})
+
+			command.SetArgs([]string{"--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"status": "ok"`)
+		})
+	})
+}
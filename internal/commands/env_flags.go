@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// flagEnvPrefix is the prefix of the environment variables setting flags, see FlagEnvVar
+const flagEnvPrefix = "PACK_"
+
+// FlagEnvVar returns the environment variable setting the flag of any command, such as PACK_PULL_POLICY for
+// --pull-policy.
+func FlagEnvVar(flagName string) string {
+	return flagEnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// ApplyEnvFlags returns a hook that sets the flags of the command it runs for from their environment variables, see
+// FlagEnvVar, unless they were given on the command line. Flags taking lists take one comma-separated value, if any.
+func ApplyEnvFlags(logger logging.Logger, lookupEnv func(string) (string, bool)) func(*cobra.Command, []string) error {
+	return logError(logger, func(cmd *cobra.Command, args []string) error {
+		var err error
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if err != nil || flag.Changed || flag.Name == "help" || flag.Name == "version" {
+				return
+			}
+
+			envVar := FlagEnvVar(flag.Name)
+			value, ok := lookupEnv(envVar)
+			if !ok {
+				return
+			}
+			for _, value := range envFlagValues(flag, value) {
+				if setErr := cmd.Flags().Set(flag.Name, value); setErr != nil {
+					err = errors.Wrapf(setErr, "invalid value of %s for flag %s", style.Symbol(envVar), style.Symbol(flag.Name))
+					return
+				}
+			}
+		})
+		return err
+	})
+}
+
+// envFlagValues returns the values to set the flag to for the value of its environment variable. Repeated flags taking
+// values one by one, unlike the slice flags splitting them on commas themselves, are set to each comma-separated value.
+func envFlagValues(flag *pflag.Flag, value string) []string {
+	if flag.Value.Type() != "stringArray" {
+		return []string{value}
+	}
+
+	var values []string
+	for _, value := range strings.Split(value, ",") {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
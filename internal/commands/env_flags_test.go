@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestApplyEnvFlags(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ApplyEnvFlags", testApplyEnvFlags, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testApplyEnvFlags(t *testing.T, when spec.G, it spec.S) {
+	var (
+		rootCmd *cobra.Command
+		outBuf  bytes.Buffer
+		env     map[string]string
+
+		pullPolicy   string
+		trustBuilder bool
+		tags         []string
+		envs         []string
+		verbose      bool
+	)
+
+	it.Before(func() {
+		env = map[string]string{}
+		pullPolicy, trustBuilder, tags, envs, verbose = "", false, nil, nil, false
+
+		lookupEnv := func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		}
+		rootCmd = &cobra.Command{
+			Use:               "pack",
+			PersistentPreRunE: commands.ApplyEnvFlags(logging.NewLogWithWriters(&outBuf, &outBuf), lookupEnv),
+		}
+		rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "")
+		buildCmd := &cobra.Command{Use: "build", RunE: func(*cobra.Command, []string) error { return nil }}
+		buildCmd.Flags().StringVar(&pullPolicy, "pull-policy", "", "")
+		buildCmd.Flags().BoolVar(&trustBuilder, "trust-builder", false, "")
+		buildCmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "")
+		buildCmd.Flags().StringArrayVarP(&envs, "env", "e", nil, "")
+		rootCmd.AddCommand(buildCmd)
+	})
+
+	when("#FlagEnvVar", func() {
+		it("names the environment variable after the flag", func() {
+			h.AssertEq(t, commands.FlagEnvVar("pull-policy"), "PACK_PULL_POLICY")
+		})
+	})
+
+	when("#ApplyEnvFlags", func() {
+		it("sets the flags of the command from their environment variables", func() {
+			env["PACK_PULL_POLICY"] = "if-not-present"
+			env["PACK_TRUST_BUILDER"] = "true"
+			env["PACK_TAG"] = "some/image:1,some/image:latest"
+			env["PACK_VERBOSE"] = "true"
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, pullPolicy, "if-not-present")
+			h.AssertEq(t, trustBuilder, true)
+			h.AssertEq(t, tags, []string{"some/image:1", "some/image:latest"})
+			h.AssertEq(t, verbose, true)
+		})
+
+		it("sets repeated flags to each comma-separated value", func() {
+			env["PACK_ENV"] = "SOME_VAR=some-value,OTHER_VAR=other-value"
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, envs, []string{"SOME_VAR=some-value", "OTHER_VAR=other-value"})
+		})
+
+		it("gives precedence to flags on the command line", func() {
+			env["PACK_PULL_POLICY"] = "if-not-present"
+			rootCmd.SetArgs([]string{"build", "--pull-policy", "always"})
+
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertEq(t, pullPolicy, "always")
+		})
+
+		it("fails on invalid values", func() {
+			env["PACK_TRUST_BUILDER"] = "maybe"
+			rootCmd.SetArgs([]string{"build"})
+
+			h.AssertError(t, rootCmd.Execute(), "invalid value of 'PACK_TRUST_BUILDER' for flag 'trust-builder'")
+		})
+	})
+}
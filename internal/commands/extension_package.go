@@ -21,6 +21,7 @@ type ExtensionPackageFlags struct {
 	Format          string
 	Publish         bool
 	Policy          string
+	Variables       map[string]string
 }
 
 // ExtensionPackager packages extensions
@@ -53,7 +54,7 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 			exPackageCfg := pubbldpkg.DefaultExtensionConfig()
 			relativeBaseDir := ""
 			if flags.PackageTomlPath != "" {
-				exPackageCfg, err = packageConfigReader.Read(flags.PackageTomlPath)
+				exPackageCfg, err = packageConfigReader.ReadWithVariables(flags.PackageTomlPath, flags.Variables)
 				if err != nil {
 					return errors.Wrap(err, "reading config")
 				}
@@ -63,16 +64,7 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 					return errors.Wrap(err, "getting absolute path for config")
 				}
 			}
-			name := args[0]
-			if flags.Format == client.FormatFile {
-				switch ext := filepath.Ext(name); ext {
-				case client.CNBExtension:
-				case "":
-					name += client.CNBExtension
-				default:
-					logger.Warnf("%s is not a valid extension for a packaged extension. Packaged extensions must have a %s extension", style.Symbol(ext), style.Symbol(client.CNBExtension))
-				}
-			}
+			name := packageFileName(logger, args[0], flags.Format, "extension")
 
 			if err := packager.PackageExtension(cmd.Context(), client.PackageBuildpackOptions{
 				RelativeBaseDir: relativeBaseDir,
@@ -85,25 +77,18 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 				return err
 			}
 
-			action := "created"
-			location := "docker daemon"
-			if flags.Publish {
-				action = "published"
-				location = "registry"
-			}
-			if flags.Format == client.FormatFile {
-				location = "file"
-			}
+			action, location := savedLocation(flags.Format, flags.Publish)
 			logger.Infof("Successfully %s package %s and saved to %s", action, style.Symbol(name), location)
 			return nil
 		}),
 	}
 
 	// flags will be added here
-	cmd.Flags().StringVarP(&flags.PackageTomlPath, "config", "c", "", "Path to package TOML config")
-	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", `Format to save package as ("image" or "file")`)
+	cmd.Flags().StringVarP(&flags.PackageTomlPath, "config", "c", "", "Path to package TOML or YAML config")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", formatFlagUsage)
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, `Publish the extension directly to the container registry specified in <name>, instead of the daemon (applies to "--format=image" only).`)
-	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+	cmd.Flags().StringToStringVar(&flags.Variables, "set", nil, "Set a variable referenced as ${<name>} in the config, in the form of '<name>=<value>'. Environment variables are used for variables that are not set")
 	AddHelpFlag(cmd, "package")
 	return cmd
 }
@@ -112,5 +97,5 @@ func validateExtensionPackageFlags(p *ExtensionPackageFlags) error {
 	if p.Publish && p.Policy == image.PullNever.String() {
 		return errors.Errorf("--publish and --pull-policy=never cannot be used together. The --publish flag requires the use of remote images.")
 	}
-	return nil
+	return validateFormat(p.Format, p.Publish)
 }
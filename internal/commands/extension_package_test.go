@@ -121,6 +121,18 @@ func testExtensionPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("oci-layout format", func() {
+				it("does not modify the name", func() {
+					cmd := packageExtensionCommand(withExtensionPackager(fakeExtensionPackager))
+					cmd.SetArgs([]string{"some-layout", "-f", "oci-layout"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeExtensionPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.Name, "some-layout")
+					h.AssertEq(t, receivedOptions.Format, "oci-layout")
+				})
+			})
+
 			when("pull-policy", func() {
 				var pullPolicyArgs = []string{
 					"some-image-name",
@@ -6,9 +6,10 @@ import (
 )
 
 type FakePackageConfigReader struct {
-	ReadCalledWithArg string
-	ReadReturnConfig  pubbldpkg.Config
-	ReadReturnError   error
+	ReadCalledWithArg       string
+	ReadCalledWithVariables map[string]string
+	ReadReturnConfig        pubbldpkg.Config
+	ReadReturnError         error
 
 	ReadBuildpackDescriptorCalledWithArg string
 	ReadBuildpackDescriptorReturn        dist.BuildpackDescriptor
@@ -21,6 +22,12 @@ func (r *FakePackageConfigReader) Read(path string) (pubbldpkg.Config, error) {
 	return r.ReadReturnConfig, r.ReadReturnError
 }
 
+func (r *FakePackageConfigReader) ReadWithVariables(path string, vars map[string]string) (pubbldpkg.Config, error) {
+	r.ReadCalledWithVariables = vars
+
+	return r.Read(path)
+}
+
 func (r *FakePackageConfigReader) ReadBuildpackDescriptor(path string) (dist.BuildpackDescriptor, error) {
 	r.ReadBuildpackDescriptorCalledWithArg = path
 
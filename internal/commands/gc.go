@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// GCFlags define flags provided to the gc command
+type GCFlags struct {
+	DryRun bool
+}
+
+// GC removes the intermediate images pack created in the daemon and could not remove
+func GC(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags GCFlags
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Args:  cobra.NoArgs,
+		Short: "Remove the intermediate images pack left in the daemon",
+		Long: "Remove the intermediate images pack creates in the daemon, such as ephemeral builders and lifecycle images, " +
+			"that were left behind by builds that were interrupted or failed to clean up.\n\n" +
+			"The images of pack processes that are still running are kept. Successful builds also remove the images left behind by earlier builds.",
+		Example: "pack gc --dry-run",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			images, err := pack.GC(cmd.Context(), client.GCOptions{DryRun: flags.DryRun})
+			for _, img := range images {
+				if flags.DryRun {
+					logger.Infof("Would remove intermediate %s image %s", img.Kind, style.Symbol(img.Name))
+				} else {
+					logger.Infof("Removed intermediate %s image %s", img.Kind, style.Symbol(img.Name))
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if len(images) == 0 {
+				logger.Info("No intermediate images to remove")
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "List the intermediate images that would be removed, without removing them")
+	AddHelpFlag(cmd, "gc")
+	return cmd
+}
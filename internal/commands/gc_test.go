@@ -0,0 +1,82 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestGCCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "GCCommand", testGCCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testGCCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		leftover       []client.IntermediateImage
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.GC(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+		leftover = []client.IntermediateImage{
+			{Name: "pack.local/builder/some:latest", Kind: client.IntermediateBuilder},
+			{Name: "pack.local/lifecycle/some:latest", Kind: client.IntermediateLifecycle},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#GC", func() {
+		it("removes the intermediate images", func() {
+			mockClient.EXPECT().GC(gomock.Any(), client.GCOptions{}).Return(leftover, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Removed intermediate builder image 'pack.local/builder/some:latest'")
+			h.AssertContains(t, outBuf.String(), "Removed intermediate lifecycle image 'pack.local/lifecycle/some:latest'")
+		})
+
+		it("lists the images it would remove with --dry-run", func() {
+			mockClient.EXPECT().GC(gomock.Any(), client.GCOptions{DryRun: true}).Return(leftover, nil)
+
+			command.SetArgs([]string{"--dry-run"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Would remove intermediate builder image 'pack.local/builder/some:latest'")
+		})
+
+		it("reports when there is nothing to remove", func() {
+			mockClient.EXPECT().GC(gomock.Any(), client.GCOptions{}).Return(nil, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No intermediate images to remove")
+		})
+
+		it("reports the images it removed before failing", func() {
+			mockClient.EXPECT().GC(gomock.Any(), client.GCOptions{}).Return(leftover[:1], errors.New("some error"))
+
+			h.AssertError(t, command.Execute(), "some error")
+			h.AssertContains(t, outBuf.String(), "Removed intermediate builder image 'pack.local/builder/some:latest'")
+		})
+	})
+}
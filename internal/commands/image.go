@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewImageCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Interact with app images",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(ImageCheckUpdates(logger, cfg, client))
+	cmd.AddCommand(ImageInspectIndex(logger, client))
+	cmd.AddCommand(ImageNeedsRebuild(logger, cfg, client))
+	cmd.AddCommand(ImageProvenance(logger, client))
+	AddHelpFlag(cmd, "image")
+	return cmd
+}
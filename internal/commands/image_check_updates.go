@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ImageCheckUpdatesFlags define flags provided to the image check-updates command
+type ImageCheckUpdatesFlags struct {
+	Daemon   bool
+	RunImage string
+	ExitCode bool
+}
+
+// ImageCheckUpdates reports whether the run image of an app image has been updated, and the app image should be rebased
+func ImageCheckUpdates(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags ImageCheckUpdatesFlags
+
+	cmd := &cobra.Command{
+		Use:   "check-updates <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check whether the run image of an app image has been updated",
+		Long: "Compare the run image an app image was built or last rebased on to the latest run image in the registry, " +
+			"found from the run image metadata of the app image and the configured run image mirrors, and report whether a rebase is recommended.\n\n" +
+			"Use --exit-code to fail when a rebase is recommended, such as when auditing images on a schedule.",
+		Example: "pack image check-updates buildpacksio/pack",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			update, err := pack.CheckUpdates(cmd.Context(), client.CheckUpdatesOptions{
+				ImageName:         args[0],
+				Daemon:            flags.Daemon,
+				RunImage:          flags.RunImage,
+				AdditionalMirrors: getMirrors(cfg),
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Run image: %s", style.Symbol(update.RunImage))
+			logger.Infof("  Current: %s", update.CurrentReference)
+			logger.Infof("  Latest:  %s", update.LatestReference)
+			if !update.RebaseRecommended() {
+				logger.Infof("Image %s is up to date", style.Symbol(update.Image))
+				return nil
+			}
+
+			logger.Infof("A newer run image is available, rebasing %s is recommended: pack rebase %s", style.Symbol(update.Image), update.Image)
+			if flags.ExitCode {
+				return client.NewSoftError()
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Daemon, "daemon", false, "Read the app image from the daemon instead of the registry")
+	cmd.Flags().StringVar(&flags.RunImage, "run-image", "", "Run image to compare the app image to. Defaults to the run image, or the best mirror of it, the app image was built on")
+	cmd.Flags().BoolVar(&flags.ExitCode, "exit-code", false, "Exit with a non-zero status when a rebase is recommended")
+
+	AddHelpFlag(cmd, "check-updates")
+	return cmd
+}
@@ -0,0 +1,91 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageCheckUpdatesCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "ImageCheckUpdatesCommand", testImageCheckUpdatesCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageCheckUpdatesCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg := config.Config{RunImages: []config.RunImage{{Image: "some/run", Mirrors: []string{"example.com/some/run"}}}}
+		command = commands.ImageCheckUpdates(logging.NewLogWithWriters(&outBuf, &outBuf), cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ImageCheckUpdates", func() {
+		it("reports an up to date image", func() {
+			mockClient.EXPECT().CheckUpdates(gomock.Any(), client.CheckUpdatesOptions{
+				ImageName:         "some/app",
+				AdditionalMirrors: map[string][]string{"some/run": {"example.com/some/run"}},
+			}).Return(&client.RunImageUpdate{
+				Image:           "some/app",
+				RunImage:        "some/run",
+				CurrentTopLayer: "some-top-layer",
+				LatestTopLayer:  "some-top-layer",
+			}, nil)
+
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Image 'some/app' is up to date")
+		})
+
+		when("a rebase is recommended", func() {
+			it.Before(func() {
+				mockClient.EXPECT().CheckUpdates(gomock.Any(), gomock.Any()).Return(&client.RunImageUpdate{
+					Image:            "some/app",
+					RunImage:         "some/run",
+					CurrentReference: "some/run@sha256:old",
+					CurrentTopLayer:  "old-top-layer",
+					LatestReference:  "some/run@sha256:new",
+					LatestTopLayer:   "new-top-layer",
+				}, nil)
+			})
+
+			it("recommends rebasing the image", func() {
+				command.SetArgs([]string{"some/app"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Latest:  some/run@sha256:new")
+				h.AssertContains(t, outBuf.String(), "rebasing 'some/app' is recommended")
+			})
+
+			it("fails with --exit-code", func() {
+				command.SetArgs([]string{"some/app", "--exit-code"})
+				err := command.Execute()
+				_, isSoftError := err.(client.SoftError)
+				h.AssertTrue(t, isSoftError)
+			})
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ImageInspectIndexFlags define flags provided to the image inspect-index command
+type ImageInspectIndexFlags struct {
+	OutputFormat string
+}
+
+// ImageInspectIndex shows the manifests of an image index in a registry
+func ImageInspectIndex(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ImageInspectIndexFlags
+
+	cmd := &cobra.Command{
+		Use:   "inspect-index <index-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Show the manifests of an image index in a registry",
+		Long: "Show the platform, digest and size of every manifest of an image index in a registry, " +
+			"and whether its image has Cloud Native Buildpacks metadata, such as the images of multi-platform builds, builders and packages.",
+		Example: "pack image inspect-index paketobuildpacks/builder-jammy-base --output json",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "table" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of table or json", style.Symbol(flags.OutputFormat))
+			}
+
+			info, err := pack.InspectIndex(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling image index")
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			logger.Infof("Image index %s (%s)", style.Symbol(info.Name), info.Digest)
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(tw, "PLATFORM\tDIGEST\tSIZE\tCNB METADATA")
+			for _, m := range info.Manifests {
+				cnbMetadata := "no"
+				if m.CNBMetadata {
+					cnbMetadata = "yes"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", orDash(m.Platform), m.Digest, humanize.Bytes(uint64(m.Size)), cnbMetadata)
+			}
+			return tw.Flush()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display the manifests (table, json)")
+
+	AddHelpFlag(cmd, "inspect-index")
+	return cmd
+}
@@ -0,0 +1,83 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageInspectIndexCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "ImageInspectIndexCommand", testImageInspectIndexCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageInspectIndexCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		info           *client.IndexInfo
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ImageInspectIndex(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+		info = &client.IndexInfo{
+			Name:      "index.docker.io/some/app:latest",
+			Digest:    "sha256:index",
+			MediaType: "application/vnd.oci.image.index.v1+json",
+			Manifests: []client.IndexManifestInfo{
+				{Platform: "linux/amd64", Digest: "sha256:amd64", Size: 2048000, CNBMetadata: true},
+				{Platform: "linux/arm64/v8", Digest: "sha256:arm64", Size: 1024000},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ImageInspectIndex", func() {
+		it("shows the manifests in a table", func() {
+			mockClient.EXPECT().InspectIndex(gomock.Any(), "some/app").Return(info, nil)
+
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsAllInOrder(t, outBuf,
+				"Image index 'index.docker.io/some/app:latest' (sha256:index)",
+				"PLATFORM", "DIGEST", "SIZE", "CNB METADATA",
+				"linux/amd64", "sha256:amd64", "2.0 MB", "yes",
+				"linux/arm64/v8", "sha256:arm64", "1.0 MB", "no",
+			)
+		})
+
+		it("shows the manifests as JSON", func() {
+			mockClient.EXPECT().InspectIndex(gomock.Any(), "some/app").Return(info, nil)
+
+			command.SetArgs([]string{"some/app", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"platform": "linux/amd64"`)
+			h.AssertContains(t, outBuf.String(), `"cnbMetadata": true`)
+		})
+
+		it("fails on unknown output formats", func() {
+			command.SetArgs([]string{"some/app", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml', must be one of table or json")
+		})
+	})
+}
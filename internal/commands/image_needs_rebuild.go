@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ImageNeedsRebuildFlags define flags provided to the image needs-rebuild command
+type ImageNeedsRebuildFlags struct {
+	OutputFormat string
+	Daemon       bool
+	Policy       string
+	ExitCode     bool
+}
+
+// ImageNeedsRebuild reports whether the builder or buildpacks an app image was built with changed, and the app image
+// should be rebuilt
+func ImageNeedsRebuild(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags ImageNeedsRebuildFlags
+
+	cmd := &cobra.Command{
+		Use:   "needs-rebuild <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check whether the builder or buildpacks of an app image have changed",
+		Long: "Compare the builder and buildpacks an app image was built with, recorded by `pack build --rebuild-triggers`, " +
+			"to the current state of the builder, and report whether the app image should be rebuilt.\n\n" +
+			"App images that don't need a rebuild may still need a rebase, which `pack image check-updates` reports. " +
+			"Use --exit-code to fail when a rebuild is needed, such as when deciding between a rebuild and a rebase for a fleet of images.",
+		Example: "pack image needs-rebuild registry.example.com/my-app",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			check, err := pack.NeedsRebuild(cmd.Context(), client.NeedsRebuildOptions{
+				ImageName:  args[0],
+				Daemon:     flags.Daemon,
+				PullPolicy: pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(check, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling rebuild check")
+				}
+				logger.Info(string(out))
+			} else {
+				writeRebuildCheck(logger, check)
+			}
+
+			if flags.ExitCode && check.RebuildNeeded() {
+				return client.NewSoftError()
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the check (json, human-readable)")
+	cmd.Flags().BoolVar(&flags.Daemon, "daemon", false, "Read the app image from the daemon instead of the registry")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use for the builder. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().BoolVar(&flags.ExitCode, "exit-code", false, "Exit with a non-zero status when a rebuild is needed")
+
+	AddHelpFlag(cmd, "needs-rebuild")
+	return cmd
+}
+
+func writeRebuildCheck(logger logging.Logger, check *client.RebuildCheck) {
+	if !check.RebuildNeeded() {
+		logger.Infof("Builder %s and the buildpacks of image %s are unchanged, no rebuild is needed", style.Symbol(check.Builder), style.Symbol(check.Image))
+		logger.Infof("Run `pack image check-updates %s` to check whether a rebase is needed", check.Image)
+		return
+	}
+
+	logger.Infof("Image %s needs a rebuild:", style.Symbol(check.Image))
+	for _, change := range check.Changes {
+		switch {
+		case change.Kind == "builder":
+			logger.Infof("  builder %s was updated, from %s to %s", style.Symbol(change.Name), change.From, change.To)
+		case change.Kind == "lifecycle":
+			logger.Infof("  lifecycle changed from %s to %s", style.Symbol(change.From), style.Symbol(change.To))
+		case change.To == "":
+			logger.Infof("  buildpack %s was removed from the builder", style.Symbol(change.Name))
+		default:
+			logger.Infof("  buildpack %s changed from %s to %s", style.Symbol(change.Name), change.From, change.To)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageNeedsRebuildCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "ImageNeedsRebuildCommand", testImageNeedsRebuildCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageNeedsRebuildCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+
+		rebuildCheck = &client.RebuildCheck{
+			Image:   "some/app",
+			Builder: "some/builder",
+			Changes: []client.RebuildTriggerChange{
+				{Kind: "lifecycle", Name: "some/builder", From: "0.19.0", To: "0.20.0"},
+				{Kind: "buildpack", Name: "some/buildpack", From: "1.0.0", To: "2.0.0"},
+				{Kind: "buildpack", Name: "some/removed-buildpack", From: "1.0.0"},
+			},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ImageNeedsRebuild(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ImageNeedsRebuild", func() {
+		it("reports the changes calling for a rebuild", func() {
+			mockClient.EXPECT().NeedsRebuild(gomock.Any(), client.NeedsRebuildOptions{ImageName: "some/app", PullPolicy: image.PullAlways}).Return(rebuildCheck, nil)
+
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Image 'some/app' needs a rebuild:")
+			h.AssertContains(t, outBuf.String(), "lifecycle changed from '0.19.0' to '0.20.0'")
+			h.AssertContains(t, outBuf.String(), "buildpack 'some/buildpack' changed from 1.0.0 to 2.0.0")
+			h.AssertContains(t, outBuf.String(), "buildpack 'some/removed-buildpack' was removed from the builder")
+		})
+
+		it("points to check-updates when no rebuild is needed", func() {
+			mockClient.EXPECT().NeedsRebuild(gomock.Any(), client.NeedsRebuildOptions{ImageName: "some/app", Daemon: true, PullPolicy: image.PullNever}).
+				Return(&client.RebuildCheck{Image: "some/app", Builder: "some/builder"}, nil)
+
+			command.SetArgs([]string{"some/app", "--daemon", "--pull-policy", "never", "--exit-code"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "no rebuild is needed")
+			h.AssertContains(t, outBuf.String(), "pack image check-updates some/app")
+		})
+
+		it("exits with a non-zero status when a rebuild is needed with --exit-code", func() {
+			mockClient.EXPECT().NeedsRebuild(gomock.Any(), gomock.Any()).Return(rebuildCheck, nil)
+
+			command.SetArgs([]string{"some/app", "--exit-code"})
+			err := command.Execute()
+			_, isSoftError := err.(client.SoftError)
+			h.AssertTrue(t, isSoftError)
+		})
+
+		it("reports the check as json", func() {
+			mockClient.EXPECT().NeedsRebuild(gomock.Any(), gomock.Any()).Return(rebuildCheck, nil)
+
+			command.SetArgs([]string{"some/app", "-o", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"kind": "lifecycle"`)
+		})
+
+		it("fails when the check fails", func() {
+			mockClient.EXPECT().NeedsRebuild(gomock.Any(), gomock.Any()).Return(nil, errors.New("could not find label"))
+
+			command.SetArgs([]string{"some/app"})
+			h.AssertError(t, command.Execute(), "could not find label")
+		})
+	})
+}
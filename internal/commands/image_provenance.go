@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ImageProvenanceFlags define flags provided to the image provenance command
+type ImageProvenanceFlags struct {
+	Daemon       bool
+	OutputFormat string
+}
+
+// ImageProvenance maps each layer of an app image back to its origin
+func ImageProvenance(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ImageProvenanceFlags
+
+	cmd := &cobra.Command{
+		Use:   "provenance <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Show the origin of each layer of an app image",
+		Long: "Map each layer of an app image back to its origin, from the lifecycle metadata of the image: the run image, " +
+			"the buildpack and the layer of the buildpack, the slice of the app, or the launcher, process types and config layers the lifecycle adds.\n\n" +
+			"Use it to audit what shipped in an image and where it came from, such as to find the origin of a layer a vulnerability scanner reports.",
+		Example: "pack image provenance buildpacksio/pack --output json",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "table" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of table or json", style.Symbol(flags.OutputFormat))
+			}
+
+			provenance, err := pack.ImageProvenance(cmd.Context(), client.ImageProvenanceOptions{
+				ImageName: args[0],
+				Daemon:    flags.Daemon,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(provenance, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "marshalling image provenance")
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			logger.Infof("Image %s (%s)", style.Symbol(provenance.Image), provenance.Identifier)
+			logger.Infof("Run image %s, top layer %s", style.Symbol(orDash(provenance.RunImage.Reference)), orDash(provenance.RunImage.TopLayer))
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(tw, "LAYER\tORIGIN\tBUILDPACK\tNAME")
+			for _, layer := range provenance.Layers {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", layer.DiffID, layer.Origin, orDash(layer.Buildpack), orDash(layer.Name))
+			}
+			return tw.Flush()
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Daemon, "daemon", false, "Read the app image from the daemon instead of the registry")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display the layers (table, json)")
+
+	AddHelpFlag(cmd, "provenance")
+	return cmd
+}
@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageProvenanceCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "ImageProvenanceCommand", testImageProvenanceCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageProvenanceCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		provenance     *client.ImageProvenance
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ImageProvenance(logging.NewLogWithWriters(&outBuf, &outBuf), mockClient)
+		provenance = &client.ImageProvenance{
+			Image:      "some/app",
+			Identifier: "some/app@sha256:app",
+			RunImage:   client.RunImageProvenance{Reference: "some/run@sha256:run", TopLayer: "sha256:run-top"},
+			Layers: []client.LayerProvenance{
+				{DiffID: "sha256:run-top", Origin: client.LayerOriginRunImage},
+				{DiffID: "sha256:bp-layer", Origin: client.LayerOriginBuildpack, Buildpack: "some/bp@1.2.3", Name: "some-layer"},
+				{DiffID: "sha256:launcher", Origin: client.LayerOriginLauncher},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ImageProvenance", func() {
+		it("shows the origin of each layer in a table", func() {
+			mockClient.EXPECT().ImageProvenance(gomock.Any(), client.ImageProvenanceOptions{ImageName: "some/app"}).Return(provenance, nil)
+
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsAllInOrder(t, outBuf,
+				"Image 'some/app' (some/app@sha256:app)",
+				"Run image 'some/run@sha256:run', top layer sha256:run-top",
+				"LAYER", "ORIGIN", "BUILDPACK", "NAME",
+				"sha256:run-top", "run-image", "-", "-",
+				"sha256:bp-layer", "buildpack", "some/bp@1.2.3", "some-layer",
+				"sha256:launcher", "launcher",
+			)
+		})
+
+		it("shows the origin of each layer as JSON, reading the image from the daemon", func() {
+			mockClient.EXPECT().ImageProvenance(gomock.Any(), client.ImageProvenanceOptions{ImageName: "some/app", Daemon: true}).Return(provenance, nil)
+
+			command.SetArgs([]string{"some/app", "--daemon", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"origin": "buildpack"`)
+			h.AssertContains(t, outBuf.String(), `"buildpack": "some/bp@1.2.3"`)
+		})
+
+		it("fails on unknown output formats", func() {
+			command.SetArgs([]string{"some/app", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml', must be one of table or json")
+		})
+	})
+}
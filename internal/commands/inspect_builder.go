@@ -31,7 +31,7 @@ func InspectBuilder(
 			}
 
 			if imageName == "" {
-				suggestSettingBuilder(logger, inspector)
+				suggestSettingBuilder(logger, cfg, inspector)
 				return client.NewSoftError()
 			}
 
@@ -8,11 +8,13 @@ import (
 	"text/tabwriter"
 	"text/template"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
 	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
@@ -41,6 +43,11 @@ Stacks:
 
 Buildpacks:
 {{ .Buildpacks }}
+{{- if .ShowLayers }}
+
+Layers:
+{{ .Layers }}
+{{- end }}
 
 Detection Order:
 {{- if ne .Order "" }}
@@ -119,11 +126,25 @@ func inspectAllBuildpacks(client PackClient, flags BuildpackInspectFlags, option
 
 func inspectBuildpackOutput(info *client.BuildpackInfo, prefix string, flags BuildpackInspectFlags) (output []byte, err error) {
 	tpl := template.Must(template.New("inspect-buildpack").Parse(inspectBuildpackTemplate))
-	bpOutput, err := buildpacksOutput(info.Buildpacks)
+	buildpacks, order := info.Buildpacks, info.Order
+	if flags.Buildpack != "" {
+		buildpacks, order, err = filterBuildpack(info.Buildpacks, flags.Buildpack)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+	bpOutput, err := buildpacksOutput(buildpacks)
 	if err != nil {
 		return []byte{}, fmt.Errorf("error writing buildpack output: %q", err)
 	}
-	orderOutput, err := detectionOrderOutput(info.Order, info.BuildpackLayers, flags.Depth)
+	var layersOutput string
+	if flags.Layers {
+		layersOutput, err = buildpackLayersOutput(buildpacks, info.BuildpackLayers, info.LayerSizes)
+		if err != nil {
+			return []byte{}, fmt.Errorf("error writing layers output: %q", err)
+		}
+	}
+	orderOutput, err := detectionOrderOutput(order, info.BuildpackLayers, flags.Depth)
 	if err != nil {
 		return []byte{}, fmt.Errorf("error writing detection order output: %q", err)
 	}
@@ -134,12 +155,16 @@ func inspectBuildpackOutput(info *client.BuildpackInfo, prefix string, flags Bui
 		Metadata   buildpack.Metadata
 		ListMixins bool
 		Buildpacks string
+		ShowLayers bool
+		Layers     string
 		Order      string
 	}{
 		Location:   prefix,
 		Metadata:   info.BuildpackMetadata,
 		ListMixins: flags.Verbose,
 		Buildpacks: bpOutput,
+		ShowLayers: flags.Layers,
+		Layers:     layersOutput,
 		Order:      orderOutput,
 	})
 
@@ -188,6 +213,60 @@ func buildpacksOutput(bps []dist.ModuleInfo) (string, error) {
 	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
 
+// filterBuildpack narrows the buildpacks of a package down to those matching an <id>[@<version>] reference,
+// and returns a detection order rooted at each of them.
+func filterBuildpack(bps []dist.ModuleInfo, ref string) ([]dist.ModuleInfo, dist.Order, error) {
+	id, version := buildpack.ParseIDLocator(ref)
+
+	var (
+		filtered []dist.ModuleInfo
+		order    dist.Order
+	)
+	for _, bp := range bps {
+		if bp.ID != id || (version != "" && bp.Version != version) {
+			continue
+		}
+		filtered = append(filtered, bp)
+		order = append(order, dist.OrderEntry{Group: []dist.ModuleRef{{ModuleInfo: bp}}})
+	}
+
+	if len(filtered) == 0 {
+		return nil, nil, errors.Errorf("buildpack %s not found in package", style.Symbol(ref))
+	}
+	return filtered, order, nil
+}
+
+func buildpackLayersOutput(bps []dist.ModuleInfo, layers dist.ModuleLayers, sizes map[string]int64) (string, error) {
+	buf := &bytes.Buffer{}
+
+	tabWriter := new(tabwriter.Writer).Init(buf, writerMinWidth, writerPadChar, buildpacksTabWidth, writerPadChar, writerFlags)
+	if _, err := fmt.Fprint(tabWriter, "  ID\tVERSION\tDIFF ID\tSIZE\n"); err != nil {
+		return "", err
+	}
+
+	for _, bp := range bps {
+		layerInfo, ok := layers.Get(bp.ID, bp.Version)
+		if !ok {
+			return "", fmt.Errorf("missing buildpack %s@%s from layer metadata", bp.ID, bp.Version)
+		}
+
+		size := "-"
+		if layerSize, ok := sizes[layerInfo.LayerDiffID]; ok {
+			size = humanize.Bytes(uint64(layerSize))
+		}
+
+		if _, err := fmt.Fprintf(tabWriter, "  %s\t%s\t%s\t%s\n", bp.ID, bp.Version, strs.ValueOrDefault(layerInfo.LayerDiffID, "-"), size); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tabWriter.Flush(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
 // Unable to easily convert format makes this feel like a poor solution...
 func detectionOrderOutput(order dist.Order, layers dist.ModuleLayers, maxDepth int) (string, error) {
 	buf := strings.Builder{}
@@ -61,6 +61,7 @@ func InspectImage(
 		}),
 	}
 	AddHelpFlag(cmd, "inspect")
+	cmd.ValidArgsFunction = completeLocalImages(client)
 	cmd.Flags().BoolVar(&flags.BOM, "bom", false, "print bill of materials")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
 	return cmd
@@ -16,7 +16,9 @@ func ListBuildpackRegistries(logger logging.Logger, cfg config.Config) *cobra.Co
 		Short:   "List buildpack registries",
 		Example: "pack list-registries",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "list-registries", "config registries list")
+			if err := deprecationWarning(logger, "list-registries", "config registries list"); err != nil {
+				return err
+			}
 			listRegistries(args, logger, cfg)
 
 			return nil
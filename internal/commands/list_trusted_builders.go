@@ -16,7 +16,9 @@ func ListTrustedBuilders(logger logging.Logger, cfg config.Config) *cobra.Comman
 		Example: "pack list-trusted-builders",
 		Hidden:  true,
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "list-trusted-builders", "config trusted-builders list")
+			if err := deprecationWarning(logger, "list-trusted-builders", "config trusted-builders list"); err != nil {
+				return err
+			}
 			listTrustedBuilders(args, logger, cfg)
 			return nil
 		}),
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// LoginFlags define flags provided to the login command
+type LoginFlags struct {
+	Username      string
+	PasswordStdin bool
+}
+
+// Login stores the credentials of a registry for pack to use, for environments without a docker config of their own
+func Login(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags LoginFlags
+
+	cmd := &cobra.Command{
+		Use:   "login <registry>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Log in to a registry",
+		Long: "Log in to a registry, storing its credentials in pack home for pack to pull and publish images with.\n\n" +
+			"This is meant for environments without the docker CLI, such as minimal CI containers. " +
+			"Credentials stored by pack take precedence over the credentials of the docker config.",
+		Example: "echo \"$TOKEN\" | pack login registry.example.com --username some-user --password-stdin",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Username == "" {
+				return errors.New("--username is required")
+			}
+			if !flags.PasswordStdin {
+				return errors.New("--password-stdin is required, passwords are not read from arguments where they would be kept in the shell history")
+			}
+
+			password, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return errors.Wrap(err, "reading password from stdin")
+			}
+
+			opts := client.LoginOptions{
+				Registry: args[0],
+				Username: flags.Username,
+				Password: strings.TrimRight(string(password), "\r\n"),
+			}
+			if err := pack.Login(cmd.Context(), opts); err != nil {
+				return err
+			}
+
+			logger.Infof("Logged in to %s", style.Symbol(args[0]))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Username, "username", "u", "", "Username to log in with")
+	cmd.Flags().BoolVar(&flags.PasswordStdin, "password-stdin", false, "Read the password, or access token, from stdin")
+	AddHelpFlag(cmd, "login")
+	return cmd
+}
+
+// Logout removes the credentials of a registry that pack stored on login
+func Logout(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "logout <registry>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Log out of a registry",
+		Long:    "Log out of a registry, removing the credentials pack stored when logging in to it. The credentials of the docker config are kept.",
+		Example: "pack logout registry.example.com",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			removed, err := pack.Logout(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if removed {
+				logger.Infof("Logged out of %s", style.Symbol(args[0]))
+			} else {
+				logger.Infof("Not logged in to %s", style.Symbol(args[0]))
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "logout")
+	return cmd
+}
@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLoginCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "LoginCommand", testLoginCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLoginCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Login", func() {
+		it("logs in with the password from stdin", func() {
+			mockClient.EXPECT().Login(gomock.Any(), client.LoginOptions{
+				Registry: "registry.example.com",
+				Username: "some-user",
+				Password: "some-password",
+			}).Return(nil)
+
+			command := commands.Login(logger, mockClient)
+			command.SetIn(strings.NewReader("some-password\n"))
+			command.SetArgs([]string{"registry.example.com", "--username", "some-user", "--password-stdin"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Logged in to 'registry.example.com'")
+		})
+
+		it("requires --password-stdin", func() {
+			command := commands.Login(logger, mockClient)
+			command.SetArgs([]string{"registry.example.com", "--username", "some-user"})
+			h.AssertError(t, command.Execute(), "--password-stdin is required")
+		})
+
+		it("fails when the client fails to log in", func() {
+			mockClient.EXPECT().Login(gomock.Any(), gomock.Any()).Return(errors.New("unauthorized"))
+
+			command := commands.Login(logger, mockClient)
+			command.SetIn(strings.NewReader("other-password"))
+			command.SetArgs([]string{"registry.example.com", "-u", "some-user", "--password-stdin"})
+			h.AssertError(t, command.Execute(), "unauthorized")
+		})
+	})
+
+	when("#Logout", func() {
+		it("logs out of the registry", func() {
+			mockClient.EXPECT().Logout(gomock.Any(), "registry.example.com").Return(true, nil)
+
+			command := commands.Logout(logger, mockClient)
+			command.SetArgs([]string{"registry.example.com"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Logged out of 'registry.example.com'")
+		})
+
+		it("reports when it was not logged in", func() {
+			mockClient.EXPECT().Logout(gomock.Any(), "registry.example.com").Return(false, nil)
+
+			command := commands.Logout(logger, mockClient)
+			command.SetArgs([]string{"registry.example.com"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Not logged in to 'registry.example.com'")
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// formatFlagUsage is the usage of the --format flag of the commands that package buildpacks, extensions and builders
+const formatFlagUsage = `Format to save as ("image", "file" or "oci-layout"). Images are saved to the daemon, or published with --publish; files and OCI layouts are written to <name> on the host`
+
+// validateFormat checks the --format flag of the packaging commands, as only images can be published
+func validateFormat(format string, publish bool) error {
+	switch format {
+	case "", client.FormatImage:
+		return nil
+	case client.FormatFile, client.FormatOCILayout:
+		if publish {
+			return errors.Errorf("--publish cannot be used with --format %s, only images can be published", format)
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid format %s, must be one of %s, %s or %s",
+			style.Symbol(format), client.FormatImage, client.FormatFile, client.FormatOCILayout)
+	}
+}
+
+// writesToHost returns whether the packaging commands write their output to the host, as a file or an OCI layout
+func writesToHost(format string) bool {
+	return format == client.FormatFile || format == client.FormatOCILayout
+}
+
+// packageFileName returns the name of a package written to a file, adding the extension of packages when it has none
+func packageFileName(logger logging.Logger, name, format, kind string) string {
+	if format != client.FormatFile {
+		return name
+	}
+	switch ext := filepath.Ext(name); ext {
+	case client.CNBExtension:
+	case "":
+		name += client.CNBExtension
+	default:
+		logger.Warnf("%s is not a valid extension for a packaged %s. Packaged %ss must have a %s extension", style.Symbol(ext), kind, kind, style.Symbol(client.CNBExtension))
+	}
+	return name
+}
+
+// savedLocation returns what was done with the output of a packaging command, and where it was saved
+func savedLocation(format string, publish bool) (action, location string) {
+	switch {
+	case format == client.FormatFile:
+		return "created", "file"
+	case format == client.FormatOCILayout:
+		return "created", "OCI layout"
+	case publish:
+		return "published", "registry"
+	default:
+		return "created", "docker daemon"
+	}
+}
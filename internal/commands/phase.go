@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// PhaseFlags define flags provided to the phase commands
+type PhaseFlags struct {
+	AppPath           string
+	Builder           string
+	DescriptorPath    string
+	Env               []string
+	EnvFiles          []string
+	LifecycleImage    string
+	Network           string
+	PhaseArtifactsDir string
+	Policy            string
+	Publish           bool
+	RunImage          string
+	TrustBuilder      bool
+	Volumes           []string
+}
+
+var phaseDescriptions = map[string]string{
+	"analyze": "Analyze the previous image and the run image, restoring the metadata of the previous image",
+	"detect":  "Copy the app into the workspace, and detect the buildpacks to build it with",
+	"restore": "Restore the layers of the cache and of the previous image",
+	"build":   "Build the app with the detected buildpacks",
+	"export":  "Export the app image, and the cache",
+}
+
+func NewPhaseCommand(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "phase",
+		Short: "Run the phases of a build one at a time",
+		Long: "Run the lifecycle phases of a build one at a time, rather than building with `pack build`, such as to debug a phase, " +
+			"or look into the state it leaves before running the next one.\n\n" +
+			"The phases of an image share the state of its build through volumes, which are kept until `pack phase clean` removes them. " +
+			"Phases are run in the order analyze, detect, restore, build, export, with the same flags.\n\n" +
+			"To run the phases in different CI stages, such as detection on a small runner and the build on a bigger one, " +
+			"pass the phases the same `--phase-artifacts-dir`, and keep it between the stages.",
+		Example: "pack phase detect my-app --builder cnbs/sample-builder:jammy",
+		RunE:    nil,
+	}
+
+	for _, phase := range []string{"analyze", "detect", "restore", "build", "export"} {
+		cmd.AddCommand(PhaseRun(logger, cfg, pack, phase))
+	}
+	cmd.AddCommand(PhaseClean(logger, pack))
+	AddHelpFlag(cmd, "phase")
+	return cmd
+}
+
+// PhaseRun runs a single lifecycle phase of the build of an image
+func PhaseRun(logger logging.Logger, cfg config.Config, pack PackClient, phase string) *cobra.Command {
+	var flags PhaseFlags
+
+	cmd := &cobra.Command{
+		Use:   phase + " <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: phaseDescriptions[phase],
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			imageName := args[0]
+
+			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
+			if err != nil {
+				return err
+			}
+
+			builder := flags.Builder
+			if !cmd.Flags().Changed("builder") && descriptor.Build.Builder != "" {
+				builder = descriptor.Build.Builder
+			}
+			if builder == "" {
+				suggestSettingBuilder(logger, cfg, pack)
+				return client.NewSoftError()
+			}
+
+			env, err := parseEnv(flags.EnvFiles, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
+			logger.Debugf("Running the %s phase of %s with builder %s", phase, style.Symbol(imageName), style.Symbol(builder))
+
+			return pack.Build(cmd.Context(), client.BuildOptions{
+				AppPath:           flags.AppPath,
+				Builder:           builder,
+				AdditionalMirrors: getMirrors(cfg),
+				RunImage:          flags.RunImage,
+				Env:               env,
+				Image:             imageName,
+				Publish:           flags.Publish,
+				PullPolicy:        pullPolicy,
+				TrustBuilder: func(string) bool {
+					return trustBuilder
+				},
+				ContainerConfig: client.ContainerConfig{
+					Network: flags.Network,
+					Volumes: flags.Volumes,
+				},
+				ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
+				ProjectDescriptor:        descriptor,
+				LifecycleImage:           flags.LifecycleImage,
+				GroupID:                  -1,
+				UserID:                   -1,
+				Phase:                    phase,
+				PhaseArtifactsDir:        flags.PhaseArtifactsDir,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'"+stringArrayHelp("env"))
+	cmd.Flags().StringArrayVar(&flags.EnvFiles, "env-file", []string{}, "Build-time environment variables file, with one variable per line, of the form 'VAR=VALUE' or 'VAR'")
+	cmd.Flags().StringVar(&flags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, "Custom lifecycle image to use for the phases which require root access")
+	cmd.Flags().StringVar(&flags.Network, "network", "", "Connect the phase container to a network")
+	cmd.Flags().StringVar(&flags.PhaseArtifactsDir, "phase-artifacts-dir", "", "Directory to export the metadata the phase leaves for the phases after it to, such as analyzed.toml, group.toml and plan.toml, and to import the metadata of the phases before it from")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Analyze and export against a registry, rather than the daemon")
+	cmd.Flags().StringVar(&flags.RunImage, "run-image", "", "Run image (defaults to the run image of the project descriptor, or else the default stack's run image)")
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the provided builder")
+	cmd.Flags().StringArrayVar(&flags.Volumes, "volume", nil, "Mount host volume into the phase container, in the form '<host path>:<target path>[:<options>]'"+stringArrayHelp("volume"))
+
+	AddHelpFlag(cmd, phase)
+	return cmd
+}
+
+// PhaseClean removes the volumes the phases of an image share
+func PhaseClean(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Remove the state the phases of an image share",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := pack.CleanPhases(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully removed the state of the phases of %s", style.Symbol(args[0]))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "clean")
+	return cmd
+}
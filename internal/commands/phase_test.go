@@ -0,0 +1,92 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPhaseCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "PhaseCommand", testPhaseCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPhaseCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		opts           client.BuildOptions
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.NewPhaseCommand(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{DefaultBuilder: "some/builder"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#PhaseRun", func() {
+		it("runs the phase of the build of the image", func() {
+			mockClient.EXPECT().
+				Build(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, o client.BuildOptions) error {
+					opts = o
+					return nil
+				})
+
+			command.SetArgs([]string{"restore", "some/app", "--env", "SOME_VAR=some-value", "--pull-policy", "never"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, opts.Phase, "restore")
+			h.AssertEq(t, opts.Image, "some/app")
+			h.AssertEq(t, opts.Builder, "some/builder")
+			h.AssertEq(t, opts.Env, map[string]string{"SOME_VAR": "some-value"})
+			h.AssertEq(t, opts.PullPolicy, image.PullNever)
+			h.AssertEq(t, opts.TrustBuilder("some/builder"), false)
+		})
+
+		it("imports and exports the phase artifacts of the artifacts directory", func() {
+			mockClient.EXPECT().
+				Build(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, o client.BuildOptions) error {
+					opts = o
+					return nil
+				})
+
+			command.SetArgs([]string{"detect", "some/app", "--phase-artifacts-dir", "some-artifacts-dir"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, opts.Phase, "detect")
+			h.AssertEq(t, opts.PhaseArtifactsDir, "some-artifacts-dir")
+		})
+	})
+
+	when("#PhaseClean", func() {
+		it("removes the state of the phases of the image", func() {
+			mockClient.EXPECT().CleanPhases(gomock.Any(), "some/app").Return(nil)
+
+			command.SetArgs([]string{"clean", "some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully removed the state of the phases of 'some/app'")
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// pluginPrefix is the prefix of the names of the executables providing plugins
+const pluginPrefix = "pack-"
+
+// Plugin is an executable named pack-<name> on the PATH, run for `pack <name>`
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// PluginExitError is returned when a plugin exits with a non-zero code, which pack exits with too
+type PluginExitError struct {
+	Plugin string
+	Code   int
+}
+
+func (e PluginExitError) Error() string {
+	return fmt.Sprintf("plugin %s exited with code %d", style.Symbol(e.Plugin), e.Code)
+}
+
+// AddPlugin adds a command to the root command for the plugin run by the arguments, when pack has no command for them:
+// the executable pack-<name> lookPath finds for the name of the first argument. Plugins are only looked up for the
+// commands pack doesn't have, rather than on each run of pack. Plugins are run with the arguments following their name,
+// and the environment and standard streams of pack.
+func AddPlugin(logger logging.Logger, rootCmd *cobra.Command, args []string, lookPath func(file string) (string, error)) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || strings.ContainsAny(args[0], `/\`) {
+		return
+	}
+	if existing, _, err := rootCmd.Find(args[:1]); err == nil && existing != rootCmd {
+		return
+	}
+
+	path, err := lookPath(pluginPrefix + args[0])
+	if err != nil {
+		return
+	}
+	logger.Debugf("Running plugin %s", style.Symbol(path))
+	rootCmd.AddCommand(pluginCommand(logger, Plugin{Name: args[0], Path: path}))
+}
+
+func pluginCommand(logger logging.Logger, plugin Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                plugin.Name,
+		Short:              fmt.Sprintf("Run plugin %s", plugin.Path),
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			pluginCmd := exec.CommandContext(cmd.Context(), plugin.Path, args...)
+			pluginCmd.Stdin = cmd.InOrStdin()
+			pluginCmd.Stdout = cmd.OutOrStdout()
+			pluginCmd.Stderr = cmd.ErrOrStderr()
+			err := pluginCmd.Run()
+
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return PluginExitError{Plugin: plugin.Name, Code: exitErr.ExitCode()}
+			}
+			if err != nil {
+				err = errors.Wrapf(err, "running plugin %s", style.Symbol(plugin.Path))
+				logger.Error(err.Error())
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPlugins(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Plugins", testPlugins, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPlugins(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger  logging.Logger
+		outBuf  bytes.Buffer
+		binDir  string
+		rootCmd *cobra.Command
+	)
+
+	writePlugin := func(dir, name, script string) string {
+		path := filepath.Join(dir, name)
+		h.AssertNil(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755))
+		return path
+	}
+
+	it.Before(func() {
+		h.SkipIf(t, runtime.GOOS == "windows", "plugins are shell scripts")
+
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		binDir = t.TempDir()
+		rootCmd = &cobra.Command{Use: "pack"}
+		rootCmd.AddCommand(&cobra.Command{Use: "build", RunE: func(*cobra.Command, []string) error { return nil }})
+		rootCmd.SetOut(&outBuf)
+		rootCmd.SetErr(&outBuf)
+	})
+
+	when("#AddPlugin", func() {
+		var lookPath func(string) (string, error)
+
+		it.Before(func() {
+			lookPath = func(file string) (string, error) {
+				return exec.LookPath(filepath.Join(binDir, file))
+			}
+		})
+
+		it("runs plugins with their arguments", func() {
+			writePlugin(binDir, "pack-deploy", `echo "deploying $@"`)
+			args := []string{"deploy", "some/image", "--env", "prod"}
+			commands.AddPlugin(logger, rootCmd, args, lookPath)
+
+			rootCmd.SetArgs(args)
+			h.AssertNil(t, rootCmd.Execute())
+			h.AssertContains(t, outBuf.String(), "deploying some/image --env prod")
+		})
+
+		it("returns the exit code of plugins", func() {
+			writePlugin(binDir, "pack-deploy", "exit 3")
+			commands.AddPlugin(logger, rootCmd, []string{"deploy"}, lookPath)
+
+			rootCmd.SetArgs([]string{"deploy"})
+			err := rootCmd.Execute()
+			h.AssertEq(t, err, commands.PluginExitError{Plugin: "deploy", Code: 3})
+		})
+
+		it("doesn't look plugins up for the commands of pack", func() {
+			commands.AddPlugin(logger, rootCmd, []string{"build"}, func(string) (string, error) {
+				t.Fatal("unexpected plugin lookup")
+				return "", nil
+			})
+
+			rootCmd.SetArgs([]string{"build"})
+			h.AssertNil(t, rootCmd.Execute())
+		})
+
+		it("doesn't look plugins up for flags or paths", func() {
+			for _, args := range [][]string{{}, {"--version"}, {"../deploy"}} {
+				commands.AddPlugin(logger, rootCmd, args, func(string) (string, error) {
+					t.Fatalf("unexpected plugin lookup for %v", args)
+					return "", nil
+				})
+			}
+		})
+
+		it("leaves unknown commands without plugins to pack", func() {
+			commands.AddPlugin(logger, rootCmd, []string{"deploy"}, lookPath)
+
+			rootCmd.SetArgs([]string{"deploy"})
+			h.AssertError(t, rootCmd.Execute(), `unknown command "deploy"`)
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"fmt"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -16,15 +19,28 @@ import (
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
 	var policy string
+	var catalogOpts client.RebaseCatalogOptions
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
+		Use: "rebase <image-name>",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if catalogOpts.Catalog != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		Short:   "Rebase app image with latest run image",
-		Example: "pack rebase buildpacksio/pack",
+		Example: "pack rebase buildpacksio/pack\npack rebase --catalog registry.example.com/team --concurrency 4",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"With --catalog, every app image in a registry, or a namespace of it, is rebased in the registry when a newer run image is available.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if catalogOpts.Catalog != "" {
+				catalogOpts.AdditionalMirrors = getMirrors(cfg)
+				catalogOpts.Force = opts.Force
+				return rebaseCatalog(cmd, logger, pack, catalogOpts)
+			}
+
 			opts.RepoName = args[0]
 			opts.AdditionalMirrors = getMirrors(cfg)
 
@@ -48,11 +64,41 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 
 	cmd.Flags().BoolVar(&opts.Publish, "publish", false, "Publish the rebased application image directly to the container registry specified in <image-name>, instead of the daemon. The previous application image must also reside in the registry.")
 	cmd.Flags().StringVar(&opts.RunImage, "run-image", "", "Run image to use for rebasing")
-	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
 	cmd.Flags().StringVar(&opts.PreviousImage, "previous-image", "", "Image to rebase. Set to a particular tag reference, digest reference, or (when performing a daemon build) image ID. Use this flag in combination with <image-name> to avoid replacing the original image.")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
 
+	cmd.Flags().StringVar(&catalogOpts.Catalog, "catalog", "", "Rebase the app images of every repository in a registry, or a namespace of it, such as 'registry.example.com/team'")
+	cmd.Flags().StringVar(&catalogOpts.Tag, "catalog-tag", "latest", "Tag of the app images to rebase with --catalog")
+	cmd.Flags().IntVar(&catalogOpts.Concurrency, "concurrency", 1, "Number of app images rebased at once with --catalog")
+
 	AddHelpFlag(cmd, "rebase")
+	cmd.ValidArgsFunction = completeLocalImages(pack)
+	cmd.RegisterFlagCompletionFunc("run-image", completeRunImages(cfg))
 	return cmd
 }
+
+func rebaseCatalog(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseCatalogOptions) error {
+	results, err := pack.RebaseCatalog(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tSTATUS\tREASON")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Image, r.Status, r.Reason)
+		if r.Status == client.CatalogFailed {
+			failed++
+		}
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images in %s", failed, len(results), style.Symbol(opts.Catalog))
+	}
+	logger.Infof("Successfully rebased images in %s", style.Symbol(opts.Catalog))
+	return nil
+}
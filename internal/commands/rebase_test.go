@@ -55,6 +55,44 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--catalog is provided", func() {
+			it("rebases the images of the catalog", func() {
+				mockClient.EXPECT().
+					RebaseCatalog(gomock.Any(), client.RebaseCatalogOptions{
+						Catalog:           "registry.example.com/team",
+						Tag:               "latest",
+						Concurrency:       4,
+						AdditionalMirrors: map[string][]string{},
+					}).
+					Return([]client.CatalogRebaseResult{
+						{Image: "registry.example.com/team/app:latest", Status: client.CatalogRebased},
+						{Image: "registry.example.com/team/other:latest", Status: client.CatalogSkipped, Reason: "not built with buildpacks"},
+					}, nil)
+
+				command.SetArgs([]string{"--catalog", "registry.example.com/team", "--concurrency", "4"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContainsMatch(t, outBuf.String(), `registry.example.com/team/other:latest +skipped +not built with buildpacks`)
+				h.AssertContains(t, outBuf.String(), "Successfully rebased images in 'registry.example.com/team'")
+			})
+
+			it("fails when an image fails to be rebased", func() {
+				mockClient.EXPECT().
+					RebaseCatalog(gomock.Any(), gomock.Any()).
+					Return([]client.CatalogRebaseResult{
+						{Image: "registry.example.com/team/app:latest", Status: client.CatalogFailed, Reason: "some-error"},
+						{Image: "registry.example.com/team/other:latest", Status: client.CatalogUpToDate},
+					}, nil)
+
+				command.SetArgs([]string{"--catalog", "registry.example.com/team"})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images in 'registry.example.com/team'")
+			})
+
+			it("fails with an image name", func() {
+				command.SetArgs([]string{"some/image", "--catalog", "registry.example.com/team"})
+				h.AssertError(t, command.Execute(), "unknown command")
+			})
+		})
+
 		when("image name is provided", func() {
 			var (
 				repoName string
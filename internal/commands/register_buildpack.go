@@ -22,7 +22,9 @@ func RegisterBuildpack(logger logging.Logger, cfg config.Config, pack PackClient
 		Short:   "Register the buildpack to a registry",
 		Example: "pack register-buildpack my-buildpack",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "register-buildpack", "buildpack register")
+			if err := deprecationWarning(logger, "register-buildpack", "buildpack register"); err != nil {
+				return err
+			}
 			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
 			if err != nil {
 				return err
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewRegistryCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Interact with buildpack registries",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(newRegistryIndexCommand(logger, client))
+
+	AddHelpFlag(cmd, "registry")
+	return cmd
+}
+
+func newRegistryIndexCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Manage a static buildpack registry index",
+		Long: "A registry index lists the buildpackage images available for each buildpack ID and version. " +
+			"The generated directory can be pushed to a git repository and added with 'pack config registries add --type git', " +
+			"providing a self-hosted buildpack registry.",
+		RunE: nil,
+	}
+
+	cmd.AddCommand(RegistryIndexCreate(logger, client))
+	cmd.AddCommand(RegistryIndexUpdate(logger, client))
+
+	AddHelpFlag(cmd, "index")
+	return cmd
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// RegistryIndexCreate generates a new registry index from a set of buildpackage images
+func RegistryIndexCreate(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create <index-dir> <buildpackage-image> [<buildpackage-image>...]",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Create a registry index from buildpackage images",
+		Example: "pack registry index create ./registry-index example.com/acme/java:1.0.0 example.com/acme/node:2.0.0",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := pack.UpdateRegistryIndex(cmd.Context(), client.RegistryIndexOptions{
+				Path:       args[0],
+				ImageNames: args[1:],
+				Create:     true,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully created registry index %s", style.Symbol(args[0]))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "create")
+	return cmd
+}
+
+// RegistryIndexUpdate adds buildpackage images to an existing registry index
+func RegistryIndexUpdate(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update <index-dir> <buildpackage-image> [<buildpackage-image>...]",
+		Args:    cobra.MinimumNArgs(2),
+		Short:   "Add buildpackage images to a registry index",
+		Long:    "Add an entry for each buildpackage image to an existing registry index. Entries for versions already in the index are replaced.",
+		Example: "pack registry index update ./registry-index example.com/acme/java:1.1.0",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := pack.UpdateRegistryIndex(cmd.Context(), client.RegistryIndexOptions{
+				Path:       args[0],
+				ImageNames: args[1:],
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully updated registry index %s", style.Symbol(args[0]))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "update")
+	return cmd
+}
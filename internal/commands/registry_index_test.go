@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRegistryIndexCommands(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RegistryIndexCommands", testRegistryIndexCommands, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryIndexCommands(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#RegistryIndexCreate", func() {
+		it("creates an index from the given images", func() {
+			mockClient.EXPECT().UpdateRegistryIndex(gomock.Any(), client.RegistryIndexOptions{
+				Path:       "some-index",
+				ImageNames: []string{"some/buildpack:1", "some/other-buildpack:2"},
+				Create:     true,
+			}).Return(nil)
+
+			command := commands.NewRegistryCommand(logger, mockClient)
+			command.SetArgs([]string{"index", "create", "some-index", "some/buildpack:1", "some/other-buildpack:2"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully created registry index 'some-index'")
+		})
+
+		it("returns errors from the client", func() {
+			mockClient.EXPECT().UpdateRegistryIndex(gomock.Any(), gomock.Any()).Return(errors.New("already exists"))
+
+			command := commands.RegistryIndexCreate(logger, mockClient)
+			command.SetArgs([]string{"some-index"})
+			h.AssertError(t, command.Execute(), "already exists")
+		})
+	})
+
+	when("#RegistryIndexUpdate", func() {
+		it("updates an existing index", func() {
+			mockClient.EXPECT().UpdateRegistryIndex(gomock.Any(), client.RegistryIndexOptions{
+				Path:       "some-index",
+				ImageNames: []string{"some/buildpack:1"},
+			}).Return(nil)
+
+			command := commands.RegistryIndexUpdate(logger, mockClient)
+			command.SetArgs([]string{"some-index", "some/buildpack:1"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully updated registry index 'some-index'")
+		})
+
+		it("requires at least one image", func() {
+			command := commands.RegistryIndexUpdate(logger, mockClient)
+			command.SetArgs([]string{"some-index"})
+			h.AssertError(t, command.Execute(), "requires at least 2 arg(s)")
+		})
+	})
+}
@@ -16,7 +16,9 @@ func RemoveRegistry(logger logging.Logger, cfg config.Config, cfgPath string) *c
 		Short:   "Remove registry",
 		Example: "pack remove-registry myregistry",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "remove-registry", "config registries remove")
+			if err := deprecationWarning(logger, "remove-registry", "config registries remove"); err != nil {
+				return err
+			}
 			return removeRegistry(args, logger, cfg, cfgPath)
 		}),
 	}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewSamplesCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "samples",
+		Short: "Interact with sample apps",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(SamplesGenerate(logger, cfg, client))
+	AddHelpFlag(cmd, "samples")
+	return cmd
+}
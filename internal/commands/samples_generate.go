@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// SamplesGenerateFlags define flags provided to the SamplesGenerate command
+type SamplesGenerateFlags struct {
+	Language string
+	Path     string
+	Builder  string
+}
+
+// SamplesGenerate generates a sample app, to smoke-test builders and buildpacks with
+func SamplesGenerate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags SamplesGenerateFlags
+
+	cmd := &cobra.Command{
+		Use:   "generate --language <language>",
+		Args:  cobra.NoArgs,
+		Short: "Generate a sample app",
+		Long: "Generate a minimal sample app serving HTTP, along with a project.toml building it with the selected builder " +
+			"(the default builder, unless --builder is provided), such as to smoke-test builders and buildpacks locally with `pack build`.",
+		Example: "pack samples generate --language go --builder cnbs/sample-builder:jammy",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Language == "" {
+				return errors.Errorf("--language is required, must be one of %s", strings.Join(client.SampleLanguages(), ", "))
+			}
+
+			path := flags.Path
+			if path == "" {
+				path = "sample-" + flags.Language
+			}
+			path, err := filepath.Abs(path)
+			if err != nil {
+				return errors.Wrap(err, "resolving sample path")
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				return fmt.Errorf("directory %s exists", style.Symbol(path))
+			}
+
+			builder := flags.Builder
+			if builder == "" {
+				builder = cfg.DefaultBuilder
+			}
+
+			if err := pack.GenerateSample(cmd.Context(), client.GenerateSampleOptions{
+				Language: flags.Language,
+				Path:     path,
+				Builder:  builder,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully generated a %s sample app in %s", flags.Language, style.Symbol(path))
+			if builder == "" {
+				logger.Infof("No builder is selected, build it with %s", style.Symbol("pack build <image-name> --builder <builder> --path "+path))
+			} else {
+				logger.Infof("Build it with %s", style.Symbol("pack build <image-name> --path "+path))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Language, "language", "l", "", "Language of the sample app ("+strings.Join(client.SampleLanguages(), ", ")+")")
+	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the sample app in (default \"sample-<language>\")")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder to build the sample app with (default builder is used if not specified)")
+
+	AddHelpFlag(cmd, "generate")
+	return cmd
+}
@@ -0,0 +1,90 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSamplesGenerateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SamplesGenerateCommand", testSamplesGenerateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSamplesGenerateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		tmpDir         string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		tmpDir = t.TempDir()
+
+		command = commands.SamplesGenerate(logger, config.Config{DefaultBuilder: "some/default-builder"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#SamplesGenerate", func() {
+		it("generates the sample app with the default builder", func() {
+			path := filepath.Join(tmpDir, "app")
+			mockClient.EXPECT().GenerateSample(gomock.Any(), client.GenerateSampleOptions{
+				Language: "go",
+				Path:     path,
+				Builder:  "some/default-builder",
+			}).Return(nil)
+
+			command.SetArgs([]string{"--language", "go", "--path", path})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully generated a go sample app")
+		})
+
+		it("generates the sample app with the provided builder", func() {
+			path := filepath.Join(tmpDir, "app")
+			mockClient.EXPECT().GenerateSample(gomock.Any(), client.GenerateSampleOptions{
+				Language: "node",
+				Path:     path,
+				Builder:  "some/builder",
+			}).Return(nil)
+
+			command.SetArgs([]string{"--language", "node", "--path", path, "--builder", "some/builder"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("errors without a language", func() {
+			command.SetArgs([]string{"--path", tmpDir})
+			h.AssertError(t, command.Execute(), "--language is required, must be one of go, java, node")
+		})
+
+		it("errors when the directory exists", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "app"), 0755))
+
+			command.SetArgs([]string{"--language", "go", "--path", filepath.Join(tmpDir, "app")})
+			h.AssertError(t, command.Execute(), "directory")
+		})
+	})
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewSchemaCommand(logger logging.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Interact with the schemas of descriptors",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(SchemaExport(logger))
+	AddHelpFlag(cmd, "schema")
+	return cmd
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/schema"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// SchemaExportFlags define flags provided to the SchemaExport command
+type SchemaExportFlags struct {
+	Format string
+}
+
+// SchemaExport exports the schema of a descriptor, to lint it with editors and CI validation tools
+func SchemaExport(logger logging.Logger) *cobra.Command {
+	var flags SchemaExportFlags
+
+	cmd := &cobra.Command{
+		Use:   "export <" + strings.Join(schema.Kinds(), " | ") + ">",
+		Args:  cobra.ExactArgs(1),
+		Short: "Export the schema of a descriptor",
+		Long: "Export the schema of builder.toml, package.toml or project.toml, such as for editors and CI validation tools to lint them with.\n\n" +
+			"The schema is generated from the types pack decodes the descriptor into, so it matches this version of pack.",
+		Example:   "pack schema export builder --format jsonschema > builder.schema.json",
+		ValidArgs: schema.Kinds(),
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			out, err := schema.Export(args[0], flags.Format)
+			if err != nil {
+				return err
+			}
+
+			logger.Info(string(out))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", schema.FormatJSONSchema, "Format of the schema (jsonschema)")
+
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
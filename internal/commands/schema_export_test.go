@@ -0,0 +1,47 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSchemaExportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SchemaExportCommand", testSchemaExportCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSchemaExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command *cobra.Command
+		outBuf  bytes.Buffer
+	)
+
+	it.Before(func() {
+		command = commands.SchemaExport(logging.NewLogWithWriters(&outBuf, &outBuf))
+	})
+
+	when("#SchemaExport", func() {
+		it("exports the JSON Schema of the descriptor", func() {
+			command.SetArgs([]string{"builder", "--format", "jsonschema"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), `"title": "builder.toml"`)
+			h.AssertContains(t, outBuf.String(), `"order-extensions"`)
+		})
+
+		it("errors for unknown formats", func() {
+			command.SetArgs([]string{"package", "--format", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid format 'yaml', must be jsonschema")
+		})
+	})
+}
@@ -20,10 +20,12 @@ func SetDefaultBuilder(logger logging.Logger, cfg config.Config, cfgPath string,
 		Long:    "Set default builder used by other commands.\n\n** For suggested builders simply leave builder name empty. **",
 		Example: "pack set-default-builder cnbs/sample-builder:bionic",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "set-default-builder", "config default-builder")
+			if err := deprecationWarning(logger, "set-default-builder", "config default-builder"); err != nil {
+				return err
+			}
 			if len(args) < 1 || args[0] == "" {
 				logger.Infof("Usage:\n\t%s\n", cmd.UseLine())
-				suggestBuilders(logger, client)
+				suggestBuilders(logger, cfg, client, false)
 				return nil
 			}
 
@@ -22,7 +22,9 @@ func SetDefaultRegistry(logger logging.Logger, cfg config.Config, cfgPath string
 		Short:   "Set default registry",
 		Example: "pack set-default-registry myregistry",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "set-default-registry", "config registries default")
+			if err := deprecationWarning(logger, "set-default-registry", "config registries default"); err != nil {
+				return err
+			}
 			registryName = args[0]
 			if !registriesContains(config.GetRegistries(cfg), registryName) {
 				return errors.Errorf("no registry with the name %s exists", style.Symbol(registryName))
@@ -20,7 +20,9 @@ func SetRunImagesMirrors(logger logging.Logger, cfg config.Config, cfgPath strin
 		Short:   "Set mirrors to other repositories for a given run image",
 		Example: "pack set-run-image-mirrors cnbs/sample-stack-run:bionic --mirror index.docker.io/cnbs/sample-stack-run:bionic",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "set-run-image-mirrors", "config run-image-mirrors")
+			if err := deprecationWarning(logger, "set-run-image-mirrors", "config run-image-mirrors"); err != nil {
+				return err
+			}
 			runImage := args[0]
 			cfg = config.SetRunImageMirrors(cfg, runImage, mirrors)
 			if err := config.Write(cfg, cfgPath); err != nil {
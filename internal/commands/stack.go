@@ -3,10 +3,11 @@ package commands
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func NewStackCommand(logger logging.Logger) *cobra.Command {
+func NewStackCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
 	command := cobra.Command{
 		Use:   "stack",
 		Short: "(deprecated) Interact with stacks",
@@ -14,6 +15,7 @@ func NewStackCommand(logger logging.Logger) *cobra.Command {
 		RunE:  nil,
 	}
 
+	command.AddCommand(StackCreate(logger, cfg, client))
 	command.AddCommand(stackSuggest(logger))
 	return &command
 }
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/target"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// StackCreateFlags define flags provided to the stack create command
+type StackCreateFlags struct {
+	ID              string
+	BuildBase       string
+	RunBase         string
+	BuildDockerfile string
+	RunDockerfile   string
+	Mixins          []string
+	UID             int
+	GID             int
+	Targets         []string
+	Publish         bool
+	Policy          string
+}
+
+// StackCreate creates a build image and a run image, from base images or Dockerfiles
+func StackCreate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags StackCreateFlags
+
+	cmd := &cobra.Command{
+		Use:   "create <build-image-name> <run-image-name>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Create build and run images",
+		Example: `pack stack create my-build-image my-run-image --base ubuntu:jammy --mixin curl --mixin build:git
+pack stack create my-build-image my-run-image --build-dockerfile ./build.Dockerfile --run-dockerfile ./run.Dockerfile`,
+		Long: `Creates a build image and a run image, with the user, labels and environment the lifecycle expects, from base images or Dockerfiles.
+
+The images run as a 'cnb' user with the given ids, which is added to them unless their base images have a user with the same ids already.
+Mixins are only declared in the labels of the images: the packages they name must be installed by the base images or Dockerfiles.
+`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			var targets []dist.Target
+			if len(flags.Targets) > 0 {
+				if targets, err = target.ParseTargets(flags.Targets, logger); err != nil {
+					return err
+				}
+			}
+
+			return pack.CreateStack(cmd.Context(), client.CreateStackOptions{
+				ID:              flags.ID,
+				BuildImage:      args[0],
+				RunImage:        args[1],
+				BuildBase:       flags.BuildBase,
+				RunBase:         flags.RunBase,
+				BuildDockerfile: flags.BuildDockerfile,
+				RunDockerfile:   flags.RunDockerfile,
+				Mixins:          flags.Mixins,
+				UID:             flags.UID,
+				GID:             flags.GID,
+				Targets:         targets,
+				Publish:         flags.Publish,
+				PullPolicy:      pullPolicy,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.ID, "id", "", "ID of the stack, set on both images")
+	cmd.Flags().StringVar(&flags.BuildBase, "base", "", "Base image of the build image, and of the run image unless --run-base is set")
+	cmd.Flags().StringVar(&flags.RunBase, "run-base", "", "Base image of the run image")
+	cmd.Flags().StringVar(&flags.BuildDockerfile, "build-dockerfile", "", "Dockerfile to build the base image of the build image from, instead of --base")
+	cmd.Flags().StringVar(&flags.RunDockerfile, "run-dockerfile", "", "Dockerfile to build the base image of the run image from, instead of --run-base")
+	cmd.Flags().StringSliceVar(&flags.Mixins, "mixin", nil, "Mixin provided by the images. Prefix it with 'build:' or 'run:' if only one of them provides it."+stringSliceHelp("mixin"))
+	cmd.Flags().IntVar(&flags.UID, "uid", 1000, "User ID the images run as")
+	cmd.Flags().IntVar(&flags.GID, "gid", 1000, "Group ID the images run as")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the images to the registries specified in their names, instead of the daemon")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. The default is always")
+	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
+		`Target platforms to create the images for, in the format '[os][/arch][/variant]'.
+More than one target requires --publish, and the images are published as image indexes.
+	`)
+
+	AddHelpFlag(cmd, "create")
+	return cmd
+}
@@ -0,0 +1,95 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestStackCreateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "StackCreateCommand", testStackCreateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testStackCreateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.StackCreate(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#StackCreate", func() {
+		it("creates the images with the default user", func() {
+			mockClient.EXPECT().CreateStack(gomock.Any(), client.CreateStackOptions{
+				BuildImage: "some/build",
+				RunImage:   "some/run",
+				BuildBase:  "some/base",
+				Mixins:     []string{"curl", "build:git"},
+				UID:        1000,
+				GID:        1000,
+				PullPolicy: image.PullAlways,
+			}).Return(nil)
+
+			command.SetArgs([]string{"some/build", "some/run", "--base", "some/base", "--mixin", "curl,build:git"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("creates the images for the targets", func() {
+			mockClient.EXPECT().CreateStack(gomock.Any(), client.CreateStackOptions{
+				ID:              "some.stack.id",
+				BuildImage:      "some/build",
+				RunImage:        "some/run",
+				BuildDockerfile: "build.Dockerfile",
+				RunDockerfile:   "run.Dockerfile",
+				UID:             1001,
+				GID:             1002,
+				Targets:         []dist.Target{{OS: "linux", Arch: "arm64"}},
+				PullPolicy:      image.PullNever,
+			}).Return(nil)
+
+			command.SetArgs([]string{
+				"some/build", "some/run",
+				"--id", "some.stack.id",
+				"--build-dockerfile", "build.Dockerfile",
+				"--run-dockerfile", "run.Dockerfile",
+				"--uid", "1001",
+				"--gid", "1002",
+				"--target", "linux/arm64",
+				"--pull-policy", "never",
+			})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("fails with an invalid pull policy", func() {
+			command.SetArgs([]string{"some/build", "some/run", "--base", "some/base", "--pull-policy", "sometimes"})
+			h.AssertError(t, command.Execute(), "parsing pull policy")
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sclevine/spec/report"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -23,7 +24,7 @@ func testStackCommand(t *testing.T, when spec.G, it spec.S) {
 	)
 
 	it.Before(func() {
-		command = NewStackCommand(logging.NewLogWithWriters(&outBuf, &outBuf))
+		command = NewStackCommand(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, nil)
 	})
 
 	when("#Stack", func() {
@@ -40,6 +41,7 @@ Usage:
 
 Available Commands:
   completion  Generate the autocompletion script for the specified shell
+  create      Create build and run images
   help        Help about any command
   suggest     (deprecated) List the recommended stacks
 
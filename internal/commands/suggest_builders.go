@@ -9,43 +9,80 @@ import (
 	"github.com/spf13/cobra"
 
 	bldr "github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 // Deprecated: Use `builder suggest` instead.
-func SuggestBuilders(logger logging.Logger, inspector BuilderInspector) *cobra.Command {
+func SuggestBuilders(logger logging.Logger, cfg config.Config, inspector BuilderInspector) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "suggest-builders",
 		Hidden:  true,
 		Args:    cobra.NoArgs,
 		Short:   "Display list of recommended builders",
 		Example: "pack suggest-builders",
-		Run: func(cmd *cobra.Command, s []string) {
-			deprecationWarning(logger, "suggest-builder", "builder suggest")
-			suggestBuilders(logger, inspector)
-		},
+		RunE: logError(logger, func(cmd *cobra.Command, s []string) error {
+			if err := deprecationWarning(logger, "suggest-builder", "builder suggest"); err != nil {
+				return err
+			}
+			suggestBuilders(logger, cfg, inspector, false)
+			return nil
+		}),
 	}
 
 	return cmd
 }
 
-func suggestSettingBuilder(logger logging.Logger, inspector BuilderInspector) {
+func suggestSettingBuilder(logger logging.Logger, cfg config.Config, inspector BuilderInspector) {
 	logger.Info("Please select a default builder with:")
 	logger.Info("")
 	logger.Info("\tpack config default-builder <builder-image>")
 	logger.Info("")
-	suggestBuilders(logger, inspector)
+	suggestBuilders(logger, cfg, inspector, false)
 }
 
-func suggestBuilders(logger logging.Logger, client BuilderInspector) {
+func suggestBuilders(logger logging.Logger, cfg config.Config, client BuilderInspector, refresh bool) {
+	WriteSuggestedBuilder(logger, client, getSuggestedBuilders(logger, cfg, refresh))
+}
+
+// getSuggestedBuilders returns the builders from the configured suggested builders source, falling back to
+// the built-in suggestions, followed by any builders added with `pack config suggested-builders add`
+func getSuggestedBuilders(logger logging.Logger, cfg config.Config, refresh bool) []bldr.KnownBuilder {
 	suggestedBuilders := []bldr.KnownBuilder{}
 	for _, knownBuilder := range bldr.KnownBuilders {
 		if knownBuilder.Suggested {
 			suggestedBuilders = append(suggestedBuilders, knownBuilder)
 		}
 	}
-	WriteSuggestedBuilder(logger, client, suggestedBuilders)
+
+	if source := cfg.SuggestedBuildersSource; source.URL != "" {
+		remoteBuilders, err := fetchSuggestedBuilders(source, refresh)
+		if err != nil {
+			logger.Warnf("Unable to load suggested builders from %s: %s", style.Symbol(source.URL), err)
+		}
+		if len(remoteBuilders) > 0 {
+			suggestedBuilders = remoteBuilders
+		}
+	}
+
+	for _, b := range cfg.SuggestedBuilders {
+		suggestedBuilders = append(suggestedBuilders, bldr.KnownBuilder{
+			Vendor:             b.Vendor,
+			Image:              b.Image,
+			DefaultDescription: b.Description,
+			Suggested:          true,
+		})
+	}
+	return suggestedBuilders
+}
+
+func fetchSuggestedBuilders(source config.SuggestedBuildersSource, refresh bool) ([]bldr.KnownBuilder, error) {
+	packHome, err := config.PackHome()
+	if err != nil {
+		return nil, err
+	}
+	return bldr.NewSuggestedBuildersSource(source.URL, source.PublicKey, packHome).Builders(refresh)
 }
 
 func WriteSuggestedBuilder(logger logging.Logger, inspector BuilderInspector, builders []bldr.KnownBuilder) {
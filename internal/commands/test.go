@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// TestFlags define flags provided to the Test command
+type TestFlags struct {
+	Builder      string
+	Buildpack    string
+	OutputFormat string
+	Policy       string
+	Run          []string
+	TrustBuilder bool
+}
+
+// Test builds the fixture apps of the tests of a buildpack, and reports whether their outcomes are the expected ones
+func Test(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags TestFlags
+
+	cmd := &cobra.Command{
+		Use:   "test [<tests-config>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Run the tests of a buildpack",
+		Long: "Build the fixture apps defined in a tests config (tests.toml by default) with a buildpack, and check that " +
+			"detection passes or fails for each of them as expected, and that the app images have the expected processes " +
+			"and environment variables.\n\n" +
+			"The command fails if any test fails. Use --output json for machine-readable results.",
+		Example: "pack test ./tests.toml --builder cnbs/sample-builder:jammy",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("invalid output format %s, must be one of human-readable or json", style.Symbol(flags.OutputFormat))
+			}
+
+			configPath := "tests.toml"
+			if len(args) > 0 {
+				configPath = args[0]
+			}
+			configPath, err := filepath.Abs(configPath)
+			if err != nil {
+				return errors.Wrap(err, "resolving tests config path")
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			results, err := pack.TestBuildpack(cmd.Context(), client.TestBuildpackOptions{
+				ConfigPath: configPath,
+				Builder:    flags.Builder,
+				Buildpack:  flags.Buildpack,
+				Names:      flags.Run,
+				PullPolicy: pullPolicy,
+				TrustBuilder: func(builder string) bool {
+					return flags.TrustBuilder || isTrustedBuilder(cfg, builder)
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			return reportTestResults(logger, flags.OutputFormat, results)
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder to build the fixture apps with, overriding the builder of the tests config")
+	cmd.Flags().StringVarP(&flags.Buildpack, "buildpack", "b", "", "Buildpack to test, overriding the buildpack of the tests config")
+	cmd.Flags().StringSliceVar(&flags.Run, "run", nil, "Names of the tests to run. Every test is run by default"+stringSliceHelp("test"))
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display results (json, human-readable)")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, if-not-present, and interval=<duration> to pull images last pulled longer ago than the duration, such as interval=24h. (default "always")`)
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the builder")
+
+	AddHelpFlag(cmd, "test")
+	return cmd
+}
+
+// reportTestResults prints results in the given format, and returns a soft error if any test failed
+func reportTestResults(logger logging.Logger, format string, results []client.BuildpackTestResult) error {
+	failed := 0
+	for _, result := range results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+
+	if format == "json" {
+		if results == nil {
+			results = []client.BuildpackTestResult{}
+		}
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshalling results")
+		}
+		logger.Info(string(out))
+	} else {
+		logger.Info("")
+		for _, result := range results {
+			if result.Passed() {
+				logger.Infof("PASS  %s", result.Name)
+				continue
+			}
+			logger.Infof("FAIL  %s", result.Name)
+			for _, failure := range result.Failures {
+				logger.Infof("      %s", failure)
+			}
+		}
+		logger.Info(fmt.Sprintf("\n%d passed, %d failed", len(results)-failed, failed))
+	}
+
+	if failed > 0 {
+		return client.NewSoftError()
+	}
+	return nil
+}
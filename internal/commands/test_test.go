@@ -0,0 +1,131 @@
+package commands_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTestCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "TestCommand", testTestCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTestCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		opts           client.TestBuildpackOptions
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.Test(logger, config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "some/trusted-builder"}}}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	expectTests := func(results []client.BuildpackTestResult) {
+		mockClient.EXPECT().
+			TestBuildpack(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, o client.TestBuildpackOptions) ([]client.BuildpackTestResult, error) {
+				opts = o
+				return results, nil
+			})
+	}
+
+	when("#Test", func() {
+		it("runs the tests of tests.toml by default, and reports them", func() {
+			expectTests([]client.BuildpackTestResult{{Name: "go app"}, {Name: "no go"}})
+
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, filepath.Base(opts.ConfigPath), "tests.toml")
+			h.AssertTrue(t, filepath.IsAbs(opts.ConfigPath))
+			h.AssertEq(t, opts.PullPolicy, image.PullAlways)
+			h.AssertContains(t, outBuf.String(), "PASS  go app")
+			h.AssertContains(t, outBuf.String(), "PASS  no go")
+			h.AssertContains(t, outBuf.String(), "2 passed, 0 failed")
+		})
+
+		it("passes the flags", func() {
+			expectTests(nil)
+
+			command.SetArgs([]string{"some/tests.toml", "--builder", "some/builder", "--buildpack", "some/bp", "--run", "go app", "--pull-policy", "never"})
+			h.AssertNil(t, command.Execute())
+
+			expectedPath, err := filepath.Abs("some/tests.toml")
+			h.AssertNil(t, err)
+			h.AssertEq(t, opts.ConfigPath, expectedPath)
+			h.AssertEq(t, opts.Builder, "some/builder")
+			h.AssertEq(t, opts.Buildpack, "some/bp")
+			h.AssertEq(t, opts.Names, []string{"go app"})
+			h.AssertEq(t, opts.PullPolicy, image.PullNever)
+		})
+
+		it("trusts trusted builders, or every builder with --trust-builder", func() {
+			expectTests(nil)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertEq(t, opts.TrustBuilder("some/trusted-builder"), true)
+			h.AssertEq(t, opts.TrustBuilder("some/builder"), false)
+
+			expectTests(nil)
+			command.SetArgs([]string{"--trust-builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertEq(t, opts.TrustBuilder("some/builder"), true)
+		})
+
+		it("fails when a test fails", func() {
+			expectTests([]client.BuildpackTestResult{
+				{Name: "go app"},
+				{Name: "no go", Failures: []string{"expected detection to fail, but it passed"}},
+			})
+
+			command.SetArgs([]string{})
+			err := command.Execute()
+			h.AssertEq(t, err, client.NewSoftError())
+			h.AssertContains(t, outBuf.String(), "FAIL  no go\n      expected detection to fail, but it passed")
+			h.AssertContains(t, outBuf.String(), "1 passed, 1 failed")
+		})
+
+		it("outputs results as json", func() {
+			expectTests([]client.BuildpackTestResult{{Name: "go app"}})
+
+			command.SetArgs([]string{"--output", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"name": "go app"`)
+			h.AssertNotContains(t, outBuf.String(), "PASS")
+		})
+
+		it("errors for unknown output formats", func() {
+			command.SetArgs([]string{"--output", "yaml"})
+			h.AssertError(t, command.Execute(), "invalid output format 'yaml'")
+		})
+	})
+}
@@ -6,11 +6,12 @@ package testmocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
-
+	buildpack "github.com/buildpacks/pack/pkg/buildpack"
 	client "github.com/buildpacks/pack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
 )
 
 // MockPackClient is a mock of PackClient interface.
@@ -78,6 +79,79 @@ func (mr *MockPackClientMockRecorder) Build(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockPackClient)(nil).Build), arg0, arg1)
 }
 
+// CheckCompatibility mocks base method.
+func (m *MockPackClient) CheckCompatibility(arg0 context.Context, arg1 client.CompatOptions) (*client.CompatReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCompatibility", arg0, arg1)
+	ret0, _ := ret[0].(*client.CompatReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCompatibility indicates an expected call of CheckCompatibility.
+func (mr *MockPackClientMockRecorder) CheckCompatibility(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCompatibility", reflect.TypeOf((*MockPackClient)(nil).CheckCompatibility), arg0, arg1)
+}
+
+// CheckUpdates mocks base method.
+func (m *MockPackClient) CheckUpdates(arg0 context.Context, arg1 client.CheckUpdatesOptions) (*client.RunImageUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUpdates", arg0, arg1)
+	ret0, _ := ret[0].(*client.RunImageUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUpdates indicates an expected call of CheckUpdates.
+func (mr *MockPackClientMockRecorder) CheckUpdates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUpdates", reflect.TypeOf((*MockPackClient)(nil).CheckUpdates), arg0, arg1)
+}
+
+// CleanPhases mocks base method.
+func (m *MockPackClient) CleanPhases(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanPhases", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanPhases indicates an expected call of CleanPhases.
+func (mr *MockPackClientMockRecorder) CleanPhases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanPhases", reflect.TypeOf((*MockPackClient)(nil).CleanPhases), arg0, arg1)
+}
+
+// CopyBuilder mocks base method.
+func (m *MockPackClient) CopyBuilder(arg0 context.Context, arg1 client.CopyBuilderOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBuilder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyBuilder indicates an expected call of CopyBuilder.
+func (mr *MockPackClientMockRecorder) CopyBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBuilder", reflect.TypeOf((*MockPackClient)(nil).CopyBuilder), arg0, arg1)
+}
+
+// CopyBuildpack mocks base method.
+func (m *MockPackClient) CopyBuildpack(arg0 context.Context, arg1 client.CopyBuildpackOptions) ([]client.RelocatedBuildpack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBuildpack", arg0, arg1)
+	ret0, _ := ret[0].([]client.RelocatedBuildpack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyBuildpack indicates an expected call of CopyBuildpack.
+func (mr *MockPackClientMockRecorder) CopyBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBuildpack", reflect.TypeOf((*MockPackClient)(nil).CopyBuildpack), arg0, arg1)
+}
+
 // CreateBuilder mocks base method.
 func (m *MockPackClient) CreateBuilder(arg0 context.Context, arg1 client.CreateBuilderOptions) error {
 	m.ctrl.T.Helper()
@@ -106,6 +180,20 @@ func (mr *MockPackClientMockRecorder) CreateManifest(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManifest", reflect.TypeOf((*MockPackClient)(nil).CreateManifest), arg0, arg1)
 }
 
+// CreateStack mocks base method.
+func (m *MockPackClient) CreateStack(arg0 context.Context, arg1 client.CreateStackOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStack", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStack indicates an expected call of CreateStack.
+func (mr *MockPackClientMockRecorder) CreateStack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStack", reflect.TypeOf((*MockPackClient)(nil).CreateStack), arg0, arg1)
+}
+
 // DeleteManifest mocks base method.
 func (m *MockPackClient) DeleteManifest(arg0 []string) error {
 	m.ctrl.T.Helper()
@@ -120,6 +208,35 @@ func (mr *MockPackClientMockRecorder) DeleteManifest(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockPackClient)(nil).DeleteManifest), arg0)
 }
 
+// DiffBuilders mocks base method.
+func (m *MockPackClient) DiffBuilders(arg0 client.DiffBuildersOptions) (*client.BuilderDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffBuilders", arg0)
+	ret0, _ := ret[0].(*client.BuilderDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffBuilders indicates an expected call of DiffBuilders.
+func (mr *MockPackClientMockRecorder) DiffBuilders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffBuilders", reflect.TypeOf((*MockPackClient)(nil).DiffBuilders), arg0)
+}
+
+// Doctor mocks base method.
+func (m *MockPackClient) Doctor(arg0 context.Context) []client.DoctorCheck {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Doctor", arg0)
+	ret0, _ := ret[0].([]client.DoctorCheck)
+	return ret0
+}
+
+// Doctor indicates an expected call of Doctor.
+func (mr *MockPackClientMockRecorder) Doctor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Doctor", reflect.TypeOf((*MockPackClient)(nil).Doctor), arg0)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -134,6 +251,64 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExtractBuildpack mocks base method.
+func (m *MockPackClient) ExtractBuildpack(arg0 context.Context, arg1 client.ExtractBuildpackOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractBuildpack", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtractBuildpack indicates an expected call of ExtractBuildpack.
+func (mr *MockPackClientMockRecorder) ExtractBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractBuildpack", reflect.TypeOf((*MockPackClient)(nil).ExtractBuildpack), arg0, arg1)
+}
+
+// GC mocks base method.
+func (m *MockPackClient) GC(arg0 context.Context, arg1 client.GCOptions) ([]client.IntermediateImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GC", arg0, arg1)
+	ret0, _ := ret[0].([]client.IntermediateImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GC indicates an expected call of GC.
+func (mr *MockPackClientMockRecorder) GC(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GC", reflect.TypeOf((*MockPackClient)(nil).GC), arg0, arg1)
+}
+
+// GenerateSample mocks base method.
+func (m *MockPackClient) GenerateSample(arg0 context.Context, arg1 client.GenerateSampleOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSample", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateSample indicates an expected call of GenerateSample.
+func (mr *MockPackClientMockRecorder) GenerateSample(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSample", reflect.TypeOf((*MockPackClient)(nil).GenerateSample), arg0, arg1)
+}
+
+// ImageProvenance mocks base method.
+func (m *MockPackClient) ImageProvenance(arg0 context.Context, arg1 client.ImageProvenanceOptions) (*client.ImageProvenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageProvenance", arg0, arg1)
+	ret0, _ := ret[0].(*client.ImageProvenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageProvenance indicates an expected call of ImageProvenance.
+func (mr *MockPackClientMockRecorder) ImageProvenance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageProvenance", reflect.TypeOf((*MockPackClient)(nil).ImageProvenance), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +344,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectBuildpackAPIShims mocks base method.
+func (m *MockPackClient) InspectBuildpackAPIShims(arg0 context.Context, arg1 client.InspectBuildpackAPIShimsOptions) (*client.BuildpackAPIShimsReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectBuildpackAPIShims", arg0, arg1)
+	ret0, _ := ret[0].(*client.BuildpackAPIShimsReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectBuildpackAPIShims indicates an expected call of InspectBuildpackAPIShims.
+func (mr *MockPackClientMockRecorder) InspectBuildpackAPIShims(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpackAPIShims", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpackAPIShims), arg0, arg1)
+}
+
 // InspectExtension mocks base method.
 func (m *MockPackClient) InspectExtension(arg0 client.InspectExtensionOptions) (*client.ExtensionInfo, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +389,21 @@ func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
 }
 
+// InspectIndex mocks base method.
+func (m *MockPackClient) InspectIndex(arg0 context.Context, arg1 string) (*client.IndexInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectIndex", arg0, arg1)
+	ret0, _ := ret[0].(*client.IndexInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectIndex indicates an expected call of InspectIndex.
+func (mr *MockPackClientMockRecorder) InspectIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectIndex", reflect.TypeOf((*MockPackClient)(nil).InspectIndex), arg0, arg1)
+}
+
 // InspectManifest mocks base method.
 func (m *MockPackClient) InspectManifest(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -213,6 +418,109 @@ func (mr *MockPackClientMockRecorder) InspectManifest(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectManifest", reflect.TypeOf((*MockPackClient)(nil).InspectManifest), arg0)
 }
 
+// LintBuilder mocks base method.
+func (m *MockPackClient) LintBuilder(arg0 context.Context, arg1 client.LintBuilderOptions) ([]buildpack.LintFinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LintBuilder", arg0, arg1)
+	ret0, _ := ret[0].([]buildpack.LintFinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LintBuilder indicates an expected call of LintBuilder.
+func (mr *MockPackClientMockRecorder) LintBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintBuilder", reflect.TypeOf((*MockPackClient)(nil).LintBuilder), arg0, arg1)
+}
+
+// LintBuildpack mocks base method.
+func (m *MockPackClient) LintBuildpack(arg0 context.Context, arg1 client.LintBuildpackOptions) ([]buildpack.LintFinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LintBuildpack", arg0, arg1)
+	ret0, _ := ret[0].([]buildpack.LintFinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LintBuildpack indicates an expected call of LintBuildpack.
+func (mr *MockPackClientMockRecorder) LintBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintBuildpack", reflect.TypeOf((*MockPackClient)(nil).LintBuildpack), arg0, arg1)
+}
+
+// ListLocalImages mocks base method.
+func (m *MockPackClient) ListLocalImages(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocalImages", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocalImages indicates an expected call of ListLocalImages.
+func (mr *MockPackClientMockRecorder) ListLocalImages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocalImages", reflect.TypeOf((*MockPackClient)(nil).ListLocalImages), arg0)
+}
+
+// Login mocks base method.
+func (m *MockPackClient) Login(arg0 context.Context, arg1 client.LoginOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockPackClientMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockPackClient)(nil).Login), arg0, arg1)
+}
+
+// Logout mocks base method.
+func (m *MockPackClient) Logout(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockPackClientMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockPackClient)(nil).Logout), arg0, arg1)
+}
+
+// MergeCNB mocks base method.
+func (m *MockPackClient) MergeCNB(arg0 context.Context, arg1 client.MergeCNBOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCNB", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCNB indicates an expected call of MergeCNB.
+func (mr *MockPackClientMockRecorder) MergeCNB(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCNB", reflect.TypeOf((*MockPackClient)(nil).MergeCNB), arg0, arg1)
+}
+
+// NeedsRebuild mocks base method.
+func (m *MockPackClient) NeedsRebuild(arg0 context.Context, arg1 client.NeedsRebuildOptions) (*client.RebuildCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRebuild", arg0, arg1)
+	ret0, _ := ret[0].(*client.RebuildCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NeedsRebuild indicates an expected call of NeedsRebuild.
+func (mr *MockPackClientMockRecorder) NeedsRebuild(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRebuild", reflect.TypeOf((*MockPackClient)(nil).NeedsRebuild), arg0, arg1)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -227,6 +535,21 @@ func (mr *MockPackClientMockRecorder) NewBuildpack(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBuildpack", reflect.TypeOf((*MockPackClient)(nil).NewBuildpack), arg0, arg1)
 }
 
+// OutdatedBuilder mocks base method.
+func (m *MockPackClient) OutdatedBuilder(arg0 client.OutdatedBuilderOptions) (*client.BuilderUpgradeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutdatedBuilder", arg0)
+	ret0, _ := ret[0].(*client.BuilderUpgradeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutdatedBuilder indicates an expected call of OutdatedBuilder.
+func (mr *MockPackClientMockRecorder) OutdatedBuilder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutdatedBuilder", reflect.TypeOf((*MockPackClient)(nil).OutdatedBuilder), arg0)
+}
+
 // PackageBuildpack mocks base method.
 func (m *MockPackClient) PackageBuildpack(arg0 context.Context, arg1 client.PackageBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -297,6 +620,21 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseCatalog mocks base method.
+func (m *MockPackClient) RebaseCatalog(arg0 context.Context, arg1 client.RebaseCatalogOptions) ([]client.CatalogRebaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseCatalog", arg0, arg1)
+	ret0, _ := ret[0].([]client.CatalogRebaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseCatalog indicates an expected call of RebaseCatalog.
+func (mr *MockPackClientMockRecorder) RebaseCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseCatalog", reflect.TypeOf((*MockPackClient)(nil).RebaseCatalog), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -311,6 +649,21 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// RelocateBuilder mocks base method.
+func (m *MockPackClient) RelocateBuilder(arg0 context.Context, arg1 client.RelocateBuilderOptions) (*client.RelocatedBuilder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelocateBuilder", arg0, arg1)
+	ret0, _ := ret[0].(*client.RelocatedBuilder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelocateBuilder indicates an expected call of RelocateBuilder.
+func (mr *MockPackClientMockRecorder) RelocateBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelocateBuilder", reflect.TypeOf((*MockPackClient)(nil).RelocateBuilder), arg0, arg1)
+}
+
 // RemoveManifest mocks base method.
 func (m *MockPackClient) RemoveManifest(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -325,6 +678,78 @@ func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
 }
 
+// SplitCNB mocks base method.
+func (m *MockPackClient) SplitCNB(arg0 context.Context, arg1 client.SplitCNBOptions) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitCNB", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitCNB indicates an expected call of SplitCNB.
+func (mr *MockPackClientMockRecorder) SplitCNB(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitCNB", reflect.TypeOf((*MockPackClient)(nil).SplitCNB), arg0, arg1)
+}
+
+// TestBuildpack mocks base method.
+func (m *MockPackClient) TestBuildpack(arg0 context.Context, arg1 client.TestBuildpackOptions) ([]client.BuildpackTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestBuildpack", arg0, arg1)
+	ret0, _ := ret[0].([]client.BuildpackTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestBuildpack indicates an expected call of TestBuildpack.
+func (mr *MockPackClientMockRecorder) TestBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestBuildpack", reflect.TypeOf((*MockPackClient)(nil).TestBuildpack), arg0, arg1)
+}
+
+// UpdateBuilder mocks base method.
+func (m *MockPackClient) UpdateBuilder(arg0 context.Context, arg1 client.UpdateBuilderOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBuilder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBuilder indicates an expected call of UpdateBuilder.
+func (mr *MockPackClientMockRecorder) UpdateBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBuilder", reflect.TypeOf((*MockPackClient)(nil).UpdateBuilder), arg0, arg1)
+}
+
+// UpdateRegistryIndex mocks base method.
+func (m *MockPackClient) UpdateRegistryIndex(arg0 context.Context, arg1 client.RegistryIndexOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRegistryIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRegistryIndex indicates an expected call of UpdateRegistryIndex.
+func (mr *MockPackClientMockRecorder) UpdateRegistryIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegistryIndex", reflect.TypeOf((*MockPackClient)(nil).UpdateRegistryIndex), arg0, arg1)
+}
+
+// WriteBuildManifest mocks base method.
+func (m *MockPackClient) WriteBuildManifest(arg0 context.Context, arg1 client.BuildOptions, arg2 string, arg3 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBuildManifest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBuildManifest indicates an expected call of WriteBuildManifest.
+func (mr *MockPackClientMockRecorder) WriteBuildManifest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBuildManifest", reflect.TypeOf((*MockPackClient)(nil).WriteBuildManifest), arg0, arg1, arg2, arg3)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -17,7 +17,9 @@ func TrustBuilder(logger logging.Logger, cfg config.Config, cfgPath string) *cob
 		Example: "pack trust-builder cnbs/sample-stack-run:bionic",
 		Hidden:  true,
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "trust-builder", "config trusted-builders add")
+			if err := deprecationWarning(logger, "trust-builder", "config trusted-builders add"); err != nil {
+				return err
+			}
 			return addTrustedBuilder(args, logger, cfg, cfgPath)
 		}),
 	}
@@ -17,7 +17,9 @@ func UntrustBuilder(logger logging.Logger, cfg config.Config, cfgPath string) *c
 		Long:    "Stop trusting builder.\n\nWhen building with this builder, all lifecycle phases will be no longer be run in a single container using the builder image.",
 		Example: "pack untrust-builder cnbs/sample-stack-run:bionic",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "untrust-builder", "config trusted-builders remove")
+			if err := deprecationWarning(logger, "untrust-builder", "config trusted-builders remove"); err != nil {
+				return err
+			}
 			return removeTrustedBuilder(args, logger, cfg, cfgPath)
 		}),
 	}
@@ -20,7 +20,9 @@ func YankBuildpack(logger logging.Logger, cfg config.Config, pack PackClient) *c
 		Short:   "Yank the buildpack from the registry",
 		Example: "pack yank-buildpack my-buildpack@0.0.1",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			deprecationWarning(logger, "yank-buildpack", "buildpack yank")
+			if err := deprecationWarning(logger, "yank-buildpack", "buildpack yank"); err != nil {
+				return err
+			}
 			buildpackIDVersion := args[0]
 
 			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
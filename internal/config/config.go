@@ -12,23 +12,49 @@ import (
 
 type Config struct {
 	// Deprecated: Use DefaultRegistryName instead. See https://github.com/buildpacks/pack/issues/747.
-	DefaultRegistry     string            `toml:"default-registry-url,omitempty"`
-	DefaultRegistryName string            `toml:"default-registry,omitempty"`
-	DefaultBuilder      string            `toml:"default-builder-image,omitempty"`
-	PullPolicy          string            `toml:"pull-policy,omitempty"`
-	Experimental        bool              `toml:"experimental,omitempty"`
-	RunImages           []RunImage        `toml:"run-images"`
-	TrustedBuilders     []TrustedBuilder  `toml:"trusted-builders,omitempty"`
-	Registries          []Registry        `toml:"registries,omitempty"`
-	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
-	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
-	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	DefaultRegistry         string                  `toml:"default-registry-url,omitempty"`
+	DefaultRegistryName     string                  `toml:"default-registry,omitempty"`
+	DefaultBuilder          string                  `toml:"default-builder-image,omitempty"`
+	PullPolicy              string                  `toml:"pull-policy,omitempty"`
+	Experimental            bool                    `toml:"experimental,omitempty"`
+	ExperimentalFeatures    []string                `toml:"experimental-features,omitempty"`
+	RunImages               []RunImage              `toml:"run-images"`
+	TrustedBuilders         []TrustedBuilder        `toml:"trusted-builders,omitempty"`
+	Registries              []Registry              `toml:"registries,omitempty"`
+	LifecycleImage          string                  `toml:"lifecycle-image,omitempty"`
+	LifecycleImageDigests   []string                `toml:"lifecycle-image-digests,omitempty"`
+	LifecycleImageAuth      string                  `toml:"lifecycle-image-docker-config,omitempty"` // directory of a docker config with the credentials of the lifecycle image
+	RegistryMirrors         map[string]string       `toml:"registry-mirrors,omitempty"`
+	LayoutRepositoryDir     string                  `toml:"layout-repo-dir,omitempty"`
+	SuggestedBuilders       []SuggestedBuilder      `toml:"suggested-builders,omitempty"`
+	SuggestedBuildersSource SuggestedBuildersSource `toml:"suggested-builders-source,omitempty"`
+	TrustPolicy             TrustPolicy             `toml:"trust-policy,omitempty"`
+	LocalCacheRegistry      bool                    `toml:"local-cache-registry,omitempty"`
+	BuildpackAPIShims       string                  `toml:"buildpack-api-shims,omitempty"`
+	SchemaVersion           int                     `toml:"schema-version,omitempty"` // see SchemaVersion and Migrate
+	Defaults                Defaults                `toml:"defaults,omitempty"`
+	LogExport               LogExport               `toml:"log-export,omitempty"`
+	Proxy                   Proxy                   `toml:"proxy,omitempty"`
+	Keyring                 string                  `toml:"keyring,omitempty"` // docker credential helper keeping the key of the encrypted values, see DefaultKeyringHelper
 }
 
+// Defaults are the default values of the flags of commands, by the path of the command without 'pack', such as
+// 'build' or 'builder create'. Flags given on the command line take precedence over them.
+type Defaults map[string]map[string]interface{}
+
 type Registry struct {
-	Name string `toml:"name"`
-	Type string `toml:"type"`
-	URL  string `toml:"url"`
+	Name  string `toml:"name"`
+	Type  string `toml:"type"`
+	URL   string `toml:"url"`
+	Token string `toml:"token,omitempty"` // token to clone private registries with, which may be encrypted, see EncryptSecrets
+}
+
+// Proxy is the proxy of the build containers, used instead of the proxy environment variables when set. Its URLs may
+// hold credentials, and be encrypted, see EncryptSecrets.
+type Proxy struct {
+	HTTPProxy  string `toml:"http-proxy,omitempty"`
+	HTTPSProxy string `toml:"https-proxy,omitempty"`
+	NoProxy    string `toml:"no-proxy,omitempty"`
 }
 
 type RunImage struct {
@@ -40,13 +66,53 @@ type TrustedBuilder struct {
 	Name string `toml:"name"`
 }
 
+// LogExport ships the logs of the commands to an OTLP or syslog endpoint, in addition to writing them, such as for the
+// builds run on developer machines to be audited centrally
+type LogExport struct {
+	Protocol string            `toml:"protocol,omitempty"` // otlp, or syslog
+	Endpoint string            `toml:"endpoint,omitempty"` // URL of the OTLP/HTTP collector, or address of the syslog server, such as udp://host:514 or tcp://host:601
+	Headers  map[string]string `toml:"headers,omitempty"`  // headers of the requests to the OTLP collector, such as for authentication
+}
+
+// SuggestedBuilder is a builder surfaced by `pack builder suggest` in addition to the default suggestions
+type SuggestedBuilder struct {
+	Image       string `toml:"image"`
+	Vendor      string `toml:"vendor,omitempty"`
+	Description string `toml:"description,omitempty"`
+}
+
+// SuggestedBuildersSource is a remote, signed metadata document listing suggested builders
+type SuggestedBuildersSource struct {
+	URL       string `toml:"url,omitempty"`
+	PublicKey string `toml:"public-key,omitempty"`
+}
+
+// TrustPolicy requires images pulled from the listed registries to be signed by a trusted signer
+type TrustPolicy struct {
+	Enforce    bool                  `toml:"enforce,omitempty"`
+	Registries []TrustPolicyRegistry `toml:"registries,omitempty"`
+}
+
+// TrustPolicyRegistry lists the PEM encoded public keys and certificate identities trusted for images under Prefix
+type TrustPolicyRegistry struct {
+	Prefix           string                `toml:"prefix"`
+	PublicKeys       []string              `toml:"public-keys,omitempty"`
+	Identities       []TrustPolicyIdentity `toml:"identities,omitempty"`
+	RootCertificates string                `toml:"root-certificates,omitempty"`
+}
+
+type TrustPolicyIdentity struct {
+	Issuer  string `toml:"issuer"`
+	Subject string `toml:"subject"`
+}
+
 const OfficialRegistryName = "official"
 
 func DefaultRegistry() Registry {
 	return Registry{
-		OfficialRegistryName,
-		"github",
-		"https://github.com/buildpacks/registry-index",
+		Name: OfficialRegistryName,
+		Type: "github",
+		URL:  "https://github.com/buildpacks/registry-index",
 	}
 }
 
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DescriptorFormat returns the format of a descriptor file based on its extension, either "yaml" or "toml"
+func DescriptorFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "toml"
+	}
+}
+
+// DecodeDescriptorFile decodes a TOML or YAML descriptor file into v. YAML files are converted to TOML first, so
+// the same field tags and undecoded key checks apply to both formats.
+//
+// Variables of the form ${NAME} are substituted before decoding, using vars and then the environment. The names of
+// any variables that could not be resolved are returned, and those variables are left as-is.
+func DecodeDescriptorFile(path string, vars map[string]string, v interface{}) (md toml.MetaData, unresolved []string, err error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return toml.MetaData{}, nil, err
+	}
+
+	contents, unresolved = SubstituteVariables(contents, vars)
+
+	if DescriptorFormat(path) == "yaml" {
+		if contents, err = YAMLToTOML(contents); err != nil {
+			return toml.MetaData{}, nil, err
+		}
+	}
+
+	md, err = toml.Decode(string(contents), v)
+	return md, unresolved, err
+}
+
+var variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// SubstituteVariables replaces ${NAME} references in contents with the value of NAME from vars or, if absent, from
+// the environment. A reference may be escaped as $${NAME} to keep it literally. The names of variables that could not
+// be resolved are returned, and those references are left unchanged.
+func SubstituteVariables(contents []byte, vars map[string]string) ([]byte, []string) {
+	var unresolved []string
+	seen := map[string]bool{}
+
+	result := variablePattern.ReplaceAllFunc(contents, func(match []byte) []byte {
+		if bytes.HasPrefix(match, []byte("$$")) {
+			return match[1:]
+		}
+
+		name := string(variablePattern.FindSubmatch(match)[1])
+		if value, ok := vars[name]; ok {
+			return []byte(value)
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			return []byte(value)
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			unresolved = append(unresolved, name)
+		}
+		return match
+	})
+	return result, unresolved
+}
+
+// YAMLToTOML converts a YAML document into an equivalent TOML document
+func YAMLToTOML(contents []byte) ([]byte, error) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(dropNulls(doc)); err != nil {
+		return nil, errors.Wrap(err, "converting yaml to toml")
+	}
+	return buf.Bytes(), nil
+}
+
+// dropNulls removes null values, which TOML cannot represent, so that they are treated like omitted keys
+func dropNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			if elem == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = dropNulls(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = dropNulls(elem)
+		}
+	}
+	return value
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Names of the experimental features, which can be enabled on their own with `pack config experimental enable <name>`
+const (
+	FeatureBuildpackRegistry = "buildpack-registry"
+	FeatureExtensions        = "extensions"
+	FeatureFlatten           = "flatten"
+	FeatureInteractive       = "interactive"
+	FeatureKeepAlive         = "keep-alive"
+	FeatureManifest          = "manifest"
+	FeatureOCILayout         = "oci-layout"
+	FeatureProjectMetadata   = "project-metadata"
+	FeatureWasi              = "wasi"
+	FeatureWindows           = "windows"
+)
+
+// ExperimentalFeature is a feature of pack that must be enabled to be used, either on its own or along with every
+// other experimental feature by `experimental = true`
+type ExperimentalFeature struct {
+	Name        string
+	Description string
+
+	// Version of pack from which the feature is no longer experimental, and doesn't need to be enabled anymore
+	Graduated string
+
+	// Version of pack in which the feature is going to be removed
+	Removal string
+}
+
+// ExperimentalFeatures is a list of experimental features
+type ExperimentalFeatures []ExperimentalFeature
+
+// KnownExperimentalFeatures are the experimental features of pack
+var KnownExperimentalFeatures = ExperimentalFeatures{
+	{Name: FeatureBuildpackRegistry, Description: "Buildpack registries, and the commands to manage them"},
+	{Name: FeatureExtensions, Description: "Image extensions in builders"},
+	{Name: FeatureFlatten, Description: "Flattening the buildpacks of a buildpackage into fewer layers"},
+	{Name: FeatureInteractive, Description: "The interactive mode of builds"},
+	{Name: FeatureKeepAlive, Description: "Keeping the build containers alive after a build"},
+	{Name: FeatureManifest, Description: "The commands to manage image indexes"},
+	{Name: FeatureOCILayout, Description: "Building images to, and from, OCI layout directories"},
+	{Name: FeatureProjectMetadata, Description: "Adding the source of the project to the metadata of app images"},
+	{Name: FeatureWasi, Description: "WASI buildpackages and builders"},
+	{Name: FeatureWindows, Description: "Windows buildpackages, extensions and builders"},
+}
+
+// Find returns the experimental feature of the name
+func (f ExperimentalFeatures) Find(name string) (ExperimentalFeature, bool) {
+	for _, feature := range f {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return ExperimentalFeature{}, false
+}
+
+// Warnings returns a warning for each of the enabled features that is unknown, no longer experimental, or going to be
+// removed
+func (f ExperimentalFeatures) Warnings(enabled []string) []string {
+	var warnings []string
+	for _, name := range enabled {
+		feature, ok := f.Find(name)
+		switch {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("Unknown experimental feature %s is enabled in the config", style.Symbol(name)))
+		case feature.Graduated != "":
+			warnings = append(warnings, fmt.Sprintf("Experimental feature %s is no longer experimental since pack %s, and doesn't need to be enabled anymore", style.Symbol(name), feature.Graduated))
+		case feature.Removal != "":
+			warnings = append(warnings, fmt.Sprintf("Experimental feature %s is deprecated, and is going to be removed in pack %s", style.Symbol(name), feature.Removal))
+		}
+	}
+	return warnings
+}
+
+// ExperimentalEnabled returns true when the experimental feature is enabled, on its own or by `experimental = true`
+func (c Config) ExperimentalEnabled(feature string) bool {
+	if c.Experimental {
+		return true
+	}
+	for _, enabled := range c.ExperimentalFeatures {
+		if enabled == feature {
+			return true
+		}
+	}
+	return false
+}
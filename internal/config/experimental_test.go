@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExperimental(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "experimental", testExperimental, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExperimental(t *testing.T, when spec.G, it spec.S) {
+	when("#ExperimentalEnabled", func() {
+		it("enables every feature with experimental = true", func() {
+			cfg := config.Config{Experimental: true}
+			h.AssertTrue(t, cfg.ExperimentalEnabled(config.FeatureWindows))
+			h.AssertTrue(t, cfg.ExperimentalEnabled(config.FeatureOCILayout))
+		})
+
+		it("enables only the experimental features of the config", func() {
+			cfg := config.Config{ExperimentalFeatures: []string{config.FeatureWindows}}
+			h.AssertTrue(t, cfg.ExperimentalEnabled(config.FeatureWindows))
+			h.AssertFalse(t, cfg.ExperimentalEnabled(config.FeatureOCILayout))
+		})
+
+		it("enables nothing by default", func() {
+			h.AssertFalse(t, config.Config{}.ExperimentalEnabled(config.FeatureWindows))
+		})
+	})
+
+	when("ExperimentalFeatures", func() {
+		var features = config.ExperimentalFeatures{
+			{Name: "some-feature", Description: "Some feature"},
+			{Name: "graduated-feature", Graduated: "0.40.0"},
+			{Name: "deprecated-feature", Removal: "1.0.0"},
+		}
+
+		when("#Find", func() {
+			it("finds features by name", func() {
+				feature, ok := features.Find("deprecated-feature")
+				h.AssertTrue(t, ok)
+				h.AssertEq(t, feature.Removal, "1.0.0")
+
+				_, ok = features.Find("other-feature")
+				h.AssertFalse(t, ok)
+			})
+		})
+
+		when("#Warnings", func() {
+			it("warns about unknown, graduated and deprecated features", func() {
+				h.AssertEq(t, features.Warnings([]string{"some-feature", "other-feature", "graduated-feature", "deprecated-feature"}), []string{
+					"Unknown experimental feature 'other-feature' is enabled in the config",
+					"Experimental feature 'graduated-feature' is no longer experimental since pack 0.40.0, and doesn't need to be enabled anymore",
+					"Experimental feature 'deprecated-feature' is deprecated, and is going to be removed in pack 1.0.0",
+				})
+			})
+
+			it("doesn't warn about known features", func() {
+				h.AssertEq(t, len(config.KnownExperimentalFeatures.Warnings([]string{config.FeatureWasi, config.FeatureManifest})), 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SchemaVersion is the latest version of the schema of the config file. Config files of older versions are upgraded
+// to it by Migrate.
+const SchemaVersion = 1
+
+// migrations upgrade a config from the schema version of their index to the next one, returning what they changed
+var migrations = []func(cfg *Config) []string{
+	migrateDefaultRegistryURL,
+}
+
+// ReadStrict reads the config file at the path like Read, but fails when the file has keys the schema doesn't define,
+// or a schema version newer than SchemaVersion.
+func ReadStrict(path string) (Config, error) {
+	cfg := Config{}
+	md, err := toml.DecodeFile(path, &cfg)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return Config{}, errors.Wrapf(err, "failed to read config file at path %s", path)
+	}
+
+	if cfg.SchemaVersion > SchemaVersion {
+		return Config{}, errors.Errorf("config file at path %s has schema version %d, but this version of pack supports up to %d", path, cfg.SchemaVersion, SchemaVersion)
+	}
+	var undecoded []toml.Key
+	for _, key := range md.Undecoded() {
+		// tables in the defaults of commands are values of flags, which the defaults are checked against when applied
+		if key[0] != "defaults" {
+			undecoded = append(undecoded, key)
+		}
+	}
+	if len(undecoded) > 0 {
+		return Config{}, errors.Errorf("%s in config file at path %s", FormatUndecodedKeys(undecoded), path)
+	}
+	return cfg, nil
+}
+
+// Migrate upgrades the config to SchemaVersion, returning what it changed
+func Migrate(cfg Config) (Config, []string) {
+	var changes []string
+	for ; cfg.SchemaVersion < SchemaVersion; cfg.SchemaVersion++ {
+		changes = append(changes, migrations[cfg.SchemaVersion](&cfg)...)
+	}
+	return cfg, changes
+}
+
+// migrateDefaultRegistryURL replaces the deprecated default registry URL with a registry set as the default one
+func migrateDefaultRegistryURL(cfg *Config) []string {
+	//nolint:staticcheck
+	url := cfg.DefaultRegistry
+	if url == "" {
+		return nil
+	}
+
+	//nolint:staticcheck
+	cfg.DefaultRegistry = ""
+	if cfg.DefaultRegistryName != "" {
+		return []string{fmt.Sprintf("removed %s, as %s is set", style.Symbol("default-registry-url"), style.Symbol("default-registry"))}
+	}
+
+	registry := Registry{Name: "default", Type: "github", URL: url}
+	cfg.Registries = append(cfg.Registries, registry)
+	cfg.DefaultRegistryName = registry.Name
+	return []string{fmt.Sprintf("replaced %s with registry %s, set as %s", style.Symbol("default-registry-url"), style.Symbol(registry.Name), style.Symbol("default-registry"))}
+}
@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSchema(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "schema", testSchema, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSchema(t *testing.T, when spec.G, it spec.S) {
+	var configPath string
+
+	it.Before(func() {
+		configPath = filepath.Join(t.TempDir(), "config.toml")
+	})
+
+	when("#ReadStrict", func() {
+		it("reads a valid config", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("schema-version = 1\npull-policy = \"never\"\n"), 0600))
+
+			cfg, err := config.ReadStrict(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.PullPolicy, "never")
+			h.AssertEq(t, cfg.SchemaVersion, 1)
+		})
+
+		it("reads the defaults of commands", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte(`
+[defaults.build]
+trust-builder = true
+pull-policy = "if-not-present"
+
+[defaults."builder create"]
+label = { team = "some-team" }
+`), 0600))
+
+			cfg, err := config.ReadStrict(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.Defaults, config.Defaults{
+				"build":          {"trust-builder": true, "pull-policy": "if-not-present"},
+				"builder create": {"label": map[string]interface{}{"team": "some-team"}},
+			})
+		})
+
+		it("returns an empty config when there is no config on disk", func() {
+			cfg, err := config.ReadStrict(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg, config.Config{})
+		})
+
+		it("lists unknown keys", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("pull-polcy = \"never\"\n"), 0600))
+
+			_, err := config.ReadStrict(configPath)
+			h.AssertError(t, err, "unknown configuration element 'pull-polcy' in config file at path "+configPath)
+		})
+
+		it("fails on newer schema versions", func() {
+			h.AssertNil(t, os.WriteFile(configPath, []byte("schema-version = 99\n"), 0600))
+
+			_, err := config.ReadStrict(configPath)
+			h.AssertError(t, err, "has schema version 99, but this version of pack supports up to 1")
+		})
+	})
+
+	when("#Migrate", func() {
+		it("replaces the default registry URL with a default registry", func() {
+			//nolint:staticcheck
+			migrated, changes := config.Migrate(config.Config{DefaultRegistry: "https://example.com/registry-index"})
+
+			h.AssertEq(t, migrated, config.Config{
+				DefaultRegistryName: "default",
+				Registries:          []config.Registry{{Name: "default", Type: "github", URL: "https://example.com/registry-index"}},
+				SchemaVersion:       config.SchemaVersion,
+			})
+			h.AssertEq(t, changes, []string{"replaced 'default-registry-url' with registry 'default', set as 'default-registry'"})
+		})
+
+		it("removes the default registry URL when a default registry is set", func() {
+			//nolint:staticcheck
+			migrated, changes := config.Migrate(config.Config{DefaultRegistry: "https://example.com/registry-index", DefaultRegistryName: "some-registry"})
+
+			h.AssertEq(t, migrated, config.Config{DefaultRegistryName: "some-registry", SchemaVersion: config.SchemaVersion})
+			h.AssertEq(t, changes, []string{"removed 'default-registry-url', as 'default-registry' is set"})
+		})
+
+		it("doesn't change configs of the latest schema version", func() {
+			cfg := config.Config{PullPolicy: "never", SchemaVersion: config.SchemaVersion}
+
+			migrated, changes := config.Migrate(cfg)
+			h.AssertEq(t, migrated, cfg)
+			h.AssertEq(t, len(changes), 0)
+		})
+	})
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// encryptedPrefix marks the values of the config file encrypted with the key of the keyring
+const encryptedPrefix = "encrypted:"
+
+// keyringServerURL is the server URL the key is stored under by the credential helper
+const keyringServerURL = "https://buildpacks.io/pack/config-encryption-key"
+
+// Keyring holds the key sensitive values of the config file are encrypted with
+type Keyring interface {
+	// Key returns the key, creating it when create is set and there's none yet.
+	Key(create bool) ([]byte, error)
+}
+
+// DefaultKeyringHelper returns the docker credential helper keeping the key in the keychain of the OS
+func DefaultKeyringHelper() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "osxkeychain"
+	case "windows":
+		return "wincred"
+	default:
+		return "secretservice"
+	}
+}
+
+// NewKeyring returns the keyring keeping the key with the docker credential helper, such as osxkeychain, wincred,
+// secretservice or pass, or the one of the OS when helper is empty
+func NewKeyring(helper string) Keyring {
+	if helper == "" {
+		helper = DefaultKeyringHelper()
+	}
+	return NewKeyringWithProgram(helper, client.NewShellProgramFunc("docker-credential-"+helper))
+}
+
+// NewKeyringWithProgram returns the keyring keeping the key with the program of a docker credential helper
+func NewKeyringWithProgram(helper string, program client.ProgramFunc) Keyring {
+	return &credentialHelperKeyring{helper: helper, program: program}
+}
+
+type credentialHelperKeyring struct {
+	helper  string
+	program client.ProgramFunc
+}
+
+func (k *credentialHelperKeyring) Key(create bool) ([]byte, error) {
+	creds, err := client.Get(k.program, keyringServerURL)
+	switch {
+	case err == nil:
+		key, err := base64.StdEncoding.DecodeString(creds.Secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding config encryption key of keyring %s", style.Symbol(k.helper))
+		}
+		return key, nil
+	case !credentials.IsErrCredentialsNotFound(err):
+		return nil, errors.Wrapf(err, "reading config encryption key from keyring %s", style.Symbol(k.helper))
+	case !create:
+		return nil, errors.Errorf("keyring %s has no config encryption key", style.Symbol(k.helper))
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "generating config encryption key")
+	}
+	if err := client.Store(k.program, &credentials.Credentials{
+		ServerURL: keyringServerURL,
+		Username:  "pack",
+		Secret:    base64.StdEncoding.EncodeToString(key),
+	}); err != nil {
+		return nil, errors.Wrapf(err, "storing config encryption key in keyring %s", style.Symbol(k.helper))
+	}
+	return key, nil
+}
+
+// IsEncrypted returns whether the value of the config file is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt encrypts the value with the key of the keyring, creating the key if there's none yet
+func Encrypt(keyring Keyring, value string) (string, error) {
+	if value == "" || IsEncrypted(value) {
+		return value, nil
+	}
+
+	gcm, err := keyringCipher(keyring, true)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value with the key of the keyring, returning values that aren't encrypted as they are
+func Decrypt(keyring Keyring, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "decoding encrypted value")
+	}
+
+	gcm, err := keyringCipher(keyring, false)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.Wrap(err, "decrypting value, it may have been encrypted with another key")
+	}
+	return string(plain), nil
+}
+
+// KeyringCipher encrypts and decrypts values with the key of the keyring, such as the passwords of the credentials
+// stored by pack login
+type KeyringCipher struct {
+	Keyring Keyring
+}
+
+// Encrypt encrypts the value, see Encrypt
+func (c KeyringCipher) Encrypt(value string) (string, error) {
+	return Encrypt(c.Keyring, value)
+}
+
+// Decrypt decrypts the value, see Decrypt
+func (c KeyringCipher) Decrypt(value string) (string, error) {
+	return Decrypt(c.Keyring, value)
+}
+
+func keyringCipher(keyring Keyring, create bool) (cipher.AEAD, error) {
+	key, err := keyring.Key(create)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptSecrets returns the config with its sensitive values encrypted with the key of the keyring: the tokens of the
+// registries, the proxies, which may hold credentials, and the headers of the log export
+func EncryptSecrets(cfg Config, keyring Keyring) (Config, error) {
+	return transformSecrets(cfg, func(value string) (string, error) {
+		return Encrypt(keyring, value)
+	})
+}
+
+// DecryptSecrets returns the config with its sensitive values decrypted with the key of the keyring, see EncryptSecrets
+func DecryptSecrets(cfg Config, keyring Keyring) (Config, error) {
+	return transformSecrets(cfg, func(value string) (string, error) {
+		return Decrypt(keyring, value)
+	})
+}
+
+// transformSecrets returns the config with the transformation applied to its sensitive values, leaving the config
+// it's given as it is
+func transformSecrets(cfg Config, transform func(string) (string, error)) (Config, error) {
+	var err error
+
+	registries := make([]Registry, len(cfg.Registries))
+	copy(registries, cfg.Registries)
+	for i := range registries {
+		if registries[i].Token, err = transform(registries[i].Token); err != nil {
+			return Config{}, errors.Wrapf(err, "token of registry %s", style.Symbol(registries[i].Name))
+		}
+	}
+	if cfg.Registries != nil {
+		cfg.Registries = registries
+	}
+
+	if cfg.Proxy.HTTPProxy, err = transform(cfg.Proxy.HTTPProxy); err != nil {
+		return Config{}, errors.Wrapf(err, "%s", style.Symbol("proxy.http-proxy"))
+	}
+	if cfg.Proxy.HTTPSProxy, err = transform(cfg.Proxy.HTTPSProxy); err != nil {
+		return Config{}, errors.Wrapf(err, "%s", style.Symbol("proxy.https-proxy"))
+	}
+
+	if cfg.LogExport.Headers != nil {
+		headers := map[string]string{}
+		for k, v := range cfg.LogExport.Headers {
+			if headers[k], err = transform(v); err != nil {
+				return Config{}, errors.Wrapf(err, "header %s of %s", style.Symbol(k), style.Symbol("log-export"))
+			}
+		}
+		cfg.LogExport.Headers = headers
+	}
+	return cfg, nil
+}
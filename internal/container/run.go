@@ -1,6 +1,7 @@
 package container
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -11,6 +12,22 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ExitError is returned when a container exits with a non-zero status code
+type ExitError struct {
+	Code int64
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("failed with status code: %d", e.Code)
+}
+
+// Result is the output and status code of a container run with Run
+type Result struct {
+	ExitCode int64
+	Stdout   []byte
+	Stderr   []byte
+}
+
 type Handler func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error
 
 type DockerClient interface {
@@ -19,6 +36,12 @@ type DockerClient interface {
 	ContainerStart(ctx context.Context, container string, options dcontainer.StartOptions) error
 }
 
+// Killer is implemented by docker clients able to kill containers, which are then killed as soon as the context
+// they run with is canceled, rather than only once they are removed.
+type Killer interface {
+	ContainerKill(ctx context.Context, container, signal string) error
+}
+
 func ContainerWaitWrapper(ctx context.Context, docker DockerClient, container string, condition dcontainer.WaitCondition) (<-chan dcontainer.WaitResponse, <-chan error) {
 	bodyChan := make(chan dcontainer.WaitResponse)
 	errChan := make(chan error)
@@ -60,12 +83,41 @@ func RunWithHandler(ctx context.Context, docker DockerClient, ctrID string, hand
 		return errors.Wrap(err, "container start")
 	}
 
-	return handler(bodyChan, errChan, resp.Reader)
+	if killer, ok := docker.(Killer); ok {
+		stop := context.AfterFunc(ctx, func() {
+			// the context is canceled already
+			_ = killer.ContainerKill(context.Background(), ctrID, "KILL")
+		})
+		defer stop()
+	}
+
+	if err := handler(bodyChan, errChan, resp.Reader); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrap(ctx.Err(), "container was stopped")
+		}
+		return err
+	}
+	return nil
+}
+
+// Run runs a container until it exits, and returns its output. When it exits with a non-zero status code, its output
+// is returned along with an *ExitError.
+func Run(ctx context.Context, docker DockerClient, ctrID string) (Result, error) {
+	var stdout, stderr bytes.Buffer
+	err := RunWithHandler(ctx, docker, ctrID, DefaultHandler(&stdout, &stderr))
+
+	result := Result{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.Code
+	}
+	return result, err
 }
 
 func DefaultHandler(out, errOut io.Writer) Handler {
 	return func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error {
-		copyErr := make(chan error)
+		// buffered so that the copy doesn't block once the handler returned early
+		copyErr := make(chan error, 1)
 		go func() {
 			_, err := stdcopy.StdCopy(out, errOut, reader)
 			defer optionallyCloseWriter(out)
@@ -77,7 +129,9 @@ func DefaultHandler(out, errOut io.Writer) Handler {
 		select {
 		case body := <-bodyChan:
 			if body.StatusCode != 0 {
-				return fmt.Errorf("failed with status code: %d", body.StatusCode)
+				// the output is kept, as it usually tells why the container failed
+				<-copyErr
+				return &ExitError{Code: body.StatusCode}
 			}
 		case err := <-errChan:
 			return err
@@ -2,8 +2,10 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/pkg/dist"
@@ -15,12 +17,17 @@ type FetchArgs struct {
 	PullPolicy   image.PullPolicy
 	LayoutOption image.LayoutOption
 	Target       *dist.Target
+
+	InsecureRegistries []string
+	Keychain           authn.Keychain
 }
 
 type FakeImageFetcher struct {
 	LocalImages  map[string]imgutil.Image
 	RemoteImages map[string]imgutil.Image
 	FetchCalls   map[string]*FetchArgs
+
+	mu sync.Mutex
 }
 
 func NewFakeImageFetcher() *FakeImageFetcher {
@@ -32,7 +39,10 @@ func NewFakeImageFetcher() *FakeImageFetcher {
 }
 
 func (f *FakeImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
-	f.FetchCalls[name] = &FetchArgs{Daemon: options.Daemon, PullPolicy: options.PullPolicy, Target: options.Target, LayoutOption: options.LayoutOption}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.FetchCalls[name] = &FetchArgs{Daemon: options.Daemon, PullPolicy: options.PullPolicy, Target: options.Target, LayoutOption: options.LayoutOption, InsecureRegistries: options.InsecureRegistries, Keychain: options.Keychain}
 
 	ri, remoteFound := f.RemoteImages[name]
 
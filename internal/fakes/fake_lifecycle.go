@@ -2,15 +2,21 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"github.com/buildpacks/pack/internal/build"
 )
 
 type FakeLifecycle struct {
 	Opts build.LifecycleOptions
+
+	mu sync.Mutex
 }
 
 func (f *FakeLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.Opts = opts
 	return nil
 }
@@ -0,0 +1,17 @@
+package git
+
+import (
+	"context"
+	"net/url"
+)
+
+var Auth = auth
+
+// SetCredentialFill replaces the lookup of the credential helpers of git, returning a function restoring it
+func SetCredentialFill(fill func(context.Context, *url.URL) (string, string, error)) func() {
+	original := credentialFill
+	credentialFill = fill
+	return func() {
+		credentialFill = original
+	}
+}
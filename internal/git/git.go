@@ -0,0 +1,273 @@
+// Package git clones the git repositories of buildpacks and app sources.
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+
+// Repository is a git repository, checked out at the branch, tag or commit of Ref, or else at its default branch
+type Repository struct {
+	URL string
+	Ref string
+}
+
+// ParseURI parses the URI of a git repository, with the branch, tag or commit to check out after a #, like
+// git://github.com/org/bp#v1.2.3. A git+ prefix of the scheme, as in git+https://github.com/org/bp, is dropped to
+// clone over the transport after it.
+func ParseURI(uri string) (Repository, error) {
+	repoURL, ref, _ := strings.Cut(uri, "#")
+	if scheme, rest, ok := strings.Cut(repoURL, "://"); ok && strings.HasPrefix(scheme, "git+") {
+		repoURL = strings.TrimPrefix(scheme, "git+") + "://" + rest
+	}
+
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return Repository{}, errors.Wrapf(err, "parsing git URI %s", style.Symbol(uri))
+	}
+	if parsed.Scheme == "" || (parsed.Host == "" && parsed.Scheme != "file") {
+		return Repository{}, errors.Errorf("invalid git URI %s, must be in the form <scheme>://<host>/<repository>[#<ref>]", style.Symbol(uri))
+	}
+	return Repository{URL: repoURL, Ref: ref}, nil
+}
+
+// String returns the URI of the repository, without the password of its URL
+func (r Repository) String() string {
+	uri := r.URL
+	if parsed, err := url.Parse(r.URL); err == nil {
+		uri = parsed.Redacted()
+	}
+	if r.Ref != "" {
+		uri += "#" + r.Ref
+	}
+	return uri
+}
+
+// Resolve returns the commit the repository is at, as given by the remote without cloning it, or an empty string when
+// the ref is an abbreviated commit, which can only be told once cloned
+func Resolve(ctx context.Context, repo Repository) (string, error) {
+	if len(repo.Ref) == 40 && commitRegexp.MatchString(repo.Ref) {
+		return repo.Ref, nil
+	}
+
+	ref, err := remoteRef(ctx, repo, auth(ctx, repo.URL))
+	if err != nil || ref == nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
+
+// Clone clones the repository to the directory, shallowly unless its ref is a commit, with its submodules, and returns
+// the commit it's checked out at. Credentials are those of the URL, or else those the credential helpers of git have
+// for it, while SSH URLs are authenticated by the SSH agent.
+func Clone(ctx context.Context, repo Repository, dir string) (string, error) {
+	repoAuth := auth(ctx, repo.URL)
+	opts := &gogit.CloneOptions{URL: repo.URL, Auth: repoAuth}
+
+	ref, err := remoteRef(ctx, repo, repoAuth)
+	if err != nil {
+		return "", err
+	}
+	if repo.Ref == "" || ref != nil {
+		// branches and tags are cloned alone, with their last commit only
+		opts.Depth = 1
+		opts.SingleBranch = true
+		if ref != nil {
+			opts.ReferenceName = ref.Name()
+		}
+	} else if !commitRegexp.MatchString(repo.Ref) {
+		return "", errors.Errorf("%s has no branch, tag or commit %s", style.Symbol(repo.URL), style.Symbol(repo.Ref))
+	}
+
+	cloned, err := gogit.PlainCloneContext(ctx, dir, false, opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "cloning %s", style.Symbol(repo.String()))
+	}
+
+	w, err := cloned.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if ref == nil && repo.Ref != "" {
+		hash, err := cloned.ResolveRevision(plumbing.Revision(repo.Ref))
+		if err != nil {
+			return "", errors.Errorf("%s has no branch, tag or commit %s", style.Symbol(repo.URL), style.Symbol(repo.Ref))
+		}
+		if err := w.Checkout(&gogit.CheckoutOptions{Hash: *hash}); err != nil {
+			return "", errors.Wrapf(err, "checking out %s", style.Symbol(repo.Ref))
+		}
+	}
+
+	if err := updateSubmodules(ctx, w); err != nil {
+		return "", err
+	}
+
+	head, err := cloned.Head()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading the commit of %s", style.Symbol(repo.String()))
+	}
+	return head.Hash().String(), nil
+}
+
+// RemoveMetadata removes the git metadata of a clone, and of its submodules, leaving the files of its worktree
+func RemoveMetadata(dir string) error {
+	var gitPaths []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Name() != ".git" {
+			return nil
+		}
+		gitPaths = append(gitPaths, path)
+		if entry.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "reading clone %s", style.Symbol(dir))
+	}
+
+	for _, gitPath := range gitPaths {
+		if err := os.RemoveAll(gitPath); err != nil {
+			return errors.Wrapf(err, "removing git metadata %s", style.Symbol(gitPath))
+		}
+	}
+	return nil
+}
+
+// remoteRef returns the branch or tag of the remote the ref is, its default branch when there's no ref, or nil when
+// the ref is neither, such as when it's a commit
+func remoteRef(ctx context.Context, repo Repository, repoAuth transport.AuthMethod) (*plumbing.Reference, error) {
+	remote := gogit.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: gogit.DefaultRemoteName,
+		URLs: []string{repo.URL},
+	})
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: repoAuth})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the refs of %s", style.Symbol(repo.String()))
+	}
+
+	names := []plumbing.ReferenceName{plumbing.HEAD}
+	if repo.Ref != "" {
+		names = []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName(repo.Ref),
+			plumbing.NewTagReferenceName(repo.Ref),
+			plumbing.ReferenceName(repo.Ref),
+		}
+	}
+	for _, name := range names {
+		if ref := findRef(refs, name); ref != nil {
+			return ref, nil
+		}
+	}
+	return nil, nil
+}
+
+// findRef returns the ref of the name, with the hash of its target when it's symbolic like HEAD
+func findRef(refs []*plumbing.Reference, name plumbing.ReferenceName) *plumbing.Reference {
+	for _, ref := range refs {
+		if ref.Name() != name {
+			continue
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			if target := findRef(refs, ref.Target()); target != nil {
+				return plumbing.NewHashReference(name, target.Hash())
+			}
+			return nil
+		}
+		return ref
+	}
+	return nil
+}
+
+// updateSubmodules checks out the submodules of the worktree, and theirs in turn, at the commits it records for them
+func updateSubmodules(ctx context.Context, w *gogit.Worktree) error {
+	submodules, err := w.Submodules()
+	if err != nil {
+		return errors.Wrap(err, "reading submodules")
+	}
+
+	for _, submodule := range submodules {
+		err := submodule.UpdateContext(ctx, &gogit.SubmoduleUpdateOptions{
+			Init:              true,
+			RecurseSubmodules: gogit.DefaultSubmoduleRecursionDepth,
+			Auth:              auth(ctx, submodule.Config().URL),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "updating submodule %s", style.Symbol(submodule.Config().Path))
+		}
+	}
+	return nil
+}
+
+// auth returns the credentials for the URL of a repository over HTTP, which are those of its user info, or else those
+// the credential helpers of git have for it. Repositories are read anonymously when there are none.
+func auth(ctx context.Context, repoURL string) transport.AuthMethod {
+	parsed, err := url.Parse(repoURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil
+	}
+
+	if password, ok := parsed.User.Password(); ok {
+		return &githttp.BasicAuth{Username: parsed.User.Username(), Password: password}
+	}
+
+	username, password, err := credentialFill(ctx, parsed)
+	if err != nil || password == "" {
+		return nil
+	}
+	return &githttp.BasicAuth{Username: username, Password: password}
+}
+
+// credentialFill returns the username and password the credential helpers of git have for the URL, without
+// prompting for them
+var credentialFill = func(ctx context.Context, repoURL *url.URL) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf(
+		"protocol=%s\nhost=%s\npath=%s\n\n",
+		repoURL.Scheme, repoURL.Host, strings.TrimPrefix(repoURL.Path, "/"),
+	))
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	var username, password string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+	return username, password, nil
+}
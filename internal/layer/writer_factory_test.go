@@ -20,7 +20,7 @@ func testWriterFactory(t *testing.T, when spec.G, it spec.S) {
 	when("#NewWriterFactory", func() {
 		it("returns an error for invalid image OS", func() {
 			_, err := layer.NewWriterFactory("not-an-os")
-			h.AssertError(t, err, "provided image OS 'not-an-os' must be either 'linux' or 'windows'")
+			h.AssertError(t, err, "provided image OS 'not-an-os' must be one of 'linux', 'windows' or 'wasi'")
 		})
 	})
 
@@ -35,6 +35,16 @@ func testWriterFactory(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 
+		it("returns a regular tar writer for WASI", func() {
+			factory, err := layer.NewWriterFactory("wasi")
+			h.AssertNil(t, err)
+
+			_, ok := factory.NewWriter(nil).(*tar.Writer)
+			if !ok {
+				t.Fatal("returned writer was not a regular tar writer")
+			}
+		})
+
 		it("returns a Windows layer writer for Windows", func() {
 			factory, err := layer.NewWriterFactory("windows")
 			h.AssertNil(t, err)
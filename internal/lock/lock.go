@@ -0,0 +1,76 @@
+// Package lock provides advisory locks on files, for processes to take turns using the state they share, such as the
+// cache volumes of the builds of an app.
+package lock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrLocked is returned by TryAcquire when the lock is held already, by another process or within this one
+var ErrLocked = errors.New("lock is held already")
+
+// pollInterval is the interval Acquire tries to acquire a lock held already at
+const pollInterval = 250 * time.Millisecond
+
+// Lock is an advisory lock on a file, released by Release or when the process exits
+type Lock struct {
+	file *os.File
+}
+
+// TryAcquire acquires the lock on the file of the path, creating it as needed, or returns ErrLocked at once when the
+// lock is held already
+func TryAcquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, errors.Wrap(err, "creating lock directory")
+	}
+
+	file, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening lock file")
+	}
+
+	locked, err := tryLock(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "locking %s", path)
+	}
+	if !locked {
+		file.Close()
+		return nil, ErrLocked
+	}
+	return &Lock{file: file}, nil
+}
+
+// Acquire acquires the lock on the file of the path, waiting for it to be released when it is held already. onWait,
+// when set, is called once before waiting.
+func Acquire(ctx context.Context, path string, onWait func()) (*Lock, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for waited := false; ; waited = true {
+		lock, err := TryAcquire(path)
+		if !errors.Is(err, ErrLocked) {
+			return lock, err
+		}
+		if !waited && onWait != nil {
+			onWait()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Release releases the lock
+func (l *Lock) Release() error {
+	defer l.file.Close()
+	return unlock(l.file)
+}
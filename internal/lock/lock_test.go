@@ -0,0 +1,101 @@
+package lock_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/lock"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLock(t *testing.T) {
+	spec.Run(t, "Lock", testLock, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLock(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir string
+		path   string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "lock")
+		h.AssertNil(t, err)
+		path = filepath.Join(tmpDir, "locks", "some.lock")
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#TryAcquire", func() {
+		it("fails while the lock is held, and succeeds once it's released", func() {
+			held, err := lock.TryAcquire(path)
+			h.AssertNil(t, err)
+
+			_, err = lock.TryAcquire(path)
+			h.AssertTrue(t, err == lock.ErrLocked)
+
+			h.AssertNil(t, held.Release())
+
+			held, err = lock.TryAcquire(path)
+			h.AssertNil(t, err)
+			h.AssertNil(t, held.Release())
+		})
+
+		it("doesn't hold the locks of other files", func() {
+			held, err := lock.TryAcquire(path)
+			h.AssertNil(t, err)
+			defer held.Release()
+
+			other, err := lock.TryAcquire(filepath.Join(tmpDir, "locks", "other.lock"))
+			h.AssertNil(t, err)
+			h.AssertNil(t, other.Release())
+		})
+	})
+
+	when("#Acquire", func() {
+		it("waits for the lock to be released", func() {
+			held, err := lock.TryAcquire(path)
+			h.AssertNil(t, err)
+
+			waiting := make(chan struct{})
+			acquired := make(chan error, 1)
+			go func() {
+				l, err := lock.Acquire(context.Background(), path, func() { close(waiting) })
+				if err == nil {
+					err = l.Release()
+				}
+				acquired <- err
+			}()
+
+			<-waiting
+			h.AssertEq(t, len(acquired), 0)
+
+			h.AssertNil(t, held.Release())
+			select {
+			case err := <-acquired:
+				h.AssertNil(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("lock wasn't acquired once released")
+			}
+		})
+
+		it("stops waiting when the context is done", func() {
+			held, err := lock.TryAcquire(path)
+			h.AssertNil(t, err)
+			defer held.Release()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			_, err = lock.Acquire(ctx, path, cancel)
+			h.AssertTrue(t, err == context.Canceled)
+		})
+	})
+}
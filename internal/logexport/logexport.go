@@ -0,0 +1,79 @@
+// Package logexport ships the logs of the pack CLI to an OTLP or syslog endpoint, as configured in the config file.
+package logexport
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// Protocols logs can be exported with
+const (
+	ProtocolOTLP   = "otlp"
+	ProtocolSyslog = "syslog"
+)
+
+// Source identifies the logs of a run of a command, for the endpoint to tell them apart from the logs of other runs
+type Source struct {
+	Command string // command run, such as 'pack build'
+	Version string // version of pack
+}
+
+// New returns the exporter of the protocol of the config, shipping the logs to its endpoint
+func New(cfg config.LogExport, source Source) (logging.Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.Errorf("%s must be set to export logs", style.Symbol("log-export.endpoint"))
+	}
+
+	hostname, _ := os.Hostname()
+	switch cfg.Protocol {
+	case ProtocolOTLP:
+		return newOTLPExporter(cfg.Endpoint, cfg.Headers, source, hostname), nil
+	case ProtocolSyslog:
+		return newSyslogExporter(cfg.Endpoint, source, hostname)
+	default:
+		return nil, errors.Errorf("invalid log export protocol %s, must be one of %s or %s", style.Symbol(cfg.Protocol), ProtocolOTLP, ProtocolSyslog)
+	}
+}
+
+// buffer holds the records exported until they're shipped, as exporters ship them when they're closed, at the end of
+// the command, rather than delaying the command with a request for each line
+type buffer struct {
+	sync.Mutex
+	records []logging.Record
+}
+
+func (b *buffer) Export(record logging.Record) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.records = append(b.records, record)
+}
+
+// take returns the records buffered, emptying the buffer
+func (b *buffer) take() []logging.Record {
+	b.Lock()
+	defer b.Unlock()
+
+	records := b.records
+	b.records = nil
+	return records
+}
+
+func severityText(level logging.Level) string {
+	switch level {
+	case logging.DebugLevel:
+		return "DEBUG"
+	case logging.WarnLevel:
+		return "WARN"
+	case logging.ErrorLevel:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
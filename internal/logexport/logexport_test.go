@@ -0,0 +1,226 @@
+package logexport_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/logexport"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLogExport(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LogExport", testLogExport, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLogExport(t *testing.T, when spec.G, it spec.S) {
+	var (
+		source     = logexport.Source{Command: "pack build", Version: "1.2.3"}
+		recordTime = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	when("#New", func() {
+		it("errors without an endpoint", func() {
+			_, err := logexport.New(config.LogExport{Protocol: "otlp"}, source)
+			h.AssertError(t, err, "'log-export.endpoint' must be set to export logs")
+		})
+
+		it("errors for unknown protocols", func() {
+			_, err := logexport.New(config.LogExport{Protocol: "gelf", Endpoint: "localhost:12201"}, source)
+			h.AssertError(t, err, "invalid log export protocol 'gelf', must be one of otlp or syslog")
+		})
+
+		it("errors for syslog endpoints that aren't udp or tcp", func() {
+			_, err := logexport.New(config.LogExport{Protocol: "syslog", Endpoint: "https://localhost:514"}, source)
+			h.AssertError(t, err, "the scheme must be one of udp or tcp")
+		})
+	})
+
+	when("otlp", func() {
+		var (
+			server   *httptest.Server
+			requests chan *http.Request
+			bodies   chan []byte
+			status   int
+		)
+
+		it.Before(func() {
+			requests = make(chan *http.Request, 10)
+			bodies = make(chan []byte, 10)
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				requests <- r
+				bodies <- body
+				w.WriteHeader(status)
+			}))
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("posts the records to the logs path of the collector when closed", func() {
+			exporter, err := logexport.New(config.LogExport{
+				Protocol: "otlp",
+				Endpoint: server.URL,
+				Headers:  map[string]string{"Authorization": "Bearer some-token"},
+			}, source)
+			h.AssertNil(t, err)
+
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.InfoLevel, Message: "===> BUILDING"})
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.ErrorLevel, Message: "failed"})
+			h.AssertEq(t, len(requests), 0)
+
+			h.AssertNil(t, exporter.Close())
+
+			req := <-requests
+			h.AssertEq(t, req.URL.Path, "/v1/logs")
+			h.AssertEq(t, req.Header.Get("Content-Type"), "application/json")
+			h.AssertEq(t, req.Header.Get("Authorization"), "Bearer some-token")
+
+			var body struct {
+				ResourceLogs []struct {
+					Resource struct {
+						Attributes []struct {
+							Key   string
+							Value struct{ StringValue string }
+						}
+					}
+					ScopeLogs []struct {
+						LogRecords []struct {
+							TimeUnixNano   string
+							SeverityNumber int
+							SeverityText   string
+							Body           struct{ StringValue string }
+							Attributes     []struct {
+								Key   string
+								Value struct{ StringValue string }
+							}
+						}
+					}
+				}
+			}
+			h.AssertNil(t, json.Unmarshal(<-bodies, &body))
+			h.AssertEq(t, len(body.ResourceLogs), 1)
+			h.AssertEq(t, body.ResourceLogs[0].Resource.Attributes[0].Key, "service.name")
+			h.AssertEq(t, body.ResourceLogs[0].Resource.Attributes[0].Value.StringValue, "pack")
+			h.AssertEq(t, body.ResourceLogs[0].Resource.Attributes[1].Value.StringValue, "1.2.3")
+
+			records := body.ResourceLogs[0].ScopeLogs[0].LogRecords
+			h.AssertEq(t, len(records), 2)
+			h.AssertEq(t, records[0].TimeUnixNano, strconv.FormatInt(recordTime.UnixNano(), 10))
+			h.AssertEq(t, records[0].SeverityNumber, 9)
+			h.AssertEq(t, records[0].SeverityText, "INFO")
+			h.AssertEq(t, records[0].Body.StringValue, "===> BUILDING")
+			h.AssertEq(t, records[0].Attributes[0].Key, "pack.command")
+			h.AssertEq(t, records[0].Attributes[0].Value.StringValue, "pack build")
+			h.AssertEq(t, records[1].SeverityNumber, 17)
+			h.AssertEq(t, records[1].SeverityText, "ERROR")
+		})
+
+		it("keeps the path of the endpoint", func() {
+			exporter, err := logexport.New(config.LogExport{Protocol: "otlp", Endpoint: server.URL + "/custom/logs"}, source)
+			h.AssertNil(t, err)
+
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.InfoLevel, Message: "some line"})
+			h.AssertNil(t, exporter.Close())
+
+			h.AssertEq(t, (<-requests).URL.Path, "/custom/logs")
+		})
+
+		it("doesn't post anything without records", func() {
+			exporter, err := logexport.New(config.LogExport{Protocol: "otlp", Endpoint: server.URL}, source)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, exporter.Close())
+			h.AssertEq(t, len(requests), 0)
+		})
+
+		it("errors when the collector rejects the logs", func() {
+			status = http.StatusUnauthorized
+			exporter, err := logexport.New(config.LogExport{Protocol: "otlp", Endpoint: server.URL}, source)
+			h.AssertNil(t, err)
+
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.InfoLevel, Message: "some line"})
+			h.AssertError(t, exporter.Close(), "401 Unauthorized")
+		})
+	})
+
+	when("syslog", func() {
+		it("sends a message of RFC 5424 for each record over udp", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			h.AssertNil(t, err)
+			defer conn.Close()
+
+			exporter, err := logexport.New(config.LogExport{Protocol: "syslog", Endpoint: "udp://" + conn.LocalAddr().String()}, source)
+			h.AssertNil(t, err)
+
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.WarnLevel, Message: "some warning"})
+			h.AssertNil(t, exporter.Close())
+
+			buf := make([]byte, 1024)
+			h.AssertNil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := conn.ReadFrom(buf)
+			h.AssertNil(t, err)
+
+			message := string(buf[:n])
+			h.AssertTrue(t, strings.HasPrefix(message, "<12>1 2024-05-01T10:00:00Z "))
+			h.AssertContains(t, message, ` pack `)
+			h.AssertTrue(t, strings.HasSuffix(message, ` [pack@32473 command="pack build" version="1.2.3"] some warning`))
+		})
+
+		it("frames the messages with their length over tcp", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			h.AssertNil(t, err)
+			defer listener.Close()
+
+			received := make(chan string, 1)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					received <- err.Error()
+					return
+				}
+				defer conn.Close()
+				data, _ := io.ReadAll(bufio.NewReader(conn))
+				received <- string(data)
+			}()
+
+			exporter, err := logexport.New(config.LogExport{Protocol: "syslog", Endpoint: "tcp://" + listener.Addr().String()}, source)
+			h.AssertNil(t, err)
+
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.InfoLevel, Message: "first"})
+			exporter.Export(logging.Record{Time: recordTime, Level: logging.ErrorLevel, Message: "second"})
+			h.AssertNil(t, exporter.Close())
+
+			data := <-received
+			length, message, ok := strings.Cut(data, " ")
+			h.AssertTrue(t, ok)
+			n, err := strconv.Atoi(length)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, strings.HasPrefix(message[:n], "<14>1 "))
+			h.AssertTrue(t, strings.HasSuffix(message[:n], "] first"))
+
+			_, second, ok := strings.Cut(message[n:], " ")
+			h.AssertTrue(t, ok)
+			h.AssertTrue(t, strings.HasPrefix(second, "<11>1 "))
+			h.AssertTrue(t, strings.HasSuffix(second, "] second"))
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package logexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	// otlpLogsPath is the path the logs are posted to when the endpoint has none, as OTLP/HTTP collectors serve them on
+	otlpLogsPath = "/v1/logs"
+
+	// otlpBatchSize is the number of records posted at most in a request
+	otlpBatchSize = 1000
+
+	otlpTimeout = 30 * time.Second
+)
+
+// otlpExporter posts the logs to an OTLP/HTTP collector, encoded in JSON
+type otlpExporter struct {
+	buffer
+	endpoint string
+	headers  map[string]string
+	resource otlpResource
+	command  string
+	client   *http.Client
+}
+
+func newOTLPExporter(endpoint string, headers map[string]string, source Source, hostname string) *otlpExporter {
+	if u, err := url.Parse(endpoint); err == nil && (u.Path == "" || u.Path == "/") {
+		u.Path = otlpLogsPath
+		endpoint = u.String()
+	}
+
+	return &otlpExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		resource: otlpResource{Attributes: []otlpAttribute{
+			stringAttribute("service.name", "pack"),
+			stringAttribute("service.version", source.Version),
+			stringAttribute("host.name", hostname),
+		}},
+		command: source.Command,
+		client:  &http.Client{Timeout: otlpTimeout},
+	}
+}
+
+// Close posts the records buffered to the collector, in batches
+func (e *otlpExporter) Close() error {
+	records := e.take()
+	for len(records) > 0 {
+		n := len(records)
+		if n > otlpBatchSize {
+			n = otlpBatchSize
+		}
+		if err := e.post(records[:n]); err != nil {
+			return err
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (e *otlpExporter) post(records []logging.Record) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, record := range records {
+		logRecords = append(logRecords, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(record.Time.UnixNano(), 10),
+			SeverityNumber: otlpSeverityNumber(record.Level),
+			SeverityText:   severityText(record.Level),
+			Body:           otlpValue{StringValue: record.Message},
+			Attributes:     []otlpAttribute{stringAttribute("pack.command", e.command)},
+		})
+	}
+
+	body, err := json.Marshal(otlpLogsRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  e.resource,
+		ScopeLogs: []otlpScopeLogs{{Scope: otlpScope{Name: "pack"}, LogRecords: logRecords}},
+	}}})
+	if err != nil {
+		return errors.Wrap(err, "encoding logs")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "creating request to %s", style.Symbol(e.endpoint))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "posting logs to %s", style.Symbol(e.endpoint))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("posting logs to %s: %s: %s", style.Symbol(e.endpoint), resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// otlpSeverityNumber returns the severity number of the OpenTelemetry log data model of the level
+func otlpSeverityNumber(level logging.Level) int {
+	switch level {
+	case logging.DebugLevel:
+		return 5
+	case logging.WarnLevel:
+		return 13
+	case logging.ErrorLevel:
+		return 17
+	default:
+		return 9
+	}
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: value}}
+}
+
+// OTLP/JSON encoding of the logs, see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string          `json:"timeUnixNano"`
+	SeverityNumber int             `json:"severityNumber"`
+	SeverityText   string          `json:"severityText"`
+	Body           otlpValue       `json:"body"`
+	Attributes     []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
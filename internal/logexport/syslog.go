@@ -0,0 +1,122 @@
+package logexport
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	// syslogFacilityUser is the facility of the messages, user-level messages
+	syslogFacilityUser = 1
+
+	// syslogEnterpriseID identifies the structured data of the messages, the example enterprise number of RFC 5424
+	syslogEnterpriseID = "pack@32473"
+
+	syslogTimeout = 30 * time.Second
+)
+
+// syslogExporter sends the logs to a syslog server in the format of RFC 5424, over UDP, a datagram for each message,
+// or TCP, with the octet counting framing of RFC 6587
+type syslogExporter struct {
+	buffer
+	network  string
+	address  string
+	hostname string
+	source   Source
+	pid      int
+}
+
+func newSyslogExporter(endpoint string, source Source, hostname string) (*syslogExporter, error) {
+	network, address := "udp", endpoint
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing syslog endpoint %s", style.Symbol(endpoint))
+		}
+		network, address = u.Scheme, u.Host
+	}
+	if network != "udp" && network != "tcp" {
+		return nil, errors.Errorf("invalid syslog endpoint %s, the scheme must be one of udp or tcp", style.Symbol(endpoint))
+	}
+
+	if hostname == "" {
+		hostname = "-"
+	}
+	return &syslogExporter{
+		network:  network,
+		address:  address,
+		hostname: hostname,
+		source:   source,
+		pid:      os.Getpid(),
+	}, nil
+}
+
+// Close sends the records buffered to the syslog server
+func (e *syslogExporter) Close() error {
+	records := e.take()
+	if len(records) == 0 {
+		return nil
+	}
+
+	conn, err := net.DialTimeout(e.network, e.address, syslogTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "connecting to syslog server %s", style.Symbol(e.address))
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return err
+	}
+	for _, record := range records {
+		message := e.format(record)
+		if e.network == "tcp" {
+			message = fmt.Sprintf("%d %s", len(message), message)
+		}
+		if _, err := conn.Write([]byte(message)); err != nil {
+			return errors.Wrapf(err, "sending logs to syslog server %s", style.Symbol(e.address))
+		}
+	}
+	return nil
+}
+
+// format returns the record as a message of RFC 5424, with the command and version of pack as structured data
+func (e *syslogExporter) format(record logging.Record) string {
+	return fmt.Sprintf("<%d>1 %s %s pack %d - [%s command=\"%s\" version=\"%s\"] %s",
+		syslogFacilityUser*8+syslogSeverity(record.Level),
+		record.Time.UTC().Format(time.RFC3339Nano),
+		e.hostname,
+		e.pid,
+		syslogEnterpriseID,
+		escapeParamValue(e.source.Command),
+		escapeParamValue(e.source.Version),
+		record.Message,
+	)
+}
+
+// syslogSeverity returns the severity of RFC 5424 of the level
+func syslogSeverity(level logging.Level) int {
+	switch level {
+	case logging.DebugLevel:
+		return 7
+	case logging.WarnLevel:
+		return 4
+	case logging.ErrorLevel:
+		return 3
+	default:
+		return 6
+	}
+}
+
+// escapeParamValue escapes the characters RFC 5424 requires to be escaped in the values of structured data
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
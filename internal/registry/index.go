@@ -1,9 +1,16 @@
 package registry
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -40,6 +47,85 @@ func IndexPath(rootDir, ns, name string) (string, error) {
 	return filepath.Join(rootDir, indexDir, fmt.Sprintf("%s_%s", ns, name)), nil
 }
 
+// ReadIndex reads the entries of a buildpack from the registry index at rootDir. An empty entry is returned if the
+// buildpack has no entries yet.
+func ReadIndex(rootDir, ns, name string) (Entry, error) {
+	index, err := IndexPath(rootDir, ns, name)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	contents, err := os.ReadFile(filepath.Clean(index))
+	if os.IsNotExist(err) {
+		return Entry{}, nil
+	} else if err != nil {
+		return Entry{}, errors.Wrapf(err, "reading index for buildpack: %s/%s", ns, name)
+	}
+
+	entry := Entry{}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var bp Buildpack
+		if err := json.Unmarshal(scanner.Bytes(), &bp); err != nil {
+			return Entry{}, errors.Wrapf(err, "parsing index for buildpack: %s/%s", ns, name)
+		}
+		entry.Buildpacks = append(entry.Buildpacks, bp)
+	}
+
+	return entry, scanner.Err()
+}
+
+// AddToIndex adds a buildpack to the registry index at rootDir, replacing any existing entry for the same version.
+// It returns whether an existing entry was replaced.
+func AddToIndex(rootDir string, b Buildpack) (replaced bool, err error) {
+	entry, err := ReadIndex(rootDir, b.Namespace, b.Name)
+	if err != nil {
+		return false, err
+	}
+
+	for i, existing := range entry.Buildpacks {
+		if existing.Version == b.Version {
+			entry.Buildpacks[i] = b
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		entry.Buildpacks = append(entry.Buildpacks, b)
+	}
+
+	index, err := IndexPath(rootDir, b.Namespace, b.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(index), 0750); err != nil {
+		return false, errors.Wrapf(err, "creating directory structure for: %s/%s", b.Namespace, b.Name)
+	}
+
+	buf := &bytes.Buffer{}
+	for _, bp := range entry.Buildpacks {
+		line, err := json.Marshal(bp)
+		if err != nil {
+			return false, errors.Wrapf(err, "converting buildpack to json: %s/%s", b.Namespace, b.Name)
+		}
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 306 in this case
+	// G306: Expect WriteFile permissions to be 0600 or less
+	/* #nosec G306 */
+	if err := os.WriteFile(index, buf.Bytes(), 0644); err != nil {
+		return false, errors.Wrapf(err, "writing index for buildpack: %s/%s", b.Namespace, b.Name)
+	}
+	return replaced, nil
+}
+
 func validateField(field, value string) error {
 	length := len(value)
 	switch {
@@ -55,3 +141,35 @@ func validateField(field, value string) error {
 
 	return nil
 }
+
+// ListIndex returns the IDs of the buildpacks of the registry index at rootDir, sorted. No IDs are returned if there
+// is no index at rootDir.
+func ListIndex(rootDir string) ([]string, error) {
+	var ids []string
+	err := filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ns, name, ok := strings.Cut(d.Name(), "_")
+		if !ok {
+			return nil
+		}
+		if indexPath, err := IndexPath(rootDir, ns, name); err == nil && indexPath == path {
+			ids = append(ids, ns+"/"+name)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "listing registry index %s", style.Symbol(rootDir))
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
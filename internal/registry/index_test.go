@@ -1,6 +1,7 @@
 package registry_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -127,4 +128,77 @@ func testIndex(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 	})
+
+	when("#AddToIndex", func() {
+		var tmpDir string
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "registry-index")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		it("appends new versions and replaces existing ones", func() {
+			v1 := registry.Buildpack{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "example.com/acme/java@sha256:1"}
+			v2 := registry.Buildpack{Namespace: "acme", Name: "java", Version: "2.0.0", Address: "example.com/acme/java@sha256:2"}
+			v1Updated := registry.Buildpack{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "example.com/acme/java@sha256:3"}
+
+			replaced, err := registry.AddToIndex(tmpDir, v1)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, replaced)
+
+			replaced, err = registry.AddToIndex(tmpDir, v2)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, replaced)
+
+			replaced, err = registry.AddToIndex(tmpDir, v1Updated)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, replaced)
+
+			entry, err := registry.ReadIndex(tmpDir, "acme", "java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, entry.Buildpacks, []registry.Buildpack{v1Updated, v2})
+
+			contents, err := os.ReadFile(filepath.Join(tmpDir, "ja", "va", "acme_java"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), `{"ns":"acme","name":"java","version":"1.0.0","yanked":false,"addr":"example.com/acme/java@sha256:3"}
+{"ns":"acme","name":"java","version":"2.0.0","yanked":false,"addr":"example.com/acme/java@sha256:2"}
+`)
+		})
+
+		it("returns an empty entry for unknown buildpacks", func() {
+			entry, err := registry.ReadIndex(tmpDir, "acme", "unknown")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entry.Buildpacks), 0)
+		})
+	})
+
+	when("#ListIndex", func() {
+		it("lists the buildpacks of the index", func() {
+			tmpDir := t.TempDir()
+			for _, bp := range []registry.Buildpack{
+				{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "example.com/acme/java@sha256:1"},
+				{Namespace: "acme", Name: "go", Version: "1.0.0", Address: "example.com/acme/go@sha256:1"},
+				{Namespace: "other", Name: "node", Version: "1.0.0", Address: "example.com/other/node@sha256:1"},
+			} {
+				_, err := registry.AddToIndex(tmpDir, bp)
+				h.AssertNil(t, err)
+			}
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "README_md"), []byte(""), 0600))
+
+			ids, err := registry.ListIndex(tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, ids, []string{"acme/go", "acme/java", "other/node"})
+		})
+
+		it("lists no buildpacks without an index", func() {
+			ids, err := registry.ListIndex(filepath.Join(t.TempDir(), "missing"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(ids), 0)
+		})
+	})
 }
@@ -3,6 +3,7 @@ package registry
 import (
 	"bufio"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 
@@ -31,6 +34,7 @@ const defaultRegistryDir = "registry"
 type Cache struct {
 	logger      logging.Logger
 	url         *url.URL
+	token       string
 	Root        string
 	RegistryDir string
 }
@@ -75,6 +79,21 @@ func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, e
 	}, nil
 }
 
+// WithToken returns the registry cache authenticating to the remote registry with the token, such as to clone a private
+// registry
+func (r Cache) WithToken(token string) Cache {
+	r.token = token
+	return r
+}
+
+// auth returns the credentials to clone and pull the remote registry with, if any
+func (r *Cache) auth() transport.AuthMethod {
+	if r.token == "" {
+		return nil
+	}
+	return &githttp.BasicAuth{Username: "pack", Password: r.token}
+}
+
 // LocateBuildpack stored in registry
 func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	err := r.Refresh()
@@ -134,7 +153,7 @@ func (r *Cache) Refresh() error {
 		return errors.Wrapf(err, "reading (%s)", r.Root)
 	}
 
-	err = w.Pull(&git.PullOptions{RemoteName: "origin"})
+	err = w.Pull(&git.PullOptions{RemoteName: "origin", Auth: r.auth()})
 	if err == git.NoErrAlreadyUpToDate {
 		return nil
 	}
@@ -180,7 +199,18 @@ func (r *Cache) CreateCache() error {
 	r.RegistryDir = registryDir
 
 	if r.url.Host == "dev.azure.com" {
-		err = exec.Command("git", "clone", r.url.String(), r.RegistryDir).Run()
+		cmd := exec.Command("git", "clone", r.url.String(), r.RegistryDir)
+		if r.token != "" {
+			// the token is given in the environment, rather than in the URL or the arguments, not to be stored in the
+			// config of the clone, nor to be seen in the processes
+			credentials := base64.StdEncoding.EncodeToString([]byte("pack:" + r.token))
+			cmd.Env = append(os.Environ(),
+				"GIT_CONFIG_COUNT=1",
+				"GIT_CONFIG_KEY_0=http.extraHeader",
+				"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+			)
+		}
+		err = cmd.Run()
 		if err != nil {
 			return errors.Wrap(err, "cloning remote registry with native git")
 		}
@@ -191,7 +221,8 @@ func (r *Cache) CreateCache() error {
 		}
 	} else {
 		repository, err = git.PlainClone(r.RegistryDir, false, &git.CloneOptions{
-			URL: r.url.String(),
+			URL:  r.url.String(),
+			Auth: r.auth(),
 		})
 		if err != nil {
 			return errors.Wrap(err, "cloning remote registry")
@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/buildpackage"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/project/v02"
+)
+
+const (
+	// FormatJSONSchema is the JSON Schema format, of draft 2020-12.
+	FormatJSONSchema = "jsonschema"
+
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// Descriptor is a TOML descriptor pack reads, and the Go struct it's decoded into
+type Descriptor struct {
+	Title       string
+	Description string
+	Type        reflect.Type
+}
+
+// Descriptors are the descriptors schemas can be exported for, by kind
+var Descriptors = map[string]Descriptor{
+	"builder": {
+		Title:       "builder.toml",
+		Description: "Configuration of a builder, as read by pack builder create",
+		Type:        reflect.TypeOf(builder.Config{}),
+	},
+	"package": {
+		Title:       "package.toml",
+		Description: "Configuration of a buildpackage, as read by pack buildpack package",
+		Type:        reflect.TypeOf(buildpackage.Config{}),
+	},
+	"project": {
+		Title:       "project.toml",
+		Description: "Project descriptor, of schema version 0.2",
+		Type:        reflect.TypeOf(v02.Descriptor{}),
+	},
+}
+
+// Kinds returns the kinds of descriptors schemas can be exported for.
+func Kinds() []string {
+	var kinds []string
+	for kind := range Descriptors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Schema is a JSON Schema
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Export exports the schema of the descriptor of the kind, in the format.
+func Export(kind, format string) ([]byte, error) {
+	descriptor, ok := Descriptors[kind]
+	if !ok {
+		return nil, errors.Errorf("unknown descriptor %s, must be one of %s", style.Symbol(kind), strings.Join(Kinds(), ", "))
+	}
+	if format != FormatJSONSchema {
+		return nil, errors.Errorf("invalid format %s, must be %s", style.Symbol(format), FormatJSONSchema)
+	}
+
+	s := FromType(descriptor.Type)
+	s.Schema = jsonSchemaDraft
+	s.Title = descriptor.Title
+	s.Description = descriptor.Description
+
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshalling schema of %s", style.Symbol(kind))
+	}
+	return out, nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// FromType generates the schema of the TOML a Go type is decoded from, following the toml tags of its fields.
+func FromType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: FromType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: FromType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addProperties(s, t)
+		return s
+	default:
+		// interface{} values, such as of metadata tables, can be anything
+		return &Schema{}
+	}
+}
+
+// addProperties adds the fields of a struct as properties of the schema, flattening untagged embedded structs the
+// way the TOML decoder does
+func addProperties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("toml")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addProperties(s, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if !hasTag || name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		s.Properties[name] = FromType(field.Type)
+	}
+}
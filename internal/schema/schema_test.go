@@ -0,0 +1,93 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/schema"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSchema(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Schema", testSchema, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type embedded struct {
+	URI string `toml:"uri"`
+}
+
+type descriptor struct {
+	embedded
+	Name     string                 `toml:"name,omitempty"`
+	Optional bool                   `toml:"optional"`
+	Count    int                    `toml:"count"`
+	API      *api.Version           `toml:"api"`
+	Tags     []string               `toml:"tags"`
+	Metadata map[string]interface{} `toml:"metadata"`
+	Ignored  string                 `toml:"-"`
+	Untagged string
+	internal string
+}
+
+func testSchema(t *testing.T, when spec.G, it spec.S) {
+	when("#FromType", func() {
+		it("follows the toml tags of the fields", func() {
+			s := schema.FromType(reflect.TypeOf(descriptor{}))
+
+			h.AssertEq(t, s.Type, "object")
+			h.AssertEq(t, len(s.Properties), 8)
+			h.AssertEq(t, s.Properties["uri"].Type, "string")
+			h.AssertEq(t, s.Properties["name"].Type, "string")
+			h.AssertEq(t, s.Properties["optional"].Type, "boolean")
+			h.AssertEq(t, s.Properties["count"].Type, "integer")
+			h.AssertEq(t, s.Properties["api"].Type, "string")
+			h.AssertEq(t, s.Properties["tags"].Type, "array")
+			h.AssertEq(t, s.Properties["tags"].Items.Type, "string")
+			h.AssertEq(t, s.Properties["metadata"].Type, "object")
+			h.AssertEq(t, s.Properties["metadata"].AdditionalProperties.Type, "")
+			h.AssertEq(t, s.Properties["untagged"].Type, "string")
+		})
+	})
+
+	when("#Export", func() {
+		it("exports the JSON Schema of each descriptor", func() {
+			for _, kind := range schema.Kinds() {
+				out, err := schema.Export(kind, schema.FormatJSONSchema)
+				h.AssertNil(t, err)
+
+				var s schema.Schema
+				h.AssertNil(t, json.Unmarshal(out, &s))
+				h.AssertEq(t, s.Schema, "https://json-schema.org/draft/2020-12/schema")
+				h.AssertEq(t, s.Title, kind+".toml")
+			}
+		})
+
+		it("exports the tables of project.toml", func() {
+			out, err := schema.Export("project", schema.FormatJSONSchema)
+			h.AssertNil(t, err)
+
+			var s schema.Schema
+			h.AssertNil(t, json.Unmarshal(out, &s))
+			h.AssertEq(t, s.Properties["_"].Properties["schema-version"].Type, "string")
+			h.AssertEq(t, s.Properties["io"].Properties["buildpacks"].Properties["group"].Items.Properties["id"].Type, "string")
+		})
+
+		it("errors for unknown descriptors", func() {
+			_, err := schema.Export("stack", schema.FormatJSONSchema)
+			h.AssertError(t, err, "unknown descriptor 'stack', must be one of builder, package, project")
+		})
+
+		it("errors for unknown formats", func() {
+			_, err := schema.Export("builder", "cue")
+			h.AssertError(t, err, "invalid format 'cue', must be jsonschema")
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package stack
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+)
+
+const (
+	passwdPath = "etc/passwd"
+	groupPath  = "etc/group"
+)
+
+// UserLayer returns a layer adding a user and group with the given name and ids to the /etc/passwd and /etc/group
+// files of img, along with the home directory of the user. Entries already in img for the ids are kept as they are.
+func UserLayer(img v1.Image, name string, uid, gid int) (v1.Layer, error) {
+	files, err := readFiles(img, passwdPath, groupPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading users of base image")
+	}
+
+	home := path.Join("/home", name)
+	passwd := appendEntry(files[passwdPath], uid, fmt.Sprintf("%s:x:%d:%d::%s:/bin/sh", name, uid, gid, home), 2)
+	group := appendEntry(files[groupPath], gid, fmt.Sprintf("%s:x:%d:", name, gid), 2)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: passwdPath, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(passwd))},
+		{Name: groupPath, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(group))},
+		{Name: strings.TrimPrefix(home, "/") + "/", Typeflag: tar.TypeDir, Mode: 0755, Uid: uid, Gid: gid},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		switch hdr.Name {
+		case passwdPath:
+			_, err = tw.Write(passwd)
+		case groupPath:
+			_, err = tw.Write(group)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	contents := buf.Bytes()
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(contents)), nil
+	})
+}
+
+// readFiles returns the contents of the named files in the filesystem of img, leaving out those it doesn't have
+func readFiles(img v1.Image, names ...string) (map[string][]byte, error) {
+	rc := mutate.Extract(img)
+	defer rc.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(rc)
+	for len(files) < len(names) {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		for _, n := range names {
+			if name == n && hdr.Typeflag == tar.TypeReg {
+				if files[n], err = io.ReadAll(tr); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return files, nil
+}
+
+// appendEntry appends entry to the contents of a passwd or group file, unless it has an entry with the same id in
+// the given field already
+func appendEntry(contents []byte, id int, entry string, idField int) []byte {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) > idField && fields[idField] == strconv.Itoa(id) {
+			return contents
+		}
+	}
+
+	if len(contents) > 0 && !bytes.HasSuffix(contents, []byte("\n")) {
+		contents = append(contents, '\n')
+	}
+	return append(contents, []byte(entry+"\n")...)
+}
@@ -0,0 +1,93 @@
+package stack_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/stack"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUserLayer(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "testUserLayer", testUserLayer, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUserLayer(t *testing.T, when spec.G, it spec.S) {
+	var baseImage v1.Image
+
+	// layerFiles returns the contents of the regular files of the layer, and the headers of all its entries
+	layerFiles := func(layer v1.Layer) (map[string]string, map[string]*tar.Header) {
+		rc, err := layer.Uncompressed()
+		h.AssertNil(t, err)
+		defer rc.Close()
+
+		files, headers := map[string]string{}, map[string]*tar.Header{}
+		tr := tar.NewReader(rc)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return files, headers
+			}
+			h.AssertNil(t, err)
+			headers[hdr.Name] = hdr
+			contents, err := io.ReadAll(tr)
+			h.AssertNil(t, err)
+			files[hdr.Name] = string(contents)
+		}
+	}
+
+	it.Before(func() {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, contents := range map[string]string{
+			"etc/passwd": "root:x:0:0:root:/root:/bin/bash\nexisting:x:1001:1001::/home/existing:/bin/sh",
+			"etc/group":  "root:x:0:\n",
+		} {
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}))
+			_, err := tw.Write([]byte(contents))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		})
+		h.AssertNil(t, err)
+		baseImage, err = mutate.AppendLayers(empty.Image, layer)
+		h.AssertNil(t, err)
+	})
+
+	when("#UserLayer", func() {
+		it("adds the user, group and home directory", func() {
+			layer, err := stack.UserLayer(baseImage, "cnb", 1000, 1000)
+			h.AssertNil(t, err)
+
+			files, headers := layerFiles(layer)
+			h.AssertEq(t, files["etc/passwd"], "root:x:0:0:root:/root:/bin/bash\nexisting:x:1001:1001::/home/existing:/bin/sh\ncnb:x:1000:1000::/home/cnb:/bin/sh\n")
+			h.AssertEq(t, files["etc/group"], "root:x:0:\ncnb:x:1000:\n")
+			h.AssertEq(t, headers["home/cnb/"].Uid, 1000)
+			h.AssertEq(t, headers["home/cnb/"].Gid, 1000)
+		})
+
+		it("keeps the entries the base image has for the ids", func() {
+			layer, err := stack.UserLayer(baseImage, "cnb", 1001, 0)
+			h.AssertNil(t, err)
+
+			files, _ := layerFiles(layer)
+			h.AssertEq(t, files["etc/passwd"], "root:x:0:0:root:/root:/bin/bash\nexisting:x:1001:1001::/home/existing:/bin/sh")
+			h.AssertEq(t, files["etc/group"], "root:x:0:\n")
+		})
+	})
+}
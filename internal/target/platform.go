@@ -42,6 +42,7 @@ var supportedOSArchs = map[string][]string{
 	"openbsd":   {"386", "amd64", "arm", "arm64"},
 	"plan9":     {"386", "amd64", "arm"},
 	"solaris":   {"amd64"},
+	"wasi":      {"wasm"},
 	"wasip1":    {"wasm"},
 	"windows":   {"386", "amd64", "arm", "arm64"},
 }
@@ -31,5 +31,9 @@ func testPlatforms(t *testing.T, when spec.G, it spec.S) {
 			b := target.SupportsPlatform("linux", "arm", "v6")
 			h.AssertTrue(t, b)
 		})
+		it("should support WebAssembly System Interface targets", func() {
+			h.AssertTrue(t, target.SupportsPlatform("wasi", "wasm", ""))
+			h.AssertFalse(t, target.SupportsPlatform("wasi", "amd64", ""))
+		})
 	})
 }
@@ -3,8 +3,12 @@ package blob
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/pkg/errors"
@@ -18,6 +22,9 @@ type Blob interface {
 
 type blob struct {
 	path string
+
+	// cached is whether the blob was downloaded to the cache, see RemoveCached
+	cached bool
 }
 
 func NewBlob(path string) Blob {
@@ -61,6 +68,45 @@ func (b blob) Open() (r io.ReadCloser, err error) {
 	return rc, nil
 }
 
+// VerifySHA256 verifies the archive of the blob, as it was downloaded rather than decompressed, has the SHA256 digest,
+// given in hex with or without the sha256: prefix
+func VerifySHA256(b Blob, expected string) error {
+	archivePath := ""
+	switch b := b.(type) {
+	case *blob:
+		archivePath = b.path
+	case blob:
+		archivePath = b.path
+	default:
+		return errors.New("the digest can only be verified for archives read from files")
+	}
+
+	fi, err := os.Stat(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, "read blob at path '%s'", archivePath)
+	}
+	if fi.IsDir() {
+		return errors.Errorf("the digest can only be verified for archives, '%s' is a directory", archivePath)
+	}
+
+	fh, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return errors.Wrap(err, "open archive")
+	}
+	defer fh.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, fh); err != nil {
+		return errors.Wrap(err, "computing digest of archive")
+	}
+
+	actual := hex.EncodeToString(hasher.Sum(nil))
+	if !strings.EqualFold(strings.TrimPrefix(expected, "sha256:"), actual) {
+		return errors.Errorf("archive has digest sha256:%s, expected sha256:%s", actual, strings.TrimPrefix(expected, "sha256:"))
+	}
+	return nil
+}
+
 func isGZip(file io.ReadSeeker) (bool, error) {
 	b := make([]byte, 3)
 	if _, err := file.Seek(0, 0); err != nil {
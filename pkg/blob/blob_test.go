@@ -1,6 +1,8 @@
 package blob_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"path/filepath"
 	"testing"
@@ -60,4 +62,40 @@ func testBlob(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("#VerifySHA256", func() {
+		var archivePath string
+
+		it.Before(func() {
+			archivePath = h.CreateTGZ(t, filepath.Join("testdata", "blob"), ".", -1)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.Remove(archivePath))
+		})
+
+		it("accepts the digest of the archive", func() {
+			digest := sha256OfFile(t, archivePath)
+			h.AssertNil(t, blob.VerifySHA256(blob.NewBlob(archivePath), digest))
+			h.AssertNil(t, blob.VerifySHA256(blob.NewBlob(archivePath), "sha256:"+digest))
+		})
+
+		it("fails when the digest doesn't match", func() {
+			err := blob.VerifySHA256(blob.NewBlob(archivePath), "sha256:0123")
+			h.AssertError(t, err, "archive has digest sha256:"+sha256OfFile(t, archivePath)+", expected sha256:0123")
+		})
+
+		it("fails for a directory", func() {
+			err := blob.VerifySHA256(blob.NewBlob(filepath.Join("testdata", "blob")), "0123")
+			h.AssertError(t, err, "can only be verified for archives")
+		})
+	})
+}
+
+func sha256OfFile(t *testing.T, path string) string {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	h.AssertNil(t, err)
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,184 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureauth "github.com/Azure/go-autorest/autorest/azure/auth"
+	awssigner "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// emptyPayloadSHA256 is the SHA256 digest of the empty body of GET requests, which S3 requires the signature of
+	emptyPayloadSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	gcsReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+	azureStorage     = "https://storage.azure.com/"
+	azureAPIVersion  = "2021-08-06"
+)
+
+// requestFunc returns the request downloading a URI, for requests to be authorized as their URI requires
+type requestFunc func(ctx context.Context) (*http.Request, error)
+
+func httpRequest(uri string) requestFunc {
+	return func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	}
+}
+
+// cloudStorageRequest returns the request downloading the object of a cloud storage URI, authorized with the ambient
+// credentials of its cloud, as the SDKs and CLIs of the cloud find them:
+//   - s3://<bucket>/<key>, with the credentials and region of the AWS config, and its endpoint for S3 compatible storage
+//   - gs://<bucket>/<object>, with the application default credentials of Google Cloud
+//   - az://<account>/<container>/<blob>, with the service principal or managed identity of the Azure environment, or
+//     else the login of the Azure CLI
+func cloudStorageRequest(uri *url.URL) requestFunc {
+	bucket, object := uri.Host, strings.TrimPrefix(uri.Path, "/")
+	return func(ctx context.Context) (*http.Request, error) {
+		if bucket == "" || object == "" {
+			return nil, errors.Errorf("invalid URI %s, must be in the form %s", style.Symbol(withoutQuery(uri)), style.Symbol(uri.Scheme+"://<bucket>/<object>"))
+		}
+
+		switch uri.Scheme {
+		case "s3":
+			return s3Request(ctx, bucket, object)
+		case "gs":
+			return gcsRequest(ctx, bucket, object)
+		default:
+			container, blobName, ok := strings.Cut(object, "/")
+			if !ok || blobName == "" {
+				return nil, errors.Errorf("invalid URI %s, must be in the form %s", style.Symbol(withoutQuery(uri)), style.Symbol("az://<account>/<container>/<blob>"))
+			}
+			return azureRequest(ctx, bucket, container, blobName, uri.RawQuery)
+		}
+	}
+}
+
+// withoutQuery returns the URI without its query, which holds the SAS token of az:// URIs, for URIs to be logged and
+// cached by without their credentials
+func withoutQuery(uri *url.URL) string {
+	withoutQuery := *uri
+	withoutQuery.RawQuery = ""
+	withoutQuery.ForceQuery = false
+	return withoutQuery.String()
+}
+
+func s3Request(ctx context.Context, bucket, key string) (*http.Request, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading AWS config")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	objectURL := &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, region), Path: "/" + key}
+	if cfg.BaseEndpoint != nil {
+		// S3 compatible storage addresses buckets by path
+		endpoint, err := url.Parse(*cfg.BaseEndpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing AWS endpoint %s", style.Symbol(*cfg.BaseEndpoint))
+		}
+		objectURL = endpoint.JoinPath(bucket, key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving AWS credentials")
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadSHA256)
+	// S3 signs the path as it's escaped once, unlike other AWS services
+	signer := awssigner.NewSigner(func(options *awssigner.SignerOptions) {
+		options.DisableURIPathEscaping = true
+	})
+	if err := signer.SignHTTP(ctx, credentials, req, emptyPayloadSHA256, "s3", region, time.Now()); err != nil {
+		return nil, errors.Wrap(err, "signing S3 request")
+	}
+	return req, nil
+}
+
+func gcsRequest(ctx context.Context, bucket, object string) (*http.Request, error) {
+	// the emulator of the Google Cloud SDKs serves objects without credentials
+	if emulatorHost := os.Getenv("STORAGE_EMULATOR_HOST"); emulatorHost != "" {
+		if !strings.Contains(emulatorHost, "://") {
+			emulatorHost = "http://" + emulatorHost
+		}
+		emulatorURL, err := url.Parse(emulatorHost)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing storage emulator host %s", style.Symbol(emulatorHost))
+		}
+		return http.NewRequestWithContext(ctx, http.MethodGet, emulatorURL.JoinPath(bucket, object).String(), nil)
+	}
+
+	objectURL := &url.URL{Scheme: "https", Host: "storage.googleapis.com", Path: "/" + bucket + "/" + object}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := google.FindDefaultCredentials(ctx, gcsReadOnlyScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding Google Cloud credentials")
+	}
+	token, err := credentials.TokenSource.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving Google Cloud token")
+	}
+	token.SetAuthHeader(req)
+	return req, nil
+}
+
+// azureRequest returns the request for a blob, authorized by the SAS token of the query when there's one
+func azureRequest(ctx context.Context, account, container, blobName, sasToken string) (*http.Request, error) {
+	blobURL := &url.URL{
+		Scheme:   "https",
+		Host:     account + ".blob.core.windows.net",
+		Path:     "/" + container + "/" + blobName,
+		RawQuery: sasToken,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Ms-Version", azureAPIVersion)
+	if sasToken != "" {
+		return req, nil
+	}
+
+	authorizer, err := azureAuthorizer()
+	if err != nil {
+		return nil, errors.Wrap(err, "finding Azure credentials")
+	}
+	if req, err = autorest.Prepare(req, authorizer.WithAuthorization()); err != nil {
+		return nil, errors.Wrap(err, "retrieving Azure token")
+	}
+	return req, nil
+}
+
+// azureAuthorizer returns the authorizer of the service principal of the environment when there's one, or else of the
+// login of the Azure CLI, or else of the managed identity of the machine
+func azureAuthorizer() (autorest.Authorizer, error) {
+	if os.Getenv(azureauth.ClientID) == "" {
+		if authorizer, err := azureauth.NewAuthorizerFromCLIWithResource(azureStorage); err == nil {
+			return authorizer, nil
+		}
+	}
+	return azureauth.NewAuthorizerFromEnvironmentWithResource(azureStorage)
+}
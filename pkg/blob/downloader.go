@@ -9,10 +9,14 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/dustin/go-humanize"
 	"github.com/mitchellh/ioprogress"
 	"github.com/pkg/errors"
 
+	"github.com/buildpacks/pack/internal/git"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 )
@@ -44,6 +48,10 @@ type downloader struct {
 	logger       Logger
 	baseCacheDir string
 	client       *http.Client
+
+	// cacheLocks holds a mutex per cache path, so that concurrent downloads of a URI don't write the same file, shared
+	// with the downloaders of DownloaderWithLogger
+	cacheLocks *sync.Map
 }
 
 func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption) Downloader {
@@ -51,6 +59,7 @@ func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption)
 		logger:       logger,
 		baseCacheDir: baseCacheDir,
 		client:       http.DefaultClient,
+		cacheLocks:   &sync.Map{},
 	}
 
 	for _, opt := range opts {
@@ -60,6 +69,21 @@ func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption)
 	return d
 }
 
+// DownloaderWithLogger returns a downloader logging the downloads with the logger, for the downloads of an operation
+// to be logged along with its output, or the downloader as it is when it isn't one of NewDownloader
+func DownloaderWithLogger(d Downloader, logger Logger) Downloader {
+	original, ok := d.(*downloader)
+	if !ok {
+		return d
+	}
+	return &downloader{
+		logger:       logger,
+		baseCacheDir: original.baseCacheDir,
+		client:       original.client,
+		cacheLocks:   original.cacheLocks,
+	}
+}
+
 func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, error) {
 	if paths.IsURI(pathOrURI) {
 		parsedURL, err := url.Parse(pathOrURI)
@@ -67,16 +91,21 @@ func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, erro
 			return nil, errors.Wrapf(err, "parsing path/uri %s", style.Symbol(pathOrURI))
 		}
 
-		var path string
+		var (
+			path   string
+			cached bool
+		)
 		switch parsedURL.Scheme {
 		case "file":
 			path, err = paths.URIToFilePath(pathOrURI)
 		case "http", "https":
-			path, err = d.handleHTTP(ctx, pathOrURI)
-			if err != nil {
-				// retry as we sometimes see `wsarecv: An existing connection was forcibly closed by the remote host.` on Windows
-				path, err = d.handleHTTP(ctx, pathOrURI)
-			}
+			path, err = d.handleHTTPWithRetry(ctx, pathOrURI, httpRequest(pathOrURI))
+			cached = true
+		case "s3", "gs", "az":
+			path, err = d.handleHTTPWithRetry(ctx, withoutQuery(parsedURL), cloudStorageRequest(parsedURL))
+			cached = true
+		case "git", "git+file", "git+http", "git+https", "git+ssh":
+			path, err = d.handleGit(ctx, pathOrURI)
 		default:
 			err = fmt.Errorf("unsupported protocol %s in URI %s", style.Symbol(parsedURL.Scheme), style.Symbol(pathOrURI))
 		}
@@ -84,7 +113,7 @@ func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, erro
 			return nil, err
 		}
 
-		return &blob{path: path}, nil
+		return &blob{path: path, cached: cached}, nil
 	}
 
 	path := d.handleFile(pathOrURI)
@@ -101,7 +130,19 @@ func (d *downloader) handleFile(path string) string {
 	return path
 }
 
-func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error) {
+func (d *downloader) handleHTTPWithRetry(ctx context.Context, uri string, newRequest requestFunc) (string, error) {
+	path, err := d.handleHTTP(ctx, uri, newRequest)
+	if err != nil {
+		// retry as we sometimes see `wsarecv: An existing connection was forcibly closed by the remote host.` on Windows
+		path, err = d.handleHTTP(ctx, uri, newRequest)
+	}
+	return path, err
+}
+
+// handleHTTP downloads the URI to the cache, unless the cached copy is still current according to its ETag. The
+// download is written to a partial file first, and resumed from where it stopped when it was interrupted, provided the
+// server supports range requests and the file hasn't changed since.
+func (d *downloader) handleHTTP(ctx context.Context, uri string, newRequest requestFunc) (string, error) {
 	cacheDir := d.versionedCacheDir()
 
 	if err := os.MkdirAll(cacheDir, 0750); err != nil {
@@ -110,77 +151,209 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 
 	cachePath := filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(uri))))
 
+	lock, _ := d.cacheLocks.LoadOrStore(cachePath, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
 	etagFile := cachePath + ".etag"
-	etagExists, err := fileExists(etagFile)
+	etag, err := readOptionalFile(etagFile)
 	if err != nil {
 		return "", err
 	}
 
-	etag := ""
-	if etagExists {
-		bytes, err := os.ReadFile(filepath.Clean(etagFile))
-		if err != nil {
-			return "", err
-		}
-		etag = string(bytes)
+	partialPath := cachePath + ".partial"
+	partialEtagFile := partialPath + ".etag"
+	partial, err := d.partialDownload(partialPath, partialEtagFile)
+	if err != nil {
+		return "", err
 	}
 
-	reader, etag, err := d.downloadAsStream(ctx, uri, etag)
+	resp, err := d.get(ctx, newRequest, etag, partial)
 	if err != nil {
 		return "", err
-	} else if reader == nil {
+	}
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && partial.size > 0 {
+		// the partial download doesn't fit the file anymore, start over
+		resp.Body.Close()
+		d.logger.Debugf("Resuming download from %s failed, starting over", style.Symbol(uri))
+		if err := removeFiles(partialPath, partialEtagFile); err != nil {
+			return "", err
+		}
+		partial = partialDownload{}
+		if resp, err = d.get(ctx, newRequest, etag, partial); err != nil {
+			return "", err
+		}
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		d.logger.Debugf("Using cached version of %s", style.Symbol(uri))
 		return cachePath, nil
+	case resp.StatusCode == http.StatusPartialContent && partial.size > 0:
+		d.logger.Infof("Resuming download from %s at %s", style.Symbol(uri), humanize.Bytes(uint64(partial.size)))
+		flags = os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		d.logger.Infof("Downloading from %s", style.Symbol(uri))
+	default:
+		return "", fmt.Errorf(
+			"could not download from %s, code http status %s",
+			style.Symbol(uri), style.SymbolF("%d", resp.StatusCode),
+		)
 	}
-	defer reader.Close()
 
-	fh, err := os.Create(cachePath)
-	if err != nil {
-		return "", errors.Wrapf(err, "create cache path %s", style.Symbol(cachePath))
+	respEtag := resp.Header.Get("Etag")
+	if err := os.WriteFile(partialEtagFile, []byte(respEtag), 0600); err != nil {
+		return "", errors.Wrap(err, "writing etag of partial download")
 	}
-	defer fh.Close()
 
-	_, err = io.Copy(fh, reader)
+	fh, err := os.OpenFile(filepath.Clean(partialPath), flags, 0600)
+	if err != nil {
+		return "", errors.Wrapf(err, "create cache path %s", style.Symbol(partialPath))
+	}
+	_, err = io.Copy(fh, withProgress(d.logger.Writer(), resp.Body, resp.ContentLength))
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return "", errors.Wrap(err, "writing cache")
 	}
 
-	if err = os.WriteFile(etagFile, []byte(etag), 0744); err != nil {
+	if err := os.Rename(partialPath, cachePath); err != nil {
+		return "", errors.Wrap(err, "writing cache")
+	}
+	if err := os.WriteFile(etagFile, []byte(respEtag), 0600); err != nil {
 		return "", errors.Wrap(err, "writing etag")
 	}
+	if err := removeFiles(partialEtagFile); err != nil {
+		return "", err
+	}
 
 	return cachePath, nil
 }
 
-func (d *downloader) downloadAsStream(ctx context.Context, uri string, etag string) (io.ReadCloser, string, error) {
-	req, err := http.NewRequest("GET", uri, nil)
+// RemoveCached removes the blob from the cache of the downloads, with its ETag and partial download, for the next
+// download to start over, such as when it doesn't have the expected digest. Blobs that weren't downloaded to the cache
+// are left as they are.
+func RemoveCached(b Blob) error {
+	var cachePath string
+	switch b := b.(type) {
+	case *blob:
+		if b.cached {
+			cachePath = b.path
+		}
+	case blob:
+		if b.cached {
+			cachePath = b.path
+		}
+	}
+	if cachePath == "" {
+		return nil
+	}
+	return removeFiles(cachePath, cachePath+".etag", cachePath+".partial", cachePath+".partial.etag")
+}
+
+// handleGit clones the repository of the URI, at the branch, tag or commit after its #, to the cache. Clones are kept
+// by the commit they're at, so that branches and tags are cloned again only once they've moved.
+func (d *downloader) handleGit(ctx context.Context, uri string) (string, error) {
+	repo, err := git.ParseURI(uri)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
-	req = req.WithContext(ctx)
 
-	if etag != "" {
-		req.Header.Set("If-None-Match", etag)
+	cacheDir := filepath.Join(d.versionedCacheDir(), "git")
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return "", err
+	}
+	cachePathOf := func(commit string) string {
+		return filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(repo.URL+"@"+commit))))
 	}
 
-	resp, err := d.client.Do(req) //nolint:bodyclose
+	commit, err := git.Resolve(ctx, repo)
 	if err != nil {
-		return nil, "", err
+		return "", err
+	}
+	if commit != "" {
+		if _, err := os.Stat(cachePathOf(commit)); err == nil {
+			d.logger.Debugf("Using cached clone of %s", style.Symbol(repo.String()))
+			return cachePathOf(commit), nil
+		}
 	}
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		d.logger.Infof("Downloading from %s", style.Symbol(uri))
-		return withProgress(d.logger.Writer(), resp.Body, resp.ContentLength), resp.Header.Get("Etag"), nil
+	d.logger.Infof("Cloning %s", style.Symbol(repo.String()))
+	cloneDir, err := os.MkdirTemp(cacheDir, "clone")
+	if err != nil {
+		return "", err
 	}
+	defer os.RemoveAll(cloneDir)
 
-	if resp.StatusCode == 304 {
-		d.logger.Debugf("Using cached version of %s", style.Symbol(uri))
-		return nil, etag, nil
+	cloned, err := git.Clone(ctx, repo, cloneDir)
+	if err != nil {
+		return "", err
+	}
+	if commit == "" {
+		commit = cloned
+	}
+	if err := git.RemoveMetadata(cloneDir); err != nil {
+		return "", err
 	}
 
-	return nil, "", fmt.Errorf(
-		"could not download from %s, code http status %s",
-		style.Symbol(uri), style.SymbolF("%d", resp.StatusCode),
-	)
+	cachePath := cachePathOf(commit)
+	if err := os.Rename(cloneDir, cachePath); err != nil {
+		// another download may have cloned the same commit at the same time
+		if _, statErr := os.Stat(cachePath); statErr != nil {
+			return "", errors.Wrap(err, "writing cache")
+		}
+	}
+	return cachePath, nil
+}
+
+// partialDownload is a download that was interrupted, to be resumed from its size when the file still has its ETag
+type partialDownload struct {
+	size int64
+	etag string
+}
+
+// partialDownload returns the partial download at the path, with a size of zero when there's none or it can't be
+// resumed, as resuming requires a strong ETag to tell whether the file has changed since
+func (d *downloader) partialDownload(path, etagFile string) (partialDownload, error) {
+	etag, err := readOptionalFile(etagFile)
+	if err != nil || etag == "" || strings.HasPrefix(etag, "W/") {
+		return partialDownload{}, err
+	}
+
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return partialDownload{}, nil
+	} else if err != nil {
+		return partialDownload{}, err
+	}
+	return partialDownload{size: fi.Size(), etag: etag}, nil
+}
+
+// get requests the URI, unless it has the ETag of the cached copy, and the rest of the partial download, unless the
+// file has changed since
+func (d *downloader) get(ctx context.Context, newRequest requestFunc, etag string, partial partialDownload) (*http.Response, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if partial.size > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", partial.size))
+		req.Header.Set("If-Range", partial.etag)
+	}
+
+	resp, err := d.client.Do(req)
+	if urlErr, ok := err.(*url.Error); ok {
+		// the query may hold credentials, like the SAS tokens of az:// URIs and the signatures of presigned URLs
+		urlErr.URL = withoutQuery(req.URL)
+	}
+	return resp, err
 }
 
 func withProgress(writer io.Writer, rc io.ReadCloser, length int64) io.ReadCloser {
@@ -203,13 +376,20 @@ func (d *downloader) versionedCacheDir() string {
 	return filepath.Join(d.baseCacheDir, cacheDirPrefix+cacheVersion)
 }
 
-func fileExists(file string) (bool, error) {
-	_, err := os.Stat(file)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
+// readOptionalFile returns the contents of the file, or an empty string when it doesn't exist
+func readOptionalFile(file string) (string, error) {
+	contents, err := os.ReadFile(filepath.Clean(file))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(contents), err
+}
+
+func removeFiles(files ...string) error {
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
 		}
-		return false, err
 	}
-	return true, nil
+	return nil
 }
@@ -1,16 +1,22 @@
 package blob_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
+	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
@@ -124,6 +130,14 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 					assertBlob(t, b)
 				})
 
+				it("logs the download with the logger of DownloaderWithLogger", func() {
+					var callBuf bytes.Buffer
+					b, err := blob.DownloaderWithLogger(subject, &logger{&callBuf}).Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertContains(t, callBuf.String(), "Downloading from")
+				})
+
 				it("uses cache from a 'http(s)://' URI tgz", func() {
 					b, err := subject.Download(context.TODO(), uri)
 					h.AssertNil(t, err)
@@ -135,6 +149,267 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("#RemoveCached", func() {
+				it("removes the download from the cache for the next download to start over", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", `"A"`)
+						http.ServeFile(w, r, tgz)
+					})
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						h.AssertEq(t, r.Header.Get("If-None-Match"), "")
+						w.Header().Add("ETag", `"A"`)
+						http.ServeFile(w, r, tgz)
+					})
+
+					b, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					h.AssertNil(t, blob.RemoveCached(b))
+
+					entries, err := os.ReadDir(filepath.Join(cacheDir, "c2"))
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(entries), 0)
+
+					b, err = subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				it("leaves blobs that weren't downloaded to the cache", func() {
+					h.AssertNil(t, blob.RemoveCached(blob.NewBlob(tgz)))
+					h.AssertPathExists(t, tgz)
+				})
+			})
+
+			when("the download is interrupted", func() {
+				var contents []byte
+
+				it.Before(func() {
+					contents, err = os.ReadFile(tgz)
+					h.AssertNil(t, err)
+					half := len(contents) / 2
+
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", `"A"`)
+						w.Header().Add("Content-Length", strconv.Itoa(len(contents)))
+						w.WriteHeader(200)
+						w.Write(contents[:half])
+					})
+				})
+
+				it("resumes the download from where it stopped", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						h.AssertEq(t, r.Header.Get("Range"), fmt.Sprintf("bytes=%d-", len(contents)/2))
+						h.AssertEq(t, r.Header.Get("If-Range"), `"A"`)
+						w.Header().Add("ETag", `"A"`)
+						w.WriteHeader(206)
+						w.Write(contents[len(contents)/2:])
+					})
+
+					var callBuf bytes.Buffer
+					b, err := blob.DownloaderWithLogger(subject, &logger{&callBuf}).Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertContains(t, callBuf.String(), "Resuming download from")
+				})
+
+				it("starts over when the server sends the whole file", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", `"B"`)
+						w.WriteHeader(200)
+						w.Write(contents)
+					})
+
+					b, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				it("starts over right away when the partial download doesn't fit the file anymore", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						h.AssertEq(t, r.Header.Get("Range"), fmt.Sprintf("bytes=%d-", len(contents)/2))
+						w.WriteHeader(416)
+					})
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						h.AssertEq(t, r.Header.Get("Range"), "")
+						w.Header().Add("ETag", `"B"`)
+						w.WriteHeader(200)
+						w.Write(contents)
+					})
+
+					b, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 3)
+				})
+
+				it("doesn't use the partial download as the cache", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(500)
+					})
+
+					_, err := subject.Download(context.TODO(), uri)
+					h.AssertError(t, err, "http status '500'")
+
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						h.AssertEq(t, r.Header.Get("If-None-Match"), "")
+						w.Header().Add("ETag", `"A"`)
+						w.WriteHeader(206)
+						w.Write(contents[len(contents)/2:])
+					})
+
+					b, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+			})
+
+			when("uri is of cloud storage", func() {
+				it.Before(func() {
+					server.RouteToHandler(http.MethodGet, "/some-bucket/path/bp.tgz", func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", `"A"`)
+						http.ServeFile(w, r, tgz)
+					})
+				})
+
+				it("downloads from an 's3://' URI with the AWS config", func() {
+					t.Setenv("AWS_CONFIG_FILE", filepath.Join(cacheDir, "no-config"))
+					t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(cacheDir, "no-credentials"))
+					t.Setenv("AWS_ACCESS_KEY_ID", "some-key-id")
+					t.Setenv("AWS_SECRET_ACCESS_KEY", "some-secret")
+					t.Setenv("AWS_REGION", "some-region")
+					t.Setenv("AWS_ENDPOINT_URL", server.URL())
+
+					var callBuf bytes.Buffer
+					b, err := blob.DownloaderWithLogger(subject, &logger{&callBuf}).Download(context.TODO(), "s3://some-bucket/path/bp.tgz")
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertContains(t, callBuf.String(), "s3://some-bucket/path/bp.tgz")
+
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+					req := server.ReceivedRequests()[0]
+					h.AssertContains(t, req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=some-key-id/")
+					h.AssertContains(t, req.Header.Get("Authorization"), "/some-region/s3/aws4_request")
+				})
+
+				it("downloads from a 'gs://' URI of the storage emulator", func() {
+					t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(server.URL(), "http://"))
+
+					b, err := subject.Download(context.TODO(), "gs://some-bucket/path/bp.tgz")
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				when("the 'az://' URI has a SAS token", func() {
+					var (
+						sasURI       string
+						azureSubject blob.Downloader
+					)
+
+					it.Before(func() {
+						sasURI = "az://some-account/some-bucket/path/bp.tgz?sv=2021-08-06&sig=some-secret"
+						serverURL, err := url.Parse(server.URL())
+						h.AssertNil(t, err)
+						azureSubject = blob.NewDownloader(&logger{io.Discard}, cacheDir, blob.WithClient(&http.Client{
+							Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+								h.AssertEq(t, req.URL.Host, "some-account.blob.core.windows.net")
+								req.URL.Scheme, req.URL.Host = serverURL.Scheme, serverURL.Host
+								return http.DefaultTransport.RoundTrip(req)
+							}),
+						}))
+					})
+
+					it("authorizes the download with the SAS token without logging it", func() {
+						var callBuf bytes.Buffer
+						b, err := blob.DownloaderWithLogger(azureSubject, &logger{&callBuf}).Download(context.TODO(), sasURI)
+						h.AssertNil(t, err)
+						assertBlob(t, b)
+						h.AssertContains(t, callBuf.String(), "az://some-account/some-bucket/path/bp.tgz")
+						h.AssertNotContains(t, callBuf.String(), "some-secret")
+
+						h.AssertEq(t, len(server.ReceivedRequests()), 1)
+						h.AssertEq(t, server.ReceivedRequests()[0].URL.Query().Get("sig"), "some-secret")
+					})
+
+					it("uses the cache of the URI with another SAS token", func() {
+						_, err := azureSubject.Download(context.TODO(), sasURI)
+						h.AssertNil(t, err)
+
+						b, err := azureSubject.Download(context.TODO(), strings.Replace(sasURI, "some-secret", "other-secret", 1))
+						h.AssertNil(t, err)
+						assertBlob(t, b)
+						h.AssertEq(t, server.ReceivedRequests()[1].Header.Get("If-None-Match"), `"A"`)
+					})
+
+					it("doesn't show the SAS token in errors", func() {
+						server.Close()
+
+						_, err := azureSubject.Download(context.TODO(), sasURI)
+						h.AssertNotNil(t, err)
+						h.AssertNotContains(t, err.Error(), "some-secret")
+					})
+				})
+
+				it("fails for an 'az://' URI without a container", func() {
+					_, err := subject.Download(context.TODO(), "az://some-account/bp.tgz")
+					h.AssertError(t, err, "invalid URI 'az://some-account/bp.tgz', must be in the form 'az://<account>/<container>/<blob>'")
+				})
+
+				it("fails for a URI without an object", func() {
+					_, err := subject.Download(context.TODO(), "s3://some-bucket")
+					h.AssertError(t, err, "invalid URI 's3://some-bucket', must be in the form 's3://<bucket>/<object>'")
+				})
+			})
+
+			when("uri is a git repository", func() {
+				var repoDir string
+
+				it.Before(func() {
+					repoDir, err = os.MkdirTemp("", "repo")
+					h.AssertNil(t, err)
+					h.AssertNil(t, os.WriteFile(filepath.Join(repoDir, "file.txt"), []byte("contents"), 0600))
+					for _, args := range [][]string{
+						{"init", "--initial-branch", "main"},
+						{"add", "file.txt"},
+						{"commit", "--message", "add file"},
+						{"tag", "v1"},
+					} {
+						cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+						cmd.Dir = repoDir
+						out, err := cmd.CombinedOutput()
+						h.AssertNil(t, errors.Wrap(err, string(out)))
+					}
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.RemoveAll(repoDir))
+				})
+
+				it("clones the ref of a 'git+file://' URI without its git metadata", func() {
+					var callBuf bytes.Buffer
+					b, err := blob.DownloaderWithLogger(subject, &logger{&callBuf}).Download(context.TODO(), "git+file://"+repoDir+"#v1")
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertContains(t, callBuf.String(), "Cloning")
+
+					r, err := b.Open()
+					h.AssertNil(t, err)
+					defer r.Close()
+					_, _, err = archive.ReadTarEntry(r, ".git/HEAD")
+					h.AssertError(t, err, "could not find entry path")
+				})
+
+				it("uses the cached clone of the commit", func() {
+					_, err := subject.Download(context.TODO(), "git+file://"+repoDir+"#v1")
+					h.AssertNil(t, err)
+
+					var callBuf bytes.Buffer
+					b, err := blob.DownloaderWithLogger(subject, &logger{&callBuf}).Download(context.TODO(), "git+file://"+repoDir+"#v1")
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertNotContains(t, callBuf.String(), "Cloning")
+				})
+			})
+
 			when("uri is invalid", func() {
 				when("uri file is not found", func() {
 					it.Before(func() {
@@ -177,6 +452,12 @@ func assertBlob(t *testing.T, b blob.Blob) {
 	h.AssertEq(t, string(bytes), "contents")
 }
 
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 type logger struct {
 	writer io.Writer
 }
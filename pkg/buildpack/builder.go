@@ -59,6 +59,15 @@ func (i *layoutImage) SetLabel(key string, val string) error {
 	return err
 }
 
+func (i *layoutImage) SetAnnotations(annotations map[string]string) error {
+	mutated, ok := mutate.Annotations(i.Image, annotations).(v1.Image)
+	if !ok {
+		return errors.New("failed to add annotations")
+	}
+	i.Image = mutated
+	return nil
+}
+
 func (i *layoutImage) AddLayerWithDiffID(path, _ string) error {
 	tarLayer, err := tarball.LayerFromFile(path, tarball.WithCompressionLevel(gzip.DefaultCompression))
 	if err != nil {
@@ -74,10 +83,11 @@ func (i *layoutImage) AddLayerWithDiffID(path, _ string) error {
 type PackageBuilderOption func(*options) error
 
 type options struct {
-	flatten bool
-	exclude []string
-	logger  logging.Logger
-	factory archive.TarWriterFactory
+	flatten     bool
+	exclude     []string
+	logger      logging.Logger
+	factory     archive.TarWriterFactory
+	annotations map[string]string
 }
 
 type PackageBuilder struct {
@@ -89,6 +99,7 @@ type PackageBuilder struct {
 	imageFactory             ImageFactory
 	flattenAllBuildpacks     bool
 	flattenExcludeBuildpacks []string
+	annotations              map[string]string
 }
 
 // TODO: Rename to PackageBuilder
@@ -107,6 +118,7 @@ func NewBuilder(imageFactory ImageFactory, ops ...PackageBuilderOption) *Package
 		flattenExcludeBuildpacks: opts.exclude,
 		logger:                   opts.logger,
 		layerWriterFactory:       opts.factory,
+		annotations:              opts.annotations,
 	}
 }
 
@@ -139,6 +151,13 @@ func WithLayerWriterFactory(factory archive.TarWriterFactory) PackageBuilderOpti
 	}
 }
 
+func WithAnnotations(annotations map[string]string) PackageBuilderOption {
+	return func(o *options) error {
+		o.annotations = annotations
+		return nil
+	}
+}
+
 func (b *PackageBuilder) SetBuildpack(buildpack BuildModule) {
 	b.buildpack = buildpack
 }
@@ -353,6 +372,32 @@ func (b *PackageBuilder) resolvedStacks() []dist.Stack {
 }
 
 func (b *PackageBuilder) SaveAsFile(path string, target dist.Target, labels map[string]string) error {
+	tmpDir, err := os.MkdirTemp("", "package-file")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	layoutDir := filepath.Join(tmpDir, "oci-layout")
+	if err := b.SaveAsLayout(layoutDir, target, labels); err != nil {
+		return err
+	}
+
+	outputFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "creating output file")
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+}
+
+// SaveAsLayout saves the package as an OCI image layout in the given directory, which is created when it does not
+// exist. The layout holds the package alone, replacing any image it held before.
+func (b *PackageBuilder) SaveAsLayout(path string, target dist.Target, labels map[string]string) error {
 	if err := b.validate(); err != nil {
 		return err
 	}
@@ -391,30 +436,21 @@ func (b *PackageBuilder) SaveAsFile(path string, target dist.Target, labels map[
 			return err
 		}
 	}
-	layoutDir, err := os.MkdirTemp(tmpDir, "oci-layout")
+	p, err := layout.Write(path, empty.Index)
 	if err != nil {
-		return errors.Wrap(err, "creating oci-layout temp dir")
+		return errors.Wrap(err, "writing index")
 	}
 
-	p, err := layout.Write(layoutDir, empty.Index)
-	if err != nil {
-		return errors.Wrap(err, "writing index")
+	if len(b.annotations) > 0 {
+		if err := layoutImage.SetAnnotations(b.annotations); err != nil {
+			return errors.Wrap(err, "adding annotations")
+		}
 	}
 
 	if err := p.AppendImage(layoutImage); err != nil {
 		return errors.Wrap(err, "writing layout")
 	}
-
-	outputFile, err := os.Create(path)
-	if err != nil {
-		return errors.Wrap(err, "creating output file")
-	}
-	defer outputFile.Close()
-
-	tw := tar.NewWriter(outputFile)
-	defer tw.Close()
-
-	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+	return nil
 }
 
 func newLayoutImage(target dist.Target) (*layoutImage, error) {
@@ -491,6 +527,12 @@ func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, target dist.
 		}
 	}
 
+	if len(b.annotations) > 0 {
+		if err := image.SetAnnotations(b.annotations); err != nil {
+			return nil, errors.Wrap(err, "adding annotations")
+		}
+	}
+
 	if err := image.Save(); err != nil {
 		return nil, err
 	}
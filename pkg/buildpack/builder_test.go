@@ -1010,6 +1010,34 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 				}))
 		})
 
+		it("sets annotations on the manifest", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.2"),
+				WithInfo:   dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+				WithStacks: []dist.Stack{{ID: "stack.id.1"}},
+				WithOrder:  nil,
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory(""), buildpack.WithAnnotations(map[string]string{"org.opencontainers.image.source": "https://example.com/repo"}))
+			builder.SetBuildpack(buildpack1)
+
+			outputFile := filepath.Join(tmpDir, fmt.Sprintf("package-%s.cnb", h.RandString(10)))
+			h.AssertNil(t, builder.SaveAsFile(outputFile, dist.Target{OS: "linux"}, map[string]string{}))
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json",
+				func(t *testing.T, header *tar.Header, data []byte) {
+					index := v1.Index{}
+					h.AssertNil(t, json.Unmarshal(data, &index))
+					h.AssertEq(t, len(index.Manifests), 1)
+
+					h.AssertOnTarEntry(t, outputFile,
+						"/blobs/sha256/"+index.Manifests[0].Digest.Hex(),
+						h.ContentContains(`"org.opencontainers.image.source":"https://example.com/repo"`),
+					)
+				})
+		})
+
 		it("adds buildpack layers", func() {
 			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
 				WithAPI:    api.MustParse("0.2"),
@@ -128,7 +128,10 @@ func readDescriptor(kind string, descriptor interface{}, blob Blob) (undecodedKe
 		return undecodedKeys, errors.Wrapf(err, "decoding %s", descriptorFile)
 	}
 
-	undecoded := md.Undecoded()
+	return filterUndecodedKeys(md.Undecoded()), nil
+}
+
+func filterUndecodedKeys(undecoded []toml.Key) (undecodedKeys []string) {
 	for _, k := range undecoded {
 		// FIXME: we should ideally update dist.ModuleInfo to expect sbom-formats, but this breaks other tests;
 		// it isn't possible to make [metadata] a decoded key because its type is undefined in the buildpack spec.
@@ -140,7 +143,7 @@ func readDescriptor(kind string, descriptor interface{}, blob Blob) (undecodedKe
 		undecodedKeys = append(undecodedKeys, k.String())
 	}
 
-	return undecodedKeys, nil
+	return undecodedKeys
 }
 
 func detectPlatformSpecificValues(descriptor *dist.BuildpackDescriptor, blob Blob) error {
@@ -0,0 +1,72 @@
+package buildpack
+
+import (
+	"fmt"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// BuildpacksFromDockerArchive constructs buildpacks from a buildpackage in an image archive, as written by
+// `docker save` or other tools exporting images to tarballs.
+func BuildpacksFromDockerArchive(path string) (mainBP BuildModule, dependencies []BuildModule, err error) {
+	archivePackage, err := newDockerArchivePackage(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return extractBuildpacks(archivePackage)
+}
+
+// ExtensionsFromDockerArchive constructs extensions from an extension package in an image archive.
+func ExtensionsFromDockerArchive(path string) (mainExt BuildModule, err error) {
+	archivePackage, err := newDockerArchivePackage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractExtensions(archivePackage)
+}
+
+type dockerArchivePackage struct {
+	image  v1.Image
+	labels map[string]string
+}
+
+func newDockerArchivePackage(path string) (*dockerArchivePackage, error) {
+	img, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading image archive %s", style.Symbol(path))
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading config of image archive %s", style.Symbol(path))
+	}
+
+	return &dockerArchivePackage{
+		image:  img,
+		labels: configFile.Config.Labels,
+	}, nil
+}
+
+func (d *dockerArchivePackage) Label(name string) (value string, err error) {
+	return d.labels[name], nil
+}
+
+func (d *dockerArchivePackage) GetLayer(diffID string) (io.ReadCloser, error) {
+	hash, err := v1.NewHash(diffID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing diff id %s", style.Symbol(diffID))
+	}
+
+	layer, err := d.image.LayerByDiffID(hash)
+	if err != nil {
+		return nil, fmt.Errorf("layer blob %s not found", style.Symbol(diffID))
+	}
+	return layer.Uncompressed()
+}
@@ -0,0 +1,132 @@
+package buildpack_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDockerArchivePackage(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DockerArchivePackage", testDockerArchivePackage, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDockerArchivePackage(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "docker-archive-package")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#BuildpacksFromDockerArchive", func() {
+		it("extracts buildpacks", func() {
+			archivePath := createDockerArchive(t, tmpDir, "some.bp", "1.2.3")
+
+			mainBP, depBPs, err := buildpack.BuildpacksFromDockerArchive(archivePath)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, mainBP.Descriptor().Info().ID, "some.bp")
+			h.AssertEq(t, mainBP.Descriptor().Info().Version, "1.2.3")
+			h.AssertEq(t, len(depBPs), 0)
+		})
+
+		it("provides readable blobs", func() {
+			archivePath := createDockerArchive(t, tmpDir, "some.bp", "1.2.3")
+
+			mainBP, _, err := buildpack.BuildpacksFromDockerArchive(archivePath)
+			h.AssertNil(t, err)
+
+			reader, err := mainBP.Open()
+			h.AssertNil(t, err)
+			defer reader.Close()
+
+			_, contents, err := archive.ReadTarEntry(reader, "/cnb/buildpacks/some.bp/1.2.3/buildpack.toml")
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), "some.bp")
+		})
+
+		when("the archive doesn't exist", func() {
+			it("errors", func() {
+				_, _, err := buildpack.BuildpacksFromDockerArchive(filepath.Join(tmpDir, "missing.tar"))
+				h.AssertError(t, err, "reading image archive")
+			})
+		})
+
+		when("the image isn't a buildpackage", func() {
+			it("errors", func() {
+				archivePath := filepath.Join(tmpDir, "not-a-package.tar")
+				h.AssertNil(t, tarball.WriteToFile(archivePath, name.MustParseReference("some/image"), empty.Image))
+
+				_, _, err := buildpack.BuildpacksFromDockerArchive(archivePath)
+				h.AssertError(t, err, fmt.Sprintf("could not find label '%s'", buildpack.MetadataLabel))
+			})
+		})
+	})
+}
+
+// createDockerArchive writes a buildpackage of a single buildpack to an image archive, as `docker save` would
+func createDockerArchive(t *testing.T, dir, id, version string) string {
+	t.Helper()
+
+	descriptor := dist.BuildpackDescriptor{
+		WithAPI:    api.MustParse("0.3"),
+		WithInfo:   dist.ModuleInfo{ID: id, Version: version},
+		WithStacks: []dist.Stack{{ID: "*"}},
+	}
+	bp, err := ifakes.NewFakeBuildpack(descriptor, 0644)
+	h.AssertNil(t, err)
+
+	layerTar, err := buildpack.ToLayerTar(dir, bp)
+	h.AssertNil(t, err)
+	layer, err := tarball.LayerFromFile(layerTar)
+	h.AssertNil(t, err)
+	diffID, err := layer.DiffID()
+	h.AssertNil(t, err)
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	h.AssertNil(t, err)
+
+	metadata, err := json.Marshal(buildpack.Metadata{ModuleInfo: descriptor.WithInfo, Stacks: descriptor.WithStacks})
+	h.AssertNil(t, err)
+	layers, err := json.Marshal(dist.ModuleLayers{
+		id: {version: {API: descriptor.WithAPI, Stacks: descriptor.WithStacks, LayerDiffID: diffID.String()}},
+	})
+	h.AssertNil(t, err)
+
+	configFile, err := img.ConfigFile()
+	h.AssertNil(t, err)
+	configFile.Config.Labels = map[string]string{
+		buildpack.MetadataLabel:   string(metadata),
+		dist.BuildpackLayersLabel: string(layers),
+	}
+	img, err = mutate.ConfigFile(img, configFile)
+	h.AssertNil(t, err)
+
+	archivePath := filepath.Join(dir, "buildpackage.tar")
+	h.AssertNil(t, tarball.WriteToFile(archivePath, name.MustParseReference("some/buildpackage"), img))
+	return archivePath
+}
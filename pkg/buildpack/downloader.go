@@ -3,6 +3,7 @@ package buildpack
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
 type Logger interface {
@@ -80,9 +82,24 @@ type DownloadOptions struct {
 
 	// The OS/Architecture/Variant to download.
 	Target *dist.Target
+
+	// SHA256 digest of the archive of a URI, verified once downloaded. Modules of other locators, which are
+	// addressed by digest already when they are images, aren't verified.
+	SHA256 string
+
+	// Logger overrides the logger of the downloader, for the downloads of an operation to be logged along with its
+	// output.
+	Logger logging.Logger
 }
 
 func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, opts DownloadOptions) (BuildModule, []BuildModule, error) {
+	if opts.Logger != nil {
+		withLogger := *c
+		withLogger.logger = opts.Logger
+		withLogger.downloader = blob.DownloaderWithLogger(c.downloader, opts.Logger)
+		c = &withLogger
+	}
+
 	kind := KindBuildpack
 	if opts.ModuleKind == KindExtension {
 		kind = KindExtension
@@ -110,6 +127,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			Daemon:     opts.Daemon,
 			PullPolicy: opts.PullPolicy,
 			Target:     opts.Target,
+			Logger:     opts.Logger,
 		})
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
@@ -125,10 +143,22 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			Daemon:     opts.Daemon,
 			PullPolicy: opts.PullPolicy,
 			Target:     opts.Target,
+			Logger:     opts.Logger,
 		})
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
+	case DockerArchiveLocator:
+		archivePath := ParseDockerArchiveLocator(moduleURI)
+		if !filepath.IsAbs(archivePath) {
+			archivePath = filepath.Join(opts.RelativeBaseDir, archivePath)
+		}
+
+		c.logger.Debugf("Reading %s from image archive: %s", kind, style.Symbol(archivePath))
+		mainBP, depBPs, err = extractDockerArchive(kind, archivePath)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "extracting from %s", style.Symbol(moduleURI))
+		}
 	case URILocator:
 		moduleURI, err = paths.FilePathToURI(moduleURI, opts.RelativeBaseDir)
 		if err != nil {
@@ -137,16 +167,25 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 
 		c.logger.Debugf("Downloading %s from URI: %s", kind, style.Symbol(moduleURI))
 
-		blob, err := c.downloader.Download(ctx, moduleURI)
+		moduleBlob, err := c.downloader.Download(ctx, moduleURI)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "downloading %s from %s", kind, style.Symbol(moduleURI))
 		}
+		if opts.SHA256 != "" {
+			if err := blob.VerifySHA256(moduleBlob, opts.SHA256); err != nil {
+				// the next download starts over rather than finding the same download in the cache
+				if removeErr := blob.RemoveCached(moduleBlob); removeErr != nil {
+					c.logger.Debugf("Removing %s from the cache: %s", style.Symbol(moduleURI), removeErr)
+				}
+				return nil, nil, errors.Wrapf(err, "verifying %s from %s", kind, style.Symbol(moduleURI))
+			}
+		}
 
 		imageOS := opts.ImageOS
 		if opts.Target != nil {
 			imageOS = opts.Target.OS
 		}
-		mainBP, depBPs, err = decomposeBlob(blob, kind, imageOS, c.logger)
+		mainBP, depBPs, err = decomposeBlob(moduleBlob, kind, imageOS, c.logger)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from %s", style.Symbol(moduleURI))
 		}
@@ -203,6 +242,18 @@ func fromOCILayoutBlob(blob blob.Blob, kind string) (mainModule BuildModule, dep
 	return mainModule, depModules, nil
 }
 
+func extractDockerArchive(kind string, path string) (mainModule BuildModule, depModules []BuildModule, err error) {
+	switch kind {
+	case KindBuildpack:
+		return BuildpacksFromDockerArchive(path)
+	case KindExtension:
+		mainModule, err = ExtensionsFromDockerArchive(path)
+		return mainModule, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown module kind: %s", kind)
+	}
+}
+
 func extractPackaged(ctx context.Context, kind string, pkgImageRef string, fetcher ImageFetcher, fetchOptions image.FetchOptions) (mainModule BuildModule, depModules []BuildModule, err error) {
 	pkgImage, err := fetcher.Fetch(ctx, pkgImageRef, fetchOptions)
 	if err != nil {
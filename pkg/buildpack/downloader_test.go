@@ -3,6 +3,8 @@ package buildpack_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -199,6 +201,31 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 					h.AssertNil(t, err)
 					h.AssertEq(t, mainBP.Descriptor().Info().ID, "example/foo")
 				})
+
+				it("logs with the logger of the options, when they have one", func() {
+					packageImage = createPackage("some/package:tag")
+					var callBuf bytes.Buffer
+					callLogger := logging.NewLogWithWriters(&callBuf, &callBuf, logging.WithVerbose())
+					downloadOptions = buildpack.DownloadOptions{
+						Daemon:     true,
+						PullPolicy: image.PullAlways,
+						Target:     &dist.Target{OS: "linux", Arch: "amd64"},
+						ImageName:  "some/package:tag",
+						Logger:     callLogger,
+					}
+
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), packageImage.Name(), image.FetchOptions{
+						Daemon:     true,
+						PullPolicy: image.PullAlways,
+						Target:     &dist.Target{OS: "linux", Arch: "amd64"},
+						Logger:     callLogger,
+					}).Return(packageImage, nil)
+					out.Reset()
+					_, _, err := buildpackDownloader.Download(context.TODO(), "", downloadOptions)
+					h.AssertNil(t, err)
+					h.AssertContains(t, callBuf.String(), "The 'image' key is deprecated")
+					h.AssertNotContains(t, out.String(), "The 'image' key is deprecated")
+				})
 			})
 
 			when("daemon=true and pull-policy=always", func() {
@@ -335,6 +362,71 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("package lives at a URI with a sha256", func() {
+			var (
+				buildpackURI = "https://example.fake/bp-sha256.tgz"
+				archivePath  string
+			)
+
+			it.Before(func() {
+				archivePath = h.CreateTGZ(t, filepath.Join("testdata", "buildpack"), ".", -1)
+				mockDownloader.EXPECT().Download(gomock.Any(), buildpackURI).Return(blob.NewBlob(archivePath), nil).AnyTimes()
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Remove(archivePath))
+			})
+
+			it("retrieves the package when the digest matches", func() {
+				contents, err := os.ReadFile(archivePath)
+				h.AssertNil(t, err)
+				sum := sha256.Sum256(contents)
+
+				downloadOptions.SHA256 = "sha256:" + hex.EncodeToString(sum[:])
+				mainBP, _, err := buildpackDownloader.Download(context.TODO(), buildpackURI, downloadOptions)
+				h.AssertNil(t, err)
+				h.AssertEq(t, mainBP.Descriptor().Info().ID, "bp.one")
+			})
+
+			it("fails when the digest doesn't match", func() {
+				downloadOptions.SHA256 = "sha256:0123"
+				_, _, err := buildpackDownloader.Download(context.TODO(), buildpackURI, downloadOptions)
+				h.AssertError(t, err, "verifying buildpack from 'https://example.fake/bp-sha256.tgz'")
+				h.AssertError(t, err, "expected sha256:0123")
+				h.AssertPathExists(t, archivePath)
+			})
+		})
+
+		when("package lives in a docker archive", func() {
+			it("should successfully retrieve package from absolute path", func() {
+				archivePath, err := filepath.Abs(createDockerArchive(t, tmpDir, "archived.bp", "1.2.3"))
+				h.AssertNil(t, err)
+
+				mainBP, _, err := buildpackDownloader.Download(context.TODO(), "docker-archive:"+archivePath, downloadOptions)
+				h.AssertNil(t, err)
+				h.AssertEq(t, mainBP.Descriptor().Info().ID, "archived.bp")
+			})
+
+			it("should successfully retrieve package from relative path", func() {
+				createDockerArchive(t, tmpDir, "archived.bp", "1.2.3")
+				downloadOptions = buildpack.DownloadOptions{
+					Target:          &dist.Target{OS: "linux"},
+					RelativeBaseDir: tmpDir,
+				}
+
+				mainBP, _, err := buildpackDownloader.Download(context.TODO(), "docker-archive:buildpackage.tar", downloadOptions)
+				h.AssertNil(t, err)
+				h.AssertEq(t, mainBP.Descriptor().Info().ID, "archived.bp")
+			})
+
+			when("the archive doesn't exist", func() {
+				it("errors", func() {
+					_, _, err := buildpackDownloader.Download(context.TODO(), "docker-archive:"+filepath.Join(tmpDir, "missing.tar"), downloadOptions)
+					h.AssertError(t, err, "extracting from 'docker-archive:")
+				})
+			})
+		})
+
 		when("package image is not a valid package", func() {
 			it("errors", func() {
 				notPackageImage := fakes.NewImage("docker.io/not/package", "", nil)
@@ -0,0 +1,312 @@
+package buildpack
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+
+	buildpackDescriptorFile = "buildpack.toml"
+	execDDir                = "exec.d"
+)
+
+// reservedBuildpackIDs may not be used as buildpack IDs, per the buildpack spec
+var reservedBuildpackIDs = []string{"app", "config", "sbom"}
+
+// LintFinding is a single problem found while linting a buildpack
+type LintFinding struct {
+	Buildpack string `json:"buildpack,omitempty"`
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity"`
+	Path      string `json:"path,omitempty"`
+	Message   string `json:"message"`
+}
+
+// HasLintErrors returns true if any of the findings has error severity
+func HasLintErrors(findings []LintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+type lintEntry struct {
+	name     string
+	mode     int64
+	typeflag byte
+}
+
+type lintTarget struct {
+	entries       []lintEntry
+	descriptor    []byte
+	hasDescriptor bool
+}
+
+// LintRootBlob lints a buildpack whose contents reside at the root of the blob, such as a buildpack directory.
+func LintRootBlob(blob Blob) ([]LintFinding, error) {
+	rc, err := blob.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "open buildpack")
+	}
+	defer rc.Close()
+
+	target := &lintTarget{}
+	if err := readLintEntries(rc, func(name string) (*lintTarget, string) {
+		return target, name
+	}); err != nil {
+		return nil, err
+	}
+
+	return lintBuildpack(target), nil
+}
+
+// LintModule lints every buildpack contained in a module whose contents are structured as per the distribution spec.
+// Extensions contained in the module are not linted.
+func LintModule(module BuildModule) ([]LintFinding, error) {
+	rc, err := module.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "open buildpack")
+	}
+	defer rc.Close()
+
+	var (
+		keys    []string
+		targets = map[string]*lintTarget{}
+	)
+	if err := readLintEntries(rc, func(name string) (*lintTarget, string) {
+		// '/cnb/buildpacks/{ID}/{version}/...'
+		parts := strings.Split(strings.TrimPrefix(name, "/"), "/")
+		if len(parts) < 5 || path.Join("/", parts[0], parts[1]) != dist.BuildpacksDir {
+			return nil, ""
+		}
+
+		key := path.Join(parts[2], parts[3])
+		if _, ok := targets[key]; !ok {
+			targets[key] = &lintTarget{}
+			keys = append(keys, key)
+		}
+		return targets[key], path.Join(parts[4:]...)
+	}); err != nil {
+		return nil, err
+	}
+
+	var findings []LintFinding
+	for _, key := range keys {
+		findings = append(findings, lintBuildpack(targets[key])...)
+	}
+	return findings, nil
+}
+
+func readLintEntries(rc io.Reader, targetFor func(name string) (*lintTarget, string)) error {
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to get next tar entry")
+		}
+
+		name := path.Clean(header.Name)
+		if name == "." || name == "/" {
+			continue
+		}
+
+		target, relName := targetFor(name)
+		if target == nil || relName == "" || relName == "." {
+			continue
+		}
+		relName = strings.TrimPrefix(relName, "/")
+
+		target.entries = append(target.entries, lintEntry{name: relName, mode: header.Mode, typeflag: header.Typeflag})
+		if relName == buildpackDescriptorFile {
+			buf, err := io.ReadAll(tr)
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", buildpackDescriptorFile)
+			}
+			target.descriptor = buf
+			target.hasDescriptor = true
+		}
+	}
+}
+
+func lintBuildpack(target *lintTarget) []LintFinding {
+	var findings []LintFinding
+	var bpName string
+	add := func(rule, severity, filePath, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{
+			Buildpack: bpName,
+			Rule:      rule,
+			Severity:  severity,
+			Path:      filePath,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	if !target.hasDescriptor {
+		add("descriptor", LintSeverityError, buildpackDescriptorFile, "%s not found", buildpackDescriptorFile)
+		return findings
+	}
+
+	descriptor := dist.BuildpackDescriptor{}
+	md, err := toml.Decode(string(target.descriptor), &descriptor)
+	if err != nil {
+		add("descriptor", LintSeverityError, buildpackDescriptorFile, "decoding %s: %s", buildpackDescriptorFile, err)
+		return findings
+	}
+	if descriptor.Info().ID != "" {
+		bpName = descriptor.Info().FullName()
+	}
+
+	for _, key := range filterUndecodedKeys(md.Undecoded()) {
+		add("unknown-key", LintSeverityWarning, buildpackDescriptorFile, "unexpected key %s", style.Symbol(key))
+	}
+
+	if descriptor.Info().ID == "" {
+		add("required-field", LintSeverityError, buildpackDescriptorFile, "%s is required", style.Symbol("buildpack.id"))
+	}
+	if descriptor.Info().Version == "" {
+		add("required-field", LintSeverityError, buildpackDescriptorFile, "%s is required", style.Symbol("buildpack.version"))
+	}
+	for _, id := range reservedBuildpackIDs {
+		if descriptor.Info().ID == id {
+			add("reserved-id", LintSeverityError, buildpackDescriptorFile, "buildpack id %s is reserved", style.Symbol(id))
+		}
+	}
+
+	if descriptor.WithAPI == nil {
+		descriptor.WithAPI = api.MustParse(dist.AssumedBuildpackAPIVersion)
+		add("api", LintSeverityWarning, buildpackDescriptorFile, "%s is not set, Buildpack API %s is assumed", style.Symbol("api"), dist.AssumedBuildpackAPIVersion)
+	}
+	bpAPI := descriptor.API()
+	switch {
+	case api.Buildpack.IsDeprecated(bpAPI):
+		add("api", LintSeverityWarning, buildpackDescriptorFile, "Buildpack API %s is deprecated", bpAPI.String())
+	case !api.Buildpack.IsSupported(bpAPI) && bpAPI.Compare(api.Buildpack.Latest()) > 0:
+		add("api", LintSeverityError, buildpackDescriptorFile, "Buildpack API %s is newer than the latest supported Buildpack API %s", bpAPI.String(), api.Buildpack.Latest().String())
+	case !api.Buildpack.IsSupported(bpAPI):
+		add("api", LintSeverityWarning, buildpackDescriptorFile, "Buildpack API %s is not supported by current lifecycles (supported: %s)", bpAPI.String(), api.Buildpack.Supported.String())
+	}
+
+	if len(descriptor.Order()) > 0 {
+		if len(descriptor.Stacks()) > 0 || len(descriptor.Targets()) > 0 {
+			add("order", LintSeverityError, buildpackDescriptorFile, "cannot have both %s/%s and an %s defined", style.Symbol("targets"), style.Symbol("stacks"), style.Symbol("order"))
+		}
+		if target.has("bin") {
+			add("order", LintSeverityWarning, "bin", "%s is ignored for buildpacks with an %s", style.Symbol("bin"), style.Symbol("order"))
+		}
+	} else {
+		for _, executable := range []string{"detect", "build"} {
+			if !target.hasExecutable(path.Join("bin", executable)) {
+				add("bin", LintSeverityError, path.Join("bin", executable), "%s is required", style.Symbol(path.Join("bin", executable)))
+			}
+		}
+
+		if len(descriptor.Stacks()) > 0 && bpAPI.AtLeast("0.10") {
+			add("stacks", LintSeverityWarning, buildpackDescriptorFile, "%s are deprecated as of Buildpack API 0.10, use %s instead", style.Symbol("stacks"), style.Symbol("targets"))
+		}
+		if len(descriptor.Targets()) == 0 && bpAPI.AtLeast("0.10") {
+			add("targets", LintSeverityWarning, buildpackDescriptorFile, "no %s are declared, they will be inferred from the contents of %s", style.Symbol("targets"), style.Symbol("bin"))
+		}
+
+		for _, stack := range descriptor.Stacks() {
+			switch {
+			case stack.ID == "":
+				add("stacks", LintSeverityError, buildpackDescriptorFile, "stack %s is required", style.Symbol("id"))
+			case stack.ID == "*" && len(stack.Mixins) > 0:
+				add("stacks", LintSeverityError, buildpackDescriptorFile, "stack %s cannot declare mixins", style.Symbol("*"))
+			}
+		}
+
+		for _, t := range descriptor.Targets() {
+			switch t.OS {
+			case "":
+				add("targets", LintSeverityError, buildpackDescriptorFile, "target %s is required", style.Symbol("os"))
+			case dist.DefaultTargetOSLinux, dist.DefaultTargetOSWindows:
+			default:
+				add("targets", LintSeverityWarning, buildpackDescriptorFile, "target os %s is not recognized", style.Symbol(t.OS))
+			}
+			for _, distro := range t.Distributions {
+				if distro.Name == "" {
+					add("targets", LintSeverityError, buildpackDescriptorFile, "target distribution %s is required", style.Symbol("name"))
+				}
+			}
+		}
+	}
+
+	warnedExecDAPI := false
+	for _, entry := range target.entries {
+		parts := strings.Split(entry.name, "/")
+		idx := indexOf(parts, execDDir)
+		if idx < 0 {
+			continue
+		}
+
+		if !warnedExecDAPI && bpAPI.LessThan("0.5") {
+			add("exec.d", LintSeverityWarning, path.Join(parts[:idx+1]...), "%s is only supported as of Buildpack API 0.5", style.Symbol(execDDir))
+			warnedExecDAPI = true
+		}
+
+		rest := parts[idx+1:]
+		switch entry.typeflag {
+		case tar.TypeDir:
+			if len(rest) > 1 {
+				add("exec.d", LintSeverityError, entry.name, "%s may only contain executables or %s directories", style.Symbol(execDDir), style.Symbol("<process>"))
+			}
+		case tar.TypeReg, tar.TypeSymlink:
+			if len(rest) > 2 {
+				add("exec.d", LintSeverityError, entry.name, "executables must be placed directly in %s or %s", style.Symbol(execDDir), style.Symbol(path.Join(execDDir, "<process>")))
+			} else if entry.typeflag != tar.TypeSymlink && !anyExecBit(entry.mode) {
+				add("exec.d", LintSeverityError, entry.name, "%s is not executable", style.Symbol(entry.name))
+			}
+		}
+	}
+
+	return findings
+}
+
+func (t *lintTarget) has(name string) bool {
+	for _, entry := range t.entries {
+		if entry.name == name || strings.HasPrefix(entry.name, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *lintTarget) hasExecutable(name string) bool {
+	for _, entry := range t.entries {
+		if entry.typeflag == tar.TypeDir {
+			continue
+		}
+		if entry.name == name || entry.name == name+".bat" || entry.name == name+".exe" {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOf(parts []string, s string) int {
+	for i, p := range parts {
+		if p == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,246 @@
+package buildpack_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLint(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Lint", testLint, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLint(t *testing.T, when spec.G, it spec.S) {
+	var bpDir string
+
+	writeFile := func(name, contents string, mode os.FileMode) {
+		t.Helper()
+		p := filepath.Join(bpDir, filepath.FromSlash(name))
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(p), 0755))
+		h.AssertNil(t, os.WriteFile(p, []byte(contents), mode))
+	}
+
+	findRule := func(findings []buildpack.LintFinding, rule string) []buildpack.LintFinding {
+		var found []buildpack.LintFinding
+		for _, f := range findings {
+			if f.Rule == rule {
+				found = append(found, f)
+			}
+		}
+		return found
+	}
+
+	it.Before(func() {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes are not preserved on Windows")
+		}
+
+		var err error
+		bpDir, err = os.MkdirTemp("", "lint-test")
+		h.AssertNil(t, err)
+
+		writeFile("bin/detect", "", 0755)
+		writeFile("bin/build", "", 0755)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(bpDir))
+	})
+
+	when("#LintRootBlob", func() {
+		it("has no findings for a valid buildpack", func() {
+			writeFile("buildpack.toml", `
+api = "0.10"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+[[targets]]
+os = "linux"
+arch = "amd64"
+`, 0644)
+			writeFile("exec.d/some-exec", "", 0755)
+			writeFile("exec.d/web/some-exec", "", 0755)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(findings), 0)
+			h.AssertFalse(t, buildpack.HasLintErrors(findings))
+		})
+
+		it("reports a missing descriptor", func() {
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			h.AssertEq(t, findings, []buildpack.LintFinding{{
+				Rule:     "descriptor",
+				Severity: buildpack.LintSeverityError,
+				Path:     "buildpack.toml",
+				Message:  "buildpack.toml not found",
+			}})
+		})
+
+		it("reports schema problems", func() {
+			writeFile("buildpack.toml", `
+api = "0.10"
+some-key = "some-value"
+[buildpack]
+id = "app"
+[metadata]
+anything = "goes"
+[[targets]]
+os = "linux"
+`, 0644)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			h.AssertTrue(t, buildpack.HasLintErrors(findings))
+
+			unknown := findRule(findings, "unknown-key")
+			h.AssertEq(t, len(unknown), 1)
+			h.AssertEq(t, unknown[0].Severity, buildpack.LintSeverityWarning)
+			h.AssertEq(t, unknown[0].Message, "unexpected key 'some-key'")
+
+			h.AssertEq(t, findRule(findings, "required-field")[0].Message, "'buildpack.version' is required")
+			h.AssertEq(t, findRule(findings, "reserved-id")[0].Message, "buildpack id 'app' is reserved")
+		})
+
+		it("reports api pitfalls", func() {
+			writeFile("buildpack.toml", `
+api = "0.99"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+`, 0644)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			apiFindings := findRule(findings, "api")
+			h.AssertEq(t, len(apiFindings), 1)
+			h.AssertEq(t, apiFindings[0].Severity, buildpack.LintSeverityError)
+			h.AssertContains(t, apiFindings[0].Message, "Buildpack API 0.99 is newer than the latest supported Buildpack API")
+		})
+
+		it("reports stack and target declaration problems", func() {
+			writeFile("buildpack.toml", `
+api = "0.10"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+[[stacks]]
+id = "*"
+mixins = ["some-mixin"]
+[[targets]]
+os = "plan9"
+`, 0644)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+
+			stacks := findRule(findings, "stacks")
+			h.AssertEq(t, len(stacks), 2)
+			h.AssertContains(t, stacks[0].Message, "'stacks' are deprecated as of Buildpack API 0.10")
+			h.AssertEq(t, stacks[1].Message, "stack '*' cannot declare mixins")
+			h.AssertEq(t, findRule(findings, "targets")[0].Message, "target os 'plan9' is not recognized")
+		})
+
+		it("reports missing executables", func() {
+			h.AssertNil(t, os.Remove(filepath.Join(bpDir, "bin", "detect")))
+			writeFile("buildpack.toml", `
+api = "0.9"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+`, 0644)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			h.AssertEq(t, findings, []buildpack.LintFinding{{
+				Buildpack: "some.bp@1.0.0",
+				Rule:      "bin",
+				Severity:  buildpack.LintSeverityError,
+				Path:      "bin/detect",
+				Message:   "'bin/detect' is required",
+			}})
+		})
+
+		it("reports order buildpacks that declare targets", func() {
+			writeFile("buildpack.toml", `
+api = "0.9"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+[[order]]
+[[order.group]]
+id = "other.bp"
+version = "1.0.0"
+[[targets]]
+os = "linux"
+`, 0644)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+			order := findRule(findings, "order")
+			h.AssertEq(t, len(order), 2)
+			h.AssertEq(t, order[0].Severity, buildpack.LintSeverityError)
+			h.AssertEq(t, order[1].Message, "'bin' is ignored for buildpacks with an 'order'")
+		})
+
+		it("reports exec.d layout problems", func() {
+			writeFile("buildpack.toml", `
+api = "0.4"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+`, 0644)
+			writeFile("exec.d/not-executable", "", 0644)
+			writeFile("exec.d/web/nested/too-deep", "", 0755)
+
+			findings, err := buildpack.LintRootBlob(blob.NewBlob(bpDir))
+			h.AssertNil(t, err)
+
+			execD := findRule(findings, "exec.d")
+			h.AssertEq(t, len(execD), 4)
+			h.AssertEq(t, execD[0].Message, "'exec.d' is only supported as of Buildpack API 0.5")
+			h.AssertEq(t, execD[1].Message, "'exec.d/not-executable' is not executable")
+			h.AssertEq(t, execD[2].Message, "'exec.d' may only contain executables or '<process>' directories")
+			h.AssertEq(t, execD[3].Message, "executables must be placed directly in 'exec.d' or 'exec.d/<process>'")
+		})
+	})
+
+	when("#LintModule", func() {
+		it("lints buildpacks structured as per the distribution spec", func() {
+			writeFile("buildpack.toml", `
+api = "0.10"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+`, 0644)
+
+			bp, err := buildpack.FromBuildpackRootBlob(blob.NewBlob(bpDir), archive.DefaultTarWriterFactory(), logging.NewSimpleLogger(io.Discard))
+			h.AssertNil(t, err)
+
+			findings, err := buildpack.LintModule(bp)
+			h.AssertNil(t, err)
+			h.AssertEq(t, findings, []buildpack.LintFinding{{
+				Buildpack: "some.bp@1.0.0",
+				Rule:      "targets",
+				Severity:  buildpack.LintSeverityWarning,
+				Path:      "buildpack.toml",
+				Message:   "no 'targets' are declared, they will be inferred from the contents of 'bin'",
+			}})
+		})
+	})
+}
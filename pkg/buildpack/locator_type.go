@@ -23,6 +23,7 @@ const (
 	IDLocator
 	PackageLocator
 	RegistryLocator
+	DockerArchiveLocator
 	// added entries here should also be added to `String()`
 )
 
@@ -31,6 +32,7 @@ const (
 	deprecatedFromBuilderPrefix = "from=builder"
 	fromRegistryPrefix          = "urn:cnb:registry"
 	fromDockerPrefix            = "docker:/"
+	fromDockerArchivePrefix     = "docker-archive:"
 )
 
 var (
@@ -47,6 +49,7 @@ func (l LocatorType) String() string {
 		"IDLocator",
 		"PackageLocator",
 		"RegistryLocator",
+		"DockerArchiveLocator",
 	}[l]
 }
 
@@ -69,6 +72,10 @@ func GetLocatorType(locator string, relativeBaseDir string, buildpacksFromBuilde
 		return RegistryLocator, nil
 	}
 
+	if HasDockerArchiveLocator(locator) {
+		return DockerArchiveLocator, nil
+	}
+
 	if paths.IsURI(locator) {
 		if HasDockerLocator(locator) {
 			if _, err := name.ParseReference(locator); err == nil {
@@ -85,6 +92,12 @@ func HasDockerLocator(locator string) bool {
 	return strings.HasPrefix(locator, fromDockerPrefix)
 }
 
+// HasDockerArchiveLocator returns true for locators of tarballs of images, such as written by `docker save`, in the
+// form of docker-archive:<path>
+func HasDockerArchiveLocator(locator string) bool {
+	return strings.HasPrefix(locator, fromDockerArchivePrefix)
+}
+
 func parseNakedLocator(locator, relativeBaseDir string, buildpacksFromBuilder []dist.ModuleInfo) LocatorType {
 	// from here on, we're dealing with a naked locator, and we try to figure out what it is. To do this we check
 	// the following characteristics in order:
@@ -79,6 +79,14 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "https://example.com/buildpack.tgz",
 			expectedType: buildpack.URILocator,
 		},
+		{
+			locator:      "git://github.com/org/bp#v1.2.3",
+			expectedType: buildpack.URILocator,
+		},
+		{
+			locator:      "s3://some-bucket/buildpack.tgz",
+			expectedType: buildpack.URILocator,
+		},
 		{
 			locator:      "localhost:1234/example/package-cnb",
 			expectedType: buildpack.PackageLocator,
@@ -143,6 +151,14 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "registry.com/cnbs/some-bp:some-tag@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 			expectedType: buildpack.PackageLocator,
 		},
+		{
+			locator:      "docker-archive:./some-bp.tar",
+			expectedType: buildpack.DockerArchiveLocator,
+		},
+		{
+			locator:      "docker-archive:/path/to/some-bp.tar",
+			expectedType: buildpack.DockerArchiveLocator,
+		},
 		{
 			locator:      "urn:cnb:registry:example/foo@1.0.0",
 			expectedType: buildpack.RegistryLocator,
@@ -2,6 +2,7 @@ package buildpack
 
 import (
 	"archive/tar"
+	"bufio"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
@@ -68,6 +69,24 @@ func ConfigFromOCILayoutBlob(blob Blob) (config v1.ImageConfig, err error) {
 	return layoutPackage.imageInfo.Config, nil
 }
 
+// TargetFromOCILayoutBlob returns the target of a packaged buildpack in OCI layout format
+func TargetFromOCILayoutBlob(blob Blob) (dist.Target, error) {
+	layoutPackage, err := newOCILayoutPackage(blob, KindBuildpack)
+	if err != nil {
+		return dist.Target{}, err
+	}
+	return dist.Target{
+		OS:          layoutPackage.imageInfo.OS,
+		Arch:        layoutPackage.imageInfo.Architecture,
+		ArchVariant: layoutPackage.imageInfo.Variant,
+	}, nil
+}
+
+// PackageFromOCILayoutBlob returns a Package for reading the labels and layers of a packaged buildpack in OCI layout format
+func PackageFromOCILayoutBlob(blob Blob) (Package, error) {
+	return newOCILayoutPackage(blob, KindBuildpack)
+}
+
 type ociLayoutPackage struct {
 	imageInfo v1.Image
 	manifest  v1.Manifest
@@ -75,9 +94,13 @@ type ociLayoutPackage struct {
 }
 
 func newOCILayoutPackage(blob Blob, kind string) (*ociLayoutPackage, error) {
-	index := &v1.Index{}
+	metadata, err := readOCILayoutMetadata(blob)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := unmarshalJSONFromBlob(blob, "/index.json", index); err != nil {
+	index := &v1.Index{}
+	if err := metadata.unmarshal("/index.json", index); err != nil {
 		return nil, err
 	}
 
@@ -94,12 +117,12 @@ func newOCILayoutPackage(blob Blob, kind string) (*ociLayoutPackage, error) {
 	}
 
 	manifest := &v1.Manifest{}
-	if err := unmarshalJSONFromBlob(blob, pathFromDescriptor(*manifestDescriptor), manifest); err != nil {
+	if err := metadata.unmarshal(pathFromDescriptor(*manifestDescriptor), manifest); err != nil {
 		return nil, err
 	}
 
 	imageInfo := &v1.Image{}
-	if err := unmarshalJSONFromBlob(blob, pathFromDescriptor(manifest.Config), imageInfo); err != nil {
+	if err := metadata.unmarshal(pathFromDescriptor(manifest.Config), imageInfo); err != nil {
 		return nil, err
 	}
 	var layersLabel string
@@ -195,21 +218,56 @@ func pathFromDescriptor(descriptor v1.Descriptor) string {
 	return path.Join("/blobs", descriptor.Digest.Algorithm().String(), descriptor.Digest.Encoded())
 }
 
-func unmarshalJSONFromBlob(blob Blob, path string, obj interface{}) error {
+// maxOCILayoutMetadataSize is the size of the largest entry of an OCI layout read as metadata
+const maxOCILayoutMetadataSize = 4 << 20
+
+// ociLayoutMetadata are the JSON entries of an OCI layout, such as the index, manifests and configs
+type ociLayoutMetadata map[string][]byte
+
+// readOCILayoutMetadata reads the JSON entries of an OCI layout in a single pass over the blob. Layers are skipped
+// rather than read, so that large packages don't need to be extracted, or read several times, to be inspected.
+func readOCILayoutMetadata(blob Blob) (ociLayoutMetadata, error) {
 	reader, err := blob.Open()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer reader.Close()
 
-	_, contents, err := archive.ReadTarEntry(reader, path)
-	if err != nil {
-		return err
+	metadata := ociLayoutMetadata{}
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get next tar entry")
+		}
+
+		if header.Typeflag != tar.TypeReg || header.Size > maxOCILayoutMetadataSize {
+			continue
+		}
+
+		br := bufio.NewReader(tr)
+		if first, err := br.Peek(1); err != nil || first[0] != '{' {
+			continue
+		}
+
+		contents, err := io.ReadAll(br)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", style.Symbol(header.Name))
+		}
+		metadata[paths.CanonicalTarPath(header.Name)] = contents
 	}
 
-	if err = json.Unmarshal(contents, obj); err != nil {
-		return err
+	return metadata, nil
+}
+
+func (m ociLayoutMetadata) unmarshal(path string, obj interface{}) error {
+	contents, ok := m[paths.CanonicalTarPath(path)]
+	if !ok {
+		return errors.Wrapf(archive.ErrEntryNotExist, "could not find entry path '%s'", path)
 	}
 
-	return nil
+	return json.Unmarshal(contents, obj)
 }
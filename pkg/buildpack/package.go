@@ -135,6 +135,91 @@ func extractBuildpacks(pkg Package) (mainBP BuildModule, depBPs []BuildModule, e
 	return mainBP, depBPs, nil
 }
 
+// ExtractBuildpack returns a buildpack from the buildpack layers of an image, such as a builder, along with the
+// buildpacks in its order, for the buildpack to be packaged on its own. The version may be empty when the image holds a
+// single version of the buildpack. Buildpacks flattened into a layer with other buildpacks can't be extracted.
+func ExtractBuildpack(pkg Package, id, version string) (mainBP BuildModule, depBPs []BuildModule, err error) {
+	pkg = &syncPkg{pkg: pkg}
+	layers := dist.ModuleLayers{}
+	if ok, err := dist.GetLabel(pkg, dist.BuildpackLayersLabel, &layers); err != nil {
+		return nil, nil, err
+	} else if !ok {
+		return nil, nil, errors.Errorf("could not find label %s", style.Symbol(dist.BuildpackLayersLabel))
+	}
+
+	diffIDs := map[string]int{}
+	for _, versions := range layers {
+		for _, info := range versions {
+			diffIDs[info.LayerDiffID]++
+		}
+	}
+
+	seen := map[string]bool{}
+	var extract func(id, version string) error
+	extract = func(id, version string) error {
+		if version == "" {
+			if versions := layers[id]; len(versions) > 1 {
+				return errors.Errorf("image has %d versions of buildpack %s; specify one as %s", len(versions), style.Symbol(id), style.Symbol(id+"@<version>"))
+			}
+			for v := range layers[id] {
+				version = v
+			}
+		}
+		info, ok := layers.Get(id, version)
+		if !ok {
+			return errors.Errorf("image has no buildpack %s", style.Symbol(dist.ModuleInfo{ID: id, Version: version}.FullName()))
+		}
+		desc := dist.BuildpackDescriptor{
+			WithAPI:     info.API,
+			WithInfo:    dist.ModuleInfo{ID: id, Version: version, Homepage: info.Homepage, Name: info.Name},
+			WithStacks:  info.Stacks,
+			WithTargets: info.Targets,
+			WithOrder:   info.Order,
+		}
+		if seen[desc.Info().FullName()] {
+			return nil
+		}
+		seen[desc.Info().FullName()] = true
+		if diffIDs[info.LayerDiffID] > 1 {
+			return errors.Errorf("buildpack %s is flattened with other buildpacks into a single layer, and can't be extracted", style.Symbol(desc.Info().FullName()))
+		}
+
+		diffID := info.LayerDiffID // Allow use in closure
+		module := FromBlob(&desc, &openerBlob{
+			opener: func() (io.ReadCloser, error) {
+				rc, err := pkg.GetLayer(diffID)
+				if err != nil {
+					return nil, errors.Wrapf(err,
+						"extracting buildpack %s layer (diffID %s)",
+						style.Symbol(desc.Info().FullName()),
+						style.Symbol(diffID),
+					)
+				}
+				return rc, nil
+			},
+		})
+		if mainBP == nil {
+			mainBP = module
+		} else {
+			depBPs = append(depBPs, module)
+		}
+
+		for _, entry := range info.Order {
+			for _, ref := range entry.Group {
+				if err := extract(ref.ID, ref.Version); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := extract(id, version); err != nil {
+		return nil, nil, err
+	}
+	return mainBP, depBPs, nil
+}
+
 func extractExtensions(pkg Package) (mainExt BuildModule, err error) {
 	pkg = &syncPkg{pkg: pkg}
 	md := &Metadata{}
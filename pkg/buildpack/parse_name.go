@@ -32,6 +32,11 @@ func ParsePackageLocator(locator string) (imageName string) {
 		fromDockerPrefix)
 }
 
+// ParseDockerArchiveLocator parses a locator (in format `docker-archive:<path>`) to the path of the image archive
+func ParseDockerArchiveLocator(locator string) (path string) {
+	return strings.TrimPrefix(locator, fromDockerArchivePrefix)
+}
+
 // ParseRegistryID parses a registry id (ie. `<namespace>/<name>@<version>`) into namespace, name and version components.
 //
 // Supported formats:
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// InTotoStatementType is the type of the in-toto statement attached to built images.
+	InTotoStatementType = "https://in-toto.io/Statement/v0.1"
+	// BuildPlanPredicateType is the predicate type of the build plan attestation.
+	BuildPlanPredicateType = "https://buildpacks.io/attestations/build-plan/v1"
+
+	// AttestationFile is the name of the file the attestation is written to, in BuildOptions.ReportDestinationDir.
+	AttestationFile = "attestation.intoto.json"
+
+	inTotoPayloadType = "application/vnd.in-toto+json"
+	dsseMediaType     = "application/vnd.dsse.envelope.v1+json"
+)
+
+// AttestOptions configure the build plan attestation of the built image.
+type AttestOptions struct {
+	// Attach an in-toto attestation of the buildpacks and build plan to the built image.
+	Enabled bool
+
+	// Path to a PEM encoded, unencrypted ECDSA, ed25519 or RSA private key used to sign the attestation.
+	// The attestation is not signed when empty.
+	SigningKeyPath string
+}
+
+// InTotoStatement is an in-toto statement about the built image.
+type InTotoStatement struct {
+	Type          string             `json:"_type"`
+	Subject       []InTotoSubject    `json:"subject"`
+	PredicateType string             `json:"predicateType"`
+	Predicate     BuildPlanPredicate `json:"predicate"`
+}
+
+// InTotoSubject identifies the image an attestation is about.
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// BuildPlanPredicate records the buildpacks selected during detection, and the build plan entries they provided.
+type BuildPlanPredicate struct {
+	Builder    string                   `json:"builder"`
+	Launcher   string                   `json:"launcher,omitempty"`
+	Buildpacks []buildpack.GroupElement `json:"buildpacks"`
+	Extensions []buildpack.GroupElement `json:"extensions,omitempty"`
+	Plan       []BuildPlanEntry         `json:"plan"`
+}
+
+// BuildPlanEntry is an entry of the build plan resolved by detection: the buildpacks providing a dependency, and what
+// the buildpacks requiring it asked for.
+type BuildPlanEntry struct {
+	Providers []buildpack.GroupElement `json:"providers"`
+	Requires  []buildpack.Require      `json:"requires"`
+}
+
+// DSSEEnvelope is a Dead Simple Signing Envelope wrapping an in-toto statement.
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+// DSSESignature is a signature of a DSSEEnvelope payload.
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// attestBuildPlan attaches an attestation of the build plan exported to planPath by the build to the built image, when
+// publishing, and writes it to the report directory
+func (c *Client) attestBuildPlan(ctx context.Context, imageRef name.Reference, planPath string, opts BuildOptions) error {
+	buildPlan, err := build.ReadBuildPlanFile(planPath)
+	if err != nil {
+		return err
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrapf(err, "fetching built image %s", style.Symbol(imageRef.Name()))
+	}
+
+	var buildMD files.BuildMetadata
+	if _, err := dist.GetLabel(img, platform.BuildMetadataLabel, &buildMD); err != nil {
+		return err
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		return errors.Wrap(err, "reading image digest")
+	}
+	digest, err := v1.NewHash(parseDigestFromImageID(id))
+	if err != nil {
+		return errors.Wrap(err, "parsing image digest")
+	}
+
+	plan := []BuildPlanEntry{}
+	for _, entry := range buildPlan.Entries {
+		plan = append(plan, BuildPlanEntry{Providers: entry.Providers, Requires: entry.Requires})
+	}
+	statement := InTotoStatement{
+		Type:          InTotoStatementType,
+		Subject:       []InTotoSubject{{Name: imageRef.Context().Name(), Digest: map[string]string{digest.Algorithm: digest.Hex}}},
+		PredicateType: BuildPlanPredicateType,
+		Predicate: BuildPlanPredicate{
+			Builder:    opts.Builder,
+			Launcher:   buildMD.Launcher.Version,
+			Buildpacks: buildPlan.Group,
+			Extensions: buildPlan.GroupExtensions,
+			Plan:       plan,
+		},
+	}
+
+	envelope, err := newDSSEEnvelope(statement, opts.Attest.SigningKeyPath)
+	if err != nil {
+		return err
+	}
+	contents, err := json.Marshal(envelope)
+	if err != nil {
+		return errors.Wrap(err, "marshalling attestation")
+	}
+
+	if opts.ReportDestinationDir != "" {
+		if err := os.MkdirAll(opts.ReportDestinationDir, os.ModePerm); err != nil {
+			return errors.Wrapf(err, "creating report directory %s", style.Symbol(opts.ReportDestinationDir))
+		}
+		// The following line's comment is for gosec, it will ignore rule 306 in this case
+		// G306: Expect WriteFile permissions to be 0600 or less
+		/* #nosec G306 */
+		if err := os.WriteFile(filepath.Join(opts.ReportDestinationDir, AttestationFile), contents, 0644); err != nil {
+			return errors.Wrap(err, "writing attestation")
+		}
+	}
+
+	if !opts.Publish {
+		c.logger.Warn("Attestations are only attached to images published to a registry; use --publish to attach it")
+		return nil
+	}
+
+	attTag := imageRef.Context().Tag(strings.Replace(digest.String(), ":", "-", 1) + ".att")
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	// attestations are appended to any attestation already attached to the image, like cosign does
+	attImage, err := ggcrremote.Image(attTag, remoteOpts...)
+	if err != nil {
+		attImage = mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+		attImage = mutate.ConfigMediaType(attImage, types.OCIConfigJSON)
+	}
+	attImage, err = mutate.Append(attImage, mutate.Addendum{
+		Layer:       static.NewLayer(contents, dsseMediaType),
+		Annotations: map[string]string{"predicateType": BuildPlanPredicateType},
+		MediaType:   dsseMediaType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "adding attestation layer")
+	}
+
+	if err := ggcrremote.Write(attTag, attImage, remoteOpts...); err != nil {
+		return errors.Wrapf(err, "attaching attestation to %s", style.Symbol(imageRef.Name()))
+	}
+	c.logger.Infof("Attached build plan attestation %s", style.Symbol(attTag.Name()))
+	return nil
+}
+
+func newDSSEEnvelope(statement InTotoStatement, signingKeyPath string) (DSSEEnvelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return DSSEEnvelope{}, errors.Wrap(err, "marshalling in-toto statement")
+	}
+
+	envelope := DSSEEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []DSSESignature{},
+	}
+	if signingKeyPath == "" {
+		return envelope, nil
+	}
+
+	signer, err := readSigningKey(signingKeyPath)
+	if err != nil {
+		return DSSEEnvelope{}, err
+	}
+
+	message := dssePreAuthEncoding(inTotoPayloadType, payload)
+	var sig []byte
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return DSSEEnvelope{}, errors.Wrap(err, "signing attestation")
+	}
+
+	envelope.Signatures = append(envelope.Signatures, DSSESignature{Sig: base64.StdEncoding.EncodeToString(sig)})
+	return envelope, nil
+}
+
+// dssePreAuthEncoding returns the message signed for a DSSE envelope
+func dssePreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func readSigningKey(path string) (crypto.Signer, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading signing key %s", style.Symbol(path))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("signing key %s is not PEM encoded", style.Symbol(path))
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing signing key %s", style.Symbol(path))
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported signing key type %T", key)
+	}
+	return signer, nil
+}
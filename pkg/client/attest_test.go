@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Attest", testAttest, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAttest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		builtImage       *fakes.Image
+		reportDir        string
+		planPath         string
+		outBuf           bytes.Buffer
+	)
+
+	const (
+		digest        = "sha256:7a8f9bdc5d4d1c9b9d0a1d5c04a3d7a0d8fd4fdb9f0ac5c4fa59a1a8f3d4e2b1"
+		buildMetadata = `{"bom": [{"name": "legacy-bom", "buildpack": {"id": "bp.legacy", "version": "0.1.0"}}],
+"buildpacks": [{"id": "bp.legacy", "version": "0.1.0"}], "launcher": {"version": "0.19.0"}}`
+		buildPlan = `
+[[group]]
+  id = "bp.node"
+  version = "1.0.0"
+
+[[group]]
+  id = "bp.npm"
+  version = "2.0.0"
+
+[[entries]]
+  [[entries.providers]]
+    id = "bp.node"
+    version = "1.0.0"
+  [[entries.requires]]
+    name = "node"
+    [entries.requires.metadata]
+      version = "20.1.0"
+`
+	)
+
+	readEnvelope := func(contents []byte) (DSSEEnvelope, InTotoStatement) {
+		var envelope DSSEEnvelope
+		h.AssertNil(t, json.Unmarshal(contents, &envelope))
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		h.AssertNil(t, err)
+		var statement InTotoStatement
+		h.AssertNil(t, json.Unmarshal(payload, &statement))
+		return envelope, statement
+	}
+
+	it.Before(func() {
+		var err error
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher: mockImageFetcher,
+			keychain:     authn.DefaultKeychain,
+		}
+		reportDir, err = os.MkdirTemp("", "attest-report")
+		h.AssertNil(t, err)
+		planPath = filepath.Join(reportDir, "plan.toml")
+		h.AssertNil(t, os.WriteFile(planPath, []byte(buildPlan), 0600))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(reportDir))
+	})
+
+	when("#attestBuildPlan", func() {
+		when("building to the daemon", func() {
+			it.Before(func() {
+				builtImage = fakes.NewImage("some/app", "", local.IDIdentifier{ImageID: strings.TrimPrefix(digest, "sha256:")})
+				h.AssertNil(t, builtImage.SetLabel("io.buildpacks.build.metadata", buildMetadata))
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "index.docker.io/some/app:latest", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+					Return(builtImage, nil)
+			})
+
+			it("writes the unsigned attestation to the report directory", func() {
+				imageRef, err := name.ParseReference("some/app", name.WeakValidation)
+				h.AssertNil(t, err)
+
+				err = subject.attestBuildPlan(context.TODO(), imageRef, planPath, BuildOptions{
+					Builder:              "some/builder",
+					ReportDestinationDir: reportDir,
+					Attest:               AttestOptions{Enabled: true},
+				})
+				h.AssertNil(t, err)
+				h.AssertContains(t, outBuf.String(), "Attestations are only attached to images published to a registry")
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, AttestationFile))
+				h.AssertNil(t, err)
+				envelope, statement := readEnvelope(contents)
+				h.AssertEq(t, envelope.PayloadType, "application/vnd.in-toto+json")
+				h.AssertEq(t, len(envelope.Signatures), 0)
+				h.AssertEq(t, statement.PredicateType, BuildPlanPredicateType)
+				h.AssertEq(t, statement.Subject, []InTotoSubject{{
+					Name:   "index.docker.io/some/app",
+					Digest: map[string]string{"sha256": strings.TrimPrefix(digest, "sha256:")},
+				}})
+				h.AssertEq(t, statement.Predicate.Builder, "some/builder")
+				h.AssertEq(t, statement.Predicate.Launcher, "0.19.0")
+			})
+
+			it("attests the group and plan resolved by detection rather than the BOM", func() {
+				imageRef, err := name.ParseReference("some/app", name.WeakValidation)
+				h.AssertNil(t, err)
+
+				err = subject.attestBuildPlan(context.TODO(), imageRef, planPath, BuildOptions{
+					ReportDestinationDir: reportDir,
+					Attest:               AttestOptions{Enabled: true},
+				})
+				h.AssertNil(t, err)
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, AttestationFile))
+				h.AssertNil(t, err)
+				_, statement := readEnvelope(contents)
+				h.AssertEq(t, len(statement.Predicate.Buildpacks), 2)
+				h.AssertEq(t, statement.Predicate.Buildpacks[0].ID, "bp.node")
+				h.AssertEq(t, statement.Predicate.Buildpacks[1].ID, "bp.npm")
+				h.AssertEq(t, len(statement.Predicate.Plan), 1)
+				h.AssertEq(t, statement.Predicate.Plan[0].Providers[0].ID, "bp.node")
+				h.AssertEq(t, statement.Predicate.Plan[0].Requires[0].Name, "node")
+				h.AssertEq(t, statement.Predicate.Plan[0].Requires[0].Metadata["version"], "20.1.0")
+			})
+		})
+
+		it("fails without the build plan exported by the build", func() {
+			imageRef, err := name.ParseReference("some/app", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			err = subject.attestBuildPlan(context.TODO(), imageRef, filepath.Join(reportDir, "missing.toml"), BuildOptions{Attest: AttestOptions{Enabled: true}})
+			h.AssertError(t, err, "reading build plan")
+		})
+
+		when("publishing", func() {
+			var (
+				server   *httptest.Server
+				imageRef name.Reference
+				keyPath  string
+				key      *ecdsa.PrivateKey
+			)
+
+			it.Before(func() {
+				server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+				var err error
+				imageRef, err = name.ParseReference(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1)+"/some/app", name.WeakValidation)
+				h.AssertNil(t, err)
+
+				digestRef, err := name.NewDigest(imageRef.Context().Name()+"@"+digest, name.WeakValidation)
+				h.AssertNil(t, err)
+				builtImage = fakes.NewImage(imageRef.Name(), "", remote.DigestIdentifier{Digest: digestRef})
+				h.AssertNil(t, builtImage.SetLabel("io.buildpacks.build.metadata", buildMetadata))
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), imageRef.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullNever}).
+					Return(builtImage, nil).AnyTimes()
+
+				key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				der, err := x509.MarshalECPrivateKey(key)
+				h.AssertNil(t, err)
+				keyPath = filepath.Join(reportDir, "cosign.key")
+				h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+			})
+
+			it.After(func() {
+				server.Close()
+			})
+
+			it("attaches a signed attestation to the image", func() {
+				opts := BuildOptions{Publish: true, Attest: AttestOptions{Enabled: true, SigningKeyPath: keyPath}}
+				h.AssertNil(t, subject.attestBuildPlan(context.TODO(), imageRef, planPath, opts))
+				h.AssertNil(t, subject.attestBuildPlan(context.TODO(), imageRef, planPath, opts))
+
+				attTag := imageRef.Context().Tag(strings.Replace(digest, ":", "-", 1) + ".att")
+				h.AssertContains(t, outBuf.String(), "Attached build plan attestation '"+attTag.Name()+"'")
+				attImage, err := ggcrremote.Image(attTag)
+				h.AssertNil(t, err)
+				layers, err := attImage.Layers()
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(layers), 2)
+
+				rc, err := layers[0].Compressed()
+				h.AssertNil(t, err)
+				defer rc.Close()
+				contents, err := io.ReadAll(rc)
+				h.AssertNil(t, err)
+
+				envelope, statement := readEnvelope(contents)
+				h.AssertEq(t, statement.Subject[0].Name, imageRef.Context().Name())
+				h.AssertEq(t, len(envelope.Signatures), 1)
+
+				payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+				h.AssertNil(t, err)
+				sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+				h.AssertNil(t, err)
+				hash := sha256.Sum256(dssePreAuthEncoding(envelope.PayloadType, payload))
+				h.AssertTrue(t, ecdsa.VerifyASN1(&key.PublicKey, hash[:], sig))
+			})
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// serviceBindingRootEnv is the environment variable buildpacks find service bindings with
+const serviceBindingRootEnv = "SERVICE_BINDING_ROOT"
+
+// bindingsRoot returns the directory service bindings are mounted in for builds on imgOS
+func bindingsRoot(imgOS string) string {
+	if imgOS == "windows" {
+		return `c:\platform\bindings`
+	}
+	return "/platform/bindings"
+}
+
+// bindingVolumes returns the volumes mounting the service bindings of the project descriptor and opts, which take
+// precedence, in the bindings root of the build containers. Each binding is a directory with a 'type' file, as
+// described by the Service Binding specification for Kubernetes.
+func bindingVolumes(opts BuildOptions, builderOS string) ([]string, error) {
+	bindings := map[string]string{}
+	for _, binding := range opts.ProjectDescriptor.Build.Bindings {
+		dir := binding.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(opts.ProjectDescriptorBaseDir, dir)
+		}
+		bindings[binding.Name] = dir
+	}
+	for name, dir := range opts.Bindings {
+		bindings[name] = dir
+	}
+
+	var names []string
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var volumes []string
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, `/\:`) {
+			return nil, errors.Errorf("invalid binding name %s", style.Symbol(name))
+		}
+		dir, err := filepath.Abs(bindings[name])
+		if err != nil {
+			return nil, err
+		}
+		if fi, err := os.Stat(filepath.Join(dir, "type")); err != nil || fi.IsDir() {
+			return nil, errors.Errorf("binding %s must be a directory with a 'type' file, but %s has none", style.Symbol(name), style.Symbol(dir))
+		}
+
+		target := path.Join(bindingsRoot(builderOS), name)
+		if builderOS == "windows" {
+			target = bindingsRoot(builderOS) + `\` + name
+		}
+		volumes = append(volumes, fmt.Sprintf("%s:%s:ro", dir, target))
+	}
+	return volumes, nil
+}
@@ -21,11 +21,14 @@ import (
 	"github.com/buildpacks/imgutil/layout"
 	"github.com/buildpacks/imgutil/local"
 	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform/files"
-	types "github.com/docker/docker/api/types/image"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	ignore "github.com/sabhiram/go-gitignore"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/internal/build"
@@ -55,6 +58,14 @@ const (
 	minLifecycleVersionSupportingCreatorWithExtensions = "0.19.0"
 )
 
+// forceColorEnvs are the environment variables that ask tools to color their output when it isn't a terminal, as the
+// output of the build containers never is
+var forceColorEnvs = []string{"CLICOLOR_FORCE", "FORCE_COLOR"}
+
+// artifactsDirEnv is the environment variable buildpacks find the directory to write the build outputs to copy out of
+// the build container with, see BuildOptions.ArtifactsDestinationDir
+const artifactsDirEnv = "CNB_ARTIFACTS_DIR"
+
 // LifecycleExecutor executes the lifecycle which satisfies the Cloud Native Buildpacks Lifecycle specification.
 // Implementations of the Lifecycle must execute the following phases by calling the
 // phase-specific lifecycle binary in order:
@@ -112,10 +123,23 @@ type BuildOptions struct {
 	//    the builder metadata
 	AdditionalMirrors map[string][]string
 
+	// Registries accessed without TLS, or without verifying their certificates, such as a local registry
+	// at localhost:5000. Requires Platform API 0.13 or later for the lifecycle to honor them.
+	InsecureRegistries []string
+
+	// Service bindings given to the buildpacks, by name, in addition to those of the ProjectDescriptor.
+	// Each binding is a directory with a 'type' file and a file for each of its secrets.
+	Bindings map[string]string
+
 	// User provided environment variables to the buildpacks.
 	// Buildpacks may both read and overwrite these values.
 	Env map[string]string
 
+	// User provided environment variables scoped to buildpacks, keyed by buildpack ID.
+	// They are written to the platform directory of each buildpack, '/platform/buildpacks/<id>/env' with any
+	// '/' of the ID replaced by '_', rather than to '/platform/env', so that other buildpacks aren't given them.
+	BuildpackEnv map[string]map[string]string
+
 	// Used to configure various cache available options
 	Cache cache.CacheOpts
 
@@ -145,9 +169,14 @@ type BuildOptions struct {
 	// share both an ID and Version with an extension on the builder.
 	Extensions []string
 
-	// Additional image tags to push to, each will contain contents identical to Image
+	// Additional image tags to push to, each will contain contents identical to Image.
+	// Tags may be in other registries than Image, each registry is pushed to with its own credentials.
 	AdditionalTags []string
 
+	// OCI manifest annotations to set on the output image.
+	// Annotations are only persisted when Publish is true.
+	Annotations map[string]string
+
 	// Configure the proxy environment variables,
 	// These variables will only be set in the build image
 	// and will not be used if proxy env vars are already set.
@@ -180,6 +209,14 @@ type BuildOptions struct {
 	// when using an untrusted builder.
 	LifecycleImage string
 
+	// Keychain to pull the lifecycle image with, for lifecycle images in registries that need credentials of their own.
+	// The keychain of the client is used when not set.
+	LifecycleImageKeychain authn.Keychain
+
+	// Digests the lifecycle image is allowed to have, the build fails when the lifecycle image has none of them.
+	// Any lifecycle image is allowed when empty.
+	LifecycleImageDigests []string
+
 	// The location at which to mount the AppDir in the build image.
 	Workspace string
 
@@ -190,6 +227,7 @@ type BuildOptions struct {
 	UserID int
 
 	// A previous image to set to a particular tag reference, digest reference, or (when performing a daemon build) image ID;
+	// When publishing with platform API 0.7 or later, it may be in a different repository or registry than Image.
 	PreviousImage string
 
 	// TrustBuilder when true optimizes builds by running
@@ -204,11 +242,89 @@ type BuildOptions struct {
 	// Directory to output the report.toml metadata artifact
 	ReportDestinationDir string
 
+	// Directory to copy the build outputs buildpacks designate, such as test reports or compiled binaries, to. Buildpacks
+	// designate them by writing them to the directory in the CNB_ARTIFACTS_DIR build environment variable.
+	ArtifactsDestinationDir string
+
+	// Fail at once when another build is using the caches of the build, rather than waiting for it to finish.
+	FailIfLocked bool
+
+	// Platform of the builder and run image variants to build with, such as linux/arm64. Defaults to the variants the
+	// daemon pulls, which are the ones for its platform when the images have them.
+	Platform *dist.Target
+
+	// Explain which buildpack layers were reused from the previous image, and why the others weren't.
+	// The report is added to the report.toml in ReportDestinationDir, when provided.
+	CacheReport bool
+
+	// Record the builder and the buildpacks the app image is built with in a label of the app image, for NeedsRebuild to
+	// tell whether the app image should be rebuilt, rather than rebased, when they change.
+	RebuildTriggers bool
+
+	// What to do when the run image doesn't match the builder, by stack ID, target or mixins, or when the buildpacks
+	// require mixins the stack doesn't provide. Defaults to CompatModeStrict, failing the build.
+	CompatMode CompatMode
+
+	// Ask the buildpacks to color their output, such as when it's written to a terminal, with the build environment
+	// variables tools commonly check. Variables set in Env take precedence.
+	Color bool
+
+	// Prefix each line of the lifecycle output with its phase, and with the buildpack when the lifecycle reports it,
+	// for the logger to timestamp.
+	Timestamps bool
+
+	// Log the lifecycle output as it is, without prefixes, timestamps or color removal, to capture it exactly.
+	RawOutput bool
+
+	// Logger of the build, overriding the logger of the client, for services running builds at once to route the
+	// output of each build to a stream of its own. It must be safe for concurrent use, as the phases of the lifecycle
+	// may log at once.
+	Logger logging.Logger
+
 	// Desired create time in the output image config
 	CreationTime *time.Time
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Vulnerability scan to run against the built image
+	Scan ScanOptions
+
+	// In-toto attestation of the build plan to attach to the built image
+	Attest AttestOptions
+
+	// Maximum number of independent build operations run at once, such as restoring the cache while pulling the
+	// run image. There is no limit if zero.
+	MaxConcurrency int
+
+	// Keep the build containers, and the volumes they use, for the next build of the same image, which then runs the
+	// lifecycle in them rather than creating new containers. The containers are removed after 30 minutes.
+	KeepAlive bool
+
+	// Save the state of a failed lifecycle phase to the debug image <image>-debug:<phase>, with the build plan, the
+	// buildpack layers, the app and the environment of the phase, to look into the failure from a shell.
+	DebugSnapshot bool
+
+	// Path the build plan resolved by detection is exported to, with the buildpack group, for later builds to replay.
+	ExportPlan string
+
+	// Path of a build plan exported by a previous build, which is replayed to skip detection and build with the same
+	// buildpacks. The builder must have them.
+	Plan string
+
+	// Run only this phase of the build, one of analyze, detect, restore, build or export, rather than every phase.
+	// The phases run one at a time share the state of the build of the image through volumes, which are kept until
+	// CleanPhases removes them, such as to debug a phase, or look into the state it leaves.
+	Phase string
+
+	// Directory the metadata a Phase leaves for the phases after it, such as analyzed.toml, group.toml and plan.toml,
+	// is exported to, and the metadata of the phases before it is imported from, so that the phases of a build can run
+	// in different CI stages, on different hosts. The restore phase copies the app too. Requires Phase.
+	PhaseArtifactsDir string
+
+	// Additional arguments of the lifecycle phases, by phase, such as {"exporter": {"-process-type=web"}}. They
+	// override the flags pack passes the phase, for debugging and for using lifecycle features pack has no option for.
+	LifecycleArgs map[string][]string
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -245,6 +361,26 @@ type ContainerConfig struct {
 	// - /layers
 	// - anything below /cnb/**
 	Volumes []string
+
+	// User namespace of the build containers, such as "host" to run them in the user namespace of the host when the
+	// daemon remaps users.
+	UsernsMode string
+
+	// Security options of the build containers, such as "no-new-privileges" or "seccomp=<profile path>", in the form
+	// the docker CLI takes them. Seccomp profiles are read from the host.
+	SecurityOpts []string
+
+	// Kernel capabilities to add to and drop from the build containers, such as "NET_ADMIN", or "ALL".
+	CapAdd  []string
+	CapDrop []string
+
+	// Run the build phase with a read-only root filesystem, such that buildpacks can only write to their layers, the
+	// app and the scratch directories, to validate buildpacks don't write anywhere else. Not supported for Windows
+	// builders.
+	ReadOnlyRootfs bool
+
+	// Directories given to the build phase as tmpfs mounts when its root filesystem is read-only, in addition to /tmp.
+	ScratchDirs []string
 }
 
 type LayoutConfig struct {
@@ -259,8 +395,25 @@ type LayoutConfig struct {
 
 	// Configure the OCI layout fetch mode to avoid saving layers on disk
 	Sparse bool
+
+	// How the layout directories are given to the build containers, one of LayoutBindAuto (the default),
+	// LayoutBindMount or LayoutBindCopy
+	BindStrategy string
 }
 
+const (
+	// LayoutBindAuto binds the layout directories into the build containers when the daemon is on this host,
+	// and copies them otherwise
+	LayoutBindAuto = "auto"
+
+	// LayoutBindMount binds the layout directories into the build containers
+	LayoutBindMount = "bind"
+
+	// LayoutBindCopy copies the layout directories in and out of a volume, which works with daemons on other hosts,
+	// or in a VM that doesn't share the host paths
+	LayoutBindCopy = "copy"
+)
+
 func (l *LayoutConfig) Enable() bool {
 	return l.InputImage.Layout()
 }
@@ -287,7 +440,19 @@ var IsTrustedBuilderFunc = func(b string) bool {
 // It then invokes the lifecycle to build an app image.
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
-func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+func (c *Client) Build(ctx context.Context, opts BuildOptions) (err error) {
+	if opts.Logger != nil {
+		c = c.withLogger(opts.Logger)
+	}
+
+	ctx, span := c.startSpan(ctx, "build", attribute.String("pack.image", opts.Image), attribute.String("pack.builder", opts.Builder))
+	defer func() { endSpan(span, err) }()
+	defer func() {
+		if err == nil {
+			c.collectIntermediateImages(context.Background())
+		}
+	}()
+
 	var pathsConfig layoutPathConfig
 
 	imageRef, err := c.parseReference(opts)
@@ -297,6 +462,24 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	imgRegistry := imageRef.Context().RegistryStr()
 	imageName := imageRef.Name()
 
+	if opts.Phase != "" && !contains(build.Phases, opts.Phase) {
+		return errors.Errorf("unknown phase %s, must be one of %s", style.Symbol(opts.Phase), strings.Join(build.Phases, ", "))
+	}
+
+	if opts.PhaseArtifactsDir != "" && opts.Phase == "" {
+		return errors.New("a phase artifacts directory requires a phase")
+	}
+
+	if len(opts.ContainerConfig.ScratchDirs) > 0 && !opts.ContainerConfig.ReadOnlyRootfs {
+		return errors.New("scratch directories require a read-only root filesystem")
+	}
+
+	for phase := range opts.LifecycleArgs {
+		if !contains(build.LifecyclePhases, phase) {
+			return errors.Errorf("unknown lifecycle phase %s, must be one of %s", style.Symbol(phase), strings.Join(build.LifecyclePhases, ", "))
+		}
+	}
+
 	if opts.Layout() {
 		pathsConfig, err = c.processLayoutPath(opts.LayoutConfig.InputImage, opts.LayoutConfig.PreviousInputImage)
 		if err != nil {
@@ -320,7 +503,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
 	}
 
-	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	rawBuilderImage, err := c.fetchBuilder(ctx, builderRef.Name(), opts)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
 	}
@@ -335,19 +518,34 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "getting builder architecture")
 	}
 
+	if opts.ContainerConfig.ReadOnlyRootfs && builderOS == "windows" {
+		return errors.New("read-only root filesystems are not supported for Windows builders")
+	}
+
 	bldr, err := c.getBuilder(rawBuilderImage)
 	if err != nil {
 		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
 	}
 
 	target := &dist.Target{OS: builderOS, Arch: builderArch}
+	c.warnEmulatedBuilder(ctx, builderRef.Name(), *target, opts.Platform != nil)
 
 	fetchOptions := image.FetchOptions{
-		Daemon:     !opts.Publish,
-		PullPolicy: opts.PullPolicy,
-		Target:     target,
-	}
-	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, fetchOptions)
+		Daemon:             !opts.Publish,
+		PullPolicy:         opts.PullPolicy,
+		Target:             target,
+		InsecureRegistries: opts.InsecureRegistries,
+	}
+	// The run image of the project descriptor applies unless a run image is provided, and is resolved like the
+	// default run image of the builder, against its mirrors
+	runImageMetadata := bldr.DefaultRunImage()
+	providedRunImage := opts.RunImage
+	if runImage := opts.ProjectDescriptor.Build.RunImage; providedRunImage == "" && runImage.Image != "" {
+		c.logger.Debugf("Using run image %s of the project descriptor", style.Symbol(runImage.Image))
+		runImageMetadata = builder.RunImageMetadata{Image: runImage.Image, Mirrors: runImage.Mirrors}
+		providedRunImage = runImage.Image
+	}
+	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), runImageMetadata, opts.AdditionalMirrors, opts.Publish, fetchOptions)
 
 	if opts.Layout() {
 		targetRunImagePath, err := layout.ParseRefToPath(runImageName)
@@ -364,7 +562,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		pathsConfig.targetRunImagePath = targetRunImagePath
 		pathsConfig.hostRunImagePath = hostRunImagePath
 	}
-	runImage, err := c.validateRunImage(ctx, runImageName, fetchOptions, bldr.StackID)
+	runImage, err := c.validateRunImage(ctx, runImageName, fetchOptions, builderRef.Name(), bldr.StackID, opts.CompatMode)
 	if err != nil {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
@@ -401,7 +599,9 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
-	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	// the creator always detects, so the phases are run one by one to replay a build plan, or to run one of them
+	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder) && opts.Plan == "" && opts.Phase == "" && !opts.ContainerConfig.ReadOnlyRootfs &&
+		len(opts.ProjectDescriptor.Build.Slices) == 0
 	var (
 		lifecycleOptsLifecycleImage string
 		lifecycleAPIs               []string
@@ -421,12 +621,17 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 					Daemon:     true,
 					PullPolicy: opts.PullPolicy,
 					Target:     target,
+					Keychain:   opts.LifecycleImageKeychain,
 				},
 			)
 			if err != nil {
 				return fmt.Errorf("fetching lifecycle image: %w", err)
 			}
 
+			if err := c.verifyLifecycleImageDigest(ctx, lifecycleImageName, opts.LifecycleImageDigests); err != nil {
+				return err
+			}
+
 			// if lifecyle container os isn't windows, use ephemeral lifecycle to add /workspace with correct ownership
 			imageOS, err := lifecycleImage.OS()
 			if err != nil {
@@ -447,7 +652,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 				}
 				c.logger.Debugf("Selecting ephemeral lifecycle image %s for build", lifecycleImage.Name())
 				// cleanup the extended lifecycle image when done
-				defer c.docker.ImageRemove(context.Background(), lifecycleImage.Name(), types.RemoveOptions{Force: true})
+				defer c.removeIntermediateImage(context.Background(), lifecycleImage.Name())
 			}
 
 			lifecycleOptsLifecycleImage = lifecycleImage.Name()
@@ -470,8 +675,11 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	if err != nil {
 		return fmt.Errorf("finding latest supported Platform API: %w", err)
 	}
+	if err := c.reportBuilderDeprecations(bldr, opts.Builder, usingPlatformAPI); err != nil {
+		return err
+	}
 	if usingPlatformAPI.LessThan("0.12") {
-		if err = c.validateMixins(fetchedBPs, bldr, runImageName, runMixins); err != nil {
+		if err = c.validateMixins(fetchedBPs, bldr, runImageName, runMixins, opts.CompatMode); err != nil {
 			return fmt.Errorf("validating stack mixins: %w", err)
 		}
 	}
@@ -485,11 +693,38 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		buildEnvs[k] = v
 	}
 
-	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"), opts.RunImage)
+	if opts.Color {
+		for _, colorEnv := range forceColorEnvs {
+			if _, ok := buildEnvs[colorEnv]; !ok {
+				buildEnvs[colorEnv] = "1"
+			}
+		}
+	}
+
+	if opts.ArtifactsDestinationDir != "" {
+		if _, ok := buildEnvs[artifactsDirEnv]; !ok {
+			buildEnvs[artifactsDirEnv] = build.ArtifactsDir(builderOS)
+		}
+	}
+
+	bindings, err := bindingVolumes(opts, builderOS)
 	if err != nil {
 		return err
 	}
-	defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.RemoveOptions{Force: true})
+	if len(bindings) > 0 {
+		opts.ContainerConfig.Volumes = append(opts.ContainerConfig.Volumes, bindings...)
+		if _, ok := buildEnvs[serviceBindingRootEnv]; !ok {
+			buildEnvs[serviceBindingRootEnv] = bindingsRoot(builderOS)
+		}
+	}
+
+	c.warnUnknownBuildpackEnv(opts.BuildpackEnv, bldr, fetchedBPs)
+
+	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, opts.BuildpackEnv, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12") && opts.CompatMode != CompatModeWarn && opts.CompatMode != CompatModeIgnore, providedRunImage)
+	if err != nil {
+		return err
+	}
+	defer c.removeIntermediateImage(context.Background(), ephemeralBuilder.Name())
 
 	if len(bldr.OrderExtensions()) > 0 || len(ephemeralBuilder.OrderExtensions()) > 0 {
 		if builderOS == "windows" {
@@ -500,8 +735,24 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	var plan *build.BuildPlan
+	if opts.Plan != "" {
+		if plan, err = replayedPlan(opts.Plan, ephemeralBuilder.Buildpacks()); err != nil {
+			return err
+		}
+	}
+
+	var layoutCopies []build.LayoutCopy
 	if opts.Layout() {
-		opts.ContainerConfig.Volumes = appendLayoutVolumes(opts.ContainerConfig.Volumes, pathsConfig)
+		copyLayout, err := c.copyLayout(opts.LayoutConfig.BindStrategy)
+		if err != nil {
+			return err
+		}
+		if copyLayout {
+			layoutCopies = layoutCopiesFor(pathsConfig)
+		} else {
+			opts.ContainerConfig.Volumes = appendLayoutVolumes(opts.ContainerConfig.Volumes, pathsConfig)
+		}
 	}
 
 	processedVolumes, warnings, err := processVolumes(builderOS, opts.ContainerConfig.Volumes)
@@ -509,6 +760,10 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
+	if opts.ContainerConfig.SecurityOpts, err = processSecurityOpts(opts.ContainerConfig.SecurityOpts); err != nil {
+		return err
+	}
+
 	for _, warning := range warnings {
 		c.logger.Warn(warning)
 	}
@@ -524,7 +779,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	}
 
 	projectMetadata := files.ProjectMetadata{}
-	if c.experimental {
+	if c.experimentalEnabled(internalConfig.FeatureProjectMetadata) {
 		version := opts.ProjectDescriptor.Project.Version
 		sourceURL := opts.ProjectDescriptor.Project.SourceURL
 		if version != "" || sourceURL != "" {
@@ -538,6 +793,17 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	// the attestation is of the group and plan resolved by detection, exported from the layers directory of the build
+	exportPlan := opts.ExportPlan
+	if opts.Attest.Enabled && exportPlan == "" {
+		planDir, err := os.MkdirTemp("", "attest-build-plan")
+		if err != nil {
+			return errors.Wrap(err, "creating build plan directory")
+		}
+		defer os.RemoveAll(planDir)
+		exportPlan = filepath.Join(planDir, "plan.toml")
+	}
+
 	lifecycleOpts := build.LifecycleOptions{
 		AppPath:                  appPath,
 		Image:                    imageRef,
@@ -560,6 +826,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Network:                  opts.ContainerConfig.Network,
 		AdditionalTags:           opts.AdditionalTags,
 		Volumes:                  processedVolumes,
+		UsernsMode:               opts.ContainerConfig.UsernsMode,
+		SecurityOpts:             opts.ContainerConfig.SecurityOpts,
+		CapAdd:                   opts.ContainerConfig.CapAdd,
+		CapDrop:                  opts.ContainerConfig.CapDrop,
+		ReadOnlyRootfs:           opts.ContainerConfig.ReadOnlyRootfs,
+		ScratchDirs:              opts.ContainerConfig.ScratchDirs,
 		DefaultProcessType:       opts.DefaultProcessType,
 		FileFilter:               fileFilter,
 		Workspace:                opts.Workspace,
@@ -570,9 +842,26 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Termui:                   termui.NewTermui(imageName, ephemeralBuilder, runImageName),
 		ReportDestinationDir:     opts.ReportDestinationDir,
 		SBOMDestinationDir:       opts.SBOMDestinationDir,
+		ArtifactsDestinationDir:  opts.ArtifactsDestinationDir,
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		MaxConcurrency:           opts.MaxConcurrency,
+		KeepAlive:                opts.KeepAlive,
+		LayoutCopies:             layoutCopies,
+		InsecureRegistries:       opts.InsecureRegistries,
+		Timestamps:               opts.Timestamps,
+		RawOutput:                opts.RawOutput,
+		DebugSnapshot:            opts.DebugSnapshot,
+		ExportPlan:               exportPlan,
+		Metrics:                  c.metricsCollector,
+		Tracer:                   c.tracer(),
+		Plan:                     plan,
+		PhaseArgs:                opts.LifecycleArgs,
+		Phase:                    opts.Phase,
+		PhaseArtifactsDir:        opts.PhaseArtifactsDir,
+		Slices:                   appSlices(opts.ProjectDescriptor.Build.Slices),
+		BuildInputs:              buildInputs(builderRef.Name(), rawBuilderImage, lifecycleVersion, runImageName, ephemeralBuilder.Buildpacks()),
 	}
 
 	switch {
@@ -585,16 +874,18 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
 	}
 
+	var ephemeralRunImages []string
+	defer func() {
+		for _, ephemeralRunImage := range ephemeralRunImages {
+			c.removeIntermediateImage(context.Background(), ephemeralRunImage)
+		}
+	}()
 	lifecycleOpts.FetchRunImageWithLifecycleLayer = func(runImageName string) (string, error) {
 		ephemeralRunImageName := fmt.Sprintf("pack.local/run-image/%x:latest", randString(10))
 		runImage, err := c.imageFetcher.Fetch(ctx, runImageName, fetchOptions)
 		if err != nil {
 			return "", err
 		}
-		ephemeralRunImage, err := local.NewImage(ephemeralRunImageName, c.docker, local.FromBaseImage(runImage.Name()))
-		if err != nil {
-			return "", err
-		}
 		tmpDir, err := os.MkdirTemp("", "extend-run-image-scratch") // we need to write to disk because manifest.json is last in the tar
 		if err != nil {
 			return "", err
@@ -602,7 +893,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		defer os.RemoveAll(tmpDir)
 		lifecycleImageTar, err := func() (string, error) {
 			lifecycleImageTar := filepath.Join(tmpDir, "lifecycle-image.tar")
-			lifecycleImageReader, err := c.docker.ImageSave(context.Background(), []string{lifecycleOpts.LifecycleImage}) // this is fast because the lifecycle image is based on distroless static
+			lifecycleImageReader, err := c.docker.ImageSave(ctx, []string{lifecycleOpts.LifecycleImage}) // this is fast because the lifecycle image is based on distroless static
 			if err != nil {
 				return "", err
 			}
@@ -720,6 +1011,21 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		if err != nil {
 			return "", err
 		}
+		c.trackIntermediateImage(ephemeralRunImageName, IntermediateRunImage)
+		ephemeralRunImages = append(ephemeralRunImages, ephemeralRunImageName)
+
+		if !fetchOptions.Daemon {
+			// the run image was read from the registry, stream it into the daemon rather than pulling it
+			if err = c.streamImageWithLayer(ctx, runImage, lifecycleLayerTar, ephemeralRunImageName); err != nil {
+				return "", err
+			}
+			return ephemeralRunImageName, nil
+		}
+
+		ephemeralRunImage, err := local.NewImage(ephemeralRunImageName, c.docker, local.FromBaseImage(runImage.Name()))
+		if err != nil {
+			return "", err
+		}
 		if err = ephemeralRunImage.AddLayerWithDiffID(lifecycleLayerTar, "sha256:"+diffID); err != nil {
 			return "", err
 		}
@@ -729,12 +1035,94 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return ephemeralRunImageName, nil
 	}
 
-	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+	var prevLayersMetadata *files.LayersMetadataCompat
+	if opts.CacheReport && !opts.Layout() {
+		prevImageName := imageRef.Name()
+		if opts.PreviousImage != "" {
+			prevImageName = opts.PreviousImage
+		}
+		if prevLayersMetadata, err = c.appLayersMetadata(ctx, prevImageName, opts); err != nil {
+			c.logger.Debugf("Reading layers metadata of the previous image %s: %s", style.Symbol(prevImageName), err)
+		}
+	}
+
+	releaseWorkspaces, err := c.lockWorkspaces(ctx, lifecycleOpts, opts.FailIfLocked)
+	if err != nil {
+		return err
+	}
+	err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts)
+	releaseWorkspaces()
+	if err != nil {
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+
+	if opts.Phase != "" && opts.Phase != "export" {
+		layersVolume, appVolume := build.PhaseVolumes(imageRef)
+		c.logger.Infof("Successfully ran the %s phase, the state of the build is in volumes %s and %s", opts.Phase, style.Symbol(layersVolume), style.Symbol(appVolume))
+		return nil
+	}
+
+	if opts.CacheReport && !opts.Layout() {
+		if err = c.reportCache(ctx, imageRef.Name(), prevLayersMetadata, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.RebuildTriggers && !opts.Layout() {
+		if err = c.labelRebuildTriggers(ctx, imageRef, builderRef.Name(), rawBuilderImage, opts); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.Annotations) > 0 {
+		if err = c.annotateImage(ctx, imageRef, opts); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Layout() {
+		if opts.Attest.Enabled {
+			if err = c.attestBuildPlan(ctx, imageRef, exportPlan, opts); err != nil {
+				return err
+			}
+		}
+
+		if err = c.scanImage(ctx, imageRef, opts); err != nil {
+			return err
+		}
+
+		if opts.Publish && len(opts.AdditionalTags) > 0 {
+			if err = c.reportExportTargets(ctx, imageRef, opts); err != nil {
+				return err
+			}
+		}
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
+// annotateImage sets the requested OCI manifest annotations on the exported image.
+// The lifecycle has no notion of manifest annotations, so the manifest is rewritten after export.
+func (c *Client) annotateImage(ctx context.Context, imageRef name.Reference, opts BuildOptions) error {
+	if !opts.Publish {
+		c.logger.Warn("Annotations are only persisted when publishing to a registry; use --publish to keep them")
+		return nil
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, InsecureRegistries: opts.InsecureRegistries})
+	if err != nil {
+		return errors.Wrapf(err, "fetching built image %s", style.Symbol(imageRef.Name()))
+	}
+
+	if err = img.SetAnnotations(opts.Annotations); err != nil {
+		return errors.Wrap(err, "adding annotations")
+	}
+
+	if err = img.Save(opts.AdditionalTags...); err != nil {
+		return errors.Wrapf(err, "saving annotated image %s", style.Symbol(imageRef.Name()))
+	}
+	return nil
+}
+
 func extractSupportedLifecycleApis(labels map[string]string) ([]string, error) {
 	// sample contents of labels:
 	//    {io.buildpacks.builder.metadata:\"{\"lifecycle\":{\"version\":\"0.15.3\"},\"api\":{\"buildpack\":\"0.2\",\"platform\":\"0.3\"}}",
@@ -772,6 +1160,22 @@ func getFileFilter(descriptor projectTypes.Descriptor) (func(string) bool, error
 	return nil, nil
 }
 
+// buildInputs returns the inputs of the build that the layers it caches depend on
+func buildInputs(builderName string, builderImage imgutil.Image, lifecycleVersion *builder.Version, runImageName string, buildpacks []dist.ModuleInfo) *build.BuildInputs {
+	inputs := &build.BuildInputs{
+		Builder:   builderName,
+		Lifecycle: lifecycleVersion.String(),
+		RunImage:  runImageName,
+	}
+	if id, err := builderImage.Identifier(); err == nil && id != nil {
+		inputs.BuilderID = id.String()
+	}
+	for _, bp := range buildpacks {
+		inputs.Buildpacks = append(inputs.Buildpacks, bp.FullName())
+	}
+	return inputs
+}
+
 func supportsCreator(lifecycleVersion *builder.Version) bool {
 	// Technically the creator is supported as of platform API version 0.3 (lifecycle version 0.7.0+) but earlier versions
 	// have bugs that make using the creator problematic.
@@ -801,6 +1205,45 @@ func supportsPlatformAPI(builderPlatformAPIs builder.APISet) bool {
 	return false
 }
 
+// reportBuilderDeprecations reports the deprecated Platform API the build uses, the deprecated Buildpack APIs of the
+// buildpacks of the builder, which the lifecycle shims, according to the shim policy of the client, and the stack of the builder when the Platform API still uses
+// stacks
+func (c *Client) reportBuilderDeprecations(bldr *builder.Builder, builderName string, platformAPI *api.Version) error {
+	apis := bldr.LifecycleDescriptor().APIs
+	if apis.Platform.Deprecated.Contains(platformAPI) {
+		if err := logging.ReportDeprecation(c.logger, logging.Deprecation{
+			Kind:    logging.DeprecatedPlatformAPI,
+			Name:    platformAPI.String(),
+			Details: fmt.Sprintf("used with the lifecycle of builder %s", style.Symbol(builderName)),
+		}); err != nil {
+			return err
+		}
+	}
+
+	var bpLayers dist.ModuleLayers
+	if _, err := dist.GetLabel(bldr.Image(), dist.BuildpackLayersLabel, &bpLayers); err != nil {
+		return err
+	}
+	lifecycleVersion := ""
+	if bldr.LifecycleDescriptor().Info.Version != nil {
+		lifecycleVersion = bldr.LifecycleDescriptor().Info.Version.String()
+	}
+	if err := c.reportBuildpackAPIShims(bpLayers, apis.Buildpack, builderName, lifecycleVersion); err != nil {
+		return err
+	}
+
+	// stacks are replaced by targets as of Platform API 0.12
+	if platformAPI.LessThan("0.12") && bldr.StackID != "" {
+		return logging.ReportDeprecation(c.logger, logging.Deprecation{
+			Kind:        logging.DeprecatedStack,
+			Name:        bldr.StackID,
+			Replacement: "targets",
+			Details:     fmt.Sprintf("builder %s uses Platform API %s", style.Symbol(builderName), platformAPI),
+		})
+	}
+	return nil
+}
+
 func (c *Client) processBuilderName(builderName string) (name.Reference, error) {
 	if builderName == "" {
 		return nil, errors.New("builder is a required parameter if the client has no default builder")
@@ -831,7 +1274,9 @@ func (c *Client) getBuilder(img imgutil.Image) (*builder.Builder, error) {
 	return bldr, nil
 }
 
-func (c *Client) validateRunImage(context context.Context, name string, opts image.FetchOptions, expectedStack string) (imgutil.Image, error) {
+// validateRunImage fetches the run image, and checks that its stack ID and target match the ones of the builder,
+// according to the compat mode
+func (c *Client) validateRunImage(context context.Context, name string, opts image.FetchOptions, builderName, expectedStack string, mode CompatMode) (imgutil.Image, error) {
 	if name == "" {
 		return nil, errors.New("run image must be specified")
 	}
@@ -844,14 +1289,47 @@ func (c *Client) validateRunImage(context context.Context, name string, opts ima
 		return nil, err
 	}
 	if stackID != expectedStack {
-		return nil, fmt.Errorf("run-image stack id '%s' does not match builder stack '%s'", stackID, expectedStack)
+		if err := c.checkCompat(mode,
+			fmt.Errorf("run-image stack id '%s' does not match builder stack '%s'", stackID, expectedStack),
+			fmt.Sprintf("Builder %s has stack ID %s", style.Symbol(builderName), style.Symbol(expectedStack)),
+			fmt.Sprintf("Run image %s has stack ID %s", style.Symbol(name), style.Symbol(stackID)),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Target != nil {
+		runOS, err := img.OS()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting run image OS")
+		}
+		runArch, err := img.Architecture()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting run image architecture")
+		}
+		runTarget := dist.Target{OS: runOS, Arch: runArch}
+		if runOS != opts.Target.OS || runArch != opts.Target.Arch {
+			if err := c.checkCompat(mode,
+				fmt.Errorf("run-image target '%s' does not match builder target '%s'", runTarget.ValuesAsPlatform(), opts.Target.ValuesAsPlatform()),
+				fmt.Sprintf("Builder %s is for %s", style.Symbol(builderName), opts.Target.ValuesAsPlatform()),
+				fmt.Sprintf("Run image %s is for %s, which the app would run on", style.Symbol(name), runTarget.ValuesAsPlatform()),
+			); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return img, nil
 }
 
-func (c *Client) validateMixins(additionalBuildpacks []buildpack.BuildModule, bldr *builder.Builder, runImageName string, runMixins []string) error {
+// validateMixins checks that the run image provides the mixins of the builder, and that the stack provides the mixins
+// the buildpacks require, according to the compat mode
+func (c *Client) validateMixins(additionalBuildpacks []buildpack.BuildModule, bldr *builder.Builder, runImageName string, runMixins []string, mode CompatMode) error {
+	diagnostics := []string{
+		fmt.Sprintf("Builder %s provides mixin(s): %s", style.Symbol(bldr.Image().Name()), orNone(strings.Join(bldr.Mixins(), ", "))),
+		fmt.Sprintf("Run image %s provides mixin(s): %s", style.Symbol(runImageName), orNone(strings.Join(runMixins, ", "))),
+	}
 	if err := stack.ValidateMixins(bldr.Image().Name(), bldr.Mixins(), runImageName, runMixins); err != nil {
-		return err
+		return c.checkCompat(mode, err, diagnostics...)
 	}
 
 	bps, err := allBuildpacks(bldr.Image(), additionalBuildpacks)
@@ -862,7 +1340,7 @@ func (c *Client) validateMixins(additionalBuildpacks []buildpack.BuildModule, bl
 
 	for _, bp := range bps {
 		if err := bp.EnsureStackSupport(bldr.StackID, mixins, true); err != nil {
-			return err
+			return c.checkCompat(mode, err, diagnostics...)
 		}
 	}
 	return nil
@@ -1432,6 +1910,7 @@ func (c *Client) addUserMountpoints(lifecycleImage imgutil.Image, dest string, w
 
 func (c *Client) createEphemeralLifecycle(lifecycleImage imgutil.Image, workspace string, uid int, gid int) (imgutil.Image, error) {
 	lifecycleImage.Rename(fmt.Sprintf("pack.local/lifecycle/%x:latest", randString(10)))
+	c.trackIntermediateImage(lifecycleImage.Name(), IntermediateLifecycle)
 
 	tmpDir, err := os.MkdirTemp("", "create-lifecycle-scratch")
 	if err != nil {
@@ -1457,6 +1936,7 @@ func (c *Client) createEphemeralLifecycle(lifecycleImage imgutil.Image, workspac
 func (c *Client) createEphemeralBuilder(
 	rawBuilderImage imgutil.Image,
 	env map[string]string,
+	buildpackEnv map[string]map[string]string,
 	order dist.Order,
 	buildpacks []buildpack.BuildModule,
 	orderExtensions dist.Order,
@@ -1471,6 +1951,7 @@ func (c *Client) createEphemeralBuilder(
 	}
 
 	bldr.SetEnv(env)
+	bldr.SetBuildpackEnv(buildpackEnv)
 	for _, bp := range buildpacks {
 		bpInfo := bp.Descriptor().Info()
 		c.logger.Debugf("Adding buildpack %s version %s to builder", style.Symbol(bpInfo.ID), style.Symbol(bpInfo.Version))
@@ -1493,12 +1974,31 @@ func (c *Client) createEphemeralBuilder(
 
 	bldr.SetValidateMixins(validateMixins)
 
+	c.trackIntermediateImage(bldr.Name(), IntermediateBuilder)
 	if err := bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version}); err != nil {
 		return nil, err
 	}
 	return bldr, nil
 }
 
+// warnUnknownBuildpackEnv warns about environment variables scoped to buildpacks that are neither on the builder
+// nor provided for the build, as no buildpack of the build would be given them
+func (c *Client) warnUnknownBuildpackEnv(buildpackEnv map[string]map[string]string, bldr *builder.Builder, buildpacks []buildpack.BuildModule) {
+	known := map[string]bool{}
+	for _, bp := range bldr.Buildpacks() {
+		known[bp.ID] = true
+	}
+	for _, bp := range buildpacks {
+		known[bp.Descriptor().Info().ID] = true
+	}
+
+	for id := range buildpackEnv {
+		if !known[id] {
+			c.logger.Warnf("Buildpack %s given environment variables is neither on the builder nor provided for the build", style.Symbol(id))
+		}
+	}
+}
+
 // Returns a string iwith lowercase a-z, of length n
 func randString(n int) string {
 	b := make([]byte, n)
@@ -1615,6 +2115,15 @@ func fullImagePath(inputImageRef InputImageReference, create bool) (string, erro
 	return imagePath, nil
 }
 
+// appSlices returns the slices of the project descriptor, for the exporter
+func appSlices(slices []projectTypes.Slice) []layers.Slice {
+	var result []layers.Slice
+	for _, slice := range slices {
+		result = append(result, layers.Slice{Paths: slice.Paths})
+	}
+	return result
+}
+
 // appendLayoutVolumes mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'
 // the volumes mounted are:
 // - The path where the user wants the image to be exported in OCI layout format
@@ -1632,6 +2141,51 @@ func appendLayoutVolumes(volumes []string, config layoutPathConfig) []string {
 	return volumes
 }
 
+// copyLayout tells whether the layout directories are copied into the build containers rather than bound
+func (c *Client) copyLayout(strategy string) (bool, error) {
+	switch strategy {
+	case LayoutBindMount:
+		return false, nil
+	case LayoutBindCopy:
+		return true, nil
+	case "", LayoutBindAuto:
+		if daemonHost := c.daemonHost(); !isLocalDaemonHost(daemonHost) {
+			c.logger.Debugf("Copying layout directories into the build, as the daemon at %s can't bind them", style.Symbol(daemonHost))
+			return true, nil
+		}
+		return false, nil
+	default:
+		return false, errors.Errorf("invalid layout bind strategy %s, must be one of %s, %s or %s", style.Symbol(strategy), LayoutBindAuto, LayoutBindMount, LayoutBindCopy)
+	}
+}
+
+// daemonHost returns the address of the daemon, or an empty string if the docker client doesn't tell it
+func (c *Client) daemonHost() string {
+	if d, ok := c.docker.(interface{ DaemonHost() string }); ok {
+		return d.DaemonHost()
+	}
+	return ""
+}
+
+func isLocalDaemonHost(daemonHost string) bool {
+	return daemonHost == "" || strings.HasPrefix(daemonHost, "unix://") || strings.HasPrefix(daemonHost, "npipe://")
+}
+
+// layoutCopiesFor returns the directories given to the build containers by appendLayoutVolumes, to copy them instead
+func layoutCopiesFor(config layoutPathConfig) []build.LayoutCopy {
+	var copies []build.LayoutCopy
+	if config.hostPreviousImagePath != "" {
+		// unlike a bind, a copy needs the previous image to exist
+		if _, err := os.Stat(config.hostPreviousImagePath); err == nil {
+			copies = append(copies, build.LayoutCopy{HostPath: config.hostPreviousImagePath, TargetPath: config.targetPreviousImagePath})
+		}
+	}
+	return append(copies,
+		build.LayoutCopy{HostPath: config.hostRunImagePath, TargetPath: config.targetRunImagePath},
+		build.LayoutCopy{HostPath: config.hostImagePath, TargetPath: config.targetImagePath, Output: true},
+	)
+}
+
 func writableVolume(hostPath, targetPath string) string {
 	tp := targetPath
 	if !filepath.IsAbs(targetPath) {
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/lock"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+)
+
+// buildWorkspaces returns the names of the caches and volumes the build keeps state in between runs, which builds
+// running at once must take turns using: the build cache, unless it's an image, the launch cache, and the volumes
+// of the phase when running a phase on its own
+func buildWorkspaces(opts build.LifecycleOptions) []string {
+	var workspaces []string
+	switch {
+	case opts.CacheImage != "" || opts.Cache.Build.Format == cache.CacheImage:
+	case opts.Cache.Build.Format == cache.CacheBind:
+		workspaces = append(workspaces, cache.NewBindCache(opts.Cache.Build, nil).Name())
+	default:
+		workspaces = append(workspaces, cache.NewVolumeCache(opts.Image, opts.Cache.Build, "build", nil).Name())
+	}
+	workspaces = append(workspaces, cache.NewVolumeCache(opts.Image, opts.Cache.Launch, "launch", nil).Name())
+
+	if opts.Phase != "" {
+		layersVolume, appVolume := build.PhaseVolumes(opts.Image)
+		workspaces = append(workspaces, layersVolume, appVolume)
+	}
+
+	sort.Strings(workspaces)
+	return workspaces
+}
+
+// lockWorkspaces locks the workspaces of the build for other builds not to use them at once, waiting for the builds
+// using them to finish or failing at once when failIfLocked is set. The locks are acquired in order, for builds
+// sharing some of their workspaces not to deadlock.
+func (c *Client) lockWorkspaces(ctx context.Context, opts build.LifecycleOptions, failIfLocked bool) (func(), error) {
+	if c.locksDir == "" {
+		return func() {}, nil
+	}
+
+	var locks []*lock.Lock
+	release := func() {
+		for _, l := range locks {
+			if err := l.Release(); err != nil {
+				c.logger.Debugf("Releasing lock: %s", err)
+			}
+		}
+	}
+
+	for _, workspace := range buildWorkspaces(opts) {
+		path := filepath.Join(c.locksDir, fmt.Sprintf("%x.lock", sha256.Sum256([]byte(workspace))))
+
+		var (
+			l   *lock.Lock
+			err error
+		)
+		if failIfLocked {
+			l, err = lock.TryAcquire(path)
+			if errors.Is(err, lock.ErrLocked) {
+				release()
+				return nil, errors.Errorf("another build is using %s, try again once it's done", style.Symbol(workspace))
+			}
+		} else {
+			l, err = lock.Acquire(ctx, path, func() {
+				c.logger.Infof("Waiting for another build using %s to finish", style.Symbol(workspace))
+			})
+		}
+		if err != nil {
+			release()
+			return nil, errors.Wrapf(err, "locking %s", style.Symbol(workspace))
+		}
+		locks = append(locks, l)
+	}
+	return release, nil
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// BuildManifestKubernetesJob is a Kubernetes Job building the app image.
+	BuildManifestKubernetesJob = "k8s-job"
+
+	// BuildManifestTektonTaskRun is a Tekton TaskRun building the app image.
+	BuildManifestTektonTaskRun = "tekton-taskrun"
+
+	// manifestCredentialsSecret is the docker config secret the lifecycle reads registry credentials from
+	manifestCredentialsSecret = "registry-credentials"
+)
+
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// WriteBuildManifest writes a manifest of the given kind, which runs the lifecycle of the builder to build and publish
+// the app image the way Build would with opts. The app source is read from a volume, which the manifest leaves to be
+// populated, and registry credentials from a docker config secret named 'registry-credentials'. Buildpacks that are
+// not in the builder are not supported, as the manifest runs the builder as it is.
+func (c *Client) WriteBuildManifest(ctx context.Context, opts BuildOptions, kind string, w io.Writer) error {
+	if kind != BuildManifestKubernetesJob && kind != BuildManifestTektonTaskRun {
+		return errors.Errorf("invalid manifest kind %s, must be one of %s or %s", style.Symbol(kind), BuildManifestKubernetesJob, BuildManifestTektonTaskRun)
+	}
+	if len(opts.Buildpacks) > 0 || len(opts.Extensions) > 0 || len(opts.PreBuildpacks) > 0 || len(opts.PostBuildpacks) > 0 ||
+		len(opts.ProjectDescriptor.Build.Buildpacks) > 0 {
+		return errors.New("buildpacks can't be given when writing a build manifest, create a builder with them instead")
+	}
+
+	imageRef, err := c.parseReference(opts)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+	builderRef, err := c.processBuilderName(opts.Builder)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+	}
+
+	// the cluster pulls the builder from the registry, so it is read from there rather than from the daemon
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: false, InsecureRegistries: opts.InsecureRegistries})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
+	}
+	bldr, err := c.getBuilder(rawBuilderImage)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
+	}
+	platformAPI, err := build.FindLatestSupported(append(
+		bldr.LifecycleDescriptor().APIs.Platform.Deprecated,
+		bldr.LifecycleDescriptor().APIs.Platform.Supported...), nil)
+	if err != nil {
+		return fmt.Errorf("finding latest supported Platform API: %w", err)
+	}
+
+	runImageName := c.resolveRunImage(opts.RunImage, imageRef.Context().RegistryStr(), builderRef.Context().RegistryStr(),
+		bldr.DefaultRunImage(), opts.AdditionalMirrors, true, image.FetchOptions{Daemon: false, InsecureRegistries: opts.InsecureRegistries})
+
+	args := []string{"-app", "/workspace", "-layers", "/layers", "-platform", "/platform", "-run-image", runImageName}
+	if opts.CacheImage != "" {
+		args = append(args, "-cache-image", opts.CacheImage)
+	}
+	if opts.PreviousImage != "" {
+		args = append(args, "-previous-image", opts.PreviousImage)
+	}
+	if opts.DefaultProcessType != "" {
+		args = append(args, "-process-type", opts.DefaultProcessType)
+	}
+	for _, tag := range opts.AdditionalTags {
+		args = append(args, "-tag", tag)
+	}
+	if !platformAPI.LessThan("0.13") {
+		for _, reg := range opts.InsecureRegistries {
+			args = append(args, "-insecure-registry", reg)
+		}
+	}
+	args = append(args, imageRef.Name())
+
+	uid, gid := bldr.UID(), bldr.GID()
+	if opts.UserID >= 0 {
+		uid = opts.UserID
+	}
+	if opts.GroupID >= 0 {
+		gid = opts.GroupID
+	}
+
+	env, err := manifestBuildEnv(opts)
+	if err != nil {
+		return err
+	}
+
+	create := manifestContainer{
+		Name:    "create",
+		Image:   builderRef.Name(),
+		Command: []string{"/cnb/lifecycle/creator"},
+		Args:    args,
+		Env: []manifestEnvVar{
+			{Name: "CNB_PLATFORM_API", Value: platformAPI.String()},
+			{Name: "DOCKER_CONFIG", Value: "/docker-config"},
+		},
+		SecurityContext: &manifestSecurityContext{RunAsUser: uid, RunAsGroup: gid},
+		VolumeMounts: []manifestVolumeMount{
+			{Name: "workspace", MountPath: "/workspace"},
+			{Name: "layers", MountPath: "/layers"},
+			{Name: "platform", MountPath: "/platform"},
+			{Name: "docker-config", MountPath: "/docker-config"},
+		},
+	}
+	// the build environment is given to the lifecycle as files in the platform directory
+	prepare := manifestContainer{
+		Name:            "prepare",
+		Image:           builderRef.Name(),
+		Command:         []string{"/bin/sh", "-c", manifestPrepareScript(env)},
+		Env:             env,
+		SecurityContext: &manifestSecurityContext{RunAsUser: uid, RunAsGroup: gid},
+		VolumeMounts:    []manifestVolumeMount{{Name: "platform", MountPath: "/platform"}},
+	}
+	volumes := []manifestVolume{
+		{Name: "layers", EmptyDir: &struct{}{}},
+		{Name: "platform", EmptyDir: &struct{}{}},
+		{Name: "docker-config", Secret: &manifestSecretVolume{
+			SecretName: manifestCredentialsSecret,
+			Items:      []manifestKeyToPath{{Key: ".dockerconfigjson", Path: "config.json"}},
+		}},
+	}
+
+	name := strings.ReplaceAll(strings.TrimSuffix(imageRef.Context().RepositoryStr(), "/"), "/", "-") + "-build"
+	var manifest interface{}
+	switch kind {
+	case BuildManifestKubernetesJob:
+		manifest = manifestJob{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+			Metadata:   manifestMetadata{GenerateName: name + "-"},
+			Spec: manifestJobSpec{
+				BackoffLimit: 0,
+				Template: manifestPodTemplate{Spec: manifestPodSpec{
+					RestartPolicy:   "Never",
+					SecurityContext: &manifestPodSecurityContext{FSGroup: gid},
+					InitContainers:  []manifestContainer{prepare},
+					Containers:      []manifestContainer{create},
+					Volumes:         append([]manifestVolume{{Name: "workspace", EmptyDir: &struct{}{}}}, volumes...),
+				}},
+			},
+		}
+	case BuildManifestTektonTaskRun:
+		manifest = manifestTaskRun{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "TaskRun",
+			Metadata:   manifestMetadata{GenerateName: name + "-"},
+			Spec: manifestTaskRunSpec{
+				TaskSpec: manifestTaskSpec{
+					Workspaces: []manifestWorkspace{{Name: "source", MountPath: "/workspace"}},
+					Steps:      []manifestContainer{prepare, withoutMount(create, "workspace")},
+					Volumes:    volumes,
+				},
+				Workspaces:  []manifestWorkspaceBinding{{Name: "source", EmptyDir: &struct{}{}}},
+				PodTemplate: &manifestPodTemplateSpec{SecurityContext: &manifestPodSecurityContext{FSGroup: gid}},
+			},
+		}
+	}
+
+	fmt.Fprintf(w, "# Builds %s with %s, as 'pack build' would.\n", imageRef.Name(), builderRef.Name())
+	fmt.Fprintln(w, "# Populate the app source volume, such as with a step cloning the app repository, and create the")
+	fmt.Fprintf(w, "# %s docker config secret with credentials for the registries before applying it.\n", manifestCredentialsSecret)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(manifest); err != nil {
+		return errors.Wrap(err, "writing manifest")
+	}
+	return enc.Close()
+}
+
+// manifestBuildEnv returns the build environment of opts, sorted by name
+func manifestBuildEnv(opts BuildOptions) ([]manifestEnvVar, error) {
+	env := map[string]string{}
+	for _, envVar := range opts.ProjectDescriptor.Build.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	for k, v := range opts.Env {
+		env[k] = v
+	}
+
+	var vars []manifestEnvVar
+	for k, v := range env {
+		if !envNameRegexp.MatchString(k) {
+			return nil, errors.Errorf("invalid environment variable name %s", style.Symbol(k))
+		}
+		vars = append(vars, manifestEnvVar{Name: k, Value: v})
+	}
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Name < vars[j].Name
+	})
+	return vars, nil
+}
+
+// manifestPrepareScript writes each environment variable to a file of the platform directory
+func manifestPrepareScript(env []manifestEnvVar) string {
+	script := "mkdir -p /platform/env"
+	for _, e := range env {
+		script += fmt.Sprintf(` && printf '%%s' "$%s" > /platform/env/%s`, e.Name, e.Name)
+	}
+	return script
+}
+
+func withoutMount(c manifestContainer, volume string) manifestContainer {
+	var mounts []manifestVolumeMount
+	for _, m := range c.VolumeMounts {
+		if m.Name != volume {
+			mounts = append(mounts, m)
+		}
+	}
+	c.VolumeMounts = mounts
+	return c
+}
+
+// The types below are the subset of the Kubernetes and Tekton resources needed for build manifests.
+
+type manifestMetadata struct {
+	GenerateName string `yaml:"generateName"`
+}
+
+type manifestJob struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Metadata   manifestMetadata `yaml:"metadata"`
+	Spec       manifestJobSpec  `yaml:"spec"`
+}
+
+type manifestJobSpec struct {
+	BackoffLimit int                 `yaml:"backoffLimit"`
+	Template     manifestPodTemplate `yaml:"template"`
+}
+
+type manifestPodTemplate struct {
+	Spec manifestPodSpec `yaml:"spec"`
+}
+
+type manifestPodSpec struct {
+	RestartPolicy   string                      `yaml:"restartPolicy"`
+	SecurityContext *manifestPodSecurityContext `yaml:"securityContext,omitempty"`
+	InitContainers  []manifestContainer         `yaml:"initContainers,omitempty"`
+	Containers      []manifestContainer         `yaml:"containers"`
+	Volumes         []manifestVolume            `yaml:"volumes,omitempty"`
+}
+
+type manifestPodSecurityContext struct {
+	FSGroup int `yaml:"fsGroup"`
+}
+
+type manifestContainer struct {
+	Name            string                   `yaml:"name"`
+	Image           string                   `yaml:"image"`
+	Command         []string                 `yaml:"command,omitempty"`
+	Args            []string                 `yaml:"args,omitempty"`
+	Env             []manifestEnvVar         `yaml:"env,omitempty"`
+	SecurityContext *manifestSecurityContext `yaml:"securityContext,omitempty"`
+	VolumeMounts    []manifestVolumeMount    `yaml:"volumeMounts,omitempty"`
+}
+
+type manifestEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type manifestSecurityContext struct {
+	RunAsUser  int `yaml:"runAsUser"`
+	RunAsGroup int `yaml:"runAsGroup"`
+}
+
+type manifestVolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
+type manifestVolume struct {
+	Name     string                `yaml:"name"`
+	EmptyDir *struct{}             `yaml:"emptyDir,omitempty"`
+	Secret   *manifestSecretVolume `yaml:"secret,omitempty"`
+}
+
+type manifestSecretVolume struct {
+	SecretName string              `yaml:"secretName"`
+	Items      []manifestKeyToPath `yaml:"items,omitempty"`
+}
+
+type manifestKeyToPath struct {
+	Key  string `yaml:"key"`
+	Path string `yaml:"path"`
+}
+
+type manifestTaskRun struct {
+	APIVersion string              `yaml:"apiVersion"`
+	Kind       string              `yaml:"kind"`
+	Metadata   manifestMetadata    `yaml:"metadata"`
+	Spec       manifestTaskRunSpec `yaml:"spec"`
+}
+
+type manifestTaskRunSpec struct {
+	TaskSpec    manifestTaskSpec           `yaml:"taskSpec"`
+	Workspaces  []manifestWorkspaceBinding `yaml:"workspaces"`
+	PodTemplate *manifestPodTemplateSpec   `yaml:"podTemplate,omitempty"`
+}
+
+type manifestTaskSpec struct {
+	Workspaces []manifestWorkspace `yaml:"workspaces"`
+	Steps      []manifestContainer `yaml:"steps"`
+	Volumes    []manifestVolume    `yaml:"volumes,omitempty"`
+}
+
+type manifestWorkspace struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
+type manifestWorkspaceBinding struct {
+	Name     string    `yaml:"name"`
+	EmptyDir *struct{} `yaml:"emptyDir,omitempty"`
+}
+
+type manifestPodTemplateSpec struct {
+	SecurityContext *manifestPodSecurityContext `yaml:"securityContext,omitempty"`
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"gopkg.in/yaml.v3"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWriteBuildManifest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "WriteBuildManifest", testWriteBuildManifest, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWriteBuildManifest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject      *Client
+		builderImage *fakes.Image
+		tmpDir       string
+		out          bytes.Buffer
+	)
+
+	// writeManifest writes the manifest and returns it, along with its parsed document
+	writeManifest := func(opts BuildOptions, kind string) (string, map[string]interface{}) {
+		var manifest bytes.Buffer
+		h.AssertNil(t, subject.WriteBuildManifest(context.TODO(), opts, kind, &manifest))
+
+		var doc map[string]interface{}
+		h.AssertNil(t, yaml.Unmarshal(manifest.Bytes(), &doc))
+		return manifest.String(), doc
+	}
+
+	// container returns the container with the name in the list of containers
+	container := func(containers interface{}, name string) map[string]interface{} {
+		for _, c := range containers.([]interface{}) {
+			if c.(map[string]interface{})["name"] == name {
+				return c.(map[string]interface{})
+			}
+		}
+		t.Fatalf("no container named %s", name)
+		return nil
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "build-manifest")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher := ifakes.NewFakeImageFetcher()
+		builderImage = newFakeBuilderImage(t, tmpDir, "example.com/some/builder:tag", "some.stack.id", "default/run", builder.DefaultLifecycleVersion, newLinuxImage)
+		fakeImageFetcher.RemoteImages[builderImage.Name()] = builderImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, builderImage.Cleanup())
+		os.RemoveAll(tmpDir)
+	})
+
+	when("#WriteBuildManifest", func() {
+		it("writes a Kubernetes Job running the creator", func() {
+			manifest, doc := writeManifest(BuildOptions{
+				Image:          "registry1.example.com/some/app",
+				Builder:        "example.com/some/builder:tag",
+				Env:            map[string]string{"SOME_VAR": "some-value"},
+				AdditionalTags: []string{"registry1.example.com/some/app:v1"},
+				UserID:         -1,
+				GroupID:        -1,
+			}, BuildManifestKubernetesJob)
+
+			h.AssertContains(t, manifest, "# Builds registry1.example.com/some/app:latest with example.com/some/builder:tag")
+			h.AssertEq(t, doc["kind"], "Job")
+			h.AssertEq(t, doc["metadata"], map[string]interface{}{"generateName": "some-app-build-"})
+
+			podSpec := doc["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+			create := container(podSpec["containers"], "create")
+			h.AssertEq(t, create["image"], "example.com/some/builder:tag")
+			h.AssertEq(t, create["args"], []interface{}{
+				"-app", "/workspace", "-layers", "/layers", "-platform", "/platform",
+				"-run-image", "registry1.example.com/run/mirror",
+				"-tag", "registry1.example.com/some/app:v1",
+				"registry1.example.com/some/app:latest",
+			})
+			h.AssertEq(t, create["securityContext"], map[string]interface{}{"runAsUser": 1234, "runAsGroup": 5678})
+
+			prepare := container(podSpec["initContainers"], "prepare")
+			h.AssertContains(t, prepare["command"].([]interface{})[2].(string), `printf '%s' "$SOME_VAR" > /platform/env/SOME_VAR`)
+			h.AssertEq(t, prepare["env"], []interface{}{map[string]interface{}{"name": "SOME_VAR", "value": "some-value"}})
+		})
+
+		it("writes a Tekton TaskRun with the app source in a workspace", func() {
+			_, doc := writeManifest(BuildOptions{
+				Image:   "registry1.example.com/some/app",
+				Builder: "example.com/some/builder:tag",
+				UserID:  1000,
+				GroupID: 1001,
+			}, BuildManifestTektonTaskRun)
+
+			h.AssertEq(t, doc["kind"], "TaskRun")
+			taskSpec := doc["spec"].(map[string]interface{})["taskSpec"].(map[string]interface{})
+			h.AssertEq(t, taskSpec["workspaces"], []interface{}{map[string]interface{}{"name": "source", "mountPath": "/workspace"}})
+			create := container(taskSpec["steps"], "create")
+			h.AssertEq(t, create["securityContext"], map[string]interface{}{"runAsUser": 1000, "runAsGroup": 1001})
+			for _, mount := range create["volumeMounts"].([]interface{}) {
+				h.AssertNotEq(t, mount.(map[string]interface{})["name"], "workspace")
+			}
+		})
+
+		it("fails with buildpacks that are not in the builder", func() {
+			err := subject.WriteBuildManifest(context.TODO(), BuildOptions{
+				Image:      "some/app",
+				Builder:    "example.com/some/builder:tag",
+				Buildpacks: []string{"some/buildpack"},
+			}, BuildManifestKubernetesJob, &out)
+			h.AssertError(t, err, "buildpacks can't be given when writing a build manifest")
+		})
+
+		it("fails with an unknown kind", func() {
+			err := subject.WriteBuildManifest(context.TODO(), BuildOptions{Image: "some/app", Builder: "example.com/some/builder:tag"}, "compose", &out)
+			h.AssertError(t, err, "invalid manifest kind 'compose', must be one of k8s-job or tekton-taskrun")
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// replayedPlan reads the build plan exported by a previous build at the path, which is replayed by the build instead
+// of detecting. Its buildpacks must be in the builder, with the same versions.
+func replayedPlan(path string, buildpacks []dist.ModuleInfo) (*build.BuildPlan, error) {
+	plan, err := build.ReadBuildPlanFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.GroupExtensions) > 0 {
+		return nil, errors.Errorf("build plan %s has image extensions, which can't be replayed", style.Symbol(path))
+	}
+
+	builderBuildpacks := map[string]bool{}
+	for _, bp := range buildpacks {
+		builderBuildpacks[bp.FullName()] = true
+	}
+	for _, bp := range plan.Group {
+		fullName := (dist.ModuleInfo{ID: bp.ID, Version: bp.Version}).FullName()
+		if !builderBuildpacks[fullName] {
+			return nil, errors.Errorf("buildpack %s of build plan %s is not in the builder", style.Symbol(fullName), style.Symbol(path))
+		}
+	}
+	return &plan, nil
+}
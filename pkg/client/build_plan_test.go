@@ -0,0 +1,82 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildPlan(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildPlan", testBuildPlan, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildPlan(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir     string
+		planPath   string
+		buildpacks = []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}, {ID: "other/bp", Version: "2.0.0"}}
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "build-plan")
+		h.AssertNil(t, err)
+		planPath = filepath.Join(tmpDir, "plan.toml")
+	})
+
+	it.After(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	when("#replayedPlan", func() {
+		it("reads the build plan", func() {
+			h.AssertNil(t, os.WriteFile(planPath, []byte(`[[group]]
+id = "some/bp"
+version = "1.0.0"
+
+[[entries]]
+  [[entries.providers]]
+  id = "some/bp"
+  version = "1.0.0"
+  [[entries.requires]]
+  name = "some-dep"
+`), 0600))
+
+			plan, err := replayedPlan(planPath, buildpacks)
+			h.AssertNil(t, err)
+			h.AssertEq(t, plan.Group[0].ID, "some/bp")
+			h.AssertEq(t, plan.Entries[0].Requires[0].Name, "some-dep")
+		})
+
+		it("fails when a buildpack of the plan isn't in the builder", func() {
+			h.AssertNil(t, os.WriteFile(planPath, []byte("[[group]]\nid = \"some/bp\"\nversion = \"0.9.0\"\n"), 0600))
+
+			_, err := replayedPlan(planPath, buildpacks)
+			h.AssertError(t, err, "buildpack 'some/bp@0.9.0' of build plan")
+			h.AssertError(t, err, "is not in the builder")
+		})
+
+		it("fails when the plan has image extensions", func() {
+			h.AssertNil(t, os.WriteFile(planPath, []byte("[[group]]\nid = \"some/bp\"\nversion = \"1.0.0\"\n\n[[group-extensions]]\nid = \"some/ext\"\nversion = \"1.0.0\"\n"), 0600))
+
+			_, err := replayedPlan(planPath, buildpacks)
+			h.AssertError(t, err, "has image extensions, which can't be replayed")
+		})
+
+		it("fails when the plan has no buildpack group", func() {
+			h.AssertNil(t, os.WriteFile(planPath, []byte("[[entries]]\n"), 0600))
+
+			_, err := replayedPlan(planPath, buildpacks)
+			h.AssertError(t, err, "has no buildpack group")
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// fetchBuilder fetches the builder image from the daemon. When a platform is given, the variant of the builder for it
+// is pulled if the daemon has another one.
+func (c *Client) fetchBuilder(ctx context.Context, name string, opts BuildOptions) (imgutil.Image, error) {
+	fetchOptions := image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Target: opts.Platform}
+	builderImage, err := c.imageFetcher.Fetch(ctx, name, fetchOptions)
+	if err != nil || opts.Platform == nil {
+		return builderImage, err
+	}
+
+	builderPlatform, err := imagePlatform(builderImage)
+	if err != nil {
+		return nil, err
+	}
+	if matchesPlatform(builderPlatform, *opts.Platform) {
+		return builderImage, nil
+	}
+
+	if opts.PullPolicy != image.PullNever {
+		c.logger.Debugf("The daemon has the %s variant of builder %s, pulling the %s variant", builderPlatform.ValuesAsPlatform(), style.Symbol(name), opts.Platform.ValuesAsPlatform())
+		fetchOptions.PullPolicy = image.PullAlways
+		if builderImage, err = c.imageFetcher.Fetch(ctx, name, fetchOptions); err != nil {
+			return nil, err
+		}
+		if builderPlatform, err = imagePlatform(builderImage); err != nil {
+			return nil, err
+		}
+		if matchesPlatform(builderPlatform, *opts.Platform) {
+			return builderImage, nil
+		}
+	}
+
+	return nil, errors.Errorf("builder %s has no %s variant, only %s", style.Symbol(name), style.Symbol(opts.Platform.ValuesAsPlatform()), style.Symbol(builderPlatform.ValuesAsPlatform()))
+}
+
+// warnEmulatedBuilder warns when the daemon runs the builder emulated, as its architecture differs from the daemon's.
+func (c *Client) warnEmulatedBuilder(ctx context.Context, name string, builderPlatform dist.Target, platformGiven bool) {
+	version, err := c.docker.ServerVersion(ctx)
+	if err != nil {
+		c.logger.Debugf("Checking the daemon platform: %s", err)
+		return
+	}
+	if version.Os != builderPlatform.OS || version.Arch == "" || version.Arch == builderPlatform.Arch {
+		return
+	}
+
+	daemonPlatform := dist.Target{OS: version.Os, Arch: version.Arch}
+	c.logger.Warnf("Builder %s is %s, but the daemon runs %s, so the build runs emulated, which is slower and may fail",
+		style.Symbol(name), style.Symbol(builderPlatform.ValuesAsPlatform()), style.Symbol(daemonPlatform.ValuesAsPlatform()))
+	if !platformGiven {
+		logging.Tip(c.logger, "Use --platform %s to build with the builder variant for the daemon, if the builder has one", daemonPlatform.ValuesAsPlatform())
+	}
+}
+
+func imagePlatform(img imgutil.Image) (dist.Target, error) {
+	os, err := img.OS()
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting image OS")
+	}
+	arch, err := img.Architecture()
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting image architecture")
+	}
+	variant, err := img.Variant()
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting image architecture variant")
+	}
+	return dist.Target{OS: os, Arch: arch, ArchVariant: variant}, nil
+}
+
+// matchesPlatform returns whether the platform of an image is the wanted one, which may leave out the variant.
+func matchesPlatform(platform, wanted dist.Target) bool {
+	return platform.OS == wanted.OS && platform.Arch == wanted.Arch &&
+		(wanted.ArchVariant == "" || platform.ArchVariant == wanted.ArchVariant)
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildPlatform(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildPlatform", testBuildPlatform, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildPlatform(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDocker       *testmocks.MockCommonAPIClient
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		outBuf           bytes.Buffer
+	)
+
+	// builderImage returns a builder image for the architecture
+	builderImage := func(arch string) *fakes.Image {
+		img := fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, img.SetArchitecture(arch))
+		return img
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher: fakeImageFetcher,
+			docker:       mockDocker,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#fetchBuilder", func() {
+		it("fetches the builder the daemon has without a platform", func() {
+			fakeImageFetcher.LocalImages["some/builder"] = builderImage("amd64")
+
+			img, err := subject.fetchBuilder(context.TODO(), "some/builder", BuildOptions{PullPolicy: image.PullNever})
+			h.AssertNil(t, err)
+			h.AssertSameInstance(t, img, fakeImageFetcher.LocalImages["some/builder"])
+		})
+
+		it("pulls the variant for the platform when the daemon has another", func() {
+			fakeImageFetcher.LocalImages["some/builder"] = builderImage("amd64")
+			fakeImageFetcher.RemoteImages["some/builder"] = builderImage("arm64")
+
+			img, err := subject.fetchBuilder(context.TODO(), "some/builder", BuildOptions{
+				PullPolicy: image.PullIfNotPresent,
+				Platform:   &dist.Target{OS: "linux", Arch: "arm64"},
+			})
+			h.AssertNil(t, err)
+			arch, err := img.Architecture()
+			h.AssertNil(t, err)
+			h.AssertEq(t, arch, "arm64")
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["some/builder"].Target, &dist.Target{OS: "linux", Arch: "arm64"})
+		})
+
+		it("fails when the builder has no variant for the platform", func() {
+			fakeImageFetcher.LocalImages["some/builder"] = builderImage("amd64")
+
+			_, err := subject.fetchBuilder(context.TODO(), "some/builder", BuildOptions{
+				PullPolicy: image.PullNever,
+				Platform:   &dist.Target{OS: "linux", Arch: "arm64"},
+			})
+			h.AssertError(t, err, "builder 'some/builder' has no 'linux/arm64' variant, only 'linux/amd64'")
+		})
+	})
+
+	when("#warnEmulatedBuilder", func() {
+		it("warns when the daemon runs another architecture", func() {
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "arm64"}, nil)
+
+			subject.warnEmulatedBuilder(context.TODO(), "some/builder", dist.Target{OS: "linux", Arch: "amd64"}, false)
+			h.AssertContains(t, outBuf.String(), "Warning: Builder 'some/builder' is 'linux/amd64', but the daemon runs 'linux/arm64', so the build runs emulated")
+			h.AssertContains(t, outBuf.String(), "Use --platform linux/arm64")
+		})
+
+		it("doesn't warn when the daemon runs the same architecture", func() {
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "amd64"}, nil)
+
+			subject.warnEmulatedBuilder(context.TODO(), "some/builder", dist.Target{OS: "linux", Arch: "amd64"}, false)
+			h.AssertEq(t, outBuf.String(), "")
+		})
+	})
+}
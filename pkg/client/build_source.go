@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// sourceBuildWorkspace is the directory the source of a buildpack is mounted at when built in a container
+const sourceBuildWorkspace = "/workspace"
+
+// sourceBuilds are the commands building the source of a buildpack, by the file they are run for, in order of
+// preference
+var sourceBuilds = []struct {
+	file string
+	cmd  []string
+}{
+	{file: "Makefile", cmd: []string{"make"}},
+	{file: "build.sh", cmd: []string{"/bin/sh", "build.sh"}},
+}
+
+// sourceBuildCommand returns the command building the source of a buildpack in the directory, if it has a Makefile or
+// a build.sh script
+func sourceBuildCommand(dir string) (file string, cmd []string, ok bool) {
+	for _, build := range sourceBuilds {
+		if _, err := os.Stat(filepath.Join(dir, build.file)); err == nil {
+			return build.file, build.cmd, true
+		}
+	}
+	return "", nil, false
+}
+
+// buildBuildpackSource builds the source of the buildpack to package, when it is a directory with a Makefile or a
+// build.sh script, either on the host or in a container of the source build image.
+func (c *Client) buildBuildpackSource(ctx context.Context, opts PackageBuildpackOptions) error {
+	uri := opts.Config.Buildpack.URI
+	if uri == "" || (paths.IsURI(uri) && !strings.HasPrefix(uri, "file://")) {
+		c.logger.Debugf("Buildpack %s isn't a local directory, skipping source build", style.Symbol(uri))
+		return nil
+	}
+
+	dir := uri
+	if strings.HasPrefix(uri, "file://") {
+		var err error
+		if dir, err = paths.URIToFilePath(uri); err != nil {
+			return errors.Wrapf(err, "invalid buildpack URI %s", style.Symbol(uri))
+		}
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(opts.RelativeBaseDir, dir)
+	}
+	if isDir, err := paths.IsDir(dir); err != nil || !isDir {
+		c.logger.Debugf("Buildpack %s isn't a local directory, skipping source build", style.Symbol(uri))
+		return nil
+	}
+
+	file, cmd, ok := sourceBuildCommand(dir)
+	if !ok {
+		c.logger.Debugf("Buildpack directory %s has no Makefile or build.sh, skipping source build", style.Symbol(dir))
+		return nil
+	}
+
+	if opts.DryRun {
+		c.logger.Infof("Buildpack source in %s would be built with %s", style.Symbol(dir), style.Symbol(file))
+		return nil
+	}
+
+	if opts.SourceBuildImage == "" {
+		c.logger.Infof("Building buildpack source in %s with %s", style.Symbol(dir), style.Symbol(file))
+		return c.buildSourceOnHost(ctx, dir, cmd)
+	}
+
+	c.logger.Infof("Building buildpack source in %s with %s in %s", style.Symbol(dir), style.Symbol(file), style.Symbol(opts.SourceBuildImage))
+	return c.buildSourceInContainer(ctx, dir, cmd, opts.SourceBuildImage, opts.PullPolicy)
+}
+
+func (c *Client) buildSourceOnHost(ctx context.Context, dir string, cmd []string) error {
+	build := exec.CommandContext(ctx, cmd[0], cmd[1:]...) // #nosec G204 -- the commands are fixed
+	build.Dir = dir
+	build.Stdout = logging.GetWriterForLevel(c.logger, logging.InfoLevel)
+	build.Stderr = logging.GetWriterForLevel(c.logger, logging.ErrorLevel)
+	if err := build.Run(); err != nil {
+		return errors.Wrapf(err, "building buildpack source with %s", style.Symbol(strings.Join(cmd, " ")))
+	}
+	return nil
+}
+
+// buildSourceInContainer builds the source in a container, with the source directory mounted as its working directory,
+// so that builds don't depend on the tools installed on the host
+func (c *Client) buildSourceInContainer(ctx context.Context, dir string, cmd []string, buildImage string, pullPolicy image.PullPolicy) error {
+	if _, err := c.imageFetcher.Fetch(ctx, buildImage, image.FetchOptions{Daemon: true, PullPolicy: pullPolicy}); err != nil {
+		return errors.Wrapf(err, "fetching source build image %s", style.Symbol(buildImage))
+	}
+
+	config := &containertypes.Config{
+		Image:      buildImage,
+		Cmd:        cmd,
+		WorkingDir: sourceBuildWorkspace,
+	}
+	if runtime.GOOS != "windows" {
+		// files written by the build are owned by the user packaging the buildpack
+		config.User = fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx, config, &containertypes.HostConfig{
+		Binds: []string{fmt.Sprintf("%s:%s", dir, sourceBuildWorkspace)},
+	}, nil, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "creating source build container")
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctr.ID, containertypes.RemoveOptions{Force: true})
+
+	handler := container.DefaultHandler(logging.GetWriterForLevel(c.logger, logging.InfoLevel), logging.GetWriterForLevel(c.logger, logging.ErrorLevel))
+	if err := container.RunWithHandler(ctx, c.docker, ctr.ID, handler); err != nil {
+		return errors.Wrapf(err, "building buildpack source with %s", style.Symbol(strings.Join(cmd, " ")))
+	}
+	return nil
+}
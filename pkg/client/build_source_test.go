@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldpkg "github.com/buildpacks/pack/buildpackage"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildSource(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildSource", testBuildSource, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildSource(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDocker       *testmocks.MockCommonAPIClient
+		mockImageFetcher *testmocks.MockImageFetcher
+		sourceDir        string
+		outBuf           bytes.Buffer
+	)
+
+	packageOptions := func(uri string) PackageBuildpackOptions {
+		return PackageBuildpackOptions{
+			Config:      pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: uri}},
+			BuildSource: true,
+		}
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf, logging.WithVerbose()),
+			docker:       mockDocker,
+			imageFetcher: mockImageFetcher,
+		}
+		sourceDir = t.TempDir()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#sourceBuildCommand", func() {
+		it("prefers the Makefile", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(sourceDir, "Makefile"), []byte("all:\n"), 0600))
+			h.AssertNil(t, os.WriteFile(filepath.Join(sourceDir, "build.sh"), []byte("exit 0\n"), 0600))
+
+			file, cmd, ok := sourceBuildCommand(sourceDir)
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, file, "Makefile")
+			h.AssertEq(t, cmd, []string{"make"})
+		})
+
+		it("uses build.sh when there is no Makefile", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(sourceDir, "build.sh"), []byte("exit 0\n"), 0600))
+
+			file, cmd, ok := sourceBuildCommand(sourceDir)
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, file, "build.sh")
+			h.AssertEq(t, cmd, []string{"/bin/sh", "build.sh"})
+		})
+	})
+
+	when("#buildBuildpackSource", func() {
+		it("skips buildpacks that aren't local directories", func() {
+			h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), packageOptions("https://example.com/bp.tgz")))
+			h.AssertContains(t, outBuf.String(), "isn't a local directory, skipping source build")
+		})
+
+		it("skips directories without a Makefile or build.sh", func() {
+			h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), packageOptions(sourceDir)))
+			h.AssertContains(t, outBuf.String(), "has no Makefile or build.sh, skipping source build")
+		})
+
+		when("the directory has a build.sh", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(filepath.Join(sourceDir, "build.sh"), []byte("echo built > built.txt\n"), 0600))
+			})
+
+			it("builds the source on the host", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "requires /bin/sh")
+
+				h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), packageOptions(sourceDir)))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Building buildpack source in '%s' with 'build.sh'", sourceDir))
+				h.AssertPathExists(t, filepath.Join(sourceDir, "built.txt"))
+			})
+
+			it("resolves relative directories against the base directory", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "requires /bin/sh")
+
+				opts := packageOptions(filepath.Base(sourceDir))
+				opts.RelativeBaseDir = filepath.Dir(sourceDir)
+				h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), opts))
+				h.AssertPathExists(t, filepath.Join(sourceDir, "built.txt"))
+			})
+
+			it("errors when the build fails", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "requires /bin/sh")
+				h.AssertNil(t, os.WriteFile(filepath.Join(sourceDir, "build.sh"), []byte("exit 3\n"), 0600))
+
+				err := subject.buildBuildpackSource(context.TODO(), packageOptions(sourceDir))
+				h.AssertError(t, err, "building buildpack source with '/bin/sh build.sh'")
+			})
+
+			it("only logs the build in a dry run", func() {
+				opts := packageOptions(sourceDir)
+				opts.DryRun = true
+				h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), opts))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Buildpack source in '%s' would be built with 'build.sh'", sourceDir))
+				h.AssertPathDoesNotExists(t, filepath.Join(sourceDir, "built.txt"))
+			})
+
+			it("builds the source in a container of the source build image", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "sets the user of the container")
+
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}).
+					Return(fakes.NewImage("some/build-image", "", nil), nil)
+				mockDocker.EXPECT().ContainerCreate(gomock.Any(), &containertypes.Config{
+					Image:      "some/build-image",
+					Cmd:        []string{"/bin/sh", "build.sh"},
+					WorkingDir: "/workspace",
+					User:       fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+				}, &containertypes.HostConfig{Binds: []string{sourceDir + ":/workspace"}}, nil, nil, "").
+					Return(containertypes.CreateResponse{ID: "source-build-id"}, nil)
+				mockDocker.EXPECT().ContainerWait(gomock.Any(), "source-build-id", gomock.Any()).DoAndReturn(
+					func(context.Context, string, containertypes.WaitCondition) (<-chan containertypes.WaitResponse, <-chan error) {
+						bodyChan := make(chan containertypes.WaitResponse, 1)
+						bodyChan <- containertypes.WaitResponse{StatusCode: 0}
+						return bodyChan, make(chan error)
+					})
+				conn, _ := net.Pipe()
+				mockDocker.EXPECT().ContainerAttach(gomock.Any(), "source-build-id", gomock.Any()).
+					Return(types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&bytes.Buffer{})}, nil)
+				mockDocker.EXPECT().ContainerStart(gomock.Any(), "source-build-id", gomock.Any()).Return(nil)
+				mockDocker.EXPECT().ContainerRemove(gomock.Any(), "source-build-id", containertypes.RemoveOptions{Force: true}).Return(nil)
+
+				opts := packageOptions(sourceDir)
+				opts.SourceBuildImage = "some/build-image"
+				opts.PullPolicy = image.PullIfNotPresent
+				h.AssertNil(t, subject.buildBuildpackSource(context.TODO(), opts))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Building buildpack source in '%s' with 'build.sh' in 'some/build-image'", sourceDir))
+			})
+		})
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
@@ -21,21 +22,26 @@ import (
 	"github.com/buildpacks/imgutil/local"
 	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform/files"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	cfg "github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/internal/lock"
 	rg "github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -161,6 +167,21 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("build inputs", func() {
+			it("records the builder, lifecycle, buildpacks and run image of the build", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+				inputs := fakeLifecycle.Opts.BuildInputs
+				h.AssertNotNil(t, inputs)
+				h.AssertEq(t, inputs.Builder, defaultBuilderName)
+				h.AssertEq(t, inputs.Lifecycle, builder.DefaultLifecycleVersion)
+				h.AssertEq(t, inputs.RunImage, "default/run")
+				h.AssertContains(t, strings.Join(inputs.Buildpacks, ","), "buildpack.1.id@buildpack.1.version")
+			})
+		})
+
 		when("Image option", func() {
 			it("is required", func() {
 				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
@@ -527,6 +548,95 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 					}),
 						"invalid run-image 'custom/run': run-image stack id 'other.stack' does not match builder stack 'some.stack.id'",
 					)
+					h.AssertContains(t, outBuf.String(), "Run image 'custom/run' has stack ID 'other.stack'")
+				})
+
+				it("warns and builds with a compat mode of warn", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						RunImage:   "custom/run",
+						CompatMode: CompatModeWarn,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.RunImage, "custom/run")
+					h.AssertContains(t, outBuf.String(), "Warning: run-image stack id 'other.stack' does not match builder stack 'some.stack.id', building anyway as the compat mode is 'warn'")
+				})
+
+				it("builds with a compat mode of ignore", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						RunImage:   "custom/run",
+						CompatMode: CompatModeIgnore,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.RunImage, "custom/run")
+					h.AssertNotContains(t, outBuf.String(), "does not match")
+				})
+			})
+
+			when("run image target does not match the builder target", func() {
+				it.Before(func() {
+					h.AssertNil(t, fakeRunImage.SetArchitecture("arm64"))
+				})
+
+				it("errors", func() {
+					h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+						Image:    "some/app",
+						Builder:  defaultBuilderName,
+						RunImage: "custom/run",
+					}),
+						"invalid run-image 'custom/run': run-image target 'linux/arm64' does not match builder target 'linux/amd64'",
+					)
+				})
+
+				it("warns and builds with a compat mode of warn", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						RunImage:   "custom/run",
+						CompatMode: CompatModeWarn,
+					}))
+					h.AssertContains(t, outBuf.String(), "Run image 'custom/run' is for linux/arm64")
+				})
+			})
+
+			when("the project descriptor declares a run image", func() {
+				descriptor := projectTypes.Descriptor{
+					Build: projectTypes.Build{RunImage: projectTypes.RunImage{
+						Image:   "custom/run",
+						Mirrors: []string{"registry1.example.com/custom/run"},
+					}},
+				}
+
+				it("uses it", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:             "some/app",
+						Builder:           defaultBuilderName,
+						ProjectDescriptor: descriptor,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.RunImage, "custom/run")
+				})
+
+				it("chooses its mirror matching the built image", func() {
+					fakeImageFetcher.RemoteImages["registry1.example.com/custom/run"] = fakeRunImage
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:             "registry1.example.com/some/app",
+						Builder:           defaultBuilderName,
+						ProjectDescriptor: descriptor,
+						Publish:           true,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.RunImage, "registry1.example.com/custom/run")
+				})
+
+				it("prefers the provided run image", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:             "some/app",
+						Builder:           defaultBuilderName,
+						RunImage:          "default/run",
+						ProjectDescriptor: descriptor,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.RunImage, "default/run")
 				})
 			})
 
@@ -641,6 +751,49 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("LifecycleArgs option", func() {
+			it("passes it through to lifecycle", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					LifecycleArgs: map[string][]string{"exporter": {"-process-type=web"}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.PhaseArgs, map[string][]string{"exporter": {"-process-type=web"}})
+			})
+
+			it("errors for unknown phases", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Phase:   "launch",
+				}), "unknown phase 'launch', must be one of analyze, detect, restore, build, export")
+
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					LifecycleArgs: map[string][]string{"launcher": {"-process-type=web"}},
+				}), "unknown lifecycle phase 'launcher', must be one of analyzer, builder, creator, detector, exporter, extender, restorer")
+			})
+
+			it("passes the phase artifacts directory through to lifecycle", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:             "some/app",
+					Builder:           defaultBuilderName,
+					Phase:             "restore",
+					PhaseArtifactsDir: "some-artifacts-dir",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.PhaseArtifactsDir, "some-artifacts-dir")
+			})
+
+			it("errors for a phase artifacts directory without a phase", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:             "some/app",
+					Builder:           defaultBuilderName,
+					PhaseArtifactsDir: "some-artifacts-dir",
+				}), "a phase artifacts directory requires a phase")
+			})
+		})
+
 		when("ClearCache option", func() {
 			it("passes it through to lifecycle", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
@@ -2049,6 +2202,99 @@ api = "0.2"
 			})
 		})
 
+		when("BuildpackEnv option", func() {
+			it("should set the env in the platform directory of each buildpack on the ephemeral builder", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					BuildpackEnv: map[string]map[string]string{
+						"buildpack.1.id": {"TOKEN": "some-token"},
+					},
+				}))
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/buildpacks/buildpack.1.id/env/TOKEN")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/buildpacks/buildpack.1.id/env/TOKEN", `some-token`)
+				_, err = defaultBuilderImage.FindLayerWithPath("/platform/env/TOKEN")
+				h.AssertNotNil(t, err)
+				h.AssertNotContains(t, outBuf.String(), "is neither on the builder nor provided for the build")
+			})
+
+			it("should warn about buildpacks that aren't part of the build", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					BuildpackEnv: map[string]map[string]string{
+						"some/other-buildpack": {"TOKEN": "some-token"},
+					},
+				}))
+				h.AssertContains(t, outBuf.String(), "Warning: Buildpack 'some/other-buildpack' given environment variables is neither on the builder nor provided for the build")
+
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/buildpacks/some_other-buildpack/env/TOKEN")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/buildpacks/some_other-buildpack/env/TOKEN", `some-token`)
+			})
+		})
+
+		when("Color option", func() {
+			it("asks the buildpacks to color their output, unless the env says otherwise", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Env:     map[string]string{"FORCE_COLOR": "0"},
+					Color:   true,
+				}))
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/CLICOLOR_FORCE")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/CLICOLOR_FORCE", `1`)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/FORCE_COLOR", `0`)
+			})
+		})
+
+		when("Bindings option", func() {
+			var bindingsDir string
+
+			it.Before(func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "Skipped on windows")
+
+				bindingsDir = filepath.Join(tmpDir, "bindings")
+				for _, name := range []string{"db", "cache"} {
+					h.AssertNil(t, os.MkdirAll(filepath.Join(bindingsDir, name), 0755))
+					h.AssertNil(t, os.WriteFile(filepath.Join(bindingsDir, name, "type"), []byte(name), 0600))
+				}
+			})
+
+			it("mounts the bindings of the project descriptor and options", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:                    "some/app",
+					Builder:                  defaultBuilderName,
+					ProjectDescriptorBaseDir: tmpDir,
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{Bindings: []projectTypes.Binding{{Name: "db", Path: "bindings/db"}}},
+					},
+					Bindings: map[string]string{"cache": filepath.Join(bindingsDir, "cache")},
+				}))
+
+				h.AssertEq(t, fakeLifecycle.Opts.Volumes, []string{
+					filepath.Join(bindingsDir, "cache") + ":/platform/bindings/cache:ro",
+					filepath.Join(bindingsDir, "db") + ":/platform/bindings/db:ro",
+				})
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/SERVICE_BINDING_ROOT")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/SERVICE_BINDING_ROOT", "/platform/bindings")
+			})
+
+			it("fails when a binding has no type", func() {
+				h.AssertNil(t, os.Remove(filepath.Join(bindingsDir, "db", "type")))
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "some/app",
+					Builder:  defaultBuilderName,
+					Bindings: map[string]string{"db": filepath.Join(bindingsDir, "db")},
+				})
+				h.AssertError(t, err, "binding 'db' must be a directory with a 'type' file")
+			})
+		})
+
 		when("Publish option", func() {
 			var remoteRunImage, builderWithoutLifecycleImageOrCreator *fakes.Image
 
@@ -2112,6 +2358,21 @@ api = "0.2"
 							h.AssertEq(t, args.PullPolicy, image.PullAlways)
 							h.AssertEq(t, args.Target.ValuesAsPlatform(), "linux/amd64")
 						})
+						it("pulls the lifecycle image with the lifecycle image keychain", func() {
+							keychain := authn.NewMultiKeychain(authn.DefaultKeychain)
+							origLifecyleName := fakeLifecycleImage.Name()
+
+							h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+								Image:                  "some/app",
+								Builder:                defaultBuilderName,
+								Publish:                true,
+								TrustBuilder:           func(string) bool { return false },
+								LifecycleImageKeychain: keychain,
+							}))
+							args := fakeImageFetcher.FetchCalls[origLifecyleName]
+							h.AssertNotNil(t, args)
+							h.AssertTrue(t, args.Keychain == keychain)
+						})
 						it("uses the api versions of the lifecycle image", func() {
 							h.AssertTrue(t, true)
 						})
@@ -2154,6 +2415,18 @@ api = "0.2"
 							args := fakeImageFetcher.FetchCalls[fakeLifecycleImage.Name()]
 							h.AssertNil(t, args)
 						})
+
+						it("runs a single phase without the creator", func() {
+							h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+								Image:        "some/app",
+								Builder:      defaultBuilderName,
+								Publish:      true,
+								TrustBuilder: func(string) bool { return true },
+								Phase:        "detect",
+							}))
+							h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+							h.AssertEq(t, fakeLifecycle.Opts.Phase, "detect")
+						})
 					})
 
 					when("lifecycle doesn't support creator", func() {
@@ -2304,6 +2577,57 @@ api = "0.2"
 			})
 		})
 
+		when("Annotations option", func() {
+			var (
+				remoteRunImage *fakes.Image
+				builtImage     *annotatedImage
+			)
+
+			it.Before(func() {
+				remoteRunImage = fakes.NewImage("default/run", "", nil)
+				h.AssertNil(t, remoteRunImage.SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+				h.AssertNil(t, remoteRunImage.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "mixinX", "run:mixinZ"]`))
+				fakeImageFetcher.RemoteImages[remoteRunImage.Name()] = remoteRunImage
+
+				builtImage = &annotatedImage{Image: fakes.NewImage("example.io/some/app:latest", "", nil)}
+				fakeImageFetcher.RemoteImages[builtImage.Name()] = builtImage
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, remoteRunImage.Cleanup())
+				h.AssertNilE(t, builtImage.Cleanup())
+			})
+
+			it("sets the annotations on the published image", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:       "example.io/some/app",
+					Builder:     defaultBuilderName,
+					Publish:     true,
+					Annotations: map[string]string{"org.opencontainers.image.source": "https://github.com/some/app"},
+				}))
+
+				h.AssertEq(t, builtImage.annotations, map[string]string{"org.opencontainers.image.source": "https://github.com/some/app"})
+				h.AssertEq(t, builtImage.IsSaved(), true)
+
+				args := fakeImageFetcher.FetchCalls["example.io/some/app:latest"]
+				h.AssertEq(t, args.Daemon, false)
+			})
+
+			it("warns that annotations aren't kept without publishing", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:       "example.io/some/app",
+					Builder:     defaultBuilderName,
+					Annotations: map[string]string{"org.opencontainers.image.source": "https://github.com/some/app"},
+				}))
+
+				h.AssertContains(t, outBuf.String(), "Warning: Annotations are only persisted when publishing to a registry")
+				h.AssertEq(t, len(builtImage.annotations), 0)
+				h.AssertEq(t, builtImage.IsSaved(), false)
+				_, fetched := fakeImageFetcher.FetchCalls["example.io/some/app:latest"]
+				h.AssertFalse(t, fetched)
+			})
+		})
+
 		when("ProxyConfig option", func() {
 			when("ProxyConfig is nil", func() {
 				it.Before(func() {
@@ -2378,6 +2702,103 @@ api = "0.2"
 			})
 		})
 
+		when("security options", func() {
+			it("passes the user namespace, security options and capabilities through", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						UsernsMode:   "host",
+						SecurityOpts: []string{"no-new-privileges", "seccomp=unconfined"},
+						CapAdd:       []string{"NET_ADMIN"},
+						CapDrop:      []string{"ALL"},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.UsernsMode, "host")
+				h.AssertEq(t, fakeLifecycle.Opts.SecurityOpts, []string{"no-new-privileges", "seccomp=unconfined"})
+				h.AssertEq(t, fakeLifecycle.Opts.CapAdd, []string{"NET_ADMIN"})
+				h.AssertEq(t, fakeLifecycle.Opts.CapDrop, []string{"ALL"})
+			})
+
+			it("passes the contents of seccomp profiles rather than their paths", func() {
+				profilePath := filepath.Join(t.TempDir(), "seccomp.json")
+				h.AssertNil(t, os.WriteFile(profilePath, []byte("{\n  \"defaultAction\": \"SCMP_ACT_ALLOW\"\n}\n"), 0600))
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						SecurityOpts: []string{"seccomp=" + profilePath},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.SecurityOpts, []string{`seccomp={"defaultAction":"SCMP_ACT_ALLOW"}`})
+			})
+
+			it("errors for missing seccomp profiles", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						SecurityOpts: []string{"seccomp=some-missing-profile.json"},
+					},
+				}), "reading seccomp profile 'some-missing-profile.json'")
+			})
+		})
+
+		when("the project descriptor declares slices", func() {
+			it("passes them through to lifecycle, and doesn't use the creator", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Slices: []projectTypes.Slice{{Paths: []string{"static"}}},
+						},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Slices, []layers.Slice{{Paths: []string{"static"}}})
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+			})
+		})
+
+		when("read-only root filesystem option", func() {
+			it("passes it and the scratch directories through, and doesn't use the creator", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					ContainerConfig: ContainerConfig{
+						ReadOnlyRootfs: true,
+						ScratchDirs:    []string{"/home/cnb"},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.ReadOnlyRootfs, true)
+				h.AssertEq(t, fakeLifecycle.Opts.ScratchDirs, []string{"/home/cnb"})
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+			})
+
+			it("errors for scratch directories without a read-only root filesystem", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						ScratchDirs: []string{"/home/cnb"},
+					},
+				}), "scratch directories require a read-only root filesystem")
+			})
+
+			it("errors for Windows builders", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultWindowsBuilderName,
+					ContainerConfig: ContainerConfig{
+						ReadOnlyRootfs: true,
+					},
+				}), "read-only root filesystems are not supported for Windows builders")
+			})
+		})
+
 		when("Lifecycle option", func() {
 			when("Platform API", func() {
 				for _, supportedPlatformAPI := range []string{"0.3", "0.4"} {
@@ -2648,6 +3069,121 @@ api = "0.2"
 			})
 		})
 
+		when("deprecations", func() {
+			updateLifecycleAPIs := func(update func(apis *builder.LifecycleAPIs)) {
+				var md builder.Metadata
+				label, err := defaultBuilderImage.Label(builder.MetadataLabel)
+				h.AssertNil(t, err)
+				h.AssertNil(t, json.Unmarshal([]byte(label), &md))
+				update(&md.Lifecycle.APIs)
+				updated, err := json.Marshal(md)
+				h.AssertNil(t, err)
+				h.AssertNil(t, defaultBuilderImage.SetLabel(builder.MetadataLabel, string(updated)))
+			}
+			setPlatformAPIs := func(deprecated, supported builder.APISet) {
+				updateLifecycleAPIs(func(apis *builder.LifecycleAPIs) {
+					apis.Platform.Deprecated = deprecated
+					apis.Platform.Supported = supported
+				})
+			}
+
+			it("reports deprecated Platform APIs", func() {
+				setPlatformAPIs(builder.APISet{api.MustParse("0.13")}, builder.APISet{api.MustParse("0.1")})
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Platform API '0.13' has been deprecated (used with the lifecycle of builder '%s')", defaultBuilderName))
+				h.AssertNotContains(t, outBuf.String(), "Stack")
+			})
+
+			setBuildpackAPIs := func(deprecated, supported builder.APISet) {
+				updateLifecycleAPIs(func(apis *builder.LifecycleAPIs) {
+					apis.Buildpack.Deprecated = deprecated
+					apis.Buildpack.Supported = supported
+				})
+			}
+
+			it("reports the deprecated Buildpack APIs of the buildpacks of the builder", func() {
+				setBuildpackAPIs(builder.APISet{api.MustParse("0.8")}, builder.APISet{api.MustParse("0.9")})
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Buildpack API '0.8' has been deprecated (used by buildpack 'buildpack.1.id@buildpack.1.version' of builder '%s')", defaultBuilderName))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Buildpack API '0.8' has been deprecated (used by buildpack 'buildpack.2.id@buildpack.2.version' of builder '%s')", defaultBuilderName))
+			})
+
+			when("the buildpack API shim policy is allow", func() {
+				it("doesn't report the deprecated Buildpack APIs", func() {
+					setBuildpackAPIs(builder.APISet{api.MustParse("0.8")}, builder.APISet{api.MustParse("0.9")})
+					WithBuildpackAPIShimPolicy(BuildpackAPIShimsAllow)(subject)
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+					}))
+					h.AssertNotContains(t, outBuf.String(), "has been deprecated")
+				})
+			})
+
+			when("the buildpack API shim policy is deny", func() {
+				it("fails for buildpacks using a deprecated Buildpack API", func() {
+					setBuildpackAPIs(builder.APISet{api.MustParse("0.8")}, builder.APISet{api.MustParse("0.9")})
+					WithBuildpackAPIShimPolicy(BuildpackAPIShimsDeny)(subject)
+
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+					})
+					h.AssertError(t, err, fmt.Sprintf("buildpack 'buildpack.1.id@buildpack.1.version' of builder '%s' requires a shim for the deprecated Buildpack API 0.8, which the buildpack API shim policy 'deny' denies", defaultBuilderName))
+					h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+				})
+			})
+
+			it("warns about buildpacks using a Buildpack API the lifecycle no longer supports", func() {
+				setBuildpackAPIs(builder.APISet{api.MustParse("0.9")}, builder.APISet{api.MustParse("0.10")})
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Buildpack 'buildpack.1.id@buildpack.1.version' of builder '%s' requires a shim for Buildpack API 0.8, which lifecycle '%s' no longer supports", defaultBuilderName, builder.DefaultLifecycleVersion))
+			})
+
+			it("reports the stack of builders when the Platform API uses stacks", func() {
+				setPlatformAPIs(nil, builder.APISet{api.MustParse("0.11")})
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Stack '%s' has been deprecated, please use 'targets' instead (builder '%s' uses Platform API 0.11)", defaultBuilderStackID, defaultBuilderName))
+			})
+
+			it("doesn't report anything for builders without deprecations", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertNotContains(t, outBuf.String(), "has been deprecated")
+			})
+
+			it("fails when deprecations are strict", func() {
+				setPlatformAPIs(nil, builder.APISet{api.MustParse("0.11")})
+				logger.WantStrictDeprecations(true)
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				})
+				h.AssertError(t, err, fmt.Sprintf("Stack '%s' has been deprecated", defaultBuilderStackID))
+				h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+			})
+		})
+
 		when("validating mixins", func() {
 			when("stack image mixins disagree", func() {
 				it.Before(func() {
@@ -2675,6 +3211,15 @@ api = "0.2"
 
 						h.AssertError(t, err, "validating stack mixins: 'default/run' missing required mixin(s): mixinA")
 					})
+
+					it("builds with a compat mode of warn", func() {
+						h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+							Image:      "some/app",
+							Builder:    defaultBuilderName,
+							CompatMode: CompatModeWarn,
+						}))
+						h.AssertContains(t, outBuf.String(), "Run image 'default/run' provides mixin(s): mixinB")
+					})
 				})
 			})
 
@@ -2704,6 +3249,14 @@ api = "0.2"
 
 						h.AssertError(t, err, "validating stack mixins: buildpack 'buildpack.1.id@buildpack.1.version' requires missing mixin(s): build:mixinY, mixinX, run:mixinZ")
 					})
+
+					it("builds with a compat mode of ignore", func() {
+						h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+							Image:      "some/app",
+							Builder:    defaultBuilderName,
+							CompatMode: CompatModeIgnore,
+						}))
+					})
 				})
 			})
 		})
@@ -3012,6 +3565,155 @@ api = "0.2"
 			})
 		})
 
+		when("artifacts destination dir option", func() {
+			it("passthroughs to lifecycle and tells the buildpacks where to write the artifacts", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:                 defaultBuilderName,
+					Image:                   "example.com/some/repo:tag",
+					ArtifactsDestinationDir: "some-artifacts-dir",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.ArtifactsDestinationDir, "some-artifacts-dir")
+
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/CNB_ARTIFACTS_DIR")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/CNB_ARTIFACTS_DIR", "/layers/.artifacts")
+			})
+
+			it("doesn't override the directory the env sets", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:                 defaultBuilderName,
+					Image:                   "example.com/some/repo:tag",
+					Env:                     map[string]string{"CNB_ARTIFACTS_DIR": "/workspace/out"},
+					ArtifactsDestinationDir: "some-artifacts-dir",
+				}))
+
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/CNB_ARTIFACTS_DIR")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/CNB_ARTIFACTS_DIR", "/workspace/out")
+			})
+		})
+
+		when("builds run at once", func() {
+			it("builds each app with its own options", func() {
+				subject.locksDir = filepath.Join(tmpDir, "locks")
+				lifecycles := map[string]*build.LifecycleOptions{}
+
+				var builds []BuildOptions
+				for i := 0; i < 4; i++ {
+					builderName := fmt.Sprintf("example.com/concurrent/builder-%d:tag", i)
+					builderImage := newFakeBuilderImage(t, tmpDir, builderName, defaultBuilderStackID, defaultRunImageName, builder.DefaultLifecycleVersion, newLinuxImage)
+					fakeImageFetcher.LocalImages[builderName] = builderImage
+					defer builderImage.Cleanup()
+
+					imageName := fmt.Sprintf("example.com/concurrent/app-%d:tag", i)
+					lifecycles[imageName] = &build.LifecycleOptions{}
+					builds = append(builds, BuildOptions{
+						Builder: builderName,
+						Image:   imageName,
+						Env:     map[string]string{"APP": imageName},
+					})
+				}
+				subject.lifecycleExecutor = executorFunc(func(ctx context.Context, opts build.LifecycleOptions) error {
+					*lifecycles[opts.Image.Name()] = opts
+					return nil
+				})
+
+				errs := make(chan error, len(builds))
+				for _, opts := range builds {
+					go func(opts BuildOptions) {
+						errs <- subject.Build(context.TODO(), opts)
+					}(opts)
+				}
+				for range builds {
+					h.AssertNil(t, <-errs)
+				}
+
+				for _, opts := range builds {
+					h.AssertEq(t, lifecycles[opts.Image].BuilderImage, opts.Builder)
+
+					layerTar, err := fakeImageFetcher.LocalImages[opts.Builder].(*fakes.Image).FindLayerWithPath("/platform/env/APP")
+					h.AssertNil(t, err)
+					h.AssertTarFileContents(t, layerTar, "/platform/env/APP", opts.Image)
+				}
+			})
+		})
+
+		when("Logger option", func() {
+			it("logs the build with the logger rather than the logger of the client", func() {
+				var callBuf bytes.Buffer
+				callLogger := logging.NewLogWithWriters(&callBuf, &callBuf, logging.WithVerbose())
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Logger:  callLogger,
+				}))
+				h.AssertSameInstance(t, fakeLifecycle.Opts.Logger, callLogger)
+				h.AssertNotEq(t, callBuf.String(), "")
+				h.AssertEq(t, outBuf.String(), "")
+			})
+		})
+
+		when("workspace locks", func() {
+			var buildCacheLock string
+
+			it.Before(func() {
+				subject.locksDir = filepath.Join(tmpDir, "locks")
+				imageRef, err := name.ParseReference("example.com/some/repo:tag")
+				h.AssertNil(t, err)
+				volume := cache.NewVolumeCache(imageRef, cache.CacheInfo{}, "build", nil).Name()
+				buildCacheLock = filepath.Join(subject.locksDir, fmt.Sprintf("%x.lock", sha256.Sum256([]byte(volume))))
+			})
+
+			it("releases the locks once the build is done", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+
+				l, err := lock.TryAcquire(buildCacheLock)
+				h.AssertNil(t, err)
+				h.AssertNil(t, l.Release())
+			})
+
+			when("another build is using the cache", func() {
+				var held *lock.Lock
+
+				it.Before(func() {
+					var err error
+					held, err = lock.TryAcquire(buildCacheLock)
+					h.AssertNil(t, err)
+				})
+
+				it.After(func() {
+					h.AssertNil(t, held.Release())
+				})
+
+				it("waits for it to finish", func() {
+					ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+					defer cancel()
+
+					err := subject.Build(ctx, BuildOptions{
+						Builder: defaultBuilderName,
+						Image:   "example.com/some/repo:tag",
+					})
+					h.AssertError(t, err, "context deadline exceeded")
+					h.AssertContains(t, outBuf.String(), "Waiting for another build using 'pack-cache-some_repo_tag-")
+					h.AssertNil(t, fakeLifecycle.Opts.Image)
+				})
+
+				it("fails at once with FailIfLocked", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Builder:      defaultBuilderName,
+						Image:        "example.com/some/repo:tag",
+						FailIfLocked: true,
+					})
+					h.AssertError(t, err, "another build is using 'pack-cache-some_repo_tag-")
+					h.AssertNil(t, fakeLifecycle.Opts.Image)
+				})
+			})
+		})
+
 		when("there are extensions", func() {
 			withExtensionsLabel = true
 
@@ -3169,6 +3871,49 @@ api = "0.2"
 					h.AssertSliceContainsMatch(t, fakeLifecycle.Opts.Volumes, hostImagePath, hostPreviousImagePath, hostRunImagePath)
 				})
 			})
+
+			when("the layout directories are copied", func() {
+				it.Before(func() {
+					layoutConfig.BindStrategy = LayoutBindCopy
+				})
+
+				it("copies the image out instead of mounting volumes", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:        inputImageReference.Name(),
+						Builder:      defaultBuilderName,
+						LayoutConfig: layoutConfig,
+					}))
+
+					h.AssertEq(t, len(fakeLifecycle.Opts.Volumes), 0)
+					h.AssertEq(t, len(fakeLifecycle.Opts.LayoutCopies), 2)
+					h.AssertEq(t, fakeLifecycle.Opts.LayoutCopies[1].HostPath, hostImagePath)
+					h.AssertEq(t, fakeLifecycle.Opts.LayoutCopies[1].Output, true)
+				})
+			})
+
+			it("fetches the run-image from insecure registries without TLS", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:              inputImageReference.Name(),
+					Builder:            defaultBuilderName,
+					LayoutConfig:       layoutConfig,
+					InsecureRegistries: []string{"localhost:5000"},
+				}))
+
+				args := fakeImageFetcher.FetchCalls["default/run"]
+				h.AssertEq(t, args.InsecureRegistries, []string{"localhost:5000"})
+				h.AssertEq(t, fakeLifecycle.Opts.InsecureRegistries, []string{"localhost:5000"})
+			})
+
+			it("fails with an unknown bind strategy", func() {
+				layoutConfig.BindStrategy = "some-strategy"
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:        inputImageReference.Name(),
+					Builder:      defaultBuilderName,
+					LayoutConfig: layoutConfig,
+				})
+				h.AssertError(t, err, "invalid layout bind strategy 'some-strategy', must be one of auto, bind or copy")
+			})
 		})
 	})
 }
@@ -3250,7 +3995,7 @@ func newFakeBuilderImage(t *testing.T, tmpDir, builderName, defaultBuilderStackI
 		dist.ModuleLayers{
 			"buildpack.1.id": {
 				"buildpack.1.version": {
-					API: api.MustParse("0.3"),
+					API: api.MustParse("0.8"),
 					Stacks: []dist.Stack{
 						{
 							ID:     defaultBuilderStackID,
@@ -3261,7 +4006,7 @@ func newFakeBuilderImage(t *testing.T, tmpDir, builderName, defaultBuilderStackI
 			},
 			"buildpack.2.id": {
 				"buildpack.2.version": {
-					API: api.MustParse("0.3"),
+					API: api.MustParse("0.8"),
 					Stacks: []dist.Stack{
 						{
 							ID:     defaultBuilderStackID,
@@ -3343,3 +4088,21 @@ func setAPIs(t *testing.T, image *fakes.Image, buildpackAPIs []string, platformA
 	h.AssertNil(t, err)
 	h.AssertNil(t, image.SetLabel(builderMDLabelName, string(builderMDLabelBytes)))
 }
+
+// executorFunc is a lifecycle executor running the function, for tests of builds running at once
+// annotatedImage records the annotations set on the image, which the fake image ignores
+type annotatedImage struct {
+	*fakes.Image
+	annotations map[string]string
+}
+
+func (i *annotatedImage) SetAnnotations(annotations map[string]string) error {
+	i.annotations = annotations
+	return nil
+}
+
+type executorFunc func(ctx context.Context, opts build.LifecycleOptions) error
+
+func (f executorFunc) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	return f(ctx, opts)
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackAPIShimPolicy is what pack does when buildpacks of a build use a deprecated Buildpack API, which the
+// lifecycle supports through a compatibility shim
+type BuildpackAPIShimPolicy string
+
+const (
+	// BuildpackAPIShimsWarn reports buildpacks using a shim as deprecated usage, which is the default.
+	BuildpackAPIShimsWarn BuildpackAPIShimPolicy = "warn"
+
+	// BuildpackAPIShimsAllow uses shims without reporting them.
+	BuildpackAPIShimsAllow BuildpackAPIShimPolicy = "allow"
+
+	// BuildpackAPIShimsDeny fails builds with buildpacks using a shim.
+	BuildpackAPIShimsDeny BuildpackAPIShimPolicy = "deny"
+)
+
+// ParseBuildpackAPIShimPolicy parses a BuildpackAPIShimPolicy, defaulting to BuildpackAPIShimsWarn when empty
+func ParseBuildpackAPIShimPolicy(policy string) (BuildpackAPIShimPolicy, error) {
+	switch p := BuildpackAPIShimPolicy(policy); p {
+	case "":
+		return BuildpackAPIShimsWarn, nil
+	case BuildpackAPIShimsWarn, BuildpackAPIShimsAllow, BuildpackAPIShimsDeny:
+		return p, nil
+	default:
+		return "", errors.Errorf("invalid buildpack API shim policy %s, must be one of %s, %s or %s", style.Symbol(policy), BuildpackAPIShimsWarn, BuildpackAPIShimsAllow, BuildpackAPIShimsDeny)
+	}
+}
+
+// WithBuildpackAPIShimPolicy sets what the client does when buildpacks of a build use a Buildpack API shim
+func WithBuildpackAPIShimPolicy(policy BuildpackAPIShimPolicy) Option {
+	return func(c *Client) {
+		c.buildpackAPIShimPolicy = policy
+	}
+}
+
+// BuildpackAPIShimStatus is how the lifecycle of a builder supports the Buildpack API of a module
+type BuildpackAPIShimStatus string
+
+const (
+	// BuildpackAPINative modules use a Buildpack API the lifecycle supports.
+	BuildpackAPINative BuildpackAPIShimStatus = "native"
+
+	// BuildpackAPIShimmed modules use a deprecated Buildpack API the lifecycle supports through a shim.
+	BuildpackAPIShimmed BuildpackAPIShimStatus = "shimmed"
+
+	// BuildpackAPIUnsupported modules use a Buildpack API the lifecycle no longer supports, even through a shim.
+	BuildpackAPIUnsupported BuildpackAPIShimStatus = "unsupported"
+)
+
+// BuildpackAPIShim is the Buildpack API of a module of a builder, and how the lifecycle of the builder supports it
+type BuildpackAPIShim struct {
+	Module string                 `json:"module"`
+	Kind   string                 `json:"kind"`
+	API    string                 `json:"api"`
+	Status BuildpackAPIShimStatus `json:"status"`
+}
+
+// BuildpackAPIShimsReport is the Buildpack API shims of the lifecycle of a builder, and the ones its modules require
+type BuildpackAPIShimsReport struct {
+	LifecycleVersion string `json:"lifecycleVersion"`
+
+	// Buildpack APIs the lifecycle supports natively.
+	NativeAPIs []string `json:"nativeAPIs"`
+
+	// Deprecated Buildpack APIs the lifecycle supports through a shim.
+	ShimmedAPIs []string `json:"shimmedAPIs"`
+
+	Modules []BuildpackAPIShim `json:"modules"`
+}
+
+// InspectBuildpackAPIShimsOptions define the builder to inspect the Buildpack API shims of.
+type InspectBuildpackAPIShimsOptions struct {
+	BuilderName string
+
+	// Whether to read the builder from the daemon, rather than from the registry.
+	Daemon bool
+
+	// Strategy for updating the builder image before inspecting it.
+	PullPolicy image.PullPolicy
+}
+
+// InspectBuildpackAPIShims reports the Buildpack APIs the lifecycle of a builder supports natively and through shims,
+// and how the Buildpack API of each buildpack and extension of the builder is supported.
+func (c *Client) InspectBuildpackAPIShims(ctx context.Context, opts InspectBuildpackAPIShimsOptions) (*BuildpackAPIShimsReport, error) {
+	img, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.BuilderName))
+	}
+	lifecycleDescriptor := bldr.LifecycleDescriptor()
+
+	report := &BuildpackAPIShimsReport{
+		NativeAPIs:  lifecycleDescriptor.APIs.Buildpack.Supported.AsStrings(),
+		ShimmedAPIs: lifecycleDescriptor.APIs.Buildpack.Deprecated.AsStrings(),
+		Modules:     []BuildpackAPIShim{},
+	}
+	if lifecycleDescriptor.Info.Version != nil {
+		report.LifecycleVersion = lifecycleDescriptor.Info.Version.String()
+	}
+
+	for _, layers := range []struct {
+		kind  string
+		label string
+	}{
+		{kind: buildpack.KindBuildpack, label: dist.BuildpackLayersLabel},
+		{kind: buildpack.KindExtension, label: dist.ExtensionLayersLabel},
+	} {
+		var moduleLayers dist.ModuleLayers
+		if _, err := dist.GetLabel(img, layers.label, &moduleLayers); err != nil {
+			return nil, err
+		}
+		for id, versions := range moduleLayers {
+			for version, info := range versions {
+				if info.API == nil {
+					continue
+				}
+				report.Modules = append(report.Modules, BuildpackAPIShim{
+					Module: id + "@" + version,
+					Kind:   layers.kind,
+					API:    info.API.String(),
+					Status: buildpackAPIShimStatus(lifecycleDescriptor.APIs.Buildpack, info.API),
+				})
+			}
+		}
+	}
+	sort.Slice(report.Modules, func(i, j int) bool {
+		if report.Modules[i].Kind != report.Modules[j].Kind {
+			return report.Modules[i].Kind < report.Modules[j].Kind
+		}
+		return report.Modules[i].Module < report.Modules[j].Module
+	})
+	return report, nil
+}
+
+func buildpackAPIShimStatus(apis builder.APIVersions, moduleAPI *api.Version) BuildpackAPIShimStatus {
+	switch {
+	case apis.Supported.Contains(moduleAPI):
+		return BuildpackAPINative
+	case apis.Deprecated.Contains(moduleAPI):
+		return BuildpackAPIShimmed
+	default:
+		return BuildpackAPIUnsupported
+	}
+}
+
+// reportBuildpackAPIShims reports, according to the shim policy of the client, the buildpacks of a builder using a
+// deprecated Buildpack API the lifecycle shims, and warns about the ones using a Buildpack API it no longer supports
+func (c *Client) reportBuildpackAPIShims(bpLayers dist.ModuleLayers, apis builder.APIVersions, builderName string, lifecycleVersion string) error {
+	var buildpacks []string
+	for id, versions := range bpLayers {
+		for version := range versions {
+			buildpacks = append(buildpacks, id+"@"+version)
+		}
+	}
+	sort.Strings(buildpacks)
+
+	for _, bp := range buildpacks {
+		id, version, _ := strings.Cut(bp, "@")
+		bpAPI := bpLayers[id][version].API
+		if bpAPI == nil {
+			continue
+		}
+
+		switch buildpackAPIShimStatus(apis, bpAPI) {
+		case BuildpackAPIUnsupported:
+			if all := append(append(builder.APISet{}, apis.Supported...), apis.Deprecated...); len(all) > 0 && bpAPI.Compare(all.Earliest()) < 0 {
+				c.logger.Warnf("Buildpack %s of builder %s requires a shim for Buildpack API %s, which lifecycle %s no longer supports", style.Symbol(bp), style.Symbol(builderName), bpAPI, style.Symbol(lifecycleVersion))
+			}
+		case BuildpackAPIShimmed:
+			switch c.buildpackAPIShimPolicy {
+			case BuildpackAPIShimsAllow:
+				c.logger.Debugf("Buildpack %s uses the shim for Buildpack API %s", style.Symbol(bp), bpAPI)
+			case BuildpackAPIShimsDeny:
+				return errors.Errorf("buildpack %s of builder %s requires a shim for the deprecated Buildpack API %s, which the buildpack API shim policy %s denies", style.Symbol(bp), style.Symbol(builderName), bpAPI, style.Symbol(string(BuildpackAPIShimsDeny)))
+			default:
+				if err := logging.ReportDeprecation(c.logger, logging.Deprecation{
+					Kind:    logging.DeprecatedBuildpackAPI,
+					Name:    bpAPI.String(),
+					Details: fmt.Sprintf("used by buildpack %s of builder %s", style.Symbol(bp), style.Symbol(builderName)),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackAPIShims(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackAPIShims", testBuildpackAPIShims, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackAPIShims(t *testing.T, when spec.G, it spec.S) {
+	when("#ParseBuildpackAPIShimPolicy", func() {
+		it("defaults to warn", func() {
+			policy, err := ParseBuildpackAPIShimPolicy("")
+			h.AssertNil(t, err)
+			h.AssertEq(t, policy, BuildpackAPIShimsWarn)
+		})
+
+		it("parses policies", func() {
+			policy, err := ParseBuildpackAPIShimPolicy("deny")
+			h.AssertNil(t, err)
+			h.AssertEq(t, policy, BuildpackAPIShimsDeny)
+		})
+
+		it("errors for unknown policies", func() {
+			_, err := ParseBuildpackAPIShimPolicy("ignore")
+			h.AssertError(t, err, "invalid buildpack API shim policy 'ignore', must be one of warn, allow or deny")
+		})
+	})
+
+	when("#InspectBuildpackAPIShims", func() {
+		var (
+			subject          *Client
+			mockController   *gomock.Controller
+			mockImageFetcher *testmocks.MockImageFetcher
+			outBuf           bytes.Buffer
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+			subject = &Client{
+				logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+				imageFetcher: mockImageFetcher,
+			}
+
+			builderImage := newFakeBuilderImage(t, t.TempDir(), "some/builder", "some.stack.id", "some/run", builder.DefaultLifecycleVersion, newLinuxImage)
+			var md builder.Metadata
+			label, err := builderImage.Label(builder.MetadataLabel)
+			h.AssertNil(t, err)
+			h.AssertNil(t, json.Unmarshal([]byte(label), &md))
+			md.Lifecycle.APIs.Buildpack.Deprecated = builder.APISet{api.MustParse("0.8")}
+			md.Lifecycle.APIs.Buildpack.Supported = builder.APISet{api.MustParse("0.9"), api.MustParse("0.10")}
+			updated, err := json.Marshal(md)
+			h.AssertNil(t, err)
+			h.AssertNil(t, builderImage.SetLabel(builder.MetadataLabel, string(updated)))
+
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(builderImage, nil)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		it("reports the Buildpack APIs of the lifecycle and of the buildpacks", func() {
+			shims, err := subject.InspectBuildpackAPIShims(context.TODO(), InspectBuildpackAPIShimsOptions{
+				BuilderName: "some/builder",
+				Daemon:      true,
+				PullPolicy:  image.PullNever,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, shims.LifecycleVersion, builder.DefaultLifecycleVersion)
+			h.AssertEq(t, shims.NativeAPIs, []string{"0.9", "0.10"})
+			h.AssertEq(t, shims.ShimmedAPIs, []string{"0.8"})
+			h.AssertEq(t, shims.Modules, []BuildpackAPIShim{
+				{Module: "buildpack.1.id@buildpack.1.version", Kind: "buildpack", API: "0.8", Status: BuildpackAPIShimmed},
+				{Module: "buildpack.2.id@buildpack.2.version", Kind: "buildpack", API: "0.8", Status: BuildpackAPIShimmed},
+			})
+		})
+	})
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// LayerCacheStatus is what became of a buildpack layer of the previous image in a build.
+type LayerCacheStatus string
+
+const (
+	// LayerRestored means the layer is the same as in the previous image.
+	LayerRestored LayerCacheStatus = "restored"
+
+	// LayerRebuilt means the layer was created again, as it couldn't be reused.
+	LayerRebuilt LayerCacheStatus = "rebuilt"
+
+	// LayerInvalidated means the layer of the previous image is no longer in the image.
+	LayerInvalidated LayerCacheStatus = "invalidated"
+)
+
+// reportFile is the name of the build report written by the lifecycle, in BuildOptions.ReportDestinationDir.
+const reportFile = "report.toml"
+
+// CacheReport explains which buildpack layers of an image were reused from its previous build.
+type CacheReport struct {
+	Layers []LayerCacheReport `toml:"layers"`
+}
+
+// LayerCacheReport explains what became of a buildpack layer in a build.
+type LayerCacheReport struct {
+	// ID of the buildpack that contributed the layer.
+	Buildpack string `toml:"buildpack"`
+
+	// Name of the layer.
+	Layer string `toml:"layer"`
+
+	// What became of the layer.
+	Status LayerCacheStatus `toml:"status"`
+
+	// Why the layer was rebuilt or invalidated, if it was.
+	Reason string `toml:"reason,omitempty"`
+}
+
+// newCacheReport compares the buildpack layers of an image with those of its previous image, which is nil if there
+// was none. The layers are reported by buildpack and name.
+func newCacheReport(prev, current *files.LayersMetadataCompat, clearCache bool) CacheReport {
+	var report CacheReport
+	prevBuildpacks := map[string]buildpack.LayersMetadata{}
+	if prev != nil {
+		for _, bp := range prev.Buildpacks {
+			prevBuildpacks[bp.ID] = bp
+		}
+	}
+
+	for _, bp := range current.Buildpacks {
+		prevBP, hadBP := prevBuildpacks[bp.ID]
+		for _, layerName := range sortedLayerNames(bp.Layers) {
+			layer := bp.Layers[layerName]
+			prevLayer, hadLayer := prevBP.Layers[layerName]
+			entry := LayerCacheReport{Buildpack: bp.ID, Layer: layerName, Status: LayerRebuilt}
+			switch {
+			case hadLayer && prevLayer.SHA == layer.SHA:
+				entry.Status = LayerRestored
+			case prev == nil:
+				entry.Reason = "missing cache: no previous image"
+			case clearCache:
+				entry.Reason = "missing cache: the cache was cleared"
+			case !hadLayer:
+				entry.Reason = "missing cache: not in the previous image"
+			case prevBP.Version != bp.Version:
+				entry.Reason = fmt.Sprintf("buildpack changed from %s to %s", style.Symbol(prevBP.Version), style.Symbol(bp.Version))
+			case !reflect.DeepEqual(prevLayer.Data, layer.Data):
+				entry.Reason = "metadata mismatch: the buildpack changed the layer metadata"
+			case !prevLayer.Cache:
+				entry.Reason = "buildpack opt-out: the layer isn't cached, so its contents weren't restored"
+			default:
+				entry.Reason = "the buildpack rebuilt the layer with the same metadata"
+			}
+			report.Layers = append(report.Layers, entry)
+		}
+
+		if !hadBP {
+			continue
+		}
+		for _, layerName := range sortedLayerNames(prevBP.Layers) {
+			if _, ok := bp.Layers[layerName]; !ok {
+				report.Layers = append(report.Layers, LayerCacheReport{
+					Buildpack: bp.ID,
+					Layer:     layerName,
+					Status:    LayerInvalidated,
+					Reason:    "no longer contributed by the buildpack",
+				})
+			}
+		}
+		delete(prevBuildpacks, bp.ID)
+	}
+
+	var removed []string
+	for id := range prevBuildpacks {
+		removed = append(removed, id)
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		for _, layerName := range sortedLayerNames(prevBuildpacks[id].Layers) {
+			report.Layers = append(report.Layers, LayerCacheReport{
+				Buildpack: id,
+				Layer:     layerName,
+				Status:    LayerInvalidated,
+				Reason:    "buildpack no longer in the build",
+			})
+		}
+	}
+	return report
+}
+
+func sortedLayerNames(layers map[string]buildpack.LayerMetadata) []string {
+	var names []string
+	for layerName := range layers {
+		names = append(names, layerName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// appLayersMetadata returns the layers metadata of the app image in the daemon, or the registry when publishing, or
+// nil when there's no such image.
+func (c *Client) appLayersMetadata(ctx context.Context, imageName string, opts BuildOptions) (*files.LayersMetadataCompat, error) {
+	appImage, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{
+		Daemon:             !opts.Publish,
+		PullPolicy:         image.PullNever,
+		InsecureRegistries: opts.InsecureRegistries,
+	})
+	if errors.Is(err, image.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var md files.LayersMetadataCompat
+	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil || !ok {
+		return nil, err
+	}
+	return &md, nil
+}
+
+// reportCache logs the cache report of the build of the image, and adds it to the build report when one is written.
+func (c *Client) reportCache(ctx context.Context, imageName string, prev *files.LayersMetadataCompat, opts BuildOptions) error {
+	current, err := c.appLayersMetadata(ctx, imageName, opts)
+	if err != nil {
+		return errors.Wrapf(err, "reading layers metadata of %s", style.Symbol(imageName))
+	}
+	if current == nil {
+		return errors.Errorf("could not find label %s on image %s", style.Symbol(platform.LifecycleMetadataLabel), style.Symbol(imageName))
+	}
+
+	report := newCacheReport(prev, current, opts.ClearCache)
+	c.logger.Info("Cache report:")
+	tw := tabwriter.NewWriter(c.logger.Writer(), 0, 0, 3, ' ', 0)
+	for _, layer := range report.Layers {
+		fmt.Fprintf(tw, "  %s:%s\t%s\t%s\n", layer.Buildpack, layer.Layer, layer.Status, layer.Reason)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if opts.ReportDestinationDir == "" {
+		return nil
+	}
+	return addToBuildReport(filepath.Join(opts.ReportDestinationDir, reportFile), "cache", report)
+}
+
+// addToBuildReport adds a section to the build report at the path, which is created when the lifecycle didn't write it.
+func addToBuildReport(path, section string, data interface{}) error {
+	buildReport := map[string]interface{}{}
+	if _, err := toml.DecodeFile(path, &buildReport); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "reading build report %s", style.Symbol(path))
+	}
+	buildReport[section] = data
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "creating report directory %s", style.Symbol(filepath.Dir(path)))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "writing build report %s", style.Symbol(path))
+	}
+	defer f.Close()
+	return toml.NewEncoder(f).Encode(buildReport)
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheReport(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheReport", testCacheReport, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheReport(t *testing.T, when spec.G, it spec.S) {
+	// layer returns the metadata of a cached launch layer
+	layer := func(sha string, data interface{}) buildpack.LayerMetadata {
+		return buildpack.LayerMetadata{SHA: sha, LayerMetadataFile: buildpack.LayerMetadataFile{Data: data, Launch: true, Cache: true}}
+	}
+
+	prev := &files.LayersMetadataCompat{Buildpacks: []buildpack.LayersMetadata{
+		{ID: "some/bp", Version: "1.0.0", Layers: map[string]buildpack.LayerMetadata{
+			"deps":    layer("sha256:deps", map[string]interface{}{"lock": "abc"}),
+			"cache":   layer("sha256:cache", nil),
+			"old":     layer("sha256:old", nil),
+			"runtime": {SHA: "sha256:runtime", LayerMetadataFile: buildpack.LayerMetadataFile{Launch: true}},
+			"same":    layer("sha256:same", nil),
+		}},
+		{ID: "other/bp", Version: "1.0.0", Layers: map[string]buildpack.LayerMetadata{
+			"tools": layer("sha256:tools", nil),
+		}},
+		{ID: "removed/bp", Version: "1.0.0", Layers: map[string]buildpack.LayerMetadata{
+			"bin": layer("sha256:bin", nil),
+		}},
+	}}
+
+	current := &files.LayersMetadataCompat{Buildpacks: []buildpack.LayersMetadata{
+		{ID: "some/bp", Version: "1.0.0", Layers: map[string]buildpack.LayerMetadata{
+			"deps":    layer("sha256:new-deps", map[string]interface{}{"lock": "def"}),
+			"cache":   layer("sha256:new-cache", nil),
+			"new":     layer("sha256:new", nil),
+			"runtime": {SHA: "sha256:new-runtime", LayerMetadataFile: buildpack.LayerMetadataFile{Launch: true}},
+			"same":    layer("sha256:same", nil),
+		}},
+		{ID: "other/bp", Version: "2.0.0", Layers: map[string]buildpack.LayerMetadata{
+			"tools": layer("sha256:new-tools", nil),
+		}},
+	}}
+
+	when("#newCacheReport", func() {
+		it("explains what became of each layer", func() {
+			h.AssertEq(t, newCacheReport(prev, current, false).Layers, []LayerCacheReport{
+				{Buildpack: "some/bp", Layer: "cache", Status: LayerRebuilt, Reason: "the buildpack rebuilt the layer with the same metadata"},
+				{Buildpack: "some/bp", Layer: "deps", Status: LayerRebuilt, Reason: "metadata mismatch: the buildpack changed the layer metadata"},
+				{Buildpack: "some/bp", Layer: "new", Status: LayerRebuilt, Reason: "missing cache: not in the previous image"},
+				{Buildpack: "some/bp", Layer: "runtime", Status: LayerRebuilt, Reason: "buildpack opt-out: the layer isn't cached, so its contents weren't restored"},
+				{Buildpack: "some/bp", Layer: "same", Status: LayerRestored},
+				{Buildpack: "some/bp", Layer: "old", Status: LayerInvalidated, Reason: "no longer contributed by the buildpack"},
+				{Buildpack: "other/bp", Layer: "tools", Status: LayerRebuilt, Reason: "buildpack changed from '1.0.0' to '2.0.0'"},
+				{Buildpack: "removed/bp", Layer: "bin", Status: LayerInvalidated, Reason: "buildpack no longer in the build"},
+			})
+		})
+
+		it("explains a missing previous image", func() {
+			layers := newCacheReport(nil, current, false).Layers
+			h.AssertEq(t, layers[0], LayerCacheReport{Buildpack: "some/bp", Layer: "cache", Status: LayerRebuilt, Reason: "missing cache: no previous image"})
+		})
+
+		it("explains a cleared cache", func() {
+			layers := newCacheReport(prev, current, true).Layers
+			h.AssertEq(t, layers[0], LayerCacheReport{Buildpack: "some/bp", Layer: "cache", Status: LayerRebuilt, Reason: "missing cache: the cache was cleared"})
+		})
+	})
+
+	when("#reportCache", func() {
+		var (
+			subject   *Client
+			outBuf    bytes.Buffer
+			reportDir string
+		)
+
+		it.Before(func() {
+			var err error
+			reportDir, err = os.MkdirTemp("", "cache-report")
+			h.AssertNil(t, err)
+
+			appImage := fakes.NewImage("some/app", "", nil)
+			h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata",
+				`{"buildpacks":[{"key":"some/bp","version":"1.0.0","layers":{"same":{"sha":"sha256:same","launch":true,"cache":true}}}]}`))
+			fakeImageFetcher := ifakes.NewFakeImageFetcher()
+			fakeImageFetcher.LocalImages["some/app"] = appImage
+
+			subject = &Client{
+				logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+				imageFetcher: fakeImageFetcher,
+			}
+		})
+
+		it.After(func() {
+			os.RemoveAll(reportDir)
+		})
+
+		it("logs the report and adds it to the build report", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(reportDir, "report.toml"), []byte("[image]\ntags = [\"some/app\"]\n"), 0600))
+
+			h.AssertNil(t, subject.reportCache(context.TODO(), "some/app", prev, BuildOptions{ReportDestinationDir: reportDir}))
+			h.AssertContains(t, outBuf.String(), "Cache report:")
+			h.AssertContains(t, outBuf.String(), "some/bp:same      restored")
+
+			var buildReport struct {
+				Image map[string]interface{} `toml:"image"`
+				Cache CacheReport            `toml:"cache"`
+			}
+			_, err := toml.DecodeFile(filepath.Join(reportDir, "report.toml"), &buildReport)
+			h.AssertNil(t, err)
+			h.AssertEq(t, buildReport.Image["tags"], []interface{}{"some/app"})
+			h.AssertEq(t, buildReport.Cache.Layers[0], LayerCacheReport{Buildpack: "some/bp", Layer: "same", Status: LayerRestored})
+		})
+
+		it("fails when the image wasn't built with buildpacks", func() {
+			err := subject.reportCache(context.TODO(), "some/other-app", prev, BuildOptions{})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.lifecycle.metadata' on image 'some/other-app'")
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// CheckUpdatesOptions define the app image to check for run image updates.
+type CheckUpdatesOptions struct {
+	// Name of the app image to check.
+	ImageName string
+
+	// Read the app image from the daemon instead of the registry.
+	Daemon bool
+
+	// Run image to compare the app image to. Defaults to the run image, or the best mirror of it, the app image was
+	// built on.
+	RunImage string
+
+	// A mapping from run image names to mirrors, used along with the mirrors in the app image metadata.
+	AdditionalMirrors map[string][]string
+}
+
+// RunImageUpdate compares the run image of an app image to the latest run image available in a registry.
+type RunImageUpdate struct {
+	// Name of the app image.
+	Image string
+
+	// Name of the run image the app image was compared to.
+	RunImage string
+
+	// Reference of the run image the app image was built or last rebased on.
+	CurrentReference string
+
+	// Top layer of the run image the app image was built or last rebased on.
+	CurrentTopLayer string
+
+	// Digest reference of the latest run image.
+	LatestReference string
+
+	// Top layer of the latest run image.
+	LatestTopLayer string
+}
+
+// RebaseRecommended returns true when the latest run image differs from the one the app image is based on.
+func (u RunImageUpdate) RebaseRecommended() bool {
+	return u.CurrentTopLayer != u.LatestTopLayer
+}
+
+// CheckUpdates compares the run image of an app image to the latest run image available in a registry, from the
+// run image metadata of the app image and the given mirrors, to tell whether the app image should be rebased.
+func (c *Client) CheckUpdates(ctx context.Context, opts CheckUpdatesOptions) (*RunImageUpdate, error) {
+	imageRef, err := c.parseTagReference(opts.ImageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image name '%s'", opts.ImageName)
+	}
+
+	appImage, err := c.imageFetcher.Fetch(ctx, opts.ImageName, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, err
+	}
+
+	var md files.LayersMetadataCompat
+	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+	}
+
+	appOS, err := appImage.OS()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting app OS")
+	}
+	appArch, err := appImage.Architecture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting app architecture")
+	}
+
+	// the latest run image is always looked up in a registry, as the daemon only has the one that was last pulled
+	fetchOptions := image.FetchOptions{Daemon: false, Target: &dist.Target{OS: appOS, Arch: appArch}}
+	runImageName := c.resolveRunImage(
+		opts.RunImage,
+		imageRef.Context().RegistryStr(),
+		"",
+		runImageMetadata(md),
+		opts.AdditionalMirrors,
+		true,
+		fetchOptions,
+	)
+	if runImageName == "" {
+		return nil, errors.New("run image must be specified")
+	}
+
+	runImage, err := c.imageFetcher.Fetch(ctx, runImageName, fetchOptions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching run image %s", style.Symbol(runImageName))
+	}
+	topLayer, err := runImage.TopLayer()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting top layer of run image %s", style.Symbol(runImageName))
+	}
+	identifier, err := runImage.Identifier()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunImageUpdate{
+		Image:            opts.ImageName,
+		RunImage:         runImageName,
+		CurrentReference: md.RunImage.Reference,
+		CurrentTopLayer:  md.RunImage.TopLayer,
+		LatestReference:  identifier.String(),
+		LatestTopLayer:   topLayer,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCheckUpdates(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CheckUpdates", testCheckUpdates, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCheckUpdates(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		fakeAppImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+
+		fakeAppImage = fakes.NewImage("example.com/some/app", "", &fakeIdentifier{name: "app-image"})
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"topLayer":"old-top-layer-sha","reference":"some/run@sha256:old","image":"some/run","mirrors":["example.com/some/run"]}}`))
+		fakeImageFetcher.RemoteImages["example.com/some/app"] = fakeAppImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	when("#CheckUpdates", func() {
+		it("recommends a rebase when the run image has changed", func() {
+			fakeImageFetcher.RemoteImages["some/run"] = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "some/run@sha256:new"})
+
+			update, err := subject.CheckUpdates(context.TODO(), CheckUpdatesOptions{ImageName: "example.com/some/app", RunImage: "some/run"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, update.RunImage, "some/run")
+			h.AssertEq(t, update.CurrentReference, "some/run@sha256:old")
+			h.AssertEq(t, update.LatestReference, "some/run@sha256:new")
+			h.AssertEq(t, update.RebaseRecommended(), true)
+		})
+
+		it("compares to the run image mirror in the registry of the app image", func() {
+			fakeImageFetcher.RemoteImages["some/run"] = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "some/run@sha256:new"})
+			fakeImageFetcher.RemoteImages["example.com/some/run"] = fakes.NewImage("example.com/some/run", "old-top-layer-sha", &fakeIdentifier{name: "example.com/some/run@sha256:old"})
+
+			update, err := subject.CheckUpdates(context.TODO(), CheckUpdatesOptions{ImageName: "example.com/some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, update.RunImage, "example.com/some/run")
+			h.AssertEq(t, update.RebaseRecommended(), false)
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["example.com/some/run"].Daemon, false)
+		})
+
+		it("reads the app image from the daemon", func() {
+			delete(fakeImageFetcher.RemoteImages, "example.com/some/app")
+			fakeImageFetcher.LocalImages["example.com/some/app"] = fakeAppImage
+			fakeImageFetcher.RemoteImages["example.com/some/run"] = fakes.NewImage("example.com/some/run", "old-top-layer-sha", &fakeIdentifier{name: "example.com/some/run@sha256:old"})
+
+			_, err := subject.CheckUpdates(context.TODO(), CheckUpdatesOptions{ImageName: "example.com/some/app", Daemon: true})
+			h.AssertNil(t, err)
+		})
+
+		it("fails when the app image has no lifecycle metadata", func() {
+			fakeImageFetcher.RemoteImages["some/other-app"] = fakes.NewImage("some/other-app", "", nil)
+
+			_, err := subject.CheckUpdates(context.TODO(), CheckUpdatesOptions{ImageName: "some/other-app"})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.lifecycle.metadata' on image")
+		})
+	})
+}
@@ -18,13 +18,17 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/imgutil/local"
 	"github.com/buildpacks/imgutil/remote"
+	dockerimage "github.com/docker/docker/api/types/image"
 	dockerClient "github.com/docker/docker/client"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/buildpacks/pack"
 	"github.com/buildpacks/pack/internal/build"
@@ -36,6 +40,7 @@ import (
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/index"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/metrics"
 )
 
 const (
@@ -114,6 +119,11 @@ type BuildpackDownloader interface {
 // Client is an orchestration object, it contains all parameters needed to
 // build an app image using Cloud Native Buildpacks.
 // All settings on this object should be changed through ClientOption functions.
+//
+// A Client is safe for concurrent use once created: its settings aren't changed by its operations, so that a service
+// may run builds and rebases at once with a single Client. Builds of the same app take turns using its caches, see
+// WithLocksDir. The components supplied through ClientOption functions, such as the logger, must be safe for
+// concurrent use as well.
 type Client struct {
 	logger logging.Logger
 	docker DockerClient
@@ -125,10 +135,24 @@ type Client struct {
 	downloader          BlobDownloader
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
-
-	experimental    bool
-	registryMirrors map[string]string
-	version         string
+	registryResolver    buildpack.RegistryResolver
+
+	experimental           bool
+	experimentalFeatures   map[string]bool
+	registryMirrors        map[string]string
+	cacheRegistry          string
+	pullTimesFile          string
+	trustPolicy            *image.TrustPolicy
+	buildpackAPIShimPolicy BuildpackAPIShimPolicy
+	version                string
+
+	metricsCollector metrics.Collector
+	tracerProvider   trace.TracerProvider
+
+	intermediateImagesFile string
+	credentialsDir         string
+	credentialsCipher      image.CredentialsCipher
+	locksDir               string
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -203,6 +227,18 @@ func WithExperimental(experimental bool) Option {
 	}
 }
 
+// WithExperimentalFeatures enables the experimental features of the names, such as 'windows' or 'wasi', on their own.
+func WithExperimentalFeatures(features ...string) Option {
+	return func(c *Client) {
+		if c.experimentalFeatures == nil {
+			c.experimentalFeatures = map[string]bool{}
+		}
+		for _, feature := range features {
+			c.experimentalFeatures[feature] = true
+		}
+	}
+}
+
 // WithRegistryMirrors sets mirrors to pull images from.
 func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	return func(c *Client) {
@@ -210,6 +246,29 @@ func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	}
 }
 
+// WithLocalCacheRegistry pulls images through a local pull-through cache registry, storing its contents in dir.
+func WithLocalCacheRegistry(dir string) Option {
+	return func(c *Client) {
+		c.cacheRegistry = dir
+	}
+}
+
+// WithPullTimesFile records the time images are pulled into the daemon at in the given state file, for pull policies
+// that pull images at an interval.
+func WithPullTimesFile(path string) Option {
+	return func(c *Client) {
+		c.pullTimesFile = path
+	}
+}
+
+// WithLocksDir sets the directory of the locks builds take turns using their caches with, for builds running at
+// once not to corrupt them. Defaults to the locks directory of pack home.
+func WithLocksDir(dir string) Option {
+	return func(c *Client) {
+		c.locksDir = dir
+	}
+}
+
 // WithKeychain sets keychain of credentials to image registries
 func WithKeychain(keychain authn.Keychain) Option {
 	return func(c *Client) {
@@ -234,6 +293,14 @@ func NewClient(opts ...Option) (*Client, error) {
 		client.logger = logging.NewSimpleLogger(os.Stderr)
 	}
 
+	if client.credentialsDir != "" {
+		credentials, err := image.NewEncryptedDockerConfigKeychain(client.credentialsDir, client.credentialsCipher)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading stored credentials")
+		}
+		client.keychain = image.NewMultiKeychain(credentials, client.keychain)
+	}
+
 	if client.docker == nil {
 		var err error
 		client.docker, err = dockerClient.NewClientWithOpts(
@@ -253,14 +320,54 @@ func NewClient(opts ...Option) (*Client, error) {
 		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
 	}
 
+	if client.locksDir == "" {
+		packHome, err := iconfig.PackHome()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pack home")
+		}
+		client.locksDir = filepath.Join(packHome, "locks")
+	}
+
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		fetcherOpts := []image.FetcherOption{image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain)}
+		if client.cacheRegistry != "" {
+			fetcherOpts = append(fetcherOpts, image.WithCacheRegistry(image.NewCacheRegistry(client.logger, client.keychain, client.cacheRegistry)))
+		}
+		if client.pullTimesFile != "" {
+			fetcherOpts = append(fetcherOpts, image.WithPullTimesFile(client.pullTimesFile))
+		}
+		client.imageFetcher = image.NewFetcher(client.logger, client.docker, fetcherOpts...)
+	}
+
+	if client.trustPolicy != nil && len(client.trustPolicy.Registries) > 0 {
+		if err := client.trustPolicy.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid trust policy")
+		}
+		client.imageFetcher = &verifyingImageFetcher{
+			ImageFetcher: client.imageFetcher,
+			verifier:     image.NewSignatureVerifier(*client.trustPolicy, client.keychain),
+			logger:       client.logger,
+		}
+	}
+
+	if client.tracerProvider != nil {
+		client.imageFetcher = &tracingImageFetcher{ImageFetcher: client.imageFetcher, tracer: client.tracer()}
+	}
+
+	if client.metricsCollector != nil {
+		client.imageFetcher = &metricsImageFetcher{ImageFetcher: client.imageFetcher, collector: client.metricsCollector}
 	}
 
 	if client.imageFactory == nil {
+		packHome, err := iconfig.PackHome()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pack home")
+		}
 		client.imageFactory = &imageFactory{
 			dockerClient: client.docker,
 			keychain:     client.keychain,
+			uploader:     image.NewBlobUploader(client.logger, client.keychain, filepath.Join(packHome, "upload-checkpoints")),
+			logger:       client.logger,
 		}
 	}
 
@@ -276,14 +383,16 @@ func NewClient(opts ...Option) (*Client, error) {
 		client.indexFactory = index.NewIndexFactory(client.keychain, indexRootStoragePath)
 	}
 
+	client.registryResolver = &registryResolver{
+		logger: client.logger,
+	}
+
 	if client.buildpackDownloader == nil {
 		client.buildpackDownloader = buildpack.NewDownloader(
 			client.logger,
 			client.imageFetcher,
 			client.downloader,
-			&registryResolver{
-				logger: client.logger,
-			},
+			client.registryResolver,
 		)
 	}
 
@@ -292,11 +401,22 @@ func NewClient(opts ...Option) (*Client, error) {
 	return client, nil
 }
 
+// experimentalEnabled returns true when the experimental feature is enabled, on its own or along with every other one
+func (c *Client) experimentalEnabled(feature string) bool {
+	return c.experimental || c.experimentalFeatures[feature]
+}
+
 type registryResolver struct {
 	logger logging.Logger
+
+	// mu serializes lookups, as the registry cache is a git repository updated in place
+	mu sync.Mutex
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	cache, err := getRegistry(r.logger, registryName)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
@@ -313,6 +433,8 @@ func (r *registryResolver) Resolve(registryName, bpName string) (string, error)
 type imageFactory struct {
 	dockerClient local.DockerClient
 	keychain     authn.Keychain
+	uploader     *image.BlobUploader
+	logger       logging.Logger
 }
 
 func (f *imageFactory) NewImage(repoName string, daemon bool, target dist.Target) (imgutil.Image, error) {
@@ -325,8 +447,86 @@ func (f *imageFactory) NewImage(repoName string, daemon bool, target dist.Target
 	}
 
 	if daemon {
-		return local.NewImage(repoName, f.dockerClient, local.WithDefaultPlatform(platform))
+		img, err := local.NewImage(repoName, f.dockerClient, local.WithDefaultPlatform(platform))
+		if err != nil {
+			return nil, err
+		}
+		return &deltaImage{Image: img, docker: f.dockerClient, logger: f.logger}, nil
+	}
+
+	img, err := remote.NewImage(repoName, f.keychain, remote.WithDefaultPlatform(platform))
+	if err != nil || f.uploader == nil {
+		return img, err
+	}
+	return &resumableImage{Image: img, uploader: f.uploader, logger: f.logger}, nil
+}
+
+// deltaImage skips sending the layers the daemon already has when saving the image, see image.SaveDelta
+type deltaImage struct {
+	imgutil.Image
+	docker local.DockerClient
+	logger logging.Logger
+	id     string
+}
+
+func (i *deltaImage) Save(additionalNames ...string) error {
+	return i.SaveAs(i.Name(), additionalNames...)
+}
+
+func (i *deltaImage) SaveAs(name string, additionalNames ...string) error {
+	if setter, ok := i.Image.(interface{ SetCreatedAtAndHistory() error }); ok {
+		if err := setter.SetCreatedAtAndHistory(); err != nil {
+			return err
+		}
+
+		id, omitted, err := image.SaveDelta(context.Background(), i.docker, i.UnderlyingImage(), name, additionalNames...)
+		if err == nil {
+			i.logger.Debugf("Saved %s without sending %d layers already in the daemon", style.Symbol(name), omitted)
+			i.id = id
+			return nil
+		}
+		if !errors.Is(err, image.ErrNoDelta) {
+			i.logger.Debugf("Saving only new layers of %s failed, saving all of them: %s", style.Symbol(name), err)
+		}
+	}
+
+	i.id = ""
+	return i.Image.SaveAs(name, additionalNames...)
+}
+
+func (i *deltaImage) Identifier() (imgutil.Identifier, error) {
+	if i.id != "" {
+		return local.IDIdentifier{ImageID: strings.TrimPrefix(i.id, "sha256:")}, nil
 	}
+	return i.Image.Identifier()
+}
+
+func (i *deltaImage) Delete() error {
+	if i.id != "" {
+		_, err := i.docker.ImageRemove(context.Background(), i.id, dockerimage.RemoveOptions{Force: true, PruneChildren: true})
+		return err
+	}
+	return i.Image.Delete()
+}
+
+// resumableImage uploads large layers in resumable chunks before saving the image, so that saving it only has to
+// push the remaining small layers and the manifest
+type resumableImage struct {
+	imgutil.Image
+	uploader *image.BlobUploader
+	logger   logging.Logger
+}
+
+func (i *resumableImage) Save(additionalNames ...string) error {
+	return i.SaveAs(i.Name(), additionalNames...)
+}
 
-	return remote.NewImage(repoName, f.keychain, remote.WithDefaultPlatform(platform))
+func (i *resumableImage) SaveAs(name string, additionalNames ...string) error {
+	for _, n := range append([]string{name}, additionalNames...) {
+		if err := i.uploader.UploadLayers(context.Background(), n, i.UnderlyingImage()); err != nil {
+			// the layers are pushed again when saving, without resuming
+			i.logger.Warnf("Chunked upload to %s failed: %s", style.Symbol(n), err)
+		}
+	}
+	return i.Image.SaveAs(name, additionalNames...)
 }
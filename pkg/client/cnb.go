@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// SplitCNBOptions define the .cnb file to split, and where to write the .cnb files of its buildpacks to.
+type SplitCNBOptions struct {
+	// Path, or URL, of the .cnb file to split.
+	Path string
+
+	// Directory to write a .cnb file for each of the buildpacks to.
+	OutputDir string
+}
+
+// MergeCNBOptions define the .cnb files to merge, and where to write the merged .cnb file to.
+type MergeCNBOptions struct {
+	// Paths, or URLs, of the .cnb files to merge. The buildpack of the first one is the main buildpack of the merged
+	// .cnb file, the buildpacks of the others being its dependencies.
+	Paths []string
+
+	// Path to write the merged .cnb file to.
+	Output string
+}
+
+// SplitCNB writes each of the buildpacks of a .cnb file to a .cnb file of its own, along with the buildpacks it
+// depends on, such as to extract the buildpacks nested in a composite buildpack. The paths of the written files are
+// returned, the one of the main buildpack first.
+func (c *Client) SplitCNB(ctx context.Context, opts SplitCNBOptions) ([]string, error) {
+	mainBP, depBPs, target, err := c.readCNB(ctx, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "creating output directory %s", style.Symbol(opts.OutputDir))
+	}
+
+	modules := map[string]buildpack.BuildModule{}
+	for _, bp := range append([]buildpack.BuildModule{mainBP}, depBPs...) {
+		modules[bp.Descriptor().Info().FullName()] = bp
+	}
+
+	var written []string
+	for _, bp := range append([]buildpack.BuildModule{mainBP}, depBPs...) {
+		packageBuilder := buildpack.NewBuilder(c.imageFactory)
+		packageBuilder.SetBuildpack(bp)
+		dependencies, err := moduleDependencies(bp, modules)
+		if err != nil {
+			return nil, err
+		}
+		for _, dependency := range dependencies {
+			packageBuilder.AddDependency(dependency)
+		}
+
+		info := bp.Descriptor().Info()
+		path := filepath.Join(opts.OutputDir, fmt.Sprintf("%s_%s.cnb", strings.ReplaceAll(info.ID, "/", "_"), info.Version))
+		if err := packageBuilder.SaveAsFile(path, target, nil); err != nil {
+			return nil, errors.Wrapf(err, "writing buildpack %s", style.Symbol(info.FullName()))
+		}
+		c.logger.Debugf("Wrote buildpack %s to %s", style.Symbol(info.FullName()), style.Symbol(path))
+
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// MergeCNB writes the buildpacks of several .cnb files to a single .cnb file, such as to put back together buildpacks
+// split by SplitCNB. Buildpacks found in more than one of the files are only written once.
+func (c *Client) MergeCNB(ctx context.Context, opts MergeCNBOptions) error {
+	if len(opts.Paths) == 0 {
+		return errors.New("at least one .cnb file must be provided")
+	}
+
+	packageBuilder := buildpack.NewBuilder(c.imageFactory)
+	added := map[string]bool{}
+	var target dist.Target
+	for i, path := range opts.Paths {
+		mainBP, depBPs, cnbTarget, err := c.readCNB(ctx, path)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			packageBuilder.SetBuildpack(mainBP)
+			target = cnbTarget
+			added[mainBP.Descriptor().Info().FullName()] = true
+		} else {
+			if cnbTarget.OS != target.OS || cnbTarget.Arch != target.Arch {
+				return errors.Errorf("%s is for %s, while %s is for %s", style.Symbol(path), style.Symbol(cnbTarget.ValuesAsPlatform()), style.Symbol(opts.Paths[0]), style.Symbol(target.ValuesAsPlatform()))
+			}
+			depBPs = append([]buildpack.BuildModule{mainBP}, depBPs...)
+		}
+
+		for _, bp := range depBPs {
+			if added[bp.Descriptor().Info().FullName()] {
+				continue
+			}
+			packageBuilder.AddDependency(bp)
+			added[bp.Descriptor().Info().FullName()] = true
+		}
+	}
+
+	if err := packageBuilder.SaveAsFile(opts.Output, target, nil); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(opts.Output))
+	}
+	return nil
+}
+
+func (c *Client) readCNB(ctx context.Context, path string) (mainBP buildpack.BuildModule, depBPs []buildpack.BuildModule, target dist.Target, err error) {
+	cnbBlob, err := c.downloader.Download(ctx, path)
+	if err != nil {
+		return nil, nil, dist.Target{}, errors.Wrapf(err, "downloading %s", style.Symbol(path))
+	}
+
+	isOCILayout, err := buildpack.IsOCILayoutBlob(cnbBlob)
+	if err != nil {
+		return nil, nil, dist.Target{}, errors.Wrapf(err, "reading %s", style.Symbol(path))
+	}
+	if !isOCILayout {
+		return nil, nil, dist.Target{}, errors.Errorf("%s is not a .cnb file", style.Symbol(path))
+	}
+
+	if target, err = buildpack.TargetFromOCILayoutBlob(cnbBlob); err != nil {
+		return nil, nil, dist.Target{}, errors.Wrapf(err, "reading %s", style.Symbol(path))
+	}
+	if mainBP, depBPs, err = buildpack.BuildpacksFromOCILayoutBlob(cnbBlob); err != nil {
+		return nil, nil, dist.Target{}, errors.Wrapf(err, "extracting buildpacks from %s", style.Symbol(path))
+	}
+	return mainBP, depBPs, target, nil
+}
+
+// moduleDependencies returns the buildpacks the order of the buildpack references, and the ones they reference in turn
+func moduleDependencies(bp buildpack.BuildModule, modules map[string]buildpack.BuildModule) ([]buildpack.BuildModule, error) {
+	var dependencies []buildpack.BuildModule
+	seen := map[string]bool{}
+
+	var visit func(bp buildpack.BuildModule) error
+	visit = func(bp buildpack.BuildModule) error {
+		for _, orderEntry := range bp.Descriptor().Order() {
+			for _, groupEntry := range orderEntry.Group {
+				fullName := groupEntry.FullName()
+				if seen[fullName] {
+					continue
+				}
+				seen[fullName] = true
+
+				dependency, ok := modules[fullName]
+				if !ok {
+					return errors.Errorf("buildpack %s references buildpack %s which is not present", style.Symbol(bp.Descriptor().Info().FullName()), style.Symbol(fullName))
+				}
+				dependencies = append(dependencies, dependency)
+				if err := visit(dependency); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	return dependencies, visit(bp)
+}
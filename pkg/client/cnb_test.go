@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCNB(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CNB", testCNB, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCNB(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *client.Client
+		mockController *gomock.Controller
+		mockDownloader *testmocks.MockBlobDownloader
+		out            bytes.Buffer
+		tmpDir         string
+		cnbPath        = filepath.Join("..", "buildpack", "testdata", "hello-universe.cnb")
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDownloader = testmocks.NewMockBlobDownloader(mockController)
+		mockDownloader.EXPECT().Download(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, path string) (blob.Blob, error) {
+			return blob.NewBlob(path), nil
+		}).AnyTimes()
+
+		subject = &client.Client{}
+		client.WithLogger(logging.NewLogWithWriters(&out, &out))(subject)
+		client.WithDownloader(mockDownloader)(subject)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "cnb-test")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	readCNB := func(path string) (buildpack.BuildModule, []buildpack.BuildModule) {
+		mainBP, depBPs, err := buildpack.BuildpacksFromOCILayoutBlob(blob.NewBlob(path))
+		h.AssertNil(t, err)
+		return mainBP, depBPs
+	}
+
+	when("#SplitCNB", func() {
+		it("writes a .cnb file for each buildpack", func() {
+			written, err := subject.SplitCNB(context.TODO(), client.SplitCNBOptions{Path: cnbPath, OutputDir: tmpDir})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(written), 3)
+
+			mainBP, depBPs := readCNB(written[0])
+			h.AssertEq(t, mainBP.Descriptor().Info().FullName(), "io.buildpacks.samples.hello-universe@0.0.1")
+			h.AssertEq(t, len(depBPs), 2)
+
+			for _, path := range written[1:] {
+				_, depBPs := readCNB(path)
+				h.AssertEq(t, len(depBPs), 0)
+			}
+		})
+
+		when("the file isn't a .cnb file", func() {
+			it("errors", func() {
+				_, err := subject.SplitCNB(context.TODO(), client.SplitCNBOptions{
+					Path:      filepath.Join("testdata", "buildpack"),
+					OutputDir: tmpDir,
+				})
+				h.AssertError(t, err, "is not a .cnb file")
+			})
+		})
+	})
+
+	when("#MergeCNB", func() {
+		it("merges split .cnb files back together", func() {
+			written, err := subject.SplitCNB(context.TODO(), client.SplitCNBOptions{Path: cnbPath, OutputDir: filepath.Join(tmpDir, "split")})
+			h.AssertNil(t, err)
+
+			output := filepath.Join(tmpDir, "merged.cnb")
+			h.AssertNil(t, subject.MergeCNB(context.TODO(), client.MergeCNBOptions{Paths: written, Output: output}))
+
+			mergedBP, depBPs := readCNB(output)
+			h.AssertEq(t, mergedBP.Descriptor().Info().FullName(), "io.buildpacks.samples.hello-universe@0.0.1")
+			h.AssertEq(t, len(depBPs), 2)
+		})
+
+		when("buildpacks aren't used by the main buildpack", func() {
+			it("errors", func() {
+				written, err := subject.SplitCNB(context.TODO(), client.SplitCNBOptions{Path: cnbPath, OutputDir: tmpDir})
+				h.AssertNil(t, err)
+
+				err = subject.MergeCNB(context.TODO(), client.MergeCNBOptions{
+					Paths:  written[1:],
+					Output: filepath.Join(tmpDir, "merged.cnb"),
+				})
+				h.AssertError(t, err, "is not used by buildpack")
+			})
+		})
+	})
+}
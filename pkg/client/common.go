@@ -98,7 +98,19 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 
 	for _, reg := range config.GetRegistries(cfg) {
 		if reg.Name == registryName {
-			return registry.NewRegistryCache(logger, home, reg.URL)
+			cache, err := registry.NewRegistryCache(logger, home, reg.URL)
+			if err != nil {
+				return registry.Cache{}, err
+			}
+			if reg.Token == "" {
+				return cache, nil
+			}
+
+			token, err := config.Decrypt(config.NewKeyring(cfg.Keyring), reg.Token)
+			if err != nil {
+				return registry.Cache{}, fmt.Errorf("decrypting token of registry %s: %w", style.Symbol(registryName), err)
+			}
+			return cache.WithToken(token), nil
 		}
 	}
 
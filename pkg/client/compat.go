@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// CompatOptions define the builder and buildpacks to check for compatibility.
+type CompatOptions struct {
+	// Name of the builder image to check against.
+	Builder string
+
+	// URIs of the buildpacks to check. Accepts the same values as the --buildpack flag of `pack build`.
+	Buildpacks []string
+
+	// Buildpack registry name. Defines where registry buildpacks will be pulled from.
+	Registry string
+
+	// The base directory to use to resolve relative buildpack paths.
+	RelativeBaseDir string
+
+	// Fetch the builder and buildpack images from the daemon instead of the registry.
+	Daemon bool
+
+	// Strategy for pulling the builder and buildpack images.
+	PullPolicy image.PullPolicy
+}
+
+// ModuleCompatibility describes the result of each check made for a single buildpack.
+// An empty reason means the check passed.
+type ModuleCompatibility struct {
+	// The buildpack that was checked.
+	Module dist.ModuleInfo
+
+	// The Buildpack API declared by the buildpack.
+	API string
+
+	// Reason the Buildpack API is not supported by the builder lifecycle.
+	APIReason string
+
+	// Reason the buildpack targets do not include the builder target.
+	TargetReason string
+
+	// Reason the buildpack stack or mixins are not provided by the builder.
+	MixinsReason string
+}
+
+// Compatible returns true when all checks passed.
+func (m ModuleCompatibility) Compatible() bool {
+	return m.APIReason == "" && m.TargetReason == "" && m.MixinsReason == ""
+}
+
+// CompatReport is a compatibility matrix of buildpacks against a builder.
+type CompatReport struct {
+	// Name of the builder image.
+	Builder string
+
+	// Lifecycle version and supported APIs of the builder.
+	Lifecycle builder.LifecycleDescriptor
+
+	// Target of the builder image.
+	Target dist.Target
+
+	// Stack ID of the builder, if any.
+	StackID string
+
+	// Mixins provided by the builder.
+	Mixins []string
+
+	// Results for each checked buildpack, including dependencies of buildpackages.
+	Modules []ModuleCompatibility
+}
+
+// Compatible returns true when every checked buildpack is compatible with the builder.
+func (r *CompatReport) Compatible() bool {
+	for _, m := range r.Modules {
+		if !m.Compatible() {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckCompatibility checks the Buildpack API, targets and mixins of each buildpack against the builder,
+// without running a build.
+func (c *Client) CheckCompatibility(ctx context.Context, opts CompatOptions) (*CompatReport, error) {
+	if opts.Builder == "" {
+		return nil, errors.New("builder is required")
+	}
+	if len(opts.Buildpacks) == 0 {
+		return nil, errors.New("at least one buildpack is required")
+	}
+
+	builderImage, err := c.imageFetcher.Fetch(ctx, opts.Builder, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching builder image %s", style.Symbol(opts.Builder))
+	}
+
+	bldr, err := builder.FromImage(builderImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
+	}
+
+	target, err := builderTarget(bldr)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CompatReport{
+		Builder:   opts.Builder,
+		Lifecycle: bldr.LifecycleDescriptor(),
+		Target:    target,
+		StackID:   bldr.StackID,
+		Mixins:    bldr.Mixins(),
+	}
+
+	distro := dist.Distribution{}
+	if len(target.Distributions) > 0 {
+		distro = target.Distributions[0]
+	}
+
+	for _, uri := range opts.Buildpacks {
+		mainBP, deps, err := c.buildpackDownloader.Download(ctx, uri, buildpack.DownloadOptions{
+			RegistryName:    opts.Registry,
+			RelativeBaseDir: opts.RelativeBaseDir,
+			Daemon:          opts.Daemon,
+			PullPolicy:      opts.PullPolicy,
+			Target:          &dist.Target{OS: target.OS, Arch: target.Arch},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "downloading buildpack %s", style.Symbol(uri))
+		}
+
+		for _, module := range append([]buildpack.BuildModule{mainBP}, deps...) {
+			desc := module.Descriptor()
+			result := ModuleCompatibility{
+				Module: desc.Info(),
+				API:    desc.API().String(),
+			}
+
+			if err := builder.ValidateLifecycleCompat(desc, report.Lifecycle); err != nil {
+				result.APIReason = err.Error()
+			}
+			if len(desc.Order()) == 0 {
+				if err := desc.EnsureTargetSupport(target.OS, target.Arch, distro.Name, distro.Version); err != nil {
+					result.TargetReason = err.Error()
+				}
+				if err := desc.EnsureStackSupport(report.StackID, report.Mixins, false); err != nil {
+					result.MixinsReason = err.Error()
+				}
+			}
+
+			report.Modules = append(report.Modules, result)
+		}
+	}
+
+	return report, nil
+}
+
+func builderTarget(bldr *builder.Builder) (dist.Target, error) {
+	img := bldr.Image()
+
+	builderOS, err := img.OS()
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting builder OS")
+	}
+
+	builderArch, err := img.Architecture()
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting builder architecture")
+	}
+
+	target := dist.Target{OS: builderOS, Arch: builderArch}
+
+	distroName, err := img.Label(platform.OSDistroNameLabel)
+	if err != nil {
+		return dist.Target{}, err
+	}
+	distroVersion, err := img.Label(platform.OSDistroVersionLabel)
+	if err != nil {
+		return dist.Target{}, err
+	}
+	if distroName != "" {
+		target.Distributions = []dist.Distribution{{Name: distroName, Version: distroVersion}}
+	}
+
+	return target, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// CompatMode is what builds do when the run image doesn't match the builder, by stack ID, target or mixins, or when the
+// buildpacks require mixins the stack doesn't provide
+type CompatMode string
+
+const (
+	// CompatModeStrict fails builds with mismatches, which is the default.
+	CompatModeStrict CompatMode = "strict"
+
+	// CompatModeWarn reports mismatches as warnings, and builds anyway.
+	CompatModeWarn CompatMode = "warn"
+
+	// CompatModeIgnore builds anyway, only reporting mismatches in the debug output.
+	CompatModeIgnore CompatMode = "ignore"
+)
+
+// ParseCompatMode parses a CompatMode, defaulting to CompatModeStrict when empty
+func ParseCompatMode(mode string) (CompatMode, error) {
+	switch m := CompatMode(mode); m {
+	case "":
+		return CompatModeStrict, nil
+	case CompatModeStrict, CompatModeWarn, CompatModeIgnore:
+		return m, nil
+	default:
+		return "", errors.Errorf("invalid compat mode %s, must be one of %s, %s or %s", style.Symbol(mode), CompatModeStrict, CompatModeWarn, CompatModeIgnore)
+	}
+}
+
+// checkCompat returns the mismatch, with the diagnostics explaining it, when the compat mode is strict, and reports it
+// otherwise
+func (c *Client) checkCompat(mode CompatMode, mismatch error, diagnostics ...string) error {
+	if mismatch == nil {
+		return nil
+	}
+
+	switch mode {
+	case CompatModeIgnore:
+		c.logger.Debugf("Ignoring mismatch, as the compat mode is %s: %s", style.Symbol(string(mode)), mismatch)
+		return nil
+	case CompatModeWarn:
+		c.logger.Warnf("%s, building anyway as the compat mode is %s", mismatch, style.Symbol(string(mode)))
+		for _, diagnostic := range diagnostics {
+			c.logger.Warnf("  %s", diagnostic)
+		}
+		return nil
+	default:
+		for _, diagnostic := range diagnostics {
+			c.logger.Info(diagnostic)
+		}
+		c.logger.Infof("Use a compat mode of %s or %s to build anyway", style.Symbol(string(CompatModeWarn)), style.Symbol(string(CompatModeIgnore)))
+		return mismatch
+	}
+}
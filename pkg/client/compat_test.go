@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CheckCompatibility", testCheckCompatibility, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCheckCompatibility(t *testing.T, when spec.G, it spec.S) {
+	const builderName = "example.com/some/builder:tag"
+
+	var (
+		subject                 *Client
+		mockController          *gomock.Controller
+		mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+		fakeImageFetcher        *ifakes.FakeImageFetcher
+		builderImage            *fakes.Image
+		tmpDir                  string
+		outBuf                  bytes.Buffer
+	)
+
+	newBuildpack := func(id, bpAPI string, stacks []dist.Stack, targets []dist.Target) buildpack.BuildModule {
+		bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+			WithAPI:     api.MustParse(bpAPI),
+			WithInfo:    dist.ModuleInfo{ID: id, Version: "1.0.0"},
+			WithStacks:  stacks,
+			WithTargets: targets,
+		}, 0644)
+		h.AssertNil(t, err)
+		return bp
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "compat-test")
+		h.AssertNil(t, err)
+
+		mockController = gomock.NewController(t)
+		mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+
+		builderImage = newFakeBuilderImage(t, tmpDir, builderName, "some.stack.id", "some/run", builder.DefaultLifecycleVersion, newLinuxImage)
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "build:mixinB"]`))
+		fakeImageFetcher.LocalImages[builderImage.Name()] = builderImage
+
+		subject = &Client{
+			logger:              logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher:        fakeImageFetcher,
+			buildpackDownloader: mockBuildpackDownloader,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#CheckCompatibility", func() {
+		it("requires a builder", func() {
+			_, err := subject.CheckCompatibility(context.TODO(), CompatOptions{Buildpacks: []string{"some/bp"}})
+			h.AssertError(t, err, "builder is required")
+		})
+
+		it("requires at least one buildpack", func() {
+			_, err := subject.CheckCompatibility(context.TODO(), CompatOptions{Builder: builderName, Daemon: true})
+			h.AssertError(t, err, "at least one buildpack is required")
+		})
+
+		it("reports compatible buildpacks", func() {
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "some/bp", gomock.Any()).
+				Return(newBuildpack("some.bp", "0.8", []dist.Stack{{ID: "some.stack.id", Mixins: []string{"mixinA"}}}, nil), nil, nil)
+
+			compat, err := subject.CheckCompatibility(context.TODO(), CompatOptions{
+				Builder:    builderName,
+				Buildpacks: []string{"some/bp"},
+				Daemon:     true,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, compat.StackID, "some.stack.id")
+			h.AssertEq(t, len(compat.Modules), 1)
+			h.AssertEq(t, compat.Modules[0].Module.ID, "some.bp")
+			h.AssertEq(t, compat.Modules[0].API, "0.8")
+			h.AssertTrue(t, compat.Compatible())
+		})
+
+		it("reports the reason each check failed", func() {
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "some/bp", gomock.Any()).
+				Return(
+					newBuildpack("some.bp", "0.1", []dist.Stack{{ID: "some.stack.id", Mixins: []string{"mixinC"}}}, nil),
+					[]buildpack.BuildModule{newBuildpack("some.dep", "0.8", nil, []dist.Target{{OS: "windows", Arch: "amd64"}})},
+					nil,
+				)
+
+			compat, err := subject.CheckCompatibility(context.TODO(), CompatOptions{
+				Builder:    builderName,
+				Buildpacks: []string{"some/bp"},
+				Daemon:     true,
+			})
+			h.AssertNil(t, err)
+			h.AssertFalse(t, compat.Compatible())
+			h.AssertEq(t, len(compat.Modules), 2)
+
+			h.AssertContains(t, compat.Modules[0].APIReason, "is incompatible with lifecycle")
+			h.AssertContains(t, compat.Modules[0].MixinsReason, "requires missing mixin(s): mixinC")
+			h.AssertEq(t, compat.Modules[0].TargetReason, "")
+
+			h.AssertEq(t, compat.Modules[1].Module.ID, "some.dep")
+			h.AssertEq(t, compat.Modules[1].APIReason, "")
+			h.AssertEq(t, compat.Modules[1].MixinsReason, "")
+			h.AssertContains(t, compat.Modules[1].TargetReason, "unable to satisfy target os/arch constraints")
+		})
+
+		it("errors when the buildpack cannot be downloaded", func() {
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "some/bp", gomock.Any()).
+				Return(nil, nil, errors.New("some error"))
+
+			_, err := subject.CheckCompatibility(context.TODO(), CompatOptions{
+				Builder:    builderName,
+				Buildpacks: []string{"some/bp"},
+				Daemon:     true,
+			})
+			h.AssertError(t, err, "downloading buildpack 'some/bp': some error")
+		})
+	})
+}
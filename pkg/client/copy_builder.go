@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// CopyBuilderOptions define the builder to copy between registries, and the images it references to copy along.
+type CopyBuilderOptions struct {
+	// Name of the builder to copy.
+	Source string
+
+	// Name to copy the builder to.
+	Destination string
+
+	// Copy the run images of the builder to the repository of the destination, next to the builder.
+	CopyRunImages bool
+
+	// Copy the lifecycle image of the version of the lifecycle of the builder, used for untrusted builds, to the
+	// repository of the destination, next to the builder.
+	CopyLifecycleImage bool
+}
+
+// CopyBuilder copies a builder image, or image index, between registries as it is, so that it keeps its digest,
+// such as to promote a builder from a staging registry to a production one. Images the builder references are copied
+// next to the destination, keeping their tag or digest.
+func (c *Client) CopyBuilder(ctx context.Context, opts CopyBuilderOptions) error {
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	srcRef, err := name.ParseReference(opts.Source, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder name %s", style.Symbol(opts.Source))
+	}
+	dstRef, err := name.ParseReference(opts.Destination, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid destination %s", style.Symbol(opts.Destination))
+	}
+
+	desc, err := ggcrremote.Get(srcRef, remoteOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "reading builder %s", style.Symbol(opts.Source))
+	}
+	if err := copyRemoteImage(desc, dstRef, remoteOpts...); err != nil {
+		return err
+	}
+	c.logger.Infof("Copied builder %s to %s (%s)", style.Symbol(opts.Source), style.Symbol(opts.Destination), desc.Digest)
+
+	if !opts.CopyRunImages && !opts.CopyLifecycleImage {
+		return nil
+	}
+
+	var md builder.Metadata
+	if err := remoteImageLabel(desc, builder.MetadataLabel, &md); err != nil {
+		return errors.Wrapf(err, "reading metadata of builder %s", style.Symbol(opts.Source))
+	}
+
+	var referenced []string
+	if opts.CopyRunImages {
+		for _, runImage := range md.RunImages {
+			referenced = append(referenced, runImage.Image)
+		}
+		if len(md.RunImages) == 0 && md.Stack.RunImage.Image != "" {
+			referenced = append(referenced, md.Stack.RunImage.Image)
+		}
+	}
+	if opts.CopyLifecycleImage {
+		if md.Lifecycle.Version == nil {
+			return errors.Errorf("builder %s has no lifecycle version", style.Symbol(opts.Source))
+		}
+		referenced = append(referenced, fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, md.Lifecycle.Version.String()))
+	}
+
+	for _, imageName := range referenced {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+		}
+		relocated := relocateReference(ref, dstRef.Context())
+
+		desc, err := ggcrremote.Get(ref, remoteOpts...)
+		if err != nil {
+			return errors.Wrapf(err, "reading image %s", style.Symbol(imageName))
+		}
+		if err := copyRemoteImage(desc, relocated, remoteOpts...); err != nil {
+			return err
+		}
+		c.logger.Infof("Copied %s to %s (%s)", style.Symbol(imageName), style.Symbol(relocated.Name()), desc.Digest)
+	}
+	return nil
+}
+
+// copyRemoteImage writes the image, or image index, of the descriptor to the reference, keeping its manifests as they
+// are
+func copyRemoteImage(desc *ggcrremote.Descriptor, dst name.Reference, opts ...ggcrremote.Option) error {
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return err
+		}
+		return errors.Wrapf(ggcrremote.WriteIndex(dst, index, opts...), "writing image index %s", style.Symbol(dst.Name()))
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(ggcrremote.Write(dst, img, opts...), "writing image %s", style.Symbol(dst.Name()))
+}
+
+// remoteImageLabel reads the JSON label of an image, or of the first image of an image index, into the value
+func remoteImageLabel(desc *ggcrremote.Descriptor, labelName string, v interface{}) error {
+	var img v1.Image
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return err
+		}
+		manifest, err := index.IndexManifest()
+		if err != nil {
+			return err
+		}
+		if len(manifest.Manifests) == 0 {
+			return errors.New("image index has no manifests")
+		}
+		if img, err = index.Image(manifest.Manifests[0].Digest); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if img, err = desc.Image(); err != nil {
+			return err
+		}
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return err
+	}
+	label, ok := configFile.Config.Labels[labelName]
+	if !ok {
+		return errors.Errorf("missing label %s", style.Symbol(labelName))
+	}
+	return errors.Wrapf(json.Unmarshal([]byte(label), v), "parsing label %s", style.Symbol(labelName))
+}
+
+// relocateReference returns the reference to the image in the repository next to the given one, of the same name
+// and tag or digest, such as registry.example.com/team/run:base for index.docker.io/some/run:base next to
+// registry.example.com/team/builder.
+func relocateReference(ref name.Reference, next name.Repository) name.Reference {
+	return referenceInRepository(ref, next.Registry.Repo(path.Join(path.Dir(next.RepositoryStr()), path.Base(ref.Context().RepositoryStr()))))
+}
+
+// referenceInRepository returns the reference to the image in the repository, of the same tag or digest
+func referenceInRepository(ref name.Reference, repo name.Repository) name.Reference {
+	if digest, ok := ref.(name.Digest); ok {
+		return repo.Digest(digest.DigestStr())
+	}
+	return repo.Tag(ref.Identifier())
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCopyBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CopyBuilder", testCopyBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCopyBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		staging, prod  *httptest.Server
+		stagingHost    string
+		prodHost       string
+		builderDigest  v1.Hash
+		runImageDigest v1.Hash
+		outBuf         bytes.Buffer
+	)
+
+	newRegistryAndHost := func() (*httptest.Server, string) {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		return server, strings.TrimPrefix(server.URL, "http://")
+	}
+	writeRemoteImage := func(imageName string, img v1.Image) {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, img))
+	}
+	remoteDigestOf := func(imageName string) v1.Hash {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		desc, err := ggcrremote.Head(ref)
+		h.AssertNil(t, err)
+		return desc.Digest
+	}
+
+	it.Before(func() {
+		staging, stagingHost = newRegistryAndHost()
+		prod, prodHost = newRegistryAndHost()
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+
+		runImage, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		writeRemoteImage(stagingHost+"/stacks/run:base", runImage)
+		runImageDigest, err = runImage.Digest()
+		h.AssertNil(t, err)
+
+		builderImage, err := random.Image(1024, 2)
+		h.AssertNil(t, err)
+		builderImage, err = mutate.Config(builderImage, v1.Config{Labels: map[string]string{
+			"io.buildpacks.builder.metadata": `{"images": [{"image": "` + stagingHost + `/stacks/run:base"}], "lifecycle": {"version": "0.19.6"}}`,
+		}})
+		h.AssertNil(t, err)
+		writeRemoteImage(stagingHost+"/builders/base:1.0", builderImage)
+		builderDigest, err = builderImage.Digest()
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		staging.Close()
+		prod.Close()
+	})
+
+	when("#CopyBuilder", func() {
+		it("copies the builder keeping its digest", func() {
+			h.AssertNil(t, subject.CopyBuilder(context.TODO(), CopyBuilderOptions{
+				Source:      stagingHost + "/builders/base:1.0",
+				Destination: prodHost + "/team/builders/base:1.0",
+			}))
+
+			h.AssertEq(t, remoteDigestOf(prodHost+"/team/builders/base:1.0"), builderDigest)
+			h.AssertContains(t, outBuf.String(), "Copied builder")
+		})
+
+		it("copies the run images of the builder next to the destination", func() {
+			h.AssertNil(t, subject.CopyBuilder(context.TODO(), CopyBuilderOptions{
+				Source:        stagingHost + "/builders/base:1.0",
+				Destination:   prodHost + "/team/builders/base:1.0",
+				CopyRunImages: true,
+			}))
+
+			h.AssertEq(t, remoteDigestOf(prodHost+"/team/builders/run:base"), runImageDigest)
+		})
+
+		it("fails when the builder doesn't exist", func() {
+			err := subject.CopyBuilder(context.TODO(), CopyBuilderOptions{
+				Source:      stagingHost + "/builders/missing:1.0",
+				Destination: prodHost + "/builders/missing:1.0",
+			})
+			h.AssertError(t, err, "reading builder")
+		})
+	})
+
+	when("#relocateReference", func() {
+		it("keeps the name and tag or digest of the image", func() {
+			next, err := name.NewRepository("registry.example.com/team/builder")
+			h.AssertNil(t, err)
+
+			ref, err := name.ParseReference("some/run:base", name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertEq(t, relocateReference(ref, next).Name(), "registry.example.com/team/run:base")
+
+			ref, err = name.ParseReference("some/run@sha256:"+strings.Repeat("a", 64), name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertEq(t, relocateReference(ref, next).Name(), "registry.example.com/team/run@sha256:"+strings.Repeat("a", 64))
+		})
+	})
+}
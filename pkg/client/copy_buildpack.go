@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// CopyBuildpackOptions define the buildpackage to copy between registries, and whether to copy its dependencies along.
+type CopyBuildpackOptions struct {
+	// Name of the buildpackage to copy.
+	Source string
+
+	// Name to copy the buildpackage to.
+	Destination string
+
+	// Copy the packages of the buildpacks the buildpackage depends on, found in the buildpack registry, next to the
+	// destination.
+	IncludeDependencies bool
+
+	// Name of the buildpack registry to find the packages of dependencies in.
+	Registry string
+}
+
+// RelocatedBuildpack is a buildpackage copied to another registry.
+type RelocatedBuildpack struct {
+	// ID and version of the buildpack, in the form of <id>@<version>.
+	Buildpack string `json:"buildpack"`
+
+	// Reference to the buildpackage before it was copied.
+	Source string `json:"source"`
+
+	// Digest reference to the copied buildpackage.
+	Destination string `json:"destination"`
+}
+
+// CopyBuildpack copies a buildpackage between registries as it is, so that it keeps its digest. Dependencies are
+// part of the layers of the buildpackage, and are copied along with it. With IncludeDependencies, the packages of the
+// dependencies found in the buildpack registry are also copied next to the destination, keeping their digest, such
+// as for air-gapped registries. The references of the copied buildpackages are returned, the copied buildpackage first.
+func (c *Client) CopyBuildpack(ctx context.Context, opts CopyBuildpackOptions) ([]RelocatedBuildpack, error) {
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	srcRef, err := name.ParseReference(opts.Source, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid buildpackage name %s", style.Symbol(opts.Source))
+	}
+	dstRef, err := name.ParseReference(opts.Destination, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid destination %s", style.Symbol(opts.Destination))
+	}
+
+	desc, err := ggcrremote.Get(srcRef, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading buildpackage %s", style.Symbol(opts.Source))
+	}
+	var md buildpack.Metadata
+	if err := remoteImageLabel(desc, buildpack.MetadataLabel, &md); err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of buildpackage %s", style.Symbol(opts.Source))
+	}
+	if err := copyRemoteImage(desc, dstRef, remoteOpts...); err != nil {
+		return nil, err
+	}
+	relocated := []RelocatedBuildpack{{
+		Buildpack:   md.ModuleInfo.FullName(),
+		Source:      srcRef.Name(),
+		Destination: dstRef.Context().Digest(desc.Digest.String()).Name(),
+	}}
+	c.logger.Infof("Copied buildpackage %s to %s (%s)", style.Symbol(opts.Source), style.Symbol(opts.Destination), desc.Digest)
+
+	if !opts.IncludeDependencies {
+		return relocated, nil
+	}
+
+	var layers dist.ModuleLayers
+	if err := remoteImageLabel(desc, dist.BuildpackLayersLabel, &layers); err != nil {
+		return nil, errors.Wrapf(err, "reading buildpacks of buildpackage %s", style.Symbol(opts.Source))
+	}
+
+	for _, dependency := range packageDependencies(md.ModuleInfo, layers) {
+		address, err := c.registryResolver.Resolve(opts.Registry, dependency)
+		if err != nil {
+			c.logger.Debugf("Buildpack %s is only part of the buildpackage: %s", style.Symbol(dependency), err)
+			continue
+		}
+
+		ref, err := name.ParseReference(address, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address %s of buildpack %s", style.Symbol(address), style.Symbol(dependency))
+		}
+		dependencyDesc, err := ggcrremote.Get(ref, remoteOpts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading buildpackage %s", style.Symbol(address))
+		}
+		dependencyRef := relocateReference(ref, dstRef.Context())
+		if err := copyRemoteImage(dependencyDesc, dependencyRef, remoteOpts...); err != nil {
+			return nil, err
+		}
+
+		relocated = append(relocated, RelocatedBuildpack{Buildpack: dependency, Source: ref.Name(), Destination: dependencyRef.Name()})
+		c.logger.Infof("Copied buildpackage of dependency %s to %s (%s)", style.Symbol(dependency), style.Symbol(dependencyRef.Context().Name()), dependencyDesc.Digest)
+	}
+	return relocated, nil
+}
+
+// packageDependencies returns the buildpacks of a buildpackage other than its own buildpack, as <id>@<version>, sorted
+func packageDependencies(main dist.ModuleInfo, layers dist.ModuleLayers) []string {
+	var dependencies []string
+	for id, versions := range layers {
+		for version := range versions {
+			if id == main.ID && version == main.Version {
+				continue
+			}
+			dependencies = append(dependencies, id+"@"+version)
+		}
+	}
+	sort.Strings(dependencies)
+	return dependencies
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCopyBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CopyBuildpack", testCopyBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCopyBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockResolver     *testmocks.MockRegistryResolver
+		public, airGap   *httptest.Server
+		publicHost       string
+		airGapHost       string
+		packageDigest    v1.Hash
+		dependencyDigest v1.Hash
+		outBuf           bytes.Buffer
+	)
+
+	newRegistryAndHost := func() (*httptest.Server, string) {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		return server, strings.TrimPrefix(server.URL, "http://")
+	}
+	writePackage := func(imageName string, labels map[string]string) v1.Hash {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		img, err = mutate.Config(img, v1.Config{Labels: labels})
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest
+	}
+	remoteDigestOf := func(imageName string) v1.Hash {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		desc, err := ggcrremote.Head(ref)
+		h.AssertNil(t, err)
+		return desc.Digest
+	}
+
+	it.Before(func() {
+		public, publicHost = newRegistryAndHost()
+		airGap, airGapHost = newRegistryAndHost()
+		mockController = gomock.NewController(t)
+		mockResolver = testmocks.NewMockRegistryResolver(mockController)
+		subject = &Client{
+			logger:           logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain:         authn.DefaultKeychain,
+			registryResolver: mockResolver,
+		}
+
+		dependencyDigest = writePackage(publicHost+"/buildpacks/jvm:1.0.0", map[string]string{
+			"io.buildpacks.buildpackage.metadata": `{"id": "example/jvm", "version": "1.0.0"}`,
+			"io.buildpacks.buildpack.layers":      `{"example/jvm": {"1.0.0": {}}}`,
+		})
+		packageDigest = writePackage(publicHost+"/buildpacks/java:2.0.0", map[string]string{
+			"io.buildpacks.buildpackage.metadata": `{"id": "example/java", "version": "2.0.0"}`,
+			"io.buildpacks.buildpack.layers":      `{"example/java": {"2.0.0": {}}, "example/jvm": {"1.0.0": {}}, "example/maven": {"3.0.0": {}}}`,
+		})
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		public.Close()
+		airGap.Close()
+	})
+
+	when("#CopyBuildpack", func() {
+		it("copies the buildpackage keeping its digest", func() {
+			relocated, err := subject.CopyBuildpack(context.TODO(), CopyBuildpackOptions{
+				Source:      publicHost + "/buildpacks/java:2.0.0",
+				Destination: airGapHost + "/mirror/java:2.0.0",
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, remoteDigestOf(airGapHost+"/mirror/java:2.0.0"), packageDigest)
+			h.AssertEq(t, relocated, []RelocatedBuildpack{{
+				Buildpack:   "example/java@2.0.0",
+				Source:      publicHost + "/buildpacks/java:2.0.0",
+				Destination: airGapHost + "/mirror/java@" + packageDigest.String(),
+			}})
+		})
+
+		it("copies the packages of the dependencies found in the registry", func() {
+			dependencyAddress := publicHost + "/buildpacks/jvm@" + dependencyDigest.String()
+			mockResolver.EXPECT().Resolve("some-registry", "example/jvm@1.0.0").Return(dependencyAddress, nil)
+			mockResolver.EXPECT().Resolve("some-registry", "example/maven@3.0.0").Return("", errors.New("not found"))
+
+			relocated, err := subject.CopyBuildpack(context.TODO(), CopyBuildpackOptions{
+				Source:              publicHost + "/buildpacks/java:2.0.0",
+				Destination:         airGapHost + "/mirror/java:2.0.0",
+				IncludeDependencies: true,
+				Registry:            "some-registry",
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, remoteDigestOf(airGapHost+"/mirror/jvm@"+dependencyDigest.String()), dependencyDigest)
+			h.AssertEq(t, len(relocated), 2)
+			h.AssertEq(t, relocated[1], RelocatedBuildpack{
+				Buildpack:   "example/jvm@1.0.0",
+				Source:      dependencyAddress,
+				Destination: airGapHost + "/mirror/jvm@" + dependencyDigest.String(),
+			})
+		})
+
+		it("fails for images that aren't buildpackages", func() {
+			writePackage(publicHost+"/some/app:latest", nil)
+
+			_, err := subject.CopyBuildpack(context.TODO(), CopyBuildpackOptions{
+				Source:      publicHost + "/some/app:latest",
+				Destination: airGapHost + "/some/app:latest",
+			})
+			h.AssertError(t, err, "missing label 'io.buildpacks.buildpackage.metadata'")
+		})
+	})
+}
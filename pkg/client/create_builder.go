@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -14,6 +16,7 @@ import (
 
 	pubbldr "github.com/buildpacks/pack/builder"
 	"github.com/buildpacks/pack/internal/builder"
+	internalConfig "github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
@@ -36,6 +39,9 @@ type CreateBuilderOptions struct {
 	// Map of labels to add to the Buildpack
 	Labels map[string]string
 
+	// Map of OCI manifest annotations to add to the builder image
+	Annotations map[string]string
+
 	// Configuration that defines the functionality a builder provides.
 	Config pubbldr.Config
 
@@ -43,6 +49,10 @@ type CreateBuilderOptions struct {
 	// Requires BuilderName to be a valid registry location.
 	Publish bool
 
+	// Type of output format, one of FormatImage (the default), FormatFile or FormatOCILayout. Builders written to a
+	// file are saved as an archive of an OCI layout.
+	Format string
+
 	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
 	Registry string
 
@@ -54,11 +64,28 @@ type CreateBuilderOptions struct {
 
 	// Target platforms to build builder images for
 	Targets []dist.Target
+
+	// Resolve the build image, lifecycle and modules, and log them with what would be saved or pushed, without
+	// saving or pushing anything.
+	DryRun bool
+
+	// Size in bytes of the contents kept in memory while creating the layers of the builder, beyond which they're
+	// written to temporary files. Defaults to builder.DefaultMemoryLimit when zero.
+	MemoryLimit int64
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+	if opts.Format == "" {
+		opts.Format = FormatImage
+	}
+	switch opts.Format {
+	case FormatImage, FormatFile, FormatOCILayout:
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+
 	targets, err := c.processBuilderCreateTargets(ctx, opts)
 	if err != nil {
 		return err
@@ -71,7 +98,7 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 		}
 	} else {
 		var digests []string
-		multiArch := len(targets) > 1 && opts.Publish
+		multiArch := len(targets) > 1 && !SavesToDaemon(opts.Format, opts.Publish)
 
 		for _, target := range targets {
 			digest, err := c.createBuilderTarget(ctx, opts, &target, multiArch)
@@ -81,7 +108,11 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 			digests = append(digests, digest)
 		}
 
-		if multiArch && len(digests) > 1 {
+		if multiArch && opts.Publish && len(digests) > 1 {
+			if opts.DryRun {
+				c.logger.Infof("Image index %s of %d builders would be pushed to the registry", style.Symbol(opts.BuilderName), len(digests))
+				return nil
+			}
 			return c.CreateManifest(ctx, CreateManifestOptions{
 				IndexRepoName: opts.BuilderName,
 				RepoNames:     digests,
@@ -98,7 +129,34 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 		return "", err
 	}
 
-	bldr, err := c.createBaseBuilder(ctx, opts, target)
+	if opts.DryRun {
+		if target != nil {
+			c.logger.Infof("Dry run of builder %s for %s:", style.Symbol(opts.BuilderName), style.Symbol(target.ValuesAsPlatform()))
+		} else {
+			c.logger.Infof("Dry run of builder %s:", style.Symbol(opts.BuilderName))
+		}
+	}
+
+	// builders written to the host filesystem are saved as an OCI layout, which is archived when written to a file
+	outputName := opts.BuilderName
+	if multiArch && !opts.Publish {
+		outputName = targetOutputName(outputName, *target)
+	}
+	layoutDir := ""
+	switch {
+	case opts.DryRun:
+	case opts.Format == FormatOCILayout:
+		layoutDir = outputName
+	case opts.Format == FormatFile:
+		tmpDir, err := os.MkdirTemp("", "builder-file")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmpDir)
+		layoutDir = filepath.Join(tmpDir, "oci-layout")
+	}
+
+	bldr, err := c.createBaseBuilder(ctx, opts, target, layoutDir)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create builder")
 	}
@@ -120,12 +178,37 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 	bldr.SetRunImage(opts.Config.Run)
 	bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
 
-	err = bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	if opts.DryRun {
+		for _, kind := range []string{buildpack.KindBuildpack, buildpack.KindExtension} {
+			if err := c.logDryRunModules(kind, bldr.AllModules(kind)); err != nil {
+				return "", err
+			}
+		}
+		switch opts.Format {
+		case FormatFile:
+			c.logger.Infof("Builder would be written to file %s", style.Symbol(outputName))
+		case FormatOCILayout:
+			c.logger.Infof("Builder would be written to OCI layout %s", style.Symbol(outputName))
+		default:
+			c.logger.Infof("Builder %s would be %s", style.Symbol(opts.BuilderName), dryRunDestination(opts.Publish))
+		}
+		return "", nil
+	}
+
+	err = c.observePush(ctx, "builder", opts.Publish, func() error {
+		return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	})
 	if err != nil {
 		return "", err
 	}
 
-	if multiArch {
+	if opts.Format == FormatFile {
+		if err := writeLayoutArchive(layoutDir, outputName); err != nil {
+			return "", errors.Wrapf(err, "writing builder to file %s", style.Symbol(outputName))
+		}
+	}
+
+	if multiArch && opts.Publish {
 		// We need to keep the identifier to create the image index
 		id, err := bldr.Image().Identifier()
 		if err != nil {
@@ -152,7 +235,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	var runImages []imgutil.Image
 	for _, r := range opts.Config.Run.Images {
 		for _, i := range append([]string{r.Image}, r.Mirrors...) {
-			if !opts.Publish {
+			if SavesToDaemon(opts.Format, opts.Publish) {
 				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Target: target})
 				if err != nil {
 					if errors.Cause(err) != image.ErrNotFound {
@@ -197,11 +280,24 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	return nil
 }
 
-func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions, target *dist.Target) (*builder.Builder, error) {
-	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Target: target})
+// createBaseBuilder creates the builder from the build image, as an image of the OCI layout in the given directory when
+// there is one
+func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions, target *dist.Target, layoutDir string) (*builder.Builder, error) {
+	fetchOptions := image.FetchOptions{Daemon: SavesToDaemon(opts.Format, opts.Publish), PullPolicy: opts.PullPolicy, Target: target}
+	builderName := opts.BuilderName
+	if layoutDir != "" {
+		fetchOptions.LayoutOption = image.LayoutOption{Path: layoutDir}
+		builderName = layoutDir
+	}
+	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, fetchOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch build image")
 	}
+	if opts.DryRun {
+		if err := c.logDryRunImage("build image", baseImage); err != nil {
+			return nil, err
+		}
+	}
 
 	c.logger.Debugf("Creating builder %s from build-image %s", style.Symbol(opts.BuilderName), style.Symbol(baseImage.Name()))
 
@@ -212,8 +308,18 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 	if opts.Labels != nil && len(opts.Labels) > 0 {
 		builderOpts = append(builderOpts, builder.WithLabels(opts.Labels))
 	}
+	if len(opts.Annotations) > 0 {
+		if SavesToDaemon(opts.Format, opts.Publish) {
+			c.logger.Warn("Annotations are not persisted when saving to the docker daemon; use --publish to keep them")
+		}
+		builderOpts = append(builderOpts, builder.WithAnnotations(opts.Annotations))
+	}
 
-	bldr, err := builder.New(baseImage, opts.BuilderName, builderOpts...)
+	if opts.MemoryLimit > 0 {
+		builderOpts = append(builderOpts, builder.WithMemoryLimit(opts.MemoryLimit))
+	}
+
+	bldr, err := builder.New(baseImage, builderName, builderOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid build-image")
 	}
@@ -228,8 +334,11 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		return nil, errors.Wrap(err, "lookup image OS")
 	}
 
-	if os == "windows" && !c.experimental {
-		return nil, NewExperimentError("Windows containers support is currently experimental.")
+	if os == "windows" && !c.experimentalEnabled(internalConfig.FeatureWindows) {
+		return nil, NewFeatureExperimentError(internalConfig.FeatureWindows, "Windows containers support is currently experimental.")
+	}
+	if os == dist.TargetOSWasi && !c.experimentalEnabled(internalConfig.FeatureWasi) {
+		return nil, NewFeatureExperimentError(internalConfig.FeatureWasi, "WASI builder support is currently experimental.")
 	}
 
 	bldr.SetDescription(opts.Config.Description)
@@ -246,8 +355,26 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch lifecycle")
 	}
+	if opts.DryRun {
+		if err := c.logDryRunBlob("lifecycle", lifecycle.Descriptor().Info.Version.String(), lifecycle); err != nil {
+			return nil, err
+		}
+	}
 
 	bldr.SetLifecycle(lifecycle)
+
+	if opts.Config.Launcher != (pubbldr.LauncherConfig{}) {
+		launcher, launcherMetadata, err := c.fetchLauncher(ctx, opts.Config.Launcher, opts.RelativeBaseDir, os, architecture)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch launcher")
+		}
+		if opts.DryRun {
+			if err := c.logDryRunBlob("launcher", launcherMetadata.Version+launcherMetadata.URI, launcher); err != nil {
+				return nil, err
+			}
+		}
+		bldr.SetLauncher(launcher, launcherMetadata)
+	}
 	bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
 
 	return bldr, nil
@@ -264,6 +391,9 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 	var uri string
 	var err error
 	switch {
+	case os == dist.TargetOSWasi && config.URI == "":
+		// lifecycle releases have no WASI binaries
+		return nil, errors.Errorf("%s must be declared for WASI builders", style.Symbol("lifecycle.uri"))
 	case config.Version != "":
 		v, err := semver.NewVersion(config.Version)
 		if err != nil {
@@ -293,26 +423,63 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 	return lifecycle, nil
 }
 
-func (c *Client) addBuildpacksToBuilder(ctx context.Context, opts CreateBuilderOptions, bldr *builder.Builder) error {
-	for _, b := range opts.Config.Buildpacks {
-		if err := c.addConfig(ctx, buildpack.KindBuildpack, b, opts, bldr); err != nil {
-			return err
+// fetchLauncher returns the launcher binary to replace the launcher of the lifecycle of the builder with, from a URI,
+// or from the lifecycle release of a version
+func (c *Client) fetchLauncher(ctx context.Context, config pubbldr.LauncherConfig, relativeBaseDir, os string, architecture string) (builder.Blob, builder.LauncherMetadata, error) {
+	if config.Version != "" && config.URI != "" {
+		return nil, builder.LauncherMetadata{}, errors.Errorf(
+			"%s can only declare %s or %s, not both",
+			style.Symbol("launcher"), style.Symbol("version"), style.Symbol("uri"),
+		)
+	}
+
+	if config.Version != "" {
+		v, err := semver.NewVersion(config.Version)
+		if err != nil {
+			return nil, builder.LauncherMetadata{}, errors.Wrapf(err, "%s must be a valid semver", style.Symbol("launcher.version"))
 		}
+		lifecycleBlob, err := c.downloader.Download(ctx, c.uriFromLifecycleVersion(*v, os, architecture))
+		if err != nil {
+			return nil, builder.LauncherMetadata{}, errors.Wrap(err, "downloading lifecycle")
+		}
+		return builder.LauncherFromLifecycle(lifecycleBlob), builder.LauncherMetadata{Version: v.String()}, nil
 	}
-	return nil
+
+	uri, err := paths.FilePathToURI(config.URI, relativeBaseDir)
+	if err != nil {
+		return nil, builder.LauncherMetadata{}, err
+	}
+	launcher, err := c.downloader.Download(ctx, uri)
+	if err != nil {
+		return nil, builder.LauncherMetadata{}, errors.Wrap(err, "downloading launcher")
+	}
+	return launcher, builder.LauncherMetadata{URI: config.URI}, nil
+}
+
+// maxParallelDownloads is the number of buildpacks or extensions downloaded at once when creating a builder
+const maxParallelDownloads = 4
+
+func (c *Client) addBuildpacksToBuilder(ctx context.Context, opts CreateBuilderOptions, bldr *builder.Builder) error {
+	return c.addModulesToBuilder(ctx, buildpack.KindBuildpack, opts.Config.Buildpacks, opts, bldr)
 }
 
 func (c *Client) addExtensionsToBuilder(ctx context.Context, opts CreateBuilderOptions, bldr *builder.Builder) error {
-	for _, e := range opts.Config.Extensions {
-		if err := c.addConfig(ctx, buildpack.KindExtension, e, opts, bldr); err != nil {
-			return err
-		}
-	}
-	return nil
+	return c.addModulesToBuilder(ctx, buildpack.KindExtension, opts.Config.Extensions, opts, bldr)
 }
 
-func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.ModuleConfig, opts CreateBuilderOptions, bldr *builder.Builder) error {
-	c.logger.Debugf("Looking up %s %s", kind, style.Symbol(config.DisplayString()))
+// moduleDownload is the result of downloading a module, available once done is closed
+type moduleDownload struct {
+	mainBP buildpack.BuildModule
+	depBPs []buildpack.BuildModule
+	err    error
+	done   chan struct{}
+}
+
+// addModulesToBuilder downloads the modules concurrently, and adds them to the builder in the order they are configured
+func (c *Client) addModulesToBuilder(ctx context.Context, kind string, configs []pubbldr.ModuleConfig, opts CreateBuilderOptions, bldr *builder.Builder) error {
+	if len(configs) == 0 {
+		return nil
+	}
 
 	builderOS, err := bldr.Image().OS()
 	if err != nil {
@@ -322,26 +489,78 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 	if err != nil {
 		return errors.Wrapf(err, "getting builder architecture")
 	}
-
 	target := &dist.Target{OS: builderOS, Arch: builderArch}
-	c.logger.Debugf("Downloading buildpack for platform: %s", target.ValuesAsPlatform())
+	c.logger.Debugf("Downloading %d %ss for platform %s, up to %d at a time", len(configs), kind, target.ValuesAsPlatform(), maxParallelDownloads)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	downloads := make([]*moduleDownload, len(configs))
+	for i := range downloads {
+		downloads[i] = &moduleDownload{done: make(chan struct{})}
+	}
+
+	// downloads are started in order, so that the first modules are available first
+	go func() {
+		workers := make(chan struct{}, maxParallelDownloads)
+		for i, d := range downloads {
+			select {
+			case workers <- struct{}{}:
+			case <-ctx.Done():
+				for _, d := range downloads[i:] {
+					d.err = ctx.Err()
+					close(d.done)
+				}
+				return
+			}
+
+			go func(d *moduleDownload, config pubbldr.ModuleConfig) {
+				defer func() {
+					<-workers
+					close(d.done)
+				}()
+				d.mainBP, d.depBPs, d.err = c.downloadModule(ctx, kind, config, opts, target)
+			}(d, configs[i])
+		}
+	}()
+
+	for i, d := range downloads {
+		<-d.done
+		if d.err != nil {
+			return d.err
+		}
+		if err := c.addModule(kind, configs[i], d.mainBP, d.depBPs, bldr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) downloadModule(ctx context.Context, kind string, config pubbldr.ModuleConfig, opts CreateBuilderOptions, target *dist.Target) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+	c.logger.Debugf("Looking up %s %s", kind, style.Symbol(config.DisplayString()))
 
 	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, config.URI, buildpack.DownloadOptions{
-		Daemon:          !opts.Publish,
+		Daemon:          SavesToDaemon(opts.Format, opts.Publish),
 		ImageName:       config.ImageName,
 		ModuleKind:      kind,
 		PullPolicy:      opts.PullPolicy,
 		RegistryName:    opts.Registry,
 		RelativeBaseDir: opts.RelativeBaseDir,
 		Target:          target,
+		SHA256:          config.SHA256,
 	})
 	if err != nil {
-		return errors.Wrapf(err, "downloading %s", kind)
+		return nil, nil, errors.Wrapf(err, "downloading %s", kind)
 	}
 	err = validateModule(kind, mainBP, config.URI, config.ID, config.Version)
 	if err != nil {
-		return errors.Wrapf(err, "invalid %s", kind)
+		return nil, nil, errors.Wrapf(err, "invalid %s", kind)
 	}
+	return mainBP, depBPs, nil
+}
+
+func (c *Client) addModule(kind string, config pubbldr.ModuleConfig, mainBP buildpack.BuildModule, depBPs []buildpack.BuildModule, bldr *builder.Builder) error {
+	c.logger.Debugf("Adding %s %s", kind, style.Symbol(config.DisplayString()))
 
 	bpDesc := mainBP.Descriptor()
 	for _, deprecatedAPI := range bldr.LifecycleDescriptor().APIs.Buildpack.Deprecated {
@@ -381,7 +600,7 @@ func (c *Client) processBuilderCreateTargets(ctx context.Context, opts CreateBui
 	var targets []dist.Target
 
 	if len(opts.Targets) > 0 {
-		if opts.Publish {
+		if !SavesToDaemon(opts.Format, opts.Publish) {
 			targets = opts.Targets
 		} else {
 			// find a target that matches the daemon
@@ -3,6 +3,7 @@ package client_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -206,6 +207,27 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			return bldr
 		}
 
+		when("the format is oci-layout", func() {
+			it("creates the builder from the build image in the OCI layout", func() {
+				layoutDir := filepath.Join(tmpDir, "some-layout")
+				opts.BuilderName = layoutDir
+				opts.Format = client.FormatOCILayout
+				mockImageFetcher.EXPECT().
+					Fetch(gomock.Any(), "some/build-image", image.FetchOptions{PullPolicy: image.PullAlways, LayoutOption: image.LayoutOption{Path: layoutDir}}).
+					Return(fakeBuildImage, nil)
+				prepareFetcherWithRunImages()
+
+				successfullyCreateBuilder()
+				h.AssertEq(t, fakeBuildImage.Name(), layoutDir)
+			})
+
+			it("fails for unknown formats", func() {
+				opts.Format = "tarball"
+
+				h.AssertError(t, subject.CreateBuilder(context.TODO(), opts), "unknown format: 'tarball'")
+			})
+		})
+
 		when("validating the builder config", func() {
 			it("should not fail when the stack ID is empty", func() {
 				opts.Config.Stack.ID = ""
@@ -479,6 +501,44 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("WASI build image", func() {
+				when("experimental enabled", func() {
+					it("requires the lifecycle by uri", func() {
+						packClientWithExperimental, err := client.NewClient(
+							client.WithLogger(logger),
+							client.WithDownloader(mockDownloader),
+							client.WithImageFactory(mockImageFactory),
+							client.WithFetcher(mockImageFetcher),
+							client.WithExperimental(true),
+						)
+						h.AssertNil(t, err)
+
+						prepareFetcherWithRunImages()
+						opts.Config.Lifecycle.URI = ""
+						opts.Config.Lifecycle.Version = "3.4.5"
+
+						h.AssertNil(t, fakeBuildImage.SetOS("wasi"))
+						h.AssertNil(t, fakeBuildImage.SetArchitecture("wasm"))
+						mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(fakeBuildImage, nil)
+
+						err = packClientWithExperimental.CreateBuilder(context.TODO(), opts)
+						h.AssertError(t, err, "'lifecycle.uri' must be declared for WASI builders")
+					})
+				})
+
+				when("experimental disabled", func() {
+					it("fails", func() {
+						prepareFetcherWithRunImages()
+
+						h.AssertNil(t, fakeBuildImage.SetOS("wasi"))
+						mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(fakeBuildImage, nil)
+
+						err := subject.CreateBuilder(context.TODO(), opts)
+						h.AssertError(t, err, "failed to create builder: WASI builder support is currently experimental.")
+					})
+				})
+			})
+
 			when("error downloading lifecycle", func() {
 				it("should fail", func() {
 					prepareFetcherWithBuildImage()
@@ -759,6 +819,54 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, bldr.LifecycleDescriptor().APIs.Platform.Supported.AsStrings(), []string{"0.3", "0.4"})
 			})
 
+			when("launcher is provided", func() {
+				it("should replace the launcher of the lifecycle with the one of the uri", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Config.Launcher = pubbldr.LauncherConfig{URI: "file:///some-launcher"}
+					mockDownloader.EXPECT().Download(gomock.Any(), "file:///some-launcher").Return(blob.NewBlob(filepath.Join("testdata", "just-a-file.txt")), nil)
+
+					bldr := successfullyCreateBuilder()
+
+					contents, err := os.ReadFile(filepath.Join("testdata", "just-a-file.txt"))
+					h.AssertNil(t, err)
+					layerTar, err := fakeBuildImage.FindLayerWithPath("/cnb/lifecycle")
+					h.AssertNil(t, err)
+					h.AssertOnTarEntry(t, layerTar, "/cnb/lifecycle/launcher", h.ContentEquals(string(contents)))
+
+					h.AssertNotNil(t, bldr.Launcher())
+					h.AssertEq(t, bldr.Launcher().URI, "file:///some-launcher")
+					h.AssertEq(t, bldr.Launcher().SHA256, fmt.Sprintf("%x", sha256.Sum256(contents)))
+				})
+
+				it("should use the launcher of the lifecycle of the version", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Config.Launcher = pubbldr.LauncherConfig{Version: "3.4.5"}
+					mockDownloader.EXPECT().Download(
+						gomock.Any(),
+						"https://github.com/buildpacks/lifecycle/releases/download/v3.4.5/lifecycle-v3.4.5+linux.x86-64.tgz",
+					).Return(
+						blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil,
+					)
+
+					bldr := successfullyCreateBuilder()
+
+					h.AssertNotNil(t, bldr.Launcher())
+					h.AssertEq(t, bldr.Launcher().Version, "3.4.5")
+					h.AssertEq(t, bldr.LifecycleDescriptor().Info.Version.String(), "0.0.0")
+				})
+
+				it("should fail when both version and uri are provided", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Config.Launcher = pubbldr.LauncherConfig{Version: "3.4.5", URI: "file:///some-launcher"}
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "'launcher' can only declare 'version' or 'uri', not both")
+				})
+			})
+
 			it("should warn when deprecated Buildpack API version is used", func() {
 				prepareFetcherWithBuildImage()
 				prepareFetcherWithRunImages()
@@ -890,6 +998,98 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("dry run", func() {
+			it.Before(func() {
+				opts.DryRun = true
+			})
+
+			it("logs the build image, lifecycle and modules without saving the builder", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+
+				h.AssertNil(t, subject.CreateBuilder(context.TODO(), opts))
+
+				h.AssertEq(t, fakeBuildImage.IsSaved(), false)
+				h.AssertContains(t, out.String(), "Dry run of builder 'some/builder':")
+				h.AssertContains(t, out.String(), "build image 'some/build-image' (")
+				h.AssertContainsMatch(t, out.String(), `lifecycle '0.0.0' \(sha256:[0-9a-f]{64}, [0-9.]+ [kM]?B\)`)
+				h.AssertContainsMatch(t, out.String(), `buildpack 'bp.one@1.2.3' \(sha256:[0-9a-f]{64}, [0-9.]+ [kM]?B\)`)
+				h.AssertContainsMatch(t, out.String(), `extension 'ext.one@1.2.3' \(sha256:[0-9a-f]{64}, [0-9.]+ [kM]?B\)`)
+				h.AssertContains(t, out.String(), "Builder 'some/builder' would be saved to the daemon")
+			})
+
+			it("logs that the builder would be pushed when publishing", func() {
+				opts.Publish = true
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+
+				h.AssertNil(t, subject.CreateBuilder(context.TODO(), opts))
+
+				h.AssertEq(t, fakeBuildImage.IsSaved(), false)
+				h.AssertContains(t, out.String(), "Builder 'some/builder' would be pushed to the registry")
+			})
+		})
+
+		when("several buildpacks are configured", func() {
+			it("downloads them concurrently and adds them in order", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+
+				thirdDownloaded := make(chan struct{})
+				for _, id := range []string{"bp.two", "bp.three"} {
+					opts.Config.Buildpacks = append(opts.Config.Buildpacks, pubbldr.ModuleConfig{
+						ModuleInfo: dist.ModuleInfo{ID: id, Version: "1.0.0"},
+						ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/" + id + ".tgz"}},
+					})
+				}
+				bpTwo := createBuildpack(dist.BuildpackDescriptor{
+					WithAPI:    api.MustParse("0.3"),
+					WithInfo:   dist.ModuleInfo{ID: "bp.two", Version: "1.0.0"},
+					WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+				})
+				bpThree := createBuildpack(dist.BuildpackDescriptor{
+					WithAPI:    api.MustParse("0.3"),
+					WithInfo:   dist.ModuleInfo{ID: "bp.three", Version: "1.0.0"},
+					WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+				})
+				mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/bp.two.tgz", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, buildpackURI string, opts buildpack.DownloadOptions) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+						// only completes once the next buildpack has been downloaded
+						<-thirdDownloaded
+						return bpTwo, nil, nil
+					})
+				mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/bp.three.tgz", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, buildpackURI string, opts buildpack.DownloadOptions) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+						close(thirdDownloaded)
+						return bpThree, nil, nil
+					})
+
+				successfullyCreateBuilder()
+
+				output := out.String()
+				h.AssertContains(t, output, "Downloading 3 buildpacks for platform linux/amd64, up to 4 at a time")
+				h.AssertTrue(t, strings.Index(output, "Adding buildpack 'bp.one@1.2.3'") < strings.Index(output, "Adding buildpack 'bp.two@1.0.0'"))
+				h.AssertTrue(t, strings.Index(output, "Adding buildpack 'bp.two@1.0.0'") < strings.Index(output, "Adding buildpack 'bp.three@1.0.0'"))
+			})
+
+			it("fails with the error of the first buildpack that could not be downloaded", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+
+				for _, id := range []string{"bp.two", "bp.three"} {
+					opts.Config.Buildpacks = append(opts.Config.Buildpacks, pubbldr.ModuleConfig{
+						ModuleInfo: dist.ModuleInfo{ID: id, Version: "1.0.0"},
+						ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/" + id + ".tgz"}},
+					})
+					mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/"+id+".tgz", gomock.Any()).
+						Return(nil, nil, errors.Errorf("%s not found", id)).AnyTimes()
+				}
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "downloading buildpack: bp.two not found")
+			})
+		})
+
 		it("supports directory buildpacks", func() {
 			prepareFetcherWithBuildImage()
 			prepareFetcherWithRunImages()
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/stack"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// stackUserName is the name of the user the build and run images are set up with
+const stackUserName = "cnb"
+
+// CreateStackOptions are the options for CreateStack
+type CreateStackOptions struct {
+	// ID of the stack, set on both images. Stacks are deprecated, so it may be left empty.
+	ID string
+
+	// Names of the build and run images to create
+	BuildImage string
+	RunImage   string
+
+	// Base images of the build and run images. RunBase defaults to BuildBase.
+	BuildBase string
+	RunBase   string
+
+	// Dockerfiles built by the daemon to make the base images, instead of BuildBase and RunBase
+	BuildDockerfile string
+	RunDockerfile   string
+
+	// Mixins provided by the images. Those prefixed with 'build:' or 'run:' are only provided by one of them.
+	Mixins []string
+
+	// IDs of the user the images run as, which is added to them if their base images don't have it
+	UID int
+	GID int
+
+	// Platforms to create the images for. Creating them for more than one platform requires publishing them.
+	Targets []dist.Target
+
+	// Publish the images to a registry instead of saving them to the daemon
+	Publish bool
+
+	// Strategy for pulling the base images when saving to the daemon
+	PullPolicy image.PullPolicy
+}
+
+// imageBuilder is implemented by docker clients able to build images from Dockerfiles
+type imageBuilder interface {
+	ImageBuild(ctx context.Context, buildContext io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error)
+}
+
+// CreateStack creates a build image and a run image, set up with the user, labels and environment the lifecycle
+// expects, from base images or Dockerfiles.
+func (c *Client) CreateStack(ctx context.Context, opts CreateStackOptions) error {
+	if err := validateCreateStackOptions(&opts); err != nil {
+		return err
+	}
+
+	for _, kind := range []string{"build", "run"} {
+		imageName, base, dockerfile := opts.BuildImage, opts.BuildBase, opts.BuildDockerfile
+		if kind == "run" {
+			imageName, base, dockerfile = opts.RunImage, opts.RunBase, opts.RunDockerfile
+		}
+
+		fromDaemon, pullPolicy := !opts.Publish, opts.PullPolicy
+		if dockerfile != "" {
+			var err error
+			if base, err = c.buildStackBase(ctx, dockerfile, opts.Targets); err != nil {
+				return errors.Wrapf(err, "building %s base image from %s", kind, style.Symbol(dockerfile))
+			}
+			defer c.removeIntermediateImage(context.Background(), base)
+			fromDaemon, pullPolicy = true, image.PullNever
+		}
+
+		targets := opts.Targets
+		if len(targets) == 0 {
+			targets = []dist.Target{{}}
+		}
+		var images []v1.Image
+		for _, target := range targets {
+			baseImage, err := c.fetchStackBase(ctx, base, target, fromDaemon, pullPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "fetching %s base image %s", kind, style.Symbol(base))
+			}
+			img, err := stackImage(baseImage, kind, opts)
+			if err != nil {
+				return errors.Wrapf(err, "creating %s image", kind)
+			}
+			images = append(images, img)
+		}
+
+		if err := c.saveStackImage(ctx, imageName, images, opts.Publish); err != nil {
+			return errors.Wrapf(err, "saving %s image %s", kind, style.Symbol(imageName))
+		}
+		c.logger.Infof("Successfully created %s image %s", kind, style.Symbol(imageName))
+	}
+	return nil
+}
+
+func validateCreateStackOptions(opts *CreateStackOptions) error {
+	if opts.BuildImage == "" || opts.RunImage == "" {
+		return errors.New("build image and run image names are required")
+	}
+	if opts.RunBase == "" && opts.RunDockerfile == "" {
+		opts.RunBase = opts.BuildBase
+	}
+	if (opts.BuildBase == "") == (opts.BuildDockerfile == "") || (opts.RunBase == "") == (opts.RunDockerfile == "") {
+		return errors.New("either a base image or a Dockerfile is required for each image, but not both")
+	}
+	if opts.UID <= 0 || opts.GID <= 0 {
+		return errors.New("the user and group ids must be positive, as the images must not run as root")
+	}
+	if len(opts.Targets) > 1 {
+		if !opts.Publish {
+			return errors.New("creating images for more than one target requires publishing them")
+		}
+		if opts.BuildDockerfile != "" || opts.RunDockerfile != "" {
+			return errors.New("creating images from Dockerfiles is only supported for a single target")
+		}
+	}
+	return nil
+}
+
+// buildStackBase builds a Dockerfile with the daemon, and returns the name of the built image
+func (c *Client) buildStackBase(ctx context.Context, dockerfile string, targets []dist.Target) (string, error) {
+	imgBuilder, ok := c.docker.(imageBuilder)
+	if !ok {
+		return "", errors.New("building Dockerfiles is not supported by the docker client")
+	}
+
+	dockerfile, err := filepath.Abs(dockerfile)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(dockerfile))
+	tag := fmt.Sprintf("pack.local/stack-base/%x:latest", sum[:6])
+	c.trackIntermediateImage(tag, IntermediateStackBase)
+
+	buildOpts := types.ImageBuildOptions{
+		Tags:       []string{tag},
+		Dockerfile: filepath.Base(dockerfile),
+		Remove:     true,
+	}
+	if len(targets) == 1 {
+		buildOpts.Platform = targets[0].ValuesAsPlatform()
+	}
+
+	buildContext := archive.ReadDirAsTar(filepath.Dir(dockerfile), "", 0, 0, -1, false, false, nil)
+	defer buildContext.Close()
+
+	res, err := imgBuilder.ImageBuild(ctx, buildContext, buildOpts)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if err := jsonmessage.DisplayJSONMessagesStream(res.Body, logging.GetWriterForLevel(c.logger, logging.DebugLevel), 0, false, nil); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// fetchStackBase returns the base image for the target, from the daemon or a registry
+func (c *Client) fetchStackBase(ctx context.Context, base string, target dist.Target, fromDaemon bool, pullPolicy image.PullPolicy) (v1.Image, error) {
+	if fromDaemon {
+		fetchOpts := image.FetchOptions{Daemon: true, PullPolicy: pullPolicy}
+		if target.OS != "" {
+			fetchOpts.Target = &target
+		}
+		img, err := c.imageFetcher.Fetch(ctx, base, fetchOpts)
+		if err != nil {
+			return nil, err
+		}
+		return img.UnderlyingImage(), nil
+	}
+
+	ref, err := name.ParseReference(base, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	remoteOpts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain)}
+	if target.OS != "" {
+		remoteOpts = append(remoteOpts, remote.WithPlatform(v1.Platform{OS: target.OS, Architecture: target.Arch, Variant: target.ArchVariant}))
+	}
+	return remote.Image(ref, remoteOpts...)
+}
+
+// stackImage sets up a base image as the build or run image of a stack
+func stackImage(base v1.Image, kind string, opts CreateStackOptions) (v1.Image, error) {
+	userLayer, err := stack.UserLayer(base, stackUserName, opts.UID, opts.GID)
+	if err != nil {
+		return nil, err
+	}
+	img, err := mutate.Append(base, mutate.Addendum{
+		Layer:   userLayer,
+		History: v1.History{CreatedBy: fmt.Sprintf("pack stack create: add user %d:%d", opts.UID, opts.GID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	config := *configFile.Config.DeepCopy()
+
+	config.User = fmt.Sprintf("%d:%d", opts.UID, opts.GID)
+	if config.Labels == nil {
+		config.Labels = map[string]string{}
+	}
+	if opts.ID != "" {
+		config.Labels[platform.StackIDLabel] = opts.ID
+	}
+
+	otherKind := "run"
+	if kind == "run" {
+		otherKind = "build"
+	}
+	mixins := []string{}
+	for _, m := range opts.Mixins {
+		if len(stack.FindStageMixins([]string{m}, otherKind)) == 0 {
+			mixins = append(mixins, m)
+		}
+	}
+	mixinsLabel, err := json.Marshal(mixins)
+	if err != nil {
+		return nil, err
+	}
+	config.Labels[stack.MixinsLabel] = string(mixinsLabel)
+
+	if kind == "build" {
+		config.Env = append(config.Env,
+			fmt.Sprintf("%s=%s", builder.EnvUID, strconv.Itoa(opts.UID)),
+			fmt.Sprintf("%s=%s", builder.EnvGID, strconv.Itoa(opts.GID)),
+		)
+		if opts.ID != "" {
+			config.Env = append(config.Env, "CNB_STACK_ID="+opts.ID)
+		}
+	}
+
+	return mutate.Config(img, config)
+}
+
+// saveStackImage saves the images, one for each target, to the daemon, or publishes them, in an image index when
+// there are several of them
+func (c *Client) saveStackImage(ctx context.Context, imageName string, images []v1.Image, publish bool) error {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	if !publish {
+		tag, ok := ref.(name.Tag)
+		if !ok {
+			return errors.New("images saved to the daemon must be named with a tag")
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(tarball.Write(tag, images[0], pw))
+		}()
+		defer pr.Close()
+
+		res, err := c.docker.ImageLoad(ctx, pr, true)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		return jsonmessage.DisplayJSONMessagesStream(res.Body, io.Discard, 0, false, nil)
+	}
+
+	remoteOpts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain)}
+	if len(images) == 1 {
+		return remote.Write(ref, images[0], remoteOpts...)
+	}
+
+	var index v1.ImageIndex = empty.Index
+	for _, img := range images {
+		configFile, err := img.ConfigFile()
+		if err != nil {
+			return err
+		}
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: configFile.OS, Architecture: configFile.Architecture, Variant: configFile.Variant},
+			},
+		})
+	}
+	return remote.WriteIndex(ref, index, remoteOpts...)
+}
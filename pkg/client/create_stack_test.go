@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCreateStack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CreateStack", testCreateStack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCreateStack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		server  *httptest.Server
+		host    string
+		outBuf  bytes.Buffer
+	)
+
+	// baseImage returns a random image for the platform
+	baseImage := func(os, arch string) v1.Image {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		configFile.OS, configFile.Architecture = os, arch
+		img, err = mutate.ConfigFile(img, configFile)
+		h.AssertNil(t, err)
+		return img
+	}
+
+	// config returns the config of the image published as imageName
+	config := func(imageName string, platform *v1.Platform) v1.Config {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		opts := []ggcrremote.Option{}
+		if platform != nil {
+			opts = append(opts, ggcrremote.WithPlatform(*platform))
+		}
+		img, err := ggcrremote.Image(ref, opts...)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		return configFile.Config
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.TrimPrefix(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1), "http://")
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+
+		ref, err := name.ParseReference(host+"/some/base", name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, baseImage("linux", "amd64")))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#CreateStack", func() {
+		it("publishes the build and run images", func() {
+			err := subject.CreateStack(context.TODO(), CreateStackOptions{
+				ID:         "some.stack.id",
+				BuildImage: host + "/some/build",
+				RunImage:   host + "/some/run",
+				BuildBase:  host + "/some/base",
+				Mixins:     []string{"curl", "build:git", "run:tzdata"},
+				UID:        1000,
+				GID:        1001,
+				Publish:    true,
+			})
+			h.AssertNil(t, err)
+
+			buildConfig := config(host+"/some/build", nil)
+			h.AssertEq(t, buildConfig.User, "1000:1001")
+			h.AssertEq(t, buildConfig.Labels["io.buildpacks.stack.id"], "some.stack.id")
+			h.AssertEq(t, buildConfig.Labels["io.buildpacks.stack.mixins"], `["curl","build:git"]`)
+			h.AssertSliceContains(t, buildConfig.Env, "CNB_USER_ID=1000", "CNB_GROUP_ID=1001", "CNB_STACK_ID=some.stack.id")
+
+			runConfig := config(host+"/some/run", nil)
+			h.AssertEq(t, runConfig.User, "1000:1001")
+			h.AssertEq(t, runConfig.Labels["io.buildpacks.stack.mixins"], `["curl","run:tzdata"]`)
+			h.AssertSliceNotContains(t, runConfig.Env, "CNB_USER_ID=1000")
+
+			h.AssertContains(t, outBuf.String(), "Successfully created build image")
+			h.AssertContains(t, outBuf.String(), "Successfully created run image")
+		})
+
+		it("publishes image indexes for several targets", func() {
+			amd64, arm64 := baseImage("linux", "amd64"), baseImage("linux", "arm64")
+			index := mutate.AppendManifests(empty.Index,
+				mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+				mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+			)
+			ref, err := name.ParseReference(host+"/some/multi-arch-base", name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.WriteIndex(ref, index))
+
+			err = subject.CreateStack(context.TODO(), CreateStackOptions{
+				BuildImage: host + "/some/build",
+				RunImage:   host + "/some/run",
+				BuildBase:  host + "/some/multi-arch-base",
+				UID:        1000,
+				GID:        1000,
+				Targets:    []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+				Publish:    true,
+			})
+			h.AssertNil(t, err)
+
+			for _, arch := range []string{"amd64", "arm64"} {
+				runConfig := config(host+"/some/run", &v1.Platform{OS: "linux", Architecture: arch})
+				h.AssertEq(t, runConfig.User, "1000:1000")
+			}
+		})
+
+		it("fails without a base image or Dockerfile", func() {
+			err := subject.CreateStack(context.TODO(), CreateStackOptions{BuildImage: "some/build", RunImage: "some/run", UID: 1000, GID: 1000})
+			h.AssertError(t, err, "either a base image or a Dockerfile is required for each image")
+		})
+
+		it("fails to run the images as root", func() {
+			err := subject.CreateStack(context.TODO(), CreateStackOptions{BuildImage: "some/build", RunImage: "some/run", BuildBase: "some/base"})
+			h.AssertError(t, err, "the user and group ids must be positive")
+		})
+
+		it("fails to save images for several targets to the daemon", func() {
+			err := subject.CreateStack(context.TODO(), CreateStackOptions{
+				BuildImage: "some/build",
+				RunImage:   "some/run",
+				BuildBase:  "some/base",
+				UID:        1000,
+				GID:        1000,
+				Targets:    []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			})
+			h.AssertError(t, err, "creating images for more than one target requires publishing them")
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// DiffBuildersOptions define the builders to compare.
+type DiffBuildersOptions struct {
+	// Name of the builder to compare from, such as the builder in use.
+	From string
+
+	// Name of the builder to compare to, such as an upgrade of the builder in use.
+	To string
+
+	// Read the builders from the daemon, rather than from the registry.
+	Daemon bool
+}
+
+// ModuleChangeType is how a buildpack or extension changed between two builders
+type ModuleChangeType string
+
+const (
+	// ModuleAdded modules are only in the builder compared to.
+	ModuleAdded ModuleChangeType = "added"
+
+	// ModuleRemoved modules are only in the builder compared from.
+	ModuleRemoved ModuleChangeType = "removed"
+
+	// ModuleChanged modules are in both builders, with different versions.
+	ModuleChanged ModuleChangeType = "changed"
+)
+
+// ModuleChange is a buildpack or extension added, removed or with versions changed between two builders
+type ModuleChange struct {
+	ID     string           `json:"id"`
+	Change ModuleChangeType `json:"change"`
+	From   []string         `json:"from,omitempty"`
+	To     []string         `json:"to,omitempty"`
+}
+
+// RunImageChange is a run image added, removed or with mirrors changed between two builders
+type RunImageChange struct {
+	Image       string           `json:"image"`
+	Change      ModuleChangeType `json:"change"`
+	FromMirrors []string         `json:"fromMirrors,omitempty"`
+	ToMirrors   []string         `json:"toMirrors,omitempty"`
+}
+
+// VersionChange is a version that changed between two builders
+type VersionChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// OrderChange is the groups of the detection order of one builder that the other doesn't have, with the buildpacks of
+// each group in the form of <id>@<version>
+type OrderChange struct {
+	Removed []string `json:"removed,omitempty"`
+	Added   []string `json:"added,omitempty"`
+}
+
+// BuilderDiff is the differences between two builders, with nothing set for what didn't change.
+type BuilderDiff struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	Buildpacks []ModuleChange   `json:"buildpacks,omitempty"`
+	Extensions []ModuleChange   `json:"extensions,omitempty"`
+	Lifecycle  *VersionChange   `json:"lifecycle,omitempty"`
+	RunImages  []RunImageChange `json:"runImages,omitempty"`
+	Order      *OrderChange     `json:"order,omitempty"`
+}
+
+// Empty returns whether the builders are the same, as far as the diff goes
+func (d *BuilderDiff) Empty() bool {
+	return len(d.Buildpacks) == 0 && len(d.Extensions) == 0 && d.Lifecycle == nil && len(d.RunImages) == 0 && d.Order == nil
+}
+
+// DiffBuilders compares two builders, reporting the buildpacks and extensions added, removed or with versions changed,
+// the change of lifecycle version, the run images added, removed or with mirrors changed, and the groups of the
+// detection order that changed, such as to validate a builder upgrade.
+func (c *Client) DiffBuilders(opts DiffBuildersOptions) (*BuilderDiff, error) {
+	from, err := c.InspectBuilder(opts.From, opts.Daemon)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(opts.From))
+	}
+	if from == nil {
+		return nil, errors.Errorf("builder %s not found", style.Symbol(opts.From))
+	}
+	to, err := c.InspectBuilder(opts.To, opts.Daemon)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(opts.To))
+	}
+	if to == nil {
+		return nil, errors.Errorf("builder %s not found", style.Symbol(opts.To))
+	}
+
+	diff := &BuilderDiff{
+		From:       opts.From,
+		To:         opts.To,
+		Buildpacks: diffModules(from.Buildpacks, to.Buildpacks),
+		Extensions: diffModules(from.Extensions, to.Extensions),
+		RunImages:  diffRunImages(from.RunImages, to.RunImages),
+	}
+
+	fromLifecycle, toLifecycle := lifecycleVersion(from), lifecycleVersion(to)
+	if fromLifecycle != toLifecycle {
+		diff.Lifecycle = &VersionChange{From: fromLifecycle, To: toLifecycle}
+	}
+
+	removed, added := diffStrings(orderGroups(from.Order), orderGroups(to.Order))
+	if len(removed) > 0 || len(added) > 0 {
+		diff.Order = &OrderChange{Removed: removed, Added: added}
+	}
+	return diff, nil
+}
+
+// diffModules compares the versions of each module of two builders, sorted by ID
+func diffModules(from, to []dist.ModuleInfo) []ModuleChange {
+	versions := func(modules []dist.ModuleInfo) map[string][]string {
+		result := map[string][]string{}
+		for _, module := range modules {
+			result[module.ID] = append(result[module.ID], module.Version)
+		}
+		return result
+	}
+	fromVersions, toVersions := versions(from), versions(to)
+
+	var changes []ModuleChange
+	for _, id := range unionKeys(fromVersions, toVersions) {
+		fromVersion, toVersion := sorted(fromVersions[id]), sorted(toVersions[id])
+		switch {
+		case len(fromVersion) == 0:
+			changes = append(changes, ModuleChange{ID: id, Change: ModuleAdded, To: toVersion})
+		case len(toVersion) == 0:
+			changes = append(changes, ModuleChange{ID: id, Change: ModuleRemoved, From: fromVersion})
+		case strings.Join(fromVersion, ",") != strings.Join(toVersion, ","):
+			changes = append(changes, ModuleChange{ID: id, Change: ModuleChanged, From: fromVersion, To: toVersion})
+		}
+	}
+	return changes
+}
+
+// diffRunImages compares the run images of two builders, and the mirrors of the ones in both, sorted by image
+func diffRunImages(from, to []pubbldr.RunImageConfig) []RunImageChange {
+	mirrors := func(runImages []pubbldr.RunImageConfig) map[string][]string {
+		result := map[string][]string{}
+		for _, runImage := range runImages {
+			result[runImage.Image] = append(result[runImage.Image], runImage.Mirrors...)
+		}
+		return result
+	}
+	fromMirrors, toMirrors := mirrors(from), mirrors(to)
+
+	var changes []RunImageChange
+	for _, image := range unionKeys(fromMirrors, toMirrors) {
+		fromImageMirrors, inFrom := fromMirrors[image]
+		toImageMirrors, inTo := toMirrors[image]
+		switch {
+		case !inFrom:
+			changes = append(changes, RunImageChange{Image: image, Change: ModuleAdded, ToMirrors: toImageMirrors})
+		case !inTo:
+			changes = append(changes, RunImageChange{Image: image, Change: ModuleRemoved, FromMirrors: fromImageMirrors})
+		case strings.Join(sorted(fromImageMirrors), ",") != strings.Join(sorted(toImageMirrors), ","):
+			changes = append(changes, RunImageChange{Image: image, Change: ModuleChanged, FromMirrors: fromImageMirrors, ToMirrors: toImageMirrors})
+		}
+	}
+	return changes
+}
+
+// orderGroups returns the groups of a detection order, with the buildpacks of each group in the form of
+// <id>@<version>, marking the optional ones
+func orderGroups(order pubbldr.DetectionOrder) []string {
+	var groups []string
+	for _, entry := range order {
+		var refs []string
+		for _, ref := range entry.GroupDetectionOrder {
+			name := ref.FullName()
+			if ref.Optional {
+				name += " (optional)"
+			}
+			refs = append(refs, name)
+		}
+		groups = append(groups, strings.Join(refs, ", "))
+	}
+	return groups
+}
+
+func lifecycleVersion(info *BuilderInfo) string {
+	if info.Lifecycle.Info.Version == nil {
+		return ""
+	}
+	return info.Lifecycle.Info.Version.String()
+}
+
+// diffStrings returns the strings only in the first list, and the ones only in the second, keeping their order
+func diffStrings(from, to []string) (removed, added []string) {
+	contains := func(list []string, s string) bool {
+		for _, item := range list {
+			if item == s {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range from {
+		if !contains(to, s) {
+			removed = append(removed, s)
+		}
+	}
+	for _, s := range to {
+		if !contains(from, s) {
+			added = append(added, s)
+		}
+	}
+	return removed, added
+}
+
+func unionKeys(a, b map[string][]string) []string {
+	var keys []string
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sorted(list []string) []string {
+	result := append([]string(nil), list...)
+	sort.Strings(result)
+	return result
+}
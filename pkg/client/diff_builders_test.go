@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffBuilders(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffBuilders", testDiffBuilders, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffBuilders(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		out              bytes.Buffer
+	)
+
+	newBuilder := func(name, metadata, order string) *fakes.Image {
+		builderImage := fakes.NewImage(name, "", nil)
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", metadata))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", order))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", "{}"))
+		return builderImage
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: mockImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#DiffBuilders", func() {
+		it("reports the buildpacks, lifecycle, run images and order that changed", func() {
+			fromBuilder := newBuilder("some/builder", `{
+  "buildpacks": [{"id": "some/kept", "version": "1.0.0"}, {"id": "some/upgraded", "version": "1.0.0"}, {"id": "some/removed", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.19.0"},
+  "images": [{"image": "some/run", "mirrors": ["mirror.example.com/some/run"]}, {"image": "some/removed-run"}]
+}`, `[{"group": [{"id": "some/kept", "version": "1.0.0"}, {"id": "some/upgraded", "version": "1.0.0"}]}, {"group": [{"id": "some/removed", "version": "1.0.0"}]}]`)
+			toBuilder := newBuilder("some/builder:next", `{
+  "buildpacks": [{"id": "some/kept", "version": "1.0.0"}, {"id": "some/upgraded", "version": "2.0.0"}, {"id": "some/added", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.20.0"},
+  "images": [{"image": "some/run", "mirrors": ["other-mirror.example.com/some/run"]}]
+}`, `[{"group": [{"id": "some/kept", "version": "1.0.0"}, {"id": "some/upgraded", "version": "2.0.0"}, {"id": "some/added", "version": "1.0.0", "optional": true}]}]`)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(fromBuilder, nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder:next", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(toBuilder, nil)
+
+			diff, err := subject.DiffBuilders(DiffBuildersOptions{From: "some/builder", To: "some/builder:next", Daemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.Buildpacks, []ModuleChange{
+				{ID: "some/added", Change: ModuleAdded, To: []string{"1.0.0"}},
+				{ID: "some/removed", Change: ModuleRemoved, From: []string{"1.0.0"}},
+				{ID: "some/upgraded", Change: ModuleChanged, From: []string{"1.0.0"}, To: []string{"2.0.0"}},
+			})
+			h.AssertEq(t, len(diff.Extensions), 0)
+			h.AssertEq(t, diff.Lifecycle, &VersionChange{From: "0.19.0", To: "0.20.0"})
+			h.AssertEq(t, diff.RunImages, []RunImageChange{
+				{Image: "some/removed-run", Change: ModuleRemoved},
+				{Image: "some/run", Change: ModuleChanged, FromMirrors: []string{"mirror.example.com/some/run"}, ToMirrors: []string{"other-mirror.example.com/some/run"}},
+			})
+			h.AssertEq(t, diff.Order, &OrderChange{
+				Removed: []string{"some/kept@1.0.0, some/upgraded@1.0.0", "some/removed@1.0.0"},
+				Added:   []string{"some/kept@1.0.0, some/upgraded@2.0.0, some/added@1.0.0 (optional)"},
+			})
+			h.AssertFalse(t, diff.Empty())
+		})
+
+		it("reports no differences for the same builder", func() {
+			metadata := `{"buildpacks": [{"id": "some/buildpack", "version": "1.0.0"}], "lifecycle": {"version": "0.20.0"}, "images": [{"image": "some/run"}]}`
+			order := `[{"group": [{"id": "some/buildpack", "version": "1.0.0"}]}]`
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{PullPolicy: image.PullNever}).Return(newBuilder("some/builder", metadata, order), nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "registry.example.com/some/builder", image.FetchOptions{PullPolicy: image.PullNever}).Return(newBuilder("registry.example.com/some/builder", metadata, order), nil)
+
+			diff, err := subject.DiffBuilders(DiffBuildersOptions{From: "some/builder", To: "registry.example.com/some/builder"})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, diff.Empty())
+		})
+
+		it("fails when a builder isn't found", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", gomock.Any()).Return(nil, image.ErrNotFound)
+
+			_, err := subject.DiffBuilders(DiffBuildersOptions{From: "some/builder", To: "some/builder:next"})
+			h.AssertError(t, err, "builder 'some/builder' not found")
+		})
+	})
+}
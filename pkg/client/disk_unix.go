@@ -0,0 +1,17 @@
+//go:build linux || darwin
+
+package client
+
+import (
+	"syscall"
+)
+
+// diskFree returns the space available to unprivileged users in the file system holding the given path
+func diskFree(path string) (uint64, error) {
+	// the widths of the fields differ between platforms
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// diskFree returns the space available to the user in the volume holding the given path
+func diskFree(path string) (uint64, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
@@ -15,6 +15,7 @@ import (
 // DockerClient is the subset of CommonAPIClient which required by this package
 type DockerClient interface {
 	ImageHistory(ctx context.Context, image string) ([]image.HistoryResponseItem, error)
+	ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error)
 	ImageInspectWithRaw(ctx context.Context, image string) (types.ImageInspect, []byte, error)
 	ImageTag(ctx context.Context, image, ref string) error
 	ImageLoad(ctx context.Context, input io.Reader, quiet bool) (types.ImageLoadResponse, error)
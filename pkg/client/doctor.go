@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/dustin/go-humanize"
+
+	iconfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// The statuses of a check of the environment.
+const (
+	DoctorOK      = "ok"
+	DoctorWarning = "warning"
+	DoctorFailure = "failure"
+)
+
+// minFreeSpace is the free disk space below which the caches and temporary files of builds may not fit
+const minFreeSpace = 2 * 1024 * 1024 * 1024
+
+// DoctorCheck is the result of a check of the environment pack runs in.
+type DoctorCheck struct {
+	// Name of the check.
+	Name string `json:"name"`
+
+	// Status of the check, one of the Doctor statuses.
+	Status string `json:"status"`
+
+	// Message describing what the check found.
+	Message string `json:"message"`
+
+	// Fix for the problem the check found, for warnings and failures.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Doctor diagnoses the environment pack runs in: the daemon and the API version it serves, the permissions of its
+// socket, the disk space available for caches and temporary files, the experimental features enabled, the platform of
+// the daemon, and the credential helpers of the docker config.
+func (c *Client) Doctor(ctx context.Context) []DoctorCheck {
+	daemonCheck, daemonReachable := c.checkDaemon(ctx)
+	checks := []DoctorCheck{
+		daemonCheck,
+		checkDockerSocket(os.Getenv("DOCKER_HOST")),
+	}
+	if daemonReachable {
+		checks = append(checks, c.checkDaemonPlatform(ctx))
+	}
+
+	if packHome, err := iconfig.PackHome(); err == nil {
+		checks = append(checks, checkDiskSpace("pack home", packHome))
+	}
+	checks = append(checks,
+		checkDiskSpace("temporary directory", os.TempDir()),
+		c.checkExperimentalFeatures(),
+		checkCredentialHelpers(dockerconfig.Dir(), exec.LookPath),
+	)
+	return checks
+}
+
+// checkDaemon checks that the daemon is reachable and serves the API version pack requires
+func (c *Client) checkDaemon(ctx context.Context) (DoctorCheck, bool) {
+	check := DoctorCheck{Name: "docker daemon"}
+
+	version, err := c.docker.ServerVersion(ctx)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "permission denied"):
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("cannot connect to the daemon: %s", err)
+		check.Fix = "add your user to the group that owns the docker socket, such as with 'sudo usermod -aG docker $USER', then log in again"
+		return check, false
+	case err != nil:
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("cannot connect to the daemon: %s", err)
+		check.Fix = "start the docker daemon, or set DOCKER_HOST to a daemon that is running"
+		return check, false
+	case versions.LessThan(version.APIVersion, DockerAPIVersion):
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("Docker %s serves API %s, older than API %s pack requires", version.Version, version.APIVersion, DockerAPIVersion)
+		check.Fix = "upgrade the docker daemon"
+		return check, true
+	default:
+		check.Status = DoctorOK
+		check.Message = fmt.Sprintf("Docker %s, API %s", version.Version, version.APIVersion)
+		return check, true
+	}
+}
+
+// checkDockerSocket checks that the socket of the daemon at the given host can be connected to, when the daemon is
+// reached through a local socket
+func checkDockerSocket(dockerHost string) DoctorCheck {
+	check := DoctorCheck{Name: "docker socket"}
+
+	if dockerHost == "" && runtime.GOOS != "windows" {
+		dockerHost = "unix:///var/run/docker.sock"
+	}
+	socket, ok := strings.CutPrefix(dockerHost, "unix://")
+	if !ok {
+		check.Status = DoctorOK
+		check.Message = fmt.Sprintf("the daemon is not reached through a unix socket (%s)", orNone(dockerHost))
+		return check
+	}
+
+	if _, err := os.Stat(socket); err != nil {
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("socket %s does not exist", style.Symbol(socket))
+		check.Fix = "start the docker daemon, or set DOCKER_HOST to the socket of a daemon that is running"
+		return check
+	}
+
+	conn, err := net.DialTimeout("unix", socket, 5*time.Second)
+	if err != nil {
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("cannot connect to socket %s: %s", style.Symbol(socket), err)
+		if os.IsPermission(err) {
+			check.Fix = "add your user to the group that owns the socket, such as with 'sudo usermod -aG docker $USER', then log in again"
+		} else {
+			check.Fix = "restart the docker daemon"
+		}
+		return check
+	}
+	conn.Close()
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("socket %s is accessible", style.Symbol(socket))
+	return check
+}
+
+// checkDaemonPlatform checks that the daemon runs on the architecture pack runs on, as builds use images for the
+// platform of the daemon by default
+func (c *Client) checkDaemonPlatform(ctx context.Context) DoctorCheck {
+	check := DoctorCheck{Name: "platform"}
+
+	info, err := c.docker.Info(ctx)
+	if err != nil {
+		check.Status = DoctorWarning
+		check.Message = fmt.Sprintf("cannot read the platform of the daemon: %s", err)
+		return check
+	}
+
+	daemonArch := normalizeArch(info.Architecture)
+	if daemonArch != runtime.GOARCH {
+		check.Status = DoctorWarning
+		check.Message = fmt.Sprintf("pack runs on %s, but the daemon runs on %s/%s", runtime.GOARCH, info.OSType, daemonArch)
+		check.Fix = fmt.Sprintf("builds use %s/%s images by default, pass --platform to build for another platform, which needs emulation in the daemon", info.OSType, daemonArch)
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("the daemon runs on %s/%s", info.OSType, daemonArch)
+	return check
+}
+
+// normalizeArch returns the name Go uses for an architecture the daemon reports
+func normalizeArch(arch string) string {
+	switch arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return arch
+	}
+}
+
+// checkDiskSpace checks that the disk holding the given directory has space for the caches and temporary files of
+// builds, the closest existing parent directory is checked when the directory does not exist yet
+func checkDiskSpace(name, dir string) DoctorCheck {
+	check := DoctorCheck{Name: fmt.Sprintf("disk space (%s)", name)}
+
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	free, err := diskFree(dir)
+	if err != nil {
+		check.Status = DoctorWarning
+		check.Message = fmt.Sprintf("cannot read the free space of %s: %s", style.Symbol(dir), err)
+		return check
+	}
+
+	if free < minFreeSpace {
+		check.Status = DoctorWarning
+		check.Message = fmt.Sprintf("%s free in %s", humanize.IBytes(free), style.Symbol(dir))
+		check.Fix = fmt.Sprintf("free up space, at least %s, such as with 'pack gc' and 'docker system prune'", humanize.IBytes(minFreeSpace))
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("%s free in %s", humanize.IBytes(free), style.Symbol(dir))
+	return check
+}
+
+// checkExperimentalFeatures reports the experimental features enabled in the config
+func (c *Client) checkExperimentalFeatures() DoctorCheck {
+	check := DoctorCheck{Name: "experimental features", Status: DoctorOK}
+
+	var features []string
+	for feature, enabled := range c.experimentalFeatures {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+
+	switch {
+	case c.experimental:
+		check.Message = "all experimental features are enabled"
+	case len(features) > 0:
+		check.Message = fmt.Sprintf("enabled: %s", strings.Join(features, ", "))
+	default:
+		check.Message = "no experimental features are enabled, enable them with 'pack config experimental'"
+	}
+	return check
+}
+
+// checkCredentialHelpers checks that the credential helpers of the docker config in the given directory are installed,
+// as pulling and publishing images to the registries they serve fails otherwise
+func checkCredentialHelpers(configDir string, lookPath func(string) (string, error)) DoctorCheck {
+	check := DoctorCheck{Name: "credential helpers"}
+
+	configFile, err := dockerconfig.Load(configDir)
+	if err != nil {
+		check.Status = DoctorWarning
+		check.Message = fmt.Sprintf("cannot read the docker config in %s: %s", style.Symbol(configDir), err)
+		return check
+	}
+
+	helpers := map[string]bool{}
+	if configFile.CredentialsStore != "" {
+		helpers[configFile.CredentialsStore] = true
+	}
+	for _, helper := range configFile.CredentialHelpers {
+		helpers[helper] = true
+	}
+	if len(helpers) == 0 {
+		check.Status = DoctorOK
+		check.Message = "no credential helpers are configured"
+		return check
+	}
+
+	var found, missing []string
+	for helper := range helpers {
+		if _, err := lookPath("docker-credential-" + helper); err != nil {
+			missing = append(missing, "docker-credential-"+helper)
+		} else {
+			found = append(found, "docker-credential-"+helper)
+		}
+	}
+	sort.Strings(found)
+	sort.Strings(missing)
+
+	if len(missing) > 0 {
+		check.Status = DoctorFailure
+		check.Message = fmt.Sprintf("not found in PATH: %s", strings.Join(missing, ", "))
+		check.Fix = fmt.Sprintf("install the credential helpers, or remove them from %s", style.Symbol(filepath.Join(configDir, dockerconfig.ConfigFileName)))
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("found: %s", strings.Join(found, ", "))
+	return check
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
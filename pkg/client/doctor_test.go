@@ -0,0 +1,176 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDoctor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Doctor", testDoctor, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDoctor(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		outBuf         bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker: mockDocker,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#checkDaemon", func() {
+		it("reports the version of the daemon", func() {
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Version: "24.0.7", APIVersion: "1.43"}, nil)
+
+			check, reachable := subject.checkDaemon(context.TODO())
+			h.AssertEq(t, reachable, true)
+			h.AssertEq(t, check, DoctorCheck{Name: "docker daemon", Status: DoctorOK, Message: "Docker 24.0.7, API 1.43"})
+		})
+
+		it("fails when the daemon serves an older API", func() {
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Version: "17.05", APIVersion: "1.29"}, nil)
+
+			check, _ := subject.checkDaemon(context.TODO())
+			h.AssertEq(t, check.Status, DoctorFailure)
+			h.AssertEq(t, check.Message, "Docker 17.05 serves API 1.29, older than API 1.38 pack requires")
+			h.AssertEq(t, check.Fix, "upgrade the docker daemon")
+		})
+
+		it("suggests a fix for the permissions of the socket", func() {
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{}, errors.New("dial unix /var/run/docker.sock: connect: permission denied"))
+
+			check, reachable := subject.checkDaemon(context.TODO())
+			h.AssertEq(t, reachable, false)
+			h.AssertEq(t, check.Status, DoctorFailure)
+			h.AssertContains(t, check.Fix, "usermod -aG docker")
+		})
+	})
+
+	when("#checkDaemonPlatform", func() {
+		it("warns when the daemon runs on another architecture", func() {
+			otherArch := "x86_64"
+			if runtime.GOARCH == "amd64" {
+				otherArch = "aarch64"
+			}
+			mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: otherArch}, nil)
+
+			check := subject.checkDaemonPlatform(context.TODO())
+			h.AssertEq(t, check.Status, DoctorWarning)
+			h.AssertContains(t, check.Message, "but the daemon runs on linux/"+normalizeArch(otherArch))
+			h.AssertContains(t, check.Fix, "--platform")
+		})
+
+		it("reports the platform of the daemon", func() {
+			mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: runtime.GOARCH}, nil)
+
+			check := subject.checkDaemonPlatform(context.TODO())
+			h.AssertEq(t, check.Status, DoctorOK)
+		})
+	})
+
+	when("#checkDockerSocket", func() {
+		it.Before(func() {
+			h.SkipIf(t, runtime.GOOS == "windows", "unix sockets are not checked on Windows")
+		})
+
+		it("reports the socket is accessible", func() {
+			socket := filepath.Join(t.TempDir(), "docker.sock")
+			listener, err := net.Listen("unix", socket)
+			h.AssertNil(t, err)
+			defer listener.Close()
+
+			check := checkDockerSocket("unix://" + socket)
+			h.AssertEq(t, check.Status, DoctorOK)
+		})
+
+		it("fails when the socket does not exist", func() {
+			check := checkDockerSocket("unix://" + filepath.Join(t.TempDir(), "docker.sock"))
+			h.AssertEq(t, check.Status, DoctorFailure)
+			h.AssertContains(t, check.Message, "does not exist")
+		})
+
+		it("skips daemons that are not reached through a unix socket", func() {
+			check := checkDockerSocket("tcp://some-host:2376")
+			h.AssertEq(t, check.Status, DoctorOK)
+			h.AssertEq(t, check.Message, "the daemon is not reached through a unix socket (tcp://some-host:2376)")
+		})
+	})
+
+	when("#checkCredentialHelpers", func() {
+		var configDir string
+
+		it.Before(func() {
+			configDir = t.TempDir()
+			h.AssertNil(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{
+  "credsStore": "desktop",
+  "credHelpers": {"gcr.io": "gcloud", "some-registry.io": "desktop"}
+}`), 0600))
+		})
+
+		it("fails when credential helpers are not installed", func() {
+			check := checkCredentialHelpers(configDir, func(file string) (string, error) {
+				if file == "docker-credential-desktop" {
+					return "/usr/local/bin/" + file, nil
+				}
+				return "", exec.ErrNotFound
+			})
+
+			h.AssertEq(t, check.Status, DoctorFailure)
+			h.AssertEq(t, check.Message, "not found in PATH: docker-credential-gcloud")
+			h.AssertContains(t, check.Fix, filepath.Join(configDir, "config.json"))
+		})
+
+		it("reports the credential helpers found", func() {
+			check := checkCredentialHelpers(configDir, func(file string) (string, error) {
+				return "/usr/local/bin/" + file, nil
+			})
+
+			h.AssertEq(t, check, DoctorCheck{
+				Name:    "credential helpers",
+				Status:  DoctorOK,
+				Message: "found: docker-credential-desktop, docker-credential-gcloud",
+			})
+		})
+	})
+
+	when("#checkExperimentalFeatures", func() {
+		it("lists the experimental features enabled", func() {
+			WithExperimentalFeatures("manifest", "lint")(subject)
+
+			check := subject.checkExperimentalFeatures()
+			h.AssertEq(t, check.Message, "enabled: lint, manifest")
+		})
+	})
+}
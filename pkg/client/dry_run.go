@@ -0,0 +1,68 @@
+package client
+
+import (
+	"io"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/dustin/go-humanize"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+)
+
+// blobDigest returns the digest and the size of the contents of the blob
+func blobDigest(b blob.Blob) (digest.Digest, int64, error) {
+	rc, err := b.Open()
+	if err != nil {
+		return "", 0, err
+	}
+	defer rc.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(digester.Hash(), rc)
+	if err != nil {
+		return "", 0, err
+	}
+	return digester.Digest(), size, nil
+}
+
+// logDryRunBlob logs a blob a dry run resolved, with its digest and size
+func (c *Client) logDryRunBlob(kind, name string, b blob.Blob) error {
+	d, size, err := blobDigest(b)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s %s", kind, style.Symbol(name))
+	}
+	c.logger.Infof("  %s %s (%s, %s)", kind, style.Symbol(name), d, humanize.Bytes(uint64(size)))
+	return nil
+}
+
+// logDryRunModules logs the modules a dry run resolved, with their digests and sizes
+func (c *Client) logDryRunModules(kind string, modules []buildpack.BuildModule) error {
+	for _, module := range modules {
+		if err := c.logDryRunBlob(kind, module.Descriptor().Info().FullName(), module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logDryRunImage logs an image a dry run resolved, with its identifier
+func (c *Client) logDryRunImage(kind string, img imgutil.Image) error {
+	id, err := img.Identifier()
+	if err != nil {
+		return errors.Wrapf(err, "getting identifier of %s %s", kind, style.Symbol(img.Name()))
+	}
+	c.logger.Infof("  %s %s (%s)", kind, style.Symbol(img.Name()), id)
+	return nil
+}
+
+// dryRunDestination returns where a dry run would have saved an image
+func dryRunDestination(publish bool) string {
+	if publish {
+		return "pushed to the registry"
+	}
+	return "saved to the daemon"
+}
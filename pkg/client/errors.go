@@ -2,11 +2,23 @@ package client
 
 // ExperimentError denotes that an experimental feature was trying to be used without experimental features enabled.
 type ExperimentError struct {
-	msg string
+	msg     string
+	feature string
 }
 
 func NewExperimentError(msg string) ExperimentError {
-	return ExperimentError{msg}
+	return ExperimentError{msg: msg}
+}
+
+// NewFeatureExperimentError denotes that the experimental feature of the name was trying to be used without being
+// enabled.
+func NewFeatureExperimentError(feature, msg string) ExperimentError {
+	return ExperimentError{msg: msg, feature: feature}
+}
+
+// Feature returns the name of the experimental feature to enable, if the error is about a single one.
+func (ee ExperimentError) Feature() string {
+	return ee.feature
 }
 
 func (ee ExperimentError) Error() string {
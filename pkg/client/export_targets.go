@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// reportExportTargets logs the digest of the published image in every registry it was exported to, and returns an
+// error if any of them could not be read or holds a different image
+func (c *Client) reportExportTargets(ctx context.Context, imageRef name.Reference, opts BuildOptions) error {
+	targets := append([]string{imageRef.Name()}, opts.AdditionalTags...)
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	var (
+		results  = make([]string, len(targets))
+		expected string
+		failed   int
+	)
+	for i, target := range targets {
+		digest, err := remoteDigest(target, remoteOpts...)
+		switch {
+		case err != nil:
+			results[i] = fmt.Sprintf("error: %s", err)
+			failed++
+		case expected != "" && digest != expected:
+			results[i] = fmt.Sprintf("%s (expected %s)", digest, expected)
+			failed++
+		default:
+			expected = digest
+			results[i] = digest
+		}
+	}
+
+	c.logger.Infof("Exported image to %d targets:", len(targets))
+	tw := tabwriter.NewWriter(c.logger.Writer(), 0, 0, 3, ' ', 0)
+	for i, target := range targets {
+		fmt.Fprintf(tw, "  %s\t%s\n", target, results[i])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to export %s to %d of %d targets", style.Symbol(imageRef.Name()), failed, len(targets))
+	}
+	return nil
+}
+
+func remoteDigest(imageName string, opts ...ggcrremote.Option) (string, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	desc, err := ggcrremote.Head(ref, opts...)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExportTargets(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExportTargets", testExportTargets, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExportTargets(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject                *Client
+		registryA, registryB   *httptest.Server
+		imageRef               name.Reference
+		tagInA, tagInB, digest string
+		outBuf                 bytes.Buffer
+	)
+
+	newRegistry := func() *httptest.Server {
+		return httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	}
+	registryHost := func(server *httptest.Server) string {
+		return strings.TrimPrefix(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1), "http://")
+	}
+
+	it.Before(func() {
+		registryA, registryB = newRegistry(), newRegistry()
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		hash, err := img.Digest()
+		h.AssertNil(t, err)
+		digest = hash.String()
+
+		imageRef, err = name.ParseReference(registryHost(registryA)+"/some/app:latest", name.WeakValidation)
+		h.AssertNil(t, err)
+		tagInA = registryHost(registryA) + "/some/app:v1"
+		tagInB = registryHost(registryB) + "/mirror/app:latest"
+		for _, target := range []string{imageRef.Name(), tagInA, tagInB} {
+			ref, err := name.ParseReference(target, name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.Write(ref, img))
+		}
+	})
+
+	it.After(func() {
+		registryA.Close()
+		registryB.Close()
+	})
+
+	when("#reportExportTargets", func() {
+		it("reports the digest of every target", func() {
+			err := subject.reportExportTargets(context.TODO(), imageRef, BuildOptions{AdditionalTags: []string{tagInA, tagInB}})
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, outBuf.String(), "Exported image to 3 targets:")
+			for _, target := range []string{imageRef.Name(), tagInA, tagInB} {
+				h.AssertContainsMatch(t, outBuf.String(), regexp.QuoteMeta(target)+` +`+regexp.QuoteMeta(digest))
+			}
+		})
+
+		it("fails when a target is missing", func() {
+			missing := registryHost(registryB) + "/missing/app:latest"
+
+			err := subject.reportExportTargets(context.TODO(), imageRef, BuildOptions{AdditionalTags: []string{tagInB, missing}})
+			h.AssertError(t, err, "failed to export '"+imageRef.Name()+"' to 1 of 3 targets")
+			h.AssertContainsMatch(t, outBuf.String(), regexp.QuoteMeta(missing)+` +error: `)
+		})
+
+		it("fails when a target holds a different image", func() {
+			other, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			ref, err := name.ParseReference(tagInB, name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.Write(ref, other))
+
+			err = subject.reportExportTargets(context.TODO(), imageRef, BuildOptions{AdditionalTags: []string{tagInB}})
+			h.AssertError(t, err, "failed to export '"+imageRef.Name()+"' to 1 of 2 targets")
+			h.AssertContains(t, outBuf.String(), "(expected "+digest+")")
+		})
+	})
+}
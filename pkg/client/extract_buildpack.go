@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ExtractBuildpackOptions define the buildpack to extract from a builder, and where to save it.
+type ExtractBuildpackOptions struct {
+	// Name of the builder to extract the buildpack from.
+	BuilderName string
+
+	// Buildpack to extract, in the form of <id>@<version>, or <id> when the builder has a single version of it.
+	Buildpack string
+
+	// Name of the buildpackage to save the buildpack as.
+	Name string
+
+	// Type of output format, one of FormatImage (the default), FormatFile or FormatOCILayout.
+	Format string
+
+	// Publish the buildpackage to the registry specified in Name, rather than saving it to the daemon.
+	Publish bool
+
+	// Strategy for updating the builder before extracting the buildpack.
+	PullPolicy image.PullPolicy
+}
+
+// ExtractBuildpack re-packages a buildpack of a builder as a buildpackage of its own, along with the buildpacks in its
+// order, such as to fork or pin the buildpacks of a builder. The builder is read from the daemon when the buildpackage
+// is saved there, and from the registry otherwise.
+func (c *Client) ExtractBuildpack(ctx context.Context, opts ExtractBuildpackOptions) error {
+	if opts.Format == "" {
+		opts.Format = FormatImage
+	}
+
+	builderImage, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: SavesToDaemon(opts.Format, opts.Publish), PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return errors.Wrapf(err, "fetching builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	id, version, _ := strings.Cut(opts.Buildpack, "@")
+	mainBP, depBPs, err := buildpack.ExtractBuildpack(builderImage, id, version)
+	if err != nil {
+		return errors.Wrapf(err, "extracting buildpack %s from builder %s", style.Symbol(opts.Buildpack), style.Symbol(opts.BuilderName))
+	}
+
+	os, err := builderImage.OS()
+	if err != nil {
+		return errors.Wrap(err, "getting builder OS")
+	}
+	arch, err := builderImage.Architecture()
+	if err != nil {
+		return errors.Wrap(err, "getting builder architecture")
+	}
+	target := dist.Target{OS: os, Arch: arch}
+
+	packageBuilder := buildpack.NewBuilder(c.imageFactory)
+	packageBuilder.SetBuildpack(mainBP)
+	for _, dep := range depBPs {
+		packageBuilder.AddDependency(dep)
+	}
+
+	c.logger.Debugf("Extracting buildpack %s with %d buildpacks of its order from builder %s", style.Symbol(mainBP.Descriptor().Info().FullName()), len(depBPs), style.Symbol(opts.BuilderName))
+	switch opts.Format {
+	case FormatFile:
+		return packageBuilder.SaveAsFile(opts.Name, target, map[string]string{})
+	case FormatOCILayout:
+		return packageBuilder.SaveAsLayout(opts.Name, target, map[string]string{})
+	case FormatImage:
+		err = c.observePush(ctx, "buildpack", opts.Publish, func() error {
+			_, err := packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, map[string]string{})
+			return err
+		})
+		return errors.Wrapf(err, "saving image")
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtractBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtractBuildpack", testExtractBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtractBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		builderImage     *fakes.Image
+		tmpDir           string
+		outBuf           bytes.Buffer
+
+		metaDescriptor = dist.BuildpackDescriptor{
+			WithAPI:  api.MustParse("0.3"),
+			WithInfo: dist.ModuleInfo{ID: "some/meta", Version: "1.0.0"},
+			WithOrder: dist.Order{{Group: []dist.ModuleRef{
+				{ModuleInfo: dist.ModuleInfo{ID: "some/child", Version: "2.0.0"}},
+			}}},
+		}
+		childDescriptor = dist.BuildpackDescriptor{
+			WithAPI:    api.MustParse("0.3"),
+			WithInfo:   dist.ModuleInfo{ID: "some/child", Version: "2.0.0"},
+			WithStacks: []dist.Stack{{ID: "*"}},
+		}
+		otherDescriptor = dist.BuildpackDescriptor{
+			WithAPI:    api.MustParse("0.3"),
+			WithInfo:   dist.ModuleInfo{ID: "some/other", Version: "3.0.0"},
+			WithStacks: []dist.Stack{{ID: "*"}},
+		}
+	)
+
+	// addBuildpacks adds a layer holding the buildpacks to the builder, as flattened builders do for several of them
+	addBuildpacks := func(layers dist.ModuleLayers, descriptors ...dist.BuildpackDescriptor) {
+		module, err := ifakes.NewFakeBuildpack(descriptors[0], 0644)
+		h.AssertNil(t, err)
+		layerPath, err := buildpack.ToLayerTar(tmpDir, module)
+		h.AssertNil(t, err)
+		h.AssertNil(t, builderImage.AddLayer(layerPath))
+		diffID, err := dist.LayerDiffID(layerPath)
+		h.AssertNil(t, err)
+		for _, descriptor := range descriptors {
+			dist.AddToLayersMD(layers, &descriptor, diffID.String())
+		}
+	}
+
+	setLayers := func(layers dist.ModuleLayers) {
+		contents, err := json.Marshal(layers)
+		h.AssertNil(t, err)
+		h.AssertNil(t, builderImage.SetLabel(dist.BuildpackLayersLabel, string(contents)))
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		tmpDir = t.TempDir()
+
+		builderImage = fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, builderImage.SetOS("linux"))
+		h.AssertNil(t, builderImage.SetArchitecture("amd64"))
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher: mockImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ExtractBuildpack", func() {
+		it("packages the buildpack with the buildpacks of its order", func() {
+			layers := dist.ModuleLayers{}
+			addBuildpacks(layers, metaDescriptor)
+			addBuildpacks(layers, childDescriptor)
+			addBuildpacks(layers, otherDescriptor)
+			setLayers(layers)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{PullPolicy: image.PullAlways}).Return(builderImage, nil)
+
+			packagePath := filepath.Join(tmpDir, "some-meta.cnb")
+			h.AssertNil(t, subject.ExtractBuildpack(context.TODO(), ExtractBuildpackOptions{
+				BuilderName: "some/builder",
+				Buildpack:   "some/meta",
+				Name:        packagePath,
+				Format:      FormatFile,
+				PullPolicy:  image.PullAlways,
+			}))
+
+			mainBP, depBPs, err := buildpack.BuildpacksFromOCILayoutBlob(blob.NewBlob(packagePath))
+			h.AssertNil(t, err)
+			h.AssertBuildpacksHaveDescriptors(t, append([]buildpack.BuildModule{mainBP}, depBPs...), []dist.BuildpackDescriptor{metaDescriptor, childDescriptor})
+		})
+
+		it("fails for buildpacks the builder doesn't have", func() {
+			layers := dist.ModuleLayers{}
+			addBuildpacks(layers, childDescriptor)
+			setLayers(layers)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", gomock.Any()).Return(builderImage, nil)
+
+			err := subject.ExtractBuildpack(context.TODO(), ExtractBuildpackOptions{
+				BuilderName: "some/builder",
+				Buildpack:   "some/child@9.9.9",
+				Name:        filepath.Join(tmpDir, "some-child.cnb"),
+				Format:      FormatFile,
+			})
+			h.AssertError(t, err, "image has no buildpack 'some/child@9.9.9'")
+		})
+
+		it("fails for buildpacks flattened with other buildpacks", func() {
+			layers := dist.ModuleLayers{}
+			addBuildpacks(layers, childDescriptor, otherDescriptor)
+			setLayers(layers)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", gomock.Any()).Return(builderImage, nil)
+
+			err := subject.ExtractBuildpack(context.TODO(), ExtractBuildpackOptions{
+				BuilderName: "some/builder",
+				Buildpack:   "some/child@2.0.0",
+				Name:        filepath.Join(tmpDir, "some-child.cnb"),
+				Format:      FormatFile,
+			})
+			h.AssertError(t, err, "buildpack 'some/child@2.0.0' is flattened with other buildpacks into a single layer")
+		})
+	})
+}
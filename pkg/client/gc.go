@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	dockerimage "github.com/docker/docker/api/types/image"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/lock"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// The kinds of intermediate images pack creates in the daemon.
+const (
+	IntermediateBuilder   = "builder"
+	IntermediateLifecycle = "lifecycle"
+	IntermediateRunImage  = "run-image"
+	IntermediateStackBase = "stack-base"
+)
+
+// IntermediateImage is an image pack created in the daemon for the duration of a command, such as the ephemeral
+// builder of a build.
+type IntermediateImage struct {
+	// Name of the image in the daemon.
+	Name string `json:"name"`
+
+	// Kind of the image, one of the Intermediate kinds.
+	Kind string `json:"kind"`
+
+	// PID of the pack process that created the image.
+	PID int `json:"pid"`
+
+	// Time the image was created at.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GCOptions define the intermediate images to collect.
+type GCOptions struct {
+	// List the intermediate images that would be removed, without removing them.
+	DryRun bool
+}
+
+// WithIntermediateImagesFile tracks the intermediate images pack creates in the daemon in the given state file, for the
+// images that a command could not remove, such as when it was interrupted, to be removed later by GC.
+func WithIntermediateImagesFile(path string) Option {
+	return func(c *Client) {
+		c.intermediateImagesFile = path
+	}
+}
+
+// GC removes the intermediate images pack created in the daemon and could not remove, such as the ephemeral builders
+// of interrupted builds. Images of pack processes that are still running, this one included, are left for these
+// processes to remove.
+func (c *Client) GC(ctx context.Context, opts GCOptions) ([]IntermediateImage, error) {
+	images, err := c.readIntermediateImages()
+	if err != nil {
+		return nil, err
+	}
+
+	var collected []IntermediateImage
+	for _, img := range images {
+		if processRunning(img.PID) {
+			continue
+		}
+		if !opts.DryRun {
+			if err := c.removeIntermediateImage(ctx, img.Name); err != nil {
+				return collected, err
+			}
+		}
+		collected = append(collected, img)
+	}
+	return collected, nil
+}
+
+// collectIntermediateImages removes the intermediate images of earlier pack processes, warning about the images that
+// could not be removed rather than failing the command
+func (c *Client) collectIntermediateImages(ctx context.Context) {
+	if c.intermediateImagesFile == "" {
+		return
+	}
+
+	collected, err := c.GC(ctx, GCOptions{})
+	for _, img := range collected {
+		c.logger.Debugf("Removed intermediate %s image %s", img.Kind, style.Symbol(img.Name))
+	}
+	if err != nil {
+		c.logger.Warnf("Failed to remove intermediate images: %s", err)
+	}
+}
+
+// trackIntermediateImage records an intermediate image before it is created, for GC to remove it if the command does not
+func (c *Client) trackIntermediateImage(name, kind string) {
+	if c.intermediateImagesFile == "" {
+		return
+	}
+
+	err := c.updateIntermediateImages(func(images []IntermediateImage) []IntermediateImage {
+		return append(images, IntermediateImage{Name: name, Kind: kind, PID: os.Getpid(), CreatedAt: time.Now().UTC()})
+	})
+	if err != nil {
+		c.logger.Warnf("Failed to track intermediate image %s: %s", style.Symbol(name), err)
+	}
+}
+
+// removeIntermediateImage removes an intermediate image from the daemon, and stops tracking it once it is gone
+func (c *Client) removeIntermediateImage(ctx context.Context, name string) error {
+	if _, err := c.docker.ImageRemove(ctx, name, dockerimage.RemoveOptions{Force: true}); err != nil && !dockerClient.IsErrNotFound(err) {
+		return errors.Wrapf(err, "removing intermediate image %s", style.Symbol(name))
+	}
+
+	if c.intermediateImagesFile == "" {
+		return nil
+	}
+	return c.updateIntermediateImages(func(images []IntermediateImage) []IntermediateImage {
+		var remaining []IntermediateImage
+		for _, img := range images {
+			if img.Name != name {
+				remaining = append(remaining, img)
+			}
+		}
+		return remaining
+	})
+}
+
+func (c *Client) readIntermediateImages() ([]IntermediateImage, error) {
+	if c.intermediateImagesFile == "" {
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(c.intermediateImagesFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading intermediate images")
+	}
+
+	var images []IntermediateImage
+	if err := json.Unmarshal(contents, &images); err != nil {
+		return nil, errors.Wrapf(err, "parsing intermediate images file %s", style.Symbol(c.intermediateImagesFile))
+	}
+	return images, nil
+}
+
+// updateIntermediateImages rewrites the intermediate images file, replacing it rather than writing it in place so that
+// other pack processes never read a partial file, and locking it for updates running at once not to lose each other's
+// changes
+func (c *Client) updateIntermediateImages(update func([]IntermediateImage) []IntermediateImage) error {
+	fileLock, err := lock.Acquire(context.Background(), c.intermediateImagesFile+".lock", nil)
+	if err != nil {
+		return errors.Wrap(err, "locking intermediate images")
+	}
+	defer fileLock.Release()
+
+	images, err := c.readIntermediateImages()
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(update(images), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling intermediate images")
+	}
+
+	dir := filepath.Dir(c.intermediateImagesFile)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(err, "creating intermediate images directory")
+	}
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(c.intermediateImagesFile))
+	if err != nil {
+		return errors.Wrap(err, "writing intermediate images")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(contents); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "writing intermediate images")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "writing intermediate images")
+	}
+	return os.Rename(tmpFile.Name(), c.intermediateImagesFile)
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestGC(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "GC", testGC, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testGC(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		stateFile      string
+		outBuf         bytes.Buffer
+	)
+
+	// no process has a PID above the maximum PID of Linux
+	const exitedPID = 1<<22 + 1
+
+	writeState := func(images ...IntermediateImage) {
+		contents, err := json.Marshal(images)
+		h.AssertNil(t, err)
+		h.AssertNil(t, os.WriteFile(stateFile, contents, 0600))
+	}
+
+	readState := func() []IntermediateImage {
+		images, err := subject.readIntermediateImages()
+		h.AssertNil(t, err)
+		return images
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		stateFile = filepath.Join(t.TempDir(), "intermediate-images.json")
+
+		subject = &Client{
+			logger:                 logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker:                 mockDocker,
+			intermediateImagesFile: stateFile,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#GC", func() {
+		it("removes the intermediate images of pack processes that are no longer running", func() {
+			leftover := IntermediateImage{Name: "pack.local/builder/left:latest", Kind: IntermediateBuilder, PID: exitedPID, CreatedAt: time.Now().UTC()}
+			inUse := IntermediateImage{Name: "pack.local/builder/in-use:latest", Kind: IntermediateBuilder, PID: os.Getpid(), CreatedAt: time.Now().UTC()}
+			writeState(leftover, inUse)
+
+			mockDocker.EXPECT().ImageRemove(gomock.Any(), leftover.Name, image.RemoveOptions{Force: true}).Return(nil, nil)
+
+			collected, err := subject.GC(context.TODO(), GCOptions{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(collected), 1)
+			h.AssertEq(t, collected[0].Name, leftover.Name)
+			h.AssertEq(t, len(readState()), 1)
+			h.AssertEq(t, readState()[0].Name, inUse.Name)
+		})
+
+		it("stops tracking the images that are already gone", func() {
+			writeState(IntermediateImage{Name: "pack.local/lifecycle/gone:latest", Kind: IntermediateLifecycle, PID: exitedPID})
+
+			mockDocker.EXPECT().ImageRemove(gomock.Any(), "pack.local/lifecycle/gone:latest", gomock.Any()).Return(nil, errdefs.NotFound(errors.New("no such image")))
+
+			collected, err := subject.GC(context.TODO(), GCOptions{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(collected), 1)
+			h.AssertEq(t, len(readState()), 0)
+		})
+
+		it("keeps tracking the images that could not be removed", func() {
+			writeState(IntermediateImage{Name: "pack.local/run-image/busy:latest", Kind: IntermediateRunImage, PID: exitedPID})
+
+			mockDocker.EXPECT().ImageRemove(gomock.Any(), "pack.local/run-image/busy:latest", gomock.Any()).Return(nil, errors.New("image is in use"))
+
+			_, err := subject.GC(context.TODO(), GCOptions{})
+			h.AssertError(t, err, "removing intermediate image 'pack.local/run-image/busy:latest': image is in use")
+			h.AssertEq(t, len(readState()), 1)
+		})
+
+		when("dry run", func() {
+			it("lists the images without removing them", func() {
+				writeState(IntermediateImage{Name: "pack.local/builder/left:latest", Kind: IntermediateBuilder, PID: exitedPID})
+
+				collected, err := subject.GC(context.TODO(), GCOptions{DryRun: true})
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(collected), 1)
+				h.AssertEq(t, len(readState()), 1)
+			})
+		})
+
+		when("no intermediate images file is configured", func() {
+			it("removes nothing", func() {
+				subject.intermediateImagesFile = ""
+
+				collected, err := subject.GC(context.TODO(), GCOptions{})
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(collected), 0)
+			})
+		})
+	})
+
+	when("#trackIntermediateImage", func() {
+		it("records the image with the PID of this process, until it is removed", func() {
+			subject.trackIntermediateImage("pack.local/builder/some:latest", IntermediateBuilder)
+
+			images := readState()
+			h.AssertEq(t, len(images), 1)
+			h.AssertEq(t, images[0].Name, "pack.local/builder/some:latest")
+			h.AssertEq(t, images[0].Kind, IntermediateBuilder)
+			h.AssertEq(t, images[0].PID, os.Getpid())
+
+			mockDocker.EXPECT().ImageRemove(gomock.Any(), "pack.local/builder/some:latest", gomock.Any()).Return(nil, nil)
+			h.AssertNil(t, subject.removeIntermediateImage(context.TODO(), "pack.local/builder/some:latest"))
+			h.AssertEq(t, len(readState()), 0)
+		})
+
+		it("records every image of builds running at once", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					subject.trackIntermediateImage(fmt.Sprintf("pack.local/builder/%d:latest", i), IntermediateBuilder)
+				}(i)
+			}
+			wg.Wait()
+
+			h.AssertEq(t, len(readState()), 50)
+		})
+	})
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// The origins of the layers of an app image.
+const (
+	LayerOriginRunImage     = "run-image"
+	LayerOriginBuildpack    = "buildpack"
+	LayerOriginApp          = "app"
+	LayerOriginLauncher     = "launcher"
+	LayerOriginProcessTypes = "process-types"
+	LayerOriginConfig       = "config"
+	LayerOriginSBOM         = "sbom"
+	// Layers of no origin the lifecycle metadata records, such as the layers run image extensions add.
+	LayerOriginUnknown = "unknown"
+)
+
+// ImageProvenanceOptions define the app image to map the layers of.
+type ImageProvenanceOptions struct {
+	// Name of the app image.
+	ImageName string
+
+	// Read the app image from the daemon instead of the registry.
+	Daemon bool
+}
+
+// ImageProvenance maps each layer of an app image to its origin.
+type ImageProvenance struct {
+	// Name of the app image.
+	Image string `json:"image"`
+
+	// Identifier of the app image, its digest reference in a registry, or its ID in the daemon.
+	Identifier string `json:"identifier"`
+
+	// Run image the app image was built or last rebased on.
+	RunImage RunImageProvenance `json:"runImage"`
+
+	// Layers of the app image, from the bottom one to the top one.
+	Layers []LayerProvenance `json:"layers"`
+}
+
+// RunImageProvenance is the run image an app image is based on.
+type RunImageProvenance struct {
+	// Reference of the run image, a digest reference when the app image was exported to a registry.
+	Reference string `json:"reference"`
+
+	// Top layer of the run image.
+	TopLayer string `json:"topLayer"`
+}
+
+// LayerProvenance is the origin of a layer of an app image.
+type LayerProvenance struct {
+	// Diff ID of the layer.
+	DiffID string `json:"diffID"`
+
+	// Origin of the layer, one of the LayerOrigin values.
+	Origin string `json:"origin"`
+
+	// Buildpack that contributed the layer, in the form '<id>@<version>', for buildpack layers.
+	Buildpack string `json:"buildpack,omitempty"`
+
+	// Name of the layer of the buildpack, for buildpack layers, or the slice of the app, for app layers.
+	Name string `json:"name,omitempty"`
+}
+
+// ImageProvenance maps each layer of an app image back to its origin, from the lifecycle metadata of the image: the run
+// image, the buildpack and layer of the buildpack, the slice of the app, or the layers the lifecycle adds.
+func (c *Client) ImageProvenance(ctx context.Context, opts ImageProvenanceOptions) (*ImageProvenance, error) {
+	img, err := c.imageFetcher.Fetch(ctx, opts.ImageName, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, err
+	}
+
+	var md files.LayersMetadataCompat
+	if ok, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &md); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+	}
+
+	underlyingImage := img.UnderlyingImage()
+	if underlyingImage == nil {
+		return nil, errors.Errorf("could not read the layers of %s", style.Symbol(opts.ImageName))
+	}
+	configFile, err := underlyingImage.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading the config of %s", style.Symbol(opts.ImageName))
+	}
+
+	identifier, err := img.Identifier()
+	if err != nil {
+		return nil, err
+	}
+
+	origins, err := layerOrigins(md)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := &ImageProvenance{
+		Image:      opts.ImageName,
+		Identifier: identifier.String(),
+		RunImage: RunImageProvenance{
+			Reference: md.RunImage.Reference,
+			TopLayer:  md.RunImage.TopLayer,
+		},
+	}
+
+	// the layers up to the top layer of the run image are the layers of the run image
+	runImageLayers := 0
+	for i, diffID := range configFile.RootFS.DiffIDs {
+		if diffID.String() == md.RunImage.TopLayer {
+			runImageLayers = i + 1
+		}
+	}
+
+	for i, diffID := range configFile.RootFS.DiffIDs {
+		layer, ok := origins[diffID.String()]
+		switch {
+		case i < runImageLayers:
+			layer = LayerProvenance{Origin: LayerOriginRunImage}
+		case !ok:
+			layer = LayerProvenance{Origin: LayerOriginUnknown}
+		}
+		layer.DiffID = diffID.String()
+		provenance.Layers = append(provenance.Layers, layer)
+	}
+	return provenance, nil
+}
+
+// layerOrigins returns the origins of the layers the lifecycle metadata records, by diff ID
+func layerOrigins(md files.LayersMetadataCompat) (map[string]LayerProvenance, error) {
+	origins := map[string]LayerProvenance{}
+	add := func(sha string, layer LayerProvenance) {
+		if sha != "" {
+			origins[sha] = layer
+		}
+	}
+
+	for _, bp := range md.Buildpacks {
+		for name, layer := range bp.Layers {
+			add(layer.SHA, LayerProvenance{Origin: LayerOriginBuildpack, Buildpack: fmt.Sprintf("%s@%s", bp.ID, bp.Version), Name: name})
+		}
+	}
+
+	appLayers, err := appLayersMetadata(md.App)
+	if err != nil {
+		return nil, err
+	}
+	for i, layer := range appLayers {
+		name := ""
+		if len(appLayers) > 1 {
+			name = fmt.Sprintf("slice %d of %d", i+1, len(appLayers))
+		}
+		add(layer.SHA, LayerProvenance{Origin: LayerOriginApp, Name: name})
+	}
+
+	add(md.Launcher.SHA, LayerProvenance{Origin: LayerOriginLauncher})
+	add(md.ProcessTypes.SHA, LayerProvenance{Origin: LayerOriginProcessTypes})
+	add(md.Config.SHA, LayerProvenance{Origin: LayerOriginConfig})
+	if md.BOM != nil {
+		add(md.BOM.SHA, LayerProvenance{Origin: LayerOriginSBOM})
+	}
+	return origins, nil
+}
+
+// appLayersMetadata reads the app layers of the lifecycle metadata, which older lifecycles record as a single layer
+func appLayersMetadata(app interface{}) ([]files.LayerMetadata, error) {
+	if app == nil {
+		return nil, nil
+	}
+	contents, err := json.Marshal(app)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading app layers metadata")
+	}
+
+	var layers []files.LayerMetadata
+	if err := json.Unmarshal(contents, &layers); err == nil {
+		return layers, nil
+	}
+	var layer files.LayerMetadata
+	if err := json.Unmarshal(contents, &layer); err != nil {
+		return nil, errors.Wrap(err, "reading app layers metadata")
+	}
+	return []files.LayerMetadata{layer}, nil
+}
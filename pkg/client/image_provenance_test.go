@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageProvenance(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImageProvenance", testImageProvenance, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// provenanceImage is a fake image with the layers of an underlying image
+type provenanceImage struct {
+	*fakes.Image
+	underlying v1.Image
+}
+
+func (i *provenanceImage) UnderlyingImage() v1.Image {
+	return i.underlying
+}
+
+func testImageProvenance(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		fakeAppImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	diffID := func(c string) string {
+		return "sha256:" + strings.Repeat(c, 64)
+	}
+
+	withLayers := func(img *fakes.Image, diffIDs ...string) *provenanceImage {
+		var hashes []v1.Hash
+		for _, d := range diffIDs {
+			hash, err := v1.NewHash(d)
+			h.AssertNil(t, err)
+			hashes = append(hashes, hash)
+		}
+		underlying, err := mutate.ConfigFile(empty.Image, &v1.ConfigFile{RootFS: v1.RootFS{Type: "layers", DiffIDs: hashes}})
+		h.AssertNil(t, err)
+		return &provenanceImage{Image: img, underlying: underlying}
+	}
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeAppImage = fakes.NewImage("some/app", "", &fakeIdentifier{name: "some/app@sha256:app"})
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	when("#ImageProvenance", func() {
+		it("maps each layer of the image to its origin", func() {
+			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "runImage": {"topLayer": "`+diffID("b")+`", "reference": "some/run@sha256:run"},
+  "buildpacks": [{"key": "some/bp", "version": "1.2.3", "layers": {"some-layer": {"sha": "`+diffID("c")+`"}}}],
+  "app": [{"sha": "`+diffID("d")+`"}, {"sha": "`+diffID("e")+`"}],
+  "launcher": {"sha": "`+diffID("f")+`"},
+  "process-types": {"sha": "`+diffID("1")+`"},
+  "config": {"sha": "`+diffID("2")+`"}
+}`))
+			fakeImageFetcher.RemoteImages["some/app"] = withLayers(fakeAppImage,
+				diffID("a"), diffID("b"), diffID("9"), diffID("c"), diffID("f"), diffID("d"), diffID("e"), diffID("2"), diffID("1"),
+			)
+
+			provenance, err := subject.ImageProvenance(context.TODO(), ImageProvenanceOptions{ImageName: "some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, provenance.Identifier, "some/app@sha256:app")
+			h.AssertEq(t, provenance.RunImage.Reference, "some/run@sha256:run")
+			h.AssertEq(t, provenance.Layers, []LayerProvenance{
+				{DiffID: diffID("a"), Origin: LayerOriginRunImage},
+				{DiffID: diffID("b"), Origin: LayerOriginRunImage},
+				{DiffID: diffID("9"), Origin: LayerOriginUnknown},
+				{DiffID: diffID("c"), Origin: LayerOriginBuildpack, Buildpack: "some/bp@1.2.3", Name: "some-layer"},
+				{DiffID: diffID("f"), Origin: LayerOriginLauncher},
+				{DiffID: diffID("d"), Origin: LayerOriginApp, Name: "slice 1 of 2"},
+				{DiffID: diffID("e"), Origin: LayerOriginApp, Name: "slice 2 of 2"},
+				{DiffID: diffID("2"), Origin: LayerOriginConfig},
+				{DiffID: diffID("1"), Origin: LayerOriginProcessTypes},
+			})
+		})
+
+		it("reads the app layer of older lifecycles", func() {
+			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "runImage": {"topLayer": "`+diffID("a")+`"},
+  "app": {"sha": "`+diffID("d")+`"}
+}`))
+			fakeImageFetcher.LocalImages["some/app"] = withLayers(fakeAppImage, diffID("a"), diffID("d"))
+
+			provenance, err := subject.ImageProvenance(context.TODO(), ImageProvenanceOptions{ImageName: "some/app", Daemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, provenance.Layers, []LayerProvenance{
+				{DiffID: diffID("a"), Origin: LayerOriginRunImage},
+				{DiffID: diffID("d"), Origin: LayerOriginApp},
+			})
+		})
+
+		it("fails when the image has no lifecycle metadata", func() {
+			fakeImageFetcher.RemoteImages["some/app"] = withLayers(fakeAppImage, diffID("a"))
+
+			_, err := subject.ImageProvenance(context.TODO(), ImageProvenanceOptions{ImageName: "some/app"})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.lifecycle.metadata' on image")
+		})
+	})
+}
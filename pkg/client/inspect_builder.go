@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 
 	pubbldr "github.com/buildpacks/pack/builder"
@@ -45,11 +46,17 @@ type BuilderInfo struct {
 	// to produce this builder.
 	CreatedBy builder.CreatorMetadata
 
+	// Version, URI and digest of the launcher, when the one of the lifecycle was replaced.
+	Launcher *builder.LauncherMetadata
+
 	// All extensions included within the builder.
 	Extensions []dist.ModuleInfo
 
 	// Detailed ordering of extensions.
 	OrderExtensions pubbldr.DetectionOrder
+
+	// Availability of the run images and of their mirrors in their registries, when they were checked.
+	RunImageChecks []RunImageCheck
 }
 
 // BuildpackInfoKey contains all information needed to determine buildpack equivalence.
@@ -60,6 +67,8 @@ type BuildpackInfoKey struct {
 
 type BuilderInspectionConfig struct {
 	OrderDetectionDepth int
+	CheckRunImages      bool
+	AdditionalMirrors   []pubbldr.RunImageConfig
 }
 
 type BuilderInspectionModifier func(config *BuilderInspectionConfig)
@@ -70,6 +79,16 @@ func WithDetectionOrderDepth(depth int) BuilderInspectionModifier {
 	}
 }
 
+// WithRunImageChecks checks the availability of the run images of the builder, and of their mirrors, in their registries
+// with the keychain of the client. Additional mirrors, such as the mirrors the user configured, are checked along with
+// the mirrors of the builder.
+func WithRunImageChecks(additionalMirrors ...pubbldr.RunImageConfig) BuilderInspectionModifier {
+	return func(config *BuilderInspectionConfig) {
+		config.CheckRunImages = true
+		config.AdditionalMirrors = additionalMirrors
+	}
+}
+
 // InspectBuilder reads label metadata of a local or remote builder image. It initializes a BuilderInfo
 // object with this metadata, and returns it. This method will error if the name image cannot be found
 // both locally and remotely, or if the found image does not contain the proper labels.
@@ -93,6 +112,11 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		return nil, err
 	}
 
+	var runImageChecks []RunImageCheck
+	if inspectionConfig.CheckRunImages {
+		runImageChecks = c.checkRunImages(context.Background(), info.RunImages, inspectionConfig.AdditionalMirrors)
+	}
+
 	return &BuilderInfo{
 		Description:     info.Description,
 		Stack:           info.StackID,
@@ -103,7 +127,9 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		BuildpackLayers: info.BuildpackLayers,
 		Lifecycle:       info.Lifecycle,
 		CreatedBy:       info.CreatedBy,
+		Launcher:        info.Launcher,
 		Extensions:      info.Extensions,
 		OrderExtensions: info.OrderExtensions,
+		RunImageChecks:  runImageChecks,
 	}, nil
 }
@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"sort"
 
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
@@ -19,12 +21,18 @@ type BuildpackInfo struct {
 	Order             dist.Order
 	BuildpackLayers   dist.ModuleLayers
 	Location          buildpack.LocatorType
+
+	// Uncompressed size of each buildpack layer, keyed by layer diff ID. Only populated when layer details are requested.
+	LayerSizes map[string]int64
 }
 
 type InspectBuildpackOptions struct {
 	BuildpackName string
 	Daemon        bool
 	Registry      string
+
+	// Read every buildpack layer to determine its uncompressed size.
+	LayerDetails bool
 }
 
 type ImgWrapper struct {
@@ -42,14 +50,15 @@ func (c *Client) InspectBuildpack(opts InspectBuildpackOptions) (*BuildpackInfo,
 	}
 	var layersMd dist.ModuleLayers
 	var buildpackMd buildpack.Metadata
+	var pkg buildpack.Package
 
 	switch locatorType {
 	case buildpack.RegistryLocator:
-		buildpackMd, layersMd, err = metadataFromRegistry(c, opts.BuildpackName, opts.Registry)
+		buildpackMd, layersMd, pkg, err = metadataFromRegistry(c, opts.BuildpackName, opts.Registry)
 	case buildpack.PackageLocator:
-		buildpackMd, layersMd, err = metadataFromImage(c, opts.BuildpackName, opts.Daemon)
+		buildpackMd, layersMd, pkg, err = metadataFromImage(c, opts.BuildpackName, opts.Daemon)
 	case buildpack.URILocator:
-		buildpackMd, layersMd, err = metadataFromArchive(c.downloader, opts.BuildpackName)
+		buildpackMd, layersMd, pkg, err = metadataFromArchive(c.downloader, opts.BuildpackName)
 	default:
 		return nil, fmt.Errorf("unable to handle locator %q: for buildpack %q", locatorType, opts.BuildpackName)
 	}
@@ -57,68 +66,110 @@ func (c *Client) InspectBuildpack(opts InspectBuildpackOptions) (*BuildpackInfo,
 		return nil, err
 	}
 
-	return &BuildpackInfo{
+	info := &BuildpackInfo{
 		BuildpackMetadata: buildpackMd,
 		BuildpackLayers:   layersMd,
 		Order:             extractOrder(buildpackMd),
 		Buildpacks:        extractBuildpacks(layersMd),
 		Location:          locatorType,
-	}, nil
+	}
+
+	if opts.LayerDetails {
+		info.LayerSizes, err = layerSizes(pkg, layersMd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
+}
+
+func layerSizes(pkg buildpack.Package, layersMd dist.ModuleLayers) (map[string]int64, error) {
+	sizes := map[string]int64{}
+	for _, versions := range layersMd {
+		for _, layerInfo := range versions {
+			if _, ok := sizes[layerInfo.LayerDiffID]; ok {
+				continue
+			}
+
+			size, err := layerSize(pkg, layerInfo.LayerDiffID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading layer %s", style.Symbol(layerInfo.LayerDiffID))
+			}
+			sizes[layerInfo.LayerDiffID] = size
+		}
+	}
+	return sizes, nil
 }
 
-func metadataFromRegistry(client *Client, name, registry string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, err error) {
+func layerSize(pkg buildpack.Package, diffID string) (int64, error) {
+	rc, err := pkg.GetLayer(diffID)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	return io.Copy(io.Discard, rc)
+}
+
+func metadataFromRegistry(client *Client, name, registry string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, pkg buildpack.Package, err error) {
 	registryCache, err := getRegistry(client.logger, registry)
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("invalid registry %s: %q", registry, err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("invalid registry %s: %q", registry, err)
 	}
 
 	registryBp, err := registryCache.LocateBuildpack(name)
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("unable to find %s in registry: %q", style.Symbol(name), err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to find %s in registry: %q", style.Symbol(name), err)
 	}
-	buildpackMd, layersMd, err = metadataFromImage(client, registryBp.Address, false)
+	buildpackMd, layersMd, pkg, err = metadataFromImage(client, registryBp.Address, false)
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("error pulling registry specified image: %s", err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("error pulling registry specified image: %s", err)
 	}
-	return buildpackMd, layersMd, nil
+	return buildpackMd, layersMd, pkg, nil
 }
 
-func metadataFromArchive(downloader BlobDownloader, path string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, err error) {
+func metadataFromArchive(downloader BlobDownloader, path string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, pkg buildpack.Package, err error) {
 	imgBlob, err := downloader.Download(context.Background(), path)
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("unable to download archive: %q", err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to download archive: %q", err)
 	}
 
 	config, err := buildpack.ConfigFromOCILayoutBlob(imgBlob)
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("unable to fetch config from buildpack blob: %q", err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to fetch config from buildpack blob: %q", err)
 	}
 	wrapper := ImgWrapper{config}
 
 	if _, err := dist.GetLabel(wrapper, dist.BuildpackLayersLabel, &layersMd); err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, err
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, err
 	}
 
 	if _, err := dist.GetLabel(wrapper, buildpack.MetadataLabel, &buildpackMd); err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, err
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, err
+	}
+
+	pkg, err = buildpack.PackageFromOCILayoutBlob(imgBlob)
+	if err != nil {
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to read layers from buildpack blob: %q", err)
 	}
-	return buildpackMd, layersMd, nil
+	return buildpackMd, layersMd, pkg, nil
 }
 
-func metadataFromImage(client *Client, name string, daemon bool) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, err error) {
+func metadataFromImage(client *Client, name string, daemon bool) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, pkg buildpack.Package, err error) {
 	imageName := buildpack.ParsePackageLocator(name)
 	img, err := client.imageFetcher.Fetch(context.Background(), imageName, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
 	if err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, err
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, err
 	}
 	if _, err := dist.GetLabel(img, dist.BuildpackLayersLabel, &layersMd); err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("unable to get image label %s: %q", dist.BuildpackLayersLabel, err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to get image label %s: %q", dist.BuildpackLayersLabel, err)
 	}
 
 	if _, err := dist.GetLabel(img, buildpack.MetadataLabel, &buildpackMd); err != nil {
-		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("unable to get image label %s: %q", buildpack.MetadataLabel, err)
+		return buildpack.Metadata{}, dist.ModuleLayers{}, nil, fmt.Errorf("unable to get image label %s: %q", buildpack.MetadataLabel, err)
 	}
-	return buildpackMd, layersMd, nil
+	return buildpackMd, layersMd, img, nil
 }
 
 func extractOrder(buildpackMd buildpack.Metadata) dist.Order {
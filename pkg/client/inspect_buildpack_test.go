@@ -449,6 +449,39 @@ func testInspectBuildpack(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			}
+
+			when("layer details are requested", func() {
+				it.Before(func() {
+					diffIDs := map[string]int{
+						"sha256:first-inner-buildpack-diff-id":  10,
+						"sha256:second-inner-buildpack-diff-id": 20,
+						"sha256:third-inner-buildpack-diff-id":  30,
+						"sha256:top-buildpack-diff-id":          40,
+					}
+					for diffID, size := range diffIDs {
+						layerPath := filepath.Join(tmpDir, diffID[len("sha256:"):]+".tar")
+						h.AssertNil(t, os.WriteFile(layerPath, bytes.Repeat([]byte("a"), size), 0600))
+						h.AssertNil(t, buildpackImage.AddLayerWithDiffID(layerPath, diffID))
+					}
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/buildpack", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(buildpackImage, nil)
+				})
+
+				it("includes the uncompressed size of each layer", func() {
+					info, err := subject.InspectBuildpack(client.InspectBuildpackOptions{
+						BuildpackName: "docker://some/buildpack",
+						Daemon:        true,
+						LayerDetails:  true,
+					})
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, info.LayerSizes, map[string]int64{
+						"sha256:first-inner-buildpack-diff-id":  10,
+						"sha256:second-inner-buildpack-diff-id": 20,
+						"sha256:third-inner-buildpack-diff-id":  30,
+						"sha256:top-buildpack-diff-id":          40,
+					})
+				})
+			})
 		})
 	})
 	when("failure cases", func() {
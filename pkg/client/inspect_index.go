@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// IndexInfo describes an image index in a registry.
+type IndexInfo struct {
+	// Name of the image index.
+	Name string `json:"name"`
+
+	// Digest of the image index.
+	Digest string `json:"digest"`
+
+	// Media type of the image index.
+	MediaType string `json:"mediaType"`
+
+	// Manifests of the image index.
+	Manifests []IndexManifestInfo `json:"manifests"`
+}
+
+// IndexManifestInfo describes a manifest of an image index.
+type IndexManifestInfo struct {
+	// Platform of the manifest, such as linux/arm64/v8.
+	Platform string `json:"platform"`
+
+	// Digest of the manifest.
+	Digest string `json:"digest"`
+
+	// Media type of the manifest.
+	MediaType string `json:"mediaType"`
+
+	// Size of the image of the manifest: the sum of the sizes of its config and compressed layers. The size of nested
+	// image indexes is the size of their manifest.
+	Size int64 `json:"size"`
+
+	// Whether the image has the labels of a Cloud Native Buildpacks app image, builder or package.
+	CNBMetadata bool `json:"cnbMetadata"`
+}
+
+// InspectIndex reads an image index from a registry, and describes each of its manifests.
+func (c *Client) InspectIndex(ctx context.Context, indexName string) (*IndexInfo, error) {
+	ref, err := name.ParseReference(indexName, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image index name %s", style.Symbol(indexName))
+	}
+
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+	index, err := ggcrremote.Index(ref, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading image index %s", style.Symbol(indexName))
+	}
+
+	digest, err := index.Digest()
+	if err != nil {
+		return nil, err
+	}
+	mediaType, err := index.MediaType()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading manifest of image index %s", style.Symbol(indexName))
+	}
+
+	info := &IndexInfo{
+		Name:      ref.Name(),
+		Digest:    digest.String(),
+		MediaType: string(mediaType),
+		Manifests: []IndexManifestInfo{},
+	}
+	for _, desc := range manifest.Manifests {
+		manifestInfo := IndexManifestInfo{
+			Digest:    desc.Digest.String(),
+			MediaType: string(desc.MediaType),
+			Size:      desc.Size,
+		}
+		if desc.Platform != nil {
+			manifestInfo.Platform = desc.Platform.String()
+		}
+
+		if desc.MediaType.IsImage() {
+			img, err := index.Image(desc.Digest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading image %s", style.Symbol(desc.Digest.String()))
+			}
+			imgManifest, err := img.Manifest()
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading manifest of image %s", style.Symbol(desc.Digest.String()))
+			}
+			manifestInfo.Size = imgManifest.Config.Size
+			for _, layer := range imgManifest.Layers {
+				manifestInfo.Size += layer.Size
+			}
+
+			configFile, err := img.ConfigFile()
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading config of image %s", style.Symbol(desc.Digest.String()))
+			}
+			for label := range configFile.Config.Labels {
+				if isCNBMetadataLabel(label) {
+					manifestInfo.CNBMetadata = true
+					break
+				}
+			}
+		}
+		info.Manifests = append(info.Manifests, manifestInfo)
+	}
+	return info, nil
+}
+
+// isCNBMetadataLabel returns true for the metadata labels of app images, builders and packages
+func isCNBMetadataLabel(label string) bool {
+	return strings.HasPrefix(label, "io.buildpacks.") && strings.HasSuffix(label, ".metadata")
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInspectIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "InspectIndex", testInspectIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testInspectIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject   *Client
+		server    *httptest.Server
+		indexName string
+		outBuf    bytes.Buffer
+	)
+
+	newImage := func(labels map[string]string) v1.Image {
+		img, err := random.Image(1024, 2)
+		h.AssertNil(t, err)
+		img, err = mutate.Config(img, v1.Config{Labels: labels})
+		h.AssertNil(t, err)
+		return img
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+		indexName = strings.TrimPrefix(server.URL, "http://") + "/some/app:latest"
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#InspectIndex", func() {
+		it("describes the manifests of the index", func() {
+			appImage := newImage(map[string]string{"io.buildpacks.lifecycle.metadata": "{}"})
+			otherImage := newImage(nil)
+			index := mutate.AppendManifests(empty.Index,
+				mutate.IndexAddendum{Add: appImage, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+				mutate.IndexAddendum{Add: otherImage, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}}},
+			)
+			ref, err := name.ParseReference(indexName, name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.WriteIndex(ref, index))
+
+			info, err := subject.InspectIndex(context.TODO(), indexName)
+			h.AssertNil(t, err)
+
+			indexDigest, err := index.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, info.Name, ref.Name())
+			h.AssertEq(t, info.Digest, indexDigest.String())
+			h.AssertEq(t, len(info.Manifests), 2)
+
+			appDigest, err := appImage.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, info.Manifests[0].Platform, "linux/amd64")
+			h.AssertEq(t, info.Manifests[0].Digest, appDigest.String())
+			h.AssertEq(t, info.Manifests[0].CNBMetadata, true)
+			h.AssertEq(t, info.Manifests[1].Platform, "linux/arm64/v8")
+			h.AssertEq(t, info.Manifests[1].CNBMetadata, false)
+
+			manifest, err := appImage.Manifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, info.Manifests[0].Size, manifest.Config.Size+manifest.Layers[0].Size+manifest.Layers[1].Size)
+		})
+
+		it("fails when the index doesn't exist", func() {
+			_, err := subject.InspectIndex(context.TODO(), indexName)
+			h.AssertError(t, err, "reading image index")
+		})
+	})
+}
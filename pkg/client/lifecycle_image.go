@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// verifyLifecycleImageDigest checks that the lifecycle image pulled into the daemon has one of the allowed digests, the
+// digest of its index or of its manifest, as recorded by the daemon when it pulled the image. Any image is allowed when
+// no digests are given.
+func (c *Client) verifyLifecycleImageDigest(ctx context.Context, imageName string, allowedDigests []string) error {
+	if len(allowedDigests) == 0 {
+		return nil
+	}
+
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return errors.Wrapf(err, "inspecting lifecycle image %s", style.Symbol(imageName))
+	}
+	if len(inspect.RepoDigests) == 0 {
+		return errors.Errorf("lifecycle image %s has no digest to verify, it must be pulled from a registry", style.Symbol(imageName))
+	}
+
+	var digests []string
+	for _, repoDigest := range inspect.RepoDigests {
+		_, digest, _ := strings.Cut(repoDigest, "@")
+		for _, allowed := range allowedDigests {
+			// digests may be allowed as a whole reference, such as some/lifecycle@sha256:..., or alone
+			if allowed == digest || strings.HasSuffix(allowed, "@"+digest) {
+				c.logger.Debugf("Verified digest %s of lifecycle image %s", style.Symbol(digest), style.Symbol(imageName))
+				return nil
+			}
+		}
+		digests = append(digests, digest)
+	}
+	return errors.Errorf("lifecycle image %s has digest %s, which is not one of the allowed digests %s",
+		style.Symbol(imageName), style.Symbol(strings.Join(digests, ", ")), style.Symbol(strings.Join(allowedDigests, ", ")))
+}
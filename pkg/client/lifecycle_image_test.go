@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLifecycleImage(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LifecycleImage", testLifecycleImage, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLifecycleImage(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		outBuf         bytes.Buffer
+	)
+
+	const digest = "sha256:d8dd0ba1e2b6cd5e4bed1b8d1e0e7f1f39a2c3c2b0e2fa5f8a5e3d9c1e5b4a7f"
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker: mockDocker,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#verifyLifecycleImageDigest", func() {
+		it("allows any lifecycle image when no digests are given", func() {
+			h.AssertNil(t, subject.verifyLifecycleImageDigest(context.TODO(), "some/lifecycle", nil))
+		})
+
+		it("allows a lifecycle image with one of the digests", func() {
+			mockDocker.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), "some/lifecycle").
+				Return(types.ImageInspect{RepoDigests: []string{"some/lifecycle@" + digest}}, nil, nil)
+
+			h.AssertNil(t, subject.verifyLifecycleImageDigest(context.TODO(), "some/lifecycle", []string{"sha256:other", digest}))
+		})
+
+		it("allows digests given as references", func() {
+			mockDocker.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), "some/lifecycle").
+				Return(types.ImageInspect{RepoDigests: []string{"some/lifecycle@" + digest}}, nil, nil)
+
+			h.AssertNil(t, subject.verifyLifecycleImageDigest(context.TODO(), "some/lifecycle", []string{"some/lifecycle@" + digest}))
+		})
+
+		it("fails for a lifecycle image with none of the digests", func() {
+			mockDocker.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), "some/lifecycle").
+				Return(types.ImageInspect{RepoDigests: []string{"some/lifecycle@" + digest}}, nil, nil)
+
+			err := subject.verifyLifecycleImageDigest(context.TODO(), "some/lifecycle", []string{"sha256:other"})
+			h.AssertError(t, err, "lifecycle image 'some/lifecycle' has digest '"+digest+"', which is not one of the allowed digests 'sha256:other'")
+		})
+
+		it("fails for a lifecycle image that was not pulled from a registry", func() {
+			mockDocker.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), "some/lifecycle").
+				Return(types.ImageInspect{}, nil, nil)
+
+			err := subject.verifyLifecycleImageDigest(context.TODO(), "some/lifecycle", []string{digest})
+			h.AssertError(t, err, "lifecycle image 'some/lifecycle' has no digest to verify")
+		})
+	})
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// LintBuilderOptions define the builder configuration to lint.
+type LintBuilderOptions struct {
+	// Configuration that defines the functionality a builder provides.
+	Config pubbldr.Config
+
+	// The base directory to use to resolve relative assets
+	RelativeBaseDir string
+
+	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
+	Registry string
+
+	// Fetch images from the registry instead of the daemon.
+	Publish bool
+
+	// Strategy for pulling images.
+	PullPolicy image.PullPolicy
+}
+
+type builderLinter struct {
+	findings []buildpack.LintFinding
+}
+
+func (l *builderLinter) add(module, rule, severity, path, format string, args ...interface{}) {
+	l.findings = append(l.findings, buildpack.LintFinding{
+		Buildpack: module,
+		Rule:      rule,
+		Severity:  severity,
+		Path:      path,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// LintBuilder validates a builder configuration without creating a builder, returning any problems found.
+//
+// Order groups, buildpack references and the lifecycle configuration are checked first. The build image, run images,
+// lifecycle, buildpacks and extensions are then fetched to check image labels, lifecycle compatibility and references
+// that would fail when creating the builder.
+func (c *Client) LintBuilder(ctx context.Context, opts LintBuilderOptions) ([]buildpack.LintFinding, error) {
+	l := &builderLinter{}
+	cfg := opts.Config
+
+	if err := pubbldr.ValidateConfig(cfg); err != nil {
+		l.add("", "config", buildpack.LintSeverityError, "", "%s", err)
+		return l.findings, nil
+	}
+
+	lintOrder(l, "order", cfg.Order)
+	lintOrder(l, "order-extensions", cfg.OrderExtensions)
+	lintModuleConfigs(l, "buildpacks", cfg.Buildpacks)
+	lintModuleConfigs(l, "extensions", cfg.Extensions)
+
+	if cfg.Lifecycle.Version != "" && cfg.Lifecycle.URI != "" {
+		l.add("", "lifecycle", buildpack.LintSeverityError, "lifecycle", "%s can only declare %s or %s, not both", style.Symbol("lifecycle"), style.Symbol("version"), style.Symbol("uri"))
+		return l.findings, nil
+	}
+	if cfg.Lifecycle.Version != "" {
+		if _, err := semver.NewVersion(cfg.Lifecycle.Version); err != nil {
+			l.add("", "lifecycle", buildpack.LintSeverityError, "lifecycle.version", "%s must be a valid semver", style.Symbol("lifecycle.version"))
+			return l.findings, nil
+		}
+	}
+
+	buildImage, err := c.imageFetcher.Fetch(ctx, cfg.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		l.add("", "build-image", buildpack.LintSeverityError, "build.image", "fetching build image %s: %s", style.Symbol(cfg.Build.Image), err)
+		return l.findings, nil
+	}
+
+	bldr, err := builder.New(buildImage, "")
+	if err != nil {
+		l.add("", "build-image", buildpack.LintSeverityError, "build.image", "invalid build image %s: %s", style.Symbol(cfg.Build.Image), err)
+		return l.findings, nil
+	}
+	if cfg.Stack.ID != "" && bldr.StackID != cfg.Stack.ID {
+		l.add("", "build-image", buildpack.LintSeverityError, "build.image", "stack %s from builder config is incompatible with stack %s from build image", style.Symbol(cfg.Stack.ID), style.Symbol(bldr.StackID))
+	}
+
+	target, err := builderTarget(bldr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lintRunImages(ctx, l, opts)
+
+	lifecycle, err := c.fetchLifecycle(ctx, cfg.Lifecycle, opts.RelativeBaseDir, target.OS, target.Arch)
+	if err != nil {
+		l.add("", "lifecycle", buildpack.LintSeverityError, "lifecycle", "%s", err)
+	}
+
+	distro := dist.Distribution{}
+	if len(target.Distributions) > 0 {
+		distro = target.Distributions[0]
+	}
+
+	var (
+		buildpacks []dist.ModuleInfo
+		extensions []dist.ModuleInfo
+	)
+	for i, moduleConfig := range cfg.Buildpacks {
+		modules := c.lintModuleConfig(ctx, l, buildpack.KindBuildpack, fmt.Sprintf("buildpacks[%d]", i), moduleConfig, opts, target)
+		for _, module := range modules {
+			desc := module.Descriptor()
+			buildpacks = append(buildpacks, desc.Info())
+
+			if lifecycle != nil {
+				lintModuleAPI(l, desc, lifecycle.Descriptor())
+			}
+			if len(desc.Order()) > 0 {
+				continue
+			}
+			if err := desc.EnsureStackSupport(bldr.StackID, bldr.Mixins(), false); err != nil {
+				l.add(desc.Info().FullName(), "mixins", buildpack.LintSeverityError, "", "%s", err)
+			}
+			if err := desc.EnsureTargetSupport(target.OS, target.Arch, distro.Name, distro.Version); err != nil {
+				l.add(desc.Info().FullName(), "targets", buildpack.LintSeverityError, "", "%s", err)
+			}
+		}
+	}
+	for i, moduleConfig := range cfg.Extensions {
+		modules := c.lintModuleConfig(ctx, l, buildpack.KindExtension, fmt.Sprintf("extensions[%d]", i), moduleConfig, opts, target)
+		for _, module := range modules {
+			extensions = append(extensions, module.Descriptor().Info())
+			if lifecycle != nil {
+				lintModuleAPI(l, module.Descriptor(), lifecycle.Descriptor())
+			}
+		}
+	}
+
+	lintDuplicateVersions(l, buildpack.KindBuildpack, buildpacks)
+	lintOrderReferences(l, "order", buildpack.KindBuildpack, cfg.Order, buildpacks)
+	lintOrderReferences(l, "order-extensions", buildpack.KindExtension, cfg.OrderExtensions, extensions)
+
+	return l.findings, nil
+}
+
+func (c *Client) lintRunImages(ctx context.Context, l *builderLinter, opts LintBuilderOptions) {
+	for i, r := range opts.Config.Run.Images {
+		for _, name := range append([]string{r.Image}, r.Mirrors...) {
+			path := fmt.Sprintf("run.images[%d]", i)
+
+			img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+			if err != nil {
+				if errors.Cause(err) != image.ErrNotFound {
+					l.add("", "run-image", buildpack.LintSeverityError, path, "fetching run image %s: %s", style.Symbol(name), err)
+				} else {
+					l.add("", "run-image", buildpack.LintSeverityWarning, path, "run image %s is not accessible", style.Symbol(name))
+				}
+				continue
+			}
+
+			if opts.Config.Stack.ID == "" {
+				continue
+			}
+			stackID, err := img.Label("io.buildpacks.stack.id")
+			if err != nil {
+				l.add("", "run-image", buildpack.LintSeverityError, path, "reading labels of run image %s: %s", style.Symbol(name), err)
+				continue
+			}
+			if stackID != opts.Config.Stack.ID {
+				l.add("", "run-image", buildpack.LintSeverityError, path,
+					"stack %s from builder config is incompatible with stack %s from run image %s",
+					style.Symbol(opts.Config.Stack.ID), style.Symbol(stackID), style.Symbol(name),
+				)
+			}
+		}
+	}
+}
+
+func (c *Client) lintModuleConfig(ctx context.Context, l *builderLinter, kind, path string, config pubbldr.ModuleConfig, opts LintBuilderOptions, target dist.Target) []buildpack.BuildModule {
+	if config.URI == "" && config.ImageName == "" {
+		// reported by lintModuleConfigs
+		return nil
+	}
+
+	mainModule, depModules, err := c.buildpackDownloader.Download(ctx, config.URI, buildpack.DownloadOptions{
+		Daemon:          !opts.Publish,
+		ImageName:       config.ImageName,
+		ModuleKind:      kind,
+		PullPolicy:      opts.PullPolicy,
+		RegistryName:    opts.Registry,
+		RelativeBaseDir: opts.RelativeBaseDir,
+		Target:          &dist.Target{OS: target.OS, Arch: target.Arch},
+		SHA256:          config.SHA256,
+	})
+	if err != nil {
+		l.add(config.DisplayString(), "reference", buildpack.LintSeverityError, path, "downloading %s: %s", kind, err)
+		return nil
+	}
+
+	if err := validateModule(kind, mainModule, config.URI, config.ID, config.Version); err != nil {
+		l.add(config.DisplayString(), "reference", buildpack.LintSeverityError, path, "%s", err)
+	}
+
+	return append([]buildpack.BuildModule{mainModule}, depModules...)
+}
+
+func lintModuleAPI(l *builderLinter, desc buildpack.Descriptor, lifecycleDescriptor builder.LifecycleDescriptor) {
+	if err := builder.ValidateLifecycleCompat(desc, lifecycleDescriptor); err != nil {
+		l.add(desc.Info().FullName(), "lifecycle", buildpack.LintSeverityError, "", "%s", err)
+		return
+	}
+
+	for _, deprecatedAPI := range lifecycleDescriptor.APIs.Buildpack.Deprecated {
+		if deprecatedAPI.Equal(desc.API()) {
+			l.add(desc.Info().FullName(), "lifecycle", buildpack.LintSeverityWarning, "",
+				"%s %s is using deprecated Buildpacks API version %s", desc.Kind(), style.Symbol(desc.Info().FullName()), style.Symbol(desc.API().String()))
+			return
+		}
+	}
+}
+
+func lintOrder(l *builderLinter, key string, order dist.Order) {
+	for i, group := range order {
+		path := fmt.Sprintf("%s[%d]", key, i)
+		if len(group.Group) == 0 {
+			l.add("", "order", buildpack.LintSeverityError, path, "group is empty")
+			continue
+		}
+
+		seen := map[string]bool{}
+		for _, ref := range group.Group {
+			if ref.ID == "" {
+				l.add("", "order", buildpack.LintSeverityError, path, "group entry is missing an %s", style.Symbol("id"))
+				continue
+			}
+			if seen[ref.ID] {
+				l.add("", "order", buildpack.LintSeverityError, path, "%s appears more than once in the same group", style.Symbol(ref.ID))
+			}
+			seen[ref.ID] = true
+		}
+	}
+}
+
+func lintModuleConfigs(l *builderLinter, key string, configs []pubbldr.ModuleConfig) {
+	seen := map[string]bool{}
+	for i, config := range configs {
+		path := fmt.Sprintf("%s[%d]", key, i)
+		if config.URI == "" && config.ImageName == "" {
+			l.add(config.DisplayString(), "reference", buildpack.LintSeverityError, path, "%s is required", style.Symbol("uri"))
+			continue
+		}
+
+		uri := config.URI
+		if uri == "" {
+			uri = config.ImageName
+		}
+		if seen[uri] {
+			l.add(config.DisplayString(), "duplicate", buildpack.LintSeverityWarning, path, "%s is declared more than once", style.Symbol(uri))
+		}
+		seen[uri] = true
+	}
+}
+
+func lintDuplicateVersions(l *builderLinter, kind string, modules []dist.ModuleInfo) {
+	versions := map[string][]string{}
+	var ids []string
+	for _, m := range modules {
+		if _, ok := versions[m.ID]; !ok {
+			ids = append(ids, m.ID)
+		}
+		if !contains(versions[m.ID], m.Version) {
+			versions[m.ID] = append(versions[m.ID], m.Version)
+		}
+	}
+
+	for _, id := range ids {
+		if len(versions[id]) > 1 {
+			l.add(id, "duplicate", buildpack.LintSeverityWarning, "", "multiple versions of %s %s are included: %s; order entries must specify a version", kind, style.Symbol(id), style.Symbol(fmt.Sprint(versions[id])))
+		}
+	}
+}
+
+func lintOrderReferences(l *builderLinter, key, kind string, order dist.Order, modules []dist.ModuleInfo) {
+	for i, group := range order {
+		path := fmt.Sprintf("%s[%d]", key, i)
+		for _, ref := range group.Group {
+			if ref.ID == "" {
+				continue
+			}
+
+			var versions []string
+			for _, m := range modules {
+				if m.ID == ref.ID && !contains(versions, m.Version) {
+					versions = append(versions, m.Version)
+				}
+			}
+
+			switch {
+			case len(versions) == 0:
+				l.add(ref.FullName(), "reference", buildpack.LintSeverityError, path, "no versions of %s %s were found", kind, style.Symbol(ref.ID))
+			case ref.Version == "" && len(versions) > 1:
+				l.add(ref.FullName(), "reference", buildpack.LintSeverityError, path, "unable to resolve version: multiple versions of %s - must specify an explicit version", style.Symbol(ref.ID))
+			case ref.Version != "" && !contains(versions, ref.Version):
+				l.add(ref.FullName(), "reference", buildpack.LintSeverityError, path, "%s %s with version %s was not found", kind, style.Symbol(ref.ID), style.Symbol(ref.Version))
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLintBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LintBuilder", testLintBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLintBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController          *gomock.Controller
+		mockDownloader          *testmocks.MockBlobDownloader
+		mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+		mockImageFetcher        *testmocks.MockImageFetcher
+		fakeBuildImage          *fakes.Image
+		fakeRunImage            *fakes.Image
+		opts                    client.LintBuilderOptions
+		subject                 *client.Client
+		out                     bytes.Buffer
+	)
+
+	findRule := func(findings []buildpack.LintFinding, rule string) []buildpack.LintFinding {
+		var found []buildpack.LintFinding
+		for _, f := range findings {
+			if f.Rule == rule {
+				found = append(found, f)
+			}
+		}
+		return found
+	}
+
+	bpOneRef := func(version string) dist.ModuleRef {
+		return dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: version}}
+	}
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&out, &out)
+		mockController = gomock.NewController(t)
+		mockDownloader = testmocks.NewMockBlobDownloader(mockController)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+		fakeBuildImage = fakes.NewImage("some/build-image", "", nil)
+		h.AssertNil(t, fakeBuildImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, fakeBuildImage.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "build:mixinY"]`))
+		h.AssertNil(t, fakeBuildImage.SetEnv("CNB_USER_ID", "1234"))
+		h.AssertNil(t, fakeBuildImage.SetEnv("CNB_GROUP_ID", "4321"))
+
+		fakeRunImage = fakes.NewImage("some/run-image", "", nil)
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(fakeBuildImage, nil).AnyTimes()
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/run-image", gomock.Any()).Return(fakeRunImage, nil).AnyTimes()
+		mockDownloader.EXPECT().Download(gomock.Any(), "file:///some-lifecycle").Return(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil).AnyTimes()
+
+		bp, err := buildpack.FromBuildpackRootBlob(blob.NewBlob(filepath.Join("testdata", "buildpack")), archive.DefaultTarWriterFactory(), nil)
+		h.AssertNil(t, err)
+		mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/bp-one.tgz", gomock.Any()).Return(bp, nil, nil).AnyTimes()
+
+		subject, err = client.NewClient(
+			client.WithLogger(logger),
+			client.WithDownloader(mockDownloader),
+			client.WithFetcher(mockImageFetcher),
+			client.WithBuildpackDownloader(mockBuildpackDownloader),
+		)
+		h.AssertNil(t, err)
+
+		opts = client.LintBuilderOptions{
+			RelativeBaseDir: "/",
+			Config: pubbldr.Config{
+				Buildpacks: []pubbldr.ModuleConfig{{
+					ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: "1.2.3"},
+					ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/bp-one.tgz"}},
+				}},
+				Order:     dist.Order{{Group: []dist.ModuleRef{bpOneRef("1.2.3")}}},
+				Stack:     pubbldr.StackConfig{ID: "some.stack.id"},
+				Run:       pubbldr.RunConfig{Images: []pubbldr.RunImageConfig{{Image: "some/run-image"}}},
+				Build:     pubbldr.BuildConfig{Image: "some/build-image"},
+				Lifecycle: pubbldr.LifecycleConfig{URI: "file:///some-lifecycle"},
+			},
+			PullPolicy: image.PullAlways,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#LintBuilder", func() {
+		it("only warns about deprecated APIs for a valid config", func() {
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertEq(t, findings, []buildpack.LintFinding{{
+				Buildpack: "bp.one@1.2.3",
+				Rule:      "lifecycle",
+				Severity:  buildpack.LintSeverityWarning,
+				Message:   "buildpack 'bp.one@1.2.3' is using deprecated Buildpacks API version '0.3'",
+			}})
+		})
+
+		it("reports invalid configs", func() {
+			opts.Config.Build.Image = ""
+
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(findings), 1)
+			h.AssertEq(t, findings[0].Rule, "config")
+			h.AssertEq(t, findings[0].Message, "build.image is required")
+		})
+
+		it("reports order group problems", func() {
+			opts.Config.Order = dist.Order{
+				{Group: []dist.ModuleRef{}},
+				{Group: []dist.ModuleRef{bpOneRef("1.2.3"), bpOneRef("1.2.3")}},
+				{Group: []dist.ModuleRef{bpOneRef("9.9.9")}},
+				{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "bp.missing"}}}},
+			}
+
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+
+			order := findRule(findings, "order")
+			h.AssertEq(t, len(order), 2)
+			h.AssertEq(t, order[0].Path, "order[0]")
+			h.AssertEq(t, order[0].Message, "group is empty")
+			h.AssertEq(t, order[1].Path, "order[1]")
+			h.AssertEq(t, order[1].Message, "'bp.one' appears more than once in the same group")
+
+			references := findRule(findings, "reference")
+			h.AssertEq(t, len(references), 2)
+			h.AssertEq(t, references[0].Message, "buildpack 'bp.one' with version '9.9.9' was not found")
+			h.AssertEq(t, references[1].Message, "no versions of buildpack 'bp.missing' were found")
+		})
+
+		it("reports image label problems", func() {
+			h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "other.stack.id"))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/missing-mirror", gomock.Any()).Return(nil, errors.Wrap(image.ErrNotFound, "some/missing-mirror"))
+			opts.Config.Run.Images[0].Mirrors = []string{"some/missing-mirror"}
+
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+
+			runImage := findRule(findings, "run-image")
+			h.AssertEq(t, len(runImage), 2)
+			h.AssertEq(t, runImage[0].Severity, buildpack.LintSeverityError)
+			h.AssertEq(t, runImage[0].Message, "stack 'some.stack.id' from builder config is incompatible with stack 'other.stack.id' from run image 'some/run-image'")
+			h.AssertEq(t, runImage[1].Severity, buildpack.LintSeverityWarning)
+			h.AssertEq(t, runImage[1].Message, "run image 'some/missing-mirror' is not accessible")
+		})
+
+		it("reports build images missing required env vars", func() {
+			h.AssertNil(t, fakeBuildImage.SetEnv("CNB_USER_ID", ""))
+
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(findings), 1)
+			h.AssertEq(t, findings[0].Rule, "build-image")
+			h.AssertContains(t, findings[0].Message, "missing required env var 'CNB_USER_ID'")
+		})
+
+		it("reports references that fail to download or do not match", func() {
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/missing.tgz", gomock.Any()).Return(nil, nil, errors.New("not found"))
+			opts.Config.Buildpacks = append(opts.Config.Buildpacks,
+				pubbldr.ModuleConfig{ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/missing.tgz"}}},
+				pubbldr.ModuleConfig{
+					ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: "2.0.0"},
+					ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/bp-one.tgz"}},
+				},
+			)
+
+			findings, err := subject.LintBuilder(context.TODO(), opts)
+			h.AssertNil(t, err)
+
+			references := findRule(findings, "reference")
+			h.AssertEq(t, len(references), 2)
+			h.AssertEq(t, references[0].Path, "buildpacks[1]")
+			h.AssertEq(t, references[0].Message, "downloading buildpack: not found")
+			h.AssertEq(t, references[1].Path, "buildpacks[2]")
+			h.AssertContains(t, references[1].Message, "has version '1.2.3' which does not match version '2.0.0' from builder config")
+
+			duplicates := findRule(findings, "duplicate")
+			h.AssertEq(t, len(duplicates), 1)
+			h.AssertEq(t, duplicates[0].Message, "'https://example.fake/bp-one.tgz' is declared more than once")
+		})
+	})
+}
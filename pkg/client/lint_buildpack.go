@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// LintBuildpackOptions define the buildpack to lint.
+type LintBuildpackOptions struct {
+	// URI of the buildpack to lint. May be a directory, an archive, an OCI layout file or a packaged buildpack image.
+	BuildpackURI string
+
+	// Buildpack registry name. Defines where registry buildpacks will be pulled from.
+	Registry string
+
+	// The base directory to use to resolve relative buildpack paths.
+	RelativeBaseDir string
+
+	// Fetch packaged buildpack images from the daemon instead of the registry.
+	Daemon bool
+
+	// Strategy for pulling packaged buildpack images.
+	PullPolicy image.PullPolicy
+}
+
+// LintBuildpack validates the descriptor, target and stack declarations, exec.d layout and Buildpack API of a buildpack,
+// returning any problems found. Every buildpack contained in a packaged buildpack is linted.
+func (c *Client) LintBuildpack(ctx context.Context, opts LintBuildpackOptions) ([]buildpack.LintFinding, error) {
+	locatorType, err := buildpack.GetLocatorType(opts.BuildpackURI, opts.RelativeBaseDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch locatorType {
+	case buildpack.URILocator:
+		uri, err := paths.FilePathToURI(opts.BuildpackURI, opts.RelativeBaseDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "making absolute: %s", style.Symbol(opts.BuildpackURI))
+		}
+
+		blob, err := c.downloader.Download(ctx, uri)
+		if err != nil {
+			return nil, errors.Wrapf(err, "downloading buildpack from %s", style.Symbol(uri))
+		}
+
+		isOCILayout, err := buildpack.IsOCILayoutBlob(blob)
+		if err != nil {
+			return nil, errors.Wrap(err, "inspecting buildpack blob")
+		}
+		if !isOCILayout {
+			return buildpack.LintRootBlob(blob)
+		}
+	case buildpack.PackageLocator, buildpack.RegistryLocator:
+	default:
+		return nil, fmt.Errorf("invalid buildpack URI %s", style.Symbol(opts.BuildpackURI))
+	}
+
+	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, opts.BuildpackURI, buildpack.DownloadOptions{
+		RegistryName:    opts.Registry,
+		RelativeBaseDir: opts.RelativeBaseDir,
+		Daemon:          opts.Daemon,
+		PullPolicy:      opts.PullPolicy,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "downloading buildpack %s", style.Symbol(opts.BuildpackURI))
+	}
+
+	var findings []buildpack.LintFinding
+	for _, module := range append([]buildpack.BuildModule{mainBP}, depBPs...) {
+		moduleFindings, err := buildpack.LintModule(module)
+		if err != nil {
+			return nil, errors.Wrapf(err, "linting buildpack %s", style.Symbol(module.Descriptor().Info().FullName()))
+		}
+		findings = append(findings, moduleFindings...)
+	}
+	return findings, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLintBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LintBuildpack", testLintBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLintBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject                 *Client
+		mockController          *gomock.Controller
+		mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+		tmpDir                  string
+		outBuf                  bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "lint-buildpack-test")
+		h.AssertNil(t, err)
+
+		mockController = gomock.NewController(t)
+		mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		subject = &Client{
+			logger:              logger,
+			downloader:          blob.NewDownloader(logger, filepath.Join(tmpDir, "cache")),
+			buildpackDownloader: mockBuildpackDownloader,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#LintBuildpack", func() {
+		it("lints a buildpack directory", func() {
+			bpDir := filepath.Join(tmpDir, "some-bp")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(bpDir, "bin"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "build"), []byte(""), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte(`
+api = "0.9"
+[buildpack]
+id = "some.bp"
+version = "1.0.0"
+`), 0644))
+
+			findings, err := subject.LintBuildpack(context.TODO(), LintBuildpackOptions{BuildpackURI: bpDir})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(findings), 1)
+			h.AssertEq(t, findings[0].Rule, "bin")
+			h.AssertEq(t, findings[0].Path, "bin/detect")
+		})
+
+		it("lints every buildpack in a packaged buildpack", func() {
+			mainBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:   api.MustParse("0.9"),
+				WithInfo:  dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"},
+				WithOrder: dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "other.bp", Version: "1.0.0"}}}}},
+			}, 0644)
+			h.AssertNil(t, err)
+			depBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.9"),
+				WithInfo:   dist.ModuleInfo{ID: "other.bp", Version: "1.0.0"},
+				WithStacks: []dist.Stack{{ID: "*", Mixins: []string{"some-mixin"}}},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "docker://some/package", gomock.Any()).
+				Return(mainBP, []buildpack.BuildModule{depBP}, nil)
+
+			findings, err := subject.LintBuildpack(context.TODO(), LintBuildpackOptions{BuildpackURI: "docker://some/package"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, findings, []buildpack.LintFinding{{
+				Buildpack: "other.bp@1.0.0",
+				Rule:      "stacks",
+				Severity:  buildpack.LintSeverityError,
+				Path:      "buildpack.toml",
+				Message:   "stack '*' cannot declare mixins",
+			}})
+		})
+
+		it("errors on an invalid buildpack URI", func() {
+			_, err := subject.LintBuildpack(context.TODO(), LintBuildpackOptions{BuildpackURI: "some.bp@1.0.0"})
+			h.AssertError(t, err, "invalid buildpack URI 'some.bp@1.0.0'")
+		})
+	})
+}
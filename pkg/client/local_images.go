@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	dimage "github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+)
+
+// ListLocalImages returns the names of the tagged images of the daemon, sorted
+func (c *Client) ListLocalImages(ctx context.Context) ([]string, error) {
+	summaries, err := c.docker.ImageList(ctx, dimage.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing images")
+	}
+
+	var names []string
+	for _, summary := range summaries {
+		for _, tag := range summary.RepoTags {
+			if tag != "<none>:<none>" {
+				names = append(names, tag)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
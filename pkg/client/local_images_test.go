@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	dimage "github.com/docker/docker/api/types/image"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestListLocalImages(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ListLocalImages", testListLocalImages, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testListLocalImages(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("returns the tags of the images, sorted", func() {
+		mockDockerClient.EXPECT().ImageList(gomock.Any(), dimage.ListOptions{}).Return([]dimage.Summary{
+			{RepoTags: []string{"some/image:latest", "some/image:1.0"}},
+			{RepoTags: []string{"<none>:<none>"}},
+			{RepoTags: []string{"other/image:latest"}},
+		}, nil)
+
+		names, err := subject.ListLocalImages(context.TODO())
+		h.AssertNil(t, err)
+		h.AssertEq(t, names, []string{"other/image:latest", "some/image:1.0", "some/image:latest"})
+	})
+
+	it("fails when the images can't be listed", func() {
+		mockDockerClient.EXPECT().ImageList(gomock.Any(), gomock.Any()).Return(nil, errors.New("no daemon"))
+
+		_, err := subject.ListLocalImages(context.TODO())
+		h.AssertError(t, err, "listing images: no daemon")
+	})
+}
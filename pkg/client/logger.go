@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// withLogger returns a copy of the client logging with the logger, along with the image fetches, downloads and
+// lifecycle runs of its operations, for the logger of an operation to get all of its output
+func (c *Client) withLogger(logger logging.Logger) *Client {
+	withLogger := *c
+	withLogger.logger = logger
+	withLogger.imageFetcher = &loggerImageFetcher{ImageFetcher: c.imageFetcher, logger: logger}
+	withLogger.downloader = blob.DownloaderWithLogger(c.downloader, logger)
+	withLogger.buildpackDownloader = &loggerBuildpackDownloader{BuildpackDownloader: c.buildpackDownloader, logger: logger}
+	withLogger.lifecycleExecutor = &loggerLifecycleExecutor{LifecycleExecutor: c.lifecycleExecutor, logger: logger}
+	return &withLogger
+}
+
+// loggerImageFetcher fetches images with the logger, unless the options have one already
+type loggerImageFetcher struct {
+	ImageFetcher
+	logger logging.Logger
+}
+
+func (f *loggerImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	if options.Logger == nil {
+		options.Logger = f.logger
+	}
+	return f.ImageFetcher.Fetch(ctx, name, options)
+}
+
+func (f *loggerImageFetcher) CheckReadAccess(repo string, options image.FetchOptions) bool {
+	if options.Logger == nil {
+		options.Logger = f.logger
+	}
+	return f.ImageFetcher.CheckReadAccess(repo, options)
+}
+
+// loggerBuildpackDownloader downloads buildpacks with the logger, unless the options have one already
+type loggerBuildpackDownloader struct {
+	BuildpackDownloader
+	logger logging.Logger
+}
+
+func (d *loggerBuildpackDownloader) Download(ctx context.Context, buildpackURI string, opts buildpack.DownloadOptions) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+	if opts.Logger == nil {
+		opts.Logger = d.logger
+	}
+	return d.BuildpackDownloader.Download(ctx, buildpackURI, opts)
+}
+
+// loggerLifecycleExecutor runs the lifecycle with the logger, unless the options have one already
+type loggerLifecycleExecutor struct {
+	LifecycleExecutor
+	logger logging.Logger
+}
+
+func (e *loggerLifecycleExecutor) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	if opts.Logger == nil {
+		opts.Logger = e.logger
+	}
+	return e.LifecycleExecutor.Execute(ctx, opts)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// LoginOptions define the registry to log in to.
+type LoginOptions struct {
+	// Registry to log in to, such as registry.example.com or docker.io.
+	Registry string
+
+	// Username to log in with.
+	Username string
+
+	// Password, or access token, to log in with.
+	Password string
+}
+
+// WithCredentialsDir stores the credentials of the registries pack logs in to in a docker config in the given directory,
+// for environments without a docker config of their own, such as minimal CI containers. These credentials take
+// precedence over the credentials of the keychain of the client.
+func WithCredentialsDir(dir string) Option {
+	return func(c *Client) {
+		c.credentialsDir = dir
+	}
+}
+
+// WithCredentialsCipher encrypts the passwords of the credentials stored in the credentials directory of the client
+// with the cipher, see WithCredentialsDir.
+func WithCredentialsCipher(cipher image.CredentialsCipher) Option {
+	return func(c *Client) {
+		c.credentialsCipher = cipher
+	}
+}
+
+// Login verifies the credentials of a registry, and stores them in the credentials directory of the client, for pack
+// to use them from then on.
+func (c *Client) Login(ctx context.Context, opts LoginOptions) error {
+	if c.credentialsDir == "" {
+		return errors.New("no credentials directory is configured to store credentials in")
+	}
+	if opts.Username == "" || opts.Password == "" {
+		return errors.New("username and password are required")
+	}
+
+	registry, err := name.NewRegistry(opts.Registry)
+	if err != nil {
+		return errors.Wrapf(err, "parsing registry %s", style.Symbol(opts.Registry))
+	}
+
+	if err := verifyCredentials(ctx, registry, opts.Username, opts.Password); err != nil {
+		return errors.Wrapf(err, "logging in to %s", style.Symbol(registry.RegistryStr()))
+	}
+
+	password := opts.Password
+	if c.credentialsCipher != nil {
+		// environments without a keyring, such as minimal CI containers, still get to log in
+		if password, err = c.credentialsCipher.Encrypt(opts.Password); err != nil {
+			c.logger.Warnf("Storing the credentials of %s unencrypted, as they can't be encrypted: %s", style.Symbol(registry.RegistryStr()), err)
+			password = opts.Password
+		}
+	}
+	return image.StoreCredentials(c.credentialsDir, registry.RegistryStr(), opts.Username, password)
+}
+
+// Logout removes the credentials of a registry from the credentials directory of the client, returning whether there
+// were credentials to remove.
+func (c *Client) Logout(ctx context.Context, registryName string) (bool, error) {
+	if c.credentialsDir == "" {
+		return false, nil
+	}
+
+	registry, err := name.NewRegistry(registryName)
+	if err != nil {
+		return false, errors.Wrapf(err, "parsing registry %s", style.Symbol(registryName))
+	}
+	return image.RemoveCredentials(c.credentialsDir, registry.RegistryStr())
+}
+
+// verifyCredentials checks the credentials against the registry, as authenticating with a registry that serves tokens
+// verifies them, but authenticating with a registry that takes basic auth does not
+func verifyCredentials(ctx context.Context, registry name.Registry, username, password string) error {
+	authenticator := authn.FromConfig(authn.AuthConfig{Username: username, Password: password})
+	rt, err := transport.NewWithContext(ctx, registry, authenticator, http.DefaultTransport, nil)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/v2/", registry.Scheme(), registry.RegistryStr()), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return transport.CheckError(resp, http.StatusOK)
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLogin(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Login", testLogin, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLogin(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		server         *httptest.Server
+		registryHost   string
+		credentialsDir string
+		outBuf         bytes.Buffer
+	)
+
+	it.Before(func() {
+		reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "some-user" || password != "some-password" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			reg.ServeHTTP(w, r)
+		}))
+		registryHost = strings.ReplaceAll(server.URL, "http://127.0.0.1", "localhost")
+
+		credentialsDir = t.TempDir()
+		subject = &Client{
+			logger:         logging.NewLogWithWriters(&outBuf, &outBuf),
+			credentialsDir: credentialsDir,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	resolve := func(dir string) *authn.AuthConfig {
+		client, err := NewClient(WithLogger(subject.logger), WithCredentialsDir(dir), WithCredentialsCipher(subject.credentialsCipher))
+		h.AssertNil(t, err)
+		reg, err := name.NewRegistry(registryHost)
+		h.AssertNil(t, err)
+		authenticator, err := client.keychain.Resolve(reg)
+		h.AssertNil(t, err)
+		authConfig, err := authenticator.Authorization()
+		h.AssertNil(t, err)
+		return authConfig
+	}
+
+	when("#Login", func() {
+		it("stores the credentials for the keychain of the client", func() {
+			h.AssertNil(t, subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "some-password"}))
+
+			authConfig := resolve(credentialsDir)
+			h.AssertEq(t, authConfig.Username, "some-user")
+			h.AssertEq(t, authConfig.Password, "some-password")
+		})
+
+		it("encrypts the stored password with the cipher", func() {
+			subject.credentialsCipher = config.KeyringCipher{Keyring: fakeKeyring{key: bytes.Repeat([]byte{1}, 32)}}
+			h.AssertNil(t, subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "some-password"}))
+
+			contents, err := os.ReadFile(filepath.Join(credentialsDir, "config.json"))
+			h.AssertNil(t, err)
+			var dockerConfig struct {
+				Auths map[string]struct {
+					Auth string `json:"auth"`
+				} `json:"auths"`
+			}
+			h.AssertNil(t, json.Unmarshal(contents, &dockerConfig))
+			auth, err := base64.StdEncoding.DecodeString(dockerConfig.Auths[registryHost].Auth)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(auth), "some-user:encrypted:")
+			h.AssertNotContains(t, string(auth), "some-password")
+
+			authConfig := resolve(credentialsDir)
+			h.AssertEq(t, authConfig.Username, "some-user")
+			h.AssertEq(t, authConfig.Password, "some-password")
+		})
+
+		it("stores the password unencrypted when the cipher can't encrypt it", func() {
+			subject.credentialsCipher = config.KeyringCipher{Keyring: fakeKeyring{err: errors.New("no keyring")}}
+			h.AssertNil(t, subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "some-password"}))
+			h.AssertContains(t, outBuf.String(), "Storing the credentials of '"+registryHost+"' unencrypted, as they can't be encrypted: no keyring")
+
+			h.AssertEq(t, resolve(credentialsDir).Password, "some-password")
+		})
+
+		it("fails for invalid credentials, without storing them", func() {
+			err := subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "other-password"})
+			h.AssertError(t, err, "logging in to '"+registryHost+"'")
+
+			h.AssertEq(t, resolve(credentialsDir).Username, "")
+		})
+
+		it("fails without a credentials directory", func() {
+			subject.credentialsDir = ""
+
+			err := subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "some-password"})
+			h.AssertError(t, err, "no credentials directory is configured")
+		})
+	})
+
+	when("#Logout", func() {
+		it("removes the stored credentials", func() {
+			h.AssertNil(t, subject.Login(context.TODO(), LoginOptions{Registry: registryHost, Username: "some-user", Password: "some-password"}))
+
+			removed, err := subject.Logout(context.TODO(), registryHost)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, removed)
+			h.AssertEq(t, resolve(credentialsDir).Username, "")
+		})
+
+		it("reports when there were no credentials to remove", func() {
+			removed, err := subject.Logout(context.TODO(), registryHost)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, removed)
+		})
+	})
+}
+
+// fakeKeyring is a keyring with the key, or failing with the error
+type fakeKeyring struct {
+	key []byte
+	err error
+}
+
+func (k fakeKeyring) Key(bool) ([]byte, error) {
+	return k.key, k.err
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/buildpacks/imgutil"
@@ -35,7 +36,7 @@ func (c *Client) PushManifest(opts PushManifestOptions) (err error) {
 		return
 	}
 
-	if err = idx.Push(ops...); err != nil {
+	if err = c.observePush(context.Background(), "index", true, func() error { return idx.Push(ops...) }); err != nil {
 		return fmt.Errorf("failed to push manifest list %s: %w", style.Symbol(opts.IndexRepoName), err)
 	}
 
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/buildpacks/imgutil"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/metrics"
+)
+
+// WithMetricsCollector collects the metrics of the image fetches, lifecycle phases and image pushes run by the client
+// with the collector. No metrics are collected by default.
+func WithMetricsCollector(collector metrics.Collector) Option {
+	return func(c *Client) {
+		c.metricsCollector = collector
+	}
+}
+
+// metricsImageFetcher collects the metrics of image fetches
+type metricsImageFetcher struct {
+	ImageFetcher
+	collector metrics.Collector
+}
+
+func (f *metricsImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (img imgutil.Image, err error) {
+	labels := map[string]string{
+		"daemon":      strconv.FormatBool(options.Daemon),
+		"pull_policy": options.PullPolicy.String(),
+	}
+	err = metrics.Observe(f.collector, metrics.ImageFetch, labels, func() error {
+		img, err = f.ImageFetcher.Fetch(ctx, name, options)
+		return err
+	})
+	return img, err
+}
+
+// observePush collects the metrics and traces the push of an image of the kind, which is only pushed when it is
+// published rather than saved to the daemon.
+func (c *Client) observePush(ctx context.Context, kind string, publish bool, push func() error) error {
+	if !publish {
+		return push()
+	}
+	_, span := c.startSpan(ctx, "push image", attribute.String("pack.kind", kind))
+	err := metrics.Observe(c.metricsCollector, metrics.ImagePush, map[string]string{"kind": kind}, push)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/metrics"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMetrics(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Metrics", testMetrics, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type fakeMetricsCollector struct {
+	counters  []map[string]string
+	durations int
+}
+
+func (c *fakeMetricsCollector) IncCounter(name string, labels map[string]string) {
+	labels["name"] = name
+	c.counters = append(c.counters, labels)
+}
+
+func (c *fakeMetricsCollector) ObserveDuration(string, time.Duration, map[string]string) {
+	c.durations++
+}
+
+func testMetrics(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject   *Client
+		collector *fakeMetricsCollector
+	)
+
+	it.Before(func() {
+		collector = &fakeMetricsCollector{}
+		fakeImageFetcher := ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.LocalImages["some/image"] = fakes.NewImage("some/image", "", nil)
+
+		var err error
+		subject, err = NewClient(WithFetcher(fakeImageFetcher), WithMetricsCollector(collector))
+		h.AssertNil(t, err)
+	})
+
+	when("#WithMetricsCollector", func() {
+		it("collects the metrics of image fetches", func() {
+			_, err := subject.imageFetcher.Fetch(context.TODO(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+			h.AssertNil(t, err)
+			_, err = subject.imageFetcher.Fetch(context.TODO(), "some/other-image", image.FetchOptions{PullPolicy: image.PullAlways})
+			h.AssertNotNil(t, err)
+
+			h.AssertEq(t, collector.counters, []map[string]string{
+				{"name": metrics.ImageFetch, "daemon": "true", "pull_policy": "never", "result": "success"},
+				{"name": metrics.ImageFetch, "daemon": "false", "pull_policy": "always", "result": "error"},
+			})
+			h.AssertEq(t, collector.durations, 2)
+		})
+
+		it("collects the metrics of published images", func() {
+			h.AssertNil(t, subject.observePush(context.TODO(), "builder", true, func() error { return nil }))
+			h.AssertError(t, subject.observePush(context.TODO(), "builder", true, func() error { return errors.New("some-push-error") }), "some-push-error")
+
+			h.AssertEq(t, collector.counters, []map[string]string{
+				{"name": metrics.ImagePush, "kind": "builder", "result": "success"},
+				{"name": metrics.ImagePush, "kind": "builder", "result": "error"},
+			})
+		})
+
+		it("doesn't collect the metrics of images saved to the daemon", func() {
+			h.AssertNil(t, subject.observePush(context.TODO(), "builder", false, func() error { return nil }))
+			h.AssertEq(t, len(collector.counters), 0)
+		})
+	})
+}
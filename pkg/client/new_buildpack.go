@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/lifecycle/api"
 
@@ -30,6 +31,14 @@ exit 0
 `
 )
 
+const (
+	// BuildpackTemplateBash generates a buildpack of bash scripts.
+	BuildpackTemplateBash = "bash"
+
+	// BuildpackTemplateLibcnb generates a Go buildpack using libcnb, with an integration test.
+	BuildpackTemplateLibcnb = "libcnb"
+)
+
 type NewBuildpackOptions struct {
 	// api compat version of the output buildpack artifact.
 	API string
@@ -48,13 +57,30 @@ type NewBuildpackOptions struct {
 
 	// the targets this buildpack will work with
 	Targets []dist.Target
+
+	// The template of the generated buildpack, either the const BuildpackTemplateBash, or BuildpackTemplateLibcnb.
+	// Defaults to BuildpackTemplateBash.
+	Template string
+
+	// The Go module path of a libcnb buildpack. Defaults to the ID of the buildpack.
+	GoModule string
 }
 
 func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) error {
+	switch opts.Template {
+	case "", BuildpackTemplateBash, BuildpackTemplateLibcnb:
+	default:
+		return errors.Errorf("unknown buildpack template %s", style.Symbol(opts.Template))
+	}
+
 	err := createBuildpackTOML(opts.Path, opts.ID, opts.Version, opts.API, opts.Stacks, opts.Targets, c)
 	if err != nil {
 		return err
 	}
+
+	if opts.Template == BuildpackTemplateLibcnb {
+		return createLibcnbBuildpack(opts.Path, opts, c)
+	}
 	return createBashBuildpack(opts.Path, c)
 }
 
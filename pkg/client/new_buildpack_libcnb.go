@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// scaffoldFile is a file of generated scaffolding
+type scaffoldFile struct {
+	path     string
+	contents string
+	mode     os.FileMode
+}
+
+// libcnbTemplateData is the data the files of a libcnb buildpack are generated with
+type libcnbTemplateData struct {
+	ID        string
+	GoModule  string
+	ImageName string
+}
+
+var libcnbFiles = []scaffoldFile{
+	{path: "go.mod", mode: 0644, contents: `module {{ .GoModule }}
+
+go 1.22
+`},
+	{path: "main.go", mode: 0644, contents: `package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb/v2"
+)
+
+func main() {
+	libcnb.BuildpackMain(detect, build)
+}
+
+// detect passes for Go projects, which have a go.mod file
+func detect(ctx libcnb.DetectContext) (libcnb.DetectResult, error) {
+	if _, err := os.Stat(filepath.Join(ctx.ApplicationPath, "go.mod")); err != nil {
+		if os.IsNotExist(err) {
+			return libcnb.DetectResult{Pass: false}, nil
+		}
+		return libcnb.DetectResult{}, err
+	}
+	return libcnb.DetectResult{Pass: true}, nil
+}
+
+func build(ctx libcnb.BuildContext) (libcnb.BuildResult, error) {
+	return libcnb.NewBuildResult(), nil
+}
+`},
+	{path: "Makefile", mode: 0644, contents: `# Builds the buildpack binary, which bin/detect and bin/build link to
+.PHONY: build
+build:
+	GOOS=linux CGO_ENABLED=0 go build -o bin/main .
+	ln -sf main bin/detect
+	ln -sf main bin/build
+`},
+	{path: filepath.Join("integration", "integration_test.go"), mode: 0644, contents: `//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+// defaultBuilder is the builder the fixture app is built with, unless the BUILDER environment variable is set
+const defaultBuilder = "paketobuildpacks/builder-jammy-base"
+
+func TestBuild(t *testing.T) {
+	buildpackDir, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	build := exec.Command("make")
+	build.Dir = buildpackDir
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		t.Fatalf("building buildpack: %s", err)
+	}
+
+	builder := os.Getenv("BUILDER")
+	if builder == "" {
+		builder = defaultBuilder
+	}
+
+	pack, err := client.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = pack.Build(context.Background(), client.BuildOptions{
+		Image:        "{{ .ImageName }}",
+		Builder:      builder,
+		AppPath:      filepath.Join("testdata", "app"),
+		Buildpacks:   []string{buildpackDir},
+		TrustBuilder: func(string) bool { return true },
+	})
+	if err != nil {
+		t.Fatalf("building fixture app: %s", err)
+	}
+}
+`},
+	{path: filepath.Join("integration", "testdata", "app", "go.mod"), mode: 0644, contents: `module example.com/app
+
+go 1.22
+`},
+	{path: filepath.Join("integration", "testdata", "app", "main.go"), mode: 0644, contents: `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello from {{ .ID }}")
+}
+`},
+}
+
+// createLibcnbBuildpack generates the scaffolding of a Go buildpack using libcnb, with an integration test building a
+// fixture app with it. Files that already exist are left untouched.
+func createLibcnbBuildpack(path string, opts NewBuildpackOptions, c *Client) error {
+	goModule := opts.GoModule
+	if goModule == "" {
+		goModule = opts.ID
+	}
+	idParts := strings.Split(opts.ID, "/")
+	data := libcnbTemplateData{
+		ID:        opts.ID,
+		GoModule:  goModule,
+		ImageName: strings.ToLower(idParts[len(idParts)-1]) + "-integration",
+	}
+
+	for _, file := range libcnbFiles {
+		tmpl, err := template.New(file.path).Parse(file.contents)
+		if err != nil {
+			return errors.Wrapf(err, "parsing template of %s", style.Symbol(file.path))
+		}
+		var contents bytes.Buffer
+		if err := tmpl.Execute(&contents, data); err != nil {
+			return errors.Wrapf(err, "generating %s", style.Symbol(file.path))
+		}
+		if err := createFile(path, file.path, contents.Bytes(), file.mode, c); err != nil {
+			return err
+		}
+	}
+
+	if c != nil {
+		c.logger.Infof("Run %s to fetch the dependencies of the buildpack, and %s to build it", style.Symbol("go mod tidy"), style.Symbol("make"))
+	}
+	return nil
+}
+
+func createFile(path, name string, contents []byte, mode os.FileMode, c *Client) error {
+	file := filepath.Join(path, name)
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		return nil
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 301 in this case
+	// G301: Expect directory permissions to be 0750 or less
+	/* #nosec G301 */
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, contents, mode); err != nil {
+		return err
+	}
+
+	if c != nil {
+		c.logger.Infof("    %s  %s", style.Symbol("create"), filepath.ToSlash(name))
+	}
+	return nil
+}
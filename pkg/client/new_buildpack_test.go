@@ -2,6 +2,8 @@ package client_test
 
 import (
 	"context"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -74,6 +76,68 @@ func testNewBuildpack(t *testing.T, when spec.G, it spec.S) {
 			assertBuildpackToml(t, tmpDir, "example/my-cnb")
 		})
 
+		when("the libcnb template is used", func() {
+			it("should create a libcnb buildpack with an integration test", func() {
+				err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.8",
+					Path:     tmpDir,
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Template: client.BuildpackTemplateLibcnb,
+					GoModule: "github.com/example/my-cnb",
+				})
+				h.AssertNil(t, err)
+
+				assertBuildpackToml(t, tmpDir, "example/my-cnb")
+				h.AssertPathDoesNotExists(t, filepath.Join(tmpDir, "bin", "build"))
+
+				goMod, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(goMod), "module github.com/example/my-cnb")
+
+				makefile, err := os.ReadFile(filepath.Join(tmpDir, "Makefile"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(makefile), "go build -o bin/main .")
+
+				for _, file := range []string{"main.go", filepath.Join("integration", "integration_test.go"), filepath.Join("integration", "testdata", "app", "main.go")} {
+					_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(tmpDir, file), nil, parser.AllErrors)
+					h.AssertNil(t, err)
+				}
+
+				integrationTest, err := os.ReadFile(filepath.Join(tmpDir, "integration", "integration_test.go"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(integrationTest), `Image:        "my-cnb-integration"`)
+				h.AssertPathExists(t, filepath.Join(tmpDir, "integration", "testdata", "app", "go.mod"))
+			})
+
+			it("should default the Go module to the buildpack ID", func() {
+				err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.8",
+					Path:     tmpDir,
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Template: client.BuildpackTemplateLibcnb,
+				})
+				h.AssertNil(t, err)
+
+				goMod, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(goMod), "module example/my-cnb")
+			})
+		})
+
+		it("should fail for an unknown template", func() {
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.8",
+				Path:     tmpDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: "cobol",
+			})
+			h.AssertError(t, err, "unknown buildpack template 'cobol'")
+			h.AssertPathDoesNotExists(t, filepath.Join(tmpDir, "buildpack.toml"))
+		})
+
 		when("files exist", func() {
 			it.Before(func() {
 				var err error
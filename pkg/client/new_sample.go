@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	SampleLanguageGo   = "go"
+	SampleLanguageNode = "node"
+	SampleLanguageJava = "java"
+)
+
+// sampleFiles are the files of the sample apps, by language. The apps serve HTTP on $PORT, defaulting to 8080.
+var sampleFiles = map[string][]scaffoldFile{
+	SampleLanguageGo: {
+		{path: "go.mod", mode: 0644, contents: `module example.com/sample
+
+go 1.22
+`},
+		{path: "main.go", mode: 0644, contents: `package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "Hello from a Go sample app!")
+	})
+	if err := http.ListenAndServe(":"+port, nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`},
+	},
+	SampleLanguageNode: {
+		{path: "package.json", mode: 0644, contents: `{
+  "name": "sample",
+  "version": "1.0.0",
+  "private": true,
+  "main": "server.js",
+  "scripts": {
+    "start": "node server.js"
+  }
+}
+`},
+		{path: "server.js", mode: 0644, contents: `const http = require("http");
+
+const port = process.env.PORT || 8080;
+
+http
+  .createServer((req, res) => res.end("Hello from a Node.js sample app!\n"))
+  .listen(port);
+`},
+	},
+	SampleLanguageJava: {
+		{path: "pom.xml", mode: 0644, contents: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+
+  <groupId>com.example</groupId>
+  <artifactId>sample</artifactId>
+  <version>1.0.0</version>
+  <packaging>jar</packaging>
+
+  <properties>
+    <maven.compiler.release>17</maven.compiler.release>
+    <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+  </properties>
+
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-jar-plugin</artifactId>
+        <configuration>
+          <archive>
+            <manifest>
+              <mainClass>com.example.sample.App</mainClass>
+            </manifest>
+          </archive>
+        </configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`},
+		{path: filepath.Join("src", "main", "java", "com", "example", "sample", "App.java"), mode: 0644, contents: `package com.example.sample;
+
+import com.sun.net.httpserver.HttpServer;
+import java.io.IOException;
+import java.io.OutputStream;
+import java.net.InetSocketAddress;
+import java.nio.charset.StandardCharsets;
+
+public class App {
+    public static void main(String[] args) throws IOException {
+        int port = Integer.parseInt(System.getenv().getOrDefault("PORT", "8080"));
+
+        HttpServer server = HttpServer.create(new InetSocketAddress(port), 0);
+        server.createContext("/", exchange -> {
+            byte[] body = "Hello from a Java sample app!\n".getBytes(StandardCharsets.UTF_8);
+            exchange.sendResponseHeaders(200, body.length);
+            try (OutputStream out = exchange.getResponseBody()) {
+                out.write(body);
+            }
+        });
+        server.start();
+    }
+}
+`},
+	},
+}
+
+// SampleLanguages returns the languages sample apps can be generated in.
+func SampleLanguages() []string {
+	var languages []string
+	for language := range sampleFiles {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// GenerateSampleOptions define the sample app to generate.
+type GenerateSampleOptions struct {
+	// Language of the sample app, one of SampleLanguages.
+	Language string
+
+	// The directory to generate the sample app in.
+	Path string
+
+	// Builder the project descriptor of the sample app builds it with. The project descriptor has no builder when
+	// empty.
+	Builder string
+}
+
+// GenerateSample generates a minimal sample app, serving HTTP, along with a project descriptor building it with the
+// builder, such as to smoke-test builders and buildpacks. Files that already exist are left untouched.
+func (c *Client) GenerateSample(ctx context.Context, opts GenerateSampleOptions) error {
+	files, ok := sampleFiles[opts.Language]
+	if !ok {
+		return errors.Errorf("unknown sample language %s, must be one of %s", style.Symbol(opts.Language), SampleLanguages())
+	}
+
+	for _, file := range files {
+		if err := createFile(opts.Path, file.path, []byte(file.contents), file.mode, c); err != nil {
+			return errors.Wrapf(err, "generating %s", style.Symbol(file.path))
+		}
+	}
+
+	descriptor := "[_]\nschema-version = \"0.2\"\n"
+	if opts.Builder != "" {
+		descriptor += fmt.Sprintf("\n[io.buildpacks]\nbuilder = %s\n", strconv.Quote(opts.Builder))
+	}
+	if err := createFile(opts.Path, "project.toml", []byte(descriptor), 0644, c); err != nil {
+		return errors.Wrap(err, "generating project.toml")
+	}
+	return nil
+}
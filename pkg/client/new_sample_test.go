@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestGenerateSample(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "GenerateSample", testGenerateSample, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testGenerateSample(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+		outBuf  bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		subject, err = client.NewClient(client.WithLogger(logging.NewLogWithWriters(&outBuf, &outBuf)))
+		h.AssertNil(t, err)
+		tmpDir = t.TempDir()
+	})
+
+	readBuilder := func(path string) string {
+		var descriptor struct {
+			IO struct {
+				Buildpacks struct {
+					Builder string `toml:"builder"`
+				} `toml:"buildpacks"`
+			} `toml:"io"`
+		}
+		_, err := toml.DecodeFile(filepath.Join(path, "project.toml"), &descriptor)
+		h.AssertNil(t, err)
+		return descriptor.IO.Buildpacks.Builder
+	}
+
+	when("#GenerateSample", func() {
+		for language, file := range map[string]string{
+			client.SampleLanguageGo:   "go.mod",
+			client.SampleLanguageNode: "package.json",
+			client.SampleLanguageJava: "pom.xml",
+		} {
+			language, file := language, file
+
+			it("generates a "+language+" sample app wired to the builder", func() {
+				path := filepath.Join(tmpDir, language)
+				h.AssertNil(t, subject.GenerateSample(context.TODO(), client.GenerateSampleOptions{
+					Language: language,
+					Path:     path,
+					Builder:  "some/builder",
+				}))
+
+				h.AssertPathExists(t, filepath.Join(path, file))
+				h.AssertEq(t, readBuilder(path), "some/builder")
+			})
+		}
+
+		it("generates a project descriptor without a builder", func() {
+			h.AssertNil(t, subject.GenerateSample(context.TODO(), client.GenerateSampleOptions{
+				Language: client.SampleLanguageGo,
+				Path:     tmpDir,
+			}))
+
+			h.AssertEq(t, readBuilder(tmpDir), "")
+			contents, err := os.ReadFile(filepath.Join(tmpDir, "project.toml"))
+			h.AssertNil(t, err)
+			h.AssertNotContains(t, string(contents), "io.buildpacks")
+		})
+
+		it("errors for unknown languages", func() {
+			err := subject.GenerateSample(context.TODO(), client.GenerateSampleOptions{
+				Language: "cobol",
+				Path:     tmpDir,
+			})
+			h.AssertError(t, err, "unknown sample language 'cobol', must be one of [go java node]")
+		})
+	})
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// OutdatedBuilderOptions define the builder to check for buildpack upgrades.
+type OutdatedBuilderOptions struct {
+	// Name of the builder to check.
+	BuilderName string
+
+	// Read the builder from the daemon, rather than from the registry.
+	Daemon bool
+
+	// Buildpack registry name. Defines the buildpack registry the buildpacks of the builder are looked up in.
+	Registry string
+}
+
+// BuildpackUpgradeStatus is whether a newer version of a buildpack of a builder is in the buildpack registry
+type BuildpackUpgradeStatus string
+
+const (
+	// BuildpackUpToDate buildpacks are at the latest version of the buildpack registry.
+	BuildpackUpToDate BuildpackUpgradeStatus = "up-to-date"
+
+	// BuildpackOutdated buildpacks have a newer version in the buildpack registry.
+	BuildpackOutdated BuildpackUpgradeStatus = "outdated"
+
+	// BuildpackNotInRegistry buildpacks can't be found in the buildpack registry.
+	BuildpackNotInRegistry BuildpackUpgradeStatus = "not-in-registry"
+)
+
+// BuildpackUpgrade compares the version of a buildpack of a builder to the latest version in the buildpack registry
+type BuildpackUpgrade struct {
+	ID      string                 `json:"id"`
+	Current string                 `json:"current"`
+	Latest  string                 `json:"latest,omitempty"`
+	Status  BuildpackUpgradeStatus `json:"status"`
+}
+
+// BuilderUpgradeReport compares the buildpacks of a builder to the latest versions in the buildpack registry.
+type BuilderUpgradeReport struct {
+	Builder    string             `json:"builder"`
+	Buildpacks []BuildpackUpgrade `json:"buildpacks"`
+
+	// Config to create the builder again with the latest version of each buildpack in the buildpack registry, from
+	// the buildpack registry. The build image of the builder is unknown, and left for the user to fill in.
+	Config pubbldr.Config `json:"-"`
+}
+
+// Outdated returns the buildpacks with a newer version in the buildpack registry
+func (r *BuilderUpgradeReport) Outdated() []BuildpackUpgrade {
+	var outdated []BuildpackUpgrade
+	for _, upgrade := range r.Buildpacks {
+		if upgrade.Status == BuildpackOutdated {
+			outdated = append(outdated, upgrade)
+		}
+	}
+	return outdated
+}
+
+// OutdatedBuilder compares each buildpack of a builder to the latest version of it in the buildpack registry, and
+// returns a report of the upgrades available, along with the config to create the builder with the upgrades.
+func (c *Client) OutdatedBuilder(opts OutdatedBuilderOptions) (*BuilderUpgradeReport, error) {
+	info, err := c.InspectBuilder(opts.BuilderName, opts.Daemon)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(opts.BuilderName))
+	}
+	if info == nil {
+		return nil, errors.Errorf("builder %s not found", style.Symbol(opts.BuilderName))
+	}
+
+	registryCache, err := getRegistry(c.logger, opts.Registry)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry %s", style.Symbol(opts.Registry))
+	}
+
+	// builders may have several versions of a buildpack, for composite buildpacks to reference; the highest is compared
+	current := map[string]string{}
+	var ids []string
+	for _, module := range info.Buildpacks {
+		version, ok := current[module.ID]
+		if !ok {
+			ids = append(ids, module.ID)
+		}
+		if !ok || compareVersions(module.Version, version) > 0 {
+			current[module.ID] = module.Version
+		}
+	}
+
+	report := &BuilderUpgradeReport{Builder: opts.BuilderName}
+	for _, id := range ids {
+		upgrade := BuildpackUpgrade{ID: id, Current: current[id], Status: BuildpackNotInRegistry}
+		if _, _, _, err := buildpack.ParseRegistryID(id); err == nil {
+			if registryBP, err := registryCache.LocateBuildpack(id); err == nil {
+				upgrade.Latest = registryBP.Version
+				upgrade.Status = BuildpackUpToDate
+				if compareVersions(registryBP.Version, upgrade.Current) > 0 {
+					upgrade.Status = BuildpackOutdated
+				}
+			} else {
+				c.logger.Debugf("Buildpack %s not found in the buildpack registry: %s", style.Symbol(id), err)
+			}
+		}
+		report.Buildpacks = append(report.Buildpacks, upgrade)
+	}
+
+	report.Config = upgradedBuilderConfig(info, report.Buildpacks)
+	return report, nil
+}
+
+// upgradedBuilderConfig returns the config of a builder, with the buildpacks of the buildpack registry at their latest
+// version, and the others at their current version without a URI, which the buildpack registry doesn't know
+func upgradedBuilderConfig(info *BuilderInfo, upgrades []BuildpackUpgrade) pubbldr.Config {
+	versions := map[string]string{}
+	config := pubbldr.Config{Description: info.Description}
+	for _, upgrade := range upgrades {
+		module := pubbldr.ModuleConfig{ModuleInfo: dist.ModuleInfo{ID: upgrade.ID, Version: upgrade.Current}}
+		if upgrade.Status != BuildpackNotInRegistry {
+			module.Version = upgrade.Latest
+			module.URI = fmt.Sprintf("urn:cnb:registry:%s@%s", upgrade.ID, upgrade.Latest)
+		}
+		versions[upgrade.ID] = module.Version
+		config.Buildpacks = append(config.Buildpacks, module)
+	}
+
+	for _, entry := range info.Order {
+		var group dist.OrderEntry
+		for _, ref := range entry.GroupDetectionOrder {
+			moduleRef := ref.ModuleRef
+			if version, ok := versions[moduleRef.ID]; ok && moduleRef.Version != "" {
+				moduleRef.Version = version
+			}
+			group.Group = append(group.Group, moduleRef)
+		}
+		config.Order = append(config.Order, group)
+	}
+
+	for _, runImage := range info.RunImages {
+		config.Run.Images = append(config.Run.Images, pubbldr.RunImageConfig{Image: runImage.Image, Mirrors: runImage.Mirrors})
+	}
+	if info.Lifecycle.Info.Version != nil {
+		config.Lifecycle.Version = info.Lifecycle.Info.Version.String()
+	}
+	return config
+}
+
+// compareVersions compares two semantic versions, such as 1.2.3, with invalid versions lower than valid ones
+func compareVersions(a, b string) int {
+	return semver.Compare("v"+a, "v"+b)
+}
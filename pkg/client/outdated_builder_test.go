@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOutdatedBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OutdatedBuilder", testOutdatedBuilder, spec.Report(report.Terminal{}))
+}
+
+func testOutdatedBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		builderImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		tmpDir := t.TempDir()
+		registryFixture := h.CreateRegistryFixture(t, tmpDir, filepath.Join("testdata", "registry"))
+		packHome := filepath.Join(tmpDir, "packHome")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, config.Write(config.Config{
+			Registries: []config.Registry{{Name: "some-registry", Type: "github", URL: registryFixture}},
+		}, filepath.Join(packHome, "config.toml")))
+
+		builderImage = fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{
+  "description": "Some description",
+  "buildpacks": [{"id": "example/foo", "version": "1.0.0"}, {"id": "example/foo", "version": "1.1.0"}, {"id": "example/java", "version": "1.0.0"}, {"id": "some-local-buildpack", "version": "0.1.0"}],
+  "lifecycle": {"version": "0.20.0"},
+  "images": [{"image": "some/run", "mirrors": ["mirror.example.com/some/run"]}]
+}`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", `[{"group": [{"id": "example/foo", "version": "1.1.0"}, {"id": "some-local-buildpack", "version": "0.1.0", "optional": true}]}, {"group": [{"id": "example/java", "version": "1.0.0"}]}]`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", "{}"))
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: mockImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		mockController.Finish()
+	})
+
+	when("#OutdatedBuilder", func() {
+		it("compares the buildpacks to the latest versions in the buildpack registry", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(builderImage, nil)
+
+			upgradeReport, err := subject.OutdatedBuilder(OutdatedBuilderOptions{BuilderName: "some/builder", Daemon: true, Registry: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, upgradeReport.Buildpacks, []BuildpackUpgrade{
+				{ID: "example/foo", Current: "1.1.0", Latest: "1.2.0", Status: BuildpackOutdated},
+				{ID: "example/java", Current: "1.0.0", Latest: "1.0.0", Status: BuildpackUpToDate},
+				{ID: "some-local-buildpack", Current: "0.1.0", Status: BuildpackNotInRegistry},
+			})
+			h.AssertEq(t, upgradeReport.Outdated(), []BuildpackUpgrade{
+				{ID: "example/foo", Current: "1.1.0", Latest: "1.2.0", Status: BuildpackOutdated},
+			})
+		})
+
+		it("returns the config of the builder with the latest versions", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{PullPolicy: image.PullNever}).Return(builderImage, nil)
+
+			upgradeReport, err := subject.OutdatedBuilder(OutdatedBuilderOptions{BuilderName: "some/builder", Registry: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, upgradeReport.Config.Description, "Some description")
+			h.AssertEq(t, upgradeReport.Config.Buildpacks, pubbldr.ModuleCollection{
+				{ModuleInfo: dist.ModuleInfo{ID: "example/foo", Version: "1.2.0"}, ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "urn:cnb:registry:example/foo@1.2.0"}}},
+				{ModuleInfo: dist.ModuleInfo{ID: "example/java", Version: "1.0.0"}, ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "urn:cnb:registry:example/java@1.0.0"}}},
+				{ModuleInfo: dist.ModuleInfo{ID: "some-local-buildpack", Version: "0.1.0"}},
+			})
+			h.AssertEq(t, upgradeReport.Config.Order, dist.Order{
+				{Group: []dist.ModuleRef{
+					{ModuleInfo: dist.ModuleInfo{ID: "example/foo", Version: "1.2.0"}},
+					{ModuleInfo: dist.ModuleInfo{ID: "some-local-buildpack", Version: "0.1.0"}, Optional: true},
+				}},
+				{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "example/java", Version: "1.0.0"}}}},
+			})
+			h.AssertEq(t, upgradeReport.Config.Run.Images, []pubbldr.RunImageConfig{{Image: "some/run", Mirrors: []string{"mirror.example.com/some/run"}}})
+			h.AssertEq(t, upgradeReport.Config.Lifecycle.Version, "0.20.0")
+		})
+
+		it("fails when the builder isn't found", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", gomock.Any()).Return(nil, image.ErrNotFound)
+
+			_, err := subject.OutdatedBuilder(OutdatedBuilderOptions{BuilderName: "some/builder", Registry: "some-registry"})
+			h.AssertError(t, err, "builder 'some/builder' not found")
+		})
+	})
+}
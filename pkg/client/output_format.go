@@ -0,0 +1,43 @@
+package client
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// SavesToDaemon returns whether an image with the given output format is saved to the daemon, rather than published
+// to a registry or written to the host filesystem as a file or an OCI layout
+func SavesToDaemon(format string, publish bool) bool {
+	return (format == "" || format == FormatImage) && !publish
+}
+
+// targetOutputName returns the name of the file or OCI layout the output for one of several targets is written to
+func targetOutputName(name string, target dist.Target) string {
+	extension := filepath.Ext(name)
+	baseName := name[:len(name)-len(extension)]
+	if target.Arch != "" {
+		return fmt.Sprintf("%s-%s-%s%s", baseName, target.OS, target.Arch, extension)
+	}
+	return fmt.Sprintf("%s-%s%s", baseName, target.OS, extension)
+}
+
+// writeLayoutArchive writes the OCI layout in the given directory to a tar archive at the given path
+func writeLayoutArchive(layoutDir, path string) error {
+	outputFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "creating output file")
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+}
@@ -3,11 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
+	"github.com/buildpacks/imgutil"
 	"github.com/pkg/errors"
 
 	pubbldpkg "github.com/buildpacks/pack/buildpackage"
+	internalConfig "github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/layer"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
@@ -24,6 +25,9 @@ const (
 	// Packaging indicator that format of output will be a file on the host filesystem.
 	FormatFile = "file"
 
+	// Packaging indicator that format of output will be an OCI image layout directory on the host filesystem.
+	FormatOCILayout = "oci-layout"
+
 	// CNBExtension is the file extension for a cloud native buildpack tar archive
 	CNBExtension = ".cnb"
 )
@@ -37,7 +41,7 @@ type PackageBuildpackOptions struct {
 	// The name of the output buildpack artifact.
 	Name string
 
-	// Type of output format, The options are the either the const FormatImage, or FormatFile.
+	// Type of output format, The options are the either the const FormatImage, FormatFile or FormatOCILayout.
 	Format string
 
 	// Defines the Buildpacks configuration.
@@ -63,21 +67,41 @@ type PackageBuildpackOptions struct {
 	// Map of labels to add to the Buildpack
 	Labels map[string]string
 
+	// Map of OCI manifest annotations to add to the Buildpack
+	Annotations map[string]string
+
 	// Target platforms to build packages for
 	Targets []dist.Target
+
+	// Resolve the buildpack and its dependencies, and log them with what would be saved or pushed, without saving or
+	// pushing anything.
+	DryRun bool
+
+	// Build the source of the buildpack before packaging it, when it is a directory with a Makefile or a build.sh
+	// script.
+	BuildSource bool
+
+	// Image to build the source of the buildpack in. The source is built on the host when empty.
+	SourceBuildImage string
 }
 
-// PackageBuildpack packages buildpack(s) into either an image or file.
+// PackageBuildpack packages buildpack(s) into an image, a file or an OCI layout.
 func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOptions) error {
 	if opts.Format == "" {
 		opts.Format = FormatImage
 	}
 
+	if opts.BuildSource {
+		if err := c.buildBuildpackSource(ctx, opts); err != nil {
+			return err
+		}
+	}
+
 	targets, err := c.processPackageBuildpackTargets(ctx, opts)
 	if err != nil {
 		return err
 	}
-	multiArch := len(targets) > 1 && (opts.Publish || opts.Format == FormatFile)
+	multiArch := len(targets) > 1 && !SavesToDaemon(opts.Format, opts.Publish)
 
 	var digests []string
 	targets = dist.ExpandTargetsDistributions(targets...)
@@ -90,6 +114,10 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	}
 
 	if opts.Publish && len(digests) > 1 {
+		if opts.DryRun {
+			c.logger.Infof("Image index %s of %d packages would be pushed to the registry", style.Symbol(opts.Name), len(digests))
+			return nil
+		}
 		// Image Index must be created only when we pushed to registry
 		return c.CreateManifest(ctx, CreateManifestOptions{
 			IndexRepoName: opts.Name,
@@ -101,10 +129,40 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	return nil
 }
 
+// downloadPackageDependency downloads a dependency of the package. Dependencies of packages written to a file or an
+// OCI layout are read from the daemon when it's reachable and has them, such as packages saved to it before, and
+// from their registry otherwise, so that no daemon is needed to write them.
+func (c *Client) downloadPackageDependency(ctx context.Context, uri string, opts PackageBuildpackOptions, downloadOpts buildpack.DownloadOptions) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+	if opts.Format != FormatFile && opts.Format != FormatOCILayout {
+		return c.buildpackDownloader.Download(ctx, uri, downloadOpts)
+	}
+
+	depName := uri
+	if depName == "" {
+		depName = downloadOpts.ImageName
+	}
+	if _, err := c.docker.ServerVersion(ctx); err == nil {
+		daemonOpts := downloadOpts
+		daemonOpts.Daemon = true
+		daemonOpts.PullPolicy = image.PullNever
+		mainBP, deps, err := c.buildpackDownloader.Download(ctx, uri, daemonOpts)
+		if !errors.Is(err, image.ErrNotFound) {
+			return mainBP, deps, err
+		}
+		c.logger.Debugf("Buildpack dependency %s isn't in the daemon, fetching it from its registry", style.Symbol(depName))
+	} else {
+		c.logger.Debugf("Fetching buildpack dependency %s from its registry, as the daemon is unreachable: %s", style.Symbol(depName), err)
+	}
+	return c.buildpackDownloader.Download(ctx, uri, downloadOpts)
+}
+
 func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpackOptions, target dist.Target, multiArch bool) (string, error) {
 	var digest string
-	if target.OS == "windows" && !c.experimental {
-		return "", NewExperimentError("Windows buildpackage support is currently experimental.")
+	if target.OS == "windows" && !c.experimentalEnabled(internalConfig.FeatureWindows) {
+		return "", NewFeatureExperimentError(internalConfig.FeatureWindows, "Windows buildpackage support is currently experimental.")
+	}
+	if target.OS == dist.TargetOSWasi && !c.experimentalEnabled(internalConfig.FeatureWasi) {
+		return "", NewFeatureExperimentError(internalConfig.FeatureWasi, "WASI buildpackage support is currently experimental.")
 	}
 
 	err := c.validateOSPlatform(ctx, target.OS, opts.Publish, opts.Format)
@@ -122,6 +180,12 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 		packageBuilderOpts = append(packageBuilderOpts, buildpack.DoNotFlatten(opts.FlattenExclude),
 			buildpack.WithLayerWriterFactory(writerFactory), buildpack.WithLogger(c.logger))
 	}
+	if len(opts.Annotations) > 0 {
+		if opts.Format == FormatImage && !opts.Publish {
+			c.logger.Warn("Annotations are not persisted when saving to the docker daemon; use --publish or --format file to keep them")
+		}
+		packageBuilderOpts = append(packageBuilderOpts, buildpack.WithAnnotations(opts.Annotations))
+	}
 	packageBuilder := buildpack.NewBuilder(c.imageFactory, packageBuilderOpts...)
 
 	bpURI := opts.Config.Buildpack.URI
@@ -146,6 +210,10 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 	packageBuilder.SetBuildpack(bp)
 
 	platform := target.ValuesAsPlatform()
+	if opts.DryRun {
+		c.logger.Infof("Dry run of package %s for %s:", style.Symbol(opts.Name), style.Symbol(platform))
+	}
+	modules := []buildpack.BuildModule{bp}
 
 	for _, dep := range opts.Config.Dependencies {
 		if multiArch {
@@ -153,18 +221,18 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 			if err != nil {
 				return digest, err
 			}
-			if locatorType == buildpack.URILocator {
+			if locatorType == buildpack.URILocator || locatorType == buildpack.DockerArchiveLocator {
 				// When building a composite multi-platform buildpack all the dependencies must be pushed to a registry
 				return digest, errors.New(fmt.Sprintf("uri %s is not allowed when creating a composite multi-platform buildpack; push your dependencies to a registry and use 'docker://<image>' instead", style.Symbol(dep.URI)))
 			}
 		}
 
 		c.logger.Debugf("Downloading buildpack dependency for platform %s", platform)
-		mainBP, deps, err := c.buildpackDownloader.Download(ctx, dep.URI, buildpack.DownloadOptions{
+		mainBP, deps, err := c.downloadPackageDependency(ctx, dep.URI, opts, buildpack.DownloadOptions{
 			RegistryName:    opts.Registry,
 			RelativeBaseDir: opts.RelativeBaseDir,
 			ImageName:       dep.ImageName,
-			Daemon:          !opts.Publish,
+			Daemon:          SavesToDaemon(opts.Format, opts.Publish),
 			PullPolicy:      opts.PullPolicy,
 			Target:          &target,
 		})
@@ -173,26 +241,51 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 		}
 
 		packageBuilder.AddDependencies(mainBP, deps)
+		modules = append(append(modules, mainBP), deps...)
+	}
+
+	if opts.DryRun {
+		if err := c.logDryRunModules(buildpack.KindBuildpack, modules); err != nil {
+			return digest, err
+		}
 	}
 
 	switch opts.Format {
 	case FormatFile:
 		name := opts.Name
 		if multiArch {
-			extension := filepath.Ext(name)
-			origFileName := name[:len(name)-len(filepath.Ext(name))]
-			if target.Arch != "" {
-				name = fmt.Sprintf("%s-%s-%s%s", origFileName, target.OS, target.Arch, extension)
-			} else {
-				name = fmt.Sprintf("%s-%s%s", origFileName, target.OS, extension)
-			}
+			name = targetOutputName(name, target)
+		}
+		if opts.DryRun {
+			c.logger.Infof("Package would be written to file %s", style.Symbol(name))
+			return digest, nil
 		}
 		err = packageBuilder.SaveAsFile(name, target, opts.Labels)
 		if err != nil {
 			return digest, err
 		}
+	case FormatOCILayout:
+		name := opts.Name
+		if multiArch {
+			name = targetOutputName(name, target)
+		}
+		if opts.DryRun {
+			c.logger.Infof("Package would be written to OCI layout %s", style.Symbol(name))
+			return digest, nil
+		}
+		if err := packageBuilder.SaveAsLayout(name, target, opts.Labels); err != nil {
+			return digest, err
+		}
 	case FormatImage:
-		img, err := packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, opts.Labels)
+		if opts.DryRun {
+			c.logger.Infof("Package %s would be %s", style.Symbol(opts.Name), dryRunDestination(opts.Publish))
+			return digest, nil
+		}
+		var img imgutil.Image
+		err := c.observePush(ctx, "buildpack", opts.Publish, func() (err error) {
+			img, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, opts.Labels)
+			return err
+		})
 		if err != nil {
 			return digest, errors.Wrapf(err, "saving image")
 		}
@@ -230,7 +323,7 @@ func (c *Client) processPackageBuildpackTargets(ctx context.Context, opts Packag
 	var targets []dist.Target
 	if len(opts.Targets) > 0 {
 		// when exporting to the daemon, we need to select just one target
-		if !opts.Publish && opts.Format == FormatImage {
+		if SavesToDaemon(opts.Format, opts.Publish) {
 			daemonTarget, err := c.daemonTarget(ctx, opts.Targets)
 			if err != nil {
 				return targets, err
@@ -246,7 +339,7 @@ func (c *Client) processPackageBuildpackTargets(ctx context.Context, opts Packag
 }
 
 func (c *Client) validateOSPlatform(ctx context.Context, os string, publish bool, format string) error {
-	if publish || format == FormatFile {
+	if !SavesToDaemon(format, publish) {
 		return nil
 	}
 
@@ -255,7 +348,8 @@ func (c *Client) validateOSPlatform(ctx context.Context, os string, publish bool
 		return err
 	}
 
-	if info.OSType != os {
+	// WASI images are stored by Linux daemons running WebAssembly workloads
+	if info.OSType != os && !(os == dist.TargetOSWasi && info.OSType == dist.DefaultTargetOSLinux) {
 		return errors.Errorf("invalid %s specified: DOCKER_OS is %s", style.Symbol("platform.os"), style.Symbol(info.OSType))
 	}
 
@@ -275,5 +369,13 @@ func (c *Client) daemonTarget(ctx context.Context, targets []dist.Target) (dist.
 			return t, nil
 		}
 	}
+	// WASI images run on Linux daemons, whatever their architecture
+	if info.Os == dist.DefaultTargetOSLinux {
+		for _, t := range targets {
+			if t.OS == dist.TargetOSWasi {
+				return t, nil
+			}
+		}
+	}
 	return dist.Target{}, errors.Errorf("could not find a target that matches daemon os=%s and architecture=%s", info.Os, info.Arch)
 }
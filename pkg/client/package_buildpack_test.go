@@ -11,10 +11,13 @@ import (
 	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/imgutil/fakes"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/system"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/heroku/color"
+	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
@@ -227,6 +230,73 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 				h.AssertError(t, err, "Windows buildpackage support is currently experimental.")
 			})
 
+			it("fails without experimental for WASI", func() {
+				packClientWithoutExperimental, err := client.NewClient(
+					client.WithDockerClient(mockDockerClient),
+					client.WithExperimental(false),
+				)
+				h.AssertNil(t, err)
+
+				err = packClientWithoutExperimental.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+					Config: pubbldpkg.Config{
+						Platform: dist.Platform{
+							OS: "wasi",
+						},
+					},
+				})
+				h.AssertError(t, err, "WASI buildpackage support is currently experimental.")
+			})
+
+			it("fails for WASI with only other experimental features enabled", func() {
+				packClientWithOtherFeatures, err := client.NewClient(
+					client.WithDockerClient(mockDockerClient),
+					client.WithExperimentalFeatures("windows"),
+				)
+				h.AssertNil(t, err)
+
+				err = packClientWithOtherFeatures.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+					Config: pubbldpkg.Config{
+						Platform: dist.Platform{
+							OS: "wasi",
+						},
+					},
+				})
+				h.AssertError(t, err, "WASI buildpackage support is currently experimental.")
+			})
+
+			it("creates WASI package images on Linux daemons", func() {
+				linuxMockDockerClient := testmocks.NewMockCommonAPIClient(mockController)
+				linuxMockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
+				linuxMockDockerClient.EXPECT().ServerVersion(context.TODO()).Return(types.Version{Os: "linux", Arch: "amd64"}, nil).AnyTimes()
+
+				packClientWithExperimental, err := client.NewClient(
+					client.WithDockerClient(linuxMockDockerClient),
+					client.WithDownloader(mockDownloader),
+					client.WithImageFactory(mockImageFactory),
+					client.WithExperimentalFeatures("wasi"),
+				)
+				h.AssertNil(t, err)
+
+				fakeImage := fakes.NewImage("basic/package-"+h.RandString(12), "", nil)
+				mockImageFactory.EXPECT().NewImage(fakeImage.Name(), true, dist.Target{OS: "wasi", Arch: "wasm"}).Return(fakeImage, nil)
+
+				h.AssertNil(t, packClientWithExperimental.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+					Format: client.FormatImage,
+					Name:   fakeImage.Name(),
+					Config: pubbldpkg.Config{
+						Platform: dist.Platform{OS: "wasi"},
+						Buildpack: dist.BuildpackURI{URI: createBuildpack(dist.BuildpackDescriptor{
+							WithAPI:     api.MustParse("0.10"),
+							WithInfo:    dist.ModuleInfo{ID: "bp.wasm", Version: "1.2.3"},
+							WithTargets: []dist.Target{{OS: "wasi", Arch: "wasm"}},
+						})},
+					},
+					Targets:    []dist.Target{{OS: "wasi", Arch: "wasm"}},
+					PullPolicy: image.PullNever,
+				}))
+				h.AssertEq(t, fakeImage.IsSaved(), true)
+			})
+
 			it("fails for mismatched platform and daemon os", func() {
 				windowsMockDockerClient := testmocks.NewMockCommonAPIClient(mockController)
 				windowsMockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "windows"}, nil).AnyTimes()
@@ -896,6 +966,8 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 
 				tmpDir, err = os.MkdirTemp("", "package-buildpack")
 				h.AssertNil(t, err)
+
+				mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "amd64"}, nil).AnyTimes()
 			})
 
 			it.After(func() {
@@ -916,13 +988,10 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 						Publish:    true,
 						PullPolicy: image.PullAlways,
 					}))
-
-					mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways, Target: &dist.Target{OS: "linux"}}).Return(nestedPackage, nil)
 				})
 
-				it("should pull and use local nested package image", func() {
+				packageWithNestedPackage := func(subject *client.Client) string {
 					packagePath := filepath.Join(tmpDir, "test.cnb")
-
 					h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
 						Name: packagePath,
 						Config: pubbldpkg.Config{
@@ -934,6 +1003,44 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 						PullPolicy: image.PullAlways,
 						Format:     client.FormatFile,
 					}))
+					return packagePath
+				}
+
+				it("should use the nested package image of the daemon", func() {
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever, Target: &dist.Target{OS: "linux"}}).Return(nestedPackage, nil)
+
+					packagePath := packageWithNestedPackage(subject)
+
+					assertPackageBPFileHasBuildpacks(t, packagePath, []dist.BuildpackDescriptor{packageDescriptor, childDescriptor})
+				})
+
+				it("should fetch the nested package image from the registry when the daemon doesn't have it", func() {
+					gomock.InOrder(
+						mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever, Target: &dist.Target{OS: "linux"}}).
+							Return(nil, errors.Wrap(image.ErrNotFound, "image does not exist on the daemon")),
+						mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, Target: &dist.Target{OS: "linux"}}).
+							Return(nestedPackage, nil),
+					)
+
+					packagePath := packageWithNestedPackage(subject)
+
+					assertPackageBPFileHasBuildpacks(t, packagePath, []dist.BuildpackDescriptor{packageDescriptor, childDescriptor})
+				})
+
+				it("should fetch the nested package image from the registry without a daemon", func() {
+					unreachableDockerClient := testmocks.NewMockCommonAPIClient(mockController)
+					unreachableDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{}, errors.New("cannot connect to the daemon")).AnyTimes()
+					daemonlessSubject, err := client.NewClient(
+						client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+						client.WithDownloader(mockDownloader),
+						client.WithImageFactory(mockImageFactory),
+						client.WithFetcher(mockImageFetcher),
+						client.WithDockerClient(unreachableDockerClient),
+					)
+					h.AssertNil(t, err)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, Target: &dist.Target{OS: "linux"}}).Return(nestedPackage, nil)
+
+					packagePath := packageWithNestedPackage(daemonlessSubject)
 
 					assertPackageBPFileHasBuildpacks(t, packagePath, []dist.BuildpackDescriptor{packageDescriptor, childDescriptor})
 				})
@@ -1032,7 +1139,7 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 						PullPolicy: image.PullAlways,
 					}))
 
-					mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways, Target: &dist.Target{OS: "linux"}}).Return(nestedPackage, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), nestedPackage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever, Target: &dist.Target{OS: "linux"}}).Return(nestedPackage, nil)
 				})
 
 				it("should include both of them", func() {
@@ -1140,7 +1247,7 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 					h.AssertNil(t, err)
 					err = packageImage.SetLabel("io.buildpacks.buildpack.layers", `{"example/foo":{"1.1.0":{"api": "0.2", "layerDiffID":"sha256:xxx", "stacks":[{"id":"some.stack.id"}]}}}`)
 					h.AssertNil(t, err)
-					mockImageFetcher.EXPECT().Fetch(gomock.Any(), packageImage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways, Target: &dist.Target{OS: "linux"}}).Return(packageImage, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), packageImage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever, Target: &dist.Target{OS: "linux"}}).Return(packageImage, nil)
 
 					packHome := filepath.Join(tmpDir, "packHome")
 					h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
@@ -1184,6 +1291,37 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("FormatOCILayout", func() {
+		it("writes the package to an OCI layout", func() {
+			layoutDir := filepath.Join(t.TempDir(), "some-layout")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Format: client.FormatOCILayout,
+				Name:   layoutDir,
+				Config: pubbldpkg.Config{
+					Platform: dist.Platform{OS: "linux"},
+					Buildpack: dist.BuildpackURI{URI: createBuildpack(dist.BuildpackDescriptor{
+						WithAPI:    api.MustParse("0.2"),
+						WithInfo:   dist.ModuleInfo{ID: "bp.basic", Version: "2.3.4"},
+						WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+					})},
+				},
+				PullPolicy: image.PullNever,
+			}))
+
+			index, err := layout.ImageIndexFromPath(layoutDir)
+			h.AssertNil(t, err)
+			manifest, err := index.IndexManifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(manifest.Manifests), 1)
+
+			img, err := index.Image(manifest.Manifests[0].Digest)
+			h.AssertNil(t, err)
+			configFile, err := img.ConfigFile()
+			h.AssertNil(t, err)
+			h.AssertContains(t, configFile.Config.Labels["io.buildpacks.buildpackage.metadata"], `"id":"bp.basic"`)
+		})
+	})
+
 	when("unknown format is provided", func() {
 		it("should error", func() {
 			mockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
@@ -1205,6 +1343,47 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 			h.AssertError(t, err, "unknown format: 'invalid-format'")
 		})
 	})
+
+	when("dry run", func() {
+		var opts client.PackageBuildpackOptions
+
+		it.Before(func() {
+			opts = client.PackageBuildpackOptions{
+				Name:   "some/package",
+				Format: client.FormatImage,
+				Config: pubbldpkg.Config{
+					Platform: dist.Platform{OS: "linux"},
+					Buildpack: dist.BuildpackURI{URI: createBuildpack(dist.BuildpackDescriptor{
+						WithAPI:    api.MustParse("0.2"),
+						WithInfo:   dist.ModuleInfo{ID: "bp.1", Version: "1.2.3"},
+						WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+					})},
+				},
+				Publish:    true,
+				PullPolicy: image.PullAlways,
+				DryRun:     true,
+			}
+		})
+
+		it("logs the buildpack without creating the package image", func() {
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), opts))
+
+			h.AssertContains(t, out.String(), "Dry run of package 'some/package' for 'linux':")
+			h.AssertContainsMatch(t, out.String(), `buildpack 'bp.1@1.2.3' \(sha256:[0-9a-f]{64}, [0-9.]+ [kM]?B\)`)
+			h.AssertContains(t, out.String(), "Package 'some/package' would be pushed to the registry")
+		})
+
+		it("logs the file without writing it", func() {
+			tmpDir := t.TempDir()
+			opts.Format = client.FormatFile
+			opts.Name = filepath.Join(tmpDir, "some-package.cnb")
+
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), opts))
+
+			h.AssertContains(t, out.String(), fmt.Sprintf("Package would be written to file '%s'", opts.Name))
+			h.AssertPathDoesNotExists(t, opts.Name)
+		})
+	})
 }
 
 func assertPackageBPFileHasBuildpacks(t *testing.T, path string, descriptors []dist.BuildpackDescriptor) {
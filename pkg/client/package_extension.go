@@ -5,20 +5,21 @@ import (
 
 	"github.com/pkg/errors"
 
+	internalConfig "github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/layer"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 )
 
-// PackageExtension packages extension(s) into either an image or file.
+// PackageExtension packages extension(s) into an image, a file or an OCI layout.
 func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOptions) error {
 	if opts.Format == "" {
 		opts.Format = FormatImage
 	}
 
-	if opts.Config.Platform.OS == "windows" && !c.experimental {
-		return NewExperimentError("Windows extensionpackage support is currently experimental.")
+	if opts.Config.Platform.OS == "windows" && !c.experimentalEnabled(internalConfig.FeatureWindows) {
+		return NewFeatureExperimentError(internalConfig.FeatureWindows, "Windows extensionpackage support is currently experimental.")
 	}
 
 	err := c.validateOSPlatform(ctx, opts.Config.Platform.OS, opts.Publish, opts.Format)
@@ -54,8 +55,13 @@ func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOpti
 	switch opts.Format {
 	case FormatFile:
 		return packageBuilder.SaveAsFile(opts.Name, target, map[string]string{})
+	case FormatOCILayout:
+		return packageBuilder.SaveAsLayout(opts.Name, target, map[string]string{})
 	case FormatImage:
-		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, map[string]string{})
+		err = c.observePush(ctx, "extension", opts.Publish, func() error {
+			_, err := packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, map[string]string{})
+			return err
+		})
 		return errors.Wrapf(err, "saving image")
 	default:
 		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
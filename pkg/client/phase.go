@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// CleanPhases removes the volumes the phases of the builds of an image share when run one at a time, with the Phase
+// build option.
+func (c *Client) CleanPhases(ctx context.Context, imageName string) error {
+	imageRef, err := c.parseTagReference(imageName)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+	}
+
+	layersVolume, appVolume := build.PhaseVolumes(imageRef)
+	for _, volume := range []string{layersVolume, appVolume} {
+		if err := c.docker.VolumeRemove(ctx, volume, true); err != nil && !errdefs.IsNotFound(err) {
+			return errors.Wrapf(err, "removing volume %s", style.Symbol(volume))
+		}
+		c.logger.Debugf("Removed volume %s", style.Symbol(volume))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCleanPhases(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CleanPhases", testCleanPhases, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCleanPhases(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		outBuf         bytes.Buffer
+		layersVolume   string
+		appVolume      string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker: mockDocker,
+		}
+
+		ref, err := name.NewTag("some/app", name.WeakValidation)
+		h.AssertNil(t, err)
+		layersVolume, appVolume = build.PhaseVolumes(ref)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CleanPhases", func() {
+		it("removes the volumes of the phases of the image", func() {
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), layersVolume, true).Return(nil)
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), appVolume, true).Return(errdefs.NotFound(errors.New("no such volume")))
+
+			h.AssertNil(t, subject.CleanPhases(context.TODO(), "some/app"))
+		})
+
+		it("errors when a volume can't be removed", func() {
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), layersVolume, true).Return(errors.New("volume is in use"))
+
+			h.AssertError(t, subject.CleanPhases(context.TODO(), "some/app"), "volume is in use")
+		})
+	})
+}
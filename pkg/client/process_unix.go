@@ -0,0 +1,19 @@
+//go:build linux || darwin
+
+package client
+
+import (
+	"os"
+	"syscall"
+)
+
+// processRunning returns whether a process with the given PID is running
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// signal 0 only checks whether the process exists
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
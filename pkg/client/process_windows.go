@@ -0,0 +1,16 @@
+package client
+
+import (
+	"os"
+)
+
+// processRunning returns whether a process with the given PID is running
+func processRunning(pid int) bool {
+	// finding a process fails on Windows when no process has the PID
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
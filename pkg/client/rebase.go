@@ -16,6 +16,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
 // RebaseOptions is a configuration struct that controls image rebase behavior.
@@ -48,11 +49,19 @@ type RebaseOptions struct {
 
 	// Image reference to use as the previous image for rebase.
 	PreviousImage string
+
+	// Logger of the rebase, overriding the logger of the client, for services running rebases at once to route the
+	// output of each rebase to a stream of its own.
+	Logger logging.Logger
 }
 
 // Rebase updates the run image layers in an app image.
 // This operation mutates the image specified in opts.
 func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
+	if opts.Logger != nil {
+		c = c.withLogger(opts.Logger)
+	}
+
 	imageRef, err := c.parseTagReference(opts.RepoName)
 	if err != nil {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
@@ -85,18 +94,7 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 	} else if !ok {
 		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
 	}
-	var runImageMD builder.RunImageMetadata
-	if md.RunImage.Image != "" {
-		runImageMD = builder.RunImageMetadata{
-			Image:   md.RunImage.Image,
-			Mirrors: md.RunImage.Mirrors,
-		}
-	} else if md.Stack != nil {
-		runImageMD = builder.RunImageMetadata{
-			Image:   md.Stack.RunImage.Image,
-			Mirrors: md.Stack.RunImage.Mirrors,
-		}
-	}
+	runImageMD := runImageMetadata(md)
 
 	target := &dist.Target{OS: appOS, Arch: appArch}
 	fetchOptions := image.FetchOptions{
@@ -126,7 +124,11 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 
 	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
 	rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
-	report, err := rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
+	var report files.RebaseReport
+	err = c.observePush(ctx, "app", opts.Publish, func() (err error) {
+		report, err = rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -155,3 +157,21 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 	}
 	return nil
 }
+
+// runImageMetadata returns the run image an app image was built on, and its mirrors, from the lifecycle metadata of the
+// app image
+func runImageMetadata(md files.LayersMetadataCompat) builder.RunImageMetadata {
+	if md.RunImage.Image != "" {
+		return builder.RunImageMetadata{
+			Image:   md.RunImage.Image,
+			Mirrors: md.RunImage.Mirrors,
+		}
+	}
+	if md.Stack != nil {
+		return builder.RunImageMetadata{
+			Image:   md.Stack.RunImage.Image,
+			Mirrors: md.Stack.RunImage.Mirrors,
+		}
+	}
+	return builder.RunImageMetadata{}
+}
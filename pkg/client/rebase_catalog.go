@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// CatalogRebaseStatus is the outcome of rebasing an image of a catalog.
+type CatalogRebaseStatus string
+
+const (
+	// CatalogRebased means the image was rebased onto an updated run image.
+	CatalogRebased CatalogRebaseStatus = "rebased"
+
+	// CatalogUpToDate means the image is already based on the latest run image.
+	CatalogUpToDate CatalogRebaseStatus = "up-to-date"
+
+	// CatalogSkipped means the image was not built with buildpacks, or the repository has no image with the tag.
+	CatalogSkipped CatalogRebaseStatus = "skipped"
+
+	// CatalogFailed means the image could not be checked or rebased.
+	CatalogFailed CatalogRebaseStatus = "failed"
+)
+
+// RebaseCatalogOptions define the images of a registry to rebase.
+type RebaseCatalogOptions struct {
+	// Registry, and optionally the namespace or repository within it, to rebase the images of, such as
+	// 'registry.example.com/team'.
+	Catalog string
+
+	// Tag of the images to rebase in each repository. Defaults to 'latest'.
+	Tag string
+
+	// Maximum number of images checked and rebased at once. Defaults to 1.
+	Concurrency int
+
+	// A mapping from run image names to mirrors, used along with the mirrors in the app image metadata.
+	AdditionalMirrors map[string][]string
+
+	// Pass-through force flag to lifecycle rebase command to skip target data validation.
+	Force bool
+}
+
+// CatalogRebaseResult is the outcome of rebasing one image of a catalog.
+type CatalogRebaseResult struct {
+	// Name of the image.
+	Image string
+
+	// Outcome of rebasing the image.
+	Status CatalogRebaseStatus
+
+	// Why the image was skipped or failed, if it was.
+	Reason string
+}
+
+// RebaseCatalog rebases every image built with buildpacks in a registry, or a namespace of it, that is not based on
+// the latest run image. The images are rebased in the registry. Images that fail to be rebased don't stop the others
+// from being rebased, and are reported in the results, sorted by image name.
+func (c *Client) RebaseCatalog(ctx context.Context, opts RebaseCatalogOptions) ([]CatalogRebaseResult, error) {
+	registryName, namespace, _ := strings.Cut(strings.TrimSuffix(opts.Catalog, "/"), "/")
+	registry, err := name.NewRegistry(registryName, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid catalog %s", style.Symbol(opts.Catalog))
+	}
+
+	repos, err := remote.Catalog(ctx, registry, remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain))
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing repositories of %s", style.Symbol(registry.Name()))
+	}
+
+	tag := opts.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		results []CatalogRebaseResult
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+	)
+	for _, repo := range repos {
+		if namespace != "" && repo != namespace && !strings.HasPrefix(repo, namespace+"/") {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(imageName string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result := c.rebaseCatalogImage(ctx, imageName, opts)
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		}(registry.Name() + "/" + repo + ":" + tag)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Image < results[j].Image
+	})
+	return results, nil
+}
+
+func (c *Client) rebaseCatalogImage(ctx context.Context, imageName string, opts RebaseCatalogOptions) CatalogRebaseResult {
+	appImage, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: false})
+	if errors.Is(err, image.ErrNotFound) {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogSkipped, Reason: "image not found"}
+	}
+	if err != nil {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogFailed, Reason: err.Error()}
+	}
+	if md, err := appImage.Label(platform.LifecycleMetadataLabel); err != nil || md == "" {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogSkipped, Reason: "not built with buildpacks"}
+	}
+
+	update, err := c.CheckUpdates(ctx, CheckUpdatesOptions{ImageName: imageName, AdditionalMirrors: opts.AdditionalMirrors})
+	if err != nil {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogFailed, Reason: err.Error()}
+	}
+	if !update.RebaseRecommended() {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogUpToDate}
+	}
+
+	if err := c.Rebase(ctx, RebaseOptions{
+		RepoName:          imageName,
+		Publish:           true,
+		AdditionalMirrors: opts.AdditionalMirrors,
+		Force:             opts.Force,
+	}); err != nil {
+		return CatalogRebaseResult{Image: imageName, Status: CatalogFailed, Reason: err.Error()}
+	}
+	return CatalogRebaseResult{Image: imageName, Status: CatalogRebased}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebaseCatalog(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RebaseCatalog", testRebaseCatalog, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRebaseCatalog(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		server           *httptest.Server
+		host             string
+		outBuf           bytes.Buffer
+	)
+
+	// appImage returns an app image built on the run image with the top layer
+	appImage := func(imageName, runImageTopLayer string) *fakes.Image {
+		img := fakes.NewImage(imageName, "", &fakeIdentifier{name: imageName})
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"topLayer":"`+runImageTopLayer+`","reference":"some/run@sha256:digest","image":"some/run"}}`))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		return img
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.TrimPrefix(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1), "http://")
+
+		// the catalog is listed from the registry, while the images are read with the fake fetcher
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		for _, repo := range []string{"team/outdated:latest", "team/up-to-date:latest", "team/not-cnb:latest", "team/untagged:v1", "other/app:latest"} {
+			ref, err := name.ParseReference(host+"/"+repo, name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.Write(ref, img))
+		}
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.RemoteImages[host+"/team/outdated:latest"] = appImage(host+"/team/outdated:latest", "old-top-layer")
+		fakeImageFetcher.RemoteImages[host+"/team/up-to-date:latest"] = appImage(host+"/team/up-to-date:latest", "new-top-layer")
+		fakeImageFetcher.RemoteImages[host+"/team/not-cnb:latest"] = fakes.NewImage(host+"/team/not-cnb:latest", "", nil)
+		runImage := fakes.NewImage("some/run", "new-top-layer", &fakeIdentifier{name: "some/run@sha256:new-digest"})
+		h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.RemoteImages["some/run"] = runImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher: fakeImageFetcher,
+			keychain:     authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#RebaseCatalog", func() {
+		it("rebases the outdated images of the namespace", func() {
+			results, err := subject.RebaseCatalog(context.TODO(), RebaseCatalogOptions{Catalog: host + "/team"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, results, []CatalogRebaseResult{
+				{Image: host + "/team/not-cnb:latest", Status: CatalogSkipped, Reason: "not built with buildpacks"},
+				{Image: host + "/team/outdated:latest", Status: CatalogRebased},
+				{Image: host + "/team/untagged:latest", Status: CatalogSkipped, Reason: "image not found"},
+				{Image: host + "/team/up-to-date:latest", Status: CatalogUpToDate},
+			})
+			h.AssertEq(t, fakeImageFetcher.RemoteImages[host+"/team/outdated:latest"].(*fakes.Image).Base(), "some/run")
+		})
+
+		it("rebases the images with the tag", func() {
+			fakeImageFetcher.RemoteImages[host+"/team/untagged:v1"] = appImage(host+"/team/untagged:v1", "old-top-layer")
+
+			results, err := subject.RebaseCatalog(context.TODO(), RebaseCatalogOptions{Catalog: host + "/team/untagged", Tag: "v1"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, results, []CatalogRebaseResult{{Image: host + "/team/untagged:v1", Status: CatalogRebased}})
+		})
+
+		it("fails when the catalog can't be listed", func() {
+			_, err := subject.RebaseCatalog(context.TODO(), RebaseCatalogOptions{Catalog: "localhost:1/team"})
+			h.AssertError(t, err, "listing repositories of 'localhost:1'")
+		})
+	})
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// RebuildTriggersLabel is the label of app images built with BuildOptions.RebuildTriggers, recording the builder and
+// buildpacks the app image was built with.
+const RebuildTriggersLabel = "io.buildpacks.pack.rebuild-triggers"
+
+// RebuildTriggers are the builder and buildpacks an app image was built with, which changes of call for a rebuild of
+// the app image, rather than a rebase.
+type RebuildTriggers struct {
+	// Name of the builder.
+	Builder string `json:"builder"`
+
+	// Identifier of the builder in the daemon, which builds read builders from.
+	BuilderDigest string `json:"builderDigest"`
+
+	// Version of the lifecycle of the builder.
+	Lifecycle string `json:"lifecycle"`
+
+	// Buildpacks of the builder that took part in the build.
+	Buildpacks []BuildpackTrigger `json:"buildpacks"`
+}
+
+// BuildpackTrigger is a buildpack an app image was built with, and the diff ID of its layer on the builder.
+type BuildpackTrigger struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Digest  string `json:"digest"`
+}
+
+// NeedsRebuildOptions define the app image to check for changes of its rebuild triggers.
+type NeedsRebuildOptions struct {
+	// Name of the app image to check.
+	ImageName string
+
+	// Read the app image from the daemon instead of the registry. The builder is read from the daemon, as builds do.
+	Daemon bool
+
+	// Strategy for updating the builder before comparing it to the one the app image was built with.
+	PullPolicy image.PullPolicy
+}
+
+// RebuildTriggerChange is a rebuild trigger of an app image that changed since it was built.
+type RebuildTriggerChange struct {
+	// Kind of the trigger, one of builder, lifecycle or buildpack.
+	Kind string `json:"kind"`
+
+	// Name of the builder, or ID of the buildpack, that changed.
+	Name string `json:"name"`
+
+	// Value the app image was built with: an identifier, a version, or a layer diff ID.
+	From string `json:"from"`
+
+	// Current value of the builder, or empty when a buildpack was removed from the builder.
+	To string `json:"to"`
+}
+
+// RebuildCheck compares the rebuild triggers of an app image to the current state of its builder.
+type RebuildCheck struct {
+	Image   string                 `json:"image"`
+	Builder string                 `json:"builder"`
+	Changes []RebuildTriggerChange `json:"changes"`
+}
+
+// RebuildNeeded returns true when the builder or the buildpacks the app image was built with changed.
+func (r RebuildCheck) RebuildNeeded() bool {
+	return len(r.Changes) > 0
+}
+
+// NeedsRebuild compares the rebuild triggers an app image was built with, to the current state of its builder, to
+// tell whether the app image should be rebuilt, or only rebased. App images must have been built with
+// BuildOptions.RebuildTriggers.
+func (c *Client) NeedsRebuild(ctx context.Context, opts NeedsRebuildOptions) (*RebuildCheck, error) {
+	appImage, err := c.imageFetcher.Fetch(ctx, opts.ImageName, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded RebuildTriggers
+	if ok, err := dist.GetLabel(appImage, RebuildTriggersLabel, &recorded); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.Errorf("could not find label %s on image, build it with rebuild triggers to check whether it needs a rebuild", style.Symbol(RebuildTriggersLabel))
+	}
+
+	builderImage, err := c.imageFetcher.Fetch(ctx, recorded.Builder, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching builder %s", style.Symbol(recorded.Builder))
+	}
+	current, err := builderTriggers(recorded.Builder, builderImage, recorded.Buildpacks)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &RebuildCheck{Image: opts.ImageName, Builder: recorded.Builder}
+	if current.BuilderDigest != recorded.BuilderDigest {
+		check.Changes = append(check.Changes, RebuildTriggerChange{Kind: "builder", Name: recorded.Builder, From: recorded.BuilderDigest, To: current.BuilderDigest})
+	}
+	if current.Lifecycle != recorded.Lifecycle {
+		check.Changes = append(check.Changes, RebuildTriggerChange{Kind: "lifecycle", Name: recorded.Builder, From: recorded.Lifecycle, To: current.Lifecycle})
+	}
+	for i, bp := range recorded.Buildpacks {
+		currentBP := current.Buildpacks[i]
+		switch {
+		case currentBP.Version != bp.Version:
+			check.Changes = append(check.Changes, RebuildTriggerChange{Kind: "buildpack", Name: bp.ID, From: bp.Version, To: currentBP.Version})
+		case currentBP.Digest != bp.Digest:
+			check.Changes = append(check.Changes, RebuildTriggerChange{Kind: "buildpack", Name: bp.ID, From: bp.Digest, To: currentBP.Digest})
+		}
+	}
+	return check, nil
+}
+
+// labelRebuildTriggers records the builder and the buildpacks of the builder the app image was built with in a label
+// of the app image. Buildpacks that don't come from the builder, such as the ones given with --buildpack, aren't
+// recorded, as the builder can't tell when they change.
+func (c *Client) labelRebuildTriggers(ctx context.Context, imageRef name.Reference, builderName string, builderImage imgutil.Image, opts BuildOptions) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever, InsecureRegistries: opts.InsecureRegistries})
+	if err != nil {
+		return errors.Wrapf(err, "fetching built image %s", style.Symbol(imageRef.Name()))
+	}
+
+	var buildMD files.BuildMetadata
+	if _, err := dist.GetLabel(img, platform.BuildMetadataLabel, &buildMD); err != nil {
+		return err
+	}
+	var buildpacks []BuildpackTrigger
+	for _, bp := range buildMD.Buildpacks {
+		buildpacks = append(buildpacks, BuildpackTrigger{ID: bp.ID, Version: bp.Version})
+	}
+
+	triggers, err := builderTriggers(builderName, builderImage, buildpacks)
+	if err != nil {
+		return err
+	}
+	var fromBuilder []BuildpackTrigger
+	for i, bp := range triggers.Buildpacks {
+		if bp.Version != buildpacks[i].Version {
+			c.logger.Debugf("Buildpack %s isn't from builder %s, leaving it out of the rebuild triggers", style.Symbol(buildpacks[i].ID+"@"+buildpacks[i].Version), style.Symbol(builderName))
+			continue
+		}
+		fromBuilder = append(fromBuilder, bp)
+	}
+	triggers.Buildpacks = fromBuilder
+
+	contents, err := json.Marshal(triggers)
+	if err != nil {
+		return errors.Wrap(err, "marshalling rebuild triggers")
+	}
+	if err = img.SetLabel(RebuildTriggersLabel, string(contents)); err != nil {
+		return errors.Wrap(err, "adding rebuild triggers label")
+	}
+	if err = img.Save(opts.AdditionalTags...); err != nil {
+		return errors.Wrapf(err, "saving image %s", style.Symbol(imageRef.Name()))
+	}
+	return nil
+}
+
+// builderTriggers returns the rebuild triggers of a builder for the given buildpacks, in the same order. Buildpacks the
+// builder doesn't have in the given version are returned in the highest version the builder has, or with no version
+// when the builder doesn't have the buildpack at all.
+func builderTriggers(builderName string, builderImage imgutil.Image, buildpacks []BuildpackTrigger) (*RebuildTriggers, error) {
+	bldr, err := builder.FromImage(builderImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder %s", style.Symbol(builderName))
+	}
+
+	triggers := &RebuildTriggers{Builder: builderName}
+	if id, err := builderImage.Identifier(); err == nil && id != nil {
+		triggers.BuilderDigest = id.String()
+	}
+	if version := bldr.LifecycleDescriptor().Info.Version; version != nil {
+		triggers.Lifecycle = version.String()
+	}
+
+	var layers dist.ModuleLayers
+	if _, err := dist.GetLabel(builderImage, dist.BuildpackLayersLabel, &layers); err != nil {
+		return nil, err
+	}
+	for _, bp := range buildpacks {
+		current := BuildpackTrigger{ID: bp.ID}
+		versions := layers[bp.ID]
+		if info, ok := versions[bp.Version]; ok {
+			current.Version, current.Digest = bp.Version, info.LayerDiffID
+		} else {
+			for version, info := range versions {
+				if current.Version == "" || compareVersions(version, current.Version) > 0 {
+					current.Version, current.Digest = version, info.LayerDiffID
+				}
+			}
+		}
+		triggers.Buildpacks = append(triggers.Buildpacks, current)
+	}
+	return triggers, nil
+}
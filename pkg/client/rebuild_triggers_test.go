@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebuildTriggers(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RebuildTriggers", testRebuildTriggers, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRebuildTriggers(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		appImage         *fakes.Image
+		out              bytes.Buffer
+	)
+
+	newBuilder := func(lifecycleVersion, layers string) *fakes.Image {
+		builderImage := fakes.NewImage("some/builder", "", local.IDIdentifier{ImageID: "some-builder-id"})
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{"lifecycle": {"version": "`+lifecycleVersion+`"}}`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", layers))
+		h.AssertNil(t, builderImage.SetEnv("CNB_USER_ID", "1234"))
+		h.AssertNil(t, builderImage.SetEnv("CNB_GROUP_ID", "4321"))
+		return builderImage
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		appImage = fakes.NewImage("some/app", "", nil)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: mockImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#labelRebuildTriggers", func() {
+		it("records the builder and the buildpacks of the builder the app image was built with", func() {
+			h.AssertNil(t, appImage.SetLabel(platform.BuildMetadataLabel, `{"buildpacks": [{"id": "some/buildpack", "version": "1.0.0"}, {"id": "some/added-buildpack", "version": "0.1.0"}]}`))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "index.docker.io/some/app:latest", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(appImage, nil)
+			builderImage := newBuilder("0.20.0", `{"some/buildpack": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:some-buildpack"}}}`)
+
+			imageRef, err := name.ParseReference("some/app")
+			h.AssertNil(t, err)
+			h.AssertNil(t, subject.labelRebuildTriggers(context.TODO(), imageRef, "some/builder", builderImage, BuildOptions{}))
+
+			label, err := appImage.Label(RebuildTriggersLabel)
+			h.AssertNil(t, err)
+			var triggers RebuildTriggers
+			h.AssertNil(t, json.Unmarshal([]byte(label), &triggers))
+			h.AssertEq(t, triggers.Builder, "some/builder")
+			h.AssertEq(t, triggers.Lifecycle, "0.20.0")
+			h.AssertEq(t, triggers.Buildpacks, []BuildpackTrigger{{ID: "some/buildpack", Version: "1.0.0", Digest: "sha256:some-buildpack"}})
+			h.AssertTrue(t, appImage.IsSaved())
+		})
+	})
+
+	when("#NeedsRebuild", func() {
+		setTriggers := func(triggers RebuildTriggers) {
+			contents, err := json.Marshal(triggers)
+			h.AssertNil(t, err)
+			h.AssertNil(t, appImage.SetLabel(RebuildTriggersLabel, string(contents)))
+		}
+
+		it("reports no changes for the builder the app image was built with", func() {
+			setTriggers(RebuildTriggers{
+				Builder:       "some/builder",
+				BuilderDigest: "some-builder-id",
+				Lifecycle:     "0.20.0",
+				Buildpacks:    []BuildpackTrigger{{ID: "some/buildpack", Version: "1.0.0", Digest: "sha256:some-buildpack"}},
+			})
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app", image.FetchOptions{PullPolicy: image.PullNever}).Return(appImage, nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways}).
+				Return(newBuilder("0.20.0", `{"some/buildpack": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:some-buildpack"}}}`), nil)
+
+			check, err := subject.NeedsRebuild(context.TODO(), NeedsRebuildOptions{ImageName: "some/app", PullPolicy: image.PullAlways})
+			h.AssertNil(t, err)
+			h.AssertFalse(t, check.RebuildNeeded())
+		})
+
+		it("reports the changes of the builder, lifecycle and buildpacks", func() {
+			setTriggers(RebuildTriggers{
+				Builder:       "some/builder",
+				BuilderDigest: "some-old-builder-digest",
+				Lifecycle:     "0.19.0",
+				Buildpacks: []BuildpackTrigger{
+					{ID: "some/buildpack", Version: "1.0.0", Digest: "sha256:some-buildpack"},
+					{ID: "some/rebuilt-buildpack", Version: "1.0.0", Digest: "sha256:some-old-layer"},
+					{ID: "some/removed-buildpack", Version: "1.0.0", Digest: "sha256:some-removed-buildpack"},
+				},
+			})
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(appImage, nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", gomock.Any()).Return(newBuilder("0.20.0", `{
+  "some/buildpack": {"2.0.0": {"api": "0.10", "layerDiffID": "sha256:some-new-buildpack"}},
+  "some/rebuilt-buildpack": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:some-new-layer"}}
+}`), nil)
+
+			check, err := subject.NeedsRebuild(context.TODO(), NeedsRebuildOptions{ImageName: "some/app", Daemon: true})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, check.RebuildNeeded())
+			h.AssertEq(t, check.Changes, []RebuildTriggerChange{
+				{Kind: "builder", Name: "some/builder", From: "some-old-builder-digest", To: "some-builder-id"},
+				{Kind: "lifecycle", Name: "some/builder", From: "0.19.0", To: "0.20.0"},
+				{Kind: "buildpack", Name: "some/buildpack", From: "1.0.0", To: "2.0.0"},
+				{Kind: "buildpack", Name: "some/rebuilt-buildpack", From: "sha256:some-old-layer", To: "sha256:some-new-layer"},
+				{Kind: "buildpack", Name: "some/removed-buildpack", From: "1.0.0", To: ""},
+			})
+		})
+
+		it("fails for app images built without rebuild triggers", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app", gomock.Any()).Return(appImage, nil)
+
+			_, err := subject.NeedsRebuild(context.TODO(), NeedsRebuildOptions{ImageName: "some/app"})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.pack.rebuild-triggers' on image")
+		})
+	})
+}
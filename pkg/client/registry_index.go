@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// RegistryIndexOptions define the registry index to generate or update.
+type RegistryIndexOptions struct {
+	// Directory containing the registry index.
+	Path string
+
+	// Buildpackage images to add to the index. Each image must be available in a registry.
+	ImageNames []string
+
+	// Create a new index. The directory must not exist or be empty.
+	// When false, the directory must already exist.
+	Create bool
+}
+
+// UpdateRegistryIndex writes an entry to a static buildpack registry index for each of the provided buildpackage
+// images. Entries for a version already in the index are replaced. The resulting directory can be served as a
+// self-hosted buildpack registry.
+func (c *Client) UpdateRegistryIndex(ctx context.Context, opts RegistryIndexOptions) error {
+	if err := prepareRegistryIndexDir(opts.Path, opts.Create); err != nil {
+		return err
+	}
+
+	for _, imageName := range opts.ImageNames {
+		bp, err := c.registryIndexEntry(ctx, imageName)
+		if err != nil {
+			return errors.Wrapf(err, "reading buildpackage %s", style.Symbol(imageName))
+		}
+
+		replaced, err := registry.AddToIndex(opts.Path, bp)
+		if err != nil {
+			return errors.Wrapf(err, "adding %s to index", style.Symbol(imageName))
+		}
+
+		action := "Added"
+		if replaced {
+			action = "Updated"
+		}
+		c.logger.Infof("%s %s to registry index", action, style.Symbol(bp.Namespace+"/"+bp.Name+"@"+bp.Version))
+	}
+
+	return nil
+}
+
+func prepareRegistryIndexDir(path string, create bool) error {
+	entries, err := os.ReadDir(path)
+	switch {
+	case create && os.IsNotExist(err):
+		return os.MkdirAll(path, 0750)
+	case err != nil:
+		return errors.Wrapf(err, "reading registry index %s", style.Symbol(path))
+	case create && len(entries) > 0:
+		return errors.Errorf("registry index %s already exists, use update to modify it", style.Symbol(path))
+	}
+	return nil
+}
+
+func (c *Client) registryIndexEntry(ctx context.Context, imageName string) (registry.Buildpack, error) {
+	img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+	if err != nil {
+		return registry.Buildpack{}, err
+	}
+
+	var bpInfo dist.ModuleInfo
+	if found, err := dist.GetLabel(img, buildpack.MetadataLabel, &bpInfo); err != nil {
+		return registry.Buildpack{}, err
+	} else if !found {
+		return registry.Buildpack{}, errors.Errorf("could not find label %s", style.Symbol(buildpack.MetadataLabel))
+	}
+
+	ns, name, err := registry.ParseNamespaceName(bpInfo.ID)
+	if err != nil {
+		return registry.Buildpack{}, err
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		return registry.Buildpack{}, err
+	}
+
+	bp := registry.Buildpack{
+		Namespace: ns,
+		Name:      name,
+		Version:   bpInfo.Version,
+		Address:   id.String(),
+	}
+	if err := registry.Validate(bp); err != nil {
+		return registry.Buildpack{}, err
+	}
+	return bp, nil
+}
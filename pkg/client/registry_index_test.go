@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateRegistryIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "UpdateRegistryIndex", testUpdateRegistryIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUpdateRegistryIndex(t *testing.T, when spec.G, it spec.S) {
+	const digestRef = "example.com/acme/java@sha256:0000000000000000000000000000000000000000000000000000000000000001"
+
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		tmpDir           string
+		indexDir         string
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "registry-index-test")
+		h.AssertNil(t, err)
+		indexDir = filepath.Join(tmpDir, "index")
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		bpImage := fakes.NewImage("example.com/acme/java:1.0.0", "", &fakeIdentifier{name: digestRef})
+		h.AssertNil(t, bpImage.SetLabel("io.buildpacks.buildpackage.metadata", `{"id":"acme/java","version":"1.0.0"}`))
+		fakeImageFetcher.RemoteImages["example.com/acme/java:1.0.0"] = bpImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#UpdateRegistryIndex", func() {
+		it("creates an index with an entry for each image", func() {
+			h.AssertNil(t, subject.UpdateRegistryIndex(context.TODO(), RegistryIndexOptions{
+				Path:       indexDir,
+				ImageNames: []string{"example.com/acme/java:1.0.0"},
+				Create:     true,
+			}))
+
+			entry, err := registry.ReadIndex(indexDir, "acme", "java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, entry.Buildpacks, []registry.Buildpack{{
+				Namespace: "acme",
+				Name:      "java",
+				Version:   "1.0.0",
+				Address:   digestRef,
+			}})
+			h.AssertContains(t, out.String(), "Added 'acme/java@1.0.0' to registry index")
+		})
+
+		it("replaces existing versions on update", func() {
+			_, err := registry.AddToIndex(indexDir, registry.Buildpack{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "old"})
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, subject.UpdateRegistryIndex(context.TODO(), RegistryIndexOptions{
+				Path:       indexDir,
+				ImageNames: []string{"example.com/acme/java:1.0.0"},
+			}))
+
+			entry, err := registry.ReadIndex(indexDir, "acme", "java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entry.Buildpacks), 1)
+			h.AssertEq(t, entry.Buildpacks[0].Address, digestRef)
+			h.AssertContains(t, out.String(), "Updated 'acme/java@1.0.0' to registry index")
+		})
+
+		it("errors when creating over an existing index", func() {
+			_, err := registry.AddToIndex(indexDir, registry.Buildpack{Namespace: "acme", Name: "java", Version: "1.0.0", Address: "old"})
+			h.AssertNil(t, err)
+
+			err = subject.UpdateRegistryIndex(context.TODO(), RegistryIndexOptions{Path: indexDir, Create: true})
+			h.AssertError(t, err, "already exists, use update to modify it")
+		})
+
+		it("errors when updating a missing index", func() {
+			err := subject.UpdateRegistryIndex(context.TODO(), RegistryIndexOptions{Path: indexDir})
+			h.AssertError(t, err, "reading registry index")
+		})
+
+		it("errors when the image is not a buildpackage", func() {
+			fakeImageFetcher.RemoteImages["some/image"] = fakes.NewImage("some/image", "", &fakeIdentifier{name: digestRef})
+
+			err := subject.UpdateRegistryIndex(context.TODO(), RegistryIndexOptions{
+				Path:       indexDir,
+				ImageNames: []string{"some/image"},
+				Create:     true,
+			})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.buildpackage.metadata'")
+		})
+	})
+}
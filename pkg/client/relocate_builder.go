@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// RelocateBuilderOptions define the builder to relocate, and the registry to relocate it to.
+type RelocateBuilderOptions struct {
+	// Name of the builder to relocate.
+	Builder string
+
+	// Registry, and optional namespace, to copy the builder and the images it references to, such as
+	// registry.example.com/cnb.
+	Registry string
+}
+
+// RelocatedBuilder holds the references to the images of a relocated builder.
+type RelocatedBuilder struct {
+	// Reference to the relocated builder.
+	Builder string
+
+	// Reference to the relocated lifecycle image, if the builder has a lifecycle version.
+	LifecycleImage string
+
+	// References to the relocated run images, by the name of the run image in the builder.
+	RunImages map[string]string
+}
+
+// RelocateBuilder copies a builder, the lifecycle image of its lifecycle version and its run images to a registry, such
+// as a private one, keeping their names, tags and digests. The returned references tell where each image was copied to,
+// to configure the relocated builder as trusted and the relocated run images as mirrors.
+func (c *Client) RelocateBuilder(ctx context.Context, opts RelocateBuilderOptions) (*RelocatedBuilder, error) {
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	builderRef, err := name.ParseReference(opts.Builder, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder name %s", style.Symbol(opts.Builder))
+	}
+	desc, err := ggcrremote.Get(builderRef, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading builder %s", style.Symbol(opts.Builder))
+	}
+	var md builder.Metadata
+	if err := remoteImageLabel(desc, builder.MetadataLabel, &md); err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of builder %s", style.Symbol(opts.Builder))
+	}
+
+	relocate := func(ref name.Reference, desc *ggcrremote.Descriptor) (string, error) {
+		repo, err := name.NewRepository(strings.TrimSuffix(opts.Registry, "/")+"/"+path.Base(ref.Context().RepositoryStr()), name.WeakValidation)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid registry %s", style.Symbol(opts.Registry))
+		}
+		relocated := referenceInRepository(ref, repo)
+		if err := copyRemoteImage(desc, relocated, remoteOpts...); err != nil {
+			return "", err
+		}
+		c.logger.Infof("Copied %s to %s (%s)", style.Symbol(ref.Name()), style.Symbol(relocated.Name()), desc.Digest)
+		return relocated.Name(), nil
+	}
+
+	relocateByName := func(imageName string) (string, error) {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+		}
+		desc, err := ggcrremote.Get(ref, remoteOpts...)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading image %s", style.Symbol(imageName))
+		}
+		return relocate(ref, desc)
+	}
+
+	result := &RelocatedBuilder{RunImages: map[string]string{}}
+	if result.Builder, err = relocate(builderRef, desc); err != nil {
+		return nil, err
+	}
+
+	if md.Lifecycle.Version != nil {
+		lifecycleImage := fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, md.Lifecycle.Version.String())
+		if result.LifecycleImage, err = relocateByName(lifecycleImage); err != nil {
+			return nil, err
+		}
+	}
+
+	runImages := md.RunImages
+	if len(runImages) == 0 && md.Stack.RunImage.Image != "" {
+		runImages = []builder.RunImageMetadata{md.Stack.RunImage}
+	}
+	for _, runImage := range runImages {
+		if result.RunImages[runImage.Image], err = relocateByName(runImage.Image); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRelocateBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RelocateBuilder", testRelocateBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRelocateBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject         *Client
+		public, private *httptest.Server
+		publicHost      string
+		privateHost     string
+		builderDigest   v1.Hash
+		runImageDigest  v1.Hash
+		outBuf          bytes.Buffer
+	)
+
+	newRegistryAndHost := func() (*httptest.Server, string) {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		return server, strings.TrimPrefix(server.URL, "http://")
+	}
+	writeImage := func(imageName string, labels map[string]string) v1.Hash {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		img, err = mutate.Config(img, v1.Config{Labels: labels})
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest
+	}
+	remoteDigestOf := func(imageName string) v1.Hash {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		desc, err := ggcrremote.Head(ref)
+		h.AssertNil(t, err)
+		return desc.Digest
+	}
+
+	it.Before(func() {
+		public, publicHost = newRegistryAndHost()
+		private, privateHost = newRegistryAndHost()
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+
+		runImageDigest = writeImage(publicHost+"/stacks/run:base", nil)
+		builderDigest = writeImage(publicHost+"/builders/base:1.0", map[string]string{
+			"io.buildpacks.builder.metadata": `{"images": [{"image": "` + publicHost + `/stacks/run:base", "mirrors": ["mirror.example.com/stacks/run:base"]}]}`,
+		})
+	})
+
+	it.After(func() {
+		public.Close()
+		private.Close()
+	})
+
+	when("#RelocateBuilder", func() {
+		it("copies the builder and its run images to the registry", func() {
+			relocated, err := subject.RelocateBuilder(context.TODO(), RelocateBuilderOptions{
+				Builder:  publicHost + "/builders/base:1.0",
+				Registry: privateHost + "/cnb",
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, relocated, &RelocatedBuilder{
+				Builder:   privateHost + "/cnb/base:1.0",
+				RunImages: map[string]string{publicHost + "/stacks/run:base": privateHost + "/cnb/run:base"},
+			})
+			h.AssertEq(t, remoteDigestOf(privateHost+"/cnb/base:1.0"), builderDigest)
+			h.AssertEq(t, remoteDigestOf(privateHost+"/cnb/run:base"), runImageDigest)
+		})
+
+		it("fails for images that aren't builders", func() {
+			writeImage(publicHost+"/some/app:latest", nil)
+
+			_, err := subject.RelocateBuilder(context.TODO(), RelocateBuilderOptions{
+				Builder:  publicHost + "/some/app:latest",
+				Registry: privateHost,
+			})
+			h.AssertError(t, err, "missing label 'io.buildpacks.builder.metadata'")
+		})
+	})
+}
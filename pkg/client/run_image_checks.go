@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+)
+
+// The statuses of a run image, or of a mirror of a run image, in its registry.
+const (
+	RunImageAvailable   = "available"
+	RunImageUnreachable = "unreachable"
+	// Mirrors holding an image other than the run image they mirror.
+	RunImageStale = "stale"
+)
+
+// RunImageCheck is the availability of a run image, or of a mirror of a run image, in its registry.
+type RunImageCheck struct {
+	// Name of the run image, or of the mirror.
+	Image string
+
+	// Run image the mirror mirrors, the run image itself for run images.
+	RunImage string
+
+	// Digest of the image in the registry, when it could be read.
+	Digest string
+
+	// Status of the image, one of the RunImage statuses.
+	Status string
+
+	// Error reading the image, for unreachable images.
+	Error string
+}
+
+// checkRunImages reads the digest of each run image and of each of its mirrors from their registries, flagging the
+// mirrors that could not be read as unreachable, and the mirrors with a digest other than the run image as stale
+func (c *Client) checkRunImages(ctx context.Context, runImages, additionalMirrors []pubbldr.RunImageConfig) []RunImageCheck {
+	remoteOpts := []ggcrremote.Option{ggcrremote.WithAuthFromKeychain(c.keychain), ggcrremote.WithContext(ctx)}
+
+	var checks []RunImageCheck
+	for _, runImage := range runImages {
+		if runImage.Image == "" {
+			continue
+		}
+
+		runImageCheck := checkRunImage(runImage.Image, runImage.Image, "", remoteOpts...)
+		checks = append(checks, runImageCheck)
+
+		var mirrors []string
+		for _, additional := range additionalMirrors {
+			if additional.Image == runImage.Image {
+				mirrors = append(mirrors, additional.Mirrors...)
+			}
+		}
+		mirrors = append(mirrors, runImage.Mirrors...)
+
+		for _, mirror := range mirrors {
+			checks = append(checks, checkRunImage(mirror, runImage.Image, runImageCheck.Digest, remoteOpts...))
+		}
+	}
+	return checks
+}
+
+func checkRunImage(imageName, runImage, expectedDigest string, opts ...ggcrremote.Option) RunImageCheck {
+	check := RunImageCheck{Image: imageName, RunImage: runImage}
+
+	digest, err := remoteDigest(imageName, opts...)
+	switch {
+	case err != nil:
+		check.Status = RunImageUnreachable
+		check.Error = err.Error()
+	case expectedDigest != "" && digest != expectedDigest:
+		check.Status = RunImageStale
+	default:
+		check.Status = RunImageAvailable
+	}
+	check.Digest = digest
+	return check
+}
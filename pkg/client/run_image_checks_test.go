@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunImageChecks(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RunImageChecks", testRunImageChecks, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRunImageChecks(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject                           *Client
+		server                            *httptest.Server
+		host, runImageDigest, staleDigest string
+	)
+
+	push := func(imageName string, img v1.Image) string {
+		ref, err := name.ParseReference(host+"/"+imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, img))
+		hash, err := img.Digest()
+		h.AssertNil(t, err)
+		return hash.String()
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.TrimPrefix(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1), "http://")
+		subject = &Client{keychain: authn.DefaultKeychain}
+
+		runImage, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		staleImage, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+
+		runImageDigest = push("some/run", runImage)
+		push("mirror/run", runImage)
+		push("user/run", runImage)
+		staleDigest = push("stale/run", staleImage)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#checkRunImages", func() {
+		it("flags the mirrors that are unreachable or hold another image", func() {
+			runImage := host + "/some/run"
+			checks := subject.checkRunImages(context.TODO(),
+				[]pubbldr.RunImageConfig{{
+					Image:   runImage,
+					Mirrors: []string{host + "/mirror/run", host + "/stale/run", host + "/missing/run"},
+				}},
+				[]pubbldr.RunImageConfig{{Image: runImage, Mirrors: []string{host + "/user/run"}}},
+			)
+
+			h.AssertEq(t, len(checks), 5)
+			h.AssertEq(t, checks[0], RunImageCheck{Image: runImage, RunImage: runImage, Digest: runImageDigest, Status: RunImageAvailable})
+			h.AssertEq(t, checks[1], RunImageCheck{Image: host + "/user/run", RunImage: runImage, Digest: runImageDigest, Status: RunImageAvailable})
+			h.AssertEq(t, checks[2], RunImageCheck{Image: host + "/mirror/run", RunImage: runImage, Digest: runImageDigest, Status: RunImageAvailable})
+			h.AssertEq(t, checks[3], RunImageCheck{Image: host + "/stale/run", RunImage: runImage, Digest: staleDigest, Status: RunImageStale})
+			h.AssertEq(t, checks[4].Status, RunImageUnreachable)
+			h.AssertContains(t, checks[4].Error, "404 Not Found")
+		})
+
+		it("does not flag mirrors as stale when the run image is unreachable", func() {
+			runImage := host + "/missing/run"
+			checks := subject.checkRunImages(context.TODO(),
+				[]pubbldr.RunImageConfig{{Image: runImage, Mirrors: []string{host + "/mirror/run"}}},
+				nil,
+			)
+
+			h.AssertEq(t, len(checks), 2)
+			h.AssertEq(t, checks[0].Status, RunImageUnreachable)
+			h.AssertEq(t, checks[1].Status, RunImageAvailable)
+		})
+	})
+}
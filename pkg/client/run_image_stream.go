@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// streamImageWithLayer streams an image read from a registry, with the layer in layerTar on top of it, into the daemon
+// as a tarball named imageName. Builds publishing to a registry use it for the images their containers run from, so
+// that the images they read from the registry are never pulled into the daemon storage.
+func (c *Client) streamImageWithLayer(ctx context.Context, img imgutil.Image, layerTar, imageName string) error {
+	base := img.UnderlyingImage()
+	if base == nil {
+		return errors.Errorf("reading the layers of %s", style.Symbol(img.Name()))
+	}
+	layer, err := tarball.LayerFromFile(layerTar)
+	if err != nil {
+		return errors.Wrapf(err, "reading layer %s", style.Symbol(layerTar))
+	}
+	withLayer, err := mutate.AppendLayers(base, layer)
+	if err != nil {
+		return errors.Wrapf(err, "adding layer to %s", style.Symbol(img.Name()))
+	}
+	tag, err := name.NewTag(imageName, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarball.Write(tag, withLayer, pw))
+	}()
+	defer pr.Close()
+
+	res, err := c.docker.ImageLoad(ctx, pr, true)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", style.Symbol(imageName))
+	}
+	defer res.Body.Close()
+	return jsonmessage.DisplayJSONMessagesStream(res.Body, io.Discard, 0, false, nil)
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunImageStream(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RunImageStream", testRunImageStream, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRunImageStream(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		outBuf         bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker: mockDocker,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#streamImageWithLayer", func() {
+		it("loads the image with the layer on top of it into the daemon", func() {
+			base, err := random.Image(1024, 2)
+			h.AssertNil(t, err)
+			runImage := &provenanceImage{Image: fakes.NewImage("some/run", "", nil), underlying: base}
+
+			layerTar := filepath.Join(t.TempDir(), "lifecycle.tar")
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddFile("cnb/lifecycle/launcher", 0755, archive.NormalizedDateTime, []byte("launcher"))
+			contents, err := io.ReadAll(tarBuilder.Reader(archive.DefaultTarWriterFactory()))
+			h.AssertNil(t, err)
+			h.AssertNil(t, os.WriteFile(layerTar, contents, 0600))
+
+			var loaded []byte
+			mockDocker.EXPECT().ImageLoad(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, input io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					loaded, err = io.ReadAll(input)
+					h.AssertNil(t, err)
+					return types.ImageLoadResponse{Body: io.NopCloser(bytes.NewBufferString(`{"stream":"Loaded image"}`))}, nil
+				})
+
+			h.AssertNil(t, subject.streamImageWithLayer(context.TODO(), runImage, layerTar, "pack.local/run-image/some:latest"))
+
+			img, err := tarball.Image(func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(loaded)), nil
+			}, nil)
+			h.AssertNil(t, err)
+			layers, err := img.Layers()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(layers), 3)
+
+			baseLayers, err := base.Layers()
+			h.AssertNil(t, err)
+			for i, layer := range baseLayers {
+				h.AssertEq(t, diffIDOf(t, layers[i]), diffIDOf(t, layer))
+			}
+		})
+
+		it("fails when the daemon cannot load the image", func() {
+			base, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			runImage := &provenanceImage{Image: fakes.NewImage("some/run", "", nil), underlying: base}
+
+			layerTar := filepath.Join(t.TempDir(), "lifecycle.tar")
+			h.AssertNil(t, os.WriteFile(layerTar, []byte{}, 0600))
+
+			mockDocker.EXPECT().ImageLoad(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, input io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					_, _ = io.Copy(io.Discard, input)
+					return types.ImageLoadResponse{Body: io.NopCloser(bytes.NewBufferString(`{"errorDetail":{"message":"no space left on device"},"error":"no space left on device"}`))}, nil
+				})
+
+			err = subject.streamImageWithLayer(context.TODO(), runImage, layerTar, "pack.local/run-image/some:latest")
+			h.AssertError(t, err, "no space left on device")
+		})
+	})
+}
+
+func diffIDOf(t *testing.T, layer v1.Layer) string {
+	t.Helper()
+	diffID, err := layer.DiffID()
+	h.AssertNil(t, err)
+	return diffID.String()
+}
@@ -0,0 +1,349 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// ScannerNone disables scanning of the built image.
+	ScannerNone = "none"
+	// ScannerGrype scans the built image with https://github.com/anchore/grype.
+	ScannerGrype = "grype"
+	// ScannerTrivy scans the built image with https://github.com/aquasecurity/trivy.
+	ScannerTrivy = "trivy"
+
+	// ScanReportFile is the name of the file the scan results are written to, in BuildOptions.ReportDestinationDir.
+	ScanReportFile = "scan.json"
+)
+
+// Severities of vulnerabilities, from lowest to highest.
+var Severities = []string{"unknown", "negligible", "low", "medium", "high", "critical"}
+
+// ScanOptions configure a vulnerability scan of the built image.
+type ScanOptions struct {
+	// Scanner to use, one of grype, trivy or none.
+	Scanner string
+
+	// Scanner image to run. Defaults to the latest image published by the scanner project.
+	Image string
+
+	// Fail the build if a vulnerability with this severity or higher is found. Empty to never fail.
+	FailOn string
+}
+
+// ScanReport lists the vulnerabilities found in an image.
+type ScanReport struct {
+	Scanner         string          `json:"scanner"`
+	Image           string          `json:"image"`
+	Summary         map[string]int  `json:"summary"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// Vulnerability is a vulnerability found in a package of an image.
+type Vulnerability struct {
+	ID       string `json:"id"`
+	Package  string `json:"package"`
+	Version  string `json:"version"`
+	FixedIn  string `json:"fixedIn,omitempty"`
+	Severity string `json:"severity"`
+}
+
+type scanner struct {
+	image string
+	args  func(imageName string, daemon bool) []string
+	parse func(output []byte) ([]Vulnerability, error)
+}
+
+var scanners = map[string]scanner{
+	ScannerGrype: {
+		image: "anchore/grype:latest",
+		args: func(imageName string, daemon bool) []string {
+			source := "registry:" + imageName
+			if daemon {
+				source = "docker:" + imageName
+			}
+			return []string{source, "--output", "json", "--quiet"}
+		},
+		parse: parseGrypeOutput,
+	},
+	ScannerTrivy: {
+		image: "aquasec/trivy:latest",
+		args: func(imageName string, daemon bool) []string {
+			source := "remote"
+			if daemon {
+				source = "docker"
+			}
+			return []string{"image", "--image-src", source, "--format", "json", "--quiet", imageName}
+		},
+		parse: parseTrivyOutput,
+	},
+}
+
+// ValidateScanOptions returns an error if the scanner or severity threshold is not supported.
+func ValidateScanOptions(opts ScanOptions) error {
+	if _, ok := scanners[opts.Scanner]; !ok && opts.Scanner != "" && opts.Scanner != ScannerNone {
+		return errors.Errorf("unsupported scanner %s, must be one of %s, %s or %s", style.Symbol(opts.Scanner), ScannerGrype, ScannerTrivy, ScannerNone)
+	}
+	if opts.FailOn != "" && severityRank(opts.FailOn) < 0 {
+		return errors.Errorf("invalid severity %s, must be one of %s", style.Symbol(opts.FailOn), strings.Join(Severities, ", "))
+	}
+	return nil
+}
+
+// scanImage runs the requested scanner against the built image, writes the results to the report directory
+// and returns an error if vulnerabilities at or above the failure threshold were found
+func (c *Client) scanImage(ctx context.Context, imageRef name.Reference, opts BuildOptions) (err error) {
+	s, ok := scanners[opts.Scan.Scanner]
+	if !ok {
+		return nil
+	}
+
+	ctx, span := c.startSpan(ctx, "scan", attribute.String("pack.image", imageRef.Name()), attribute.String("pack.scanner", opts.Scan.Scanner))
+	defer func() { endSpan(span, err) }()
+
+	scannerImage := s.image
+	if opts.Scan.Image != "" {
+		scannerImage = opts.Scan.Image
+	}
+	if _, err := c.imageFetcher.Fetch(ctx, scannerImage, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy}); err != nil {
+		return errors.Wrapf(err, "fetching scanner image %s", style.Symbol(scannerImage))
+	}
+
+	c.logger.Infof("Scanning %s with %s", style.Symbol(imageRef.Name()), opts.Scan.Scanner)
+	daemon := !opts.Publish
+	hostConfig := &containertypes.HostConfig{
+		UsernsMode:  containertypes.UsernsMode(opts.ContainerConfig.UsernsMode),
+		SecurityOpt: opts.ContainerConfig.SecurityOpts,
+		CapAdd:      opts.ContainerConfig.CapAdd,
+		CapDrop:     opts.ContainerConfig.CapDrop,
+	}
+	var env []string
+	if daemon {
+		bind, dockerHost := scannerDaemonAccess(opts.DockerHost)
+		if bind != "" {
+			hostConfig.Binds = append(hostConfig.Binds, bind)
+		}
+		if dockerHost != "" {
+			env = append(env, "DOCKER_HOST="+dockerHost)
+		}
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx, &containertypes.Config{
+		Image: scannerImage,
+		Cmd:   s.args(imageRef.Name(), daemon),
+		Env:   env,
+	}, hostConfig, nil, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "creating scanner container")
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctr.ID, containertypes.RemoveOptions{Force: true})
+
+	result, err := container.Run(ctx, c.docker, ctr.ID)
+	if len(result.Stderr) > 0 {
+		c.logger.Debug(strings.TrimSpace(string(result.Stderr)))
+	}
+	if err != nil {
+		var exitErr *container.ExitError
+		if errors.As(err, &exitErr) && len(result.Stderr) > 0 {
+			return errors.Errorf("running %s: exited with status code %d: %s", opts.Scan.Scanner, exitErr.Code, lastLine(result.Stderr))
+		}
+		return errors.Wrapf(err, "running %s", opts.Scan.Scanner)
+	}
+
+	vulnerabilities, err := s.parse(result.Stdout)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s output", opts.Scan.Scanner)
+	}
+
+	report := newScanReport(opts.Scan.Scanner, imageRef.Name(), vulnerabilities)
+	c.logScanSummary(report)
+
+	if opts.ReportDestinationDir != "" {
+		if err := writeScanReport(report, opts.ReportDestinationDir); err != nil {
+			return err
+		}
+	}
+
+	if opts.Scan.FailOn != "" {
+		threshold := severityRank(opts.Scan.FailOn)
+		var count int
+		for _, v := range report.Vulnerabilities {
+			if severityRank(v.Severity) >= threshold {
+				count++
+			}
+		}
+		if count > 0 {
+			return errors.Errorf("found %d vulnerabilities with severity %s or higher in %s", count, opts.Scan.FailOn, style.Symbol(imageRef.Name()))
+		}
+	}
+	return nil
+}
+
+func newScanReport(scannerName, imageName string, vulnerabilities []Vulnerability) ScanReport {
+	report := ScanReport{
+		Scanner:         scannerName,
+		Image:           imageName,
+		Summary:         map[string]int{},
+		Vulnerabilities: []Vulnerability{},
+	}
+	for _, v := range vulnerabilities {
+		v.Severity = normalizeSeverity(v.Severity)
+		report.Summary[v.Severity]++
+		report.Vulnerabilities = append(report.Vulnerabilities, v)
+	}
+	sort.SliceStable(report.Vulnerabilities, func(i, j int) bool {
+		return severityRank(report.Vulnerabilities[i].Severity) > severityRank(report.Vulnerabilities[j].Severity)
+	})
+	return report
+}
+
+func (c *Client) logScanSummary(report ScanReport) {
+	if len(report.Vulnerabilities) == 0 {
+		c.logger.Infof("No vulnerabilities found in %s", style.Symbol(report.Image))
+		return
+	}
+
+	var counts []string
+	for i := len(Severities) - 1; i >= 0; i-- {
+		if n := report.Summary[Severities[i]]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%s: %d", Severities[i], n))
+		}
+	}
+	c.logger.Warnf("Found %d vulnerabilities in %s (%s)", len(report.Vulnerabilities), style.Symbol(report.Image), strings.Join(counts, ", "))
+}
+
+func writeScanReport(report ScanReport, dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "creating report directory %s", style.Symbol(dir))
+	}
+
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling scan report")
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 306 in this case
+	// G306: Expect WriteFile permissions to be 0600 or less
+	/* #nosec G306 */
+	if err := os.WriteFile(filepath.Join(dir, ScanReportFile), contents, 0644); err != nil {
+		return errors.Wrap(err, "writing scan report")
+	}
+	return nil
+}
+
+// scannerDaemonAccess returns the bind mount or DOCKER_HOST value giving the scanner access to the daemon
+func scannerDaemonAccess(dockerHost string) (string, string) {
+	if dockerHost == "inherit" {
+		dockerHost = os.Getenv("DOCKER_HOST")
+	}
+	switch {
+	case dockerHost == "":
+		return "/var/run/docker.sock:/var/run/docker.sock", ""
+	case strings.HasPrefix(dockerHost, "unix://"):
+		return fmt.Sprintf("%s:/var/run/docker.sock", strings.TrimPrefix(dockerHost, "unix://")), ""
+	default:
+		return "", dockerHost
+	}
+}
+
+func normalizeSeverity(severity string) string {
+	severity = strings.ToLower(severity)
+	if severityRank(severity) < 0 {
+		return "unknown"
+	}
+	return severity
+}
+
+func severityRank(severity string) int {
+	for i, s := range Severities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseGrypeOutput(output []byte) ([]Vulnerability, error) {
+	var result struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID       string `json:"id"`
+				Severity string `json:"severity"`
+				Fix      struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"artifact"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, err
+	}
+
+	var vulnerabilities []Vulnerability
+	for _, m := range result.Matches {
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			ID:       m.Vulnerability.ID,
+			Package:  m.Artifact.Name,
+			Version:  m.Artifact.Version,
+			FixedIn:  strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity: m.Vulnerability.Severity,
+		})
+	}
+	return vulnerabilities, nil
+}
+
+func parseTrivyOutput(output []byte) ([]Vulnerability, error) {
+	var result struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, err
+	}
+
+	var vulnerabilities []Vulnerability
+	for _, r := range result.Results {
+		for _, v := range r.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:       v.VulnerabilityID,
+				Package:  v.PkgName,
+				Version:  v.InstalledVersion,
+				FixedIn:  v.FixedVersion,
+				Severity: v.Severity,
+			})
+		}
+	}
+	return vulnerabilities, nil
+}
+
+// lastLine returns the last non-empty line of a scanner's output, which is usually the error it failed with
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestScan(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Scan", testScan, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testScan(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDocker       *testmocks.MockCommonAPIClient
+		mockImageFetcher *testmocks.MockImageFetcher
+		imageRef         name.Reference
+		reportDir        string
+		outBuf           bytes.Buffer
+	)
+
+	const grypeOutput = `{"matches": [
+  {"vulnerability": {"id": "CVE-2024-0001", "severity": "Medium", "fix": {"versions": []}}, "artifact": {"name": "zlib", "version": "1.2.11"}},
+  {"vulnerability": {"id": "CVE-2024-0002", "severity": "Critical", "fix": {"versions": ["3.0.1"]}}, "artifact": {"name": "openssl", "version": "3.0.0"}}
+]}`
+
+	// expectScannerExit expects the scanner container to be run with cmd, writing output to stdout and errOutput to
+	// stderr, and exiting with statusCode
+	expectScannerExit := func(cmd []string, binds []string, output, errOutput string, statusCode int64) {
+		var framed bytes.Buffer
+		_, err := stdcopy.NewStdWriter(&framed, stdcopy.Stdout).Write([]byte(output))
+		h.AssertNil(t, err)
+		_, err = stdcopy.NewStdWriter(&framed, stdcopy.Stderr).Write([]byte(errOutput))
+		h.AssertNil(t, err)
+
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "anchore/grype:latest", image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}).
+			Return(fakes.NewImage("anchore/grype:latest", "", nil), nil)
+		mockDocker.EXPECT().ContainerCreate(gomock.Any(), &containertypes.Config{Image: "anchore/grype:latest", Cmd: cmd}, &containertypes.HostConfig{Binds: binds}, nil, nil, "").
+			Return(containertypes.CreateResponse{ID: "scanner-id"}, nil)
+		mockDocker.EXPECT().ContainerWait(gomock.Any(), "scanner-id", gomock.Any()).DoAndReturn(
+			func(context.Context, string, containertypes.WaitCondition) (<-chan containertypes.WaitResponse, <-chan error) {
+				bodyChan := make(chan containertypes.WaitResponse, 1)
+				bodyChan <- containertypes.WaitResponse{StatusCode: statusCode}
+				return bodyChan, make(chan error)
+			})
+		conn, _ := net.Pipe()
+		mockDocker.EXPECT().ContainerAttach(gomock.Any(), "scanner-id", gomock.Any()).
+			Return(types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&framed)}, nil)
+		mockDocker.EXPECT().ContainerStart(gomock.Any(), "scanner-id", gomock.Any()).Return(nil)
+		mockDocker.EXPECT().ContainerRemove(gomock.Any(), "scanner-id", containertypes.RemoveOptions{Force: true}).Return(nil)
+	}
+
+	// expectScannerRun expects the scanner container to be run with cmd, writing output to stdout
+	expectScannerRun := func(cmd []string, binds []string, output string) {
+		expectScannerExit(cmd, binds, output, "", 0)
+	}
+
+	it.Before(func() {
+		var err error
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker:       mockDocker,
+			imageFetcher: mockImageFetcher,
+		}
+
+		imageRef, err = name.ParseReference("some/app", name.WeakValidation)
+		h.AssertNil(t, err)
+		reportDir, err = os.MkdirTemp("", "scan-report")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(reportDir))
+	})
+
+	when("#scanImage", func() {
+		it("does nothing when no scanner is selected", func() {
+			h.AssertNil(t, subject.scanImage(context.TODO(), imageRef, BuildOptions{Scan: ScanOptions{Scanner: ScannerNone}}))
+		})
+
+		it("scans the daemon image and writes the scan report", func() {
+			expectScannerRun(
+				[]string{"docker:index.docker.io/some/app:latest", "--output", "json", "--quiet"},
+				[]string{"/var/run/docker.sock:/var/run/docker.sock"},
+				grypeOutput,
+			)
+
+			err := subject.scanImage(context.TODO(), imageRef, BuildOptions{
+				PullPolicy:           image.PullIfNotPresent,
+				ReportDestinationDir: reportDir,
+				Scan:                 ScanOptions{Scanner: ScannerGrype},
+			})
+			h.AssertNil(t, err)
+			h.AssertContains(t, outBuf.String(), "Found 2 vulnerabilities in 'index.docker.io/some/app:latest' (critical: 1, medium: 1)")
+
+			contents, err := os.ReadFile(filepath.Join(reportDir, ScanReportFile))
+			h.AssertNil(t, err)
+			var scanReport ScanReport
+			h.AssertNil(t, json.Unmarshal(contents, &scanReport))
+			h.AssertEq(t, scanReport.Scanner, "grype")
+			h.AssertEq(t, scanReport.Summary, map[string]int{"critical": 1, "medium": 1})
+			h.AssertEq(t, scanReport.Vulnerabilities[0], Vulnerability{ID: "CVE-2024-0002", Package: "openssl", Version: "3.0.0", FixedIn: "3.0.1", Severity: "critical"})
+		})
+
+		it("scans the published image and fails on the severity threshold", func() {
+			expectScannerRun(
+				[]string{"registry:index.docker.io/some/app:latest", "--output", "json", "--quiet"},
+				nil,
+				grypeOutput,
+			)
+
+			err := subject.scanImage(context.TODO(), imageRef, BuildOptions{
+				Publish:    true,
+				PullPolicy: image.PullIfNotPresent,
+				Scan:       ScanOptions{Scanner: ScannerGrype, FailOn: "high"},
+			})
+			h.AssertError(t, err, "found 1 vulnerabilities with severity high or higher in 'index.docker.io/some/app:latest'")
+		})
+
+		it("returns the error the scanner failed with", func() {
+			expectScannerExit(
+				[]string{"registry:index.docker.io/some/app:latest", "--output", "json", "--quiet"},
+				nil,
+				"",
+				"loading image\nfailed to fetch image: unauthorized\n",
+				1,
+			)
+
+			err := subject.scanImage(context.TODO(), imageRef, BuildOptions{
+				Publish:    true,
+				PullPolicy: image.PullIfNotPresent,
+				Scan:       ScanOptions{Scanner: ScannerGrype},
+			})
+			h.AssertError(t, err, "running grype: exited with status code 1: failed to fetch image: unauthorized")
+		})
+	})
+
+	when("#parseTrivyOutput", func() {
+		it("reads the vulnerabilities of every result", func() {
+			vulnerabilities, err := parseTrivyOutput([]byte(`{"Results": [
+  {"Vulnerabilities": [{"VulnerabilityID": "CVE-2024-0003", "PkgName": "curl", "InstalledVersion": "7.0", "FixedVersion": "8.0", "Severity": "HIGH"}]},
+  {"Vulnerabilities": null}
+]}`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, vulnerabilities, []Vulnerability{{ID: "CVE-2024-0003", Package: "curl", Version: "7.0", FixedIn: "8.0", Severity: "HIGH"}})
+		})
+	})
+
+	when("#ValidateScanOptions", func() {
+		it("rejects unknown scanners", func() {
+			h.AssertError(t, ValidateScanOptions(ScanOptions{Scanner: "clair"}), "unsupported scanner 'clair', must be one of grype, trivy or none")
+		})
+
+		it("rejects unknown severities", func() {
+			h.AssertError(t, ValidateScanOptions(ScanOptions{Scanner: ScannerTrivy, FailOn: "severe"}), "invalid severity 'severe'")
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// processSecurityOpts replaces the paths of seccomp profiles in the security options with the contents of the profiles,
+// the way the docker CLI does, as the daemon expects the profile itself
+func processSecurityOpts(securityOpts []string) ([]string, error) {
+	var processed []string
+	for _, opt := range securityOpts {
+		key, value, _ := strings.Cut(opt, "=")
+		if key == "seccomp" && value != "unconfined" && value != "builtin" {
+			profile, err := os.ReadFile(filepath.Clean(value))
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading seccomp profile %s", style.Symbol(value))
+			}
+
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, profile); err != nil {
+				return nil, errors.Wrapf(err, "parsing seccomp profile %s", style.Symbol(value))
+			}
+			opt = "seccomp=" + compacted.String()
+		}
+		processed = append(processed, opt)
+	}
+	return processed, nil
+}
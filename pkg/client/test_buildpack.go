@@ -0,0 +1,317 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	dockerimage "github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// BuildpackTestDetectPass expects detection to pass for the app of a test, which is the default.
+	BuildpackTestDetectPass = "pass"
+
+	// BuildpackTestDetectFail expects detection to fail for the app of a test.
+	BuildpackTestDetectFail = "fail"
+
+	// detectFailedExitCode is the code the lifecycle exits with when no group of buildpacks passes detection
+	detectFailedExitCode = 20
+)
+
+// BuildpackTestsConfig is the configuration of the tests of a buildpack, as read from a tests.toml file.
+type BuildpackTestsConfig struct {
+	// Builder to build the apps of the tests with.
+	Builder string `toml:"builder"`
+
+	// Path of the buildpack to test, relative to the tests.toml file. Defaults to the directory of the file.
+	Buildpack string `toml:"buildpack"`
+
+	Tests []BuildpackTest `toml:"tests"`
+}
+
+// BuildpackTest is a fixture app to build with the tested buildpack, and the expected outcome of the build.
+type BuildpackTest struct {
+	Name string `toml:"name"`
+
+	// Path of the app, relative to the tests.toml file.
+	App string `toml:"app"`
+
+	// Expected outcome of detection, either BuildpackTestDetectPass or BuildpackTestDetectFail.
+	Detect string `toml:"detect"`
+
+	// Processes expected on the app image.
+	Processes []BuildpackTestProcess `toml:"processes"`
+
+	// Environment variables expected on the app image, and their values.
+	Env map[string]string `toml:"env"`
+}
+
+// BuildpackTestProcess is a process expected on an app image.
+type BuildpackTestProcess struct {
+	Type string `toml:"type"`
+
+	// Command of the process, with its arguments, separated by spaces. Any command is expected when empty.
+	Command string `toml:"command"`
+}
+
+// BuildpackTestResult is the outcome of a test of a buildpack.
+type BuildpackTestResult struct {
+	Name string `json:"name"`
+
+	// Reasons the test failed. The test passed when empty.
+	Failures []string `json:"failures,omitempty"`
+}
+
+// Passed returns whether the test passed.
+func (r BuildpackTestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// TestBuildpackOptions define the tests to run on a buildpack.
+type TestBuildpackOptions struct {
+	// Path of the tests.toml file defining the tests.
+	ConfigPath string
+
+	// Builder to build with, overriding the builder of the config.
+	Builder string
+
+	// Buildpack to test, overriding the buildpack of the config.
+	Buildpack string
+
+	// Names of the tests to run. Every test is run when empty.
+	Names []string
+
+	// Strategy for updating images before the builds.
+	PullPolicy image.PullPolicy
+
+	// Whether the builder is trusted.
+	TrustBuilder IsTrustedBuilder
+}
+
+// ReadBuildpackTestsConfig reads the tests of a buildpack from a tests.toml file.
+func ReadBuildpackTestsConfig(path string) (BuildpackTestsConfig, error) {
+	var config BuildpackTestsConfig
+	md, err := toml.DecodeFile(path, &config)
+	if err != nil {
+		return BuildpackTestsConfig{}, errors.Wrapf(err, "reading tests config %s", style.Symbol(path))
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return BuildpackTestsConfig{}, errors.Errorf("unknown keys %s in tests config %s", style.Symbol(fmt.Sprintf("%v", undecoded)), style.Symbol(path))
+	}
+
+	for i, test := range config.Tests {
+		if test.Name == "" {
+			return BuildpackTestsConfig{}, errors.Errorf("test %d of %s has no name", i+1, style.Symbol(path))
+		}
+		if test.App == "" {
+			return BuildpackTestsConfig{}, errors.Errorf("test %s has no app", style.Symbol(test.Name))
+		}
+		switch test.Detect {
+		case "", BuildpackTestDetectPass, BuildpackTestDetectFail:
+		default:
+			return BuildpackTestsConfig{}, errors.Errorf("test %s expects detection to %s, must be one of %s or %s", style.Symbol(test.Name), style.Symbol(test.Detect), BuildpackTestDetectPass, BuildpackTestDetectFail)
+		}
+		for _, process := range test.Processes {
+			if process.Type == "" {
+				return BuildpackTestsConfig{}, errors.Errorf("test %s expects a process without a type", style.Symbol(test.Name))
+			}
+		}
+	}
+	return config, nil
+}
+
+// TestBuildpack builds the fixture apps of the tests of a buildpack with it, and checks the outcome of each build
+// against the one the test expects. Builds failing are reported as failed tests, rather than returned as errors.
+func (c *Client) TestBuildpack(ctx context.Context, opts TestBuildpackOptions) ([]BuildpackTestResult, error) {
+	config, err := ReadBuildpackTestsConfig(opts.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	baseDir := filepath.Dir(opts.ConfigPath)
+
+	builder := opts.Builder
+	if builder == "" {
+		builder = config.Builder
+	}
+	if builder == "" {
+		return nil, errors.Errorf("no builder provided, and none in %s", style.Symbol(opts.ConfigPath))
+	}
+
+	bp := opts.Buildpack
+	if bp == "" {
+		bp = config.Buildpack
+		if !paths.IsURI(bp) {
+			bp = resolveTestPath(baseDir, bp)
+		}
+	}
+
+	tests, err := selectBuildpackTests(config.Tests, opts.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BuildpackTestResult
+	for _, test := range tests {
+		c.logger.Infof("Running test %s", style.Symbol(test.Name))
+		result := c.runBuildpackTest(ctx, test, bp, builder, baseDir, opts)
+		if result.Passed() {
+			c.logger.Infof("Test %s passed", style.Symbol(test.Name))
+		} else {
+			c.logger.Infof("Test %s failed", style.Symbol(test.Name))
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *Client) runBuildpackTest(ctx context.Context, test BuildpackTest, bp, builder, baseDir string, opts TestBuildpackOptions) BuildpackTestResult {
+	imageName, err := testImageName()
+	if err != nil {
+		return BuildpackTestResult{Name: test.Name, Failures: []string{err.Error()}}
+	}
+
+	buildErr := c.Build(ctx, BuildOptions{
+		Image:        imageName,
+		Builder:      builder,
+		AppPath:      resolveTestPath(baseDir, test.App),
+		Buildpacks:   []string{bp},
+		PullPolicy:   opts.PullPolicy,
+		TrustBuilder: opts.TrustBuilder,
+	})
+	if buildErr == nil {
+		defer func() {
+			if _, err := c.docker.ImageRemove(context.Background(), imageName, dockerimage.RemoveOptions{Force: true, PruneChildren: true}); err != nil {
+				c.logger.Debugf("Removing test image %s: %s", style.Symbol(imageName), err)
+			}
+		}()
+	}
+
+	return c.checkBuildpackTest(ctx, test, imageName, buildErr)
+}
+
+// checkBuildpackTest checks the outcome of the build of the app of a test against the one the test expects
+func (c *Client) checkBuildpackTest(ctx context.Context, test BuildpackTest, imageName string, buildErr error) BuildpackTestResult {
+	result := BuildpackTestResult{Name: test.Name}
+	failf := func(format string, args ...interface{}) {
+		result.Failures = append(result.Failures, fmt.Sprintf(format, args...))
+	}
+
+	var exitErr *container.ExitError
+	detectFailed := errors.As(buildErr, &exitErr) && exitErr.Code == detectFailedExitCode
+	if test.Detect == BuildpackTestDetectFail {
+		switch {
+		case detectFailed:
+		case buildErr != nil:
+			failf("expected detection to fail, but the build failed: %s", buildErr)
+		default:
+			failf("expected detection to fail, but it passed")
+		}
+		return result
+	}
+	if detectFailed {
+		failf("expected detection to pass, but it failed")
+		return result
+	}
+	if buildErr != nil {
+		failf("build failed: %s", buildErr)
+		return result
+	}
+
+	if len(test.Processes) > 0 {
+		info, err := c.InspectImage(imageName, true)
+		if err != nil || info == nil {
+			failf("inspecting app image: %v", err)
+			return result
+		}
+
+		processes := info.Processes.OtherProcesses
+		if info.Processes.DefaultProcess != nil {
+			processes = append(processes, *info.Processes.DefaultProcess)
+		}
+		commands := map[string]string{}
+		for _, process := range processes {
+			commands[process.Type] = strings.Join(append(process.Command.Entries, process.Args...), " ")
+		}
+		for _, expected := range test.Processes {
+			command, ok := commands[expected.Type]
+			switch {
+			case !ok:
+				failf("expected process %s, but the app image has none", style.Symbol(expected.Type))
+			case expected.Command != "" && command != expected.Command:
+				failf("expected process %s to run %s, but it runs %s", style.Symbol(expected.Type), style.Symbol(expected.Command), style.Symbol(command))
+			}
+		}
+	}
+
+	if len(test.Env) > 0 {
+		img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+		if err != nil {
+			failf("reading app image: %s", err)
+			return result
+		}
+
+		keys := make([]string, 0, len(test.Env))
+		for key := range test.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, err := img.Env(key)
+			if err != nil {
+				failf("reading environment variable %s: %s", style.Symbol(key), err)
+				continue
+			}
+			if value != test.Env[key] {
+				failf("expected environment variable %s to be %s, but it is %s", style.Symbol(key), style.Symbol(test.Env[key]), style.Symbol(value))
+			}
+		}
+	}
+	return result
+}
+
+func selectBuildpackTests(tests []BuildpackTest, names []string) ([]BuildpackTest, error) {
+	if len(names) == 0 {
+		return tests, nil
+	}
+
+	byName := map[string]BuildpackTest{}
+	for _, test := range tests {
+		byName[test.Name] = test
+	}
+	var selected []BuildpackTest
+	for _, name := range names {
+		test, ok := byName[name]
+		if !ok {
+			return nil, errors.Errorf("no test named %s", style.Symbol(name))
+		}
+		selected = append(selected, test)
+	}
+	return selected, nil
+}
+
+func resolveTestPath(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+func testImageName() (string, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", errors.Wrap(err, "generating test image name")
+	}
+	return "pack-test-" + hex.EncodeToString(suffix), nil
+}
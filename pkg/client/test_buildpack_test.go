@@ -0,0 +1,215 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTestBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TestBuildpack", testTestBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTestBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		tmpDir           string
+		outBuf           bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher: mockImageFetcher,
+		}
+		tmpDir = t.TempDir()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	writeConfig := func(contents string) string {
+		path := filepath.Join(tmpDir, "tests.toml")
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+
+	when("#ReadBuildpackTestsConfig", func() {
+		it("reads the tests", func() {
+			config, err := ReadBuildpackTestsConfig(writeConfig(`
+builder = "some/builder"
+
+[[tests]]
+name = "go app"
+app = "fixtures/go"
+
+[[tests.processes]]
+type = "web"
+command = "/workspace/app --port 8080"
+
+[tests.env]
+GOPATH = "/go"
+
+[[tests]]
+name = "no go"
+app = "fixtures/node"
+detect = "fail"
+`))
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, config.Builder, "some/builder")
+			h.AssertEq(t, len(config.Tests), 2)
+			h.AssertEq(t, config.Tests[0].Processes, []BuildpackTestProcess{{Type: "web", Command: "/workspace/app --port 8080"}})
+			h.AssertEq(t, config.Tests[0].Env, map[string]string{"GOPATH": "/go"})
+			h.AssertEq(t, config.Tests[1].Detect, BuildpackTestDetectFail)
+		})
+
+		it("errors for unknown keys", func() {
+			_, err := ReadBuildpackTestsConfig(writeConfig(`
+[[tests]]
+name = "go app"
+app = "fixtures/go"
+expect = "pass"
+`))
+			h.AssertError(t, err, "unknown keys")
+		})
+
+		it("errors for an unknown detection outcome", func() {
+			_, err := ReadBuildpackTestsConfig(writeConfig(`
+[[tests]]
+name = "go app"
+app = "fixtures/go"
+detect = "maybe"
+`))
+			h.AssertError(t, err, "test 'go app' expects detection to 'maybe', must be one of pass or fail")
+		})
+
+		it("errors for tests without an app", func() {
+			_, err := ReadBuildpackTestsConfig(writeConfig(`
+[[tests]]
+name = "go app"
+`))
+			h.AssertError(t, err, "test 'go app' has no app")
+		})
+	})
+
+	when("#TestBuildpack", func() {
+		it("errors without a builder", func() {
+			_, err := subject.TestBuildpack(context.TODO(), TestBuildpackOptions{ConfigPath: writeConfig(`
+[[tests]]
+name = "go app"
+app = "fixtures/go"
+`)})
+			h.AssertError(t, err, "no builder provided")
+		})
+
+		it("errors for unknown test names", func() {
+			_, err := subject.TestBuildpack(context.TODO(), TestBuildpackOptions{
+				ConfigPath: writeConfig(`
+builder = "some/builder"
+
+[[tests]]
+name = "go app"
+app = "fixtures/go"
+`),
+				Names: []string{"node app"},
+			})
+			h.AssertError(t, err, "no test named 'node app'")
+		})
+	})
+
+	when("#checkBuildpackTest", func() {
+		detectFailed := errors.Wrap(&container.ExitError{Code: detectFailedExitCode}, "executing lifecycle")
+		buildFailed := errors.Wrap(&container.ExitError{Code: 51}, "executing lifecycle")
+
+		when("detection is expected to fail", func() {
+			test := BuildpackTest{Name: "no go", Detect: BuildpackTestDetectFail}
+
+			it("passes when detection fails", func() {
+				h.AssertEq(t, subject.checkBuildpackTest(context.TODO(), test, "some/app", detectFailed).Passed(), true)
+			})
+
+			it("fails when detection passes", func() {
+				result := subject.checkBuildpackTest(context.TODO(), test, "some/app", nil)
+				h.AssertEq(t, result.Failures, []string{"expected detection to fail, but it passed"})
+			})
+
+			it("fails when the build fails otherwise", func() {
+				result := subject.checkBuildpackTest(context.TODO(), test, "some/app", buildFailed)
+				h.AssertEq(t, result.Failures, []string{"expected detection to fail, but the build failed: executing lifecycle: failed with status code: 51"})
+			})
+		})
+
+		when("detection is expected to pass", func() {
+			it("fails when detection fails", func() {
+				result := subject.checkBuildpackTest(context.TODO(), BuildpackTest{Name: "go app"}, "some/app", detectFailed)
+				h.AssertEq(t, result.Failures, []string{"expected detection to pass, but it failed"})
+			})
+
+			it("fails when the build fails", func() {
+				result := subject.checkBuildpackTest(context.TODO(), BuildpackTest{Name: "go app"}, "some/app", buildFailed)
+				h.AssertEq(t, result.Failures, []string{"build failed: executing lifecycle: failed with status code: 51"})
+			})
+		})
+
+		when("processes and environment variables are expected", func() {
+			it.Before(func() {
+				appImage := testmocks.NewImage("some/app", "", nil)
+				h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata", `{}`))
+				h.AssertNil(t, appImage.SetLabel("io.buildpacks.build.metadata", `{
+  "processes": [{"type": "web", "command": ["/workspace/app"], "args": ["--port", "8080"]}],
+  "launcher": {"version": "0.5.0"}
+}`))
+				h.AssertNil(t, appImage.SetEnv("GOPATH", "/go"))
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(appImage, nil).AnyTimes()
+			})
+
+			it("passes when the app image has them", func() {
+				result := subject.checkBuildpackTest(context.TODO(), BuildpackTest{
+					Name:      "go app",
+					Processes: []BuildpackTestProcess{{Type: "web", Command: "/workspace/app --port 8080"}},
+					Env:       map[string]string{"GOPATH": "/go"},
+				}, "some/app", nil)
+				h.AssertEq(t, result.Passed(), true)
+			})
+
+			it("reports each difference", func() {
+				result := subject.checkBuildpackTest(context.TODO(), BuildpackTest{
+					Name: "go app",
+					Processes: []BuildpackTestProcess{
+						{Type: "web", Command: "/workspace/app"},
+						{Type: "worker"},
+					},
+					Env: map[string]string{"GOPATH": "/workspace/go", "CGO_ENABLED": "0"},
+				}, "some/app", nil)
+				h.AssertEq(t, result.Failures, []string{
+					"expected process 'web' to run '/workspace/app', but it runs '/workspace/app --port 8080'",
+					"expected process 'worker', but the app image has none",
+					"expected environment variable 'CGO_ENABLED' to be '0', but it is ''",
+					"expected environment variable 'GOPATH' to be '/workspace/go', but it is '/go'",
+				})
+			})
+		})
+	})
+}
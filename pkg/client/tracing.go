@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// tracerName is the name of the tracer of the spans of the operations run by the client
+const tracerName = "github.com/buildpacks/pack"
+
+// WithTracerProvider traces the operations run by the client, such as builds, image fetches, lifecycle phases, image
+// pushes and scans, with OpenTelemetry spans from the provider, for them to appear in the traces of the caller. No
+// spans are recorded by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracerProvider = provider
+	}
+}
+
+// tracer returns the tracer of the spans of the operations run by the client
+func (c *Client) tracer() trace.Tracer {
+	if c.tracerProvider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+	return c.tracerProvider.Tracer(tracerName)
+}
+
+// startSpan starts a span of the operation, as a child of the span of the context, if any
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends the span of an operation, with its error if it failed
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingImageFetcher traces image fetches
+type tracingImageFetcher struct {
+	ImageFetcher
+	tracer trace.Tracer
+}
+
+func (f *tracingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	ctx, span := f.tracer.Start(ctx, "fetch image", trace.WithAttributes(
+		attribute.String("pack.image", name),
+		attribute.Bool("pack.daemon", options.Daemon),
+		attribute.String("pack.pull_policy", options.PullPolicy.String()),
+	))
+	img, err := f.ImageFetcher.Fetch(ctx, name, options)
+	endSpan(span, err)
+	return img, err
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTracing(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Tracing", testTracing, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type fakeSpan struct {
+	noop.Span
+	name   string
+	attrs  map[string]string
+	status codes.Code
+	ended  bool
+}
+
+func (s *fakeSpan) SetStatus(code codes.Code, _ string)     { s.status = code }
+func (s *fakeSpan) RecordError(error, ...trace.EventOption) {}
+func (s *fakeSpan) End(...trace.SpanEndOption)              { s.ended = true }
+
+type fakeTracer struct {
+	embedded.Tracer
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &fakeSpan{name: name, attrs: map[string]string{}}
+	for _, attr := range config.Attributes() {
+		span.attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type fakeTracerProvider struct {
+	embedded.TracerProvider
+	fakeTracer
+}
+
+func (p *fakeTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer { return &p.fakeTracer }
+
+func testTracing(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		provider         *fakeTracerProvider
+		fakeImageFetcher *ifakes.FakeImageFetcher
+	)
+
+	it.Before(func() {
+		provider = &fakeTracerProvider{}
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.LocalImages["some/image"] = fakes.NewImage("some/image", "", nil)
+
+		var err error
+		subject, err = NewClient(WithFetcher(fakeImageFetcher), WithTracerProvider(provider))
+		h.AssertNil(t, err)
+	})
+
+	when("#WithTracerProvider", func() {
+		it("traces image fetches", func() {
+			_, err := subject.imageFetcher.Fetch(context.TODO(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+			h.AssertNil(t, err)
+			_, err = subject.imageFetcher.Fetch(context.TODO(), "some/other-image", image.FetchOptions{PullPolicy: image.PullAlways})
+			h.AssertNotNil(t, err)
+
+			h.AssertEq(t, len(provider.spans), 2)
+			h.AssertEq(t, provider.spans[0].name, "fetch image")
+			h.AssertEq(t, provider.spans[0].attrs, map[string]string{
+				"pack.image":       "some/image",
+				"pack.daemon":      "true",
+				"pack.pull_policy": "never",
+			})
+			h.AssertEq(t, provider.spans[0].status, codes.Unset)
+			h.AssertEq(t, provider.spans[1].status, codes.Error)
+			h.AssertTrue(t, provider.spans[0].ended && provider.spans[1].ended)
+		})
+
+		it("traces published images", func() {
+			h.AssertError(t, subject.observePush(context.TODO(), "builder", true, func() error { return errors.New("some-push-error") }), "some-push-error")
+
+			h.AssertEq(t, len(provider.spans), 1)
+			h.AssertEq(t, provider.spans[0].name, "push image")
+			h.AssertEq(t, provider.spans[0].attrs, map[string]string{"pack.kind": "builder"})
+			h.AssertEq(t, provider.spans[0].status, codes.Error)
+		})
+
+		it("doesn't trace images saved to the daemon", func() {
+			h.AssertNil(t, subject.observePush(context.TODO(), "builder", false, func() error { return nil }))
+			h.AssertEq(t, len(provider.spans), 0)
+		})
+
+		it("doesn't wrap the image fetcher by default", func() {
+			cl, err := NewClient(WithFetcher(fakeImageFetcher))
+			h.AssertNil(t, err)
+			h.AssertSameInstance(t, cl.imageFetcher, fakeImageFetcher)
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// WithTrustPolicy requires images pulled from the registries of policy, such as builders, buildpacks,
+// lifecycle and run images, to be signed by one of the signers trusted for that registry. Those images are fetched by
+// the digest of the signature, and their signatures are read from the registry, even with the pull policy never.
+func WithTrustPolicy(policy image.TrustPolicy) Option {
+	return func(c *Client) {
+		c.trustPolicy = &policy
+	}
+}
+
+// verifyingImageFetcher verifies the signature of images before fetching them, by the digest verified
+type verifyingImageFetcher struct {
+	ImageFetcher
+	verifier *image.SignatureVerifier
+	logger   logging.Logger
+}
+
+func (f *verifyingImageFetcher) Fetch(ctx context.Context, imageName string, options image.FetchOptions) (imgutil.Image, error) {
+	if (options.LayoutOption != image.LayoutOption{}) {
+		return f.ImageFetcher.Fetch(ctx, imageName, options)
+	}
+
+	digest, err := f.verifier.Verify(ctx, imageName)
+	if err != nil {
+		if options.PullPolicy == image.PullNever {
+			err = errors.Wrapf(err, "verifying %s, which needs its registry even with pull policy %s", style.Symbol(imageName), style.Symbol("never"))
+		}
+		if f.verifier.Enforced() {
+			return nil, err
+		}
+		f.logger.Warnf("Trust policy: %s", err)
+		return f.ImageFetcher.Fetch(ctx, imageName, options)
+	}
+	if digest == "" {
+		return f.ImageFetcher.Fetch(ctx, imageName, options)
+	}
+
+	// the image is fetched by the digest verified, as its tag may have been moved since. Images of the daemon are only
+	// found by digest when they were pulled with it, so that other images tagged with the name aren't used.
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing image name %s", style.Symbol(imageName))
+	}
+	return f.ImageFetcher.Fetch(ctx, ref.Context().Digest(digest).String(), options)
+}
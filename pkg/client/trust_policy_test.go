@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTrustPolicy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TrustPolicy", testTrustPolicy, spec.Report(report.Terminal{}))
+}
+
+func testTrustPolicy(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server           *httptest.Server
+		host             string
+		imageName        string
+		digest           string
+		key              *ecdsa.PrivateKey
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		subject          *verifyingImageFetcher
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1)
+		imageName = host + "/buildpacks/builder:latest"
+
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+		imgDigest, err := img.Digest()
+		h.AssertNil(t, err)
+		digest = imgDigest.String()
+
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		h.AssertNil(t, err)
+		policy := image.TrustPolicy{Enforce: true, Registries: []image.TrustedRegistry{{
+			Prefix:     host + "/buildpacks",
+			PublicKeys: []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+		}}}
+		h.AssertNil(t, policy.Validate())
+
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		subject = &verifyingImageFetcher{
+			ImageFetcher: mockImageFetcher,
+			verifier:     image.NewSignatureVerifier(policy, authn.DefaultKeychain),
+			logger:       logging.NewSimpleLogger(io.Discard),
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		server.Close()
+	})
+
+	// sign pushes a cosign signature of the image by key
+	sign := func() {
+		payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, host+"/buildpacks/builder", digest))
+		hash := sha256.Sum256(payload)
+		signature, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+		h.AssertNil(t, err)
+
+		sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+			Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+			Annotations: map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature)},
+			MediaType:   types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json"),
+		})
+		h.AssertNil(t, err)
+		sigTag, err := name.NewTag(host+"/buildpacks/builder:"+strings.Replace(digest, ":", "-", 1)+".sig", name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(sigTag, sigImage))
+	}
+
+	when("#Fetch", func() {
+		it("fetches the digest the signature was verified for", func() {
+			sign()
+			options := image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), host+"/buildpacks/builder@"+digest, options).Return(nil, nil)
+
+			_, err := subject.Fetch(context.TODO(), imageName, options)
+			h.AssertNil(t, err)
+		})
+
+		it("fetches images outside of the policy by their name", func() {
+			otherName := host + "/other/builder:latest"
+			options := image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), otherName, options).Return(nil, nil)
+
+			_, err := subject.Fetch(context.TODO(), otherName, options)
+			h.AssertNil(t, err)
+		})
+
+		it("doesn't fetch unsigned images", func() {
+			_, err := subject.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent})
+			h.AssertError(t, err, fmt.Sprintf("image '%s' is not signed", imageName))
+		})
+
+		it("explains that verifying needs the registry with pull policy never", func() {
+			server.Close()
+
+			_, err := subject.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+			h.AssertError(t, err, fmt.Sprintf("verifying '%s', which needs its registry even with pull policy 'never'", imageName))
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// UpdateBuilderOptions define the builder to update, and the buildpacks to add to it, or to replace in it.
+type UpdateBuilderOptions struct {
+	// Name of the builder to update.
+	BuilderName string
+
+	// Name of the updated builder. Defaults to the name of the builder, which is then overwritten.
+	Tag string
+
+	// Buildpacks to add to the builder. Buildpacks with the ID of a buildpack in the order of the builder replace it
+	// in the order, in any other version.
+	Buildpacks []string
+
+	// The base directory to use to resolve relative buildpacks.
+	RelativeBaseDir string
+
+	// Skip saving the builder locally, read it from and publish it to a registry.
+	Publish bool
+
+	// Strategy for updating images before the update.
+	PullPolicy image.PullPolicy
+
+	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
+	Registry string
+}
+
+// UpdateBuilder produces a builder from an existing one by adding layers for the provided buildpacks on top of it,
+// rather than re-downloading and re-assembling every buildpack, lifecycle and base image of the builder. Buildpacks
+// replacing a version in the order of the builder are ordered instead of it; the previous version is kept on the
+// builder for composite buildpacks referencing it.
+func (c *Client) UpdateBuilder(ctx context.Context, opts UpdateBuilderOptions) error {
+	if len(opts.Buildpacks) == 0 {
+		return errors.New("at least one buildpack must be provided")
+	}
+
+	tag := opts.Tag
+	if tag == "" {
+		tag = opts.BuilderName
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return errors.Wrapf(err, "fetching builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.BuilderName))
+	}
+	if img.Name() != tag {
+		img.Rename(tag)
+	}
+
+	builderOS, err := img.OS()
+	if err != nil {
+		return errors.Wrap(err, "getting builder OS")
+	}
+	builderArch, err := img.Architecture()
+	if err != nil {
+		return errors.Wrap(err, "getting builder architecture")
+	}
+	target := &dist.Target{OS: builderOS, Arch: builderArch}
+
+	updatedVersions := map[string]string{}
+	for _, uri := range opts.Buildpacks {
+		mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, uri, buildpack.DownloadOptions{
+			Daemon:          !opts.Publish,
+			ModuleKind:      buildpack.KindBuildpack,
+			PullPolicy:      opts.PullPolicy,
+			RegistryName:    opts.Registry,
+			RelativeBaseDir: opts.RelativeBaseDir,
+			Target:          target,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "downloading buildpack %s", style.Symbol(uri))
+		}
+
+		for _, bp := range append([]buildpack.BuildModule{mainBP}, depBPs...) {
+			info := bp.Descriptor().Info()
+			updatedVersions[info.ID] = info.Version
+		}
+		bldr.AddBuildpacks(mainBP, depBPs)
+	}
+
+	if order, replaced := replaceOrderVersions(bldr.Order(), updatedVersions); len(replaced) > 0 {
+		for _, ref := range replaced {
+			c.logger.Infof("Replacing buildpack %s with version %s in the order", style.Symbol(ref.FullName()), style.Symbol(updatedVersions[ref.ID]))
+		}
+		bldr.SetOrder(order)
+	}
+
+	err = c.observePush(ctx, "builder", opts.Publish, func() error {
+		return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Infof("Successfully updated builder image %s", style.Symbol(tag))
+	return nil
+}
+
+// replaceOrderVersions returns the order with the version of the buildpacks of the IDs replaced, along with the
+// references replaced
+func replaceOrderVersions(order dist.Order, versions map[string]string) (dist.Order, []dist.ModuleRef) {
+	var replaced []dist.ModuleRef
+	updated := dist.Order{}
+	for _, entry := range order {
+		group := make([]dist.ModuleRef, 0, len(entry.Group))
+		for _, ref := range entry.Group {
+			if version, ok := versions[ref.ID]; ok && ref.Version != version {
+				replaced = append(replaced, ref)
+				ref.Version = version
+			}
+			group = append(group, ref)
+		}
+		updated = append(updated, dist.OrderEntry{Group: group})
+	}
+	return updated, replaced
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "UpdateBuilder", testUpdateBuilder, spec.Report(report.Terminal{}))
+}
+
+func testUpdateBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		builderImage     *fakes.Image
+		outBuf           bytes.Buffer
+		tmpDir           string
+	)
+
+	createBuildpack := func(id, version string) string {
+		bpDir := filepath.Join(tmpDir, id+"-"+version)
+		h.AssertNil(t, os.MkdirAll(filepath.Join(bpDir, "bin"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte(`api = "0.8"
+
+[buildpack]
+id = "`+id+`"
+version = "`+version+`"
+
+[[stacks]]
+id = "some.stack.id"
+`), 0644))
+		h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "detect"), []byte("detect"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "build"), []byte("build"), 0755))
+		return bpDir
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "update-builder-test")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		builderImage = newFakeBuilderImage(t, tmpDir, "example.com/some/builder", "some.stack.id", "default/run", builder.DefaultLifecycleVersion, newLinuxImage)
+		fakeImageFetcher.LocalImages[builderImage.Name()] = builderImage
+
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		blobDownloader := blob.NewDownloader(logger, filepath.Join(tmpDir, "dl-cache"))
+		subject = &Client{
+			logger:              logger,
+			imageFetcher:        fakeImageFetcher,
+			downloader:          blobDownloader,
+			buildpackDownloader: buildpack.NewDownloader(logger, fakeImageFetcher, blobDownloader, &registryResolver{logger: logger}),
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, builderImage.Cleanup())
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#UpdateBuilder", func() {
+		it("replaces the version of a buildpack in the order", func() {
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), UpdateBuilderOptions{
+				BuilderName: builderImage.Name(),
+				Buildpacks:  []string{createBuildpack("buildpack.1.id", "2.0.0")},
+			}))
+			h.AssertEq(t, builderImage.IsSaved(), true)
+
+			bldr, err := builder.FromImage(builderImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, bldr.Order()[0].Group[0].ModuleInfo, dist.ModuleInfo{ID: "buildpack.1.id", Version: "2.0.0"})
+			h.AssertContains(t, outBuf.String(), "Replacing buildpack 'buildpack.1.id@buildpack.1.version' with version '2.0.0' in the order")
+
+			var layers dist.ModuleLayers
+			_, err = dist.GetLabel(builderImage, dist.BuildpackLayersLabel, &layers)
+			h.AssertNil(t, err)
+			_, ok := layers.Get("buildpack.1.id", "2.0.0")
+			h.AssertTrue(t, ok)
+			_, ok = layers.Get("buildpack.1.id", "buildpack.1.version")
+			h.AssertTrue(t, ok)
+		})
+
+		it("adds buildpacks that aren't on the builder", func() {
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), UpdateBuilderOptions{
+				BuilderName: builderImage.Name(),
+				Buildpacks:  []string{createBuildpack("some.new.id", "1.0.0")},
+			}))
+
+			bldr, err := builder.FromImage(builderImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, bldr.Buildpacks()[len(bldr.Buildpacks())-1], dist.ModuleInfo{ID: "some.new.id", Version: "1.0.0"})
+			h.AssertNotContains(t, outBuf.String(), "Replacing buildpack")
+		})
+
+		it("saves the updated builder with the tag", func() {
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), UpdateBuilderOptions{
+				BuilderName: builderImage.Name(),
+				Tag:         "example.com/some/builder:updated",
+				Buildpacks:  []string{createBuildpack("buildpack.1.id", "2.0.0")},
+			}))
+			h.AssertEq(t, builderImage.Name(), "example.com/some/builder:updated")
+		})
+
+		when("no buildpack is provided", func() {
+			it("errors", func() {
+				err := subject.UpdateBuilder(context.TODO(), UpdateBuilderOptions{BuilderName: builderImage.Name()})
+				h.AssertError(t, err, "at least one buildpack must be provided")
+			})
+		})
+
+		when("the image isn't a builder", func() {
+			it("errors", func() {
+				notBuilder := newLinuxImage("some/image", "", nil)
+				fakeImageFetcher.LocalImages[notBuilder.Name()] = notBuilder
+
+				err := subject.UpdateBuilder(context.TODO(), UpdateBuilderOptions{
+					BuilderName: notBuilder.Name(),
+					Buildpacks:  []string{createBuildpack("buildpack.1.id", "2.0.0")},
+				})
+				h.AssertError(t, err, "invalid builder 'some/image'")
+			})
+		})
+	})
+}
@@ -11,6 +11,8 @@ const (
 	DefaultTargetOSLinux   = "linux"
 	DefaultTargetOSWindows = "windows"
 	DefaultTargetArch      = "amd64"
+	TargetOSWasi           = "wasi"
+	TargetArchWasm         = "wasm"
 )
 
 type BuildpackURI struct {
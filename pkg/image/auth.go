@@ -0,0 +1,203 @@
+package image
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/docker/errdefs"
+	"github.com/google/go-containerregistry/pkg/authn"
+	gname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// AuthError is returned when a registry denies access to an image, naming where the credentials it was pulled with
+// were read from.
+type AuthError struct {
+	// Registry that denied access.
+	Registry string
+
+	// Repository of the image.
+	Repository string
+
+	// CredentialSource the credentials were read from, such as a docker config or a credential helper.
+	CredentialSource string
+
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("registry %s denied access to repository %s with %s: %s",
+		style.Symbol(e.Registry), style.Symbol(e.Repository), e.CredentialSource, e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// CredentialSource is implemented by keychains that can tell where they read the credentials of a registry from
+type CredentialSource interface {
+	CredentialSource(target authn.Resource) string
+}
+
+// NewMultiKeychain returns a keychain with the credentials of the first of the keychains that has credentials for a
+// registry, like authn.NewMultiKeychain, that tells which of them they came from.
+func NewMultiKeychain(keychains ...authn.Keychain) authn.Keychain {
+	return &multiKeychain{keychains: keychains}
+}
+
+type multiKeychain struct {
+	keychains []authn.Keychain
+}
+
+func (k *multiKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	keychain, authenticator, err := k.resolve(target)
+	if err != nil || keychain == nil {
+		return authn.Anonymous, err
+	}
+	return authenticator, nil
+}
+
+func (k *multiKeychain) CredentialSource(target authn.Resource) string {
+	keychain, _, err := k.resolve(target)
+	if err != nil || keychain == nil {
+		return noCredentials
+	}
+	return credentialSource(keychain, target)
+}
+
+func (k *multiKeychain) resolve(target authn.Resource) (authn.Keychain, authn.Authenticator, error) {
+	for _, keychain := range k.keychains {
+		authenticator, err := keychain.Resolve(target)
+		if err != nil {
+			return nil, nil, err
+		}
+		if authenticator != authn.Anonymous {
+			return keychain, authenticator, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+const noCredentials = "no credentials"
+
+// credentialSource describes where the keychain reads the credentials of a registry from
+func credentialSource(keychain authn.Keychain, target authn.Resource) string {
+	if source, ok := keychain.(CredentialSource); ok {
+		return source.CredentialSource(target)
+	}
+
+	authenticator, err := keychain.Resolve(target)
+	if err != nil || authenticator == authn.Anonymous {
+		return noCredentials
+	}
+	if keychain == authn.DefaultKeychain {
+		if configFile, err := dockerconfig.Load(dockerconfig.Dir()); err == nil {
+			if source := configFileSource(configFile, target.RegistryStr()); source != "" {
+				return source
+			}
+		}
+	}
+	return "credentials of the keychain"
+}
+
+// configFileSource describes where a docker config reads the credentials of a registry from, or returns nothing when
+// it has no credentials for the registry
+func configFileSource(configFile *configfile.ConfigFile, registry string) string {
+	key := dockerConfigKey(registry)
+	if helper, ok := configFile.CredentialHelpers[key]; ok {
+		return fmt.Sprintf("credentials of credential helper %s", style.Symbol("docker-credential-"+helper))
+	}
+	if configFile.CredentialsStore != "" {
+		return fmt.Sprintf("credentials of credential store %s", style.Symbol("docker-credential-"+configFile.CredentialsStore))
+	}
+	if _, ok := configFile.AuthConfigs[key]; ok {
+		return fmt.Sprintf("credentials of docker config %s", style.Symbol(configFile.Filename))
+	}
+	return ""
+}
+
+// newAuthError returns an AuthError for an image the registry denied access to with the credentials of the keychain
+func newAuthError(imageName string, keychain authn.Keychain, err error) error {
+	ref, parseErr := gname.ParseReference(imageName, gname.WeakValidation)
+	if parseErr != nil {
+		return err
+	}
+	return &AuthError{
+		Registry:         ref.Context().RegistryStr(),
+		Repository:       ref.Context().RepositoryStr(),
+		CredentialSource: credentialSource(keychain, ref.Context()),
+		Err:              err,
+	}
+}
+
+// imageCredentialSource describes where the keychain reads the credentials of the registry of the image from
+func imageCredentialSource(keychain authn.Keychain, imageName string) string {
+	ref, err := gname.ParseReference(imageName, gname.WeakValidation)
+	if err != nil {
+		return noCredentials
+	}
+	return credentialSource(keychain, ref.Context())
+}
+
+// hasCredentials returns whether the keychain has credentials for the registry of the image
+func hasCredentials(keychain authn.Keychain, imageName string) bool {
+	ref, err := gname.ParseReference(imageName, gname.WeakValidation)
+	if err != nil {
+		return false
+	}
+	authenticator, err := keychain.Resolve(ref.Context())
+	return err == nil && authenticator != authn.Anonymous
+}
+
+// remoteAccessError returns an AuthError when the registry denies access to the image with the credentials of the
+// keychain, as images the registry denies access to are reported as not found when fetched
+func remoteAccessError(imageName string, keychain authn.Keychain, insecureRegistries []string) error {
+	ref, err := gname.ParseReference(imageName, gname.WeakValidation)
+	if err != nil {
+		return nil
+	}
+	for _, registry := range insecureRegistries {
+		if registry == ref.Context().RegistryStr() {
+			if ref, err = gname.ParseReference(imageName, gname.WeakValidation, gname.Insecure); err != nil {
+				return nil
+			}
+		}
+	}
+
+	if _, err := remote.Head(ref, remote.WithAuthFromKeychain(keychain)); isDeniedByRegistry(err) {
+		// registries also deny access to repositories that do not exist, so the error is kept a not found error
+		return newAuthError(imageName, keychain, fmt.Errorf("%w: %w", ErrNotFound, err))
+	}
+	return nil
+}
+
+// isDeniedByDaemon returns whether the daemon failed to pull an image because the registry denied access to it, which
+// the daemon reports as an error of its own or in the pull progress
+func isDeniedByDaemon(err error) bool {
+	if errdefs.IsUnauthorized(err) || errdefs.IsForbidden(err) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "unauthorized") ||
+		strings.Contains(message, "authentication required") ||
+		strings.Contains(message, "denied")
+}
+
+// isDeniedByRegistry returns whether a registry request failed because the registry denied access
+func isDeniedByRegistry(err error) bool {
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+	return transportErr.StatusCode == http.StatusUnauthorized || transportErr.StatusCode == http.StatusForbidden
+}
+
+// anonymousKeychain has no credentials for any registry
+var anonymousKeychain authn.Keychain = authn.NewMultiKeychain()
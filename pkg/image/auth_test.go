@@ -0,0 +1,178 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAuth(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Auth", testAuth, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAuth(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server       *httptest.Server
+		registryHost string
+		configDir    string
+		outBuf       bytes.Buffer
+		target       = &dist.Target{OS: "linux", Arch: runtime.GOARCH}
+	)
+
+	// writeCredentials stores credentials for the registry in a docker config, returning a keychain reading them
+	writeCredentials := func(username, password string) authn.Keychain {
+		h.AssertNil(t, image.StoreCredentials(configDir, registryHost, username, password))
+		keychain, err := image.NewDockerConfigKeychain(configDir)
+		h.AssertNil(t, err)
+		return keychain
+	}
+
+	it.Before(func() {
+		reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		denying := false
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the registry rejects any credentials, and only serves private repositories with credentials
+			_, _, authenticated := r.BasicAuth()
+			if denying && (authenticated || strings.Contains(r.URL.Path, "/private/")) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			reg.ServeHTTP(w, r)
+		}))
+		registryHost = strings.ReplaceAll(server.URL, "http://127.0.0.1", "localhost")
+		configDir = t.TempDir()
+
+		for _, repo := range []string{"public/app", "private/app"} {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			ref, err := name.ParseReference(registryHost + "/" + repo)
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(ref, img))
+		}
+		denying = true
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("fetching from the registry", func() {
+		it("fetches public images anonymously when the registry denies access with credentials", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithKeychain(writeCredentials("some-user", "some-password")))
+
+			img, err := fetcher.Fetch(context.TODO(), registryHost+"/public/app", image.FetchOptions{Target: target})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, img.Found())
+			h.AssertContains(t, outBuf.String(), "fetched it anonymously")
+		})
+
+		it("warns with the logger of the options, when they have one", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithKeychain(writeCredentials("some-user", "some-password")))
+
+			var callBuf bytes.Buffer
+			_, err := fetcher.Fetch(context.TODO(), registryHost+"/public/app", image.FetchOptions{Target: target, Logger: logging.NewLogWithWriters(&callBuf, &callBuf)})
+			h.AssertNil(t, err)
+			h.AssertContains(t, callBuf.String(), "fetched it anonymously")
+			h.AssertNotContains(t, outBuf.String(), "fetched it anonymously")
+		})
+
+		it("names the registry, repository and credential source of images it is denied access to", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithKeychain(writeCredentials("some-user", "some-password")))
+
+			_, err := fetcher.Fetch(context.TODO(), registryHost+"/private/app", image.FetchOptions{Target: target})
+			var authErr *image.AuthError
+			h.AssertTrue(t, errors.As(err, &authErr))
+			h.AssertEq(t, authErr.Registry, registryHost)
+			h.AssertEq(t, authErr.Repository, "private/app")
+			h.AssertEq(t, authErr.CredentialSource, "credentials of docker config '"+filepath.Join(configDir, "config.json")+"'")
+		})
+
+		it("reports images it is denied access to without credentials", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithKeychain(authn.NewMultiKeychain()))
+
+			_, err := fetcher.Fetch(context.TODO(), registryHost+"/private/app", image.FetchOptions{Target: target})
+			h.AssertError(t, err, "registry '"+registryHost+"' denied access to repository 'private/app' with no credentials")
+		})
+	})
+
+	when("pulling into the daemon", func() {
+		var (
+			mockController *gomock.Controller
+			mockDocker     *testmocks.MockCommonAPIClient
+			keychain       authn.Keychain
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+			keychain = image.NewMultiKeychain(writeCredentials("some-user", "some-password"), authn.DefaultKeychain)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		it("pulls public images anonymously when the registry denies access with credentials", func() {
+			imageName := registryHost + "/public/app"
+			gomock.InOrder(
+				mockDocker.EXPECT().
+					ImagePull(gomock.Any(), imageName, gomock.Any()).
+					Return(nil, errors.New("unauthorized: incorrect username or password")),
+				mockDocker.EXPECT().
+					ImagePull(gomock.Any(), imageName, gomock.Any()).
+					Return(io.NopCloser(strings.NewReader(`{"status":"Downloaded newer image"}`)), nil),
+			)
+			mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{}, nil).AnyTimes()
+			mockDocker.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{ID: "some-id", Os: "linux"}, nil, nil)
+			mockDocker.EXPECT().ImageHistory(gomock.Any(), imageName).Return(nil, nil)
+
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDocker, image.WithKeychain(keychain))
+			_, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+			h.AssertNil(t, err)
+			h.AssertContains(t, outBuf.String(), "pulled it anonymously")
+		})
+
+		it("names the registry, repository and credential source of images it is denied access to", func() {
+			imageName := registryHost + "/private/app"
+			mockDocker.EXPECT().
+				ImagePull(gomock.Any(), imageName, gomock.Any()).
+				Return(nil, errors.New("unauthorized: incorrect username or password")).
+				Times(2)
+
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDocker, image.WithKeychain(keychain))
+			_, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+			h.AssertError(t, err, "registry '"+registryHost+"' denied access to repository 'private/app' with credentials of docker config")
+			h.AssertError(t, err, "unauthorized: incorrect username or password")
+		})
+	})
+}
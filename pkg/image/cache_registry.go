@@ -0,0 +1,348 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheRegistry is a read-only, pull-through cache of the registries images are pulled from. It serves the registry
+// API on a loopback address, and stores manifests and layers by digest on disk, so that images shared by several
+// builds or projects are only downloaded once.
+//
+// An image is pulled through the cache using its cache name, which prefixes the original image name with the address
+// of the cache registry, see Name. As the cache registry pulls images with the credentials of the user, it only serves
+// the clients authenticated with a secret generated when it starts, see Keychain.
+type CacheRegistry struct {
+	logger   logging.Logger
+	keychain authn.Keychain
+	dir      string
+
+	once     sync.Once
+	addr     string
+	secret   string
+	startErr error
+}
+
+const cacheRegistryUsername = "pack"
+
+// NewCacheRegistry returns a CacheRegistry storing its contents in dir. It is only started when first used.
+func NewCacheRegistry(logger logging.Logger, keychain authn.Keychain, dir string) *CacheRegistry {
+	return &CacheRegistry{
+		logger:   logger,
+		keychain: keychain,
+		dir:      dir,
+	}
+}
+
+// Start starts serving the cache registry, if it isn't already.
+func (c *CacheRegistry) Start() error {
+	c.once.Do(func() {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			c.startErr = errors.Wrap(err, "generating secret of local cache registry")
+			return
+		}
+		c.secret = hex.EncodeToString(secret)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			c.startErr = errors.Wrap(err, "starting local cache registry")
+			return
+		}
+		c.addr = listener.Addr().String()
+		c.logger.Debugf("Started local cache registry at %s", style.Symbol(c.addr))
+
+		server := &http.Server{Handler: c, ReadHeaderTimeout: 30 * time.Second}
+		go func() {
+			_ = server.Serve(listener)
+		}()
+	})
+	return c.startErr
+}
+
+// Keychain returns the keychain authenticating to the cache registry with its secret, starting it if needed. Other
+// registries are accessed anonymously with it.
+func (c *CacheRegistry) Keychain() authn.Keychain {
+	return cacheRegistryKeychain{c}
+}
+
+type cacheRegistryKeychain struct {
+	cacheRegistry *CacheRegistry
+}
+
+func (k cacheRegistryKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if err := k.cacheRegistry.Start(); err != nil {
+		return nil, err
+	}
+	if resource.RegistryStr() != k.cacheRegistry.addr {
+		return authn.Anonymous, nil
+	}
+	return &authn.Basic{Username: cacheRegistryUsername, Password: k.cacheRegistry.secret}, nil
+}
+
+// Name returns the name imageName is pulled as through the cache registry, starting it if needed.
+func (c *CacheRegistry) Name(imageName string) (string, error) {
+	if err := c.Start(); err != nil {
+		return "", err
+	}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	separator := ":"
+	if _, ok := ref.(name.Digest); ok {
+		separator = "@"
+	}
+	// registries may have a port, which is not allowed in a repository name
+	upstream := strings.ReplaceAll(ref.Context().RegistryStr(), ":", "_")
+	return fmt.Sprintf("%s/%s/%s%s%s", c.addr, upstream, ref.Context().RepositoryStr(), separator, ref.Identifier()), nil
+}
+
+// Resolve returns the name imageName is pulled as through the cache registry, if the cache registry can serve it.
+func (c *CacheRegistry) Resolve(imageName string) (string, error) {
+	cacheName, err := c.Name(imageName)
+	if err != nil {
+		return "", err
+	}
+	ref, err := name.ParseReference(cacheName, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	if _, err := remote.Head(ref, remote.WithAuthFromKeychain(c.Keychain())); err != nil {
+		return "", err
+	}
+	return cacheName, nil
+}
+
+func (c *CacheRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	username, secret, ok := r.BasicAuth()
+	if !ok || username != cacheRegistryUsername || subtle.ConstantTimeCompare([]byte(secret), []byte(c.secret)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Basic realm="pack local cache registry"`)
+		writeRegistryError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeRegistryError(w, http.StatusMethodNotAllowed, "UNSUPPORTED", "the local cache registry is read-only")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if path == "" || path == r.URL.Path {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var err error
+	switch {
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		err = c.serveManifest(w, r, path[:i], path[i+len("/manifests/"):])
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		err = c.serveBlob(w, r, path[:i], path[i+len("/blobs/"):])
+	default:
+		writeRegistryError(w, http.StatusNotFound, "NAME_UNKNOWN", "unknown path")
+		return
+	}
+
+	if err != nil {
+		c.logger.Debugf("Local cache registry failed to serve %s: %s", style.Symbol(r.URL.Path), err)
+		writeRegistryError(w, http.StatusNotFound, "MANIFEST_UNKNOWN", err.Error())
+	}
+}
+
+// upstreamRepository returns the repository the cache repository path was named after, see Name
+func upstreamRepository(path string) (name.Repository, error) {
+	upstream, repo, ok := strings.Cut(path, "/")
+	if !ok {
+		return name.Repository{}, errors.Errorf("invalid repository %s", style.Symbol(path))
+	}
+	return name.NewRepository(strings.ReplaceAll(upstream, "_", ":")+"/"+repo, name.WeakValidation)
+}
+
+func (c *CacheRegistry) serveManifest(w http.ResponseWriter, r *http.Request, path, reference string) error {
+	repo, err := upstreamRepository(path)
+	if err != nil {
+		return err
+	}
+	remoteOpts := []remote.Option{remote.WithAuthFromKeychain(c.keychain), remote.WithContext(r.Context())}
+
+	digest, err := v1.NewHash(reference)
+	if err != nil {
+		// tags are always resolved upstream, to pick up new versions of the image, unless the registry is unreachable
+		digest, err = c.resolveTag(repo.Tag(reference), remoteOpts)
+		if err != nil {
+			return err
+		}
+	}
+
+	manifest, mediaType, err := c.readManifest(digest)
+	if err != nil {
+		desc, err := remote.Get(repo.Digest(digest.String()), remoteOpts...)
+		if err != nil {
+			return err
+		}
+		manifest, mediaType = desc.Manifest, string(desc.MediaType)
+		if err := c.writeManifest(digest, manifest, mediaType); err != nil {
+			return err
+		}
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(manifest)))
+	w.Header().Set("Docker-Content-Digest", digest.String())
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(manifest)
+	}
+	return nil
+}
+
+func (c *CacheRegistry) resolveTag(tag name.Tag, remoteOpts []remote.Option) (v1.Hash, error) {
+	tagPath := filepath.Join(c.dir, "tags", hashString(tag.Name()))
+
+	desc, err := remote.Head(tag, remoteOpts...)
+	if err != nil {
+		contents, readErr := os.ReadFile(filepath.Clean(tagPath))
+		if readErr != nil {
+			return v1.Hash{}, err
+		}
+		c.logger.Debugf("Using cached digest of %s: %s", style.Symbol(tag.Name()), err)
+		return v1.NewHash(string(contents))
+	}
+
+	if err := writeFileAtomic(tagPath, strings.NewReader(desc.Digest.String())); err != nil {
+		return v1.Hash{}, err
+	}
+	return desc.Digest, nil
+}
+
+func (c *CacheRegistry) readManifest(digest v1.Hash) ([]byte, string, error) {
+	manifest, err := os.ReadFile(c.manifestPath(digest))
+	if err != nil {
+		return nil, "", err
+	}
+	mediaType, err := os.ReadFile(c.manifestPath(digest) + ".mediatype")
+	if err != nil {
+		return nil, "", err
+	}
+	return manifest, string(mediaType), nil
+}
+
+func (c *CacheRegistry) writeManifest(digest v1.Hash, manifest []byte, mediaType string) error {
+	if err := writeFileAtomic(c.manifestPath(digest)+".mediatype", strings.NewReader(mediaType)); err != nil {
+		return err
+	}
+	return writeFileAtomic(c.manifestPath(digest), bytes.NewReader(manifest))
+}
+
+func (c *CacheRegistry) serveBlob(w http.ResponseWriter, r *http.Request, path, reference string) error {
+	digest, err := v1.NewHash(reference)
+	if err != nil {
+		return err
+	}
+
+	blobPath := c.blobPath(digest)
+	if _, err := os.Stat(blobPath); os.IsNotExist(err) {
+		repo, err := upstreamRepository(path)
+		if err != nil {
+			return err
+		}
+		if err := c.downloadBlob(r.Context(), repo.Digest(digest.String()), blobPath); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(filepath.Clean(blobPath))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Header().Set("Docker-Content-Digest", digest.String())
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", time.Time{}, f)
+	return nil
+}
+
+func (c *CacheRegistry) downloadBlob(ctx context.Context, ref name.Digest, blobPath string) error {
+	layer, err := remote.Layer(ref, remote.WithAuthFromKeychain(c.keychain), remote.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// the reader verifies the digest of the blob once it has been read completely
+	rc, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	c.logger.Debugf("Caching blob %s", style.Symbol(ref.Name()))
+	return writeFileAtomic(blobPath, rc)
+}
+
+func (c *CacheRegistry) manifestPath(digest v1.Hash) string {
+	return filepath.Join(c.dir, "manifests", digest.Algorithm, digest.Hex)
+}
+
+func (c *CacheRegistry) blobPath(digest v1.Hash) string {
+	return filepath.Join(c.dir, "blobs", digest.Algorithm, digest.Hex)
+}
+
+// writeFileAtomic writes the contents of r to path, so that concurrent readers never see a partially written file
+func writeFileAtomic(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeRegistryError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"errors":[{"code":%q,"message":%q}]}`, code, message)
+}
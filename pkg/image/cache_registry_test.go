@@ -0,0 +1,161 @@
+package image_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheRegistry(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheRegistry", testCacheRegistry, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheRegistry(t *testing.T, when spec.G, it spec.S) {
+	var (
+		upstream  *httptest.Server
+		imageName string
+		img       v1.Image
+		cacheDir  string
+		outBuf    bytes.Buffer
+
+		mu        sync.Mutex
+		blobPulls int
+	)
+
+	newCacheRegistry := func() *image.CacheRegistry {
+		return image.NewCacheRegistry(logging.NewLogWithWriters(&outBuf, &outBuf), authn.DefaultKeychain, cacheDir)
+	}
+
+	// pullAll reads every layer of imageName from the cache registry
+	pullAll := func(cacheRegistry *image.CacheRegistry) v1.Hash {
+		cacheName, err := cacheRegistry.Resolve(imageName)
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(cacheName, name.WeakValidation)
+		h.AssertNil(t, err)
+		pulled, err := remote.Image(ref, remote.WithAuthFromKeychain(cacheRegistry.Keychain()))
+		h.AssertNil(t, err)
+		layers, err := pulled.Layers()
+		h.AssertNil(t, err)
+		for _, layer := range layers {
+			rc, err := layer.Compressed()
+			h.AssertNil(t, err)
+			_, err = io.Copy(io.Discard, rc)
+			h.AssertNil(t, err)
+			h.AssertNil(t, rc.Close())
+		}
+		digest, err := pulled.Digest()
+		h.AssertNil(t, err)
+		return digest
+	}
+
+	it.Before(func() {
+		regHandler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/blobs/") {
+				mu.Lock()
+				blobPulls++
+				mu.Unlock()
+			}
+			regHandler.ServeHTTP(w, r)
+		}))
+
+		var err error
+		imageName = strings.Replace(upstream.URL, "http://127.0.0.1", "localhost", 1) + "/some/app:latest"
+		img, err = random.Image(1024, 2)
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+
+		cacheDir, err = os.MkdirTemp("", "registry-cache")
+		h.AssertNil(t, err)
+		blobPulls = 0
+	})
+
+	it.After(func() {
+		upstream.Close()
+		h.AssertNil(t, os.RemoveAll(cacheDir))
+	})
+
+	when("#Name", func() {
+		it("prefixes the image name with the cache registry address", func() {
+			cacheName, err := newCacheRegistry().Name("docker.io/library/alpine:3")
+			h.AssertNil(t, err)
+			h.AssertContainsMatch(t, cacheName, `^127\.0\.0\.1:\d+/index\.docker\.io/library/alpine:3$`)
+		})
+
+		it("encodes the port of the upstream registry", func() {
+			cacheName, err := newCacheRegistry().Name("localhost:5000/some/app@sha256:7a8f9bdc5d4d1c9b9d0a1d5c04a3d7a0d8fd4fdb9f0ac5c4fa59a1a8f3d4e2b1")
+			h.AssertNil(t, err)
+			h.AssertContainsMatch(t, cacheName, `/localhost_5000/some/app@sha256:7a8f`)
+		})
+	})
+
+	when("pulling through the cache", func() {
+		it("serves the upstream image", func() {
+			expected, err := img.Digest()
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, pullAll(newCacheRegistry()), expected)
+			h.AssertEq(t, blobPulls, 2)
+		})
+
+		it("only downloads layers once across cache registries sharing a directory", func() {
+			pullAll(newCacheRegistry())
+			pullAll(newCacheRegistry())
+
+			h.AssertEq(t, blobPulls, 2)
+		})
+
+		it("serves cached images when the upstream registry is unreachable", func() {
+			expected := pullAll(newCacheRegistry())
+			upstream.Close()
+
+			h.AssertEq(t, pullAll(newCacheRegistry()), expected)
+		})
+
+		it("only serves clients authenticated with its secret", func() {
+			cacheRegistry := newCacheRegistry()
+			cacheName, err := cacheRegistry.Name(imageName)
+			h.AssertNil(t, err)
+			ref, err := name.ParseReference(cacheName, name.WeakValidation)
+			h.AssertNil(t, err)
+
+			_, err = remote.Head(ref)
+			h.AssertError(t, err, "401 Unauthorized")
+
+			_, err = remote.Head(ref, remote.WithAuth(&authn.Basic{Username: "pack", Password: "some-guess"}))
+			h.AssertError(t, err, "401 Unauthorized")
+
+			_, err = remote.Head(ref, remote.WithAuthFromKeychain(cacheRegistry.Keychain()))
+			h.AssertNil(t, err)
+		})
+
+		it("fails for images that are not upstream", func() {
+			_, err := newCacheRegistry().Resolve(strings.Replace(imageName, "some/app", "missing/app", 1))
+			h.AssertNotNil(t, err)
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/buildpacks/imgutil/local"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+)
+
+// ErrNoDelta is returned by SaveDelta when the daemon has none of the layers of the image that would not be omitted
+// anyway, in which case the image should be saved as usual.
+var ErrNoDelta = errors.New("no layers of the image are known to the daemon")
+
+// SaveDelta loads img into the daemon as imageName, and tags it with additionalNames, without sending the leading
+// layers the daemon already has. Those are found by comparing the layer diffIDs of img with those of the images
+// currently named imageName or additionalNames, such as a previous version of the image, and of its base image.
+// It returns the ID of the saved image and the number of layers that were not sent.
+func SaveDelta(ctx context.Context, docker local.DockerClient, img v1.Image, imageName string, additionalNames ...string) (string, int, error) {
+	// the containerd image store requires every layer to be sent
+	info, err := docker.Info(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	for _, status := range info.DriverStatus {
+		if status[0] == "driver-type" && status[1] == "io.containerd.snapshotter.v1" {
+			return "", 0, ErrNoDelta
+		}
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return "", 0, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return "", 0, err
+	}
+
+	// layers of images in the daemon that were never read from it have a size of -1
+	var baseLayers int
+	sizes := make([]int64, len(layers))
+	for i, layer := range layers {
+		if sizes[i], err = layer.Size(); err != nil {
+			return "", 0, err
+		}
+		if sizes[i] < 0 && baseLayers == i {
+			baseLayers++
+		}
+	}
+
+	known := knownLayers(ctx, docker, configFile.RootFS.DiffIDs, append([]string{imageName}, additionalNames...))
+	if known <= baseLayers {
+		return "", 0, ErrNoDelta
+	}
+	for _, size := range sizes[known:] {
+		if size < 0 {
+			return "", 0, ErrNoDelta
+		}
+	}
+
+	if tag, err := name.NewTag(imageName, name.WeakValidation); err == nil {
+		imageName = tag.Name()
+	}
+	if err := loadLayers(ctx, docker, img, layers[known:], known, imageName); err != nil {
+		return "", 0, err
+	}
+
+	inspect, _, err := docker.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "inspecting saved image %s", imageName)
+	}
+	for _, n := range additionalNames {
+		if err := docker.ImageTag(ctx, inspect.ID, n); err != nil {
+			return "", 0, errors.Wrapf(err, "tagging image %s", n)
+		}
+	}
+	return inspect.ID, known, nil
+}
+
+// knownLayers returns the length of the longest prefix of diffIDs that is shared with one of the named images
+func knownLayers(ctx context.Context, docker local.DockerClient, diffIDs []v1.Hash, imageNames []string) int {
+	var known int
+	for _, imageName := range imageNames {
+		inspect, _, err := docker.ImageInspectWithRaw(ctx, imageName)
+		if err != nil {
+			continue
+		}
+		var shared int
+		for shared < len(diffIDs) && shared < len(inspect.RootFS.Layers) && diffIDs[shared].String() == inspect.RootFS.Layers[shared] {
+			shared++
+		}
+		if shared > known {
+			known = shared
+		}
+	}
+	return known
+}
+
+// loadLayers loads an image archive into the daemon, in which the first omitted layers are empty files
+func loadLayers(ctx context.Context, docker local.DockerClient, img v1.Image, layers []v1.Layer, omitted int, imageName string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeImageArchive(pw, img, layers, omitted, imageName))
+	}()
+	defer pr.Close()
+
+	res, err := docker.ImageLoad(ctx, pr, true)
+	if err != nil {
+		return errors.Wrap(err, "loading image")
+	}
+	defer res.Body.Close()
+
+	var message jsonmessage.JSONMessage
+	if err := json.NewDecoder(res.Body).Decode(&message); err != nil {
+		return errors.Wrap(err, "parsing daemon response")
+	}
+	if message.Error != nil {
+		return errors.Wrap(message.Error, "loading image")
+	}
+	_, err = io.Copy(io.Discard, res.Body)
+	return err
+}
+
+func writeImageArchive(w io.Writer, img v1.Image, layers []v1.Layer, omitted int, imageName string) error {
+	tw := tar.NewWriter(w)
+
+	rawConfig, err := img.RawConfigFile()
+	if err != nil {
+		return err
+	}
+	configHash, _, err := v1.SHA256(bytes.NewReader(rawConfig))
+	if err != nil {
+		return err
+	}
+	configName := configHash.Hex + ".json"
+	if err := writeTarFile(tw, configName, int64(len(rawConfig)), bytes.NewReader(rawConfig)); err != nil {
+		return err
+	}
+
+	var layerNames []string
+	for i := 0; i < omitted; i++ {
+		// the daemon doesn't read layers it already has, given all the layers before them are the same
+		layerName := fmt.Sprintf("blank_%d", i)
+		if err := writeTarFile(tw, layerName, 0, bytes.NewReader(nil)); err != nil {
+			return err
+		}
+		layerNames = append(layerNames, layerName)
+	}
+	for _, layer := range layers {
+		diffID, err := layer.DiffID()
+		if err != nil {
+			return err
+		}
+		size, err := uncompressedSize(layer)
+		if err != nil {
+			return err
+		}
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return err
+		}
+		layerName := fmt.Sprintf("/%s.tar", diffID)
+		err = writeTarFile(tw, layerName, size, rc)
+		rc.Close()
+		if err != nil {
+			return errors.Wrapf(err, "adding layer %s", diffID)
+		}
+		layerNames = append(layerNames, layerName)
+	}
+
+	manifest, err := json.Marshal([]map[string]interface{}{{
+		"Config":   configName,
+		"RepoTags": []string{imageName},
+		"Layers":   layerNames,
+	}})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, "manifest.json", int64(len(manifest)), bytes.NewReader(manifest)); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// uncompressedSize reads the layer to find its uncompressed size, which the size of local layers doesn't always report
+func uncompressedSize(layer v1.Layer) (int64, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	return io.Copy(io.Discard, rc)
+}
+
+func writeTarFile(tw *tar.Writer, fileName string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{Name: fileName, Mode: 0644, Size: size}); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
@@ -0,0 +1,132 @@
+package image_test
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSaveDelta(t *testing.T) {
+	spec.Run(t, "SaveDelta", testSaveDelta, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSaveDelta(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		img            v1.Image
+		diffIDs        []string
+	)
+
+	// readArchive returns the manifest of the loaded image archive, and the size of each file in it
+	readArchive := func(r io.Reader) (map[string]interface{}, map[string]int64) {
+		var manifest []map[string]interface{}
+		sizes := map[string]int64{}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			h.AssertNil(t, err)
+			sizes[hdr.Name] = hdr.Size
+			if hdr.Name == "manifest.json" {
+				h.AssertNil(t, json.NewDecoder(tr).Decode(&manifest))
+			}
+		}
+		h.AssertEq(t, len(manifest), 1)
+		return manifest[0], sizes
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		img, err = random.Image(1024, 3)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		for _, diffID := range configFile.RootFS.DiffIDs {
+			diffIDs = append(diffIDs, diffID.String())
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("a previous version of the image shares layers with it", func() {
+		it.Before(func() {
+			mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{}, nil)
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), "some/app").
+				Return(types.ImageInspect{RootFS: types.RootFS{Layers: []string{diffIDs[0], diffIDs[1], "sha256:other"}}}, nil, nil)
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), "some/app:v2").
+				Return(types.ImageInspect{}, nil, errors.New("not found"))
+		})
+
+		it("only sends the layers after the shared ones", func() {
+			mockDocker.EXPECT().ImageLoad(gomock.Any(), gomock.Any(), true).DoAndReturn(
+				func(_ context.Context, r io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					manifest, sizes := readArchive(r)
+					h.AssertEq(t, manifest["RepoTags"], []interface{}{"index.docker.io/some/app:latest"})
+					h.AssertEq(t, manifest["Layers"], []interface{}{"blank_0", "blank_1", "/" + diffIDs[2] + ".tar"})
+					h.AssertEq(t, sizes["blank_0"], int64(0))
+					h.AssertEq(t, sizes["blank_1"], int64(0))
+					h.AssertTrue(t, sizes["/"+diffIDs[2]+".tar"] > 1024)
+					return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"stream":"Loaded image"}`))}, nil
+				})
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), "index.docker.io/some/app:latest").
+				Return(types.ImageInspect{ID: "sha256:new-id"}, nil, nil)
+			mockDocker.EXPECT().ImageTag(gomock.Any(), "sha256:new-id", "some/app:v2").Return(nil)
+
+			id, omitted, err := image.SaveDelta(context.TODO(), mockDocker, img, "some/app", "some/app:v2")
+			h.AssertNil(t, err)
+			h.AssertEq(t, id, "sha256:new-id")
+			h.AssertEq(t, omitted, 2)
+		})
+
+		it("returns daemon errors", func() {
+			mockDocker.EXPECT().ImageLoad(gomock.Any(), gomock.Any(), true).DoAndReturn(
+				func(_ context.Context, r io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					_, _ = io.Copy(io.Discard, r)
+					return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"layer does not exist"},"error":"layer does not exist"}`))}, nil
+				})
+
+			_, _, err := image.SaveDelta(context.TODO(), mockDocker, img, "some/app", "some/app:v2")
+			h.AssertError(t, err, "layer does not exist")
+		})
+	})
+
+	it("returns ErrNoDelta when the daemon has none of the layers", func() {
+		mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{}, nil)
+		mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), "some/app").
+			Return(types.ImageInspect{RootFS: types.RootFS{Layers: []string{"sha256:other"}}}, nil, nil)
+
+		_, _, err := image.SaveDelta(context.TODO(), mockDocker, img, "some/app")
+		h.AssertTrue(t, errors.Is(err, image.ErrNoDelta))
+	})
+
+	it("returns ErrNoDelta with the containerd image store", func() {
+		mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{DriverStatus: [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}}}, nil)
+
+		_, _, err := image.SaveDelta(context.TODO(), mockDocker, img, "some/app")
+		h.AssertTrue(t, errors.Is(err, image.ErrNoDelta))
+	})
+}
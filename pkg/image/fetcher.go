@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"io"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/buildpacks/imgutil/layout"
 	"github.com/buildpacks/imgutil/layout/sparse"
@@ -18,6 +20,7 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/google/go-containerregistry/pkg/authn"
+	gname "github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
 	pname "github.com/buildpacks/pack/internal/name"
@@ -49,6 +52,13 @@ func WithKeychain(keychain authn.Keychain) FetcherOption {
 	}
 }
 
+// WithCacheRegistry pulls images through the given local cache registry.
+func WithCacheRegistry(cacheRegistry *CacheRegistry) FetcherOption {
+	return func(c *Fetcher) {
+		c.cacheRegistry = cacheRegistry
+	}
+}
+
 type DockerClient interface {
 	local.DockerClient
 	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
@@ -59,6 +69,17 @@ type Fetcher struct {
 	logger          logging.Logger
 	registryMirrors map[string]string
 	keychain        authn.Keychain
+	cacheRegistry   *CacheRegistry
+	pullTimesFile   string
+
+	// daemonPlatform is shared with the copies of the fetcher logging with the logger of a fetch, see FetchOptions.Logger
+	daemonPlatform *daemonPlatform
+}
+
+// daemonPlatform is the platform of the daemon, checked once
+type daemonPlatform struct {
+	once   sync.Once
+	target *dist.Target
 }
 
 type FetchOptions struct {
@@ -66,13 +87,25 @@ type FetchOptions struct {
 	Target       *dist.Target
 	PullPolicy   PullPolicy
 	LayoutOption LayoutOption
+
+	// InsecureRegistries are accessed without TLS, or without verifying their certificates, when fetching from a
+	// registry rather than through the daemon, which has its own configuration
+	InsecureRegistries []string
+
+	// Keychain overrides the keychain of the fetcher, for images read with credentials of their own. These images are
+	// not fetched through the local cache registry.
+	Keychain authn.Keychain
+
+	// Logger overrides the logger of the fetcher, for the fetches of an operation to be logged along with its output.
+	Logger logging.Logger
 }
 
 func NewFetcher(logger logging.Logger, docker DockerClient, opts ...FetcherOption) *Fetcher {
 	fetcher := &Fetcher{
-		logger:   logger,
-		docker:   docker,
-		keychain: authn.DefaultKeychain,
+		logger:         logger,
+		docker:         docker,
+		keychain:       authn.DefaultKeychain,
+		daemonPlatform: &daemonPlatform{},
 	}
 
 	for _, opt := range opts {
@@ -85,17 +118,27 @@ func NewFetcher(logger logging.Logger, docker DockerClient, opts ...FetcherOptio
 var ErrNotFound = errors.New("not found")
 
 func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
+	f = f.withLoggerOf(options)
 	name, err := pname.TranslateRegistry(name, f.registryMirrors, f.logger)
 	if err != nil {
 		return nil, err
 	}
 
 	if (options.LayoutOption != LayoutOption{}) {
-		return f.fetchLayoutImage(name, options.LayoutOption)
+		return f.fetchLayoutImage(name, options.LayoutOption, options.Target, options.InsecureRegistries)
+	}
+
+	keychain, useCache := f.keychain, true
+	if options.Keychain != nil {
+		keychain, useCache = options.Keychain, false
 	}
 
 	if !options.Daemon {
-		return f.fetchRemoteImage(name, options.Target)
+		target := options.Target
+		if target == nil {
+			target = f.daemonTarget(ctx)
+		}
+		return f.fetchRemoteImage(name, target, options.InsecureRegistries, keychain, useCache)
 	}
 
 	switch options.PullPolicy {
@@ -109,6 +152,17 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 		}
 	}
 
+	if interval, ok := options.PullPolicy.Interval(); ok && f.pulledWithin(name, interval) {
+		img, err := f.fetchDaemonImage(name)
+		if err == nil {
+			f.logger.Debugf("Using image %s, pulled within the last %s", style.Symbol(name), interval)
+			return img, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
 	f.logger.Debugf("Pulling image %s", style.Symbol(name))
 
 	platform := ""
@@ -116,23 +170,71 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 		platform = options.Target.ValuesAsPlatform()
 	}
 
-	if err = f.pullImage(ctx, name, platform); err != nil {
+	if err = f.pull(ctx, name, platform, keychain, useCache); err != nil {
 		// sample error from docker engine:
 		// image with reference <image> was found but does not match the specified platform: wanted linux/amd64, actual: linux
 		if strings.Contains(err.Error(), "does not match the specified platform") {
-			err = f.pullImage(ctx, name, "")
+			err = f.pull(ctx, name, "", keychain, useCache)
 		}
 	}
+	if err != nil && strings.Contains(err.Error(), "no matching manifest for") {
+		// sample error from docker engine:
+		// no matching manifest for linux/arm64/v8 in the manifest list entries
+		return nil, errors.Wrapf(err, "image %s has no variant for the platform of the daemon", style.Symbol(name))
+	}
 	if err != nil && !errors.Is(err, ErrNotFound) {
 		return nil, err
 	}
+	if err == nil {
+		f.recordPull(name)
+	}
 
 	return f.fetchDaemonImage(name)
 }
 
+// withLoggerOf returns a copy of the fetcher logging with the logger of the options, or the fetcher when they have none
+func (f *Fetcher) withLoggerOf(options FetchOptions) *Fetcher {
+	if options.Logger == nil {
+		return f
+	}
+	withLogger := *f
+	withLogger.logger = options.Logger
+	return &withLogger
+}
+
+// daemonTarget returns the platform of the daemon, to pick the manifest for it from image indexes when no target is
+// given. When there is no daemon to ask, such as when publishing from environments without one, it is the platform
+// pack runs on, with linux containers unless pack runs on Windows.
+func (f *Fetcher) daemonTarget(ctx context.Context) *dist.Target {
+	platform := f.daemonPlatform
+	platform.once.Do(func() {
+		platform.target = hostTarget()
+		if f.docker == nil {
+			return
+		}
+		version, err := f.docker.ServerVersion(ctx)
+		if err != nil {
+			f.logger.Debugf("Checking the daemon platform, using %s instead: %s", platform.target.ValuesAsPlatform(), err)
+			return
+		}
+		if version.Os != "" && version.Arch != "" {
+			platform.target = &dist.Target{OS: version.Os, Arch: version.Arch}
+		}
+	})
+	return platform.target
+}
+
+func hostTarget() *dist.Target {
+	if runtime.GOOS == "windows" {
+		return &dist.Target{OS: "windows", Arch: runtime.GOARCH}
+	}
+	return &dist.Target{OS: "linux", Arch: runtime.GOARCH}
+}
+
 func (f *Fetcher) CheckReadAccess(repo string, options FetchOptions) bool {
+	f = f.withLoggerOf(options)
 	if !options.Daemon || options.PullPolicy == PullAlways {
-		return f.checkRemoteReadAccess(repo)
+		return f.checkRemoteReadAccess(repo, options.InsecureRegistries)
 	}
 	if _, err := f.fetchDaemonImage(repo); err != nil {
 		if errors.Is(err, ErrNotFound) {
@@ -142,7 +244,7 @@ func (f *Fetcher) CheckReadAccess(repo string, options FetchOptions) bool {
 			if options.PullPolicy == PullNever {
 				return false
 			}
-			return f.checkRemoteReadAccess(repo)
+			return f.checkRemoteReadAccess(repo, options.InsecureRegistries)
 		}
 		f.logger.Debugf("failed reading image '%s' from the daemon, error: %s", repo, err.Error())
 		return false
@@ -150,8 +252,8 @@ func (f *Fetcher) CheckReadAccess(repo string, options FetchOptions) bool {
 	return true
 }
 
-func (f *Fetcher) checkRemoteReadAccess(repo string) bool {
-	img, err := remote.NewImage(repo, f.keychain)
+func (f *Fetcher) checkRemoteReadAccess(repo string, insecureRegistries []string) bool {
+	img, err := remote.NewImage(repo, f.keychain, registrySettings(insecureRegistries)...)
 	if err != nil {
 		f.logger.Debugf("failed accessing remote image %s, error: %s", repo, err.Error())
 		return false
@@ -178,37 +280,75 @@ func (f *Fetcher) fetchDaemonImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
-func (f *Fetcher) fetchRemoteImage(name string, target *dist.Target) (imgutil.Image, error) {
-	var (
-		image imgutil.Image
-		err   error
-	)
+func (f *Fetcher) fetchRemoteImage(name string, target *dist.Target, insecureRegistries []string, keychain authn.Keychain, useCache bool) (imgutil.Image, error) {
+	if f.cacheRegistry != nil && useCache {
+		cacheName, err := f.cacheRegistry.Resolve(name)
+		if err == nil {
+			return f.newRemoteImage(name, cacheName, target, insecureRegistries, authn.NewMultiKeychain(f.cacheRegistry.Keychain(), keychain))
+		}
+		f.logger.Debugf("Fetching %s through the local cache registry failed, fetching it directly: %s", style.Symbol(name), err)
+	}
 
-	if target == nil {
-		image, err = remote.NewImage(name, f.keychain, remote.FromBaseImage(name))
-	} else {
-		platform := imgutil.Platform{OS: target.OS, Architecture: target.Arch, Variant: target.ArchVariant}
-		image, err = remote.NewImage(name, f.keychain, remote.FromBaseImage(name), remote.WithDefaultPlatform(platform))
+	image, err := f.newRemoteImage(name, name, target, insecureRegistries, keychain)
+	if err == nil && image.Found() {
+		return image, nil
+	}
+	if err != nil && !isDeniedByRegistry(err) {
+		return nil, err
 	}
 
+	// registries deny access to images with credentials that are invalid, or meant for other repositories, even when
+	// the images are public
+	if hasCredentials(keychain, name) {
+		anonymous, anonymousErr := f.newRemoteImage(name, name, target, insecureRegistries, anonymousKeychain)
+		if anonymousErr == nil && anonymous.Found() {
+			f.logger.Warnf("Registry denied access to %s with %s, fetched it anonymously", style.Symbol(name), imageCredentialSource(keychain, name))
+			return anonymous, nil
+		}
+	}
 	if err != nil {
+		return nil, newAuthError(name, keychain, err)
+	}
+
+	if err := remoteAccessError(name, keychain, insecureRegistries); err != nil {
 		return nil, err
 	}
+	return nil, errors.Wrapf(ErrNotFound, "image %s does not exist in registry", style.Symbol(name))
+}
 
-	if !image.Found() {
-		return nil, errors.Wrapf(ErrNotFound, "image %s does not exist in registry", style.Symbol(name))
+// newRemoteImage returns the image name, based on the contents of baseName
+func (f *Fetcher) newRemoteImage(name, baseName string, target *dist.Target, insecureRegistries []string, keychain authn.Keychain) (imgutil.Image, error) {
+	imageOpts := append(registrySettings(insecureRegistries), remote.FromBaseImage(baseName))
+	if target != nil {
+		platform := imgutil.Platform{OS: target.OS, Architecture: target.Arch, Variant: target.ArchVariant}
+		imageOpts = append(imageOpts, remote.WithDefaultPlatform(platform))
 	}
+	return remote.NewImage(name, keychain, imageOpts...)
+}
 
-	return image, nil
+// registrySettings returns the image options to access the given registries without TLS
+func registrySettings(insecureRegistries []string) []imgutil.ImageOption {
+	var opts []imgutil.ImageOption
+	for _, registry := range insecureRegistries {
+		opts = append(opts, remote.WithRegistrySetting(registry, true))
+	}
+	return opts
 }
 
-func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption) (imgutil.Image, error) {
+func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption, target *dist.Target, insecureRegistries []string) (imgutil.Image, error) {
 	var (
 		image imgutil.Image
 		err   error
 	)
 
-	v1Image, err := remote.NewV1Image(name, f.keychain)
+	var v1ImageOpts []func(*imgutil.ImageOptions)
+	for _, opt := range registrySettings(insecureRegistries) {
+		v1ImageOpts = append(v1ImageOpts, opt)
+	}
+	if target != nil {
+		v1ImageOpts = append(v1ImageOpts, imgutil.WithDefaultPlatform(imgutil.Platform{OS: target.OS, Architecture: target.Arch, Variant: target.ArchVariant}))
+	}
+	v1Image, err := remote.NewV1Image(name, f.keychain, v1ImageOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -231,12 +371,71 @@ func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption) (imgutil.I
 	return image, nil
 }
 
-func (f *Fetcher) pullImage(ctx context.Context, imageID string, platform string) error {
-	regAuth, err := f.registryAuth(imageID)
+// pull pulls the image into the daemon, through the local cache registry when there is one
+func (f *Fetcher) pull(ctx context.Context, imageID string, platform string, keychain authn.Keychain, useCache bool) error {
+	if f.cacheRegistry != nil && useCache {
+		err := f.pullThroughCache(ctx, imageID, platform)
+		if err == nil {
+			return nil
+		}
+		f.logger.Debugf("Pulling %s through the local cache registry failed, pulling it directly: %s", style.Symbol(imageID), err)
+	}
+	return f.pullImage(ctx, imageID, platform, keychain)
+}
+
+// pullThroughCache pulls the image from the local cache registry, and tags it with its original name
+func (f *Fetcher) pullThroughCache(ctx context.Context, imageID string, platform string) error {
+	ref, err := gname.ParseReference(imageID, gname.WeakValidation)
 	if err != nil {
 		return err
 	}
+	if _, ok := ref.(gname.Tag); !ok {
+		// the daemon can't tag an image with a digest reference
+		return errors.New("only tagged images can be pulled through the cache")
+	}
+
+	cacheName, err := f.cacheRegistry.Name(imageID)
+	if err != nil {
+		return err
+	}
+	if err := f.pullImage(ctx, cacheName, platform, f.cacheRegistry.Keychain()); err != nil {
+		return err
+	}
+	if err := f.docker.ImageTag(ctx, cacheName, imageID); err != nil {
+		return errors.Wrapf(err, "tagging %s", style.Symbol(imageID))
+	}
+	_, err = f.docker.ImageRemove(ctx, cacheName, image.RemoveOptions{})
+	return err
+}
+
+// pullImage pulls the image into the daemon with the credentials of the keychain, retrying anonymously when the
+// registry denies access with them, as public images may be pulled without credentials
+func (f *Fetcher) pullImage(ctx context.Context, imageID string, platform string, keychain authn.Keychain) error {
+	regAuth, err := registryAuth(keychain, imageID)
+	if err != nil {
+		return err
+	}
+
+	err = f.pullImageWithAuth(ctx, imageID, platform, regAuth)
+	if err == nil || errors.Is(err, ErrNotFound) || !isDeniedByDaemon(err) {
+		return err
+	}
+
+	if hasCredentials(keychain, imageID) {
+		f.logger.Debugf("Pulling %s with credentials failed, retrying anonymously: %s", style.Symbol(imageID), err)
+		anonymousAuth, authErr := registryAuth(anonymousKeychain, imageID)
+		if authErr != nil {
+			return authErr
+		}
+		if anonymousErr := f.pullImageWithAuth(ctx, imageID, platform, anonymousAuth); anonymousErr == nil {
+			f.logger.Warnf("Registry denied access to %s with %s, pulled it anonymously", style.Symbol(imageID), imageCredentialSource(keychain, imageID))
+			return nil
+		}
+	}
+	return newAuthError(imageID, keychain, err)
+}
 
+func (f *Fetcher) pullImageWithAuth(ctx context.Context, imageID string, platform string, regAuth string) error {
 	rc, err := f.docker.ImagePull(ctx, imageID, image.PullOptions{RegistryAuth: regAuth, Platform: platform})
 	if err != nil {
 		if client.IsErrNotFound(err) {
@@ -257,8 +456,8 @@ func (f *Fetcher) pullImage(ctx context.Context, imageID string, platform string
 	return rc.Close()
 }
 
-func (f *Fetcher) registryAuth(ref string) (string, error) {
-	_, a, err := auth.ReferenceForRepoName(f.keychain, ref)
+func registryAuth(keychain authn.Keychain, ref string) (string, error) {
+	_, a, err := auth.ReferenceForRepoName(keychain, ref)
 	if err != nil {
 		return "", errors.Wrapf(err, "resolve auth for ref %s", ref)
 	}
@@ -16,6 +16,12 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -85,6 +91,13 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 							_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
 							h.AssertNil(t, err)
 						})
+
+						it("returns the remote image with the keychain of the options", func() {
+							fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), docker, image.WithKeychain(authn.NewMultiKeychain()))
+
+							_, err := fetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, Keychain: authn.DefaultKeychain})
+							h.AssertNil(t, err)
+						})
 					})
 
 					when("platform with variant and version", func() {
@@ -125,6 +138,51 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 
+				when("there is a remote image index", func() {
+					it.Before(func() {
+						var index v1.ImageIndex = empty.Index
+						for _, arch := range []string{"amd64", "arm64"} {
+							img, err := random.Image(1024, 1)
+							h.AssertNil(t, err)
+							img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: arch})
+							h.AssertNil(t, err)
+							index = mutate.AppendManifests(index, mutate.IndexAddendum{
+								Add:        img,
+								Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: arch}},
+							})
+						}
+						ref, err := name.ParseReference(repoName, name.WeakValidation)
+						h.AssertNil(t, err)
+						h.AssertNil(t, ggcrremote.WriteIndex(ref, index, ggcrremote.WithAuthFromKeychain(authn.DefaultKeychain)))
+					})
+
+					it("returns the manifest for the platform of the daemon when no target is given", func() {
+						mockController := gomock.NewController(t)
+						mockDockerClient := testmocks.NewMockCommonAPIClient(mockController)
+						mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "arm64"}, nil)
+						imageFetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDockerClient)
+
+						img, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+						h.AssertNil(t, err)
+						arch, err := img.Architecture()
+						h.AssertNil(t, err)
+						h.AssertEq(t, arch, "arm64")
+					})
+
+					it("returns the manifest for the platform pack runs on when there is no daemon", func() {
+						mockController := gomock.NewController(t)
+						mockDockerClient := testmocks.NewMockCommonAPIClient(mockController)
+						mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{}, errors.New("Cannot connect to the Docker daemon"))
+						imageFetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDockerClient)
+
+						img, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+						h.AssertNil(t, err)
+						arch, err := img.Architecture()
+						h.AssertNil(t, err)
+						h.AssertEq(t, arch, runtime.GOARCH)
+					})
+				})
+
 				when("there is no remote image", func() {
 					it("returns an error", func() {
 						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
@@ -0,0 +1,123 @@
+package image
+
+import (
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// CredentialsCipher encrypts the passwords of stored credentials, for them not to be kept in plain text, see
+// NewEncryptedDockerConfigKeychain
+type CredentialsCipher interface {
+	Encrypt(value string) (string, error)
+	// Decrypt returns the decrypted value, or the value as it is when it isn't encrypted.
+	Decrypt(value string) (string, error)
+}
+
+// dockerConfigKeychain resolves the credentials of registries from a docker config, rather than from the docker config
+// of the user as authn.DefaultKeychain does
+type dockerConfigKeychain struct {
+	configFile *configfile.ConfigFile
+	cipher     CredentialsCipher
+}
+
+// NewDockerConfigKeychain returns a keychain with the credentials of the docker config in the given directory, including
+// the credentials of its credential helpers, for images read with credentials other than the ones of the user.
+func NewDockerConfigKeychain(dir string) (authn.Keychain, error) {
+	return NewEncryptedDockerConfigKeychain(dir, nil)
+}
+
+// NewEncryptedDockerConfigKeychain returns a keychain with the credentials of the docker config in the given directory,
+// like NewDockerConfigKeychain, with their passwords decrypted by the cipher when they're encrypted.
+func NewEncryptedDockerConfigKeychain(dir string, cipher CredentialsCipher) (authn.Keychain, error) {
+	configFile, err := dockerconfig.Load(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading docker config in %s", style.Symbol(dir))
+	}
+	return &dockerConfigKeychain{configFile: configFile, cipher: cipher}, nil
+}
+
+func (k *dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	cfg, err := k.configFile.GetAuthConfig(dockerConfigKey(target.RegistryStr()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading credentials of %s", style.Symbol(target.RegistryStr()))
+	}
+	if k.cipher != nil {
+		if cfg.Password, err = k.cipher.Decrypt(cfg.Password); err != nil {
+			return nil, errors.Wrapf(err, "decrypting credentials of %s", style.Symbol(target.RegistryStr()))
+		}
+	}
+
+	authConfig := authn.AuthConfig{
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		Auth:          cfg.Auth,
+		IdentityToken: cfg.IdentityToken,
+		RegistryToken: cfg.RegistryToken,
+	}
+	if authConfig == (authn.AuthConfig{}) {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authConfig), nil
+}
+
+func (k *dockerConfigKeychain) CredentialSource(target authn.Resource) string {
+	if source := configFileSource(k.configFile, target.RegistryStr()); source != "" {
+		return source
+	}
+	return noCredentials
+}
+
+// StoreCredentials stores the credentials of a registry in the docker config in the given directory, creating it when
+// it does not exist. The credentials are stored in the credential store of the config, or else in the config itself.
+func StoreCredentials(dir, registry, username, password string) error {
+	configFile, err := dockerconfig.Load(dir)
+	if err != nil {
+		return errors.Wrapf(err, "loading docker config in %s", style.Symbol(dir))
+	}
+
+	key := dockerConfigKey(registry)
+	authConfig := types.AuthConfig{Username: username, Password: password, ServerAddress: key}
+	if err := configFile.GetCredentialsStore(key).Store(authConfig); err != nil {
+		return errors.Wrapf(err, "storing credentials of %s", style.Symbol(registry))
+	}
+	return nil
+}
+
+// RemoveCredentials removes the credentials of a registry from the docker config in the given directory, returning
+// whether there were credentials to remove.
+func RemoveCredentials(dir, registry string) (bool, error) {
+	configFile, err := dockerconfig.Load(dir)
+	if err != nil {
+		return false, errors.Wrapf(err, "loading docker config in %s", style.Symbol(dir))
+	}
+
+	key := dockerConfigKey(registry)
+	store := configFile.GetCredentialsStore(key)
+	authConfig, err := store.Get(key)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading credentials of %s", style.Symbol(registry))
+	}
+	if authConfig == (types.AuthConfig{ServerAddress: authConfig.ServerAddress}) {
+		return false, nil
+	}
+
+	if err := store.Erase(key); err != nil {
+		return false, errors.Wrapf(err, "removing credentials of %s", style.Symbol(registry))
+	}
+	return true, nil
+}
+
+// dockerConfigKey returns the key of the credentials of a registry in a docker config
+func dockerConfigKey(registry string) string {
+	if registry == name.DefaultRegistry || registry == "docker.io" {
+		// the docker config keeps the credentials of Docker Hub under its legacy address
+		return authn.DefaultAuthKey
+	}
+	return registry
+}
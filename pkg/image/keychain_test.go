@@ -0,0 +1,117 @@
+package image_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestKeychain(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Keychain", testKeychain, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testKeychain(t *testing.T, when spec.G, it spec.S) {
+	var configDir string
+
+	it.Before(func() {
+		configDir = t.TempDir()
+		h.AssertNil(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{
+  "auths": {
+    "registry.example.com": {"auth": "c29tZS11c2VyOnNvbWUtcGFzc3dvcmQ="},
+    "https://index.docker.io/v1/": {"auth": "aHViLXVzZXI6aHViLXBhc3N3b3Jk"}
+  }
+}`), 0600))
+	})
+
+	resolve := func(keychain authn.Keychain, repo string) *authn.AuthConfig {
+		ref, err := name.ParseReference(repo)
+		h.AssertNil(t, err)
+		authenticator, err := keychain.Resolve(ref.Context())
+		h.AssertNil(t, err)
+		authConfig, err := authenticator.Authorization()
+		h.AssertNil(t, err)
+		return authConfig
+	}
+
+	when("#NewDockerConfigKeychain", func() {
+		it("resolves the credentials of the docker config in the directory", func() {
+			keychain, err := image.NewDockerConfigKeychain(configDir)
+			h.AssertNil(t, err)
+
+			authConfig := resolve(keychain, "registry.example.com/some/lifecycle")
+			h.AssertEq(t, authConfig.Username, "some-user")
+			h.AssertEq(t, authConfig.Password, "some-password")
+		})
+
+		it("resolves the credentials of Docker Hub", func() {
+			keychain, err := image.NewDockerConfigKeychain(configDir)
+			h.AssertNil(t, err)
+
+			authConfig := resolve(keychain, "buildpacksio/lifecycle")
+			h.AssertEq(t, authConfig.Username, "hub-user")
+		})
+
+		it("is anonymous for registries without credentials", func() {
+			keychain, err := image.NewDockerConfigKeychain(configDir)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, resolve(keychain, "other.example.com/some/lifecycle"), &authn.AuthConfig{})
+		})
+	})
+
+	when("#NewEncryptedDockerConfigKeychain", func() {
+		it("decrypts the passwords of the credentials", func() {
+			// "some-user:reversed:drowssap-emos"
+			h.AssertNil(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{
+  "auths": {"registry.example.com": {"auth": "c29tZS11c2VyOnJldmVyc2VkOmRyb3dzc2FwLWVtb3M="}}
+}`), 0600))
+			keychain, err := image.NewEncryptedDockerConfigKeychain(configDir, reversingCipher{})
+			h.AssertNil(t, err)
+
+			authConfig := resolve(keychain, "registry.example.com/some/lifecycle")
+			h.AssertEq(t, authConfig.Username, "some-user")
+			h.AssertEq(t, authConfig.Password, "some-password")
+		})
+
+		it("resolves the passwords that aren't encrypted as they are", func() {
+			keychain, err := image.NewEncryptedDockerConfigKeychain(configDir, reversingCipher{})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, resolve(keychain, "registry.example.com/some/lifecycle").Password, "some-password")
+		})
+	})
+}
+
+// reversingCipher "encrypts" values by reversing them, behind a reversed: prefix
+type reversingCipher struct{}
+
+func (reversingCipher) Encrypt(value string) (string, error) {
+	return "reversed:" + reverse(value), nil
+}
+
+func (reversingCipher) Decrypt(value string) (string, error) {
+	if reversed, ok := strings.CutPrefix(value, "reversed:"); ok {
+		return reverse(reversed), nil
+	}
+	return value, nil
+}
+
+func reverse(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -1,10 +1,13 @@
 package image
 
 import (
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
 )
 
-// PullPolicy defines a policy for how to manage images
+// PullPolicy defines a policy for how to manage images. Policies below zero pull images at an interval, see PullInterval.
 type PullPolicy int
 
 const (
@@ -18,12 +21,37 @@ const (
 
 var nameMap = map[string]PullPolicy{"always": PullAlways, "never": PullNever, "if-not-present": PullIfNotPresent, "": PullAlways}
 
-// ParsePullPolicy from string
+// PullInterval returns a policy that pulls images only when they were last pulled longer ago than the interval, and
+// otherwise uses the images in the daemon. The interval is rounded down to the second.
+func PullInterval(interval time.Duration) PullPolicy {
+	return PullPolicy(-int64(interval / time.Second))
+}
+
+// Interval returns the interval of a policy returned by PullInterval, and whether the policy pulls at an interval.
+func (p PullPolicy) Interval() (time.Duration, bool) {
+	if p >= 0 {
+		return 0, false
+	}
+	return time.Duration(-p) * time.Second, true
+}
+
+// ParsePullPolicy from string, such as always, or interval=24h for PullInterval
 func ParsePullPolicy(policy string) (PullPolicy, error) {
 	if val, ok := nameMap[policy]; ok {
 		return val, nil
 	}
 
+	if value, ok := strings.CutPrefix(policy, "interval="); ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return PullAlways, errors.Wrapf(err, "invalid pull policy %s", policy)
+		}
+		if interval < time.Second {
+			return PullAlways, errors.Errorf("invalid pull policy %s, the interval must be at least a second", policy)
+		}
+		return PullInterval(interval), nil
+	}
+
 	return PullAlways, errors.Errorf("invalid pull policy %s", policy)
 }
 
@@ -37,5 +65,17 @@ func (p PullPolicy) String() string {
 		return "if-not-present"
 	}
 
+	if interval, ok := p.Interval(); ok {
+		// 24h rather than 24h0m0s
+		value := interval.String()
+		if strings.HasSuffix(value, "m0s") {
+			value = strings.TrimSuffix(value, "0s")
+		}
+		if strings.HasSuffix(value, "h0m") {
+			value = strings.TrimSuffix(value, "0m")
+		}
+		return "interval=" + value
+	}
+
 	return ""
 }
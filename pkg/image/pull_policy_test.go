@@ -2,6 +2,7 @@ package image_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -40,6 +41,24 @@ func testPullPolicy(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, policy, image.PullAlways)
 		})
 
+		it("returns PullInterval for interval=<duration>", func() {
+			policy, err := image.ParsePullPolicy("interval=24h")
+			h.AssertNil(t, err)
+			h.AssertEq(t, policy, image.PullInterval(24*time.Hour))
+
+			interval, ok := policy.Interval()
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, interval, 24*time.Hour)
+		})
+
+		it("returns error for invalid intervals", func() {
+			_, err := image.ParsePullPolicy("interval=daily")
+			h.AssertError(t, err, "invalid pull policy interval=daily")
+
+			_, err = image.ParsePullPolicy("interval=10ms")
+			h.AssertError(t, err, "the interval must be at least a second")
+		})
+
 		it("returns error for unknown string", func() {
 			_, err := image.ParsePullPolicy("fake-policy-here")
 			h.AssertError(t, err, "invalid pull policy")
@@ -51,6 +70,9 @@ func testPullPolicy(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, image.PullAlways.String(), "always")
 			h.AssertEq(t, image.PullNever.String(), "never")
 			h.AssertEq(t, image.PullIfNotPresent.String(), "if-not-present")
+			h.AssertEq(t, image.PullInterval(24*time.Hour).String(), "interval=24h")
+			h.AssertEq(t, image.PullInterval(90*time.Minute).String(), "interval=1h30m")
+			h.AssertEq(t, image.PullInterval(30*time.Second).String(), "interval=30s")
 		})
 	})
 }
@@ -0,0 +1,109 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/lock"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// WithPullTimesFile records the time images are pulled into the daemon at in the given state file, for policies
+// returned by PullInterval to tell how long ago images were pulled, which the daemon does not record.
+func WithPullTimesFile(path string) FetcherOption {
+	return func(c *Fetcher) {
+		c.pullTimesFile = path
+	}
+}
+
+// pulledWithin returns whether the image was last pulled within the interval, images whose pull time was not recorded
+// are not
+func (f *Fetcher) pulledWithin(name string, interval time.Duration) bool {
+	pullTimes, err := f.readPullTimes()
+	if err != nil {
+		f.logger.Debugf("Reading pull times: %s", err)
+		return false
+	}
+
+	pulledAt, ok := pullTimes[name]
+	return ok && time.Since(pulledAt) < interval
+}
+
+// recordPull records that the image was pulled now
+func (f *Fetcher) recordPull(name string) {
+	if f.pullTimesFile == "" {
+		return
+	}
+
+	err := f.updatePullTimes(func(pullTimes map[string]time.Time) {
+		pullTimes[name] = time.Now().UTC()
+	})
+	if err != nil {
+		f.logger.Debugf("Recording pull time of %s: %s", style.Symbol(name), err)
+	}
+}
+
+func (f *Fetcher) readPullTimes() (map[string]time.Time, error) {
+	pullTimes := map[string]time.Time{}
+	if f.pullTimesFile == "" {
+		return pullTimes, nil
+	}
+
+	contents, err := os.ReadFile(f.pullTimesFile)
+	if os.IsNotExist(err) {
+		return pullTimes, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pull times")
+	}
+
+	if err := json.Unmarshal(contents, &pullTimes); err != nil {
+		return nil, errors.Wrapf(err, "parsing pull times file %s", style.Symbol(f.pullTimesFile))
+	}
+	return pullTimes, nil
+}
+
+// updatePullTimes rewrites the pull times file, replacing it rather than writing it in place so that other pack
+// processes never read a partial file, and locking it for updates running at once not to lose each other's changes
+func (f *Fetcher) updatePullTimes(update func(map[string]time.Time)) error {
+	fileLock, err := lock.Acquire(context.Background(), f.pullTimesFile+".lock", nil)
+	if err != nil {
+		return errors.Wrap(err, "locking pull times")
+	}
+	defer fileLock.Release()
+
+	pullTimes, err := f.readPullTimes()
+	if err != nil {
+		return err
+	}
+	update(pullTimes)
+
+	contents, err := json.MarshalIndent(pullTimes, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling pull times")
+	}
+
+	dir := filepath.Dir(f.pullTimesFile)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(err, "creating pull times directory")
+	}
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(f.pullTimesFile))
+	if err != nil {
+		return errors.Wrap(err, "writing pull times")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(contents); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "writing pull times")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "writing pull times")
+	}
+	return os.Rename(tmpFile.Name(), f.pullTimesFile)
+}
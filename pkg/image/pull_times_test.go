@@ -0,0 +1,118 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPullTimes(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "PullTimes", testPullTimes, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPullTimes(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		fetcher        *image.Fetcher
+		pullTimesFile  string
+		outBuf         bytes.Buffer
+	)
+
+	const imageName = "some/builder"
+
+	writePullTimes := func(pullTimes map[string]time.Time) {
+		contents, err := json.Marshal(pullTimes)
+		h.AssertNil(t, err)
+		h.AssertNil(t, os.WriteFile(pullTimesFile, contents, 0600))
+	}
+
+	readPullTimes := func() map[string]time.Time {
+		contents, err := os.ReadFile(pullTimesFile)
+		h.AssertNil(t, err)
+		pullTimes := map[string]time.Time{}
+		h.AssertNil(t, json.Unmarshal(contents, &pullTimes))
+		return pullTimes
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		pullTimesFile = filepath.Join(t.TempDir(), "pull-times.json")
+		fetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDocker, image.WithPullTimesFile(pullTimesFile))
+
+		mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{}, nil).AnyTimes()
+		mockDocker.EXPECT().
+			ImageInspectWithRaw(gomock.Any(), imageName).
+			Return(types.ImageInspect{ID: "some-id", Os: "linux"}, nil, nil).
+			AnyTimes()
+		mockDocker.EXPECT().ImageHistory(gomock.Any(), imageName).Return(nil, nil).AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	expectPull := func() {
+		mockDocker.EXPECT().
+			ImagePull(gomock.Any(), imageName, gomock.Any()).
+			Return(io.NopCloser(strings.NewReader(`{"status":"Downloaded newer image"}`)), nil)
+	}
+
+	when("the pull policy pulls at an interval", func() {
+		it("uses the image in the daemon when it was pulled within the interval", func() {
+			writePullTimes(map[string]time.Time{imageName: time.Now().Add(-time.Hour)})
+
+			img, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullInterval(24 * time.Hour)})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, img.Found())
+		})
+
+		it("pulls the image when it was pulled longer ago than the interval", func() {
+			pulledAt := time.Now().Add(-48 * time.Hour)
+			writePullTimes(map[string]time.Time{imageName: pulledAt})
+			expectPull()
+
+			_, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullInterval(24 * time.Hour)})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, readPullTimes()[imageName].After(pulledAt))
+		})
+
+		it("pulls the image when its pull time was not recorded", func() {
+			expectPull()
+
+			_, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullInterval(24 * time.Hour)})
+			h.AssertNil(t, err)
+			_, ok := readPullTimes()[imageName]
+			h.AssertTrue(t, ok)
+		})
+	})
+
+	it("records the pull times of other policies", func() {
+		expectPull()
+
+		_, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+		h.AssertNil(t, err)
+		_, ok := readPullTimes()[imageName]
+		h.AssertTrue(t, ok)
+	})
+}
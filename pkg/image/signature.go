@@ -0,0 +1,359 @@
+package image
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation       = "dev.sigstore.cosign/chain"
+)
+
+var (
+	// OIDs of the Fulcio certificate extensions holding the OIDC issuer of the signer
+	fulcioIssuerV1OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	fulcioIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// TrustPolicy defines which signatures are required for images pulled from a set of registries.
+type TrustPolicy struct {
+	// When Enforce is false, images failing verification are reported but still used.
+	Enforce bool
+
+	// Registries governed by the policy. Images that do not match any registry are not verified.
+	Registries []TrustedRegistry
+}
+
+// TrustedRegistry defines the signers trusted for images whose name starts with Prefix.
+type TrustedRegistry struct {
+	// Registry (`gcr.io`) or repository prefix (`docker.io/paketobuildpacks`) the entry applies to.
+	Prefix string
+
+	// PEM encoded public keys accepted for key based signatures.
+	PublicKeys []string
+
+	// Identities accepted for certificate based (keyless) signatures.
+	Identities []TrustedIdentity
+
+	// PEM encoded root certificates the signing certificates must chain to.
+	RootCertificates string
+}
+
+// TrustedIdentity is a signer identity, as recorded in a signing certificate.
+type TrustedIdentity struct {
+	// OIDC issuer that authenticated the signer, e.g. `https://token.actions.githubusercontent.com`.
+	Issuer string
+
+	// Email or URI subject alternative name of the signing certificate.
+	Subject string
+}
+
+// Validate returns an error if any registry of the policy is misconfigured.
+func (p TrustPolicy) Validate() error {
+	for _, r := range p.Registries {
+		if prefix, _ := normalizePrefix(r.Prefix); prefix == "" {
+			return errors.Errorf("invalid trust policy prefix %s", style.Symbol(r.Prefix))
+		}
+
+		if len(r.PublicKeys) == 0 && len(r.Identities) == 0 {
+			return errors.Errorf("trust policy for %s must define public keys or identities", style.Symbol(r.Prefix))
+		}
+
+		for _, key := range r.PublicKeys {
+			if _, err := parsePublicKey(key); err != nil {
+				return errors.Wrapf(err, "trust policy for %s", style.Symbol(r.Prefix))
+			}
+		}
+
+		for _, identity := range r.Identities {
+			if identity.Issuer == "" || identity.Subject == "" {
+				return errors.Errorf("identities in trust policy for %s require an issuer and a subject", style.Symbol(r.Prefix))
+			}
+		}
+
+		if len(r.Identities) > 0 && !x509.NewCertPool().AppendCertsFromPEM([]byte(r.RootCertificates)) {
+			return errors.Errorf("trust policy for %s defines identities but no root certificates", style.Symbol(r.Prefix))
+		}
+	}
+	return nil
+}
+
+// SignatureVerifier checks cosign signatures of images against a TrustPolicy.
+//
+// Signatures are read from the `sha256-<digest>.sig` tag of the image repository. Certificates of keyless
+// signatures are validated against the configured roots at the time they were issued; inclusion in a
+// transparency log is not verified.
+type SignatureVerifier struct {
+	policy   TrustPolicy
+	keychain authn.Keychain
+}
+
+// NewSignatureVerifier returns a SignatureVerifier for policy, using keychain to access registries.
+func NewSignatureVerifier(policy TrustPolicy, keychain authn.Keychain) *SignatureVerifier {
+	return &SignatureVerifier{
+		policy:   policy,
+		keychain: keychain,
+	}
+}
+
+// Enforced returns whether images failing verification must be refused.
+func (v *SignatureVerifier) Enforced() bool {
+	return v.policy.Enforce
+}
+
+// Verify returns an error if imageName is governed by the policy and has no signature from a trusted signer, or else
+// the digest the signature was verified for, which is empty when imageName isn't governed by the policy. Images must
+// be fetched by that digest, as tags may have moved since. The signatures are read from the registry, so verification
+// needs access to it even when images are otherwise read from the daemon.
+func (v *SignatureVerifier) Verify(ctx context.Context, imageName string) (string, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing image name %s", style.Symbol(imageName))
+	}
+
+	registry, ok := v.registryFor(ref)
+	if !ok {
+		return "", nil
+	}
+
+	opts := []remote.Option{remote.WithAuthFromKeychain(v.keychain), remote.WithContext(ctx)}
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving digest of %s for signature verification", style.Symbol(imageName))
+	}
+
+	sigTag := ref.Context().Tag(strings.Replace(desc.Digest.String(), ":", "-", 1) + ".sig")
+	sigImage, err := remote.Image(sigTag, opts...)
+	if err != nil {
+		return "", errors.Errorf("image %s is not signed", style.Symbol(imageName))
+	}
+
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading signatures of %s", style.Symbol(imageName))
+	}
+
+	for _, layer := range manifest.Layers {
+		if err := v.verifySignatureLayer(sigImage, layer, desc.Digest, registry); err == nil {
+			return desc.Digest.String(), nil
+		}
+	}
+
+	return "", errors.Errorf("image %s has no signature from a signer trusted for %s", style.Symbol(imageName), style.Symbol(registry.Prefix))
+}
+
+func (v *SignatureVerifier) registryFor(ref name.Reference) (TrustedRegistry, bool) {
+	var (
+		match     TrustedRegistry
+		matchSize = -1
+	)
+	repo := ref.Context()
+	for _, r := range v.policy.Registries {
+		prefix, isRegistry := normalizePrefix(r.Prefix)
+		if prefix == "" {
+			continue
+		}
+
+		var matches bool
+		if isRegistry {
+			matches = repo.RegistryStr() == prefix
+		} else {
+			matches = repo.Name() == prefix || strings.HasPrefix(repo.Name(), prefix+"/")
+		}
+
+		if matches && len(prefix) > matchSize {
+			match, matchSize = r, len(prefix)
+		}
+	}
+	return match, matchSize >= 0
+}
+
+// normalizePrefix returns the fully qualified form of prefix, and whether it names a whole registry
+func normalizePrefix(prefix string) (string, bool) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if !strings.Contains(prefix, "/") {
+		registry, err := name.NewRegistry(prefix, name.WeakValidation)
+		if err != nil {
+			return "", false
+		}
+		return registry.RegistryStr(), true
+	}
+
+	repo, err := name.NewRepository(prefix, name.WeakValidation)
+	if err != nil {
+		return "", false
+	}
+	return repo.Name(), false
+}
+
+func (v *SignatureVerifier) verifySignatureLayer(sigImage v1.Image, desc v1.Descriptor, digest v1.Hash, registry TrustedRegistry) error {
+	signature, err := base64.StdEncoding.DecodeString(desc.Annotations[cosignSignatureAnnotation])
+	if err != nil || len(signature) == 0 {
+		return errors.New("missing signature")
+	}
+
+	layer, err := sigImage.LayerByDigest(desc.Digest)
+	if err != nil {
+		return err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	payload, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return err
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest.String() {
+		return errors.New("signature is for a different image")
+	}
+
+	for _, key := range registry.PublicKeys {
+		publicKey, err := parsePublicKey(key)
+		if err == nil && verifySignature(publicKey, payload, signature) == nil {
+			return nil
+		}
+	}
+
+	if cert := desc.Annotations[cosignCertificateAnnotation]; cert != "" && len(registry.Identities) > 0 {
+		return verifyCertificateSignature(cert, desc.Annotations[cosignChainAnnotation], payload, signature, registry)
+	}
+
+	return errors.New("signature does not match any trusted signer")
+}
+
+func verifyCertificateSignature(certPEM, chainPEM string, payload, signature []byte, registry TrustedRegistry) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return errors.New("invalid signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "parsing signing certificate")
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(registry.RootCertificates)) {
+		return errors.Errorf("no root certificates configured for %s", style.Symbol(registry.Prefix))
+	}
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM([]byte(chainPEM))
+
+	// signing certificates are short lived, so the chain is validated at the time the certificate was issued
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   cert.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return errors.Wrap(err, "verifying signing certificate")
+	}
+
+	if !matchesIdentity(cert, registry.Identities) {
+		return errors.New("signing certificate identity is not trusted")
+	}
+
+	return verifySignature(cert.PublicKey, payload, signature)
+}
+
+func matchesIdentity(cert *x509.Certificate, identities []TrustedIdentity) bool {
+	issuer := certificateIssuer(cert)
+	subjects := append([]string{}, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+
+	for _, identity := range identities {
+		if identity.Issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if subject == identity.Subject {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(fulcioIssuerV2OID):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(fulcioIssuerV1OID):
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+func parsePublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("invalid PEM encoded public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing public key")
+	}
+	return publicKey, nil
+}
+
+func verifySignature(publicKey crypto.PublicKey, payload, signature []byte) error {
+	digest := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(key, digest[:], signature) {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(key, payload, signature) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil {
+			return nil
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", publicKey)
+	}
+	return errors.New("invalid signature")
+}
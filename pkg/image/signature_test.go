@@ -0,0 +1,283 @@
+package image_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SignatureVerifier", testSignatureVerifier, spec.Report(report.Terminal{}))
+}
+
+func testSignatureVerifier(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server    *httptest.Server
+		host      string
+		imageName string
+		key       *ecdsa.PrivateKey
+		keyPEM    string
+	)
+
+	pushImage := func(repoName string) string {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(repoName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest.String()
+	}
+
+	// sign pushes a cosign signature of imageName, produced by signer, with the given extra layer annotations
+	sign := func(imageName string, signer crypto.Signer, annotations map[string]string) {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		desc, err := remote.Head(ref)
+		h.AssertNil(t, err)
+
+		payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, ref.Context().Name(), desc.Digest))
+		hash := sha256.Sum256(payload)
+		signature, err := signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+		h.AssertNil(t, err)
+
+		layerAnnotations := map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature)}
+		for k, v := range annotations {
+			layerAnnotations[k] = v
+		}
+
+		sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+			Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+			Annotations: layerAnnotations,
+			MediaType:   types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json"),
+		})
+		h.AssertNil(t, err)
+		sigTag := ref.Context().Tag(strings.Replace(desc.Digest.String(), ":", "-", 1) + ".sig")
+		h.AssertNil(t, remote.Write(sigTag, sigImage))
+	}
+
+	publicKeyPEM := func(key crypto.PublicKey) string {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		h.AssertNil(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	newRoot := func() (*x509.Certificate, *ecdsa.PrivateKey, string) {
+		rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		rootTemplate := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "test root"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+		h.AssertNil(t, err)
+		root, err := x509.ParseCertificate(rootDER)
+		h.AssertNil(t, err)
+		return root, rootKey, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER}))
+	}
+
+	verifier := func(registries ...image.TrustedRegistry) *image.SignatureVerifier {
+		policy := image.TrustPolicy{Enforce: true, Registries: registries}
+		h.AssertNil(t, policy.Validate())
+		return image.NewSignatureVerifier(policy, authn.DefaultKeychain)
+	}
+
+	verifyErr := func(subject *image.SignatureVerifier) error {
+		_, err := subject.Verify(context.TODO(), imageName)
+		return err
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		host = strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1)
+		imageName = host + "/buildpacks/builder:latest"
+		pushImage(imageName)
+
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		keyPEM = publicKeyPEM(key.Public())
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#Verify", func() {
+		when("the image is signed with a trusted key", func() {
+			it.Before(func() {
+				sign(imageName, key, nil)
+			})
+
+			it("succeeds", func() {
+				subject := verifier(image.TrustedRegistry{Prefix: host + "/buildpacks", PublicKeys: []string{keyPEM}})
+				h.AssertNil(t, verifyErr(subject))
+			})
+
+			it("returns the digest the signature was verified for", func() {
+				ref, err := name.ParseReference(imageName, name.WeakValidation)
+				h.AssertNil(t, err)
+				desc, err := remote.Head(ref)
+				h.AssertNil(t, err)
+
+				subject := verifier(image.TrustedRegistry{Prefix: host + "/buildpacks", PublicKeys: []string{keyPEM}})
+				digest, err := subject.Verify(context.TODO(), imageName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, digest, desc.Digest.String())
+			})
+
+			it("fails when the key is not trusted", func() {
+				otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				subject := verifier(image.TrustedRegistry{Prefix: host, PublicKeys: []string{publicKeyPEM(otherKey.Public())}})
+				err = verifyErr(subject)
+				h.AssertError(t, err, fmt.Sprintf("image '%s' has no signature from a signer trusted for '%s'", imageName, host))
+			})
+
+			it("fails when the tag was moved to an unsigned image", func() {
+				pushImage(imageName)
+
+				subject := verifier(image.TrustedRegistry{Prefix: host, PublicKeys: []string{keyPEM}})
+				h.AssertError(t, verifyErr(subject), fmt.Sprintf("image '%s' is not signed", imageName))
+			})
+		})
+
+		it("fails for unsigned images", func() {
+			subject := verifier(image.TrustedRegistry{Prefix: host, PublicKeys: []string{keyPEM}})
+			h.AssertError(t, verifyErr(subject), fmt.Sprintf("image '%s' is not signed", imageName))
+		})
+
+		it("does not verify images outside of the policy", func() {
+			subject := verifier(image.TrustedRegistry{Prefix: host + "/other", PublicKeys: []string{keyPEM}})
+			h.AssertNil(t, verifyErr(subject))
+		})
+
+		it("uses the most specific registry of the policy", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			sign(imageName, otherKey, nil)
+
+			subject := verifier(
+				image.TrustedRegistry{Prefix: host, PublicKeys: []string{keyPEM}},
+				image.TrustedRegistry{Prefix: host + "/buildpacks/builder", PublicKeys: []string{publicKeyPEM(otherKey.Public())}},
+			)
+			h.AssertNil(t, verifyErr(subject))
+		})
+
+		when("the image is signed with a certificate", func() {
+			var (
+				rootPEM  string
+				issuer   = "https://token.actions.example.com"
+				subject  = "release@example.com"
+				leafKey  *ecdsa.PrivateKey
+				identity = image.TrustedIdentity{Issuer: issuer, Subject: subject}
+			)
+
+			it.Before(func() {
+				var root *x509.Certificate
+				var rootKey *ecdsa.PrivateKey
+				root, rootKey, rootPEM = newRoot()
+
+				issuerValue, err := asn1.Marshal(issuer)
+				h.AssertNil(t, err)
+				leafKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				leafTemplate := &x509.Certificate{
+					SerialNumber:    big.NewInt(2),
+					NotBefore:       time.Now().Add(-time.Minute),
+					NotAfter:        time.Now().Add(-time.Second),
+					EmailAddresses:  []string{subject},
+					KeyUsage:        x509.KeyUsageDigitalSignature,
+					ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+					ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}, Value: issuerValue}},
+				}
+				leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, root, leafKey.Public(), rootKey)
+				h.AssertNil(t, err)
+				leafPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}))
+
+				sign(imageName, leafKey, map[string]string{"dev.sigstore.cosign/certificate": leafPEM})
+			})
+
+			it("succeeds for an expired certificate issued to a trusted identity", func() {
+				subject := verifier(image.TrustedRegistry{Prefix: host, Identities: []image.TrustedIdentity{identity}, RootCertificates: rootPEM})
+				h.AssertNil(t, verifyErr(subject))
+			})
+
+			it("fails when the identity is not trusted", func() {
+				subject := verifier(image.TrustedRegistry{
+					Prefix:           host,
+					Identities:       []image.TrustedIdentity{{Issuer: issuer, Subject: "someone-else@example.com"}},
+					RootCertificates: rootPEM,
+				})
+				h.AssertNotNil(t, verifyErr(subject))
+			})
+
+			it("fails when the certificate does not chain to the trusted roots", func() {
+				_, _, otherRootPEM := newRoot()
+				subject := verifier(image.TrustedRegistry{Prefix: host, Identities: []image.TrustedIdentity{identity}, RootCertificates: otherRootPEM})
+				h.AssertNotNil(t, verifyErr(subject))
+			})
+		})
+	})
+
+	when("#Validate", func() {
+		it("requires public keys or identities", func() {
+			err := image.TrustPolicy{Registries: []image.TrustedRegistry{{Prefix: "gcr.io"}}}.Validate()
+			h.AssertError(t, err, "trust policy for 'gcr.io' must define public keys or identities")
+		})
+
+		it("rejects invalid public keys", func() {
+			err := image.TrustPolicy{Registries: []image.TrustedRegistry{{Prefix: "gcr.io", PublicKeys: []string{"not a key"}}}}.Validate()
+			h.AssertError(t, err, "invalid PEM encoded public key")
+		})
+
+		it("requires root certificates for identities", func() {
+			err := image.TrustPolicy{Registries: []image.TrustedRegistry{{
+				Prefix:     "gcr.io",
+				Identities: []image.TrustedIdentity{{Issuer: "https://issuer", Subject: "someone@example.com"}},
+			}}}.Validate()
+			h.AssertError(t, err, "trust policy for 'gcr.io' defines identities but no root certificates")
+		})
+	})
+}
@@ -0,0 +1,361 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// DefaultUploadChunkSize is the number of bytes of a layer sent to the registry per request.
+const DefaultUploadChunkSize = 16 * 1024 * 1024
+
+// UploaderOption is a type of function that mutate settings on the BlobUploader.
+// Values in these functions are set through currying.
+type UploaderOption func(u *BlobUploader)
+
+// WithChunkSize sets the number of bytes sent per request. Layers smaller than a chunk are not uploaded by the
+// BlobUploader, and are left to be pushed with the image.
+func WithChunkSize(size int64) UploaderOption {
+	return func(u *BlobUploader) {
+		u.chunkSize = size
+	}
+}
+
+// WithRetries sets how many times a chunk is resent after a network or server error before giving up.
+func WithRetries(retries int) UploaderOption {
+	return func(u *BlobUploader) {
+		u.retries = retries
+	}
+}
+
+// BlobUploader pushes image layers to a registry using the chunked upload API, recording the progress of each
+// upload so that an interrupted push resumes from the last chunk the registry received instead of starting over.
+type BlobUploader struct {
+	logger        logging.Logger
+	keychain      authn.Keychain
+	checkpointDir string
+	chunkSize     int64
+	retries       int
+	backoff       time.Duration
+}
+
+// uploadCheckpoint records how much of a layer has been received by the registry
+type uploadCheckpoint struct {
+	Location string `json:"location"`
+	Offset   int64  `json:"offset"`
+}
+
+var errUploadExpired = errors.New("upload session expired")
+
+func NewBlobUploader(logger logging.Logger, keychain authn.Keychain, checkpointDir string, opts ...UploaderOption) *BlobUploader {
+	uploader := &BlobUploader{
+		logger:        logger,
+		keychain:      keychain,
+		checkpointDir: checkpointDir,
+		chunkSize:     DefaultUploadChunkSize,
+		retries:       3,
+		backoff:       time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(uploader)
+	}
+
+	return uploader
+}
+
+// UploadLayers uploads the layers of img, that are not already in the registry, to the repository of imageName.
+func (u *BlobUploader) UploadLayers(ctx context.Context, imageName string, img v1.Image) error {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return errors.Wrap(err, "getting layers")
+	}
+
+	for _, layer := range layers {
+		if err := u.UploadLayer(ctx, ref.Context(), layer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UploadLayer uploads layer to repo, resuming any previously interrupted upload of it.
+func (u *BlobUploader) UploadLayer(ctx context.Context, repo name.Repository, layer v1.Layer) error {
+	size, err := layer.Size()
+	if err != nil {
+		return errors.Wrap(err, "getting layer size")
+	}
+	if size < u.chunkSize {
+		return nil
+	}
+
+	digest, err := layer.Digest()
+	if err != nil {
+		return errors.Wrap(err, "getting layer digest")
+	}
+
+	auth, err := u.keychain.Resolve(repo.Registry)
+	if err != nil {
+		return errors.Wrapf(err, "resolving credentials for %s", style.Symbol(repo.RegistryStr()))
+	}
+	tr, err := transport.NewWithContext(ctx, repo.Registry, auth, remote.DefaultTransport, []string{repo.Scope(transport.PushScope)})
+	if err != nil {
+		return errors.Wrapf(err, "connecting to %s", style.Symbol(repo.RegistryStr()))
+	}
+	client := &http.Client{Transport: tr}
+
+	exists, err := blobExists(ctx, client, repo, digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	checkpointPath := u.checkpointPath(repo, digest)
+	err = u.upload(ctx, client, repo, layer, digest, size, checkpointPath)
+	if errors.Is(err, errUploadExpired) {
+		u.logger.Debugf("Upload of layer %s expired, restarting it", style.Symbol(digest.String()))
+		if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "removing upload checkpoint")
+		}
+		err = u.upload(ctx, client, repo, layer, digest, size, checkpointPath)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "uploading layer %s to %s", style.Symbol(digest.String()), style.Symbol(repo.Name()))
+	}
+
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing upload checkpoint")
+	}
+	return nil
+}
+
+func (u *BlobUploader) upload(ctx context.Context, client *http.Client, repo name.Repository, layer v1.Layer, digest v1.Hash, size int64, checkpointPath string) error {
+	checkpoint, err := readCheckpoint(checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint.Location == "" {
+		checkpoint.Location, err = startUpload(ctx, client, repo)
+		if err != nil {
+			return err
+		}
+	} else {
+		u.logger.Infof("Resuming upload of layer %s at %d of %d bytes", style.Symbol(digest.String()), checkpoint.Offset, size)
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return errors.Wrap(err, "reading layer")
+	}
+	defer rc.Close()
+
+	if _, err := io.CopyN(io.Discard, rc, checkpoint.Offset); err != nil {
+		return errors.Wrap(err, "skipping uploaded bytes")
+	}
+
+	chunk := make([]byte, u.chunkSize)
+	for checkpoint.Offset < size {
+		n, err := io.ReadFull(rc, chunk)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return errors.Wrap(err, "reading layer")
+		}
+
+		var location string
+		for attempt := 0; ; attempt++ {
+			location, err = patchChunk(ctx, client, checkpoint.Location, checkpoint.Offset, chunk[:n])
+			if err == nil || attempt >= u.retries || !isRetryable(err) {
+				break
+			}
+			u.logger.Debugf("Retrying upload of layer %s at %d bytes: %s", style.Symbol(digest.String()), checkpoint.Offset, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(u.backoff * time.Duration(attempt+1)):
+			}
+		}
+		if err != nil {
+			if isExpired(err) {
+				return errUploadExpired
+			}
+			return err
+		}
+
+		checkpoint.Location = location
+		checkpoint.Offset += int64(n)
+		if err := writeCheckpoint(checkpointPath, checkpoint); err != nil {
+			return err
+		}
+	}
+
+	return commitUpload(ctx, client, checkpoint.Location, digest)
+}
+
+// checkpointPath returns the path the progress of uploading digest to repo is recorded at
+func (u *BlobUploader) checkpointPath(repo name.Repository, digest v1.Hash) string {
+	sum := sha256.Sum256([]byte(repo.Name() + "@" + digest.String()))
+	return filepath.Join(u.checkpointDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func readCheckpoint(path string) (uploadCheckpoint, error) {
+	var checkpoint uploadCheckpoint
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, errors.Wrap(err, "reading upload checkpoint")
+	}
+	if err := json.Unmarshal(contents, &checkpoint); err != nil {
+		// a corrupt checkpoint only means the upload starts over
+		return uploadCheckpoint{}, nil
+	}
+	return checkpoint, nil
+}
+
+func writeCheckpoint(path string, checkpoint uploadCheckpoint) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrap(err, "creating upload checkpoint directory")
+	}
+	contents, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "marshalling upload checkpoint")
+	}
+	if err := os.WriteFile(path, contents, 0600); err != nil {
+		return errors.Wrap(err, "writing upload checkpoint")
+	}
+	return nil
+}
+
+func blobExists(ctx context.Context, client *http.Client, repo name.Repository, digest v1.Hash) (bool, error) {
+	u := url.URL{Scheme: repo.Scheme(), Host: repo.RegistryStr(), Path: fmt.Sprintf("/v2/%s/blobs/%s", repo.RepositoryStr(), digest)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if err := transport.CheckError(resp, http.StatusOK, http.StatusNotFound); err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+func startUpload(ctx context.Context, client *http.Client, repo name.Repository) (string, error) {
+	u := url.URL{Scheme: repo.Scheme(), Host: repo.RegistryStr(), Path: fmt.Sprintf("/v2/%s/blobs/uploads/", repo.RepositoryStr())}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := transport.CheckError(resp, http.StatusAccepted); err != nil {
+		return "", errors.Wrap(err, "starting upload")
+	}
+	return resolveLocation(resp)
+}
+
+func patchChunk(ctx context.Context, client *http.Client, location string, offset int64, chunk []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, location, bytes.NewReader(chunk))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(len(chunk))-1))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := transport.CheckError(resp, http.StatusAccepted, http.StatusNoContent); err != nil {
+		return "", err
+	}
+	return resolveLocation(resp)
+}
+
+func commitUpload(ctx context.Context, client *http.Client, location string, digest v1.Hash) error {
+	u, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("digest", digest.String())
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := transport.CheckError(resp, http.StatusCreated); err != nil {
+		return errors.Wrap(err, "committing upload")
+	}
+	return nil
+}
+
+// resolveLocation returns the absolute URL of the upload session, which registries may return as a relative path
+func resolveLocation(resp *http.Response) (string, error) {
+	location, err := resp.Location()
+	if err != nil {
+		return "", errors.Wrap(err, "reading upload location")
+	}
+	return location.String(), nil
+}
+
+// isRetryable returns true for network errors and server errors, which may succeed if the request is sent again
+func isRetryable(err error) bool {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		return terr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// isExpired returns true if the registry no longer knows about the upload session or its progress
+func isExpired(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && (terr.StatusCode == http.StatusNotFound || terr.StatusCode == http.StatusRequestedRangeNotSatisfiable)
+}
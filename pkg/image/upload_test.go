@@ -0,0 +1,164 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBlobUploader(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BlobUploader", testBlobUploader, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBlobUploader(t *testing.T, when spec.G, it spec.S) {
+	const chunkSize = 1024
+
+	var (
+		server        *httptest.Server
+		repo          name.Repository
+		layer         v1.Layer
+		checkpointDir string
+		outBuf        bytes.Buffer
+
+		mu           sync.Mutex
+		patches      int
+		patchedBytes int64
+		dropPatch    int
+	)
+
+	it.Before(func() {
+		regHandler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				mu.Lock()
+				patches++
+				drop := patches == dropPatch
+				if !drop {
+					patchedBytes += r.ContentLength
+				}
+				mu.Unlock()
+
+				if drop {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					h.AssertNil(t, err)
+					h.AssertNil(t, conn.Close())
+					return
+				}
+			}
+			regHandler.ServeHTTP(w, r)
+		}))
+
+		var err error
+		repo, err = name.NewRepository(strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1)+"/some/app", name.WeakValidation)
+		h.AssertNil(t, err)
+
+		contents := make([]byte, 4*chunkSize+100)
+		_, err = rand.Read(contents)
+		h.AssertNil(t, err)
+		layer = static.NewLayer(contents, types.OCILayer)
+
+		checkpointDir, err = os.MkdirTemp("", "upload-checkpoints")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNil(t, os.RemoveAll(checkpointDir))
+	})
+
+	newUploader := func(opts ...image.UploaderOption) *image.BlobUploader {
+		return image.NewBlobUploader(logging.NewLogWithWriters(&outBuf, &outBuf), authn.DefaultKeychain, checkpointDir,
+			append([]image.UploaderOption{image.WithChunkSize(chunkSize)}, opts...)...)
+	}
+
+	assertLayerUploaded := func() {
+		digest, err := layer.Digest()
+		h.AssertNil(t, err)
+		uploaded, err := remote.Layer(repo.Digest(digest.String()))
+		h.AssertNil(t, err)
+		size, err := uploaded.Size()
+		h.AssertNil(t, err)
+		h.AssertEq(t, size, int64(4*chunkSize+100))
+	}
+
+	when("#UploadLayer", func() {
+		it("uploads the layer in chunks", func() {
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, layer))
+
+			assertLayerUploaded()
+			h.AssertEq(t, patches, 5)
+			entries, err := os.ReadDir(checkpointDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+
+		it("does not upload layers already in the registry", func() {
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, layer))
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, layer))
+
+			h.AssertEq(t, patches, 5)
+		})
+
+		it("leaves layers smaller than a chunk to be pushed with the image", func() {
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, static.NewLayer([]byte("small"), types.OCILayer)))
+
+			h.AssertEq(t, patches, 0)
+		})
+
+		it("resumes an interrupted upload from the last chunk received", func() {
+			dropPatch = 3
+
+			err := newUploader(image.WithRetries(0)).UploadLayer(context.TODO(), repo, layer)
+			h.AssertNotNil(t, err)
+			entries, err := os.ReadDir(checkpointDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 1)
+			h.AssertEq(t, patchedBytes, int64(2*chunkSize))
+
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, layer))
+
+			assertLayerUploaded()
+			h.AssertContains(t, outBuf.String(), "at 2048 of 4196 bytes")
+			h.AssertEq(t, patchedBytes, int64(4*chunkSize+100))
+			entries, err = os.ReadDir(checkpointDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+
+		it("restarts the upload when the registry no longer knows about it", func() {
+			dropPatch = 3
+			h.AssertNotNil(t, newUploader(image.WithRetries(0)).UploadLayer(context.TODO(), repo, layer))
+
+			// a new registry has no record of the interrupted upload session
+			server.Config.Handler = registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+
+			h.AssertNil(t, newUploader().UploadLayer(context.TODO(), repo, layer))
+			assertLayerUploaded()
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// DeprecationKind is the kind of what is deprecated
+type DeprecationKind string
+
+const (
+	DeprecatedCommand      DeprecationKind = "command"
+	DeprecatedFlag         DeprecationKind = "flag"
+	DeprecatedBuildpackAPI DeprecationKind = "buildpack-api"
+	DeprecatedPlatformAPI  DeprecationKind = "platform-api"
+	DeprecatedStack        DeprecationKind = "stack"
+	DeprecatedSchema       DeprecationKind = "schema"
+)
+
+var deprecationKindNames = map[DeprecationKind]string{
+	DeprecatedCommand:      "Command",
+	DeprecatedFlag:         "Flag",
+	DeprecatedBuildpackAPI: "Buildpack API",
+	DeprecatedPlatformAPI:  "Platform API",
+	DeprecatedStack:        "Stack",
+	DeprecatedSchema:       "Schema",
+}
+
+// DeprecationFormat is the format deprecations are reported in
+type DeprecationFormat string
+
+const (
+	DeprecationFormatText DeprecationFormat = "text"
+	DeprecationFormatJSON DeprecationFormat = "json"
+)
+
+// Deprecation is a deprecated usage of pack, such as of a deprecated command, flag, API or schema version
+type Deprecation struct {
+	Kind DeprecationKind `json:"kind"`
+
+	// Name of what is deprecated, such as 'pack create-builder' or '--stacks'
+	Name string `json:"name"`
+
+	// Name of what to use instead, if any
+	Replacement string `json:"replacement,omitempty"`
+
+	// Details about the usage, such as the buildpack using a deprecated API
+	Details string `json:"details,omitempty"`
+}
+
+func (d Deprecation) String() string {
+	kindName, ok := deprecationKindNames[d.Kind]
+	if !ok {
+		kindName = string(d.Kind)
+	}
+
+	msg := fmt.Sprintf("%s %s has been deprecated", kindName, style.Symbol(d.Name))
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(", please use %s instead", style.Symbol(d.Replacement))
+	}
+	if d.Details != "" {
+		msg += fmt.Sprintf(" (%s)", d.Details)
+	}
+	return msg
+}
+
+// DeprecationError is returned for deprecated usage when deprecations are strict
+type DeprecationError struct {
+	Deprecation Deprecation
+}
+
+func (e DeprecationError) Error() string {
+	return fmt.Sprintf("%s, and deprecated usage isn't allowed in strict mode", e.Deprecation)
+}
+
+type isDeprecationReporter interface {
+	ReportDeprecation(d Deprecation) error
+}
+
+// ReportDeprecation reports deprecated usage. Loggers reporting deprecations themselves may fail on it, or report it
+// in another format; other loggers log a warning.
+//
+// See isDeprecationReporter
+func ReportDeprecation(logger Logger, d Deprecation) error {
+	if r, ok := logger.(isDeprecationReporter); ok {
+		return r.ReportDeprecation(d)
+	}
+
+	logger.Warn(d.String())
+	return nil
+}
+
+// ReportDeprecation logs a warning for deprecated usage, in the format of the logger, or fails on it when
+// deprecations are strict
+func (lw *LogWithWriters) ReportDeprecation(d Deprecation) error {
+	if lw.strictDeprecations {
+		return DeprecationError{Deprecation: d}
+	}
+
+	if lw.deprecationFormat == DeprecationFormatJSON {
+		out, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(lw.RawWriterForLevel(WarnLevel), string(out))
+		return err
+	}
+
+	lw.Warn(d.String())
+	return nil
+}
+
+// WantStrictDeprecations turns deprecated usage into errors
+func (lw *LogWithWriters) WantStrictDeprecations(f bool) {
+	lw.strictDeprecations = f
+}
+
+// WantDeprecationFormat sets the format deprecations are reported in
+func (lw *LogWithWriters) WantDeprecationFormat(format DeprecationFormat) {
+	lw.deprecationFormat = format
+}
@@ -0,0 +1,88 @@
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDeprecation(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Deprecation", testDeprecation, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDeprecation(t *testing.T, when spec.G, it spec.S) {
+	var deprecation = logging.Deprecation{
+		Kind:        logging.DeprecatedCommand,
+		Name:        "pack create-builder",
+		Replacement: "pack builder create",
+	}
+
+	when("Deprecation", func() {
+		when("#String", func() {
+			it("describes the deprecation and its replacement", func() {
+				h.AssertEq(t, deprecation.String(), "Command 'pack create-builder' has been deprecated, please use 'pack builder create' instead")
+			})
+
+			it("describes the details of the usage", func() {
+				h.AssertEq(t, logging.Deprecation{
+					Kind:    logging.DeprecatedBuildpackAPI,
+					Name:    "0.2",
+					Details: "used by buildpack 'some/bp@1.2.3'",
+				}.String(), "Buildpack API '0.2' has been deprecated (used by buildpack 'some/bp@1.2.3')")
+			})
+		})
+	})
+
+	when("#ReportDeprecation", func() {
+		when("the logger reports deprecations", func() {
+			var (
+				outBuf bytes.Buffer
+				logger *logging.LogWithWriters
+			)
+
+			it.Before(func() {
+				logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+			})
+
+			it("logs a warning", func() {
+				h.AssertNil(t, logging.ReportDeprecation(logger, deprecation))
+				h.AssertEq(t, outBuf.String(), "Warning: Command 'pack create-builder' has been deprecated, please use 'pack builder create' instead\n")
+			})
+
+			it("writes a JSON object for the json format", func() {
+				logger.WantDeprecationFormat(logging.DeprecationFormatJSON)
+				h.AssertNil(t, logging.ReportDeprecation(logger, deprecation))
+				h.AssertEq(t, outBuf.String(), `{"kind":"command","name":"pack create-builder","replacement":"pack builder create"}`+"\n")
+			})
+
+			it("fails when deprecations are strict", func() {
+				logger.WantStrictDeprecations(true)
+				err := logging.ReportDeprecation(logger, deprecation)
+				h.AssertError(t, err, "Command 'pack create-builder' has been deprecated, please use 'pack builder create' instead, and deprecated usage isn't allowed in strict mode")
+
+				var deprecationErr logging.DeprecationError
+				h.AssertTrue(t, errors.As(err, &deprecationErr))
+				h.AssertEq(t, deprecationErr.Deprecation, deprecation)
+				h.AssertEq(t, outBuf.String(), "")
+			})
+		})
+
+		when("the logger doesn't report deprecations", func() {
+			it("logs a warning", func() {
+				var w bytes.Buffer
+				logger := logging.NewSimpleLogger(&w)
+				h.AssertNil(t, logging.ReportDeprecation(logger, deprecation))
+				h.AssertContains(t, w.String(), "Command 'pack create-builder' has been deprecated, please use 'pack builder create' instead")
+			})
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a line of the logs, as given to an Exporter
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+}
+
+// Exporter ships the logs to a remote endpoint, such as for the builds run on developer machines to be audited
+// centrally. Exporters must be safe for concurrent use, as the lifecycle phases of a build may log at once.
+type Exporter interface {
+	// Export queues the record to be shipped.
+	Export(record Record)
+
+	// Close ships the records not shipped yet, and releases the resources of the exporter.
+	Close() error
+}
+
+// exportedLines exports the lines written at a level, keeping the line not completed yet between writes, as the
+// writers of a level are created on each call but share the lines
+type exportedLines struct {
+	sync.Mutex
+	exporter Exporter
+	level    Level
+	clock    func() time.Time
+	partial  []byte
+}
+
+func newExportedLines(exporter Exporter, level Level, clock func() time.Time) *exportedLines {
+	return &exportedLines{
+		exporter: exporter,
+		level:    level,
+		clock:    clock,
+	}
+}
+
+// write exports the lines the buffer completes
+func (l *exportedLines) write(buf []byte) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.partial = append(l.partial, buf...)
+	for {
+		i := bytes.IndexByte(l.partial, lineFeed)
+		if i < 0 {
+			return
+		}
+		l.export(string(l.partial[:i]))
+		l.partial = l.partial[i+1:]
+	}
+}
+
+func (l *exportedLines) export(line string) {
+	line = strings.TrimRight(string(stripColor([]byte(line))), "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	l.exporter.Export(Record{Time: l.clock(), Level: l.level, Message: line})
+}
+
+// exportingWriter exports the lines written to it, in addition to writing them to out
+type exportingWriter struct {
+	out   io.Writer
+	lines *exportedLines
+}
+
+// Write writes the buffer to the underlying writer, exporting the lines it completes
+func (w *exportingWriter) Write(buf []byte) (int, error) {
+	w.lines.write(buf)
+	return w.out.Write(buf)
+}
+
+// Fd returns the file descriptor of the underlying writer, for the writer to be detected as a terminal when it is one
+func (w *exportingWriter) Fd() uintptr {
+	if file, ok := w.out.(hasDescriptor); ok {
+		return file.Fd()
+	}
+
+	return InvalidFileDescriptor
+}
@@ -40,6 +40,11 @@ type LogWithWriters struct {
 	clock    func() time.Time
 	out      io.Writer
 	errOut   io.Writer
+	exporter Exporter
+	exported map[Level]*exportedLines
+
+	strictDeprecations bool
+	deprecationFormat  DeprecationFormat
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -81,14 +86,22 @@ func (lw *LogWithWriters) HandleLog(e *log.Entry) error {
 	lw.Lock()
 	defer lw.Unlock()
 
-	writer := lw.WriterForLevel(Level(e.Level))
+	writer := lw.writerForLevel(Level(e.Level))
 	_, err := fmt.Fprint(writer, appendMissingLineFeed(fmt.Sprintf("%s%s", formatLevel(e.Level), e.Message)))
 
+	if lw.exporter != nil && writer != io.Discard {
+		lw.exporter.Export(Record{Time: lw.clock(), Level: Level(e.Level), Message: string(stripColor([]byte(e.Message)))})
+	}
+
 	return err
 }
 
 // WriterForLevel returns a Writer for the given Level
 func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
+	return lw.exportingWriter(lw.writerForLevel(level), level)
+}
+
+func (lw *LogWithWriters) writerForLevel(level Level) io.Writer {
 	if lw.Level > log.Level(level) {
 		return io.Discard
 	}
@@ -100,9 +113,57 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 	return newLogWriter(lw.out, lw.clock, lw.wantTime)
 }
 
+// RawWriterForLevel returns a Writer for the given Level that writes as it is, without timestamps or color removal
+func (lw *LogWithWriters) RawWriterForLevel(level Level) io.Writer {
+	if lw.Level > log.Level(level) {
+		return io.Discard
+	}
+
+	if level == ErrorLevel {
+		return lw.exportingWriter(lw.errOut, level)
+	}
+
+	return lw.exportingWriter(lw.out, level)
+}
+
 // Writer returns the base Writer for the LogWithWriters
 func (lw *LogWithWriters) Writer() io.Writer {
-	return lw.out
+	return lw.exportingWriter(lw.out, InfoLevel)
+}
+
+// exportingWriter wraps the writer for the lines written to it to be exported too, when an exporter is set
+func (lw *LogWithWriters) exportingWriter(writer io.Writer, level Level) io.Writer {
+	if lw.exporter == nil || writer == io.Discard {
+		return writer
+	}
+
+	return &exportingWriter{out: writer, lines: lw.exported[level]}
+}
+
+// WantExport ships the logs to a remote endpoint with the exporter, in addition to writing them
+func (lw *LogWithWriters) WantExport(exporter Exporter) {
+	lw.Lock()
+	defer lw.Unlock()
+
+	lw.exporter = exporter
+	lw.exported = map[Level]*exportedLines{}
+	for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		lw.exported[level] = newExportedLines(exporter, level, lw.clock)
+	}
+}
+
+// CloseExporter ships the logs the exporter hasn't shipped yet, and stops exporting the logs. It does nothing if no
+// exporter is set.
+func (lw *LogWithWriters) CloseExporter() error {
+	lw.Lock()
+	exporter := lw.exporter
+	lw.exporter = nil
+	lw.Unlock()
+
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Close()
 }
 
 // WantTime turns timestamps on in log entries
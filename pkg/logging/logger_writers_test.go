@@ -119,6 +119,19 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("RawWriterForLevel", func() {
+		it("writes without time", func() {
+			logger.WantTime(true)
+			writer := logger.RawWriterForLevel(logging.InfoLevel)
+			writer.Write([]byte(color.HiBlueString("test") + "\n"))
+			h.AssertEq(t, fOut(), color.HiBlueString("test")+"\n")
+		})
+
+		it("discards levels that aren't logged", func() {
+			h.AssertSameInstance(t, logger.RawWriterForLevel(logging.DebugLevel), io.Discard)
+		})
+	})
+
 	when("colors are disabled", func() {
 		it("don't display colors", func() {
 			outCons.DisableColors(true)
@@ -210,6 +223,47 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("an exporter is set", func() {
+		var exporter *fakeExporter
+
+		it.Before(func() {
+			exporter = &fakeExporter{}
+			logger.WantExport(exporter)
+		})
+
+		it("exports the messages logged, without color", func() {
+			logger.Info(color.HiBlueString("info"))
+			logger.Debug("debug")
+			logger.Warn("warn")
+			logger.Error("error")
+
+			h.AssertEq(t, exporter.messages(), []string{"INFO info", "WARN warn", "ERROR error"})
+			h.AssertContains(t, fOut(), "info")
+			h.AssertContains(t, fErr(), "error")
+		})
+
+		it("exports each line written to the writers", func() {
+			_, err := io.WriteString(logger.Writer(), "first line\nsecond ")
+			h.AssertNil(t, err)
+			_, err = io.WriteString(logger.Writer(), "line\n")
+			h.AssertNil(t, err)
+			_, err = io.WriteString(logging.GetWriterForLevel(logger, logging.ErrorLevel), "failed\n\n")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, exporter.messages(), []string{"INFO first line", "INFO second line", "ERROR failed"})
+			h.AssertEq(t, fOut(), "first line\nsecond line\n")
+		})
+
+		it("closes the exporter once", func() {
+			h.AssertNil(t, logger.CloseExporter())
+			h.AssertNil(t, logger.CloseExporter())
+			h.AssertEq(t, exporter.closed, 1)
+
+			logger.Info("after close")
+			h.AssertEq(t, len(exporter.messages()), 0)
+		})
+	})
+
 	it("will convert an empty string to a line feed", func() {
 		logger.Info("")
 		expected := "\n"
@@ -226,3 +280,26 @@ func assertLogWriterHasOut(t *testing.T, writer io.Writer, out io.Writer) {
 type hasWriter interface {
 	Writer() io.Writer
 }
+
+type fakeExporter struct {
+	records []logging.Record
+	closed  int
+}
+
+func (e *fakeExporter) Export(record logging.Record) {
+	e.records = append(e.records, record)
+}
+
+func (e *fakeExporter) Close() error {
+	e.closed++
+	return nil
+}
+
+func (e *fakeExporter) messages() []string {
+	var messages []string
+	for _, record := range e.records {
+		level := map[logging.Level]string{logging.DebugLevel: "DEBUG", logging.InfoLevel: "INFO", logging.WarnLevel: "WARN", logging.ErrorLevel: "ERROR"}[record.Level]
+		messages = append(messages, level+" "+record.Message)
+	}
+	return messages
+}
@@ -50,6 +50,22 @@ func GetWriterForLevel(logger Logger, level Level) io.Writer {
 	return logger.Writer()
 }
 
+type isRawSelectableWriter interface {
+	RawWriterForLevel(level Level) io.Writer
+}
+
+// GetRawWriterForLevel retrieves the Writer for the log level provided that writes as it is, without the timestamps or
+// color removal of the writer GetWriterForLevel retrieves.
+//
+// See isRawSelectableWriter
+func GetRawWriterForLevel(logger Logger, level Level) io.Writer {
+	if w, ok := logger.(isRawSelectableWriter); ok {
+		return w.RawWriterForLevel(level)
+	}
+
+	return GetWriterForLevel(logger, level)
+}
+
 // IsQuiet defines whether a pack logger is set to quiet mode
 func IsQuiet(logger Logger) bool {
 	if writer := GetWriterForLevel(logger, InfoLevel); writer == io.Discard {
@@ -5,16 +5,22 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"regexp"
 
 	"github.com/buildpacks/pack/internal/style"
 )
 
+// moduleRunMatcher matches the lines the lifecycle logs when it runs a buildpack or extension, at debug level
+var moduleRunMatcher = regexp.MustCompile(`^Running (?:build|generate) for (?:buildpack|extension) (\S+)`)
+
 // PrefixWriter is a buffering writer that prefixes each new line. Close should be called to properly flush the buffer.
 type PrefixWriter struct {
 	out           io.Writer
 	buf           *bytes.Buffer
+	name          string
 	prefix        string
 	readerFactory func(data []byte) io.Reader
+	trackModules  bool
 }
 
 type PrefixWriterOption func(c *PrefixWriter)
@@ -25,10 +31,18 @@ func WithReaderFactory(factory func(data []byte) io.Reader) PrefixWriterOption {
 	}
 }
 
+// WithModuleNames adds the buildpack or extension the lifecycle reports running to the prefix of the lines that follow
+func WithModuleNames() PrefixWriterOption {
+	return func(writer *PrefixWriter) {
+		writer.trackModules = true
+	}
+}
+
 // NewPrefixWriter writes by w will be prefixed
 func NewPrefixWriter(w io.Writer, prefix string, opts ...PrefixWriterOption) *PrefixWriter {
 	writer := &PrefixWriter{
 		out:    w,
+		name:   prefix,
 		prefix: fmt.Sprintf("[%s] ", style.Prefix(prefix)),
 		buf:    &bytes.Buffer{},
 		readerFactory: func(data []byte) io.Reader {
@@ -92,6 +106,12 @@ func (w *PrefixWriter) flush() error {
 		bits = bits[i+1:]
 	}
 
+	if w.trackModules {
+		if match := moduleRunMatcher.FindSubmatch(stripColor(bits)); match != nil {
+			w.prefix = fmt.Sprintf("[%s] ", style.Prefix(w.name+":"+string(match[1])))
+		}
+	}
+
 	_, err := fmt.Fprint(w.out, w.prefix+string(bits)+"\n")
 	return err
 }
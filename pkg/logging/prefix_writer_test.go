@@ -124,6 +124,20 @@ func testPrefixWriter(t *testing.T, when spec.G, it spec.S) {
 
 			h.AssertEq(t, buf.String(), "[prefix] completed!      \n[prefix] all done!\n[prefix] \n")
 		})
+
+		when("WithModuleNames", func() {
+			it("adds the buildpack the lifecycle runs to the prefix", func() {
+				var buf bytes.Buffer
+
+				writer := logging.NewPrefixWriter(&buf, "builder", logging.WithModuleNames())
+				_, err := writer.Write([]byte("Starting build\nRunning build for buildpack some/bp@1.0.0\nInstalling\n"))
+				assert.Nil(err)
+				err = writer.Close()
+				assert.Nil(err)
+
+				h.AssertEq(t, buf.String(), "[builder] Starting build\n[builder:some/bp@1.0.0] Running build for buildpack some/bp@1.0.0\n[builder:some/bp@1.0.0] Installing\n")
+			})
+		})
 	})
 }
 
@@ -0,0 +1,61 @@
+// Package metrics defines the interface that collectors of the metrics of the operations run by client must support,
+// for platforms embedding pack to feed them to Prometheus, OpenTelemetry or any other system.
+package metrics
+
+import (
+	"time"
+)
+
+// Operations whose metrics are collected, with their labels
+const (
+	// ImageFetch is the fetch of an image from the daemon or a registry, labeled with `daemon` and `pull_policy`.
+	ImageFetch = "image_fetch"
+
+	// LifecyclePhase is the run of a lifecycle phase in a build, labeled with `phase`.
+	LifecyclePhase = "lifecycle_phase"
+
+	// ImagePush is the push of an image to a registry, labeled with the `kind` of image: builder, buildpack,
+	// extension, app or index.
+	ImagePush = "image_push"
+)
+
+// ResultLabel is added to the labels of every operation, with the value ResultSuccess or ResultError.
+const (
+	ResultLabel   = "result"
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Collector collects the metrics of the operations run by client. Each operation is counted, and timed, under its name.
+// Collectors must be safe for concurrent use, as operations may run at once.
+type Collector interface {
+	// IncCounter increments the counter of the operation with the labels by one.
+	IncCounter(name string, labels map[string]string)
+
+	// ObserveDuration records how long a run of the operation with the labels took.
+	ObserveDuration(name string, duration time.Duration, labels map[string]string)
+}
+
+// Observe runs the operation, counting and timing it with the collector, with the result of the run added to the
+// labels. The collector may be nil, for the operation to be run only.
+func Observe(collector Collector, name string, labels map[string]string, operation func() error) error {
+	if collector == nil {
+		return operation()
+	}
+
+	start := time.Now()
+	err := operation()
+	duration := time.Since(start)
+
+	resultLabels := map[string]string{}
+	for k, v := range labels {
+		resultLabels[k] = v
+	}
+	resultLabels[ResultLabel] = ResultSuccess
+	if err != nil {
+		resultLabels[ResultLabel] = ResultError
+	}
+	collector.IncCounter(name, resultLabels)
+	collector.ObserveDuration(name, duration, resultLabels)
+	return err
+}
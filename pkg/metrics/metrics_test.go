@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/metrics"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMetrics(t *testing.T) {
+	spec.Run(t, "Metrics", testMetrics, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type fakeCollector struct {
+	counters  map[string][]map[string]string
+	durations map[string][]time.Duration
+}
+
+func (c *fakeCollector) IncCounter(name string, labels map[string]string) {
+	c.counters[name] = append(c.counters[name], labels)
+}
+
+func (c *fakeCollector) ObserveDuration(name string, duration time.Duration, labels map[string]string) {
+	c.durations[name] = append(c.durations[name], duration)
+}
+
+func testMetrics(t *testing.T, when spec.G, it spec.S) {
+	var collector *fakeCollector
+
+	it.Before(func() {
+		collector = &fakeCollector{counters: map[string][]map[string]string{}, durations: map[string][]time.Duration{}}
+	})
+
+	when("#Observe", func() {
+		it("counts and times the operation", func() {
+			err := metrics.Observe(collector, metrics.LifecyclePhase, map[string]string{"phase": "builder"}, func() error {
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, collector.counters[metrics.LifecyclePhase], []map[string]string{{"phase": "builder", "result": "success"}})
+			h.AssertEq(t, len(collector.durations[metrics.LifecyclePhase]), 1)
+			h.AssertTrue(t, collector.durations[metrics.LifecyclePhase][0] >= time.Millisecond)
+		})
+
+		it("labels failed operations, and returns their error", func() {
+			err := metrics.Observe(collector, metrics.ImagePush, map[string]string{"kind": "builder"}, func() error {
+				return errors.New("some-push-error")
+			})
+			h.AssertError(t, err, "some-push-error")
+
+			h.AssertEq(t, collector.counters[metrics.ImagePush], []map[string]string{{"kind": "builder", "result": "error"}})
+		})
+
+		it("only runs the operation without a collector", func() {
+			ran := false
+			h.AssertNil(t, metrics.Observe(nil, metrics.ImageFetch, nil, func() error {
+				ran = true
+				return nil
+			}))
+			h.AssertTrue(t, ran)
+		})
+	})
+}
@@ -0,0 +1,34 @@
+// Package plugin helps writing plugins of pack: executables named pack-<name> on the PATH, which pack runs for
+// `pack <name>` with the arguments following the name, such as a pack-deploy executable run by `pack deploy`.
+package plugin
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/cmd"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// Config is the config of pack
+type Config = config.Config
+
+// ReadConfig reads the config of pack from the config file in the pack home, set by PACK_HOME
+func ReadConfig() (Config, error) {
+	path, err := config.DefaultConfigPath()
+	if err != nil {
+		return Config{}, errors.Wrap(err, "getting config path")
+	}
+	return config.Read(path)
+}
+
+// NewClient returns a pack client configured by the config like the one of pack, using the Docker context and the
+// Docker host over SSH pack uses. Options are applied after the ones of pack.
+func NewClient(logger logging.Logger, cfg Config, opts ...client.Option) (*client.Client, error) {
+	packOpts, err := cmd.ClientOptions(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewClient(append(packOpts, opts...)...)
+}
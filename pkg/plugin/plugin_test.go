@@ -0,0 +1,53 @@
+package plugin_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/plugin"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPlugin(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Plugin", testPlugin, spec.Report(report.Terminal{}))
+}
+
+func testPlugin(t *testing.T, when spec.G, it spec.S) {
+	var packHome string
+
+	it.Before(func() {
+		packHome = t.TempDir()
+		t.Setenv("PACK_HOME", packHome)
+	})
+
+	when("#ReadConfig", func() {
+		it("reads the config of pack", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(packHome, "config.toml"), []byte("default-builder-image = \"some/builder\"\n"), 0600))
+
+			cfg, err := plugin.ReadConfig()
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.DefaultBuilder, "some/builder")
+		})
+
+		it("returns an empty config without a config file", func() {
+			cfg, err := plugin.ReadConfig()
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg, plugin.Config{})
+		})
+	})
+	when("#NewClient", func() {
+		it("configures the client like pack does", func() {
+			_, err := plugin.NewClient(logging.NewSimpleLogger(io.Discard), plugin.Config{BuildpackAPIShims: "some-policy"})
+			h.AssertError(t, err, "invalid buildpack API shim policy 'some-policy'")
+		})
+	})
+}
@@ -3,6 +3,7 @@ package project
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -55,6 +56,17 @@ func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Desc
 		return types.Descriptor{}, fmt.Errorf("unknown project descriptor schema version %s", version)
 	}
 
+	if version == "0.1" {
+		if err := logging.ReportDeprecation(logger, logging.Deprecation{
+			Kind:        logging.DeprecatedSchema,
+			Name:        "project.toml 0.1",
+			Replacement: "project.toml 0.2",
+			Details:     "set schema-version = \"0.2\" in the [_] table",
+		}); err != nil {
+			return types.Descriptor{}, err
+		}
+	}
+
 	descriptor, tomlMetaData, err := parsers[version](string(projectTomlContents))
 	if err != nil {
 		return types.Descriptor{}, err
@@ -96,26 +108,62 @@ func unsupportedKey(keyName, schemaVersion string) bool {
 }
 
 func validate(p types.Descriptor) error {
+	if errs := validationErrors(p); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// validationErrors returns every violation of the rules a descriptor must follow
+func validationErrors(p types.Descriptor) []error {
+	var errs []error
 	if p.Build.Exclude != nil && p.Build.Include != nil {
-		return errors.New("project.toml: cannot have both include and exclude defined")
+		errs = append(errs, errors.New("project.toml: cannot have both include and exclude defined"))
 	}
 
-	if len(p.Project.Licenses) > 0 {
-		for _, license := range p.Project.Licenses {
-			if license.Type == "" && license.URI == "" {
-				return errors.New("project.toml: must have a type or uri defined for each license")
-			}
+	for _, license := range p.Project.Licenses {
+		if license.Type == "" && license.URI == "" {
+			errs = append(errs, errors.New("project.toml: must have a type or uri defined for each license"))
 		}
 	}
 
 	for _, bp := range p.Build.Buildpacks {
 		if bp.ID == "" && bp.URI == "" {
-			return errors.New("project.toml: buildpacks must have an id or url defined")
+			errs = append(errs, errors.New("project.toml: buildpacks must have an id or url defined"))
 		}
 		if bp.URI != "" && bp.Version != "" {
-			return errors.New("project.toml: buildpacks cannot have both uri and version defined")
+			errs = append(errs, errors.New("project.toml: buildpacks cannot have both uri and version defined"))
 		}
 	}
 
-	return nil
+	bindings := map[string]bool{}
+	for _, binding := range p.Build.Bindings {
+		if binding.Name == "" || binding.Path == "" {
+			errs = append(errs, errors.New("project.toml: bindings must have a name and path defined"))
+			continue
+		}
+		if strings.ContainsAny(binding.Name, `/\`) {
+			errs = append(errs, errors.Errorf("project.toml: binding name %s must not contain path separators", binding.Name))
+		}
+		if bindings[binding.Name] {
+			errs = append(errs, errors.Errorf("project.toml: binding %s is defined more than once", binding.Name))
+		}
+		bindings[binding.Name] = true
+	}
+
+	for _, slice := range p.Build.Slices {
+		if len(slice.Paths) == 0 {
+			errs = append(errs, errors.New("project.toml: slices must have paths defined"))
+		}
+		for _, slicePath := range slice.Paths {
+			cleaned := path.Clean(filepath.ToSlash(slicePath))
+			if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+				errs = append(errs, errors.Errorf("project.toml: slice path %s must be relative to the app directory, and within it", slicePath))
+			} else if _, err := path.Match(slicePath, ""); err != nil {
+				errs = append(errs, errors.Errorf("project.toml: slice path %s is not a valid pattern", slicePath))
+			}
+		}
+	}
+
+	return errs
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -175,6 +176,128 @@ value = "-Xmx300m"
 					expected, projectDescriptor.Build.Env[0].Value)
 			}
 		})
+		it("should parse the bindings of a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[[io.buildpacks.bindings]]
+name = "db"
+path = "./bindings/db"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.AssertEq(t, projectDescriptor.Build.Bindings, []types.Binding{{Name: "db", Path: "./bindings/db"}})
+			h.AssertNotContains(t, readStdout(), "io.buildpacks.bindings")
+		})
+
+		it("should parse the run image of a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[io.buildpacks.run-image]
+image = "some/run"
+mirrors = ["registry1.example.com/some/run"]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.AssertEq(t, projectDescriptor.Build.RunImage, types.RunImage{Image: "some/run", Mirrors: []string{"registry1.example.com/some/run"}})
+			h.AssertNotContains(t, readStdout(), "io.buildpacks.run-image")
+		})
+
+		it("should parse the slices of a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[[io.buildpacks.slices]]
+paths = ["static/*.css", "vendor"]
+[[io.buildpacks.slices]]
+paths = ["docs"]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.AssertEq(t, projectDescriptor.Build.Slices, []types.Slice{{Paths: []string{"static/*.css", "vendor"}}, {Paths: []string{"docs"}}})
+			h.AssertNotContains(t, readStdout(), "io.buildpacks.slices")
+		})
+
+		it("should not allow slice paths outside of the app directory", func() {
+			projectToml := `
+[_]
+schema-version="0.2"
+[[io.buildpacks.slices]]
+paths = ["../other-app"]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "project.toml: slice path ../other-app must be relative to the app directory, and within it")
+		})
+
+		it("should not allow slices without paths", func() {
+			projectToml := `
+[_]
+schema-version="0.2"
+[[io.buildpacks.slices]]
+paths = []
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "project.toml: slices must have paths defined")
+		})
+
+		it("should not allow a binding to be defined more than once", func() {
+			projectToml := `
+[_]
+schema-version="0.2"
+[[io.buildpacks.bindings]]
+name = "db"
+path = "./bindings/db"
+[[io.buildpacks.bindings]]
+name = "db"
+path = "./bindings/other-db"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "project.toml: binding db is defined more than once")
+		})
+
 		it("should parse a valid v0.1 project.toml file", func() {
 			projectToml := `
 [project]
@@ -401,6 +524,30 @@ name = "licenses should have either a type or uri defined"
 			h.AssertContains(t, readStdout(), "Warning: No schema version declared in project.toml, defaulting to schema version 0.1\n")
 		})
 
+		it("should report schema version 0.1 as deprecated", func() {
+			tmpProjectToml, err := createTmpProjectTomlFile(`
+[_]
+schema-version = "0.1"
+`)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertNil(t, err)
+			h.AssertContains(t, readStdout(), "Warning: Schema 'project.toml 0.1' has been deprecated, please use 'project.toml 0.2' instead")
+		})
+
+		it("should fail for schema version 0.1 when deprecations are strict", func() {
+			tmpProjectToml, err := createTmpProjectTomlFile(`
+[_]
+schema-version = "0.1"
+`)
+			h.AssertNil(t, err)
+
+			logger.WantStrictDeprecations(true)
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "Schema 'project.toml 0.1' has been deprecated")
+		})
+
 		it("should warn when unsupported keys, on tables the project owns, are declared with schema v0.1", func() {
 			projectToml := `
 # try to use some schema 0.2 configuration with 0.1 version - warning message expected
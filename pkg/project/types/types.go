@@ -22,12 +22,34 @@ type EnvVar struct {
 	Value string `toml:"value"`
 }
 
+// Binding is a service binding given to the buildpacks, read from a directory with the type and secrets of the
+// binding in files, as described by the Service Binding specification for Kubernetes
+type Binding struct {
+	Name string `toml:"name"`
+	Path string `toml:"path"`
+}
+
+// RunImage is the run image the app is built on, unless a run image is provided to the build, along with mirrors of it
+type RunImage struct {
+	Image   string   `toml:"image"`
+	Mirrors []string `toml:"mirrors"`
+}
+
+// Slice is a group of paths of the app, relative to the app directory, whose files are exported as a layer of their
+// own, such that rebuilds changing other files of the app reuse it
+type Slice struct {
+	Paths []string `toml:"paths"`
+}
+
 type Build struct {
 	Include    []string    `toml:"include"`
 	Exclude    []string    `toml:"exclude"`
 	Buildpacks []Buildpack `toml:"buildpacks"`
 	Env        []EnvVar    `toml:"env"`
+	Bindings   []Binding   `toml:"bindings"`
 	Builder    string      `toml:"builder"`
+	RunImage   RunImage    `toml:"run-image"`
+	Slices     []Slice     `toml:"slices"`
 	Pre        GroupAddition
 	Post       GroupAddition
 }
@@ -8,14 +8,17 @@ import (
 )
 
 type Buildpacks struct {
-	Include []string            `toml:"include"`
-	Exclude []string            `toml:"exclude"`
-	Group   []types.Buildpack   `toml:"group"`
-	Env     Env                 `toml:"env"`
-	Build   Build               `toml:"build"`
-	Builder string              `toml:"builder"`
-	Pre     types.GroupAddition `toml:"pre"`
-	Post    types.GroupAddition `toml:"post"`
+	Include  []string            `toml:"include"`
+	Exclude  []string            `toml:"exclude"`
+	Group    []types.Buildpack   `toml:"group"`
+	Env      Env                 `toml:"env"`
+	Build    Build               `toml:"build"`
+	Bindings []types.Binding     `toml:"bindings"`
+	Builder  string              `toml:"builder"`
+	RunImage types.RunImage      `toml:"run-image"`
+	Slices   []types.Slice       `toml:"slices"`
+	Pre      types.GroupAddition `toml:"pre"`
+	Post     types.GroupAddition `toml:"post"`
 }
 
 type Build struct {
@@ -66,7 +69,10 @@ func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData,
 			Exclude:    versionedDescriptor.IO.Buildpacks.Exclude,
 			Buildpacks: versionedDescriptor.IO.Buildpacks.Group,
 			Env:        env,
+			Bindings:   versionedDescriptor.IO.Buildpacks.Bindings,
 			Builder:    versionedDescriptor.IO.Buildpacks.Builder,
+			RunImage:   versionedDescriptor.IO.Buildpacks.RunImage,
+			Slices:     versionedDescriptor.IO.Buildpacks.Slices,
 			Pre:        versionedDescriptor.IO.Buildpacks.Pre,
 			Post:       versionedDescriptor.IO.Buildpacks.Post,
 		},
@@ -0,0 +1,210 @@
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/project/types"
+)
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidationResult is the problems found in a project descriptor
+type ValidationResult struct {
+	// Problems a build would fail for, or that make a build ignore part of the descriptor.
+	Errors []string
+
+	// Problems a build tolerates, such as deprecated schema versions.
+	Warnings []string
+}
+
+// Valid returns whether no errors were found
+func (r ValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *ValidationResult) errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *ValidationResult) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// ValidateProjectDescriptor checks a project descriptor the way a build reads it, and further checks the environment
+// variable names, buildpack references and include and exclude patterns it declares, such as to catch mistakes before
+// build time. Unlike ReadProjectDescriptor, it reports every problem found rather than the first one.
+func ValidateProjectDescriptor(pathToFile string) (ValidationResult, error) {
+	var result ValidationResult
+
+	projectTomlContents, err := os.ReadFile(filepath.Clean(pathToFile))
+	if err != nil {
+		return result, err
+	}
+
+	var versionDescriptor VersionDescriptor
+	if _, err := toml.Decode(string(projectTomlContents), &versionDescriptor); err != nil {
+		result.errorf("invalid TOML: %s", err)
+		return result, nil
+	}
+
+	version := versionDescriptor.Project.Version
+	switch version {
+	case "":
+		result.warnf("no schema version declared, so schema version 0.1 is assumed: set schema-version = \"0.2\" in the [_] table")
+		version = "0.1"
+	case "0.1":
+		result.warnf("schema version 0.1 is deprecated: set schema-version = \"0.2\" in the [_] table")
+	}
+	if _, ok := parsers[version]; !ok {
+		result.errorf("unknown schema version %s, must be one of 0.1 or 0.2", style.Symbol(version))
+		return result, nil
+	}
+
+	descriptor, tomlMetaData, err := parsers[version](string(projectTomlContents))
+	if err != nil {
+		result.errorf("invalid project descriptor: %s", err)
+		return result, nil
+	}
+
+	for _, undecodedKey := range tomlMetaData.Undecoded() {
+		if keyName := undecodedKey.String(); unsupportedKey(keyName, version) {
+			result.errorf("key %s is not supported in schema version %s, and is ignored", style.Symbol(keyName), version)
+		}
+	}
+
+	for _, err := range validationErrors(descriptor) {
+		result.Errors = append(result.Errors, strings.TrimPrefix(err.Error(), "project.toml: "))
+	}
+
+	for _, env := range descriptor.Build.Env {
+		if !envVarNamePattern.MatchString(env.Name) {
+			result.errorf("environment variable name %s is invalid: it must consist of letters, digits and underscores, and must not start with a digit", style.Symbol(env.Name))
+		}
+	}
+
+	projectDir := filepath.Dir(pathToFile)
+	validateBuildpackReferences(&result, projectDir, descriptor)
+	if err := validatePatterns(&result, projectDir, descriptor.Build); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// validateBuildpackReferences checks the buildpack URIs resolve the way they would at build time, relative to the
+// project directory
+func validateBuildpackReferences(result *ValidationResult, projectDir string, descriptor types.Descriptor) {
+	groups := []struct {
+		name       string
+		buildpacks []types.Buildpack
+	}{
+		{name: "group", buildpacks: descriptor.Build.Buildpacks},
+		{name: "pre.group", buildpacks: descriptor.Build.Pre.Buildpacks},
+		{name: "post.group", buildpacks: descriptor.Build.Post.Buildpacks},
+	}
+
+	for _, group := range groups {
+		for _, bp := range group.buildpacks {
+			if bp.URI == "" {
+				continue
+			}
+
+			// Buildpacks of the builder can only be resolved against a builder
+			if strings.HasPrefix(bp.URI, "urn:cnb:builder:") || strings.HasPrefix(bp.URI, "from=builder") {
+				continue
+			}
+
+			if paths.IsURI(bp.URI) && strings.HasPrefix(bp.URI, "file://") {
+				localPath, err := paths.URIToFilePath(bp.URI)
+				if err == nil {
+					if _, err := os.Stat(localPath); err != nil {
+						result.errorf("buildpack %s of %s does not exist", style.Symbol(bp.URI), group.name)
+					}
+					continue
+				}
+			}
+
+			if strings.HasPrefix(bp.URI, ".") || filepath.IsAbs(bp.URI) {
+				path := bp.URI
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(projectDir, path)
+				}
+				if _, err := os.Stat(path); err != nil {
+					result.errorf("buildpack %s of %s does not exist, relative paths are resolved from %s", style.Symbol(bp.URI), group.name, style.Symbol(projectDir))
+				}
+				continue
+			}
+
+			locatorType, err := buildpack.GetLocatorType(bp.URI, projectDir, nil)
+			if err != nil || locatorType == buildpack.InvalidLocator {
+				result.errorf("buildpack %s of %s is neither a path, a URL, a buildpack registry ID nor an image reference", style.Symbol(bp.URI), group.name)
+			}
+		}
+	}
+}
+
+// validatePatterns warns about include and exclude patterns matching no file of the project directory, which are
+// usually mistakes
+func validatePatterns(result *ValidationResult, projectDir string, build types.Build) error {
+	patterns := map[string]string{}
+	for _, pattern := range build.Include {
+		patterns[pattern] = "include"
+	}
+	for _, pattern := range build.Exclude {
+		patterns[pattern] = "exclude"
+	}
+
+	unmatched := map[string]*ignore.GitIgnore{}
+	for pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			result.errorf("%s pattern %s is empty", patterns[pattern], style.Symbol(pattern))
+			continue
+		}
+		unmatched[pattern] = ignore.CompileIgnoreLines(pattern)
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(projectDir, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+		for pattern, matcher := range unmatched {
+			if matcher.MatchesPath(filepath.ToSlash(relPath)) {
+				delete(unmatched, pattern)
+			}
+		}
+		if len(unmatched) == 0 {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "matching patterns against %s", style.Symbol(projectDir))
+	}
+
+	for _, pattern := range append(build.Include, build.Exclude...) {
+		if _, ok := unmatched[pattern]; ok {
+			result.warnf("%s pattern %s matches no file of %s", patterns[pattern], style.Symbol(pattern), style.Symbol(projectDir))
+			delete(unmatched, pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestValidateProjectDescriptor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ValidateProjectDescriptor", testValidateProjectDescriptor, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testValidateProjectDescriptor(t *testing.T, when spec.G, it spec.S) {
+	var projectDir string
+
+	it.Before(func() {
+		projectDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(projectDir, "buildpacks", "my-bp"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(projectDir, "app.jar"), []byte("jar"), 0600))
+	})
+
+	validate := func(contents string) ValidationResult {
+		path := filepath.Join(projectDir, "project.toml")
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+		result, err := ValidateProjectDescriptor(path)
+		h.AssertNil(t, err)
+		return result
+	}
+
+	when("#ValidateProjectDescriptor", func() {
+		it("passes a valid descriptor", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+exclude = ["*.jar"]
+
+[[io.buildpacks.group]]
+uri = "./buildpacks/my-bp"
+
+[[io.buildpacks.group]]
+uri = "docker://cnbs/sample-package:hello-universe"
+
+[[io.buildpacks.group]]
+id = "example/lua"
+version = "1.0"
+
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx1g"
+`)
+			h.AssertEq(t, result.Valid(), true)
+			h.AssertEq(t, len(result.Warnings), 0)
+		})
+
+		it("reports every problem", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+include = ["*.war"]
+exclude = ["*.jar"]
+buidler = "some/builder"
+
+[[io.buildpacks.group]]
+uri = "./buildpacks/missing-bp"
+
+[[io.buildpacks.group]]
+uri = "Not A Reference"
+
+[[io.buildpacks.group]]
+uri = "./buildpacks/my-bp"
+version = "1.0"
+
+[[io.buildpacks.build.env]]
+name = "1JAVA-OPTS"
+value = "-Xmx1g"
+`)
+			h.AssertEq(t, result.Valid(), false)
+			h.AssertEq(t, result.Errors, []string{
+				"key 'io.buildpacks.buidler' is not supported in schema version 0.2, and is ignored",
+				"cannot have both include and exclude defined",
+				"buildpacks cannot have both uri and version defined",
+				"environment variable name '1JAVA-OPTS' is invalid: it must consist of letters, digits and underscores, and must not start with a digit",
+				"buildpack './buildpacks/missing-bp' of group does not exist, relative paths are resolved from '" + projectDir + "'",
+				"buildpack 'Not A Reference' of group is neither a path, a URL, a buildpack registry ID nor an image reference",
+			})
+			h.AssertEq(t, result.Warnings, []string{"include pattern '*.war' matches no file of '" + projectDir + "'"})
+		})
+
+		it("warns about the deprecated schema version", func() {
+			result := validate(`
+[project]
+name = "my-app"
+`)
+			h.AssertEq(t, result.Valid(), true)
+			h.AssertContains(t, result.Warnings[0], "no schema version declared")
+		})
+
+		it("reports unknown schema versions", func() {
+			result := validate(`
+[_]
+schema-version = "0.3"
+`)
+			h.AssertEq(t, result.Errors, []string{"unknown schema version '0.3', must be one of 0.1 or 0.2"})
+		})
+
+		it("reports invalid TOML", func() {
+			result := validate(`[_`)
+			h.AssertEq(t, len(result.Errors), 1)
+			h.AssertContains(t, result.Errors[0], "invalid TOML")
+		})
+	})
+}